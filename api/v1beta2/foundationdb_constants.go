@@ -30,6 +30,14 @@ const (
 	// InitContainerName represents the container name of the init container.
 	InitContainerName = "foundationdb-kubernetes-init"
 
+	// ConnectivityCheckContainerName represents the container name of the init container that checks the reachability
+	// of the coordinators.
+	ConnectivityCheckContainerName = "foundationdb-connectivity-check"
+
+	// NodeLabelsContainerName represents the container name of the init container that waits until the operator has
+	// copied the required node labels into the Pod annotations.
+	NodeLabelsContainerName = "foundationdb-wait-for-node-labels"
+
 	// NoneFaultDomainKey represents the none fault domain, where every Pod is a fault domain.
 	NoneFaultDomainKey = "foundationdb.org/none"
 )
@@ -64,6 +64,22 @@ var (
 	}
 )
 
+// getName returns the name of the custom parameter without the value.
+func (customParameter FoundationDBCustomParameter) getName() string {
+	return strings.TrimSpace(strings.Split(string(customParameter), "=")[0])
+}
+
+// HasParameter returns true if the custom parameters contain a parameter with the provided name.
+func (customParameters FoundationDBCustomParameters) HasParameter(name string) bool {
+	for _, parameter := range customParameters {
+		if parameter.getName() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ValidateCustomParameters ensures that no duplicate values are set and that no
 // protected/forbidden parameters are set. Theoretically we could also check if FDB
 // supports the given parameter.
@@ -72,8 +88,7 @@ func (customParameters FoundationDBCustomParameters) ValidateCustomParameters()
 	violations := make([]string, 0)
 
 	for _, parameter := range customParameters {
-		parameterName := strings.Split(string(parameter), "=")[0]
-		parameterName = strings.TrimSpace(parameterName)
+		parameterName := parameter.getName()
 
 		if _, ok := parameters[parameterName]; !ok {
 			parameters[parameterName] = None{}
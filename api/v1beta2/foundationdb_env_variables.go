@@ -23,4 +23,10 @@ package v1beta2
 const (
 	// EnvNamePublicIP defines the FDB_PUBLIC_IP environment variable name.
 	EnvNamePublicIP = "FDB_PUBLIC_IP"
+
+	// EnvNameRackID defines the FDB_RACK_ID environment variable name.
+	EnvNameRackID = "FDB_RACK_ID"
+
+	// EnvNameMachineID defines the FDB_MACHINE_ID environment variable name.
+	EnvNameMachineID = "FDB_MACHINE_ID"
 )
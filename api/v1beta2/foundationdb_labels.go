@@ -33,6 +33,56 @@ const (
 	// timestamp when we saw an outdated config map.
 	OutdatedConfigMapKey = "foundationdb.org/outdated-config-map-seen"
 
+	// LastTLSSecretsKey provides the annotation name we use to store the hash of the
+	// secrets that are mounted in the pod.
+	LastTLSSecretsKey = "foundationdb.org/last-applied-tls-secrets"
+
+	// OutdatedTLSSecretsKey provides the annotation name we use to store the
+	// timestamp when we saw that the mounted secrets were rotated.
+	OutdatedTLSSecretsKey = "foundationdb.org/outdated-tls-secrets-seen"
+
+	// LastBlobCredentialsKey provides the annotation name we use to store the
+	// hash of the blob credentials that are mounted in the backup agent pods.
+	LastBlobCredentialsKey = "foundationdb.org/last-applied-blob-credentials"
+
+	// LastConfigMapUpdateKey provides the annotation name we use to store the
+	// timestamp of the last config map update.
+	LastConfigMapUpdateKey = "foundationdb.org/last-config-map-update"
+
+	// PVCRetentionStartKey provides the annotation name we use to store the
+	// timestamp when the retention of a PVC of a removed process group started.
+	PVCRetentionStartKey = "foundationdb.org/pvc-retention-start"
+
+	// SkipPVCRetentionKey provides the annotation name that can be set on a PVC
+	// to delete it directly when the process group is removed, without waiting for
+	// the PVC retention duration.
+	SkipPVCRetentionKey = "foundationdb.org/skip-pvc-retention"
+
+	// LastReconcileTraceKey provides the annotation name we use to store the
+	// decision trace of the last reconciliation loop.
+	LastReconcileTraceKey = "foundationdb.org/last-reconcile-trace"
+
+	// SkipReconcilersAnnotation provides the annotation name that can be set on a cluster to skip individual
+	// sub-reconcilers. The value is a comma separated list of sub-reconciler names, e.g. "updatePods,excludeProcesses".
+	SkipReconcilersAnnotation = "foundationdb.org/skip-reconcilers"
+
+	// SkipSetAtAnnotation provides the annotation name that the kubectl plugin sets on a cluster when it sets the skip
+	// flag. The value is the RFC3339 timestamp when the skip flag was set.
+	SkipSetAtAnnotation = "foundationdb.org/skip-set-at"
+
+	// RotateCoordinatorsAnnotation provides the annotation name that can be set on a cluster to force the operator to
+	// select a new set of coordinators, even if the current coordinators are valid. The value should be a unique value,
+	// e.g. a timestamp, the coordinators are only rotated once per value.
+	RotateCoordinatorsAnnotation = "foundationdb.org/rotate-coordinators"
+
+	// DeletionCleanupFinalizer provides the finalizer name we use to perform the deletion cleanup steps before a
+	// cluster is deleted.
+	DeletionCleanupFinalizer = "foundationdb.org/cleanup"
+
+	// RestoreAbortFinalizer provides the finalizer name we use to abort a restore that is still in progress before the
+	// restore resource is deleted.
+	RestoreAbortFinalizer = "foundationdb.org/restore-abort"
+
 	// BackupDeploymentLabel provides the label we use to connect backup
 	// deployments to a cluster.
 	BackupDeploymentLabel = "foundationdb.org/backup-for"
@@ -44,6 +94,10 @@ const (
 	// gets its public IP from.
 	PublicIPSourceAnnotation = "foundationdb.org/public-ip-source"
 
+	// ProcessPortBaseAnnotation is an annotation key that specifies the process
+	// port base that was used when creating a pod.
+	ProcessPortBaseAnnotation = "foundationdb.org/process-port-base"
+
 	// PublicIPAnnotation is an annotation key that specifies the current public
 	// IP for a pod.
 	PublicIPAnnotation = "foundationdb.org/public-ip"
@@ -52,6 +106,14 @@ const (
 	// The information is fetched from Pod.Spec.NodeName of the Pod resource.
 	NodeAnnotation = "foundationdb.org/current-node"
 
+	// RackAnnotation is an annotation key that specifies the rack of the node where a Pod is currently running on.
+	// The information is fetched from the node label defined in the fault domain rack key.
+	RackAnnotation = "foundationdb.org/rack"
+
+	// MachineIDAnnotation is an annotation key that specifies the machine ID of the node where a Pod is currently
+	// running on. The information is fetched from the node label defined in the machine ID source.
+	MachineIDAnnotation = "foundationdb.org/machine-id"
+
 	// FDBProcessGroupIDLabel represents the label that is used to represent a instance ID
 	FDBProcessGroupIDLabel = "foundationdb.org/fdb-process-group-id"
 
@@ -61,6 +123,10 @@ const (
 	// FDBClusterLabel represents the label that is used to represent the cluster of an instance
 	FDBClusterLabel = "foundationdb.org/fdb-cluster-name"
 
+	// LogVolumeLabel represents the label that is used to mark a persistent volume claim that holds the trace logs
+	// of a process group.
+	LogVolumeLabel = "foundationdb.org/log-volume"
+
 	// NodeSelectorNoScheduleLabel is a label used when adding node selectors to block scheduling.
 	NodeSelectorNoScheduleLabel = "foundationdb.org/no-schedule-allowed"
 
@@ -95,6 +161,10 @@ const (
 	// the data hall.
 	FDBLocalityDataHallKey = "data_hall"
 
+	// FDBLocalityRackKey represents the key in the locality map that holds
+	// the rack.
+	FDBLocalityRackKey = "rack"
+
 	// FDBLocalityDCIDlKey represents the key in the locality map that holds
 	// the data center ID.
 	FDBLocalityDCIDlKey = "dcid"
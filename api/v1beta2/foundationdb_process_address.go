@@ -288,11 +288,17 @@ func (address ProcessAddress) StringWithoutFlags() string {
 // GetProcessPort returns the expected port for a given process number
 // and the tls setting.
 func GetProcessPort(processNumber int, tls bool) int {
+	return GetProcessPortWithBase(DefaultProcessPortBase, processNumber, tls)
+}
+
+// GetProcessPortWithBase returns the expected port for a given process number
+// and the tls setting, where portBase is the non-TLS port of the first process.
+func GetProcessPortWithBase(portBase int, processNumber int, tls bool) int {
 	if tls {
-		return 4498 + 2*processNumber
+		return portBase - 3 + 2*processNumber
 	}
 
-	return 4499 + 2*processNumber
+	return portBase - 2 + 2*processNumber
 }
 
 // GetFullAddressList gets the full list of public addresses we should use for a
@@ -304,6 +310,11 @@ func GetProcessPort(processNumber int, tls bool) int {
 // separated by commas. If you pass false for primaryOnly, this will return only
 // the primary address.
 func GetFullAddressList(address string, primaryOnly bool, processNumber int, requireTLS bool, requireNonTLS bool) []ProcessAddress {
+	return getFullAddressList(address, primaryOnly, processNumber, requireTLS, requireNonTLS, DefaultProcessPortBase)
+}
+
+// getFullAddressList is the same as GetFullAddressList but allows to specify the port base for the addresses.
+func getFullAddressList(address string, primaryOnly bool, processNumber int, requireTLS bool, requireNonTLS bool, portBase int) []ProcessAddress {
 	addrs := make([]ProcessAddress, 0, 2)
 
 	// If the address is already enclosed in brackets, remove them since they
@@ -313,7 +324,7 @@ func GetFullAddressList(address string, primaryOnly bool, processNumber int, req
 	// When a TLS address is provided the TLS address will always be the primary address
 	// see: https://github.com/apple/foundationdb/blob/master/fdbrpc/FlowTransport.h#L49-L56
 	if requireTLS {
-		pAddr := NewProcessAddress(nil, address, GetProcessPort(processNumber, true), map[string]bool{"tls": true})
+		pAddr := NewProcessAddress(nil, address, GetProcessPortWithBase(portBase, processNumber, true), map[string]bool{"tls": true})
 		addrs = append(addrs, pAddr)
 
 		if requireTLS && primaryOnly {
@@ -322,7 +333,7 @@ func GetFullAddressList(address string, primaryOnly bool, processNumber int, req
 	}
 
 	if requireNonTLS {
-		pAddr := NewProcessAddress(nil, address, GetProcessPort(processNumber, false), nil)
+		pAddr := NewProcessAddress(nil, address, GetProcessPortWithBase(portBase, processNumber, false), nil)
 		if !requireTLS && primaryOnly {
 			return []ProcessAddress{pAddr}
 		}
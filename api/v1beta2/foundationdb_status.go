@@ -107,6 +107,9 @@ type FoundationDBStatusClusterInfo struct {
 	// MaintenanceZone contains current zone under maintenance, if any.
 	MaintenanceZone FaultDomain `json:"maintenance_zone,omitempty"`
 
+	// MaintenanceSecondsRemaining contains the remaining seconds of the current maintenance zone, if any.
+	MaintenanceSecondsRemaining float64 `json:"maintenance_seconds_remaining,omitempty"`
+
 	// Clients provides information about clients that are connected to the
 	// database.
 	Clients FoundationDBStatusClusterClientInfo `json:"clients,omitempty"`
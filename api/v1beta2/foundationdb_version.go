@@ -216,6 +216,16 @@ func (version Version) SupportsDNSInClusterFile() bool {
 	return version.IsAtLeast(Versions.SupportsDNSInClusterFile)
 }
 
+// SupportsDualStackAddresses returns true if the version of FDB supports listening on an IPv4 and an IPv6 address.
+func (version Version) SupportsDualStackAddresses() bool {
+	return version.IsAtLeast(Versions.SupportsDualStackAddresses)
+}
+
+// SupportsTracing returns true if the version of FDB supports the tracer option for distributed tracing.
+func (version Version) SupportsTracing() bool {
+	return version.IsAtLeast(Versions.SupportsTracing)
+}
+
 // SupportsVersionChange returns true if the current version can be downgraded or upgraded to provided other version.
 func (version Version) SupportsVersionChange(other Version) bool {
 	return version.IsProtocolCompatible(other) || other.IsAtLeast(version)
@@ -259,6 +269,8 @@ var Versions = struct {
 	SupportsDNSInClusterFile,
 	SupportsLocalityBasedExclusions71,
 	SupportsLocalityBasedExclusions,
+	SupportsDualStackAddresses,
+	SupportsTracing,
 	Default Version
 }{
 	Default:                           Version{Major: 6, Minor: 2, Patch: 21},
@@ -276,4 +288,6 @@ var Versions = struct {
 	SupportsDNSInClusterFile:          Version{Major: 7, Minor: 0, Patch: 0},
 	SupportsLocalityBasedExclusions71: Version{Major: 7, Minor: 1, Patch: 42},
 	SupportsLocalityBasedExclusions:   Version{Major: 7, Minor: 3, Patch: 26},
+	SupportsDualStackAddresses:        Version{Major: 7, Minor: 3, Patch: 0},
+	SupportsTracing:                   Version{Major: 7, Minor: 0, Patch: 0},
 }
@@ -18,10 +18,14 @@ package v1beta2
 
 import (
 	"fmt"
+	"math"
 	"net/url"
+	"path"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
@@ -60,19 +64,40 @@ type FoundationDBBackupSpec struct {
 	// The cluster this backup is for.
 	ClusterName string `json:"clusterName"`
 
-	// +kubebuilder:validation:Enum=Running;Stopped;Paused
+	// Tag defines the tag of the backup in the cluster. Multiple backups with
+	// different tags can run for the same cluster. The default is "default".
+	// +kubebuilder:validation:MaxLength=100
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// +kubebuilder:validation:Enum=Running;Stopped;Paused;Aborted
 	// The desired state of the backup.
-	// The default is Running.
+	// The default is Running. A Stopped backup will be discontinued after
+	// the current snapshot is complete, an Aborted backup will be stopped
+	// immediately.
 	BackupState BackupState `json:"backupState,omitempty"`
 
 	// AgentCount defines the number of backup agents to run.
-	// The default is run 2 agents.
+	// The default is run 2 agents. If set, this takes precedence over the
+	// AgentScaling.
 	AgentCount *int `json:"agentCount,omitempty"`
 
+	// AgentScaling defines how the number of backup agents should be
+	// derived from the size of the cluster. This is only used if AgentCount
+	// is not set.
+	// +optional
+	AgentScaling *BackupAgentScaling `json:"agentScaling,omitempty"`
+
 	// The time window between new snapshots.
 	// This is measured in seconds. The default is 864,000, or 10 days.
 	SnapshotPeriodSeconds *int `json:"snapshotPeriodSeconds,omitempty"`
 
+	// RetentionPolicy defines how long the backup data should be kept. If
+	// set, the operator will periodically expire the backup data that is
+	// older than the retention period.
+	// +optional
+	RetentionPolicy *BackupRetentionPolicy `json:"retentionPolicy,omitempty"`
+
 	// BackupDeploymentMetadata allows customizing labels and annotations on the
 	// deployment for the backup agents.
 	BackupDeploymentMetadata *metav1.ObjectMeta `json:"backupDeploymentMetadata,omitempty"`
@@ -81,6 +106,12 @@ type FoundationDBBackupSpec struct {
 	// agents.
 	PodTemplateSpec *corev1.PodTemplateSpec `json:"podTemplateSpec,omitempty"`
 
+	// AgentScheduling allows customizing the scheduling of the backup agents
+	// without providing a full pod template. Those settings are merged into
+	// the PodTemplateSpec.
+	// +optional
+	AgentScheduling *BackupAgentScheduling `json:"agentScheduling,omitempty"`
+
 	// CustomParameters defines additional parameters to pass to the backup
 	// agents.
 	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
@@ -104,8 +135,102 @@ type FoundationDBBackupSpec struct {
 	SidecarContainer ContainerOverrides `json:"sidecarContainer,omitempty"`
 
 	// UseUnifiedImage determines if we should use the unified image rather than
-	// separate images for the main container and the sidecar container.
+	// separate images for the main container and the sidecar container. If
+	// unset, the setting of the cluster will be used.
 	UseUnifiedImage *bool `json:"useUnifiedImage,omitempty"`
+
+	// DescribeIntervalSeconds defines the minimum time between two updates of
+	// the restorability details in the status, which are fetched from the
+	// blob store. This is measured in seconds. The default is 3,600, or 1
+	// hour. A value of 0 disables the restorability details.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DescribeIntervalSeconds *int `json:"describeIntervalSeconds,omitempty"`
+
+	// KeyRanges defines the key ranges that should be backed up. If empty,
+	// the entire keyspace will be backed up. Changing the key ranges of a
+	// running backup will restart the backup with the new key ranges.
+	// +optional
+	KeyRanges []FoundationDBKeyRange `json:"keyRanges,omitempty"`
+}
+
+// BackupAgentScalingMode defines how the number of backup agents is
+// determined.
+type BackupAgentScalingMode string
+
+const (
+	// BackupAgentScalingModeManual uses the AgentCount of the backup.
+	BackupAgentScalingModeManual BackupAgentScalingMode = "Manual"
+	// BackupAgentScalingModeAuto derives the number of backup agents from the
+	// number of storage processes of the cluster.
+	BackupAgentScalingModeAuto BackupAgentScalingMode = "Auto"
+)
+
+// BackupAgentScaling defines how the number of backup agents is derived from
+// the size of the cluster.
+type BackupAgentScaling struct {
+	// Mode defines how the number of backup agents is determined. The
+	// default is Manual.
+	// +kubebuilder:validation:Enum=Manual;Auto
+	// +optional
+	Mode BackupAgentScalingMode `json:"mode,omitempty"`
+
+	// AgentsPerStorageProcess defines how many backup agents should run per
+	// desired storage process of the cluster, e.g. 0.25 for one backup agent
+	// per four storage processes. The default is 0.25.
+	// +optional
+	AgentsPerStorageProcess *resource.Quantity `json:"agentsPerStorageProcess,omitempty"`
+
+	// Min defines the minimum number of backup agents. The default is 2.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Min *int `json:"min,omitempty"`
+
+	// Max defines the maximum number of backup agents. The default is 20.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Max *int `json:"max,omitempty"`
+}
+
+// BackupAgentScheduling defines the scheduling settings for the backup agent
+// pods.
+type BackupAgentScheduling struct {
+	// Affinity defines the affinity for the backup agent pods. If set, this
+	// replaces the affinity from the PodTemplateSpec.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations defines additional tolerations for the backup agent pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints defines additional topology spread
+	// constraints for the backup agent pods. If a constraint has no label
+	// selector, the selector of the backup agent pods will be used.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// BackupRetentionPolicy defines how long the data of a backup should be kept.
+type BackupRetentionPolicy struct {
+	// ExpireBeforeDays defines the age in days after which the backup data
+	// will be expired.
+	// +kubebuilder:validation:Minimum=1
+	ExpireBeforeDays int `json:"expireBeforeDays"`
+
+	// MinRestorableDays defines for how many days in the past the backup must
+	// stay restorable after the expiration. This value must not be greater
+	// than ExpireBeforeDays. The default is ExpireBeforeDays.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinRestorableDays *int `json:"minRestorableDays,omitempty"`
+
+	// ExpirationIntervalSeconds defines the minimum time between two
+	// expirations. This is measured in seconds. The default is 86,400, or 1
+	// day.
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	ExpirationIntervalSeconds *int `json:"expirationIntervalSeconds,omitempty"`
 }
 
 // FoundationDBBackupStatus describes the current status of the backup for a cluster.
@@ -114,14 +239,38 @@ type FoundationDBBackupStatus struct {
 	// and not terminated.
 	AgentCount int `json:"agentCount,omitempty"`
 
+	// DesiredAgentCount provides the number of backup agents that was derived
+	// from the size of the cluster, if the agent scaling is set to Auto.
+	// +optional
+	DesiredAgentCount int `json:"desiredAgentCount,omitempty"`
+
 	// DeploymentConfigured indicates whether the deployment is correctly
 	// configured.
 	DeploymentConfigured bool `json:"deploymentConfigured,omitempty"`
 
+	// State provides the last observed state of the backup.
+	// +optional
+	State BackupState `json:"state,omitempty"`
+
 	// BackupDetails provides information about the state of the backup in the
 	// cluster.
 	BackupDetails *FoundationDBBackupStatusBackupDetails `json:"backupDetails,omitempty"`
 
+	// LastExpiration provides information about the last expiration of the
+	// backup data.
+	// +optional
+	LastExpiration *BackupExpirationStatus `json:"lastExpiration,omitempty"`
+
+	// RestorabilityDetails provides information about the data of the backup
+	// in the blob store.
+	// +optional
+	RestorabilityDetails *BackupRestorabilityDetails `json:"restorabilityDetails,omitempty"`
+
+	// KeyRanges provides the key ranges that were submitted for the running
+	// backup. If empty, the entire keyspace is backed up.
+	// +optional
+	KeyRanges []FoundationDBKeyRange `json:"keyRanges,omitempty"`
+
 	// Generations provides information about the latest generation to be
 	// reconciled, or to reach other stages in reconciliation.
 	Generations BackupGenerationStatus `json:"generations,omitempty"`
@@ -134,6 +283,71 @@ type FoundationDBBackupStatusBackupDetails struct {
 	Running               bool   `json:"running,omitempty"`
 	Paused                bool   `json:"paused,omitempty"`
 	SnapshotPeriodSeconds int    `json:"snapshotTime,omitempty"`
+
+	// Lag provides the time between the latest restorable point of the
+	// backup and the time the status was updated.
+	// +optional
+	Lag *metav1.Duration `json:"lag,omitempty"`
+}
+
+// BackupExpirationStatus provides information about an expiration of the
+// backup data.
+type BackupExpirationStatus struct {
+	// Timestamp provides the time when the expiration was run.
+	Timestamp metav1.Time `json:"timestamp,omitempty"`
+
+	// ExpireBefore provides the time before which the backup data was
+	// expired.
+	ExpireBefore metav1.Time `json:"expireBefore,omitempty"`
+
+	// Output provides the output of the expiration command.
+	// +optional
+	Output string `json:"output,omitempty"`
+
+	// Error provides the error message if the expiration failed.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// BackupRestorabilityDetails provides information about the data of a backup
+// in the blob store, as provided by the backup describe command.
+type BackupRestorabilityDetails struct {
+	// LastUpdated provides the time when the details were fetched from the
+	// blob store.
+	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
+
+	// LastAttempt provides the time when the operator tried to fetch the
+	// details from the blob store the last time. This is also updated if the
+	// backup could not be described.
+	// +optional
+	LastAttempt metav1.Time `json:"lastAttempt,omitempty"`
+
+	// Error provides the error of the last attempt to fetch the details, if
+	// the attempt failed.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Restorable determines whether the backup data can be restored.
+	Restorable bool `json:"restorable,omitempty"`
+
+	// LatestRestorableTimestamp provides the latest point in time the backup
+	// data can be restored to.
+	// +optional
+	LatestRestorableTimestamp *metav1.Time `json:"latestRestorableTimestamp,omitempty"`
+
+	// LatestRestorableVersion provides the latest version the backup data can
+	// be restored to.
+	// +optional
+	LatestRestorableVersion int64 `json:"latestRestorableVersion,omitempty"`
+
+	// LatestSnapshotStartTimestamp provides the time when the latest snapshot
+	// was started.
+	// +optional
+	LatestSnapshotStartTimestamp *metav1.Time `json:"latestSnapshotStartTimestamp,omitempty"`
+
+	// SnapshotBytes provides the total size of all snapshots in bytes.
+	// +optional
+	SnapshotBytes int64 `json:"snapshotBytes,omitempty"`
 }
 
 // BackupGenerationStatus stores information on which generations have reached
@@ -174,8 +388,28 @@ const (
 	BackupStatePaused BackupState = "Paused"
 	// BackupStateStopped defines the stopped state
 	BackupStateStopped BackupState = "Stopped"
+	// BackupStateAborted defines the aborted state
+	BackupStateAborted BackupState = "Aborted"
+	// BackupStateRestarting defines the state of a backup that was
+	// discontinued to be started again with new key ranges. This state is
+	// only reported in the status.
+	BackupStateRestarting BackupState = "Restarting"
 )
 
+// DefaultBackupTag defines the tag of a backup if no tag is defined in the
+// backup spec.
+const DefaultBackupTag = "default"
+
+// BlobCredentialsMountPath defines the directory in which the blob credentials
+// secrets are mounted. Every secret is mounted in a subdirectory with the name
+// of the secret.
+const BlobCredentialsMountPath = "/var/blob-credentials"
+
+// BlobCABundleMountPath defines the directory in which the CA bundles for the
+// blobstore are mounted. Every bundle is mounted in a subdirectory with the
+// name of the Secret or ConfigMap.
+const BlobCABundleMountPath = "/var/blob-ca-bundle"
+
 // URLParameter defines a single URL parameter to pass to the blobstore.
 // +kubebuilder:validation:MaxLength=1024
 type URLParameter string
@@ -204,6 +438,42 @@ type BlobStoreConfiguration struct {
 	// See: https://apple.github.io/foundationdb/backups.html#backup-urls
 	// +kubebuilder:validation:MaxItems=100
 	URLParameters []URLParameter `json:"urlParameters,omitempty"`
+
+	// CredentialsSecret references the key of a Secret that contains the blob
+	// credentials file. The Secret will be mounted in the backup agent pods
+	// and the path will be passed to the backup agents and to the fdbbackup
+	// commands run by the operator. This setting is currently only used for
+	// backups.
+	// +optional
+	CredentialsSecret *corev1.SecretKeySelector `json:"credentialsSecret,omitempty"`
+
+	// CABundle references the CA bundle that is used to verify the
+	// certificate of the blobstore. The bundle will be mounted in the backup
+	// agent pods and the path will be passed to the backup agents, the
+	// operator reads the bundle for the fdbbackup and fdbrestore commands it
+	// runs.
+	// +optional
+	CABundle *BlobStoreCABundle `json:"caBundle,omitempty"`
+
+	// TLSVerifyPeers defines whether the certificate of the blobstore should
+	// be verified. If set to false, the backup agents and the fdbbackup and
+	// fdbrestore commands run by the operator will not verify the peers.
+	// Default is true.
+	// +optional
+	TLSVerifyPeers *bool `json:"tlsVerifyPeers,omitempty"`
+}
+
+// BlobStoreCABundle references the CA bundle for the blobstore. Exactly one
+// of the Secret or the ConfigMap must be defined.
+type BlobStoreCABundle struct {
+	// SecretKeyRef references the key of a Secret that contains the CA bundle.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef references the key of a ConfigMap that contains the CA
+	// bundle.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
 }
 
 // ShouldRun determines whether a backup should be running.
@@ -211,6 +481,11 @@ func (backup *FoundationDBBackup) ShouldRun() bool {
 	return backup.Spec.BackupState == "" || backup.Spec.BackupState == BackupStateRunning || backup.Spec.BackupState == BackupStatePaused
 }
 
+// ShouldBeAborted determines whether the backup should be aborted.
+func (backup *FoundationDBBackup) ShouldBeAborted() bool {
+	return backup.Spec.BackupState == BackupStateAborted
+}
+
 // ShouldBePaused determines whether the backups should be paused.
 func (backup *FoundationDBBackup) ShouldBePaused() bool {
 	return backup.Spec.BackupState == BackupStatePaused
@@ -236,11 +511,192 @@ func (backup *FoundationDBBackup) BackupName() string {
 	return backup.Spec.BlobStoreConfiguration.BackupName
 }
 
+// BackupTag gets the tag of the backup in the cluster.
+// This will fill in a default value if the tag in the spec is empty.
+func (backup *FoundationDBBackup) BackupTag() string {
+	if backup.Spec.Tag == "" {
+		return DefaultBackupTag
+	}
+
+	return backup.Spec.Tag
+}
+
 // BackupURL gets the destination url of the backup.
 func (backup *FoundationDBBackup) BackupURL() string {
 	return backup.Spec.BlobStoreConfiguration.getURL(backup.BackupName(), backup.Bucket())
 }
 
+// BlobCredentialsPath gets the path of the blob credentials file that is
+// mounted from the credentials secret. This will be empty if no credentials
+// secret is defined.
+func (backup *FoundationDBBackup) BlobCredentialsPath() string {
+	if backup.Spec.BlobStoreConfiguration == nil || backup.Spec.BlobStoreConfiguration.CredentialsSecret == nil {
+		return ""
+	}
+
+	secret := backup.Spec.BlobStoreConfiguration.CredentialsSecret
+	return path.Join(BlobCredentialsMountPath, secret.Name, secret.Key)
+}
+
+// ExpirationIntervalSeconds gets the minimum time between two expirations
+// of the backup data.
+func (backup *FoundationDBBackup) ExpirationIntervalSeconds() int {
+	if backup.Spec.RetentionPolicy == nil {
+		return 86400
+	}
+
+	return pointer.IntDeref(backup.Spec.RetentionPolicy.ExpirationIntervalSeconds, 86400)
+}
+
+// GetExpirationTimestamps returns the timestamp before which the backup data
+// should be expired and the timestamp after which the backup must stay
+// restorable, based on the retention policy. If no retention policy is
+// defined, both timestamps will be zero.
+func (backup *FoundationDBBackup) GetExpirationTimestamps(now time.Time) (time.Time, time.Time) {
+	policy := backup.Spec.RetentionPolicy
+	if policy == nil {
+		return time.Time{}, time.Time{}
+	}
+
+	minRestorableDays := pointer.IntDeref(policy.MinRestorableDays, policy.ExpireBeforeDays)
+
+	return now.AddDate(0, 0, -policy.ExpireBeforeDays), now.AddDate(0, 0, -minRestorableDays)
+}
+
+// NeedsExpiration returns true if a retention policy is defined and the last
+// expiration of the backup data is longer ago than the expiration interval.
+func (backup *FoundationDBBackup) NeedsExpiration(now time.Time) bool {
+	if backup.Spec.RetentionPolicy == nil {
+		return false
+	}
+
+	if backup.Status.LastExpiration == nil {
+		return true
+	}
+
+	return !now.Before(backup.NextExpiration())
+}
+
+// NextExpiration returns the earliest time when the next expiration of the
+// backup data should be run.
+func (backup *FoundationDBBackup) NextExpiration() time.Time {
+	if backup.Status.LastExpiration == nil {
+		return time.Time{}
+	}
+
+	return backup.Status.LastExpiration.Timestamp.Add(time.Duration(backup.ExpirationIntervalSeconds()) * time.Second)
+}
+
+// DescribeIntervalSeconds gets the minimum time between two updates of the
+// restorability details.
+func (backup *FoundationDBBackup) DescribeIntervalSeconds() int {
+	return pointer.IntDeref(backup.Spec.DescribeIntervalSeconds, 3600)
+}
+
+// NeedsDescription returns true if the restorability details are enabled and
+// the last update of the restorability details is longer ago than the
+// describe interval.
+func (backup *FoundationDBBackup) NeedsDescription(now time.Time) bool {
+	if backup.DescribeIntervalSeconds() <= 0 {
+		return false
+	}
+
+	if backup.Status.RestorabilityDetails == nil {
+		return true
+	}
+
+	return !now.Before(backup.NextDescription())
+}
+
+// NextDescription returns the earliest time when the restorability details
+// should be updated again. Failed attempts are retried after the describe
+// interval as well.
+func (backup *FoundationDBBackup) NextDescription() time.Time {
+	if backup.Status.RestorabilityDetails == nil {
+		return time.Time{}
+	}
+
+	lastAttempt := backup.Status.RestorabilityDetails.LastAttempt.Time
+	if backup.Status.RestorabilityDetails.LastUpdated.After(lastAttempt) {
+		lastAttempt = backup.Status.RestorabilityDetails.LastUpdated.Time
+	}
+
+	return lastAttempt.Add(time.Duration(backup.DescribeIntervalSeconds()) * time.Second)
+}
+
+// Validate checks if the backup spec is valid.
+func (backup *FoundationDBBackup) Validate() error {
+	err := validateKeyRanges(backup.Spec.KeyRanges)
+	if err != nil {
+		return err
+	}
+
+	policy := backup.Spec.RetentionPolicy
+	if policy != nil && pointer.IntDeref(policy.MinRestorableDays, 0) > policy.ExpireBeforeDays {
+		return fmt.Errorf("minRestorableDays %d must not be greater than expireBeforeDays %d", *policy.MinRestorableDays, policy.ExpireBeforeDays)
+	}
+
+	scaling := backup.Spec.AgentScaling
+	if scaling != nil {
+		if scaling.AgentsPerStorageProcess != nil && scaling.AgentsPerStorageProcess.Sign() <= 0 {
+			return fmt.Errorf("agentsPerStorageProcess %s must be greater than 0", scaling.AgentsPerStorageProcess.String())
+		}
+
+		if pointer.IntDeref(scaling.Min, 2) > pointer.IntDeref(scaling.Max, 20) {
+			return fmt.Errorf("the minimum agent count %d must not be greater than the maximum agent count %d", pointer.IntDeref(scaling.Min, 2), pointer.IntDeref(scaling.Max, 20))
+		}
+	}
+
+	if backup.Spec.BlobStoreConfiguration == nil {
+		return nil
+	}
+
+	if backup.Spec.BlobStoreConfiguration.requiresCredentials() && backup.Spec.BlobStoreConfiguration.CredentialsSecret == nil && !backup.hasBlobCredentialsEnv() {
+		return fmt.Errorf("the account name %s requires blob credentials, but neither a credentials secret nor the FDB_BLOB_CREDENTIALS environment variable is defined", backup.Spec.BlobStoreConfiguration.AccountName)
+	}
+
+	return backup.Spec.BlobStoreConfiguration.validateCABundle()
+}
+
+// KeyRangesChanged returns true if the key ranges of the running backup differ
+// from the key ranges defined in the spec.
+func (backup *FoundationDBBackup) KeyRangesChanged() bool {
+	if len(backup.Spec.KeyRanges) != len(backup.Status.KeyRanges) {
+		return true
+	}
+
+	for idx, keyRange := range backup.Spec.KeyRanges {
+		if keyRange != backup.Status.KeyRanges[idx] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasBlobCredentialsEnv returns true if the blob credentials are provided
+// through the FDB_BLOB_CREDENTIALS environment variable in the main container
+// of the PodTemplateSpec.
+func (backup *FoundationDBBackup) hasBlobCredentialsEnv() bool {
+	if backup.Spec.PodTemplateSpec == nil {
+		return false
+	}
+
+	for _, container := range backup.Spec.PodTemplateSpec.Spec.Containers {
+		if container.Name != MainContainerName {
+			continue
+		}
+
+		for _, env := range container.Env {
+			if env.Name == "FDB_BLOB_CREDENTIALS" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // SnapshotPeriodSeconds gets the period between snapshots for a backup.
 func (backup *FoundationDBBackup) SnapshotPeriodSeconds() int {
 	return pointer.IntDeref(backup.Spec.SnapshotPeriodSeconds, 864000)
@@ -249,6 +705,9 @@ func (backup *FoundationDBBackup) SnapshotPeriodSeconds() int {
 // FoundationDBLiveBackupStatus describes the live status of the backup for a
 // cluster, as provided by the backup status command.
 type FoundationDBLiveBackupStatus struct {
+	// Tag provides the tag of the backup.
+	Tag string `json:"Tag,omitempty"`
+
 	// DestinationURL provides the URL that the backup is being written to.
 	DestinationURL string `json:"DestinationURL,omitempty"`
 
@@ -260,6 +719,23 @@ type FoundationDBLiveBackupStatus struct {
 
 	// BackupAgentsPaused describes whether the backup agents are paused.
 	BackupAgentsPaused bool `json:"BackupAgentsPaused,omitempty"`
+
+	// LatestRestorablePoint provides the latest point in time the backup
+	// can be restored to.
+	LatestRestorablePoint *FoundationDBLiveBackupStatusRestorablePoint `json:"LatestRestorablePoint,omitempty"`
+}
+
+// FoundationDBLiveBackupStatusRestorablePoint provides information about a
+// restorable point of a backup.
+type FoundationDBLiveBackupStatusRestorablePoint struct {
+	// Version provides the version of the restorable point.
+	Version int64 `json:"Version,omitempty"`
+
+	// EpochSeconds provides the unix timestamp of the restorable point.
+	EpochSeconds float64 `json:"EpochSeconds,omitempty"`
+
+	// Timestamp provides the human-readable timestamp of the restorable point.
+	Timestamp string `json:"Timestamp,omitempty"`
 }
 
 // FoundationDBLiveBackupStatusState provides the state of a backup in the
@@ -269,12 +745,98 @@ type FoundationDBLiveBackupStatusState struct {
 	Running bool `json:"Running,omitempty"`
 }
 
+// FoundationDBBackupDescription describes the data of a backup in the blob
+// store, as provided by the backup describe command.
+type FoundationDBBackupDescription struct {
+	// URL provides the URL of the backup.
+	URL string `json:"URL,omitempty"`
+
+	// Restorable determines whether the backup data can be restored.
+	Restorable bool `json:"Restorable,omitempty"`
+
+	// Snapshots provides the snapshots of the backup.
+	Snapshots []FoundationDBBackupDescriptionSnapshot `json:"Snapshots,omitempty"`
+
+	// TotalSnapshotBytes provides the total size of all snapshots in bytes.
+	TotalSnapshotBytes int64 `json:"TotalSnapshotBytes,omitempty"`
+
+	// MinRestorablePoint provides the earliest point in time the backup can
+	// be restored to.
+	MinRestorablePoint *FoundationDBLiveBackupStatusRestorablePoint `json:"MinRestorablePoint,omitempty"`
+
+	// MaxRestorablePoint provides the latest point in time the backup can be
+	// restored to.
+	MaxRestorablePoint *FoundationDBLiveBackupStatusRestorablePoint `json:"MaxRestorablePoint,omitempty"`
+}
+
+// FoundationDBBackupDescriptionSnapshot provides information about a snapshot
+// of a backup, as provided by the backup describe command.
+type FoundationDBBackupDescriptionSnapshot struct {
+	// Start provides the version at which the snapshot was started.
+	Start FoundationDBLiveBackupStatusRestorablePoint `json:"Start,omitempty"`
+
+	// End provides the version at which the snapshot was finished.
+	End FoundationDBLiveBackupStatusRestorablePoint `json:"End,omitempty"`
+
+	// Restorable determines whether the snapshot can be restored.
+	Restorable bool `json:"Restorable,omitempty"`
+
+	// TotalBytes provides the size of the snapshot in bytes.
+	TotalBytes int64 `json:"TotalBytes,omitempty"`
+}
+
 // GetDesiredAgentCount determines how many backup agents we should run
-// for a cluster.
+// for a cluster. If the agent count is derived from the size of the cluster,
+// this will return the agent count from the status.
 func (backup *FoundationDBBackup) GetDesiredAgentCount() int {
+	if backup.UsesAgentScaling() {
+		return backup.Status.DesiredAgentCount
+	}
+
 	return pointer.IntDeref(backup.Spec.AgentCount, 2)
 }
 
+// UsesAgentScaling returns true if the number of backup agents should be
+// derived from the size of the cluster.
+func (backup *FoundationDBBackup) UsesAgentScaling() bool {
+	return backup.Spec.AgentCount == nil && backup.Spec.AgentScaling != nil && backup.Spec.AgentScaling.Mode == BackupAgentScalingModeAuto
+}
+
+// GetDesiredAgentCountForCluster determines how many backup agents we should
+// run for the provided cluster. If the agent scaling is set to Auto and no
+// agent count is defined, the agent count is derived from the desired storage
+// processes of the cluster and bounded by the minimum and maximum of the
+// agent scaling.
+func (backup *FoundationDBBackup) GetDesiredAgentCountForCluster(cluster *FoundationDBCluster) (int, error) {
+	if !backup.UsesAgentScaling() {
+		return pointer.IntDeref(backup.Spec.AgentCount, 2), nil
+	}
+
+	counts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return 0, err
+	}
+
+	scaling := backup.Spec.AgentScaling
+	agentsPerStorageProcess := 0.25
+	if scaling.AgentsPerStorageProcess != nil {
+		agentsPerStorageProcess = scaling.AgentsPerStorageProcess.AsApproximateFloat64()
+	}
+
+	agentCount := int(math.Round(float64(counts.Storage) * agentsPerStorageProcess))
+	minAgents := pointer.IntDeref(scaling.Min, 2)
+	if agentCount < minAgents {
+		return minAgents, nil
+	}
+
+	maxAgents := pointer.IntDeref(scaling.Max, 20)
+	if agentCount > maxAgents {
+		return maxAgents, nil
+	}
+
+	return agentCount, nil
+}
+
 // CheckReconciliation compares the spec and the status to determine if
 // reconciliation is complete.
 func (backup *FoundationDBBackup) CheckReconciliation() (bool, error) {
@@ -309,6 +871,12 @@ func (backup *FoundationDBBackup) CheckReconciliation() (bool, error) {
 		reconciled = false
 	}
 
+	// A backup that is restarted with new key ranges is reconciled once it runs with the key ranges from the spec.
+	if isRunning && (backup.KeyRangesChanged() || backup.Status.State == BackupStateRestarting) {
+		backup.Status.Generations.NeedsBackupReconfiguration = backup.ObjectMeta.Generation
+		reconciled = false
+	}
+
 	if reconciled {
 		backup.Status.Generations = BackupGenerationStatus{
 			Reconciled: backup.ObjectMeta.Generation,
@@ -362,6 +930,101 @@ func (configuration *BlobStoreConfiguration) getURL(backup string, bucket string
 	return fmt.Sprintf("blobstore://%s%s/%s?bucket=%s%s", configuration.AccountName, defaultPort, backup, bucket, sb.String())
 }
 
+// requiresCredentials returns true if the account name has the format
+// <api_key>@<hostname> without an inline secret. In this case the secret for
+// the api key must be read from a blob credentials file.
+func (configuration *BlobStoreConfiguration) requiresCredentials() bool {
+	key, _, found := strings.Cut(configuration.AccountName, "@")
+	if !found {
+		return false
+	}
+
+	return key != "" && !strings.Contains(key, ":")
+}
+
+// validateCABundle checks that the CA bundle references exactly one Secret or
+// ConfigMap with a name and a key.
+func (configuration *BlobStoreConfiguration) validateCABundle() error {
+	bundle := configuration.CABundle
+	if bundle == nil {
+		return nil
+	}
+
+	if bundle.SecretKeyRef == nil && bundle.ConfigMapKeyRef == nil {
+		return fmt.Errorf("the caBundle must reference either a Secret or a ConfigMap")
+	}
+
+	if bundle.SecretKeyRef != nil && bundle.ConfigMapKeyRef != nil {
+		return fmt.Errorf("the caBundle must reference only one of a Secret or a ConfigMap")
+	}
+
+	name, key := bundle.getNameAndKey()
+	if name == "" || key == "" {
+		return fmt.Errorf("the caBundle reference must define a name and a key")
+	}
+
+	return nil
+}
+
+// getNameAndKey returns the name and the key of the referenced Secret or
+// ConfigMap.
+func (bundle *BlobStoreCABundle) getNameAndKey() (string, string) {
+	if bundle.SecretKeyRef != nil {
+		return bundle.SecretKeyRef.Name, bundle.SecretKeyRef.Key
+	}
+
+	if bundle.ConfigMapKeyRef != nil {
+		return bundle.ConfigMapKeyRef.Name, bundle.ConfigMapKeyRef.Key
+	}
+
+	return "", ""
+}
+
+// CAFilePath gets the path of the CA bundle that is mounted from the
+// referenced Secret or ConfigMap in the backup agent pods. This will be empty
+// if no CA bundle is defined.
+func (configuration *BlobStoreConfiguration) CAFilePath() string {
+	if configuration == nil || configuration.CABundle == nil {
+		return ""
+	}
+
+	name, key := configuration.CABundle.getNameAndKey()
+	if name == "" || key == "" {
+		return ""
+	}
+
+	return path.Join(BlobCABundleMountPath, name, key)
+}
+
+// VerifyPeers returns the peer verification that should be used for the
+// connections to the blobstore. This will be empty if the default
+// verification should be used.
+func (configuration *BlobStoreConfiguration) VerifyPeers() string {
+	if configuration == nil || pointer.BoolDeref(configuration.TLSVerifyPeers, true) {
+		return ""
+	}
+
+	return "Check.Valid=0"
+}
+
+// GetTLSArgsForCLI returns the TLS arguments for the fdbbackup and fdbrestore
+// commands to connect to the blobstore. The caFilePath defines where the CA
+// bundle can be read, as the backup agents and the operator use different
+// paths.
+func (configuration *BlobStoreConfiguration) GetTLSArgsForCLI(caFilePath string) []string {
+	var args []string
+	if caFilePath != "" {
+		args = append(args, "--tls_ca_file", caFilePath)
+	}
+
+	verifyPeers := configuration.VerifyPeers()
+	if verifyPeers != "" {
+		args = append(args, "--tls_verify_peers", verifyPeers)
+	}
+
+	return args
+}
+
 // BucketName gets the bucket this backup will use.
 // This will fill in a default value if the bucket in the spec is empty.
 func (configuration *BlobStoreConfiguration) BucketName() string {
@@ -21,9 +21,17 @@
 package v1beta2
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("[api] FoundationDBBackup", func() {
@@ -191,6 +199,26 @@ var _ = Describe("[api] FoundationDBBackup", func() {
 				NeedsBackupReconfiguration: 2,
 			}))
 			backup.Spec.SnapshotPeriodSeconds = nil
+
+			backup = createBackup()
+			backup.Spec.KeyRanges = []FoundationDBKeyRange{{Start: "a", End: "b"}}
+			result, err = backup.CheckReconciliation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+			Expect(backup.Status.Generations).To(Equal(BackupGenerationStatus{
+				Reconciled:                 1,
+				NeedsBackupReconfiguration: 2,
+			}))
+
+			backup = createBackup()
+			backup.Status.State = BackupStateRestarting
+			result, err = backup.CheckReconciliation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+			Expect(backup.Status.Generations).To(Equal(BackupGenerationStatus{
+				Reconciled:                 1,
+				NeedsBackupReconfiguration: 2,
+			}))
 		})
 
 	})
@@ -211,6 +239,12 @@ var _ = Describe("[api] FoundationDBBackup", func() {
 			backup.Spec.BackupState = BackupStatePaused
 			Expect(backup.ShouldRun()).To(BeTrue())
 			Expect(backup.ShouldBePaused()).To(BeTrue())
+			Expect(backup.ShouldBeAborted()).To(BeFalse())
+
+			backup.Spec.BackupState = BackupStateAborted
+			Expect(backup.ShouldRun()).To(BeFalse())
+			Expect(backup.ShouldBePaused()).To(BeFalse())
+			Expect(backup.ShouldBeAborted()).To(BeTrue())
 		})
 	})
 
@@ -224,6 +258,15 @@ var _ = Describe("[api] FoundationDBBackup", func() {
 		})
 	})
 
+	When("getting the backup tag", func() {
+		It("should return the backup tag", func() {
+			Expect(backup.BackupTag()).To(Equal("default"))
+
+			backup.Spec.Tag = "secondary"
+			Expect(backup.BackupTag()).To(Equal("secondary"))
+		})
+	})
+
 	When("getting the backup URL", func() {
 		DescribeTable("should generate the correct backup URL",
 			func(backup FoundationDBBackup, expected string) {
@@ -450,4 +493,496 @@ var _ = Describe("[api] FoundationDBBackup", func() {
 				"blobstore://account@[2001:0db8:85a3:0000:0000:8a2e:0370:7334]:80/mybackup?bucket=fdb-backups&sc=0"),
 		)
 	})
+
+	When("getting the blob credentials path", func() {
+		DescribeTable("should return the path of the mounted credentials",
+			func(configuration *BlobStoreConfiguration, expected string) {
+				backup := FoundationDBBackup{
+					Spec: FoundationDBBackupSpec{
+						BlobStoreConfiguration: configuration,
+					},
+				}
+				Expect(backup.BlobCredentialsPath()).To(Equal(expected))
+			},
+			Entry("no blobstore config", nil, ""),
+			Entry("no credentials secret", &BlobStoreConfiguration{AccountName: "account@account"}, ""),
+			Entry("a credentials secret",
+				&BlobStoreConfiguration{
+					AccountName: "account@account",
+					CredentialsSecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-secret"},
+						Key:                  "credentials.json",
+					},
+				},
+				"/var/blob-credentials/blob-secret/credentials.json"),
+		)
+	})
+
+	When("validating the backup", func() {
+		DescribeTable("should validate the blob credentials",
+			func(backup FoundationDBBackup, expectedErr string) {
+				err := backup.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("an account without an api key",
+				FoundationDBBackup{
+					Spec: FoundationDBBackupSpec{
+						BlobStoreConfiguration: &BlobStoreConfiguration{
+							AccountName: "account:443",
+						},
+					},
+				},
+				""),
+			Entry("an account with an inline secret",
+				FoundationDBBackup{
+					Spec: FoundationDBBackupSpec{
+						BlobStoreConfiguration: &BlobStoreConfiguration{
+							AccountName: "key:secret@account",
+						},
+					},
+				},
+				""),
+			Entry("an account that requires credentials without a credentials secret",
+				FoundationDBBackup{
+					Spec: FoundationDBBackupSpec{
+						BlobStoreConfiguration: &BlobStoreConfiguration{
+							AccountName: "key@account",
+						},
+					},
+				},
+				"the account name key@account requires blob credentials, but neither a credentials secret nor the FDB_BLOB_CREDENTIALS environment variable is defined"),
+			Entry("an account that requires credentials with a credentials secret",
+				FoundationDBBackup{
+					Spec: FoundationDBBackupSpec{
+						BlobStoreConfiguration: &BlobStoreConfiguration{
+							AccountName: "key@account",
+							CredentialsSecret: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "blob-secret"},
+								Key:                  "credentials",
+							},
+						},
+					},
+				},
+				""),
+			Entry("an account that requires credentials with the credentials in the environment",
+				FoundationDBBackup{
+					Spec: FoundationDBBackupSpec{
+						BlobStoreConfiguration: &BlobStoreConfiguration{
+							AccountName: "key@account",
+						},
+						PodTemplateSpec: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: MainContainerName,
+										Env: []corev1.EnvVar{
+											{Name: "FDB_BLOB_CREDENTIALS", Value: "/tmp/credentials"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				""),
+		)
+	})
+
+	When("validating the CA bundle", func() {
+		DescribeTable("should return the expected error",
+			func(bundle *BlobStoreCABundle, expectedErr string) {
+				backup := FoundationDBBackup{
+					Spec: FoundationDBBackupSpec{
+						BlobStoreConfiguration: &BlobStoreConfiguration{
+							AccountName: "account:443",
+							CABundle:    bundle,
+						},
+					},
+				}
+
+				err := backup.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("no CA bundle", nil, ""),
+			Entry("a Secret",
+				&BlobStoreCABundle{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+						Key:                  "ca.pem",
+					},
+				},
+				""),
+			Entry("a ConfigMap",
+				&BlobStoreCABundle{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+						Key:                  "ca.pem",
+					},
+				},
+				""),
+			Entry("no reference",
+				&BlobStoreCABundle{},
+				"the caBundle must reference either a Secret or a ConfigMap"),
+			Entry("a Secret and a ConfigMap",
+				&BlobStoreCABundle{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+						Key:                  "ca.pem",
+					},
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+						Key:                  "ca.pem",
+					},
+				},
+				"the caBundle must reference only one of a Secret or a ConfigMap"),
+			Entry("a Secret without a name",
+				&BlobStoreCABundle{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						Key: "ca.pem",
+					},
+				},
+				"the caBundle reference must define a name and a key"),
+			Entry("a ConfigMap without a key",
+				&BlobStoreCABundle{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+					},
+				},
+				"the caBundle reference must define a name and a key"),
+		)
+	})
+
+	When("getting the TLS arguments for the blobstore", func() {
+		DescribeTable("should return the expected arguments",
+			func(configuration *BlobStoreConfiguration, caFilePath string, expected []string) {
+				Expect(configuration.GetTLSArgsForCLI(caFilePath)).To(Equal(expected))
+			},
+			Entry("no blobstore config", nil, "", nil),
+			Entry("no TLS settings", &BlobStoreConfiguration{AccountName: "account:443"}, "", nil),
+			Entry("a CA bundle",
+				&BlobStoreConfiguration{
+					AccountName: "account:443",
+					CABundle: &BlobStoreCABundle{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+							Key:                  "ca.pem",
+						},
+					},
+				},
+				"/tmp/blob-ca/ca.pem",
+				[]string{"--tls_ca_file", "/tmp/blob-ca/ca.pem"}),
+			Entry("peer verification enabled",
+				&BlobStoreConfiguration{AccountName: "account:443", TLSVerifyPeers: pointer.Bool(true)},
+				"",
+				nil),
+			Entry("peer verification disabled",
+				&BlobStoreConfiguration{AccountName: "account:443", TLSVerifyPeers: pointer.Bool(false)},
+				"",
+				[]string{"--tls_verify_peers", "Check.Valid=0"}),
+		)
+	})
+
+	When("validating the agent scaling", func() {
+		DescribeTable("should return the expected error",
+			func(scaling *BackupAgentScaling, expectedErr string) {
+				backup.Spec.AgentScaling = scaling
+				err := backup.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("no agent scaling", nil, ""),
+			Entry("the default agent scaling", &BackupAgentScaling{Mode: BackupAgentScalingModeAuto}, ""),
+			Entry("no agents per storage process",
+				&BackupAgentScaling{Mode: BackupAgentScalingModeAuto, AgentsPerStorageProcess: resource.NewQuantity(0, resource.DecimalSI)},
+				"agentsPerStorageProcess 0 must be greater than 0"),
+			Entry("a minimum greater than the maximum",
+				&BackupAgentScaling{Mode: BackupAgentScalingModeAuto, Min: pointer.Int(10), Max: pointer.Int(5)},
+				"the minimum agent count 10 must not be greater than the maximum agent count 5"),
+			Entry("a minimum greater than the default maximum",
+				&BackupAgentScaling{Mode: BackupAgentScalingModeAuto, Min: pointer.Int(30)},
+				"the minimum agent count 30 must not be greater than the maximum agent count 20"),
+		)
+	})
+
+	When("getting the desired agent count for a cluster", func() {
+		DescribeTable("should return the expected agent count",
+			func(agentCount *int, scaling *BackupAgentScaling, storageProcesses int, expected int) {
+				backup.Spec.AgentCount = agentCount
+				backup.Spec.AgentScaling = scaling
+				cluster := &FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.Default.String(),
+						ProcessCounts: ProcessCounts{
+							Storage: storageProcesses,
+						},
+					},
+				}
+
+				Expect(backup.GetDesiredAgentCountForCluster(cluster)).To(Equal(expected))
+			},
+			Entry("no agent count and no agent scaling", nil, nil, 40, 2),
+			Entry("an agent count and no agent scaling", pointer.Int(5), nil, 40, 5),
+			Entry("an agent count with automatic agent scaling", pointer.Int(5), &BackupAgentScaling{Mode: BackupAgentScalingModeAuto}, 40, 5),
+			Entry("manual agent scaling", nil, &BackupAgentScaling{Mode: BackupAgentScalingModeManual}, 40, 2),
+			Entry("automatic agent scaling with the defaults", nil, &BackupAgentScaling{Mode: BackupAgentScalingModeAuto}, 40, 10),
+			Entry("automatic agent scaling below the minimum", nil, &BackupAgentScaling{Mode: BackupAgentScalingModeAuto}, 4, 2),
+			Entry("automatic agent scaling above the maximum", nil, &BackupAgentScaling{Mode: BackupAgentScalingModeAuto}, 200, 20),
+			Entry("automatic agent scaling with a custom ratio and bounds",
+				nil,
+				&BackupAgentScaling{
+					Mode:                    BackupAgentScalingModeAuto,
+					AgentsPerStorageProcess: resource.NewMilliQuantity(500, resource.DecimalSI),
+					Min:                     pointer.Int(3),
+					Max:                     pointer.Int(50),
+				},
+				41,
+				21),
+			Entry("automatic agent scaling with a custom minimum",
+				nil,
+				&BackupAgentScaling{
+					Mode: BackupAgentScalingModeAuto,
+					Min:  pointer.Int(8),
+				},
+				12,
+				8),
+		)
+
+		It("should use the agent count from the status with automatic agent scaling", func() {
+			backup.Spec.AgentScaling = &BackupAgentScaling{Mode: BackupAgentScalingModeAuto}
+			backup.Status.DesiredAgentCount = 7
+			Expect(backup.GetDesiredAgentCount()).To(Equal(7))
+
+			backup.Spec.AgentCount = pointer.Int(3)
+			Expect(backup.GetDesiredAgentCount()).To(Equal(3))
+		})
+	})
+
+	When("getting the expiration timestamps", func() {
+		now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+		DescribeTable("should return the timestamps based on the retention policy",
+			func(policy *BackupRetentionPolicy, expectedExpireBefore time.Time, expectedRestorableAfter time.Time) {
+				backup.Spec.RetentionPolicy = policy
+				expireBefore, restorableAfter := backup.GetExpirationTimestamps(now)
+				Expect(expireBefore).To(Equal(expectedExpireBefore))
+				Expect(restorableAfter).To(Equal(expectedRestorableAfter))
+			},
+			Entry("no retention policy", nil, time.Time{}, time.Time{}),
+			Entry("no min restorable days",
+				&BackupRetentionPolicy{ExpireBeforeDays: 30},
+				time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)),
+			Entry("min restorable days",
+				&BackupRetentionPolicy{ExpireBeforeDays: 30, MinRestorableDays: pointer.Int(7)},
+				time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 24, 12, 0, 0, 0, time.UTC)),
+		)
+	})
+
+	When("checking if the backup data needs to be expired", func() {
+		now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+		DescribeTable("should return the expected result",
+			func(policy *BackupRetentionPolicy, lastExpiration *BackupExpirationStatus, expected bool) {
+				backup.Spec.RetentionPolicy = policy
+				backup.Status.LastExpiration = lastExpiration
+				Expect(backup.NeedsExpiration(now)).To(Equal(expected))
+			},
+			Entry("no retention policy", nil, nil, false),
+			Entry("no previous expiration", &BackupRetentionPolicy{ExpireBeforeDays: 30}, nil, true),
+			Entry("the last expiration is within the default interval",
+				&BackupRetentionPolicy{ExpireBeforeDays: 30},
+				&BackupExpirationStatus{Timestamp: metav1.NewTime(now.Add(-23 * time.Hour))},
+				false),
+			Entry("the last expiration is older than the default interval",
+				&BackupRetentionPolicy{ExpireBeforeDays: 30},
+				&BackupExpirationStatus{Timestamp: metav1.NewTime(now.Add(-24 * time.Hour))},
+				true),
+			Entry("the last expiration is older than a custom interval",
+				&BackupRetentionPolicy{ExpireBeforeDays: 30, ExpirationIntervalSeconds: pointer.Int(3600)},
+				&BackupExpirationStatus{Timestamp: metav1.NewTime(now.Add(-2 * time.Hour))},
+				true),
+		)
+	})
+
+	When("validating the retention policy", func() {
+		DescribeTable("should validate the min restorable days",
+			func(policy *BackupRetentionPolicy, expectedErr string) {
+				backup.Spec.RetentionPolicy = policy
+				err := backup.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("min restorable days not set", &BackupRetentionPolicy{ExpireBeforeDays: 30}, ""),
+			Entry("min restorable days smaller than expire before days", &BackupRetentionPolicy{ExpireBeforeDays: 30, MinRestorableDays: pointer.Int(7)}, ""),
+			Entry("min restorable days greater than expire before days",
+				&BackupRetentionPolicy{ExpireBeforeDays: 7, MinRestorableDays: pointer.Int(30)},
+				"minRestorableDays 30 must not be greater than expireBeforeDays 7"),
+		)
+	})
+
+	When("validating the key ranges", func() {
+		DescribeTable("should validate the key ranges",
+			func(keyRanges []FoundationDBKeyRange, expectedErr string) {
+				backup.Spec.KeyRanges = keyRanges
+				err := backup.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("no key ranges", nil, ""),
+			Entry("valid key ranges",
+				[]FoundationDBKeyRange{
+					{Start: `\x15user1/`, End: `\x15user2/`},
+					{Start: `\x15user2/`, End: `\x15user3/`},
+				},
+				""),
+			Entry("a key range with an invalid escape sequence",
+				[]FoundationDBKeyRange{
+					{Start: "a", End: `b\xzz`},
+				},
+				`key b\xzz contains an invalid escape sequence at position 1`),
+			Entry("overlapping key ranges",
+				[]FoundationDBKeyRange{
+					{Start: `\x15user2/`, End: `\x15user4/`},
+					{Start: `\x15user1/`, End: `\x15user3/`},
+				},
+				`the key range \x15user1/ - \x15user3/ overlaps with the key range \x15user2/ - \x15user4/`),
+		)
+	})
+
+	When("checking if the key ranges changed", func() {
+		DescribeTable("should return the expected result",
+			func(specKeyRanges []FoundationDBKeyRange, statusKeyRanges []FoundationDBKeyRange, expected bool) {
+				backup.Spec.KeyRanges = specKeyRanges
+				backup.Status.KeyRanges = statusKeyRanges
+				Expect(backup.KeyRangesChanged()).To(Equal(expected))
+			},
+			Entry("no key ranges are defined", nil, nil, false),
+			Entry("the key ranges are the same",
+				[]FoundationDBKeyRange{{Start: "a", End: "b"}},
+				[]FoundationDBKeyRange{{Start: "a", End: "b"}},
+				false),
+			Entry("key ranges were added",
+				[]FoundationDBKeyRange{{Start: "a", End: "b"}},
+				nil,
+				true),
+			Entry("key ranges were removed",
+				nil,
+				[]FoundationDBKeyRange{{Start: "a", End: "b"}},
+				true),
+			Entry("a key range was modified",
+				[]FoundationDBKeyRange{{Start: "a", End: "c"}},
+				[]FoundationDBKeyRange{{Start: "a", End: "b"}},
+				true),
+		)
+	})
+
+	DescribeTable("parsing the backup description", func(fileName string, expected FoundationDBBackupDescription) {
+		descriptionFile, err := os.OpenFile(filepath.Join("testdata", fileName), os.O_RDONLY, os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+		defer descriptionFile.Close()
+
+		description := FoundationDBBackupDescription{}
+		Expect(json.NewDecoder(descriptionFile).Decode(&description)).NotTo(HaveOccurred())
+		Expect(description).To(Equal(expected))
+	},
+		Entry("7.1", "fdbbackup_describe_7_1.json", FoundationDBBackupDescription{
+			URL:        "blobstore://minio@minio-service:9000/sample-cluster?bucket=fdb-backups",
+			Restorable: true,
+			Snapshots: []FoundationDBBackupDescriptionSnapshot{
+				{
+					Start:      FoundationDBLiveBackupStatusRestorablePoint{Version: 334642281, EpochSeconds: 1709287200, Timestamp: "2024/03/01.10:00:00+0000"},
+					End:        FoundationDBLiveBackupStatusRestorablePoint{Version: 340642281, EpochSeconds: 1709287300, Timestamp: "2024/03/01.10:01:40+0000"},
+					Restorable: true,
+					TotalBytes: 1048576,
+				},
+			},
+			TotalSnapshotBytes: 1048576,
+			MinRestorablePoint: &FoundationDBLiveBackupStatusRestorablePoint{Version: 340642281, EpochSeconds: 1709287300, Timestamp: "2024/03/01.10:01:40+0000"},
+			MaxRestorablePoint: &FoundationDBLiveBackupStatusRestorablePoint{Version: 3934642280, EpochSeconds: 1709290800, Timestamp: "2024/03/01.11:00:00+0000"},
+		}),
+		Entry("7.3", "fdbbackup_describe_7_3.json", FoundationDBBackupDescription{
+			URL:        "blobstore://minio@minio-service:9000/sample-cluster?bucket=fdb-backups",
+			Restorable: true,
+			Snapshots: []FoundationDBBackupDescriptionSnapshot{
+				{
+					Start:      FoundationDBLiveBackupStatusRestorablePoint{Version: 334642281, EpochSeconds: 1709287200, Timestamp: "2024/03/01.10:00:00+0000"},
+					End:        FoundationDBLiveBackupStatusRestorablePoint{Version: 340642281, EpochSeconds: 1709287300, Timestamp: "2024/03/01.10:01:40+0000"},
+					Restorable: true,
+					TotalBytes: 1048576,
+				},
+				{
+					Start:      FoundationDBLiveBackupStatusRestorablePoint{Version: 86734642281, EpochSeconds: 1709373600, Timestamp: "2024/03/02.10:00:00+0000"},
+					End:        FoundationDBLiveBackupStatusRestorablePoint{Version: 86740642281, EpochSeconds: 1709373700, Timestamp: "2024/03/02.10:01:40+0000"},
+					TotalBytes: 2097152,
+				},
+			},
+			TotalSnapshotBytes: 3145728,
+			MinRestorablePoint: &FoundationDBLiveBackupStatusRestorablePoint{Version: 340642281, EpochSeconds: 1709287300, Timestamp: "2024/03/01.10:01:40+0000"},
+			MaxRestorablePoint: &FoundationDBLiveBackupStatusRestorablePoint{Version: 86738642280, EpochSeconds: 1709373660, Timestamp: "2024/03/02.10:01:00+0000"},
+		}),
+	)
+
+	When("checking if the restorability details need to be updated", func() {
+		now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+		DescribeTable("should return the expected result",
+			func(describeIntervalSeconds *int, details *BackupRestorabilityDetails, expected bool) {
+				backup.Spec.DescribeIntervalSeconds = describeIntervalSeconds
+				backup.Status.RestorabilityDetails = details
+				Expect(backup.NeedsDescription(now)).To(Equal(expected))
+			},
+			Entry("no previous details", nil, nil, true),
+			Entry("the details are disabled", pointer.Int(0), nil, false),
+			Entry("the details are within the default interval",
+				nil,
+				&BackupRestorabilityDetails{LastUpdated: metav1.NewTime(now.Add(-59 * time.Minute))},
+				false),
+			Entry("the details are older than the default interval",
+				nil,
+				&BackupRestorabilityDetails{LastUpdated: metav1.NewTime(now.Add(-1 * time.Hour))},
+				true),
+			Entry("the details are older than a custom interval",
+				pointer.Int(60),
+				&BackupRestorabilityDetails{LastUpdated: metav1.NewTime(now.Add(-2 * time.Minute))},
+				true),
+			Entry("the last attempt failed within the default interval",
+				nil,
+				&BackupRestorabilityDetails{
+					LastUpdated: metav1.NewTime(now.Add(-2 * time.Hour)),
+					LastAttempt: metav1.NewTime(now.Add(-59 * time.Minute)),
+					Error:       "could not connect to blobstore",
+				},
+				false),
+			Entry("the last attempt failed before the default interval",
+				nil,
+				&BackupRestorabilityDetails{
+					LastAttempt: metav1.NewTime(now.Add(-1 * time.Hour)),
+					Error:       "could not connect to blobstore",
+				},
+				true),
+		)
+	})
 })
@@ -22,6 +22,7 @@ import (
 	"math"
 	"math/rand"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -65,10 +66,16 @@ type FoundationDBClusterList struct {
 }
 
 var conditionsThatNeedReplacement = []ProcessGroupConditionType{MissingProcesses, PodFailing, MissingPod, MissingPVC,
-	MissingService, PodPending, NodeTaintReplacing, ProcessIsMarkedAsExcluded}
+	MissingService, PodPending, NodeTaintReplacing, ProcessIsMarkedAsExcluded, PVCFailed}
 
 const (
 	oneHourDuration = 1 * time.Hour
+
+	// MaximumCoordinatorCount defines the maximum number of coordinators the operator will select.
+	MaximumCoordinatorCount = 9
+
+	// DefaultProcessPortBase defines the default port of the non-TLS address of the first process in a Pod.
+	DefaultProcessPortBase = 4501
 )
 
 func init() {
@@ -106,9 +113,22 @@ type FoundationDBClusterSpec struct {
 	// together.
 	PartialConnectionString ConnectionString `json:"partialConnectionString,omitempty"`
 
+	// UseSeedClusterFile defines if the fdbserver processes should get the
+	// seed_cluster_file argument and if the cluster file should be provided
+	// in the dynamic config. This can be disabled if the cluster file is
+	// provided by another mechanism.
+	// Default is true.
+	UseSeedClusterFile *bool `json:"useSeedClusterFile,omitempty"`
+
 	// FaultDomain defines the rules for what fault domain to replicate across.
 	FaultDomain FoundationDBClusterFaultDomain `json:"faultDomain,omitempty"`
 
+	// MachineIDSource defines the source of the locality_machineid of the
+	// processes. If unset the machine ID is derived from the fault domain
+	// settings.
+	// +optional
+	MachineIDSource *MachineIDSource `json:"machineIDSource,omitempty"`
+
 	// ProcessGroupsToRemove defines the process groups that we should remove from the
 	// cluster. This list contains the process group IDs.
 	// +kubebuilder:validation:MinItems=0
@@ -147,6 +167,35 @@ type FoundationDBClusterSpec struct {
 	// LogGroup defines the log group to use for the trace logs for the cluster.
 	LogGroup string `json:"logGroup,omitempty"`
 
+	// AutomaticLogGroupPrefix defines if the default log group should be prefixed to make it unique across namespaces.
+	// This setting only has an effect if LogGroup is unset. The log group will then be `<prefix>.<cluster name>`,
+	// where the prefix is LogGroupPrefix or the namespace of the cluster if LogGroupPrefix is unset.
+	// +kubebuilder:default:=false
+	// +optional
+	AutomaticLogGroupPrefix *bool `json:"automaticLogGroupPrefix,omitempty"`
+
+	// LogGroupPrefix defines the prefix for the default log group if AutomaticLogGroupPrefix is enabled.
+	// +kubebuilder:validation:MaxLength=100
+	// +optional
+	LogGroupPrefix string `json:"logGroupPrefix,omitempty"`
+
+	// TraceFormat defines the format of the trace logs of the fdbserver processes. If a process class defines the
+	// trace_format custom parameter, the custom parameter will be used for this process class. If unset fdbserver
+	// will use its default format.
+	// +kubebuilder:validation:Enum=xml;json
+	// +optional
+	TraceFormat TraceFormat `json:"traceFormat,omitempty"`
+
+	// Tracing defines the distributed tracing settings for the fdbserver processes. Those settings are only applied
+	// for versions of FDB that support the tracer option.
+	// +optional
+	Tracing TracingOptions `json:"tracing,omitempty"`
+
+	// FdbMonitor defines the settings for the [general] section of the fdbmonitor conf. Those settings are only used
+	// for the split image, the unified image doesn't use fdbmonitor.
+	// +optional
+	FdbMonitor FdbMonitorSettings `json:"fdbMonitor,omitempty"`
+
 	// DataCenter defines the data center where these processes are running.
 	DataCenter string `json:"dataCenter,omitempty"`
 
@@ -235,6 +284,26 @@ type FoundationDBClusterSpec struct {
 	// MaxZonesWithUnavailablePods defines the maximum number of zones that can have unavailable pods during the update process.
 	// When unset, there is no limit to the  number of zones with unavailable pods.
 	MaxZonesWithUnavailablePods *int `json:"maxZonesWithUnavailablePods,omitempty"`
+
+	// ConnectivityCheck defines the settings for an optional init container that waits until the coordinators
+	// are reachable before the fdbserver processes are started.
+	ConnectivityCheck ConnectivityCheckOptions `json:"connectivityCheck,omitempty"`
+}
+
+// ConnectivityCheckOptions defines the settings for the connectivity check init container.
+type ConnectivityCheckOptions struct {
+	// Enabled defines if the connectivity check init container should be added to the Pods.
+	// The init container can be customized in the Pod template by defining an init container with the name
+	// foundationdb-connectivity-check.
+	// Default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TimeoutSeconds defines how long the init container waits for a reachable coordinator. If no coordinator
+	// is reachable after this time, the init container will exit and the fdbserver processes will be started
+	// anyway.
+	// Default is 300.
+	// +kubebuilder:validation:Minimum=0
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
 }
 
 // ImageType defines a single kind of images used in the cluster.
@@ -315,6 +384,203 @@ type FoundationDBClusterStatus struct {
 
 	// ReconciledProcessGroups reflects the number of process groups that have no condition and are not marked for removal.
 	ReconciledProcessGroups int `json:"reconciledProcessGroups,omitempty"`
+
+	// CoordinatorAvailability reflects how many of the configured coordinators are currently reachable.
+	CoordinatorAvailability CoordinatorAvailability `json:"coordinatorAvailability,omitempty"`
+
+	// LastRequeue contains information about the sub-reconciler that most recently caused a requeue of the
+	// reconciliation loop.
+	LastRequeue *RequeueInfo `json:"lastRequeue,omitempty"`
+
+	// LastReconciliationError contains information about the sub-reconciler that blocked the most recent
+	// reconciliation loop. This information will be cleared once a reconciliation loop completes successfully.
+	LastReconciliationError *ReconciliationError `json:"lastReconciliationError,omitempty"`
+
+	// DatabaseStatusCachedAt provides the time when the machine-readable status that was used during the last
+	// reconciliation loop was fetched. This field is only set if the machine-readable status is cached, see
+	// CacheDatabaseStatusForReconciliation.
+	DatabaseStatusCachedAt *metav1.Time `json:"databaseStatusCachedAt,omitempty"`
+
+	// StorageEngineMigration contains information about an ongoing migration of the storage engine. The migration is
+	// done by replacing all storage process groups that were created with the previous storage engine.
+	// +optional
+	StorageEngineMigration *StorageEngineMigrationStatus `json:"storageEngineMigration,omitempty"`
+
+	// ReconcileLoops contains the number of reconciliation loops for this cluster in the current window. A high
+	// number of loops can indicate that the cluster is stuck in a reconciliation loop.
+	// +optional
+	ReconcileLoops *ReconcileLoopStatus `json:"reconcileLoops,omitempty"`
+
+	// TunedCoordinatorCount contains the number of coordinators that was computed based on the number of fault domains
+	// in the cluster. This value is only used if the automatic tuning of the coordinator count is enabled.
+	// +optional
+	TunedCoordinatorCount int `json:"tunedCoordinatorCount,omitempty"`
+
+	// HandledCoordinatorRotation contains the value of the foundationdb.org/rotate-coordinators annotation for which
+	// the coordinators were rotated the last time.
+	// +optional
+	HandledCoordinatorRotation string `json:"handledCoordinatorRotation,omitempty"`
+
+	// RecentInclusions contains the addresses that were most recently included by the operator after the
+	// corresponding process groups were removed. Only the last MaxRecentInclusions entries are kept.
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	RecentInclusions []InclusionRecord `json:"recentInclusions,omitempty"`
+
+	// LastFaultDomainReplacements contains the time of the last automatic replacement per fault domain. This is used
+	// to apply the FaultDomainReplacementCooldownSeconds even if the replaced process groups were already removed.
+	// Entries are only kept as long as the cool-down applies.
+	// +optional
+	LastFaultDomainReplacements map[FaultDomain]metav1.Time `json:"lastFaultDomainReplacements,omitempty"`
+
+	// Conditions represent the latest available observations of the state of the cluster.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// ClusterConditionTypeReconciled represents the condition that the latest generation of the cluster spec was
+	// reconciled.
+	ClusterConditionTypeReconciled = "Reconciled"
+
+	// ClusterConditionTypeAvailable represents the condition that the database is available.
+	ClusterConditionTypeAvailable = "Available"
+
+	// ClusterConditionTypeFullReplication represents the condition that the data in the database is fully replicated.
+	ClusterConditionTypeFullReplication = "FullReplication"
+
+	// ClusterConditionTypeReconciliationStalled represents the condition that the same sub-reconciler has returned a
+	// delayed requeue for longer than the configured duration.
+	ClusterConditionTypeReconciliationStalled = "ReconciliationStalled"
+
+	// ClusterConditionTypeCoordinatorRotationRecommended represents the condition that at least one process group has
+	// been serving as a coordinator for longer than the configured coordinator age warning duration.
+	ClusterConditionTypeCoordinatorRotationRecommended = "CoordinatorRotationRecommended"
+)
+
+// ReconcileLoopStatus provides information about the number of reconciliation loops in a rolling window.
+type ReconcileLoopStatus struct {
+	// WindowStart provides the time when the current window was started.
+	WindowStart *metav1.Time `json:"windowStart,omitempty"`
+
+	// Count is the number of reconciliation loops in the current window.
+	Count int `json:"count,omitempty"`
+
+	// PreviousCount is the number of reconciliation loops in the previous window.
+	PreviousCount int `json:"previousCount,omitempty"`
+}
+
+// StorageEngineMigrationStatus provides information about the migration from one storage engine to another.
+type StorageEngineMigrationStatus struct {
+	// From is the storage engine that was used before the migration.
+	From StorageEngine `json:"from,omitempty"`
+
+	// To is the storage engine the cluster migrates to.
+	To StorageEngine `json:"to,omitempty"`
+
+	// StartTimestamp provides the time when the migration was started.
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// PendingProcessGroups contains the process groups that still use the previous storage engine and must be
+	// replaced.
+	PendingProcessGroups []ProcessGroupID `json:"pendingProcessGroups,omitempty"`
+}
+
+// IsPending returns true if the provided process group must be replaced as part of the storage engine migration.
+func (migration *StorageEngineMigrationStatus) IsPending(processGroupID ProcessGroupID) bool {
+	if migration == nil {
+		return false
+	}
+
+	for _, pendingProcessGroupID := range migration.PendingProcessGroups {
+		if pendingProcessGroupID == processGroupID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RequeueInfo provides information about a requeue of the reconciliation loop.
+type RequeueInfo struct {
+	// Reconciler is the type of the sub-reconciler that requested the requeue.
+	Reconciler string `json:"reconciler,omitempty"`
+
+	// Message explains the reason for the requeue.
+	Message string `json:"message,omitempty"`
+
+	// Delayed defines if the requeue was delayed to the end of the reconciliation loop.
+	Delayed bool `json:"delayed,omitempty"`
+
+	// Timestamp provides the time when this requeue reason was first observed.
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
+}
+
+// ReconciliationErrorSeverity defines how severe a reconciliation error is.
+// +kubebuilder:validation:MaxLength=64
+type ReconciliationErrorSeverity string
+
+const (
+	// ReconciliationErrorSeverityError is used if a sub-reconciler stopped the reconciliation loop.
+	ReconciliationErrorSeverityError ReconciliationErrorSeverity = "Error"
+
+	// ReconciliationErrorSeverityWarning is used if a sub-reconciler delayed the requeue to the end of the
+	// reconciliation loop.
+	ReconciliationErrorSeverityWarning ReconciliationErrorSeverity = "Warning"
+)
+
+// ReconciliationError provides information about the sub-reconciler that blocked the reconciliation loop.
+type ReconciliationError struct {
+	// Reconciler is the type of the sub-reconciler that blocked the reconciliation loop.
+	Reconciler string `json:"reconciler,omitempty"`
+
+	// Message contains the error or the reason reported by the sub-reconciler. Long messages are truncated.
+	Message string `json:"message,omitempty"`
+
+	// Severity defines if the sub-reconciler stopped the reconciliation loop or only delayed the requeue.
+	// +kubebuilder:validation:Enum=Error;Warning
+	Severity ReconciliationErrorSeverity `json:"severity,omitempty"`
+
+	// Timestamp provides the time when this error was first observed.
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
+}
+
+// MaxRecentInclusions defines how many entries are kept in the RecentInclusions of the cluster status.
+const MaxRecentInclusions = 50
+
+// InclusionRecord provides information about an address that was included by the operator.
+type InclusionRecord struct {
+	// Address is the address or the locality that was included.
+	Address string `json:"address,omitempty"`
+
+	// ProcessGroupID is the ID of the removed process group that used this address.
+	ProcessGroupID ProcessGroupID `json:"processGroupID,omitempty"`
+
+	// IncludedAt provides the time when the address was included.
+	IncludedAt *metav1.Time `json:"includedAt,omitempty"`
+}
+
+// AddRecentInclusions appends the provided records to the recent inclusions. If the list contains more than
+// MaxRecentInclusions entries afterwards, the oldest entries will be dropped.
+func (clusterStatus *FoundationDBClusterStatus) AddRecentInclusions(records ...InclusionRecord) {
+	clusterStatus.RecentInclusions = append(clusterStatus.RecentInclusions, records...)
+	if len(clusterStatus.RecentInclusions) > MaxRecentInclusions {
+		clusterStatus.RecentInclusions = clusterStatus.RecentInclusions[len(clusterStatus.RecentInclusions)-MaxRecentInclusions:]
+	}
+}
+
+// CoordinatorAvailability provides information about the reachability of the coordinators in the connection string.
+type CoordinatorAvailability struct {
+	// Reachable reports the number of coordinators that are currently reachable.
+	Reachable int `json:"reachable,omitempty"`
+
+	// Unreachable reports the number of coordinators that are currently not reachable.
+	Unreachable int `json:"unreachable,omitempty"`
+
+	// Total reports the number of coordinators in the connection string.
+	Total int `json:"total,omitempty"`
 }
 
 // MaintenanceModeInfo contains information regarding the zone and process groups that are put
@@ -349,6 +615,12 @@ type ProcessGroupStatus struct {
 	Addresses []string `json:"addresses,omitempty"`
 	// RemoveTimestamp if not empty defines when the process group was marked for removal.
 	RemovalTimestamp *metav1.Time `json:"removalTimestamp,omitempty"`
+	// RemovalRequested defines if the process group was marked for removal because it was listed in the
+	// ProcessGroupsToRemove or ProcessGroupsToRemoveWithoutExclusion of the cluster spec.
+	RemovalRequested bool `json:"removalRequested,omitempty"`
+	// ExclusionStartedTimestamp defines when the operator issued the exclusion for the process group. Together with
+	// the ExclusionTimestamp this can be used to calculate how long the exclusion took.
+	ExclusionStartedTimestamp *metav1.Time `json:"exclusionStartedTimestamp,omitempty"`
 	// ExclusionTimestamp defines when the process group has been fully excluded.
 	// This is only used within the reconciliation process, and should not be considered authoritative.
 	ExclusionTimestamp *metav1.Time `json:"exclusionTimestamp,omitempty"`
@@ -359,6 +631,21 @@ type ProcessGroupStatus struct {
 	// FaultDomain represents the last seen fault domain from the cluster status. This can be used if a Pod or process
 	// is not running and would be missing in the cluster status.
 	FaultDomain FaultDomain `json:"faultDomain,omitempty"`
+	// CoordinatorTimestamp defines since when the process group has been serving as a coordinator. If the process group
+	// is not a coordinator this will be nil.
+	CoordinatorTimestamp *metav1.Time `json:"coordinatorTimestamp,omitempty"`
+	// ExclusionProgress represents the progress of the data movement for a process group that is marked for removal
+	// and is currently being excluded. If the process group is not being excluded this will be nil.
+	ExclusionProgress *ExclusionProgress `json:"exclusionProgress,omitempty"`
+}
+
+// ExclusionProgress represents the progress of the data movement for a process group that is being excluded.
+type ExclusionProgress struct {
+	// InitialBytes defines the bytes that were stored on the processes of the process group when the exclusion
+	// was first observed.
+	InitialBytes int `json:"initialBytes,omitempty"`
+	// RemainingBytes defines the bytes that are still stored on the processes of the process group.
+	RemainingBytes int `json:"remainingBytes,omitempty"`
 }
 
 // String returns string representation.
@@ -379,6 +666,13 @@ func (processGroupStatus *ProcessGroupStatus) String() string {
 		sb.WriteString(processGroupStatus.RemovalTimestamp.String())
 	}
 
+	sb.WriteString(", ExclusionStartedTimestamp: ")
+	if processGroupStatus.ExclusionStartedTimestamp.IsZero() {
+		sb.WriteString("-")
+	} else {
+		sb.WriteString(processGroupStatus.ExclusionStartedTimestamp.String())
+	}
+
 	sb.WriteString(", ExclusionTimestamp: ")
 	if processGroupStatus.ExclusionTimestamp.IsZero() {
 		sb.WriteString("-")
@@ -456,6 +750,31 @@ func (processGroupStatus *ProcessGroupStatus) SetExclude() {
 	processGroupStatus.ProcessGroupConditions = newConditions
 }
 
+// MarkExclusionStarted sets the ExclusionStartedTimestamp of the process group. If the ExclusionStartedTimestamp is
+// already set it won't be changed.
+func (processGroupStatus *ProcessGroupStatus) MarkExclusionStarted() {
+	if !processGroupStatus.ExclusionStartedTimestamp.IsZero() {
+		return
+	}
+
+	processGroupStatus.ExclusionStartedTimestamp = &metav1.Time{Time: time.Now()}
+}
+
+// GetExclusionDuration returns how long the exclusion of the process group took. If the exclusion is still ongoing,
+// the duration since the exclusion was started will be returned. If the operator has not started the exclusion, 0 will
+// be returned.
+func (processGroupStatus *ProcessGroupStatus) GetExclusionDuration() time.Duration {
+	if processGroupStatus.ExclusionStartedTimestamp.IsZero() {
+		return 0
+	}
+
+	if processGroupStatus.ExclusionTimestamp.IsZero() {
+		return time.Since(processGroupStatus.ExclusionStartedTimestamp.Time)
+	}
+
+	return processGroupStatus.ExclusionTimestamp.Sub(processGroupStatus.ExclusionStartedTimestamp.Time)
+}
+
 // IsMarkedForRemoval returns if a process group is marked for removal
 func (processGroupStatus *ProcessGroupStatus) IsMarkedForRemoval() bool {
 	return !processGroupStatus.RemovalTimestamp.IsZero()
@@ -470,6 +789,15 @@ func (processGroupStatus *ProcessGroupStatus) MarkForRemoval() {
 	processGroupStatus.RemovalTimestamp = &metav1.Time{Time: time.Now()}
 }
 
+// CancelRemoval resets the removal mark of a process group. This should only be used for process groups that are not
+// excluded yet.
+func (processGroupStatus *ProcessGroupStatus) CancelRemoval() {
+	processGroupStatus.RemovalTimestamp = nil
+	processGroupStatus.RemovalRequested = false
+	processGroupStatus.ExclusionStartedTimestamp = nil
+	processGroupStatus.ExclusionProgress = nil
+}
+
 // GetPodName returns the Pod name for the associated Process Group.
 func (processGroupStatus *ProcessGroupStatus) GetPodName(cluster *FoundationDBCluster) string {
 	var sb strings.Builder
@@ -507,6 +835,12 @@ func (processGroupStatus *ProcessGroupStatus) NeedsReplacement(failureTime int,
 		}
 
 		conditionTime := *conditionTimePtr
+		// A failed PVC will not recover, so the process group should be replaced without waiting for the failure
+		// detection time.
+		if conditionType == PVCFailed {
+			return conditionType, conditionTime
+		}
+
 		if conditionType == NodeTaintReplacing {
 			if earliestTaintReplacementTime > conditionTime {
 				earliestTaintReplacementTime = conditionTime
@@ -926,6 +1260,10 @@ const (
 	NodeTaintReplacing ProcessGroupConditionType = "NodeTaintReplacing"
 	// ProcessIsMarkedAsExcluded represents a process group where at least one process is excluded.
 	ProcessIsMarkedAsExcluded ProcessGroupConditionType = "ProcessIsMarkedAsExcluded"
+	// IncorrectImage represents a process group whose Pod runs a container image that differs from the image in the Pod spec.
+	IncorrectImage ProcessGroupConditionType = "IncorrectImage"
+	// PVCFailed represents a process group whose PVC is in the Lost phase.
+	PVCFailed ProcessGroupConditionType = "PVCFailed"
 )
 
 // AllProcessGroupConditionTypes returns all ProcessGroupConditionType
@@ -945,6 +1283,8 @@ func AllProcessGroupConditionTypes() []ProcessGroupConditionType {
 		NodeTaintDetected,
 		NodeTaintReplacing,
 		ProcessIsMarkedAsExcluded,
+		IncorrectImage,
+		PVCFailed,
 	}
 }
 
@@ -981,6 +1321,10 @@ func GetProcessGroupConditionType(processGroupConditionType string) (ProcessGrou
 		return NodeTaintReplacing, nil
 	case "ProcessIsMarkedAsExcluded":
 		return ProcessIsMarkedAsExcluded, nil
+	case "IncorrectImage":
+		return IncorrectImage, nil
+	case "PVCFailed":
+		return PVCFailed, nil
 	}
 
 	return "", fmt.Errorf("unknown process group condition type: %s", processGroupConditionType)
@@ -1078,11 +1422,22 @@ type FoundationDBClusterAutomationOptions struct {
 	// processes.
 	KillProcesses *bool `json:"killProcesses,omitempty"`
 
+	// KillProcessesOnConfigurationChange defines whether fdbmonitor should restart the fdbserver processes when the
+	// monitor conf changes. In this case the operator will wait for fdbmonitor to restart the processes before it
+	// bounces them. This setting is only used for the split image and will be ignored during version incompatible
+	// upgrades, as those require all processes to be restarted at the same time.
+	// Default is false.
+	KillProcessesOnConfigurationChange *bool `json:"killProcessesOnConfigurationChange,omitempty"`
+
 	// CacheDatabaseStatusForReconciliation defines whether the operator is using the same FoundationDB machine-readable
 	// status for all sub-reconcilers or if the machine-readable status should be fetched by ever sub-reconciler if
 	// required. Enabling this setting might improve the operator reconciliation speed for large clusters.
 	CacheDatabaseStatusForReconciliation *bool `json:"cacheDatabaseStatusForReconciliation,omitempty"`
 
+	// RestartIncompatibleProcesses defines whether the operator should restart processes that are reported as
+	// incompatible connections, e.g. after an upgrade. If unset, the operator default will be used.
+	RestartIncompatibleProcesses *bool `json:"restartIncompatibleProcesses,omitempty"`
+
 	// Replacements contains options for automatically replacing failed
 	// processes.
 	Replacements AutomaticReplacementOptions `json:"replacements,omitempty"`
@@ -1110,12 +1465,30 @@ type FoundationDBClusterAutomationOptions struct {
 	// it will be ignored during reconciliation. This prevents that a process will block reconciliation.
 	IgnoreMissingProcessesSeconds *int `json:"ignoreMissingProcessesSeconds,omitempty"`
 
+	// IgnoreMissingProcessesForExclusionSeconds defines how long a process group has to be in the MissingProcess
+	// condition until it will be ignored when checking if new exclusions are allowed. Until then the missing process
+	// blocks further exclusions of the same process class. Default is 300 seconds.
+	// +kubebuilder:validation:Minimum=0
+	IgnoreMissingProcessesForExclusionSeconds *int `json:"ignoreMissingProcessesForExclusionSeconds,omitempty"`
+
 	// FailedPodDurationSeconds defines the duration a Pod can stay in the deleted state (deletionTimestamp != 0) before
 	// it gets marked as PodFailed. This is important in cases where a fdbserver process is still reporting but the
 	// Pod resource is marked for deletion. This can happen when the kubelet or a node fails. Setting this condition
 	// will ensure that the operator is replacing affected Pods.
 	FailedPodDurationSeconds *int `json:"failedPodDurationSeconds,omitempty"`
 
+	// ReconciliationStalledSeconds defines how long the same sub-reconciler can return a delayed requeue for the same
+	// reason before the ReconciliationStalled condition is set on the cluster status.
+	// The default is 1800 (30 minutes).
+	// +kubebuilder:validation:Minimum=0
+	ReconciliationStalledSeconds *int `json:"reconciliationStalledSeconds,omitempty"`
+
+	// ReconciliationTimeoutSeconds defines the maximum duration of a single reconciliation run for this cluster. If
+	// the duration is exceeded, the reconciliation will be stopped and requeued. This prevents a single cluster from
+	// blocking the operator. If unset, the operator default will be used, a value of 0 disables the timeout.
+	// +kubebuilder:validation:Minimum=0
+	ReconciliationTimeoutSeconds *int `json:"reconciliationTimeoutSeconds,omitempty"`
+
 	// MaxConcurrentReplacements defines how many process groups can be concurrently
 	// replaced if they are misconfigured. If the value will be set to 0 this will block replacements
 	// and these misconfigured Pods must be replaced manually or by another process. For each reconcile
@@ -1170,6 +1543,362 @@ type FoundationDBClusterAutomationOptions struct {
 	// The default is a list that includes "fdb-kubernetes-operator".
 	// +kubebuilder:validation:MaxItems=10
 	IgnoreLogGroupsForUpgrade []LogGroup `json:"ignoreLogGroupsForUpgrade,omitempty"`
+
+	// CoordinatorAgeWarningSeconds defines how long the same process group can serve as a coordinator before the
+	// operator emits an event that recommends to rotate the coordinators. The operator will not change the
+	// coordinators on its own. If unset or 0, no warning will be emitted.
+	// +kubebuilder:validation:Minimum=0
+	CoordinatorAgeWarningSeconds *int `json:"coordinatorAgeWarningSeconds,omitempty"`
+
+	// DeletionCleanup contains options for the cleanup steps that should be performed before the cluster resource
+	// is deleted.
+	DeletionCleanup DeletionCleanupOptions `json:"deletionCleanup,omitempty"`
+
+	// ConfigMapUpdateDebounceSeconds defines the minimum time between two updates of the cluster config map. Changes
+	// that happen within this window are coalesced and applied in a single update once the window has passed, the
+	// latest content will always be applied. If unset or 0, the config map will be updated directly.
+	// +kubebuilder:validation:Minimum=0
+	ConfigMapUpdateDebounceSeconds *int `json:"configMapUpdateDebounceSeconds,omitempty"`
+
+	// IgnoreTestProcessGroups defines if process groups of the test process class should be ignored by the update and
+	// replacement automation of the operator. Test processes are never excluded or restarted by the operator.
+	// Default is false.
+	IgnoreTestProcessGroups *bool `json:"ignoreTestProcessGroups,omitempty"`
+
+	// ExclusionMaintenanceWindows defines time windows in which the operator will not exclude any processes, e.g. during
+	// a change freeze. Other operations of the operator will continue during those windows.
+	// +kubebuilder:validation:MaxItems=10
+	ExclusionMaintenanceWindows []MaintenanceWindow `json:"exclusionMaintenanceWindows,omitempty"`
+
+	// ReplaceMismatchedImages defines if the operator should recreate Pods that run a container image that differs
+	// from the image defined in the Pod spec, e.g. after a failed image rollout. Those process groups will get
+	// the IncorrectImage condition and will be recreated in the same way as Pods with an incorrect spec.
+	// Default is false.
+	ReplaceMismatchedImages *bool `json:"replaceMismatchedImages,omitempty"`
+
+	// CoordinatorChangeDelayAfterExclusionSeconds defines how long the operator waits after excluding a process that
+	// serves as coordinator before changing the coordinators. The operator will requeue the reconciliation until the
+	// delay has passed, if set to 0 the coordinators will be changed directly after the exclusion. A short delay can
+	// reduce the recovery turbulence on some clusters. If unset, the coordinators will be changed in a later
+	// reconciliation without any delay.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=300
+	CoordinatorChangeDelayAfterExclusionSeconds *int `json:"coordinatorChangeDelayAfterExclusionSeconds,omitempty"`
+
+	// AutoTuneCoordinatorCount defines if the operator should increase the number of coordinators when the cluster
+	// spans more fault domains. The operator will use the largest odd number of coordinators that can be placed in
+	// distinct zones, up to 9 coordinators. The count will never be lower than the count required for the
+	// desired fault tolerance. A coordinator change because of a changed count is only performed if the cluster
+	// allows configuration changes.
+	// Default is false.
+	AutoTuneCoordinatorCount *bool `json:"autoTuneCoordinatorCount,omitempty"`
+
+	// ReconcileTraceMode defines if the operator should record the decisions of all sub-reconcilers for every
+	// reconciliation loop. If set to Log the trace will be logged at the end of the reconciliation loop, if set to
+	// Annotation the trace will additionally be stored in the foundationdb.org/last-reconcile-trace annotation of the
+	// cluster. This is intended for debugging and will increase the amount of logs.
+	// Default is Disabled.
+	// +kubebuilder:validation:Enum=Disabled;Log;Annotation
+	ReconcileTraceMode *ReconcileTraceMode `json:"reconcileTraceMode,omitempty"`
+
+	// DeriveMemoryKnobsFromResources defines if the operator should set the memory knob of the fdbserver processes
+	// based on the memory limit of the main container, divided by the number of servers per Pod. For the memory
+	// storage engine the storage_memory knob will be set for storage processes to half of the memory knob. Knobs
+	// that are defined in the custom parameters take precedence.
+	// Default is false.
+	DeriveMemoryKnobsFromResources *bool `json:"deriveMemoryKnobsFromResources,omitempty"`
+
+	// MemoryKnobsHeadroomPercentage defines the percentage of the main container memory limit that should not be
+	// used for the derived memory knobs, e.g. to leave room for the fdbmonitor or the fdb-kubernetes-monitor. This
+	// setting is only used when DeriveMemoryKnobsFromResources is enabled.
+	// Default is 10.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=90
+	MemoryKnobsHeadroomPercentage *int `json:"memoryKnobsHeadroomPercentage,omitempty"`
+
+	// PodDisruptionBudget contains options for the PodDisruptionBudgets that are managed by the operator.
+	PodDisruptionBudget PodDisruptionBudgetOptions `json:"podDisruptionBudget,omitempty"`
+
+	// ExcludeFailedProcessesAfterSeconds defines how long a process group that is marked for removal must be without
+	// Pod and PVC before the operator excludes the processes with the failed flag. Excluding processes with the failed
+	// flag skips waiting for the data to be fetched from the missing processes, so this should only be used if the
+	// processes will never come back. If unset, the operator will never exclude processes with the failed flag.
+	// +kubebuilder:validation:Minimum=0
+	ExcludeFailedProcessesAfterSeconds *int `json:"excludeFailedProcessesAfterSeconds,omitempty"`
+
+	// MaxConcurrentExclusions defines how many process groups can be excluded concurrently. Process groups that are
+	// excluded but haven't finished the exclusion count against this limit. Process groups with missing processes are
+	// preferred. The remaining process groups will be excluded in a later reconciliation. This limit is applied in
+	// addition to the fault tolerance based limit, so the stricter of both limits applies. If unset, the number of
+	// exclusions is only limited by the fault tolerance.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentExclusions *int `json:"maxConcurrentExclusions,omitempty"`
+
+	// MinimumRecoveryTimeForExclusionSeconds defines the duration in seconds that the cluster must be up since the last
+	// recovery before new exclusions are allowed. If unset, the operator default will be used.
+	// +kubebuilder:validation:Minimum=0
+	MinimumRecoveryTimeForExclusionSeconds *int `json:"minimumRecoveryTimeForExclusionSeconds,omitempty"`
+
+	// MinimumRecoveryTimeForInclusionSeconds defines the duration in seconds that the cluster must be up since the last
+	// recovery before new inclusions are allowed. If unset, the operator default will be used.
+	// +kubebuilder:validation:Minimum=0
+	MinimumRecoveryTimeForInclusionSeconds *int `json:"minimumRecoveryTimeForInclusionSeconds,omitempty"`
+
+	// Removals contains options for the removal of process groups.
+	Removals RemovalOptions `json:"removals,omitempty"`
+}
+
+// RemovalOptions controls how the resources of removed process groups are cleaned up.
+type RemovalOptions struct {
+	// PVCRetentionDuration defines how long the PVCs of a removed process group are retained after the Pod was
+	// deleted. During this window the data of the process group can still be recovered, e.g. after an accidental
+	// shrink of the cluster, and the process group will stay in the ResourcesTerminating state. Process groups that
+	// are removed with the kubectl plugin and the force flag will not wait for the retention duration.
+	// If unset or 0, the PVCs will be deleted together with the Pod.
+	PVCRetentionDuration *metav1.Duration `json:"pvcRetentionDuration,omitempty"`
+}
+
+// PodDisruptionBudgetOptions controls the PodDisruptionBudgets that are managed by the operator.
+type PodDisruptionBudgetOptions struct {
+	// Enabled defines whether the operator should create and maintain a PodDisruptionBudget that selects all Pods
+	// of the cluster. The maxUnavailable of the PodDisruptionBudget is based on the desired fault tolerance of the
+	// cluster. If disabled, the PodDisruptionBudgets created by the operator will be removed.
+	// Default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ReconcileTraceMode defines how the decisions of a reconciliation loop should be traced.
+type ReconcileTraceMode string
+
+const (
+	// ReconcileTraceModeDisabled disables the reconcile trace.
+	ReconcileTraceModeDisabled ReconcileTraceMode = "Disabled"
+
+	// ReconcileTraceModeLog logs the reconcile trace at the end of every reconciliation loop.
+	ReconcileTraceModeLog ReconcileTraceMode = "Log"
+
+	// ReconcileTraceModeAnnotation logs the reconcile trace and stores it as annotation on the cluster.
+	ReconcileTraceModeAnnotation ReconcileTraceMode = "Annotation"
+)
+
+// MaintenanceWindow defines a recurring time window.
+type MaintenanceWindow struct {
+	// Start defines the time of day when the window starts in the format HH:MM.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End defines the time of day when the window ends in the format HH:MM. If End is before Start, the window
+	// ends on the next day. If End equals Start, the window covers the whole day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// Days defines the days of the week on which the window starts, e.g. Monday. If empty the window starts
+	// on every day.
+	// +kubebuilder:validation:MaxItems=7
+	Days []string `json:"days,omitempty"`
+
+	// TimeZone defines the time zone for Start and End as IANA time zone name, e.g. Europe/Berlin.
+	// Default is UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Contains returns true if the provided time is inside the maintenance window.
+func (window MaintenanceWindow) Contains(now time.Time) (bool, error) {
+	location := time.UTC
+	if window.TimeZone != "" {
+		var err error
+		location, err = time.LoadLocation(window.TimeZone)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return false, err
+	}
+
+	end, err := time.Parse("15:04", window.End)
+	if err != nil {
+		return false, err
+	}
+
+	for _, day := range window.Days {
+		if _, ok := weekdays[day]; !ok {
+			return false, fmt.Errorf("%s is not a valid day of the week", day)
+		}
+	}
+
+	localTime := now.In(location)
+	minute := localTime.Hour()*60 + localTime.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	startDay := localTime.Weekday()
+	if startMinute < endMinute {
+		if minute < startMinute || minute >= endMinute {
+			return false, nil
+		}
+	} else if startMinute > endMinute {
+		if minute >= endMinute && minute < startMinute {
+			return false, nil
+		}
+
+		// The window started on the previous day.
+		if minute < endMinute {
+			startDay = localTime.AddDate(0, 0, -1).Weekday()
+		}
+	}
+
+	if len(window.Days) == 0 {
+		return true, nil
+	}
+
+	for _, day := range window.Days {
+		if weekdays[day] == startDay {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// weekdays maps the name of a day of the week to the according time.Weekday.
+var weekdays = map[string]time.Weekday{
+	time.Sunday.String():    time.Sunday,
+	time.Monday.String():    time.Monday,
+	time.Tuesday.String():   time.Tuesday,
+	time.Wednesday.String(): time.Wednesday,
+	time.Thursday.String():  time.Thursday,
+	time.Friday.String():    time.Friday,
+	time.Saturday.String():  time.Saturday,
+}
+
+// TraceFormat defines the format of the trace logs.
+type TraceFormat string
+
+const (
+	// TraceFormatXML defines the XML format for trace logs.
+	TraceFormatXML TraceFormat = "xml"
+
+	// TraceFormatJSON defines the JSON format for trace logs.
+	TraceFormatJSON TraceFormat = "json"
+)
+
+// Tracer defines the tracer that is used by fdbserver for distributed tracing.
+type Tracer string
+
+const (
+	// TracerNone disables distributed tracing.
+	TracerNone Tracer = "none"
+
+	// TracerLogFile writes the spans into the trace logs.
+	TracerLogFile Tracer = "log_file"
+
+	// TracerNetworkLossy sends the spans over UDP to a listener.
+	TracerNetworkLossy Tracer = "network_lossy"
+)
+
+// TracingOptions defines the settings for distributed tracing.
+type TracingOptions struct {
+	// Tracer defines the tracer that fdbserver should use. If a process class defines the tracer custom parameter,
+	// the custom parameter will be used for this process class. If unset fdbserver will use its default tracer.
+	// +kubebuilder:validation:Enum=none;log_file;network_lossy
+	// +optional
+	Tracer Tracer `json:"tracer,omitempty"`
+
+	// UDPListenerAddress defines the address of the UDP listener that receives the spans when the network_lossy
+	// tracer is used. If unset fdbserver will use its default address.
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	UDPListenerAddress string `json:"udpListenerAddress,omitempty"`
+}
+
+// MachineIDSource defines the source of the locality_machineid of the processes. Only one of the fields can be set.
+type MachineIDSource struct {
+	// EnvironmentVariable defines the name of an environment variable that holds the machine ID. The environment
+	// variable must be defined in the main container and, for the split image, in the sidecar container.
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	EnvironmentVariable string `json:"environmentVariable,omitempty"`
+
+	// NodeLabel defines the node label key that holds the machine ID. The operator copies the value of this label
+	// from the node a Pod is running on to the Pod annotations.
+	// +kubebuilder:validation:MaxLength=317
+	// +optional
+	NodeLabel string `json:"nodeLabel,omitempty"`
+}
+
+// FdbMonitorSettings defines the settings for the [general] section of the fdbmonitor conf.
+// See: https://apple.github.io/foundationdb/configuration.html#general-section
+type FdbMonitorSettings struct {
+	// RestartDelaySeconds defines the maximum number of seconds fdbmonitor waits before restarting a failed process.
+	// Default is 60.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RestartDelaySeconds *int `json:"restartDelaySeconds,omitempty"`
+
+	// RestartBackoff defines the factor by which the delay is multiplied when a process fails repeatedly, up to
+	// RestartDelaySeconds. If unset fdbmonitor uses the value of the restart delay.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RestartBackoff *int `json:"restartBackoff,omitempty"`
+
+	// RestartDelayResetIntervalSeconds defines how many seconds a process must be running before the backoff is
+	// reset. If unset fdbmonitor uses the value of the restart delay.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RestartDelayResetIntervalSeconds *int `json:"restartDelayResetIntervalSeconds,omitempty"`
+}
+
+// DeletionCleanupOptions controls the cleanup that is performed by the operator when a cluster is deleted.
+type DeletionCleanupOptions struct {
+	// Enabled defines whether the operator should add a finalizer to the cluster resource and perform the cleanup steps
+	// before the cluster resource is deleted.
+	// Default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Steps defines the cleanup steps that should be performed before the cluster resource is deleted. If no steps are
+	// defined all steps will be performed.
+	// +kubebuilder:validation:MaxItems=10
+	Steps []DeletionCleanupStep `json:"steps,omitempty"`
+
+	// TimeoutSeconds defines how long the operator will try to perform the cleanup steps after the deletion was
+	// requested. If the cleanup steps are not finished after the timeout the finalizer will be removed anyway.
+	// Default is 600.
+	// +kubebuilder:validation:Minimum=0
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+}
+
+// DeletionCleanupStep represents a cleanup step that is performed before a cluster is deleted.
+// +kubebuilder:validation:MaxLength=128
+// +kubebuilder:validation:Enum=StopBackups;StatusSnapshot;IncludeProcesses;ReleaseLocks
+type DeletionCleanupStep string
+
+const (
+	// DeletionCleanupStepStopBackups will stop all running backups for the cluster.
+	DeletionCleanupStepStopBackups DeletionCleanupStep = "StopBackups"
+
+	// DeletionCleanupStepStatusSnapshot will fetch the machine-readable status of the cluster and log it.
+	DeletionCleanupStepStatusSnapshot DeletionCleanupStep = "StatusSnapshot"
+
+	// DeletionCleanupStepIncludeProcesses will include all processes of the cluster that are currently excluded. This
+	// prevents stale exclusions when multiple cluster resources manage the same FoundationDB cluster.
+	DeletionCleanupStepIncludeProcesses DeletionCleanupStep = "IncludeProcesses"
+
+	// DeletionCleanupStepReleaseLocks will clear the pending upgrades of the cluster and release the lock if the
+	// current operator instance is the lock owner.
+	DeletionCleanupStepReleaseLocks DeletionCleanupStep = "ReleaseLocks"
+)
+
+// AllDeletionCleanupSteps returns all the supported deletion cleanup steps in the order they will be performed.
+func AllDeletionCleanupSteps() []DeletionCleanupStep {
+	return []DeletionCleanupStep{
+		DeletionCleanupStepStopBackups,
+		DeletionCleanupStepStatusSnapshot,
+		DeletionCleanupStepIncludeProcesses,
+		DeletionCleanupStepReleaseLocks,
+	}
 }
 
 // LogGroup represents a LogGroup used by a FoundationDB process to log trace events. The LogGroup can be used to filter
@@ -1268,6 +1997,19 @@ type AutomaticReplacementOptions struct {
 	// Defaults to 10% of the fault domains or at least 1.
 	// +kubebuilder:validation:XIntOrString
 	MaxFaultDomainsWithTaintedProcessGroups *intstr.IntOrString `json:"maxFaultDomainsWithTaintedProcessGroups,omitempty"`
+
+	// MaxConcurrentReplacementsPerFaultDomain defines how many automatic replacements can be ongoing in a single fault
+	// domain at the same time. This limit is applied in addition to MaxConcurrentReplacements. If unset, the number of
+	// replacements in a fault domain is only limited by MaxConcurrentReplacements.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentReplacementsPerFaultDomain *int `json:"maxConcurrentReplacementsPerFaultDomain,omitempty"`
+
+	// FaultDomainReplacementCooldownSeconds defines how long the operator waits after a process group in a fault
+	// domain was marked for removal before another process group in the same fault domain will be automatically
+	// replaced. The time of the last replacement per fault domain will be tracked in the cluster status.
+	// If unset or 0, no cool-down will be applied.
+	// +kubebuilder:validation:Minimum=0
+	FaultDomainReplacementCooldownSeconds *int `json:"faultDomainReplacementCooldownSeconds,omitempty"`
 }
 
 // ProcessSettings defines process-level settings.
@@ -1285,6 +2027,86 @@ type ProcessSettings struct {
 	// from the [general] and [fdbmonitor] section are not supported. For more Information
 	// see: https://apple.github.io/foundationdb/configuration.html#general-section
 	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+
+	// CustomParametersPerProcess defines additional parameters to pass to a specific fdbserver process in a Pod.
+	// Parameters defined for a process number take precedence over the parameters with the same name in
+	// CustomParameters. The process number must not be larger than the number of servers per Pod. This setting
+	// is only supported for the split image.
+	// +optional
+	CustomParametersPerProcess []ProcessCustomParameters `json:"customParametersPerProcess,omitempty"`
+
+	// ProbeOverrides defines probes that should be set on the containers of the Pods of this process class. The
+	// probes will replace the probes defined in the Pod template or the probes added by the operator.
+	// +kubebuilder:validation:MaxItems=10
+	ProbeOverrides []ContainerProbeOverride `json:"probeOverrides,omitempty"`
+
+	// LogGroup defines the log group to use for the trace logs of the processes of this process class. If unset the
+	// log group of the cluster will be used.
+	LogGroup string `json:"logGroup,omitempty"`
+
+	// LogVolumeClaimTemplate allows to use a dedicated persistent volume claim for the trace logs of the processes.
+	// If set, the fdb-trace-logs volume will be backed by a persistent volume claim based on this template instead of
+	// an emptyDir volume. The persistent volume claim will be deleted together with the process group.
+	LogVolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"logVolumeClaimTemplate,omitempty"`
+
+	// TraceLogDir defines the directory where the fdbserver processes write their trace logs to. The fdb-trace-logs
+	// volume will be mounted at this directory in the main container. If unset the trace logs will be written to
+	// /var/log/fdb-trace-logs.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +kubebuilder:validation:MaxLength=255
+	TraceLogDir string `json:"traceLogDir,omitempty"`
+}
+
+// ProcessCustomParameters defines additional parameters for a specific fdbserver process in a Pod.
+type ProcessCustomParameters struct {
+	// ProcessNumber defines the number of the process in the Pod, starting with 1.
+	// +kubebuilder:validation:Minimum=1
+	ProcessNumber int `json:"processNumber"`
+
+	// CustomParameters defines additional parameters to pass to the fdbserver process.
+	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+}
+
+// GetCustomParametersForProcess returns the custom parameters for the provided process number. Parameters that are
+// defined for the process number replace the parameters with the same name from the shared custom parameters.
+func (settings ProcessSettings) GetCustomParametersForProcess(processNumber int) FoundationDBCustomParameters {
+	var processParameters FoundationDBCustomParameters
+	for _, entry := range settings.CustomParametersPerProcess {
+		if entry.ProcessNumber == processNumber {
+			processParameters = entry.CustomParameters
+			break
+		}
+	}
+
+	if len(processParameters) == 0 {
+		return settings.CustomParameters
+	}
+
+	parameters := make(FoundationDBCustomParameters, 0, len(settings.CustomParameters)+len(processParameters))
+	for _, parameter := range settings.CustomParameters {
+		if processParameters.HasParameter(parameter.getName()) {
+			continue
+		}
+
+		parameters = append(parameters, parameter)
+	}
+
+	return append(parameters, processParameters...)
+}
+
+// ContainerProbeOverride defines the probes for a container.
+type ContainerProbeOverride struct {
+	// ContainerName defines the name of the container the probes should be set on.
+	ContainerName string `json:"containerName"`
+
+	// LivenessProbe defines the liveness probe for the container.
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe defines the readiness probe for the container.
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// StartupProbe defines the startup probe for the container.
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
 }
 
 // GetProcessSettings gets settings for a process.
@@ -1308,6 +2130,21 @@ func (cluster *FoundationDBCluster) GetProcessSettings(processClass ProcessClass
 		if merged.CustomParameters == nil {
 			merged.CustomParameters = entry.CustomParameters
 		}
+		if merged.CustomParametersPerProcess == nil {
+			merged.CustomParametersPerProcess = entry.CustomParametersPerProcess
+		}
+		if merged.ProbeOverrides == nil {
+			merged.ProbeOverrides = entry.ProbeOverrides
+		}
+		if merged.LogGroup == "" {
+			merged.LogGroup = entry.LogGroup
+		}
+		if merged.LogVolumeClaimTemplate == nil {
+			merged.LogVolumeClaimTemplate = entry.LogVolumeClaimTemplate
+		}
+		if merged.TraceLogDir == "" {
+			merged.TraceLogDir = entry.TraceLogDir
+		}
 	}
 
 	return merged
@@ -1484,13 +2321,44 @@ func (cluster *FoundationDBCluster) MinimumFaultDomains() int {
 
 // DesiredCoordinatorCount returns the number of coordinators to recruit for a cluster.
 func (cluster *FoundationDBCluster) DesiredCoordinatorCount() int {
+	desiredCount := cluster.MinimumCoordinatorCount()
+	if cluster.ShouldAutoTuneCoordinatorCount() && cluster.Status.TunedCoordinatorCount > desiredCount {
+		return cluster.Status.TunedCoordinatorCount
+	}
+
+	return desiredCount
+}
+
+// MinimumCoordinatorCount returns the number of coordinators required for the desired fault tolerance of the cluster.
+func (cluster *FoundationDBCluster) MinimumCoordinatorCount() int {
 	if cluster.Spec.DatabaseConfiguration.UsableRegions > 1 || cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall {
-		return 9
+		return MaximumCoordinatorCount
 	}
 
 	return cluster.MinimumFaultDomains() + cluster.DesiredFaultTolerance()
 }
 
+// GetTunedCoordinatorCount returns the number of coordinators for the provided number of zones that can host a
+// coordinator. The result is the largest odd number of coordinators that fits into the zones, limited by
+// MaximumCoordinatorCount, and never lower than MinimumCoordinatorCount.
+func (cluster *FoundationDBCluster) GetTunedCoordinatorCount(zones int) int {
+	minimumCount := cluster.MinimumCoordinatorCount()
+	tunedCount := zones
+	if tunedCount > MaximumCoordinatorCount {
+		tunedCount = MaximumCoordinatorCount
+	}
+
+	if tunedCount%2 == 0 {
+		tunedCount--
+	}
+
+	if tunedCount < minimumCount {
+		return minimumCount
+	}
+
+	return tunedCount
+}
+
 // CheckReconciliation compares the spec and the status to determine if
 // reconciliation is complete.
 func (cluster *FoundationDBCluster) CheckReconciliation(log logr.Logger) (bool, error) {
@@ -1550,7 +2418,7 @@ func (cluster *FoundationDBCluster) CheckReconciliation(log logr.Logger) (bool,
 				}
 
 				// If there is at least one Pod with a IncorrectPodSpec condition we have to delete/recreate that Pod.
-				if condition.ProcessGroupConditionType == IncorrectPodSpec && cluster.Status.Generations.NeedsPodDeletion == 0 {
+				if (condition.ProcessGroupConditionType == IncorrectPodSpec || condition.ProcessGroupConditionType == IncorrectImage) && cluster.Status.Generations.NeedsPodDeletion == 0 {
 					logger.V(1).Info("Pending restart of fdbserver processes", "state", "NeedsPodDeletion")
 					cluster.Status.Generations.NeedsPodDeletion = cluster.ObjectMeta.Generation
 				}
@@ -1770,12 +2638,13 @@ func (cluster *FoundationDBCluster) GetFullAddress(address string, processNumber
 // separated by commas. If you pass false for primaryOnly, this will return only
 // the primary address.
 func (cluster *FoundationDBCluster) GetFullAddressList(address string, primaryOnly bool, processNumber int) []ProcessAddress {
-	return GetFullAddressList(
+	return getFullAddressList(
 		address,
 		primaryOnly,
 		processNumber,
 		cluster.Status.RequiredAddresses.TLS,
-		cluster.Status.RequiredAddresses.NonTLS)
+		cluster.Status.RequiredAddresses.NonTLS,
+		cluster.GetProcessPortBase())
 }
 
 // HasCoordinators checks whether this connection string matches a set of
@@ -1827,6 +2696,11 @@ type FoundationDBClusterFaultDomain struct {
 	// KCs in the data center. This is only used in the `kubernetes-cluster`
 	// fault domain strategy.
 	ZoneIndex int `json:"zoneIndex,omitempty"`
+
+	// RackKey provides the node label key that holds the rack of a node. If
+	// set, the value of this label on the node a Pod is running on will be
+	// passed to the processes as locality_rack.
+	RackKey string `json:"rackKey,omitempty"`
 }
 
 // ContainerOverrides provides options for customizing a container created by
@@ -2010,6 +2884,17 @@ func (cluster *FoundationDBCluster) GetPublicIPSource() PublicIPSource {
 	return *source
 }
 
+// GetProcessPortBase returns the port of the non-TLS address of the first process in a Pod, the default is 4501.
+func (cluster *FoundationDBCluster) GetProcessPortBase() int {
+	return pointer.IntDeref(cluster.Spec.Routing.ProcessPortBase, DefaultProcessPortBase)
+}
+
+// GetProcessPort returns the expected port for a given process number and the tls setting based on the process
+// port base of the cluster.
+func (cluster *FoundationDBCluster) GetProcessPort(processNumber int, tls bool) int {
+	return GetProcessPortWithBase(cluster.GetProcessPortBase(), processNumber, tls)
+}
+
 // LockOptions provides customization for locking global operations.
 type LockOptions struct {
 	// DisableLocks determines whether we should disable locking entirely.
@@ -2056,6 +2941,15 @@ type RoutingConfig struct {
 	// dual-stack support in your Kubernetes environment.
 	PodIPFamily *int `json:"podIPFamily,omitempty"`
 
+	// DualStack defines if the processes should use an IPv4 and an IPv6 address.
+	// If enabled the processes will get a public address for both IP families,
+	// the family defined in PodIPFamily will be used as the primary address, the
+	// default for the primary address is IPv4.
+	// This feature is only supported in FDB 7.3 or later with the unified image,
+	// and requires dual-stack support in your Kubernetes environment.
+	// +optional
+	DualStack *bool `json:"dualStack,omitempty"`
+
 	// UseDNSInClusterFile determines whether to use DNS names rather than IP
 	// addresses to identify coordinators in the cluster file. This requires
 	// FoundationDB 7.0+.
@@ -2073,6 +2967,15 @@ type RoutingConfig struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
 	DNSDomain *string `json:"dnsDomain,omitempty"`
+
+	// ProcessPortBase defines the port of the non-TLS address of the first process in a Pod. The TLS address
+	// uses the port before the ProcessPortBase and every additional process in the Pod uses the next two ports.
+	// Changing this value for a running cluster will replace all process groups.
+	// The default is 4501.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	ProcessPortBase *int `json:"processPortBase,omitempty"`
 }
 
 // RequiredAddressSet provides settings for which addresses we need to listen
@@ -2200,6 +3103,18 @@ func (cluster *FoundationDBCluster) GetMaxConcurrentAutomaticReplacements() int
 	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacements, 1)
 }
 
+// GetMaxConcurrentReplacementsPerFaultDomain returns the maximum number of automatic replacements that can be ongoing
+// in a single fault domain. If unset, this will return math.MaxInt.
+func (cluster *FoundationDBCluster) GetMaxConcurrentReplacementsPerFaultDomain() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacementsPerFaultDomain, math.MaxInt)
+}
+
+// GetFaultDomainReplacementCooldown returns the duration the operator waits between two automatic replacements in the
+// same fault domain. If no cool-down should be applied this will return 0.
+func (cluster *FoundationDBCluster) GetFaultDomainReplacementCooldown() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.FaultDomainReplacementCooldownSeconds, 0)) * time.Second
+}
+
 // FaultDomainBasedReplacements returns true if the operator is allowed to replace all failed process groups of a
 // fault domain. Default is false
 func (cluster *FoundationDBCluster) FaultDomainBasedReplacements() bool {
@@ -2285,6 +3200,22 @@ func (cluster *FoundationDBCluster) SkipProcessGroup(processGroup *ProcessGroupS
 	return time.Unix(*pendingTime, 0).Add(cluster.GetIgnorePendingPodsDuration()).Before(time.Now())
 }
 
+// IgnoreProcessGroupForAutomation checks if a ProcessGroupStatus should be ignored by the update and replacement
+// automation of the operator.
+func (cluster *FoundationDBCluster) IgnoreProcessGroupForAutomation(processGroup *ProcessGroupStatus) bool {
+	if processGroup == nil || processGroup.ProcessClass != ProcessClassTest {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.IgnoreTestProcessGroups, false)
+}
+
+// ShouldReplaceMismatchedImages returns true if the operator should recreate Pods that run a container image that
+// differs from the image in the Pod spec.
+func (cluster *FoundationDBCluster) ShouldReplaceMismatchedImages() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.ReplaceMismatchedImages, false)
+}
+
 // GetIgnorePendingPodsDuration returns the value of IgnorePendingPodsDuration or 5 minutes if unset.
 func (cluster *FoundationDBCluster) GetIgnorePendingPodsDuration() time.Duration {
 	if cluster.Spec.AutomationOptions.IgnorePendingPodsDuration == 0 {
@@ -2299,11 +3230,62 @@ func (cluster *FoundationDBCluster) GetIgnoreMissingProcessesSeconds() time.Dura
 	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.IgnoreMissingProcessesSeconds, 30)) * time.Second
 }
 
+// GetIgnoreMissingProcessesForExclusionDuration returns the value of IgnoreMissingProcessesForExclusionSeconds or 5
+// minutes if unset.
+func (cluster *FoundationDBCluster) GetIgnoreMissingProcessesForExclusionDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.IgnoreMissingProcessesForExclusionSeconds, 300)) * time.Second
+}
+
 // GetFailedPodDuration returns the value of FailedPodDuration or 5 minutes if unset.
 func (cluster *FoundationDBCluster) GetFailedPodDuration() time.Duration {
 	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.FailedPodDurationSeconds, 300)) * time.Second
 }
 
+// GetReconciliationStalledDuration returns the value of ReconciliationStalledSeconds or 30 minutes if unset.
+func (cluster *FoundationDBCluster) GetReconciliationStalledDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.ReconciliationStalledSeconds, 1800)) * time.Second
+}
+
+// GetReconciliationTimeout returns the maximum duration of a single reconciliation run. If
+// ReconciliationTimeoutSeconds is unset, the provided default will be returned.
+func (cluster *FoundationDBCluster) GetReconciliationTimeout(defaultTimeout time.Duration) time.Duration {
+	if cluster.Spec.AutomationOptions.ReconciliationTimeoutSeconds == nil {
+		return defaultTimeout
+	}
+
+	return time.Duration(*cluster.Spec.AutomationOptions.ReconciliationTimeoutSeconds) * time.Second
+}
+
+// GetMinimumRecoveryTimeForExclusion returns the minimum time in seconds since the last recovery before exclusions are
+// allowed. If MinimumRecoveryTimeForExclusionSeconds is unset, the provided default will be returned.
+func (cluster *FoundationDBCluster) GetMinimumRecoveryTimeForExclusion(defaultValue float64) float64 {
+	if cluster.Spec.AutomationOptions.MinimumRecoveryTimeForExclusionSeconds == nil {
+		return defaultValue
+	}
+
+	return float64(*cluster.Spec.AutomationOptions.MinimumRecoveryTimeForExclusionSeconds)
+}
+
+// GetMinimumRecoveryTimeForInclusion returns the minimum time in seconds since the last recovery before inclusions are
+// allowed. If MinimumRecoveryTimeForInclusionSeconds is unset, the provided default will be returned.
+func (cluster *FoundationDBCluster) GetMinimumRecoveryTimeForInclusion(defaultValue float64) float64 {
+	if cluster.Spec.AutomationOptions.MinimumRecoveryTimeForInclusionSeconds == nil {
+		return defaultValue
+	}
+
+	return float64(*cluster.Spec.AutomationOptions.MinimumRecoveryTimeForInclusionSeconds)
+}
+
+// GetPVCRetentionDuration returns the duration the PVCs of a removed process group should be retained after the Pod
+// was deleted. If PVCs should be deleted directly this will return 0.
+func (cluster *FoundationDBCluster) GetPVCRetentionDuration() time.Duration {
+	if cluster.Spec.AutomationOptions.Removals.PVCRetentionDuration == nil {
+		return 0
+	}
+
+	return cluster.Spec.AutomationOptions.Removals.PVCRetentionDuration.Duration
+}
+
 // GetUseNonBlockingExcludes returns the value of useNonBlockingExcludes or false if unset.
 func (cluster *FoundationDBCluster) GetUseNonBlockingExcludes() bool {
 	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseNonBlockingExcludes, false)
@@ -2389,6 +3371,28 @@ func (cluster *FoundationDBCluster) DefineDNSLocalityFields() bool {
 	return pointer.BoolDeref(cluster.Spec.Routing.DefineDNSLocalityFields, false) || cluster.UseDNSInClusterFile()
 }
 
+// UseRackLocality determines whether the processes should get the rack locality
+// from the node labels.
+func (cluster *FoundationDBCluster) UseRackLocality() bool {
+	return cluster.Spec.FaultDomain.RackKey != ""
+}
+
+// UseMachineIDFromNodeLabel determines whether the processes should get the
+// machine ID from the node labels.
+func (cluster *FoundationDBCluster) UseMachineIDFromNodeLabel() bool {
+	return cluster.Spec.MachineIDSource != nil && cluster.Spec.MachineIDSource.NodeLabel != ""
+}
+
+// GetMachineIDVariable returns the name of the environment variable that
+// holds the machine ID of the processes.
+func (cluster *FoundationDBCluster) GetMachineIDVariable() string {
+	if cluster.Spec.MachineIDSource != nil && cluster.Spec.MachineIDSource.EnvironmentVariable != "" {
+		return cluster.Spec.MachineIDSource.EnvironmentVariable
+	}
+
+	return EnvNameMachineID
+}
+
 // GetDNSDomain gets the domain used when forming DNS names generated for a
 // service.
 func (cluster *FoundationDBCluster) GetDNSDomain() string {
@@ -2430,6 +3434,145 @@ func (cluster *FoundationDBCluster) GetMaintenaceModeTimeoutSeconds() int {
 	return pointer.IntDeref(cluster.Spec.AutomationOptions.MaintenanceModeOptions.MaintenanceModeTimeSeconds, 600)
 }
 
+// ShouldRunDeletionCleanup returns true if the operator should perform the deletion cleanup steps before the cluster
+// resource is deleted.
+func (cluster *FoundationDBCluster) ShouldRunDeletionCleanup() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.DeletionCleanup.Enabled, false)
+}
+
+// ShouldManagePodDisruptionBudgets returns true if the operator should create and maintain the PodDisruptionBudgets
+// for the cluster.
+func (cluster *FoundationDBCluster) ShouldManagePodDisruptionBudgets() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.PodDisruptionBudget.Enabled, false)
+}
+
+// ShouldExcludeFailedProcesses returns true if the operator should exclude the processes of process groups without
+// Pod and PVC with the failed flag.
+func (cluster *FoundationDBCluster) ShouldExcludeFailedProcesses() bool {
+	return cluster.Spec.AutomationOptions.ExcludeFailedProcessesAfterSeconds != nil
+}
+
+// GetExcludeFailedProcessesDuration returns the duration a process group must be without Pod and PVC before the
+// processes are excluded with the failed flag.
+func (cluster *FoundationDBCluster) GetExcludeFailedProcessesDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.ExcludeFailedProcessesAfterSeconds, 0)) * time.Second
+}
+
+// GetMaxConcurrentExclusions returns the maximum number of process groups that can be excluded concurrently. If unset,
+// math.MaxInt will be returned.
+func (cluster *FoundationDBCluster) GetMaxConcurrentExclusions() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.MaxConcurrentExclusions, math.MaxInt)
+}
+
+// GetDeletionCleanupSteps returns the deletion cleanup steps that should be performed. If no steps are defined all
+// steps will be returned.
+func (cluster *FoundationDBCluster) GetDeletionCleanupSteps() []DeletionCleanupStep {
+	if len(cluster.Spec.AutomationOptions.DeletionCleanup.Steps) == 0 {
+		return AllDeletionCleanupSteps()
+	}
+
+	return cluster.Spec.AutomationOptions.DeletionCleanup.Steps
+}
+
+// GetDeletionCleanupTimeout returns the duration after which the deletion cleanup finalizer will be removed, even if
+// the cleanup steps were not successful.
+func (cluster *FoundationDBCluster) GetDeletionCleanupTimeout() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.DeletionCleanup.TimeoutSeconds, 600)) * time.Second
+}
+
+// GetCoordinatorAgeWarningDuration returns the duration after which the operator should recommend to rotate the
+// coordinators. If no warning should be emitted this will return 0.
+func (cluster *FoundationDBCluster) GetCoordinatorAgeWarningDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.CoordinatorAgeWarningSeconds, 0)) * time.Second
+}
+
+// GetReconcileTraceMode returns the value of ReconcileTraceMode or Disabled if unset.
+func (cluster *FoundationDBCluster) GetReconcileTraceMode() ReconcileTraceMode {
+	if cluster.Spec.AutomationOptions.ReconcileTraceMode == nil {
+		return ReconcileTraceModeDisabled
+	}
+
+	return *cluster.Spec.AutomationOptions.ReconcileTraceMode
+}
+
+// ShouldAutoTuneCoordinatorCount returns true if the operator should tune the number of coordinators based on the
+// number of fault domains in the cluster.
+func (cluster *FoundationDBCluster) ShouldAutoTuneCoordinatorCount() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.AutoTuneCoordinatorCount, false)
+}
+
+// ChangeCoordinatorsAfterExclusion returns true if the operator should change the coordinators directly after
+// excluding a process that serves as coordinator.
+func (cluster *FoundationDBCluster) ChangeCoordinatorsAfterExclusion() bool {
+	return cluster.Spec.AutomationOptions.CoordinatorChangeDelayAfterExclusionSeconds != nil
+}
+
+// GetCoordinatorChangeDelayAfterExclusion returns the duration the operator waits after excluding a process that serves
+// as coordinator before changing the coordinators.
+func (cluster *FoundationDBCluster) GetCoordinatorChangeDelayAfterExclusion() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.CoordinatorChangeDelayAfterExclusionSeconds, 0)) * time.Second
+}
+
+// GetConfigMapUpdateDebounceDuration returns the minimum duration between two updates of the cluster config map. If
+// config map updates should not be delayed this will return 0.
+func (cluster *FoundationDBCluster) GetConfigMapUpdateDebounceDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.ConfigMapUpdateDebounceSeconds, 0)) * time.Second
+}
+
+// DeriveMemoryKnobsFromResources returns true if the memory knobs should be derived from the memory limit of the main
+// container.
+func (cluster *FoundationDBCluster) DeriveMemoryKnobsFromResources() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.DeriveMemoryKnobsFromResources, false)
+}
+
+// GetMemoryKnobsHeadroomPercentage returns the percentage of the main container memory limit that should not be used
+// for the derived memory knobs.
+func (cluster *FoundationDBCluster) GetMemoryKnobsHeadroomPercentage() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.MemoryKnobsHeadroomPercentage, 10)
+}
+
+// KillProcessesOnConfigurationChange returns true if fdbmonitor should restart the fdbserver processes when the
+// monitor conf changes.
+func (cluster *FoundationDBCluster) KillProcessesOnConfigurationChange() bool {
+	if cluster.GetUseUnifiedImage() || cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.KillProcessesOnConfigurationChange, false)
+}
+
+// UseSeedClusterFile returns true if the processes should use the seed cluster file from the dynamic config.
+func (cluster *FoundationDBCluster) UseSeedClusterFile() bool {
+	return pointer.BoolDeref(cluster.Spec.UseSeedClusterFile, true)
+}
+
+// UseConnectivityCheck returns true if the connectivity check init container should be added to the Pods.
+func (cluster *FoundationDBCluster) UseConnectivityCheck() bool {
+	return pointer.BoolDeref(cluster.Spec.ConnectivityCheck.Enabled, false)
+}
+
+// GetConnectivityCheckTimeoutSeconds returns the time in seconds the connectivity check init container waits for a
+// reachable coordinator.
+func (cluster *FoundationDBCluster) GetConnectivityCheckTimeoutSeconds() int {
+	return pointer.IntDeref(cluster.Spec.ConnectivityCheck.TimeoutSeconds, 300)
+}
+
+// IsInExclusionMaintenanceWindow returns true if the provided time is inside one of the exclusion maintenance windows.
+func (cluster *FoundationDBCluster) IsInExclusionMaintenanceWindow(now time.Time) (bool, error) {
+	for _, window := range cluster.Spec.AutomationOptions.ExclusionMaintenanceWindows {
+		contains, err := window.Contains(now)
+		if err != nil {
+			return false, err
+		}
+
+		if contains {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // PodUpdateStrategy defines how Pod spec changes should be applied.
 type PodUpdateStrategy string
 
@@ -2840,6 +3983,113 @@ func (cluster *FoundationDBCluster) Validate() error {
 		}
 	}
 
+	if cluster.UseDualStack() {
+		if !version.SupportsDualStackAddresses() {
+			validations = append(validations, fmt.Sprintf("dual-stack addresses are not supported on version %s, minimum supported version is: %s", cluster.Spec.Version, Versions.SupportsDualStackAddresses.String()))
+		}
+
+		if !cluster.GetUseUnifiedImage() {
+			validations = append(validations, "dual-stack addresses are only supported with the unified image")
+		}
+	}
+
+	// Make sure all processes in a Pod will get a valid port assigned.
+	maxProcessesPerPod := cluster.GetStorageServersPerPod()
+	if cluster.GetLogServersPerPod() > maxProcessesPerPod {
+		maxProcessesPerPod = cluster.GetLogServersPerPod()
+	}
+
+	if cluster.GetProcessPort(1, true) < 1 || cluster.GetProcessPort(maxProcessesPerPod, false) > 65535 {
+		validations = append(validations, fmt.Sprintf("process port base %d is not valid, the ports for %d processes per Pod must be between 1 and 65535", cluster.GetProcessPortBase(), maxProcessesPerPod))
+	}
+
+	// Make sure the process specific custom parameters only target existing processes.
+	for processClass, settings := range cluster.Spec.Processes {
+		if len(settings.CustomParametersPerProcess) == 0 {
+			continue
+		}
+
+		if cluster.GetUseUnifiedImage() {
+			validations = append(validations, fmt.Sprintf("customParametersPerProcess for process class %s are only supported with the split image", processClass))
+		}
+
+		serversPerPod := maxProcessesPerPod
+		if processClass != ProcessClassGeneral {
+			serversPerPod = cluster.GetDesiredServersPerPod(processClass)
+		}
+
+		processNumbers := make(map[int]None, len(settings.CustomParametersPerProcess))
+		for _, entry := range settings.CustomParametersPerProcess {
+			if entry.ProcessNumber < 1 || entry.ProcessNumber > serversPerPod {
+				validations = append(validations, fmt.Sprintf("customParametersPerProcess for process class %s defines process number %d, but only %d processes per Pod are configured", processClass, entry.ProcessNumber, serversPerPod))
+			}
+
+			if _, ok := processNumbers[entry.ProcessNumber]; ok {
+				validations = append(validations, fmt.Sprintf("customParametersPerProcess for process class %s defines process number %d multiple times", processClass, entry.ProcessNumber))
+			}
+			processNumbers[entry.ProcessNumber] = None{}
+		}
+	}
+
+	// Make sure the persistent volume claim for the trace logs doesn't use the same name as the data volume claim.
+	for processClass, settings := range cluster.Spec.Processes {
+		if settings.LogVolumeClaimTemplate == nil {
+			continue
+		}
+
+		logVolumeName := "logs"
+		if settings.LogVolumeClaimTemplate.Name != "" {
+			logVolumeName = settings.LogVolumeClaimTemplate.Name
+		}
+
+		dataVolumeName := "data"
+		volumeClaimTemplate := cluster.GetProcessSettings(processClass).VolumeClaimTemplate
+		if processClass == ProcessClassGeneral {
+			volumeClaimTemplate = settings.VolumeClaimTemplate
+		}
+		if volumeClaimTemplate != nil && volumeClaimTemplate.Name != "" {
+			dataVolumeName = volumeClaimTemplate.Name
+		}
+
+		if logVolumeName == dataVolumeName {
+			validations = append(validations, fmt.Sprintf("logVolumeClaimTemplate for process class %s uses the name %s, which is already used by the volumeClaimTemplate", processClass, logVolumeName))
+		}
+	}
+
+	if !cluster.UseSeedClusterFile() && cluster.UseConnectivityCheck() {
+		validations = append(validations, "the connectivity check requires the seed cluster file, useSeedClusterFile must not be disabled")
+	}
+
+	if cluster.Spec.TraceFormat != "" && cluster.Spec.TraceFormat != TraceFormatXML && cluster.Spec.TraceFormat != TraceFormatJSON {
+		validations = append(validations, fmt.Sprintf("trace format %s is not valid, only %s and %s are supported", cluster.Spec.TraceFormat, TraceFormatXML, TraceFormatJSON))
+	}
+
+	if cluster.Spec.Tracing.Tracer != "" && cluster.Spec.Tracing.Tracer != TracerNone && cluster.Spec.Tracing.Tracer != TracerLogFile && cluster.Spec.Tracing.Tracer != TracerNetworkLossy {
+		validations = append(validations, fmt.Sprintf("tracer %s is not valid, only %s, %s and %s are supported", cluster.Spec.Tracing.Tracer, TracerNone, TracerLogFile, TracerNetworkLossy))
+	}
+
+	if cluster.Spec.MachineIDSource != nil && cluster.Spec.MachineIDSource.EnvironmentVariable != "" && cluster.Spec.MachineIDSource.NodeLabel != "" {
+		validations = append(validations, "machine ID source can only define an environment variable or a node label, not both")
+	}
+
+	// Check if the probe overrides are well-formed.
+	validations = append(validations, cluster.validateProbeOverrides()...)
+
+	for _, window := range cluster.Spec.AutomationOptions.ExclusionMaintenanceWindows {
+		_, err := window.Contains(time.Now())
+		if err != nil {
+			validations = append(validations, fmt.Sprintf("exclusion maintenance window is invalid: %s", err.Error()))
+		}
+	}
+
+	if pointer.IntDeref(cluster.Spec.AutomationOptions.MinimumRecoveryTimeForExclusionSeconds, 0) < 0 {
+		validations = append(validations, fmt.Sprintf("minimumRecoveryTimeForExclusionSeconds must not be negative, got %d", *cluster.Spec.AutomationOptions.MinimumRecoveryTimeForExclusionSeconds))
+	}
+
+	if pointer.IntDeref(cluster.Spec.AutomationOptions.MinimumRecoveryTimeForInclusionSeconds, 0) < 0 {
+		validations = append(validations, fmt.Sprintf("minimumRecoveryTimeForInclusionSeconds must not be negative, got %d", *cluster.Spec.AutomationOptions.MinimumRecoveryTimeForInclusionSeconds))
+	}
+
 	if len(validations) == 0 {
 		return nil
 	}
@@ -2847,6 +4097,130 @@ func (cluster *FoundationDBCluster) Validate() error {
 	return fmt.Errorf(strings.Join(validations, ", "))
 }
 
+// GetValidationWarnings returns the issues of the cluster spec that are not severe enough to stop the reconciliation,
+// e.g. a termination grace period that is shorter than the restart delay used in the monitor conf. In this case a
+// process could be restarted by fdbmonitor while the Pod is being shut down.
+func (cluster *FoundationDBCluster) GetValidationWarnings() []string {
+	restartDelay := cluster.GetRestartDelaySeconds()
+	processClasses := make([]ProcessClass, 0, len(cluster.Spec.Processes))
+	for processClass := range cluster.Spec.Processes {
+		processClasses = append(processClasses, processClass)
+	}
+
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	var warnings []string
+	for _, processClass := range processClasses {
+		podTemplate := cluster.Spec.Processes[processClass].PodTemplate
+		if podTemplate == nil || podTemplate.Spec.TerminationGracePeriodSeconds == nil {
+			continue
+		}
+
+		gracePeriod := *podTemplate.Spec.TerminationGracePeriodSeconds
+		if gracePeriod < int64(restartDelay) {
+			warnings = append(warnings, fmt.Sprintf("termination grace period of %d seconds for process class %s is shorter than the restart delay of %d seconds", gracePeriod, processClass, restartDelay))
+		}
+	}
+
+	return warnings
+}
+
+// validateProbeOverrides checks that the probe overrides of each process class are well-formed and don't replace the
+// probes of the containers that are managed by the operator.
+func (cluster *FoundationDBCluster) validateProbeOverrides() []string {
+	processClasses := make([]ProcessClass, 0, len(cluster.Spec.Processes))
+	for processClass := range cluster.Spec.Processes {
+		processClasses = append(processClasses, processClass)
+	}
+
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	var validations []string
+	for _, processClass := range processClasses {
+		containerNames := map[string]None{}
+		for _, override := range cluster.Spec.Processes[processClass].ProbeOverrides {
+			if override.ContainerName == "" {
+				validations = append(validations, fmt.Sprintf("probe override for process class %s has no container name", processClass))
+				continue
+			}
+
+			if _, ok := containerNames[override.ContainerName]; ok {
+				validations = append(validations, fmt.Sprintf("probe override for process class %s defines container %s multiple times", processClass, override.ContainerName))
+				continue
+			}
+			containerNames[override.ContainerName] = None{}
+
+			if override.ContainerName == SidecarContainerName || override.ContainerName == InitContainerName {
+				validations = append(validations, fmt.Sprintf("probe override for process class %s must not change the probes of the operator managed container %s", processClass, override.ContainerName))
+				continue
+			}
+
+			probes := []struct {
+				probeType string
+				probe     *corev1.Probe
+			}{
+				{probeType: "liveness", probe: override.LivenessProbe},
+				{probeType: "readiness", probe: override.ReadinessProbe},
+				{probeType: "startup", probe: override.StartupProbe},
+			}
+
+			for _, entry := range probes {
+				// Only readiness probes are allowed to require more than one success.
+				err := validateProbe(entry.probe, entry.probeType != "readiness")
+				if err != nil {
+					validations = append(validations, fmt.Sprintf("%s probe override for container %s of process class %s is invalid: %s", entry.probeType, override.ContainerName, processClass, err.Error()))
+				}
+			}
+		}
+	}
+
+	return validations
+}
+
+// validateProbe checks if the probe defines exactly one handler and has valid thresholds.
+func validateProbe(probe *corev1.Probe, requiresSingleSuccess bool) error {
+	if probe == nil {
+		return nil
+	}
+
+	handlers := 0
+	if probe.Exec != nil {
+		handlers++
+	}
+	if probe.HTTPGet != nil {
+		handlers++
+	}
+	if probe.TCPSocket != nil {
+		handlers++
+	}
+	if probe.GRPC != nil {
+		handlers++
+	}
+
+	if handlers != 1 {
+		return fmt.Errorf("exactly one handler must be defined but found %d", handlers)
+	}
+
+	if probe.InitialDelaySeconds < 0 || probe.TimeoutSeconds < 0 || probe.PeriodSeconds < 0 || probe.SuccessThreshold < 0 || probe.FailureThreshold < 0 {
+		return fmt.Errorf("probe settings must not be negative")
+	}
+
+	if requiresSingleSuccess && probe.SuccessThreshold > 1 {
+		return fmt.Errorf("success threshold must be 1")
+	}
+
+	return nil
+}
+
+// GetRestartDelaySeconds returns the restart delay in seconds that is used by fdbmonitor to restart processes.
+func (cluster *FoundationDBCluster) GetRestartDelaySeconds() int {
+	return pointer.IntDeref(cluster.Spec.FdbMonitor.RestartDelaySeconds, 60)
+}
+
 // IsTaintFeatureDisabled return true if operator is configured to not replace Pods tainted Nodes OR
 // if operator's TaintReplacementOptions is not set.
 func (cluster *FoundationDBCluster) IsTaintFeatureDisabled() bool {
@@ -2865,6 +4239,42 @@ func (cluster *FoundationDBCluster) CacheDatabaseStatusForReconciliation(default
 	return pointer.BoolDeref(cluster.Spec.AutomationOptions.CacheDatabaseStatusForReconciliation, defaultValue)
 }
 
+// ShouldRestartIncompatibleProcesses returns the value of RestartIncompatibleProcesses or the provided default value if
+// unset.
+func (cluster *FoundationDBCluster) ShouldRestartIncompatibleProcesses(defaultValue bool) bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.RestartIncompatibleProcesses, defaultValue)
+}
+
+// GetLogGroup returns the log group for the trace logs of the cluster. If no log group is defined the cluster name will
+// be used, prefixed with the configured prefix or the namespace if AutomaticLogGroupPrefix is enabled.
+func (cluster *FoundationDBCluster) GetLogGroup() string {
+	if cluster.Spec.LogGroup != "" {
+		return cluster.Spec.LogGroup
+	}
+
+	if !pointer.BoolDeref(cluster.Spec.AutomaticLogGroupPrefix, false) {
+		return cluster.Name
+	}
+
+	prefix := cluster.Spec.LogGroupPrefix
+	if prefix == "" {
+		prefix = cluster.Namespace
+	}
+
+	return prefix + "." + cluster.Name
+}
+
+// GetLogGroupForProcessClass returns the log group for the trace logs of the processes of the provided process class.
+// If the process settings define no log group, the log group of the cluster will be used.
+func (cluster *FoundationDBCluster) GetLogGroupForProcessClass(processClass ProcessClass) string {
+	logGroup := cluster.GetProcessSettings(processClass).LogGroup
+	if logGroup != "" {
+		return logGroup
+	}
+
+	return cluster.GetLogGroup()
+}
+
 // GetIgnoreLogGroupsForUpgrade will return the IgnoreLogGroupsForUpgrade, if the value is not set it will include the default `fdb-kubernetes-operator`
 // LogGroup.
 func (cluster *FoundationDBCluster) GetIgnoreLogGroupsForUpgrade() []LogGroup {
@@ -2932,6 +4342,29 @@ func (cluster *FoundationDBCluster) GetProcessGroupID(processClass ProcessClass,
 	return fmt.Sprintf("%s-%s-%d", cluster.Name, processClass.GetProcessClassForPodName(), idNum), processGroupID
 }
 
+// UseDualStack determines whether the processes should use an IPv4 and an IPv6 address.
+func (cluster *FoundationDBCluster) UseDualStack() bool {
+	return pointer.BoolDeref(cluster.Spec.Routing.DualStack, false)
+}
+
+// GetIPFamilies returns the IP families that should be used for the addresses of the processes. The first entry is
+// the family of the primary address. If no IP family is specified, nil will be returned.
+func (cluster *FoundationDBCluster) GetIPFamilies() []int {
+	if cluster.UseDualStack() {
+		if cluster.IsPodIPFamily6() {
+			return []int{6, 4}
+		}
+
+		return []int{4, 6}
+	}
+
+	if cluster.Spec.Routing.PodIPFamily == nil {
+		return nil
+	}
+
+	return []int{*cluster.Spec.Routing.PodIPFamily}
+}
+
 // IsPodIPFamily6 determines whether the podIPFamily setting in cluster is set to use the IPv6 family.
 func (cluster *FoundationDBCluster) IsPodIPFamily6() bool {
 	return pointer.IntDeref(cluster.Spec.Routing.PodIPFamily, 4) == 6
@@ -31,6 +31,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -674,6 +675,49 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 		})
 	})
 
+	DescribeTable("getting the tuned coordinator count", func(redundancyMode RedundancyMode, zones int, expected int) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				DatabaseConfiguration: DatabaseConfiguration{
+					RedundancyMode: redundancyMode,
+				},
+			},
+		}
+
+		Expect(cluster.GetTunedCoordinatorCount(zones)).To(Equal(expected))
+	},
+		Entry("double redundancy with 3 zones", RedundancyModeDouble, 3, 3),
+		Entry("double redundancy with 4 zones", RedundancyModeDouble, 4, 3),
+		Entry("double redundancy with 5 zones", RedundancyModeDouble, 5, 5),
+		Entry("double redundancy with 2 zones", RedundancyModeDouble, 2, 3),
+		Entry("double redundancy with 20 zones", RedundancyModeDouble, 20, 9),
+		Entry("triple redundancy with 6 zones", RedundancyModeTriple, 6, 5),
+		Entry("triple redundancy with 8 zones", RedundancyModeTriple, 8, 7),
+	)
+
+	When("the coordinator count is tuned", func() {
+		var cluster *FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					DatabaseConfiguration: DatabaseConfiguration{
+						RedundancyMode: RedundancyModeDouble,
+					},
+				},
+				Status: FoundationDBClusterStatus{
+					TunedCoordinatorCount: 5,
+				},
+			}
+		})
+
+		It("should only use the tuned count if the automatic tuning is enabled", func() {
+			Expect(cluster.DesiredCoordinatorCount()).To(Equal(3))
+			cluster.Spec.AutomationOptions.AutoTuneCoordinatorCount = pointer.Bool(true)
+			Expect(cluster.DesiredCoordinatorCount()).To(Equal(5))
+		})
+	})
+
 	When("parsing the backup status for 6.2", func() {
 		It("should be parsed correctly", func() {
 			statusFile, err := os.OpenFile(filepath.Join("testdata", "fdbbackup_status_6_2.json"), os.O_RDONLY, os.ModePerm)
@@ -684,6 +728,7 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			err = statusDecoder.Decode(&status)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(status).To(Equal(FoundationDBLiveBackupStatus{
+				Tag:                     "default",
 				DestinationURL:          "blobstore://minio@minio-service:9000/sample-cluster?bucket=fdb-backups",
 				SnapshotIntervalSeconds: 864000,
 				Status: FoundationDBLiveBackupStatusState{
@@ -3580,6 +3625,85 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 		})
 	})
 
+	DescribeTable("getting the custom parameters for a process",
+		func(processNumber int, expected FoundationDBCustomParameters) {
+			settings := ProcessSettings{
+				CustomParameters: FoundationDBCustomParameters{
+					"knob_cache_memory = 1000",
+					"knob_disable_posix_kernel_aio = 1",
+				},
+				CustomParametersPerProcess: []ProcessCustomParameters{
+					{
+						ProcessNumber: 1,
+						CustomParameters: FoundationDBCustomParameters{
+							"knob_cache_memory = 2000",
+							"knob_test = 1",
+						},
+					},
+				},
+			}
+
+			Expect(settings.GetCustomParametersForProcess(processNumber)).To(Equal(expected))
+		},
+		Entry("process with specific parameters",
+			1,
+			FoundationDBCustomParameters{
+				"knob_disable_posix_kernel_aio = 1",
+				"knob_cache_memory = 2000",
+				"knob_test = 1",
+			},
+		),
+		Entry("process without specific parameters",
+			2,
+			FoundationDBCustomParameters{
+				"knob_cache_memory = 1000",
+				"knob_disable_posix_kernel_aio = 1",
+			},
+		),
+		Entry("no process number",
+			0,
+			FoundationDBCustomParameters{
+				"knob_cache_memory = 1000",
+				"knob_disable_posix_kernel_aio = 1",
+			},
+		),
+	)
+
+	DescribeTable("getting the IP families",
+		func(routing RoutingConfig, expected []int) {
+			cluster := &FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Routing: routing,
+				},
+			}
+
+			Expect(cluster.GetIPFamilies()).To(Equal(expected))
+		},
+		Entry("no IP family is defined",
+			RoutingConfig{},
+			nil,
+		),
+		Entry("IPv6 is defined",
+			RoutingConfig{
+				PodIPFamily: pointer.Int(6),
+			},
+			[]int{6},
+		),
+		Entry("dual-stack is enabled",
+			RoutingConfig{
+				DualStack: pointer.Bool(true),
+			},
+			[]int{4, 6},
+		),
+		Entry("dual-stack is enabled with IPv6 as pod IP family",
+			RoutingConfig{
+				PodIPFamily: pointer.Int(6),
+				DualStack:   pointer.Bool(true),
+			},
+			[]int{6, 4},
+		),
+	)
+
 	When("getting the process address and port", func() {
 		type testCase struct {
 			processNumber int
@@ -3616,6 +3740,40 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 					4503,
 				}),
 		)
+
+		When("a custom process port base is defined", func() {
+			var cluster *FoundationDBCluster
+
+			BeforeEach(func() {
+				cluster = &FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Routing: RoutingConfig{
+							ProcessPortBase: pointer.Int(5001),
+						},
+					},
+					Status: FoundationDBClusterStatus{
+						RequiredAddresses: RequiredAddressSet{
+							TLS:    true,
+							NonTLS: true,
+						},
+					},
+				}
+			})
+
+			It("should generate the ports based on the process port base", func() {
+				Expect(cluster.GetProcessPort(1, true)).To(Equal(5000))
+				Expect(cluster.GetProcessPort(1, false)).To(Equal(5001))
+				Expect(cluster.GetProcessPort(2, true)).To(Equal(5002))
+				Expect(cluster.GetProcessPort(2, false)).To(Equal(5003))
+			})
+
+			It("should generate the addresses based on the process port base", func() {
+				Expect(cluster.GetFullAddressList("1.1.1.1", false, 2)).To(ConsistOf(
+					ProcessAddress{IPAddress: net.ParseIP("1.1.1.1"), Port: 5002, Flags: map[string]bool{"tls": true}},
+					ProcessAddress{IPAddress: net.ParseIP("1.1.1.1"), Port: 5003},
+				))
+			})
+		})
 	})
 
 	When("adding StorageServerPerDisk", func() {
@@ -3659,6 +3817,35 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 		)
 	})
 
+	When("adding recent inclusions", func() {
+		var status FoundationDBClusterStatus
+
+		BeforeEach(func() {
+			status = FoundationDBClusterStatus{}
+		})
+
+		It("should append the inclusions", func() {
+			status.AddRecentInclusions(InclusionRecord{Address: "1.1.1.1"}, InclusionRecord{Address: "1.1.1.2"})
+			status.AddRecentInclusions(InclusionRecord{Address: "1.1.1.3"})
+			Expect(status.RecentInclusions).To(Equal([]InclusionRecord{{Address: "1.1.1.1"}, {Address: "1.1.1.2"}, {Address: "1.1.1.3"}}))
+		})
+
+		When("the maximum number of inclusions is exceeded", func() {
+			BeforeEach(func() {
+				for i := 0; i < MaxRecentInclusions; i++ {
+					status.AddRecentInclusions(InclusionRecord{Address: fmt.Sprintf("1.1.1.%d", i)})
+				}
+				status.AddRecentInclusions(InclusionRecord{Address: "1.1.2.1"})
+			})
+
+			It("should drop the oldest inclusions", func() {
+				Expect(status.RecentInclusions).To(HaveLen(MaxRecentInclusions))
+				Expect(status.RecentInclusions[0].Address).To(Equal("1.1.1.1"))
+				Expect(status.RecentInclusions[MaxRecentInclusions-1].Address).To(Equal("1.1.2.1"))
+			})
+		})
+	})
+
 	When("adding LogServersPerDisk", func() {
 		type testCase struct {
 			ValuesToAdd               []int
@@ -4133,6 +4320,17 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			})
 		})
 
+		Context("with a process group that has a failed PVC", func() {
+			BeforeEach(func() {
+				processGroup.UpdateCondition(PVCFailed, true)
+			})
+
+			It("should need replacement without waiting for the failure window", func() {
+				Expect(failureTime).NotTo(BeZero())
+				Expect(failureCondition).To(Equal(PVCFailed))
+			})
+		})
+
 		Context("with a process group that failed", func() {
 			BeforeEach(func() {
 				processGroup.UpdateCondition(PodFailing, true)
@@ -4816,6 +5014,382 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 				nil,
 			),
+			Entry("using an invalid exclusion maintenance window",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							ExclusionMaintenanceWindows: []MaintenanceWindow{
+								{
+									Start: "09:00",
+									End:   "17:00",
+									Days:  []string{"Someday"},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("exclusion maintenance window is invalid: Someday is not a valid day of the week"),
+			),
+			Entry("using a negative minimum recovery time for exclusions and inclusions",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							MinimumRecoveryTimeForExclusionSeconds: pointer.Int(-1),
+							MinimumRecoveryTimeForInclusionSeconds: pointer.Int(-10),
+						},
+					},
+				},
+				fmt.Errorf("minimumRecoveryTimeForExclusionSeconds must not be negative, got -1, minimumRecoveryTimeForInclusionSeconds must not be negative, got -10"),
+			),
+			Entry("using a valid probe override",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								ProbeOverrides: []ContainerProbeOverride{
+									{
+										ContainerName: MainContainerName,
+										LivenessProbe: &corev1.Probe{
+											ProbeHandler: corev1.ProbeHandler{
+												TCPSocket: &corev1.TCPSocketAction{
+													Port: intstr.FromInt(4501),
+												},
+											},
+											SuccessThreshold: 1,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using a probe override for the sidecar container",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								ProbeOverrides: []ContainerProbeOverride{
+									{
+										ContainerName: SidecarContainerName,
+										ReadinessProbe: &corev1.Probe{
+											ProbeHandler: corev1.ProbeHandler{
+												TCPSocket: &corev1.TCPSocketAction{
+													Port: intstr.FromInt(8080),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("probe override for process class storage must not change the probes of the operator managed container foundationdb-kubernetes-sidecar"),
+			),
+			Entry("using a probe override without a handler",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								ProbeOverrides: []ContainerProbeOverride{
+									{
+										ContainerName: MainContainerName,
+										LivenessProbe: &corev1.Probe{
+											FailureThreshold: 3,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("liveness probe override for container foundationdb of process class storage is invalid: exactly one handler must be defined but found 0"),
+			),
+			Entry("using a termination grace period that is longer than a custom restart delay",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						FdbMonitor: FdbMonitorSettings{
+							RestartDelaySeconds: pointer.Int(10),
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								PodTemplate: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										TerminationGracePeriodSeconds: pointer.Int64(30),
+									},
+								},
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using a process port base that exceeds the port range",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						StorageServersPerPod: 2,
+						Routing: RoutingConfig{
+							ProcessPortBase: pointer.Int(65534),
+						},
+					},
+				},
+				fmt.Errorf("process port base 65534 is not valid, the ports for 2 processes per Pod must be between 1 and 65535"),
+			),
+			Entry("using dual-stack addresses with the unified image",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.SupportsDualStackAddresses.String(),
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						UseUnifiedImage: pointer.Bool(true),
+						Routing: RoutingConfig{
+							DualStack: pointer.Bool(true),
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using dual-stack addresses with the split image and an unsupported version",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.57",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Routing: RoutingConfig{
+							DualStack: pointer.Bool(true),
+						},
+					},
+				},
+				fmt.Errorf("dual-stack addresses are not supported on version 7.1.57, minimum supported version is: 7.3.0, dual-stack addresses are only supported with the unified image"),
+			),
+			Entry("using custom parameters for an existing process",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						StorageServersPerPod: 2,
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								CustomParametersPerProcess: []ProcessCustomParameters{
+									{
+										ProcessNumber:    2,
+										CustomParameters: FoundationDBCustomParameters{"knob_cache_memory = 2000"},
+									},
+								},
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using custom parameters for a process number larger than the servers per pod",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								CustomParametersPerProcess: []ProcessCustomParameters{
+									{
+										ProcessNumber:    2,
+										CustomParameters: FoundationDBCustomParameters{"knob_cache_memory = 2000"},
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("customParametersPerProcess for process class storage defines process number 2, but only 1 processes per Pod are configured"),
+			),
+			Entry("using custom parameters for a process with the unified image",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						UseUnifiedImage: pointer.Bool(true),
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								CustomParametersPerProcess: []ProcessCustomParameters{
+									{
+										ProcessNumber:    1,
+										CustomParameters: FoundationDBCustomParameters{"knob_cache_memory = 2000"},
+									},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("customParametersPerProcess for process class storage are only supported with the split image"),
+			),
+			Entry("using a log volume claim template",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassLog: {
+								LogVolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using a log volume claim template with the same name as the volume claim template",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassLog: {
+								LogVolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+									ObjectMeta: metav1.ObjectMeta{Name: "data"},
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf("logVolumeClaimTemplate for process class log uses the name data, which is already used by the volumeClaimTemplate"),
+			),
+			Entry("using the connectivity check without the seed cluster file",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						UseSeedClusterFile: pointer.Bool(false),
+						ConnectivityCheck: ConnectivityCheckOptions{
+							Enabled: pointer.Bool(true),
+						},
+					},
+				},
+				fmt.Errorf("the connectivity check requires the seed cluster file, useSeedClusterFile must not be disabled"),
+			),
+			Entry("using a valid trace format",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						TraceFormat: TraceFormatJSON,
+					},
+				},
+				nil,
+			),
+			Entry("using an invalid trace format",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.2",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						TraceFormat: "yaml",
+					},
+				},
+				fmt.Errorf("trace format yaml is not valid, only xml and json are supported"),
+			),
+			Entry("using a valid tracer",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Tracing: TracingOptions{
+							Tracer:             TracerNetworkLossy,
+							UDPListenerAddress: "127.0.0.1:8889",
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using an invalid tracer",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						Tracing: TracingOptions{
+							Tracer: "jaeger",
+						},
+					},
+				},
+				fmt.Errorf("tracer jaeger is not valid, only none, log_file and network_lossy are supported"),
+			),
+			Entry("using a machine ID source with a node label",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						MachineIDSource: &MachineIDSource{
+							NodeLabel: "kubernetes.io/hostname",
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using a machine ID source with a node label and an environment variable",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "7.1.26",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						MachineIDSource: &MachineIDSource{
+							EnvironmentVariable: "MACHINE_ID",
+							NodeLabel:           "kubernetes.io/hostname",
+						},
+					},
+				},
+				fmt.Errorf("machine ID source can only define an environment variable or a node label, not both"),
+			),
 			Entry("using valid coordinator selection",
 				&FoundationDBCluster{
 					Spec: FoundationDBClusterSpec{
@@ -5407,6 +5981,39 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			}),
 	)
 
+	When("marking the exclusion of a process group as started", func() {
+		It("should only set the timestamp once", func() {
+			processGroup := &ProcessGroupStatus{}
+			processGroup.MarkExclusionStarted()
+			Expect(processGroup.ExclusionStartedTimestamp.IsZero()).To(BeFalse())
+
+			startedTimestamp := processGroup.ExclusionStartedTimestamp.DeepCopy()
+			processGroup.MarkExclusionStarted()
+			Expect(processGroup.ExclusionStartedTimestamp).To(Equal(startedTimestamp))
+		})
+	})
+
+	DescribeTable("getting the exclusion duration of a process group", func(processGroup *ProcessGroupStatus, expected time.Duration) {
+		Expect(processGroup.GetExclusionDuration()).To(BeNumerically("~", expected, time.Second))
+	},
+		Entry("the exclusion was not started",
+			&ProcessGroupStatus{
+				ExclusionTimestamp: &metav1.Time{Time: time.Now()},
+			},
+			time.Duration(0)),
+		Entry("the exclusion is ongoing",
+			&ProcessGroupStatus{
+				ExclusionStartedTimestamp: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			},
+			10*time.Minute),
+		Entry("the exclusion is completed",
+			&ProcessGroupStatus{
+				ExclusionStartedTimestamp: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+				ExclusionTimestamp:        &metav1.Time{Time: time.Now().Add(-1 * time.Hour)},
+			},
+			time.Hour),
+	)
+
 	When("getting the ID number from the process group ID", func() {
 		Context("with a storage ID", func() {
 			It("can parse the ID", func() {
@@ -5656,4 +6263,300 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			})
 		})
 	})
+
+	DescribeTable("checking if a time is inside a maintenance window", func(window MaintenanceWindow, now time.Time, expected bool, expectedErr bool) {
+		contains, err := window.Contains(now)
+		if expectedErr {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contains).To(Equal(expected))
+	},
+		Entry("time inside the window",
+			MaintenanceWindow{Start: "09:00", End: "17:00"},
+			time.Date(2024, 5, 13, 10, 30, 0, 0, time.UTC),
+			true,
+			false,
+		),
+		Entry("time after the window",
+			MaintenanceWindow{Start: "09:00", End: "17:00"},
+			time.Date(2024, 5, 13, 18, 0, 0, 0, time.UTC),
+			false,
+			false,
+		),
+		Entry("time at the end of the window",
+			MaintenanceWindow{Start: "09:00", End: "17:00"},
+			time.Date(2024, 5, 13, 17, 0, 0, 0, time.UTC),
+			false,
+			false,
+		),
+		Entry("window covering the whole day",
+			MaintenanceWindow{Start: "00:00", End: "00:00"},
+			time.Date(2024, 5, 13, 17, 0, 0, 0, time.UTC),
+			true,
+			false,
+		),
+		Entry("time inside the window on a matching day",
+			MaintenanceWindow{Start: "09:00", End: "17:00", Days: []string{"Monday"}},
+			time.Date(2024, 5, 13, 10, 30, 0, 0, time.UTC),
+			true,
+			false,
+		),
+		Entry("time inside the window on a different day",
+			MaintenanceWindow{Start: "09:00", End: "17:00", Days: []string{"Tuesday"}},
+			time.Date(2024, 5, 13, 10, 30, 0, 0, time.UTC),
+			false,
+			false,
+		),
+		Entry("time inside a window that spans midnight before midnight",
+			MaintenanceWindow{Start: "22:00", End: "06:00", Days: []string{"Monday"}},
+			time.Date(2024, 5, 13, 23, 0, 0, 0, time.UTC),
+			true,
+			false,
+		),
+		Entry("time inside a window that spans midnight after midnight",
+			MaintenanceWindow{Start: "22:00", End: "06:00", Days: []string{"Monday"}},
+			time.Date(2024, 5, 14, 5, 0, 0, 0, time.UTC),
+			true,
+			false,
+		),
+		Entry("time inside a window that spans midnight and started on a different day",
+			MaintenanceWindow{Start: "22:00", End: "06:00", Days: []string{"Monday"}},
+			time.Date(2024, 5, 13, 5, 0, 0, 0, time.UTC),
+			false,
+			false,
+		),
+		Entry("time inside the window in a different time zone",
+			MaintenanceWindow{Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"},
+			time.Date(2024, 5, 13, 7, 30, 0, 0, time.UTC),
+			true,
+			false,
+		),
+		Entry("time outside the window in a different time zone",
+			MaintenanceWindow{Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"},
+			time.Date(2024, 5, 13, 15, 30, 0, 0, time.UTC),
+			false,
+			false,
+		),
+		Entry("invalid time zone",
+			MaintenanceWindow{Start: "09:00", End: "17:00", TimeZone: "Invalid/Zone"},
+			time.Date(2024, 5, 13, 10, 30, 0, 0, time.UTC),
+			false,
+			true,
+		),
+		Entry("invalid day",
+			MaintenanceWindow{Start: "09:00", End: "17:00", Days: []string{"Someday"}},
+			time.Date(2024, 5, 13, 10, 30, 0, 0, time.UTC),
+			false,
+			true,
+		),
+	)
+
+	DescribeTable("getting the log group", func(spec FoundationDBClusterSpec, expected string) {
+		cluster := &FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample-cluster",
+				Namespace: "sample-ns",
+			},
+			Spec: spec,
+		}
+
+		Expect(cluster.GetLogGroup()).To(Equal(expected))
+	},
+		Entry("no log group is defined",
+			FoundationDBClusterSpec{},
+			"sample-cluster",
+		),
+		Entry("a log group is defined",
+			FoundationDBClusterSpec{
+				LogGroup: "custom",
+			},
+			"custom",
+		),
+		Entry("the automatic prefix is enabled",
+			FoundationDBClusterSpec{
+				AutomaticLogGroupPrefix: pointer.Bool(true),
+			},
+			"sample-ns.sample-cluster",
+		),
+		Entry("the automatic prefix is enabled with a custom prefix",
+			FoundationDBClusterSpec{
+				AutomaticLogGroupPrefix: pointer.Bool(true),
+				LogGroupPrefix:          "prod",
+			},
+			"prod.sample-cluster",
+		),
+		Entry("the automatic prefix is enabled and a log group is defined",
+			FoundationDBClusterSpec{
+				AutomaticLogGroupPrefix: pointer.Bool(true),
+				LogGroup:                "custom",
+			},
+			"custom",
+		),
+		Entry("only the prefix is defined",
+			FoundationDBClusterSpec{
+				LogGroupPrefix: "prod",
+			},
+			"sample-cluster",
+		),
+	)
+
+	DescribeTable("getting the log group for a process class", func(spec FoundationDBClusterSpec, processClass ProcessClass, expected string) {
+		cluster := &FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample-cluster",
+				Namespace: "sample-ns",
+			},
+			Spec: spec,
+		}
+
+		Expect(cluster.GetLogGroupForProcessClass(processClass)).To(Equal(expected))
+	},
+		Entry("no log group is defined",
+			FoundationDBClusterSpec{},
+			ProcessClassStorage,
+			"sample-cluster",
+		),
+		Entry("only a cluster log group is defined",
+			FoundationDBClusterSpec{
+				LogGroup: "custom",
+			},
+			ProcessClassStorage,
+			"custom",
+		),
+		Entry("a log group is defined for the process class",
+			FoundationDBClusterSpec{
+				LogGroup: "custom",
+				Processes: map[ProcessClass]ProcessSettings{
+					ProcessClassStorage: {LogGroup: "storage"},
+				},
+			},
+			ProcessClassStorage,
+			"storage",
+		),
+		Entry("a log group is defined for another process class",
+			FoundationDBClusterSpec{
+				LogGroup: "custom",
+				Processes: map[ProcessClass]ProcessSettings{
+					ProcessClassStorage: {LogGroup: "storage"},
+				},
+			},
+			ProcessClassStateless,
+			"custom",
+		),
+		Entry("a log group is defined for the general process class",
+			FoundationDBClusterSpec{
+				Processes: map[ProcessClass]ProcessSettings{
+					ProcessClassGeneral: {LogGroup: "general"},
+				},
+			},
+			ProcessClassLog,
+			"general",
+		),
+	)
+
+	DescribeTable("getting the minimum recovery time for exclusions and inclusions", func(automationOptions FoundationDBClusterAutomationOptions, expectedExclusion float64, expectedInclusion float64) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				AutomationOptions: automationOptions,
+			},
+		}
+
+		Expect(cluster.GetMinimumRecoveryTimeForExclusion(120.0)).To(BeNumerically("==", expectedExclusion))
+		Expect(cluster.GetMinimumRecoveryTimeForInclusion(600.0)).To(BeNumerically("==", expectedInclusion))
+	},
+		Entry("no overrides are defined",
+			FoundationDBClusterAutomationOptions{},
+			120.0,
+			600.0,
+		),
+		Entry("both overrides are defined",
+			FoundationDBClusterAutomationOptions{
+				MinimumRecoveryTimeForExclusionSeconds: pointer.Int(10),
+				MinimumRecoveryTimeForInclusionSeconds: pointer.Int(20),
+			},
+			10.0,
+			20.0,
+		),
+		Entry("the overrides are set to 0",
+			FoundationDBClusterAutomationOptions{
+				MinimumRecoveryTimeForExclusionSeconds: pointer.Int(0),
+				MinimumRecoveryTimeForInclusionSeconds: pointer.Int(0),
+			},
+			0.0,
+			0.0,
+		),
+	)
+
+	DescribeTable("getting the PVC retention duration", func(removals RemovalOptions, expected time.Duration) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				AutomationOptions: FoundationDBClusterAutomationOptions{
+					Removals: removals,
+				},
+			},
+		}
+
+		Expect(cluster.GetPVCRetentionDuration()).To(Equal(expected))
+	},
+		Entry("no retention is defined",
+			RemovalOptions{},
+			time.Duration(0),
+		),
+		Entry("a retention is defined",
+			RemovalOptions{
+				PVCRetentionDuration: &metav1.Duration{Duration: 24 * time.Hour},
+			},
+			24*time.Hour,
+		),
+	)
+
+	DescribeTable("getting the validation warnings", func(processes map[ProcessClass]ProcessSettings, expected []string) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				Version: "6.3.2",
+				DatabaseConfiguration: DatabaseConfiguration{
+					StorageEngine: StorageEngineSSD2,
+				},
+				Processes: processes,
+			},
+		}
+
+		Expect(cluster.Validate()).NotTo(HaveOccurred())
+		Expect(cluster.GetValidationWarnings()).To(Equal(expected))
+	},
+		Entry("using a sufficient termination grace period",
+			map[ProcessClass]ProcessSettings{
+				ProcessClassGeneral: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							TerminationGracePeriodSeconds: pointer.Int64(120),
+						},
+					},
+				},
+			},
+			nil,
+		),
+		Entry("using an insufficient termination grace period",
+			map[ProcessClass]ProcessSettings{
+				ProcessClassGeneral: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							TerminationGracePeriodSeconds: pointer.Int64(120),
+						},
+					},
+				},
+				ProcessClassStorage: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							TerminationGracePeriodSeconds: pointer.Int64(30),
+						},
+					},
+				},
+			},
+			[]string{"termination grace period of 30 seconds for process class storage is shorter than the restart delay of 60 seconds"},
+		),
+	)
 })
@@ -17,7 +17,14 @@ limitations under the License.
 package v1beta2
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 // +kubebuilder:object:root=true
@@ -53,18 +60,174 @@ type FoundationDBRestoreSpec struct {
 	// The key ranges to restore.
 	KeyRanges []FoundationDBKeyRange `json:"keyRanges,omitempty"`
 
+	// RestoreVersion defines the version of the backup data that should be
+	// restored. If neither the RestoreVersion nor the RestoreTimestamp is
+	// defined, the latest restorable version will be restored.
+	// +optional
+	RestoreVersion *int64 `json:"restoreVersion,omitempty"`
+
+	// RestoreTimestamp defines the point in time of the backup data that
+	// should be restored. fdbrestore resolves the timestamp to a version
+	// based on the version history of the source cluster. This must not be
+	// defined together with the RestoreVersion.
+	// +optional
+	RestoreTimestamp *metav1.Time `json:"restoreTimestamp,omitempty"`
+
+	// SourceClusterName provides the name of the cluster that the backup was
+	// taken from. The version history of this cluster is used to resolve the
+	// RestoreTimestamp. If empty, the destination cluster is used.
+	// +optional
+	SourceClusterName string `json:"sourceClusterName,omitempty"`
+
 	// This is the configuration of the target blobstore for this backup.
 	BlobStoreConfiguration *BlobStoreConfiguration `json:"blobStoreConfiguration,omitempty"`
 
 	// CustomParameters defines additional parameters to pass to the backup
 	// agents.
 	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+
+	// Abort defines whether the restore should be aborted. If the restore was
+	// not started yet, it will not be started. An aborted restore cannot be
+	// resumed.
+	// +optional
+	Abort bool `json:"abort,omitempty"`
+
+	// AbortTimeoutSeconds defines how long the operator will try to abort a
+	// restore that is still in progress after the deletion of the restore
+	// resource was requested. If the restore could not be aborted after the
+	// timeout, e.g. because the destination cluster is unreachable, the
+	// restore resource will be deleted anyway.
+	// Default is 600.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AbortTimeoutSeconds *int `json:"abortTimeoutSeconds,omitempty"`
+
+	// AllowNonEmptyDestination defines whether the restore can be started if
+	// the keyspace that should be restored already contains data in the
+	// destination cluster. By default the operator will not start a restore
+	// into a non-empty keyspace.
+	// +optional
+	AllowNonEmptyDestination bool `json:"allowNonEmptyDestination,omitempty"`
 }
 
 // FoundationDBRestoreStatus describes the current status of the restore for a cluster.
 type FoundationDBRestoreStatus struct {
 	// Running describes whether the restore is currently running.
 	Running bool `json:"running,omitempty"`
+
+	// State provides the last observed state of the restore.
+	// +optional
+	State RestoreState `json:"state,omitempty"`
+
+	// Tag provides the tag of the restore.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// ProgressPercentage provides the percentage of the backup data that was
+	// already restored.
+	// +optional
+	ProgressPercentage int `json:"progressPercentage,omitempty"`
+
+	// BytesWritten provides the number of bytes that were written to the
+	// destination cluster.
+	// +optional
+	BytesWritten int64 `json:"bytesWritten,omitempty"`
+
+	// KeyRanges provides the key ranges that were submitted for the restore.
+	// If empty, the entire keyspace was restored.
+	// +optional
+	KeyRanges []FoundationDBKeyRange `json:"keyRanges,omitempty"`
+
+	// RestoreVersion provides the version that is restored, as reported by
+	// fdbrestore. If empty, the version was not reported yet.
+	// +optional
+	RestoreVersion int64 `json:"restoreVersion,omitempty"`
+}
+
+// RestoreState defines the observed state of a restore
+type RestoreState string
+
+const (
+	// RestoreStateQueued defines the state of a restore that was submitted
+	// but not yet started
+	RestoreStateQueued RestoreState = "Queued"
+	// RestoreStateRunning defines the running state
+	RestoreStateRunning RestoreState = "Running"
+	// RestoreStateCompleted defines the completed state
+	RestoreStateCompleted RestoreState = "Completed"
+	// RestoreStateFailed defines the state of a restore that was aborted
+	// before it completed
+	RestoreStateFailed RestoreState = "Failed"
+	// RestoreStateAborted defines the state of a restore that was aborted
+	// through the restore spec
+	RestoreStateAborted RestoreState = "Aborted"
+)
+
+// IsInProgress returns true if the restore was submitted and not yet
+// completed or failed.
+func (state RestoreState) IsInProgress() bool {
+	return state == RestoreStateQueued || state == RestoreStateRunning
+}
+
+// FoundationDBLiveRestoreStatus describes the live status of the restore for a
+// cluster, as provided by the restore status command.
+type FoundationDBLiveRestoreStatus struct {
+	// Tag provides the tag of the restore.
+	Tag string `json:"tag,omitempty"`
+
+	// State provides the state of the restore as reported by fdbrestore,
+	// e.g. queued, running or completed.
+	State string `json:"state,omitempty"`
+
+	// BlocksCompleted provides the number of restored blocks.
+	BlocksCompleted int64 `json:"blocksCompleted,omitempty"`
+
+	// BlocksTotal provides the number of blocks that must be restored.
+	BlocksTotal int64 `json:"blocksTotal,omitempty"`
+
+	// BytesWritten provides the number of bytes that were written to the
+	// destination cluster.
+	BytesWritten int64 `json:"bytesWritten,omitempty"`
+
+	// URL provides the URL of the backup that is restored.
+	URL string `json:"url,omitempty"`
+
+	// LastError provides the last error that was reported for the restore.
+	LastError string `json:"lastError,omitempty"`
+
+	// Version provides the version that is restored.
+	Version int64 `json:"version,omitempty"`
+}
+
+// GetState returns the restore state for the state reported by fdbrestore. If
+// no restore was submitted, an empty state will be returned.
+func (status *FoundationDBLiveRestoreStatus) GetState() RestoreState {
+	switch status.State {
+	case "queued", "starting":
+		return RestoreStateQueued
+	case "running":
+		return RestoreStateRunning
+	case "completed":
+		return RestoreStateCompleted
+	case "aborted":
+		return RestoreStateFailed
+	default:
+		return ""
+	}
+}
+
+// GetProgressPercentage returns the percentage of the restored blocks. A
+// completed restore will always report 100 percent.
+func (status *FoundationDBLiveRestoreStatus) GetProgressPercentage() int {
+	if status.GetState() == RestoreStateCompleted {
+		return 100
+	}
+
+	if status.BlocksTotal <= 0 {
+		return 0
+	}
+
+	return int(status.BlocksCompleted * 100 / status.BlocksTotal)
 }
 
 // FoundationDBKeyRange describes a range of keys for a command.
@@ -82,6 +245,119 @@ type FoundationDBKeyRange struct {
 	End string `json:"end"`
 }
 
+// GetRawKeys returns the start and the end of the key range as raw bytes.
+func (keyRange FoundationDBKeyRange) GetRawKeys() ([]byte, []byte, error) {
+	start, err := parseKey(keyRange.Start)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	end, err := parseKey(keyRange.End)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return start, end, nil
+}
+
+// parseKey converts the escaped representation of a key into the raw bytes.
+// Besides `\xBB` escapes, a backslash can be escaped as `\\`.
+func parseKey(key string) ([]byte, error) {
+	result := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		if key[i] != '\\' {
+			result = append(result, key[i])
+			continue
+		}
+
+		if i+1 < len(key) && key[i+1] == '\\' {
+			result = append(result, '\\')
+			i++
+			continue
+		}
+
+		if i+3 >= len(key) || key[i+1] != 'x' {
+			return nil, fmt.Errorf("key %s contains an invalid escape sequence at position %d", key, i)
+		}
+
+		decoded, err := hex.DecodeString(key[i+2 : i+4])
+		if err != nil {
+			return nil, fmt.Errorf("key %s contains an invalid escape sequence at position %d", key, i)
+		}
+
+		result = append(result, decoded...)
+		i += 3
+	}
+
+	return result, nil
+}
+
+// validateKeyRanges checks that the key ranges contain valid escape
+// sequences, that the start of every key range is smaller than the end and
+// that the key ranges don't overlap.
+func validateKeyRanges(keyRanges []FoundationDBKeyRange) error {
+	type parsedKeyRange struct {
+		keyRange FoundationDBKeyRange
+		start    []byte
+		end      []byte
+	}
+
+	parsedKeyRanges := make([]parsedKeyRange, 0, len(keyRanges))
+	for _, keyRange := range keyRanges {
+		start, err := parseKey(keyRange.Start)
+		if err != nil {
+			return err
+		}
+
+		end, err := parseKey(keyRange.End)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Compare(start, end) >= 0 {
+			return fmt.Errorf("the start %s of the key range must be smaller than the end %s", keyRange.Start, keyRange.End)
+		}
+
+		parsedKeyRanges = append(parsedKeyRanges, parsedKeyRange{keyRange: keyRange, start: start, end: end})
+	}
+
+	sort.Slice(parsedKeyRanges, func(i, j int) bool {
+		return bytes.Compare(parsedKeyRanges[i].start, parsedKeyRanges[j].start) < 0
+	})
+
+	for i := 1; i < len(parsedKeyRanges); i++ {
+		previous, current := parsedKeyRanges[i-1], parsedKeyRanges[i]
+		if bytes.Compare(previous.end, current.start) > 0 {
+			return fmt.Errorf("the key range %s - %s overlaps with the key range %s - %s", previous.keyRange.Start, previous.keyRange.End, current.keyRange.Start, current.keyRange.End)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks if the restore spec is valid. Only one of the restore
+// version and the restore timestamp can be defined. The key ranges must contain
+// valid escape sequences, the start of every key range must be smaller than
+// the end and the key ranges must not overlap.
+func (restore *FoundationDBRestore) Validate() error {
+	if restore.Spec.RestoreVersion != nil && restore.Spec.RestoreTimestamp != nil {
+		return fmt.Errorf("only one of restoreVersion and restoreTimestamp can be defined")
+	}
+
+	if restore.Spec.RestoreVersion != nil && *restore.Spec.RestoreVersion <= 0 {
+		return fmt.Errorf("restoreVersion %d must be greater than 0", *restore.Spec.RestoreVersion)
+	}
+
+	if restore.Spec.BlobStoreConfiguration != nil {
+		err := restore.Spec.BlobStoreConfiguration.validateCABundle()
+		if err != nil {
+			return err
+		}
+	}
+
+	return validateKeyRanges(restore.Spec.KeyRanges)
+}
+
 // BackupName gets the name of the backup for the source backup.
 // This will fill in a default value if the backup name in the spec is empty.
 func (restore *FoundationDBRestore) BackupName() string {
@@ -97,6 +373,12 @@ func (restore *FoundationDBRestore) BackupURL() string {
 	return restore.Spec.BlobStoreConfiguration.getURL(restore.BackupName(), restore.Spec.BlobStoreConfiguration.BucketName())
 }
 
+// GetAbortTimeout returns the duration after which the restore abort
+// finalizer will be removed, even if the restore could not be aborted.
+func (restore *FoundationDBRestore) GetAbortTimeout() time.Duration {
+	return time.Duration(pointer.IntDeref(restore.Spec.AbortTimeoutSeconds, 600)) * time.Second
+}
+
 func init() {
 	SchemeBuilder.Register(&FoundationDBRestore{}, &FoundationDBRestoreList{})
 }
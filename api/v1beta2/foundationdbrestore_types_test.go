@@ -21,9 +21,12 @@
 package v1beta2
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("[api] FoundationDBRestore", func() {
@@ -118,4 +121,156 @@ var _ = Describe("[api] FoundationDBRestore", func() {
 				"blobstore://account@account:80/mybackup?bucket=fdb-backups&secure_connection=0"),
 		)
 	})
+
+	When("getting the state of a live restore status", func() {
+		DescribeTable("should return the correct state",
+			func(state string, expected RestoreState) {
+				status := FoundationDBLiveRestoreStatus{State: state}
+				Expect(status.GetState()).To(Equal(expected))
+			},
+			Entry("no restore", "", RestoreState("")),
+			Entry("an uninitialized restore", "unitialized", RestoreState("")),
+			Entry("a queued restore", "queued", RestoreStateQueued),
+			Entry("a starting restore", "starting", RestoreStateQueued),
+			Entry("a running restore", "running", RestoreStateRunning),
+			Entry("a completed restore", "completed", RestoreStateCompleted),
+			Entry("an aborted restore", "aborted", RestoreStateFailed),
+		)
+	})
+
+	When("getting the progress of a live restore status", func() {
+		DescribeTable("should return the correct percentage",
+			func(status FoundationDBLiveRestoreStatus, expected int) {
+				Expect(status.GetProgressPercentage()).To(Equal(expected))
+			},
+			Entry("no blocks are known",
+				FoundationDBLiveRestoreStatus{State: "queued"},
+				0),
+			Entry("half of the blocks are restored",
+				FoundationDBLiveRestoreStatus{State: "running", BlocksCompleted: 5, BlocksTotal: 10},
+				50),
+			Entry("the percentage is rounded down",
+				FoundationDBLiveRestoreStatus{State: "running", BlocksCompleted: 2, BlocksTotal: 3},
+				66),
+			Entry("the restore is completed",
+				FoundationDBLiveRestoreStatus{State: "completed"},
+				100),
+		)
+	})
+
+	When("validating the restore", func() {
+		DescribeTable("should validate the key ranges",
+			func(keyRanges []FoundationDBKeyRange, expected string) {
+				restore := FoundationDBRestore{
+					Spec: FoundationDBRestoreSpec{
+						KeyRanges: keyRanges,
+					},
+				}
+
+				err := restore.Validate()
+				if expected == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expected))
+			},
+			Entry("no key ranges",
+				nil,
+				""),
+			Entry("non-overlapping key ranges with escaped bytes",
+				[]FoundationDBKeyRange{
+					{Start: `\x15user3/`, End: `\x15user4/`},
+					{Start: `\x15user1/`, End: `\x15user2/`},
+				},
+				""),
+			Entry("adjacent key ranges",
+				[]FoundationDBKeyRange{
+					{Start: "a", End: "b"},
+					{Start: "b", End: "c"},
+				},
+				""),
+			Entry("a key range with an escaped backslash",
+				[]FoundationDBKeyRange{
+					{Start: `a\\`, End: `a\xff`},
+				},
+				""),
+			Entry("a key range with the start after the end",
+				[]FoundationDBKeyRange{
+					{Start: `\x15user2/`, End: `\x15user1/`},
+				},
+				`the start \x15user2/ of the key range must be smaller than the end \x15user1/`),
+			Entry("an empty key range",
+				[]FoundationDBKeyRange{
+					{Start: "a", End: "a"},
+				},
+				"the start a of the key range must be smaller than the end a"),
+			Entry("a key range with an escape sequence that is too short",
+				[]FoundationDBKeyRange{
+					{Start: `a\x1`, End: "b"},
+				},
+				`key a\x1 contains an invalid escape sequence at position 1`),
+			Entry("a key range with an invalid escape sequence",
+				[]FoundationDBKeyRange{
+					{Start: "a", End: `b\xzz`},
+				},
+				`key b\xzz contains an invalid escape sequence at position 1`),
+			Entry("overlapping key ranges",
+				[]FoundationDBKeyRange{
+					{Start: `\x15user2/`, End: `\x15user4/`},
+					{Start: `\x15user1/`, End: `\x15user3/`},
+				},
+				`the key range \x15user1/ - \x15user3/ overlaps with the key range \x15user2/ - \x15user4/`),
+		)
+	})
+
+	When("validating the restore version", func() {
+		DescribeTable("should validate the restore version and timestamp",
+			func(spec FoundationDBRestoreSpec, expected string) {
+				restore := FoundationDBRestore{Spec: spec}
+
+				err := restore.Validate()
+				if expected == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expected))
+			},
+			Entry("no version and no timestamp",
+				FoundationDBRestoreSpec{},
+				""),
+			Entry("a version",
+				FoundationDBRestoreSpec{RestoreVersion: pointer.Int64(1000)},
+				""),
+			Entry("a timestamp",
+				FoundationDBRestoreSpec{RestoreTimestamp: &metav1.Time{Time: time.Unix(1709280000, 0)}},
+				""),
+			Entry("a version and a timestamp",
+				FoundationDBRestoreSpec{RestoreVersion: pointer.Int64(1000), RestoreTimestamp: &metav1.Time{Time: time.Unix(1709280000, 0)}},
+				"only one of restoreVersion and restoreTimestamp can be defined"),
+			Entry("a negative version",
+				FoundationDBRestoreSpec{RestoreVersion: pointer.Int64(-1)},
+				"restoreVersion -1 must be greater than 0"),
+		)
+	})
+
+	When("getting the raw keys of a key range", func() {
+		DescribeTable("should decode the escaped keys",
+			func(keyRange FoundationDBKeyRange, expectedStart []byte, expectedEnd []byte) {
+				start, end, err := keyRange.GetRawKeys()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(start).To(Equal(expectedStart))
+				Expect(end).To(Equal(expectedEnd))
+			},
+			Entry("plain keys",
+				FoundationDBKeyRange{Start: "a", End: "b"},
+				[]byte("a"),
+				[]byte("b")),
+			Entry("keys with escaped bytes",
+				FoundationDBKeyRange{Start: `\x15user1/`, End: `a\\`},
+				[]byte("\x15user1/"),
+				[]byte("a\\")),
+		)
+	})
 })
@@ -68,6 +68,16 @@ func (in *AutomaticReplacementOptions) DeepCopyInto(out *AutomaticReplacementOpt
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaxConcurrentReplacementsPerFaultDomain != nil {
+		in, out := &in.MaxConcurrentReplacementsPerFaultDomain, &out.MaxConcurrentReplacementsPerFaultDomain
+		*out = new(int)
+		**out = **in
+	}
+	if in.FaultDomainReplacementCooldownSeconds != nil {
+		in, out := &in.FaultDomainReplacementCooldownSeconds, &out.FaultDomainReplacementCooldownSeconds
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticReplacementOptions.
@@ -80,6 +90,87 @@ func (in *AutomaticReplacementOptions) DeepCopy() *AutomaticReplacementOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupAgentScaling) DeepCopyInto(out *BackupAgentScaling) {
+	*out = *in
+	if in.AgentsPerStorageProcess != nil {
+		in, out := &in.AgentsPerStorageProcess, &out.AgentsPerStorageProcess
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupAgentScaling.
+func (in *BackupAgentScaling) DeepCopy() *BackupAgentScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupAgentScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupAgentScheduling) DeepCopyInto(out *BackupAgentScheduling) {
+	*out = *in
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupAgentScheduling.
+func (in *BackupAgentScheduling) DeepCopy() *BackupAgentScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupAgentScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupExpirationStatus) DeepCopyInto(out *BackupExpirationStatus) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	in.ExpireBefore.DeepCopyInto(&out.ExpireBefore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupExpirationStatus.
+func (in *BackupExpirationStatus) DeepCopy() *BackupExpirationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupExpirationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupGenerationStatus) DeepCopyInto(out *BackupGenerationStatus) {
 	*out = *in
@@ -95,6 +186,81 @@ func (in *BackupGenerationStatus) DeepCopy() *BackupGenerationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRestorabilityDetails) DeepCopyInto(out *BackupRestorabilityDetails) {
+	*out = *in
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	in.LastAttempt.DeepCopyInto(&out.LastAttempt)
+	if in.LatestRestorableTimestamp != nil {
+		in, out := &in.LatestRestorableTimestamp, &out.LatestRestorableTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LatestSnapshotStartTimestamp != nil {
+		in, out := &in.LatestSnapshotStartTimestamp, &out.LatestSnapshotStartTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRestorabilityDetails.
+func (in *BackupRestorabilityDetails) DeepCopy() *BackupRestorabilityDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRestorabilityDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetentionPolicy) DeepCopyInto(out *BackupRetentionPolicy) {
+	*out = *in
+	if in.MinRestorableDays != nil {
+		in, out := &in.MinRestorableDays, &out.MinRestorableDays
+		*out = new(int)
+		**out = **in
+	}
+	if in.ExpirationIntervalSeconds != nil {
+		in, out := &in.ExpirationIntervalSeconds, &out.ExpirationIntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetentionPolicy.
+func (in *BackupRetentionPolicy) DeepCopy() *BackupRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlobStoreCABundle) DeepCopyInto(out *BlobStoreCABundle) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobStoreCABundle.
+func (in *BlobStoreCABundle) DeepCopy() *BlobStoreCABundle {
+	if in == nil {
+		return nil
+	}
+	out := new(BlobStoreCABundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BlobStoreConfiguration) DeepCopyInto(out *BlobStoreConfiguration) {
 	*out = *in
@@ -103,6 +269,21 @@ func (in *BlobStoreConfiguration) DeepCopyInto(out *BlobStoreConfiguration) {
 		*out = make([]URLParameter, len(*in))
 		copy(*out, *in)
 	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(BlobStoreCABundle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSVerifyPeers != nil {
+		in, out := &in.TLSVerifyPeers, &out.TLSVerifyPeers
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobStoreConfiguration.
@@ -207,6 +388,31 @@ func (in *ConnectionString) DeepCopy() *ConnectionString {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivityCheckOptions) DeepCopyInto(out *ConnectivityCheckOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivityCheckOptions.
+func (in *ConnectivityCheckOptions) DeepCopy() *ConnectivityCheckOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivityCheckOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerOverrides) DeepCopyInto(out *ContainerOverrides) {
 	*out = *in
@@ -237,6 +443,51 @@ func (in *ContainerOverrides) DeepCopy() *ContainerOverrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerProbeOverride) DeepCopyInto(out *ContainerProbeOverride) {
+	*out = *in
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerProbeOverride.
+func (in *ContainerProbeOverride) DeepCopy() *ContainerProbeOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerProbeOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatorAvailability) DeepCopyInto(out *CoordinatorAvailability) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorAvailability.
+func (in *CoordinatorAvailability) DeepCopy() *CoordinatorAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatorAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CoordinatorSelectionSetting) DeepCopyInto(out *CoordinatorSelectionSetting) {
 	*out = *in
@@ -316,6 +567,36 @@ func (in *DatabaseConfiguration) DeepCopy() *DatabaseConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionCleanupOptions) DeepCopyInto(out *DeletionCleanupOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]DeletionCleanupStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionCleanupOptions.
+func (in *DeletionCleanupOptions) DeepCopy() *DeletionCleanupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionCleanupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExcludedServers) DeepCopyInto(out *ExcludedServers) {
 	*out = *in
@@ -331,6 +612,21 @@ func (in *ExcludedServers) DeepCopy() *ExcludedServers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExclusionProgress) DeepCopyInto(out *ExclusionProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExclusionProgress.
+func (in *ExclusionProgress) DeepCopy() *ExclusionProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ExclusionProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FaultTolerance) DeepCopyInto(out *FaultTolerance) {
 	*out = *in
@@ -346,6 +642,36 @@ func (in *FaultTolerance) DeepCopy() *FaultTolerance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FdbMonitorSettings) DeepCopyInto(out *FdbMonitorSettings) {
+	*out = *in
+	if in.RestartDelaySeconds != nil {
+		in, out := &in.RestartDelaySeconds, &out.RestartDelaySeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.RestartBackoff != nil {
+		in, out := &in.RestartBackoff, &out.RestartBackoff
+		*out = new(int)
+		**out = **in
+	}
+	if in.RestartDelayResetIntervalSeconds != nil {
+		in, out := &in.RestartDelayResetIntervalSeconds, &out.RestartDelayResetIntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FdbMonitorSettings.
+func (in *FdbMonitorSettings) DeepCopy() *FdbMonitorSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(FdbMonitorSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBBackup) DeepCopyInto(out *FoundationDBBackup) {
 	*out = *in
@@ -373,6 +699,53 @@ func (in *FoundationDBBackup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupDescription) DeepCopyInto(out *FoundationDBBackupDescription) {
+	*out = *in
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]FoundationDBBackupDescriptionSnapshot, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinRestorablePoint != nil {
+		in, out := &in.MinRestorablePoint, &out.MinRestorablePoint
+		*out = new(FoundationDBLiveBackupStatusRestorablePoint)
+		**out = **in
+	}
+	if in.MaxRestorablePoint != nil {
+		in, out := &in.MaxRestorablePoint, &out.MaxRestorablePoint
+		*out = new(FoundationDBLiveBackupStatusRestorablePoint)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupDescription.
+func (in *FoundationDBBackupDescription) DeepCopy() *FoundationDBBackupDescription {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupDescription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupDescriptionSnapshot) DeepCopyInto(out *FoundationDBBackupDescriptionSnapshot) {
+	*out = *in
+	out.Start = in.Start
+	out.End = in.End
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupDescriptionSnapshot.
+func (in *FoundationDBBackupDescriptionSnapshot) DeepCopy() *FoundationDBBackupDescriptionSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupDescriptionSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBBackupList) DeepCopyInto(out *FoundationDBBackupList) {
 	*out = *in
@@ -413,11 +786,21 @@ func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.AgentScaling != nil {
+		in, out := &in.AgentScaling, &out.AgentScaling
+		*out = new(BackupAgentScaling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SnapshotPeriodSeconds != nil {
 		in, out := &in.SnapshotPeriodSeconds, &out.SnapshotPeriodSeconds
 		*out = new(int)
 		**out = **in
 	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(BackupRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BackupDeploymentMetadata != nil {
 		in, out := &in.BackupDeploymentMetadata, &out.BackupDeploymentMetadata
 		*out = new(v1.ObjectMeta)
@@ -428,6 +811,11 @@ func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
 		*out = new(corev1.PodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AgentScheduling != nil {
+		in, out := &in.AgentScheduling, &out.AgentScheduling
+		*out = new(BackupAgentScheduling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CustomParameters != nil {
 		in, out := &in.CustomParameters, &out.CustomParameters
 		*out = make(FoundationDBCustomParameters, len(*in))
@@ -450,6 +838,16 @@ func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DescribeIntervalSeconds != nil {
+		in, out := &in.DescribeIntervalSeconds, &out.DescribeIntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.KeyRanges != nil {
+		in, out := &in.KeyRanges, &out.KeyRanges
+		*out = make([]FoundationDBKeyRange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupSpec.
@@ -468,7 +866,22 @@ func (in *FoundationDBBackupStatus) DeepCopyInto(out *FoundationDBBackupStatus)
 	if in.BackupDetails != nil {
 		in, out := &in.BackupDetails, &out.BackupDetails
 		*out = new(FoundationDBBackupStatusBackupDetails)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastExpiration != nil {
+		in, out := &in.LastExpiration, &out.LastExpiration
+		*out = new(BackupExpirationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestorabilityDetails != nil {
+		in, out := &in.RestorabilityDetails, &out.RestorabilityDetails
+		*out = new(BackupRestorabilityDetails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyRanges != nil {
+		in, out := &in.KeyRanges, &out.KeyRanges
+		*out = make([]FoundationDBKeyRange, len(*in))
+		copy(*out, *in)
 	}
 	out.Generations = in.Generations
 }
@@ -486,6 +899,11 @@ func (in *FoundationDBBackupStatus) DeepCopy() *FoundationDBBackupStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBBackupStatusBackupDetails) DeepCopyInto(out *FoundationDBBackupStatusBackupDetails) {
 	*out = *in
+	if in.Lag != nil {
+		in, out := &in.Lag, &out.Lag
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupStatusBackupDetails.
@@ -558,11 +976,21 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		*out = new(bool)
 		**out = **in
 	}
+	if in.KillProcessesOnConfigurationChange != nil {
+		in, out := &in.KillProcessesOnConfigurationChange, &out.KillProcessesOnConfigurationChange
+		*out = new(bool)
+		**out = **in
+	}
 	if in.CacheDatabaseStatusForReconciliation != nil {
 		in, out := &in.CacheDatabaseStatusForReconciliation, &out.CacheDatabaseStatusForReconciliation
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RestartIncompatibleProcesses != nil {
+		in, out := &in.RestartIncompatibleProcesses, &out.RestartIncompatibleProcesses
+		*out = new(bool)
+		**out = **in
+	}
 	in.Replacements.DeepCopyInto(&out.Replacements)
 	if in.UseNonBlockingExcludes != nil {
 		in, out := &in.UseNonBlockingExcludes, &out.UseNonBlockingExcludes
@@ -584,32 +1012,122 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		*out = new(int)
 		**out = **in
 	}
+	if in.IgnoreMissingProcessesForExclusionSeconds != nil {
+		in, out := &in.IgnoreMissingProcessesForExclusionSeconds, &out.IgnoreMissingProcessesForExclusionSeconds
+		*out = new(int)
+		**out = **in
+	}
 	if in.FailedPodDurationSeconds != nil {
 		in, out := &in.FailedPodDurationSeconds, &out.FailedPodDurationSeconds
 		*out = new(int)
 		**out = **in
 	}
+	if in.ReconciliationStalledSeconds != nil {
+		in, out := &in.ReconciliationStalledSeconds, &out.ReconciliationStalledSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.ReconciliationTimeoutSeconds != nil {
+		in, out := &in.ReconciliationTimeoutSeconds, &out.ReconciliationTimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
 	if in.MaxConcurrentReplacements != nil {
 		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
 		*out = new(int)
 		**out = **in
 	}
-	if in.WaitBetweenRemovalsSeconds != nil {
-		in, out := &in.WaitBetweenRemovalsSeconds, &out.WaitBetweenRemovalsSeconds
+	if in.WaitBetweenRemovalsSeconds != nil {
+		in, out := &in.WaitBetweenRemovalsSeconds, &out.WaitBetweenRemovalsSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.UseManagementAPI != nil {
+		in, out := &in.UseManagementAPI, &out.UseManagementAPI
+		*out = new(bool)
+		**out = **in
+	}
+	in.MaintenanceModeOptions.DeepCopyInto(&out.MaintenanceModeOptions)
+	if in.IgnoreLogGroupsForUpgrade != nil {
+		in, out := &in.IgnoreLogGroupsForUpgrade, &out.IgnoreLogGroupsForUpgrade
+		*out = make([]LogGroup, len(*in))
+		copy(*out, *in)
+	}
+	if in.CoordinatorAgeWarningSeconds != nil {
+		in, out := &in.CoordinatorAgeWarningSeconds, &out.CoordinatorAgeWarningSeconds
+		*out = new(int)
+		**out = **in
+	}
+	in.DeletionCleanup.DeepCopyInto(&out.DeletionCleanup)
+	if in.ConfigMapUpdateDebounceSeconds != nil {
+		in, out := &in.ConfigMapUpdateDebounceSeconds, &out.ConfigMapUpdateDebounceSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.IgnoreTestProcessGroups != nil {
+		in, out := &in.IgnoreTestProcessGroups, &out.IgnoreTestProcessGroups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExclusionMaintenanceWindows != nil {
+		in, out := &in.ExclusionMaintenanceWindows, &out.ExclusionMaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReplaceMismatchedImages != nil {
+		in, out := &in.ReplaceMismatchedImages, &out.ReplaceMismatchedImages
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CoordinatorChangeDelayAfterExclusionSeconds != nil {
+		in, out := &in.CoordinatorChangeDelayAfterExclusionSeconds, &out.CoordinatorChangeDelayAfterExclusionSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.AutoTuneCoordinatorCount != nil {
+		in, out := &in.AutoTuneCoordinatorCount, &out.AutoTuneCoordinatorCount
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReconcileTraceMode != nil {
+		in, out := &in.ReconcileTraceMode, &out.ReconcileTraceMode
+		*out = new(ReconcileTraceMode)
+		**out = **in
+	}
+	if in.DeriveMemoryKnobsFromResources != nil {
+		in, out := &in.DeriveMemoryKnobsFromResources, &out.DeriveMemoryKnobsFromResources
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MemoryKnobsHeadroomPercentage != nil {
+		in, out := &in.MemoryKnobsHeadroomPercentage, &out.MemoryKnobsHeadroomPercentage
+		*out = new(int)
+		**out = **in
+	}
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	if in.ExcludeFailedProcessesAfterSeconds != nil {
+		in, out := &in.ExcludeFailedProcessesAfterSeconds, &out.ExcludeFailedProcessesAfterSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConcurrentExclusions != nil {
+		in, out := &in.MaxConcurrentExclusions, &out.MaxConcurrentExclusions
 		*out = new(int)
 		**out = **in
 	}
-	if in.UseManagementAPI != nil {
-		in, out := &in.UseManagementAPI, &out.UseManagementAPI
-		*out = new(bool)
+	if in.MinimumRecoveryTimeForExclusionSeconds != nil {
+		in, out := &in.MinimumRecoveryTimeForExclusionSeconds, &out.MinimumRecoveryTimeForExclusionSeconds
+		*out = new(int)
 		**out = **in
 	}
-	in.MaintenanceModeOptions.DeepCopyInto(&out.MaintenanceModeOptions)
-	if in.IgnoreLogGroupsForUpgrade != nil {
-		in, out := &in.IgnoreLogGroupsForUpgrade, &out.IgnoreLogGroupsForUpgrade
-		*out = make([]LogGroup, len(*in))
-		copy(*out, *in)
+	if in.MinimumRecoveryTimeForInclusionSeconds != nil {
+		in, out := &in.MinimumRecoveryTimeForInclusionSeconds, &out.MinimumRecoveryTimeForInclusionSeconds
+		*out = new(int)
+		**out = **in
 	}
+	in.Removals.DeepCopyInto(&out.Removals)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterAutomationOptions.
@@ -682,7 +1200,17 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 	}
 	out.ProcessCounts = in.ProcessCounts
 	in.PartialConnectionString.DeepCopyInto(&out.PartialConnectionString)
+	if in.UseSeedClusterFile != nil {
+		in, out := &in.UseSeedClusterFile, &out.UseSeedClusterFile
+		*out = new(bool)
+		**out = **in
+	}
 	out.FaultDomain = in.FaultDomain
+	if in.MachineIDSource != nil {
+		in, out := &in.MachineIDSource, &out.MachineIDSource
+		*out = new(MachineIDSource)
+		**out = **in
+	}
 	if in.ProcessGroupsToRemove != nil {
 		in, out := &in.ProcessGroupsToRemove, &out.ProcessGroupsToRemove
 		*out = make([]ProcessGroupID, len(*in))
@@ -710,6 +1238,13 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AutomaticLogGroupPrefix != nil {
+		in, out := &in.AutomaticLogGroupPrefix, &out.AutomaticLogGroupPrefix
+		*out = new(bool)
+		**out = **in
+	}
+	out.Tracing = in.Tracing
+	in.FdbMonitor.DeepCopyInto(&out.FdbMonitor)
 	in.AutomationOptions.DeepCopyInto(&out.AutomationOptions)
 	in.LockOptions.DeepCopyInto(&out.LockOptions)
 	in.Routing.DeepCopyInto(&out.Routing)
@@ -740,6 +1275,7 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	in.ConnectivityCheck.DeepCopyInto(&out.ConnectivityCheck)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterSpec.
@@ -787,6 +1323,51 @@ func (in *FoundationDBClusterStatus) DeepCopyInto(out *FoundationDBClusterStatus
 	}
 	in.Locks.DeepCopyInto(&out.Locks)
 	in.MaintenanceModeInfo.DeepCopyInto(&out.MaintenanceModeInfo)
+	if in.LastRequeue != nil {
+		in, out := &in.LastRequeue, &out.LastRequeue
+		*out = new(RequeueInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastReconciliationError != nil {
+		in, out := &in.LastReconciliationError, &out.LastReconciliationError
+		*out = new(ReconciliationError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DatabaseStatusCachedAt != nil {
+		in, out := &in.DatabaseStatusCachedAt, &out.DatabaseStatusCachedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StorageEngineMigration != nil {
+		in, out := &in.StorageEngineMigration, &out.StorageEngineMigration
+		*out = new(StorageEngineMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReconcileLoops != nil {
+		in, out := &in.ReconcileLoops, &out.ReconcileLoops
+		*out = new(ReconcileLoopStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecentInclusions != nil {
+		in, out := &in.RecentInclusions, &out.RecentInclusions
+		*out = make([]InclusionRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastFaultDomainReplacements != nil {
+		in, out := &in.LastFaultDomainReplacements, &out.LastFaultDomainReplacements
+		*out = make(map[FaultDomain]v1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterStatus.
@@ -837,6 +1418,11 @@ func (in *FoundationDBKeyRange) DeepCopy() *FoundationDBKeyRange {
 func (in *FoundationDBLiveBackupStatus) DeepCopyInto(out *FoundationDBLiveBackupStatus) {
 	*out = *in
 	out.Status = in.Status
+	if in.LatestRestorablePoint != nil {
+		in, out := &in.LatestRestorablePoint, &out.LatestRestorablePoint
+		*out = new(FoundationDBLiveBackupStatusRestorablePoint)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatus.
@@ -849,6 +1435,21 @@ func (in *FoundationDBLiveBackupStatus) DeepCopy() *FoundationDBLiveBackupStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveBackupStatusRestorablePoint) DeepCopyInto(out *FoundationDBLiveBackupStatusRestorablePoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatusRestorablePoint.
+func (in *FoundationDBLiveBackupStatusRestorablePoint) DeepCopy() *FoundationDBLiveBackupStatusRestorablePoint {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveBackupStatusRestorablePoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBLiveBackupStatusState) DeepCopyInto(out *FoundationDBLiveBackupStatusState) {
 	*out = *in
@@ -864,13 +1465,28 @@ func (in *FoundationDBLiveBackupStatusState) DeepCopy() *FoundationDBLiveBackupS
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveRestoreStatus) DeepCopyInto(out *FoundationDBLiveRestoreStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveRestoreStatus.
+func (in *FoundationDBLiveRestoreStatus) DeepCopy() *FoundationDBLiveRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBRestore) DeepCopyInto(out *FoundationDBRestore) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestore.
@@ -931,6 +1547,15 @@ func (in *FoundationDBRestoreSpec) DeepCopyInto(out *FoundationDBRestoreSpec) {
 		*out = make([]FoundationDBKeyRange, len(*in))
 		copy(*out, *in)
 	}
+	if in.RestoreVersion != nil {
+		in, out := &in.RestoreVersion, &out.RestoreVersion
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RestoreTimestamp != nil {
+		in, out := &in.RestoreTimestamp, &out.RestoreTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.BlobStoreConfiguration != nil {
 		in, out := &in.BlobStoreConfiguration, &out.BlobStoreConfiguration
 		*out = new(BlobStoreConfiguration)
@@ -941,6 +1566,11 @@ func (in *FoundationDBRestoreSpec) DeepCopyInto(out *FoundationDBRestoreSpec) {
 		*out = make(FoundationDBCustomParameters, len(*in))
 		copy(*out, *in)
 	}
+	if in.AbortTimeoutSeconds != nil {
+		in, out := &in.AbortTimeoutSeconds, &out.AbortTimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreSpec.
@@ -956,6 +1586,11 @@ func (in *FoundationDBRestoreSpec) DeepCopy() *FoundationDBRestoreSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBRestoreStatus) DeepCopyInto(out *FoundationDBRestoreStatus) {
 	*out = *in
+	if in.KeyRanges != nil {
+		in, out := &in.KeyRanges, &out.KeyRanges
+		*out = make([]FoundationDBKeyRange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreStatus.
@@ -1453,6 +2088,25 @@ func (in *ImageConfig) DeepCopy() *ImageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InclusionRecord) DeepCopyInto(out *InclusionRecord) {
+	*out = *in
+	if in.IncludedAt != nil {
+		in, out := &in.IncludedAt, &out.IncludedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InclusionRecord.
+func (in *InclusionRecord) DeepCopy() *InclusionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(InclusionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LabelConfig) DeepCopyInto(out *LabelConfig) {
 	*out = *in
@@ -1562,6 +2216,21 @@ func (in *LockSystemStatus) DeepCopy() *LockSystemStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineIDSource) DeepCopyInto(out *MachineIDSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineIDSource.
+func (in *MachineIDSource) DeepCopy() *MachineIDSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineIDSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaintenanceModeInfo) DeepCopyInto(out *MaintenanceModeInfo) {
 	*out = *in
@@ -1616,6 +2285,26 @@ func (in *MaintenanceModeOptions) DeepCopy() *MaintenanceModeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *None) DeepCopyInto(out *None) {
 	*out = *in
@@ -1631,6 +2320,26 @@ func (in *None) DeepCopy() *None {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetOptions) DeepCopyInto(out *PodDisruptionBudgetOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetOptions.
+func (in *PodDisruptionBudgetOptions) DeepCopy() *PodDisruptionBudgetOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessAddress) DeepCopyInto(out *ProcessAddress) {
 	*out = *in
@@ -1673,6 +2382,26 @@ func (in *ProcessCounts) DeepCopy() *ProcessCounts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessCustomParameters) DeepCopyInto(out *ProcessCustomParameters) {
+	*out = *in
+	if in.CustomParameters != nil {
+		in, out := &in.CustomParameters, &out.CustomParameters
+		*out = make(FoundationDBCustomParameters, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessCustomParameters.
+func (in *ProcessCustomParameters) DeepCopy() *ProcessCustomParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessCustomParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessGroupCondition) DeepCopyInto(out *ProcessGroupCondition) {
 	*out = *in
@@ -1700,6 +2429,10 @@ func (in *ProcessGroupStatus) DeepCopyInto(out *ProcessGroupStatus) {
 		in, out := &in.RemovalTimestamp, &out.RemovalTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.ExclusionStartedTimestamp != nil {
+		in, out := &in.ExclusionStartedTimestamp, &out.ExclusionStartedTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.ExclusionTimestamp != nil {
 		in, out := &in.ExclusionTimestamp, &out.ExclusionTimestamp
 		*out = (*in).DeepCopy()
@@ -1715,6 +2448,15 @@ func (in *ProcessGroupStatus) DeepCopyInto(out *ProcessGroupStatus) {
 			}
 		}
 	}
+	if in.CoordinatorTimestamp != nil {
+		in, out := &in.CoordinatorTimestamp, &out.CoordinatorTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ExclusionProgress != nil {
+		in, out := &in.ExclusionProgress, &out.ExclusionProgress
+		*out = new(ExclusionProgress)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessGroupStatus.
@@ -1745,6 +2487,25 @@ func (in *ProcessSettings) DeepCopyInto(out *ProcessSettings) {
 		*out = make(FoundationDBCustomParameters, len(*in))
 		copy(*out, *in)
 	}
+	if in.CustomParametersPerProcess != nil {
+		in, out := &in.CustomParametersPerProcess, &out.CustomParametersPerProcess
+		*out = make([]ProcessCustomParameters, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProbeOverrides != nil {
+		in, out := &in.ProbeOverrides, &out.ProbeOverrides
+		*out = make([]ContainerProbeOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LogVolumeClaimTemplate != nil {
+		in, out := &in.LogVolumeClaimTemplate, &out.LogVolumeClaimTemplate
+		*out = new(corev1.PersistentVolumeClaim)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessSettings.
@@ -1757,6 +2518,44 @@ func (in *ProcessSettings) DeepCopy() *ProcessSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileLoopStatus) DeepCopyInto(out *ReconcileLoopStatus) {
+	*out = *in
+	if in.WindowStart != nil {
+		in, out := &in.WindowStart, &out.WindowStart
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileLoopStatus.
+func (in *ReconcileLoopStatus) DeepCopy() *ReconcileLoopStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileLoopStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconciliationError) DeepCopyInto(out *ReconciliationError) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconciliationError.
+func (in *ReconciliationError) DeepCopy() *ReconciliationError {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconciliationError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RecoveryState) DeepCopyInto(out *RecoveryState) {
 	*out = *in
@@ -1792,6 +2591,45 @@ func (in *Region) DeepCopy() *Region {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovalOptions) DeepCopyInto(out *RemovalOptions) {
+	*out = *in
+	if in.PVCRetentionDuration != nil {
+		in, out := &in.PVCRetentionDuration, &out.PVCRetentionDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovalOptions.
+func (in *RemovalOptions) DeepCopy() *RemovalOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovalOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequeueInfo) DeepCopyInto(out *RequeueInfo) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequeueInfo.
+func (in *RequeueInfo) DeepCopy() *RequeueInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(RequeueInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequiredAddressSet) DeepCopyInto(out *RequiredAddressSet) {
 	*out = *in
@@ -1840,6 +2678,11 @@ func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.DualStack != nil {
+		in, out := &in.DualStack, &out.DualStack
+		*out = new(bool)
+		**out = **in
+	}
 	if in.UseDNSInClusterFile != nil {
 		in, out := &in.UseDNSInClusterFile, &out.UseDNSInClusterFile
 		*out = new(bool)
@@ -1855,6 +2698,11 @@ func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ProcessPortBase != nil {
+		in, out := &in.ProcessPortBase, &out.ProcessPortBase
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingConfig.
@@ -1867,6 +2715,30 @@ func (in *RoutingConfig) DeepCopy() *RoutingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEngineMigrationStatus) DeepCopyInto(out *StorageEngineMigrationStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingProcessGroups != nil {
+		in, out := &in.PendingProcessGroups, &out.PendingProcessGroups
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEngineMigrationStatus.
+func (in *StorageEngineMigrationStatus) DeepCopy() *StorageEngineMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEngineMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaintReplacementOption) DeepCopyInto(out *TaintReplacementOption) {
 	*out = *in
@@ -1892,6 +2764,21 @@ func (in *TaintReplacementOption) DeepCopy() *TaintReplacementOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingOptions) DeepCopyInto(out *TracingOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingOptions.
+func (in *TracingOptions) DeepCopy() *TracingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Version) DeepCopyInto(out *Version) {
 	*out = *in
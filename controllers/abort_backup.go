@@ -0,0 +1,59 @@
+/*
+ * abort_backup.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
+)
+
+// abortBackup provides a reconciliation step for aborting a backup without
+// waiting for the current snapshot to complete.
+type abortBackup struct{}
+
+// reconcile runs the reconciler's work.
+func (s abortBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	if !backup.ShouldBeAborted() || backup.Status.BackupDetails == nil || !backup.Status.BackupDetails.Running {
+		return nil
+	}
+
+	adminClient, err := r.adminClientForBackup(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	err = adminClient.AbortBackup(backup.BackupURL(), backup.BackupTag())
+	if err != nil {
+		metrics.RecordBackupOperationFailure(backup.Namespace, backup.Name, "abort")
+		return &requeue{curError: err}
+	}
+
+	backup.Status.State = fdbv1beta2.BackupStateAborted
+	err = r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
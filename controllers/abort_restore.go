@@ -0,0 +1,143 @@
+/*
+ * abort_restore.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// abortRestore provides a reconciliation step for aborting a restore that is still in progress when the restore spec
+// requests the abort.
+type abortRestore struct{}
+
+// reconcile runs the reconciler's work.
+func (s abortRestore) reconcile(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore) *requeue {
+	if !restore.Spec.Abort {
+		return nil
+	}
+
+	adminClient, err := r.adminClientForRestore(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	aborted, err := abortRestoreInProgress(globalControllerLogger.WithValues("namespace", restore.Namespace, "restore", restore.Name), adminClient, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if !aborted {
+		return &requeue{message: "waiting for the restore to be aborted", delay: restoreProgressInterval}
+	}
+
+	return nil
+}
+
+// ensureRestoreAbortFinalizer adds the restore abort finalizer if it is missing.
+func (r *FoundationDBRestoreReconciler) ensureRestoreAbortFinalizer(ctx context.Context, logger logr.Logger, restore *fdbv1beta2.FoundationDBRestore) error {
+	if controllerutil.ContainsFinalizer(restore, fdbv1beta2.RestoreAbortFinalizer) {
+		return nil
+	}
+
+	logger.Info("Adding restore abort finalizer")
+	controllerutil.AddFinalizer(restore, fdbv1beta2.RestoreAbortFinalizer)
+	return r.Update(ctx, restore)
+}
+
+// abortRestoreForDeletion aborts the restore if it is still in progress and removes the restore abort finalizer once
+// the restore is no longer in progress or the abort timeout is exceeded.
+func (r *FoundationDBRestoreReconciler) abortRestoreForDeletion(ctx context.Context, logger logr.Logger, restore *fdbv1beta2.FoundationDBRestore) (ctrl.Result, error) {
+	timeout := restore.GetAbortTimeout()
+	if time.Since(restore.DeletionTimestamp.Time) > timeout {
+		logger.Info("Restore was not aborted in time, removing finalizer", "timeout", timeout.String())
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "RestoreAbortTimedOut", fmt.Sprintf("restore was not aborted after %s", timeout.String()))
+		controllerutil.RemoveFinalizer(restore, fdbv1beta2.RestoreAbortFinalizer)
+		return ctrl.Result{}, r.Update(ctx, restore)
+	}
+
+	adminClient, err := r.adminClientForRestore(ctx, restore)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer adminClient.Close()
+
+	aborted, err := abortRestoreInProgress(logger, adminClient, restore)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !aborted {
+		logger.Info("Waiting for the restore to be aborted")
+		return ctrl.Result{RequeueAfter: restoreProgressInterval}, nil
+	}
+
+	logger.Info("Restore is not in progress, removing finalizer")
+	controllerutil.RemoveFinalizer(restore, fdbv1beta2.RestoreAbortFinalizer)
+	return ctrl.Result{}, r.Update(ctx, restore)
+}
+
+// abortRestoreInProgress aborts the current restore if it is in progress and was started by the provided restore. The
+// return value defines whether the restore is no longer in progress.
+func abortRestoreInProgress(logger logr.Logger, adminClient fdbadminclient.AdminClient, restore *fdbv1beta2.FoundationDBRestore) (bool, error) {
+	// A restore that was never started by this object or that already finished must not abort a restore that was
+	// started by another restore object on the same destination cluster.
+	if !restore.Status.Running || restore.Status.State == fdbv1beta2.RestoreStateCompleted || restore.Status.State == fdbv1beta2.RestoreStateFailed {
+		return true, nil
+	}
+
+	status, err := adminClient.GetLiveRestoreStatus()
+	if err != nil {
+		return false, err
+	}
+
+	if !status.GetState().IsInProgress() {
+		return true, nil
+	}
+
+	if status.URL != restore.BackupURL() || (restore.Status.Tag != "" && status.Tag != restore.Status.Tag) {
+		logger.Info("Restore in progress was not started by this restore, skipping abort", "tag", status.Tag, "url", status.URL)
+		return true, nil
+	}
+
+	logger.Info("Aborting restore", "tag", status.Tag, "state", status.State)
+	err = adminClient.AbortRestore(status.Tag)
+	if err != nil {
+		return false, err
+	}
+
+	// Make sure the abort took effect before we report the restore as aborted.
+	status, err = adminClient.GetLiveRestoreStatus()
+	if err != nil {
+		return false, err
+	}
+
+	return !status.GetState().IsInProgress(), nil
+}
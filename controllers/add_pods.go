@@ -36,7 +36,7 @@ import (
 type addPods struct{}
 
 // reconcile runs the reconciler's work.
-func (a addPods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (a addPods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *cachedDatabaseStatus, logger logr.Logger) *requeue {
 	configMap, err := internal.GetConfigMap(cluster)
 	if err != nil {
 		return &requeue{curError: err}
@@ -54,9 +54,16 @@ func (a addPods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler
 	}
 
 	for _, processGroup := range cluster.Status.ProcessGroups {
-		_, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
+		existingPod, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
 		// If no error is returned the Pod exists
 		if err == nil {
+			// The Pod waits in its init container until the node labels are copied into its annotations, so the
+			// annotations must be set before the Pod can be running.
+			err = updateNodeLocalityAnnotations(ctx, logger, r, cluster, existingPod)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+
 			continue
 		}
 
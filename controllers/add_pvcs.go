@@ -37,7 +37,7 @@ import (
 type addPVCs struct{}
 
 // reconcile runs the reconciler's work.
-func (a addPVCs) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (a addPVCs) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *cachedDatabaseStatus, logger logr.Logger) *requeue {
 	for _, processGroup := range cluster.Status.ProcessGroups {
 		if processGroup.IsMarkedForRemoval() && processGroup.IsExcluded() {
 			continue
@@ -48,26 +48,43 @@ func (a addPVCs) reconcile(ctx context.Context, r *FoundationDBClusterReconciler
 			return &requeue{curError: err}
 		}
 
-		if pvc == nil {
-			continue
+		err = createPVCIfMissing(ctx, r, cluster, pvc, logger)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
 		}
-		existingPVC := &corev1.PersistentVolumeClaim{}
 
-		err = r.Get(ctx, client.ObjectKey{Namespace: pvc.Namespace, Name: pvc.Name}, existingPVC)
+		logPVC, err := internal.GetLogPvc(cluster, processGroup)
 		if err != nil {
-			if !k8serrors.IsNotFound(err) {
-				return &requeue{curError: err, delayedRequeue: true}
-			}
-
-			owner := internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
-			pvc.ObjectMeta.OwnerReferences = owner
-			logger.V(1).Info("Creating PVC", "name", pvc.Name)
-			err = r.Create(ctx, pvc)
-			if err != nil {
-				return &requeue{curError: err, delayedRequeue: true}
-			}
+			return &requeue{curError: err}
+		}
+
+		err = createPVCIfMissing(ctx, r, cluster, logPVC, logger)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
 		}
 	}
 
 	return nil
 }
+
+// createPVCIfMissing creates the provided PVC, if it doesn't exist yet. If the provided PVC is nil, nothing will be done.
+func createPVCIfMissing(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pvc *corev1.PersistentVolumeClaim, logger logr.Logger) error {
+	if pvc == nil {
+		return nil
+	}
+
+	existingPVC := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: pvc.Namespace, Name: pvc.Name}, existingPVC)
+	if err == nil {
+		return nil
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	owner := internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+	pvc.ObjectMeta.OwnerReferences = owner
+	logger.V(1).Info("Creating PVC", "name", pvc.Name)
+	return r.Create(ctx, pvc)
+}
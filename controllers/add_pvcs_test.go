@@ -139,4 +139,35 @@ var _ = Describe("add_pvcs", func() {
 			Expect(newPVCs.Items).To(HaveLen(len(initialPVCs.Items)))
 		})
 	})
+
+	Context("with a log process group with a log volume claim template", func() {
+		BeforeEach(func() {
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassLog] = fdbv1beta2.ProcessSettings{
+				LogVolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+			}
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus("log-9", "log", nil))
+		})
+
+		It("should not requeue", func() {
+			Expect(requeue).To(BeNil())
+		})
+
+		It("should create the data and the log PVCs", func() {
+			pvcNames := make([]string, 0, len(newPVCs.Items))
+			for _, pvc := range newPVCs.Items {
+				pvcNames = append(pvcNames, pvc.Name)
+			}
+
+			Expect(pvcNames).To(ContainElements("operator-test-1-log-9-data", "operator-test-1-log-9-logs"))
+			for _, pvc := range newPVCs.Items {
+				if pvc.Name != "operator-test-1-log-9-logs" {
+					continue
+				}
+
+				Expect(pvc.Labels[fdbv1beta2.FDBProcessGroupIDLabel]).To(Equal("log-9"))
+				Expect(pvc.Labels[fdbv1beta2.LogVolumeLabel]).To(Equal("true"))
+				Expect(pvc.OwnerReferences).To(Equal(internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)))
+			}
+		})
+	})
 })
@@ -37,7 +37,7 @@ import (
 type addServices struct{}
 
 // reconcile runs the reconciler's work.
-func (a addServices) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (a addServices) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *cachedDatabaseStatus, logger logr.Logger) *requeue {
 	headlessService := internal.GetHeadlessService(cluster)
 	if headlessService != nil {
 		existingService := &corev1.Service{}
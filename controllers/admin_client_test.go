@@ -345,6 +345,18 @@ var _ = Describe("admin_client_test", func() {
 				})
 			})
 
+			Context("with an aborted backup", func() {
+				BeforeEach(func() {
+					err = mockAdminClient.AbortBackup("blobstore://test@test-service/test-backup", fdbv1beta2.DefaultBackupTag)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should mark the backup as stopped", func() {
+					Expect(status.Status.Running).To(BeFalse())
+					Expect(mockAdminClient.BackupAborts).To(Equal(1))
+				})
+			})
+
 			Context("with a modification to the snapshot time", func() {
 				BeforeEach(func() {
 					err = mockAdminClient.ModifyBackup(20)
@@ -374,7 +386,7 @@ var _ = Describe("admin_client_test", func() {
 
 		Context("with a restore running", func() {
 			BeforeEach(func() {
-				err = mockAdminClient.StartRestore("blobstore://test@test-service/test-backup", nil)
+				err = mockAdminClient.StartRestore("blobstore://test@test-service/test-backup", nil, 0)
 				Expect(err).NotTo(HaveOccurred())
 
 				status, err = mockAdminClient.GetRestoreStatus()
@@ -384,6 +396,72 @@ var _ = Describe("admin_client_test", func() {
 			It("should contain the backup URL", func() {
 				Expect(status).To(Equal("blobstore://test@test-service/test-backup\n"))
 			})
+
+			It("should report the restore as running", func() {
+				liveStatus, err := mockAdminClient.GetLiveRestoreStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(liveStatus.GetState()).To(Equal(fdbv1beta2.RestoreStateRunning))
+				Expect(liveStatus.Tag).To(Equal(fdbv1beta2.DefaultBackupTag))
+				Expect(liveStatus.URL).To(Equal("blobstore://test@test-service/test-backup"))
+			})
+
+			When("a progress is defined", func() {
+				BeforeEach(func() {
+					mockAdminClient.RestoreProgress = []fdbv1beta2.FoundationDBLiveRestoreStatus{
+						{State: "queued"},
+						{State: "completed"},
+					}
+				})
+
+				It("should report the progress until the last status is reached", func() {
+					for _, expected := range []fdbv1beta2.RestoreState{fdbv1beta2.RestoreStateQueued, fdbv1beta2.RestoreStateCompleted, fdbv1beta2.RestoreStateCompleted} {
+						liveStatus, err := mockAdminClient.GetLiveRestoreStatus()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(liveStatus.GetState()).To(Equal(expected))
+					}
+				})
+			})
+
+			When("the restore is aborted", func() {
+				BeforeEach(func() {
+					Expect(mockAdminClient.AbortRestore(fdbv1beta2.DefaultBackupTag)).NotTo(HaveOccurred())
+				})
+
+				It("should report the restore as failed", func() {
+					Expect(mockAdminClient.RestoreAborts).To(Equal(1))
+					liveStatus, err := mockAdminClient.GetLiveRestoreStatus()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(liveStatus.GetState()).To(Equal(fdbv1beta2.RestoreStateFailed))
+				})
+
+				It("should ignore a second abort", func() {
+					Expect(mockAdminClient.AbortRestore(fdbv1beta2.DefaultBackupTag)).NotTo(HaveOccurred())
+					Expect(mockAdminClient.RestoreAborts).To(Equal(1))
+				})
+			})
+		})
+	})
+
+	Describe("checking the keyspace", func() {
+		BeforeEach(func() {
+			mockAdminClient.DatabaseKeys = []string{"\x15user1/data", "\xff/system"}
 		})
+
+		DescribeTable("should report if the keyspace is empty",
+			func(keyRanges []fdbv1beta2.FoundationDBKeyRange, expected bool) {
+				empty, err := mockAdminClient.IsKeyspaceEmpty(keyRanges)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(empty).To(Equal(expected))
+			},
+			Entry("the whole keyspace",
+				nil,
+				false),
+			Entry("a key range that contains a key",
+				[]fdbv1beta2.FoundationDBKeyRange{{Start: `\x15user1/`, End: `\x15user2/`}},
+				false),
+			Entry("a key range that contains no key",
+				[]fdbv1beta2.FoundationDBKeyRange{{Start: `\x15user2/`, End: `\x15user3/`}},
+				true),
+		)
 	})
 })
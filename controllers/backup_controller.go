@@ -22,17 +22,25 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -40,6 +48,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// backupRequeueMinDelay defines the delay for the first requeue of a backup that was not fully reconciled.
+	backupRequeueMinDelay = 5 * time.Second
+	// backupRequeueMaxDelay defines the upper limit for the delay of consecutive requeues of a backup that was not
+	// fully reconciled.
+	backupRequeueMaxDelay = 5 * time.Minute
+	// backupOperationRetryDelay defines the delay after which a failed operation against the blobstore will be retried.
+	backupOperationRetryDelay = time.Minute
+)
+
 // FoundationDBBackupReconciler reconciles a FoundationDBCluster object
 type FoundationDBBackupReconciler struct {
 	client.Client
@@ -48,6 +66,9 @@ type FoundationDBBackupReconciler struct {
 	InSimulation           bool
 	DatabaseClientProvider fdbadminclient.DatabaseClientProvider
 	ServerSideApply        bool
+
+	// requeueBackoff tracks the consecutive requeues of backups that are not fully reconciled.
+	requeueBackoff requeueBackoff
 }
 
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbbackups,verbs=get;list;watch;create;update;patch;delete
@@ -67,6 +88,9 @@ func (r *FoundationDBBackupReconciler) Reconcile(ctx context.Context, request ct
 		if k8serrors.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			// The backup was deleted, so the metrics and the requeue backoff of this backup can be removed.
+			metrics.DeleteBackupMetrics(request.Namespace, request.Name)
+			r.requeueBackoff.reset(request.NamespacedName.String())
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -75,33 +99,153 @@ func (r *FoundationDBBackupReconciler) Reconcile(ctx context.Context, request ct
 
 	backupLog := globalControllerLogger.WithValues("namespace", backup.Namespace, "backup", backup.Name)
 
+	err = backup.Validate()
+	if err != nil {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupSpec not valid", err.Error())
+		return ctrl.Result{}, fmt.Errorf("BackupSpec is not valid: %w", err)
+	}
+
+	err = r.validateOtherBackups(ctx, backup)
+	if err != nil {
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "BackupSpec not valid", err.Error())
+		return ctrl.Result{}, fmt.Errorf("BackupSpec is not valid: %w", err)
+	}
+
 	subReconcilers := []backupSubReconciler{
 		updateBackupStatus{},
 		updateBackupAgents{},
 		startBackup{},
 		stopBackup{},
+		abortBackup{},
 		toggleBackupPaused{},
+		expireBackup{},
 		modifyBackup{},
 		updateBackupStatus{},
 	}
 
+	backoffKey := request.NamespacedName.String()
+	delayedRequeue := false
+	var delayedRequeueDelay time.Duration
+
 	for _, subReconciler := range subReconcilers {
-		requeue := subReconciler.reconcile(ctx, r, backup)
+		requeue := runSubReconciler(subReconciler, backup, func() *requeue {
+			return subReconciler.reconcile(ctx, r, backup)
+		})
 		if requeue == nil {
 			continue
 		}
 
+		if requeue.delayedRequeue {
+			backupLog.Info("Delaying requeue for sub-reconciler",
+				"reconciler", fmt.Sprintf("%T", subReconciler),
+				"message", requeue.message,
+				"error", requeue.curError)
+			r.recordDelayedRequeueEvent(backup, subReconciler, requeue)
+			delayedRequeue = true
+			if requeue.delay > delayedRequeueDelay {
+				delayedRequeueDelay = requeue.delay
+			}
+			continue
+		}
+
+		// Errors are retried by the rate limiter of the controller, for all other requeues without a delay we use
+		// the backoff to prevent a tight reconciliation loop.
+		if requeue.curError == nil && requeue.delay == 0 {
+			requeue.delay = r.requeueBackoff.next(backoffKey, backupRequeueMinDelay, backupRequeueMaxDelay)
+		}
+
 		return processRequeue(requeue, subReconciler, backup, r.Recorder, backupLog)
 	}
 
-	if backup.Status.Generations.Reconciled < originalGeneration {
-		backupLog.Info("Backup was not fully reconciled by reconciliation process")
-		return ctrl.Result{Requeue: true}, nil
+	if backup.Status.Generations.Reconciled < originalGeneration || delayedRequeue {
+		requeueAfter := delayedRequeueDelay
+		if requeueAfter == 0 {
+			requeueAfter = r.requeueBackoff.next(backoffKey, backupRequeueMinDelay, backupRequeueMaxDelay)
+		}
+
+		backupLog.Info("Backup was not fully reconciled by reconciliation process",
+			"CurrentGeneration", backup.Status.Generations.Reconciled,
+			"OriginalGeneration", originalGeneration,
+			"DelayedRequeue", delayedRequeue,
+			"RequeueAfter", requeueAfter.String())
+		return ctrl.Result{Requeue: true, RequeueAfter: requeueAfter}, nil
 	}
 
+	r.requeueBackoff.reset(backoffKey)
 	backupLog.Info("Reconciliation complete")
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: getPeriodicRequeueDelay(backup, time.Now())}, nil
+}
+
+// recordDelayedRequeueEvent emits an event for the delayed requeue of the provided sub-reconciler. The event will be a
+// warning if the sub-reconciler returned an error.
+func (r *FoundationDBBackupReconciler) recordDelayedRequeueEvent(backup *fdbv1beta2.FoundationDBBackup, subReconciler backupSubReconciler, requeue *requeue) {
+	eventType := corev1.EventTypeNormal
+	if requeue.curError != nil {
+		eventType = corev1.EventTypeWarning
+	}
+
+	r.Recorder.Event(backup, eventType, "ReconciliationDelayed", fmt.Sprintf("%T delayed the reconciliation: %s", subReconciler, getRequeueMessage(requeue)))
+}
+
+// validateOtherBackups makes sure that the backup doesn't conflict with an older backup for the same cluster in the same
+// namespace. A backup conflicts if it uses the same tag or if it requests a different paused state, as pausing the
+// backup agents is not limited to a tag. In case of a conflict only the oldest backup will be accepted.
+func (r *FoundationDBBackupReconciler) validateOtherBackups(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup) error {
+	backups := &fdbv1beta2.FoundationDBBackupList{}
+	err := r.List(ctx, backups, client.InNamespace(backup.Namespace))
+	if err != nil {
+		return err
+	}
+
+	for _, other := range backups.Items {
+		if other.Name == backup.Name || other.Spec.ClusterName != backup.Spec.ClusterName {
+			continue
+		}
+
+		if !other.CreationTimestamp.Before(&backup.CreationTimestamp) && !(other.CreationTimestamp.Equal(&backup.CreationTimestamp) && other.Name < backup.Name) {
+			continue
+		}
+
+		if other.BackupTag() == backup.BackupTag() {
+			return fmt.Errorf("backup %s already uses the tag %s for cluster %s", other.Name, backup.BackupTag(), backup.Spec.ClusterName)
+		}
+
+		if backup.ShouldRun() && other.ShouldRun() && backup.ShouldBePaused() != other.ShouldBePaused() {
+			return fmt.Errorf("backup %s for cluster %s requests a different paused state, pausing affects all backups of the cluster", other.Name, backup.Spec.ClusterName)
+		}
+	}
+
+	return nil
+}
+
+// getPeriodicRequeueDelay returns the delay after which the backup should be reconciled again, to run the next periodic
+// task like expiring the backup data or updating the restorability details. If no periodic task is scheduled, 0 will be
+// returned.
+func getPeriodicRequeueDelay(backup *fdbv1beta2.FoundationDBBackup, now time.Time) time.Duration {
+	var next time.Time
+	if backup.Spec.RetentionPolicy != nil && backup.Status.LastExpiration != nil {
+		next = backup.NextExpiration()
+	}
+
+	if backup.DescribeIntervalSeconds() > 0 && backup.Status.RestorabilityDetails != nil {
+		nextDescription := backup.NextDescription()
+		if next.IsZero() || nextDescription.Before(next) {
+			next = nextDescription
+		}
+	}
+
+	if next.IsZero() {
+		return 0
+	}
+
+	// If the next task is already due, make sure we reconcile again soon.
+	delay := next.Sub(now)
+	if delay < time.Second {
+		return time.Second
+	}
+
+	return delay
 }
 
 // getDatabaseClientProvider gets the client provider for a reconciler.
@@ -120,12 +264,17 @@ func (r *FoundationDBBackupReconciler) adminClientForBackup(ctx context.Context,
 		return nil, err
 	}
 
+	caFilePath, err := writeBlobCABundle(ctx, r, backup.Namespace, backup.Spec.BlobStoreConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
 	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
 	if err != nil {
 		return nil, err
 	}
 
-	adminClient.SetKnobs(backup.Spec.CustomParameters.GetKnobsForCLI())
+	adminClient.SetKnobs(append(backup.Spec.CustomParameters.GetKnobsForCLI(), backup.Spec.BlobStoreConfiguration.GetTLSArgsForCLI(caFilePath)...))
 
 	return adminClient, nil
 }
@@ -138,6 +287,11 @@ func (r *FoundationDBBackupReconciler) SetupWithManager(mgr ctrl.Manager, maxCon
 	if err != nil {
 		return err
 	}
+	err = metrics.Register(ctrlmetrics.Registry)
+	if err != nil {
+		return err
+	}
+
 	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
 	if err != nil {
 		return err
@@ -149,6 +303,12 @@ func (r *FoundationDBBackupReconciler) SetupWithManager(mgr ctrl.Manager, maxCon
 		).
 		For(&fdbv1beta2.FoundationDBBackup{}).
 		Owns(&appsv1.Deployment{}).
+		// Reconcile the backups of a cluster when the cluster changes, e.g. to
+		// update the desired agent count of the backup deployment.
+		Watches(
+			&source.Kind{Type: &fdbv1beta2.FoundationDBCluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.findFoundationDBBackupsForCluster),
+		).
 		// Only react on generation changes or annotation changes and only watch
 		// resources with the provided label selector.
 		WithEventFilter(
@@ -162,6 +322,28 @@ func (r *FoundationDBBackupReconciler) SetupWithManager(mgr ctrl.Manager, maxCon
 		Complete(r)
 }
 
+// findFoundationDBBackupsForCluster returns the reconcile requests for all FoundationDBBackups in the namespace of the
+// provided cluster that reference the cluster.
+func (r *FoundationDBBackupReconciler) findFoundationDBBackupsForCluster(cluster client.Object) []reconcile.Request {
+	backups := &fdbv1beta2.FoundationDBBackupList{}
+	err := r.List(context.Background(), backups, client.InNamespace(cluster.GetNamespace()))
+	if err != nil {
+		r.Log.Error(err, "could not fetch backups for cluster", "namespace", cluster.GetNamespace(), "cluster", cluster.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(backups.Items))
+	for _, backup := range backups.Items {
+		if backup.Spec.ClusterName != cluster.GetName() {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}})
+	}
+
+	return requests
+}
+
 // backupSubReconciler describes a class that does part of the work of
 // reconciliation for a backup.
 type backupSubReconciler interface {
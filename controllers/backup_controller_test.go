@@ -23,8 +23,14 @@ package controllers
 import (
 	"fmt"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	fdbmetrics "github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -33,8 +39,13 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func reloadBackup(backup *fdbv1beta2.FoundationDBBackup) (int64, error) {
@@ -57,11 +68,25 @@ var _ = Describe("backup_controller", func() {
 	var cluster *fdbv1beta2.FoundationDBCluster
 	var backup *fdbv1beta2.FoundationDBBackup
 	var adminClient *mock.AdminClient
+	var credentialsSecret *corev1.Secret
 	var err error
 
 	BeforeEach(func() {
 		cluster = internal.CreateDefaultCluster()
 		backup = internal.CreateDefaultBackup(cluster)
+		credentialsSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "blob-credentials",
+				Namespace: cluster.Namespace,
+			},
+			Data: map[string][]byte{
+				"credentials": []byte("initial"),
+			},
+		}
+		backup.Spec.BlobStoreConfiguration.CredentialsSecret = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecret.Name},
+			Key:                  "credentials",
+		}
 		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -85,6 +110,7 @@ var _ = Describe("backup_controller", func() {
 			err = k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}, cluster)
 			Expect(err).NotTo(HaveOccurred())
 
+			Expect(k8sClient.Create(context.TODO(), credentialsSecret)).NotTo(HaveOccurred())
 			err = k8sClient.Create(context.TODO(), backup)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -130,9 +156,12 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should update the status on the resource", func() {
+				Expect(backup.Status.RestorabilityDetails).NotTo(BeNil())
+				backup.Status.RestorabilityDetails = nil
 				Expect(backup.Status).To(Equal(fdbv1beta2.FoundationDBBackupStatus{
 					AgentCount:           3,
 					DeploymentConfigured: true,
+					State:                fdbv1beta2.BackupStateRunning,
 					BackupDetails: &fdbv1beta2.FoundationDBBackupStatusBackupDetails{
 						URL:                   "blobstore://test@test-service:443/test-backup?bucket=fdb-backups",
 						Running:               true,
@@ -145,12 +174,154 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should start a backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.DestinationURL).To(Equal("blobstore://test@test-service:443/test-backup?bucket=fdb-backups"))
 				Expect(status.Status.Running).To(BeTrue())
 				Expect(status.BackupAgentsPaused).To(BeFalse())
 			})
+
+			It("should update the restorability details", func() {
+				Expect(adminClient.BackupDescriptions).To(Equal(1))
+				Expect(backup.Status.RestorabilityDetails).NotTo(BeNil())
+				Expect(backup.Status.RestorabilityDetails.Restorable).To(BeTrue())
+				Expect(backup.Status.RestorabilityDetails.LastUpdated.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+
+			It("should pass the blob credentials to the backup", func() {
+				Expect(adminClient.BlobCredentials).To(Equal("initial"))
+				Expect(adminClient.BlobCredentialsPath).NotTo(HavePrefix(fdbv1beta2.BlobCredentialsMountPath))
+				Expect(adminClient.BlobCredentialsPath).NotTo(BeAnExistingFile())
+
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+				Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "FDB_BLOB_CREDENTIALS", Value: "/var/blob-credentials/blob-credentials/credentials"}))
+				Expect(deployment.Spec.Template.ObjectMeta.Annotations).To(HaveKey(fdbv1beta2.LastBlobCredentialsKey))
+			})
+
+			It("should record the backup metrics", func() {
+				Expect(testutil.ToFloat64(fdbmetrics.BackupRunning.WithLabelValues(backup.Namespace, backup.Name))).To(BeNumerically("==", 1))
+				Expect(testutil.ToFloat64(fdbmetrics.BackupPaused.WithLabelValues(backup.Namespace, backup.Name))).To(BeNumerically("==", 0))
+				Expect(testutil.ToFloat64(fdbmetrics.BackupAgentsReady.WithLabelValues(backup.Namespace, backup.Name))).To(BeNumerically("==", 3))
+				Expect(testutil.ToFloat64(fdbmetrics.BackupAgentsDesired.WithLabelValues(backup.Namespace, backup.Name))).To(BeNumerically("==", 3))
+			})
+
+			When("the backup is deleted", func() {
+				JustBeforeEach(func() {
+					Expect(k8sClient.Delete(context.TODO(), backup)).NotTo(HaveOccurred())
+					_, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should remove the backup metrics", func() {
+					// DeleteLabelValues returns false if no metric with the provided labels exists.
+					for _, vec := range []*prometheus.GaugeVec{fdbmetrics.BackupRunning, fdbmetrics.BackupPaused, fdbmetrics.BackupAgentsReady, fdbmetrics.BackupAgentsDesired} {
+						Expect(vec.DeleteLabelValues(backup.Namespace, backup.Name)).To(BeFalse())
+					}
+				})
+			})
+		})
+
+		Context("when the backup has a restorable point", func() {
+			BeforeEach(func() {
+				adminClient.LatestRestorablePoint = &fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{
+					Version:      1000,
+					EpochSeconds: float64(time.Now().Add(-5 * time.Minute).Unix()),
+				}
+				generationGap = 0
+			})
+
+			AfterEach(func() {
+				adminClient.LatestRestorablePoint = nil
+			})
+
+			It("should report the backup lag in the status", func() {
+				Expect(backup.Status.BackupDetails).NotTo(BeNil())
+				Expect(backup.Status.BackupDetails.Lag).NotTo(BeNil())
+				Expect(backup.Status.BackupDetails.Lag.Duration).To(BeNumerically(">=", 5*time.Minute))
+				Expect(backup.Status.BackupDetails.Lag.Duration).To(BeNumerically("<", 6*time.Minute))
+			})
+
+			It("should record the timestamp of the latest restorable point", func() {
+				Expect(testutil.ToFloat64(fdbmetrics.BackupLatestRestorableTimestamp.WithLabelValues(backup.Namespace, backup.Name))).To(BeNumerically("==", adminClient.LatestRestorablePoint.EpochSeconds))
+			})
+		})
+
+		When("the backup data has snapshots", func() {
+			BeforeEach(func() {
+				adminClient.BackupDescription = &fdbv1beta2.FoundationDBBackupDescription{
+					Restorable: true,
+					Snapshots: []fdbv1beta2.FoundationDBBackupDescriptionSnapshot{
+						{
+							Start:      fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{Version: 1000, EpochSeconds: 1709287200},
+							Restorable: true,
+							TotalBytes: 1024,
+						},
+					},
+					TotalSnapshotBytes: 1024,
+					MaxRestorablePoint: &fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{Version: 2000, EpochSeconds: 1709290800},
+				}
+				// Reset the restorability details from the initial reconciliation.
+				backup.Status.RestorabilityDetails = nil
+				Expect(k8sClient.Status().Update(context.TODO(), backup)).NotTo(HaveOccurred())
+
+				backup.Spec.DescribeIntervalSeconds = pointer.Int(60)
+				Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				adminClient.BackupDescription = nil
+			})
+
+			It("should update the restorability details", func() {
+				Expect(backup.Status.RestorabilityDetails).NotTo(BeNil())
+				Expect(backup.Status.RestorabilityDetails.SnapshotBytes).To(Equal(int64(1024)))
+				Expect(backup.Status.RestorabilityDetails.LatestRestorableVersion).To(Equal(int64(2000)))
+				Expect(backup.Status.RestorabilityDetails.LatestRestorableTimestamp.Unix()).To(Equal(int64(1709290800)))
+				Expect(backup.Status.RestorabilityDetails.LatestSnapshotStartTimestamp.Unix()).To(Equal(int64(1709287200)))
+			})
+
+			It("should not describe the backup again within the describe interval", func() {
+				describeCalls := adminClient.BackupDescriptions
+				result, err := reconcileBackup(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(adminClient.BackupDescriptions).To(Equal(describeCalls))
+				Expect(result.RequeueAfter).To(BeNumerically("~", time.Minute, 5*time.Second))
+			})
+
+			It("should pass the blob credentials to the describe command", func() {
+				Expect(adminClient.BlobCredentials).To(Equal("initial"))
+			})
+		})
+
+		When("the backup cannot be described", func() {
+			BeforeEach(func() {
+				adminClient.DescribeBackupError = fmt.Errorf("could not connect to blobstore")
+				// Reset the restorability details from the initial reconciliation.
+				backup.Status.RestorabilityDetails = nil
+				Expect(k8sClient.Status().Update(context.TODO(), backup)).NotTo(HaveOccurred())
+
+				backup.Spec.DescribeIntervalSeconds = pointer.Int(60)
+				Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				adminClient.DescribeBackupError = nil
+			})
+
+			It("should record the failed attempt", func() {
+				Expect(backup.Status.RestorabilityDetails).NotTo(BeNil())
+				Expect(backup.Status.RestorabilityDetails.Error).To(Equal("could not connect to blobstore"))
+				Expect(backup.Status.RestorabilityDetails.LastAttempt.Time).To(BeTemporally("~", time.Now(), time.Minute))
+				Expect(backup.Status.RestorabilityDetails.LastUpdated.IsZero()).To(BeTrue())
+			})
+
+			It("should not describe the backup again within the describe interval", func() {
+				describeCalls := adminClient.BackupDescriptions
+				_, err := reconcileBackup(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(adminClient.BackupDescriptions).To(Equal(describeCalls))
+			})
 		})
 
 		Context("with a nil backup agent count", func() {
@@ -169,6 +340,97 @@ var _ = Describe("backup_controller", func() {
 			})
 		})
 
+		When("the agent count is derived from the cluster size", func() {
+			BeforeEach(func() {
+				backup.Spec.AgentCount = nil
+				backup.Spec.AgentScaling = &fdbv1beta2.BackupAgentScaling{
+					Mode:                    fdbv1beta2.BackupAgentScalingModeAuto,
+					AgentsPerStorageProcess: resource.NewQuantity(1, resource.DecimalSI),
+					Min:                     pointer.Int(1),
+				}
+				Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+			})
+
+			It("should set the replica count based on the storage processes", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: backup.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+				Expect(*deployment.Spec.Replicas).To(Equal(int32(4)))
+				Expect(backup.Status.DesiredAgentCount).To(Equal(4))
+			})
+
+			When("the cluster is scaled up", func() {
+				BeforeEach(func() {
+					cluster.Spec.ProcessCounts.Storage = 6
+					Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				})
+
+				It("should update the replica count", func() {
+					deployment := &appsv1.Deployment{}
+					Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: backup.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+					Expect(*deployment.Spec.Replicas).To(Equal(int32(6)))
+					Expect(backup.Status.DesiredAgentCount).To(Equal(6))
+				})
+			})
+
+			When("the rounded agent count doesn't change", func() {
+				var deploymentGeneration int64
+
+				BeforeEach(func() {
+					backup.Spec.AgentScaling.AgentsPerStorageProcess = resource.NewMilliQuantity(250, resource.DecimalSI)
+					Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+					_, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					originalVersion++
+
+					deployment := &appsv1.Deployment{}
+					Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: backup.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+					Expect(*deployment.Spec.Replicas).To(Equal(int32(1)))
+					deploymentGeneration = deployment.Generation
+
+					cluster.Spec.ProcessCounts.Storage = 5
+					Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				})
+
+				It("should not update the deployment", func() {
+					deployment := &appsv1.Deployment{}
+					Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: backup.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+					Expect(*deployment.Spec.Replicas).To(Equal(int32(1)))
+					Expect(deployment.Generation).To(Equal(deploymentGeneration))
+				})
+			})
+		})
+
+		When("the cluster uses the unified image", func() {
+			BeforeEach(func() {
+				cluster.Spec.UseUnifiedImage = pointer.Bool(true)
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				generationGap = 0
+			})
+
+			It("should use the unified image for the backup agents", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: backup.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+				Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal(fmt.Sprintf("foundationdb/foundationdb-kubernetes:%s", cluster.Spec.Version)))
+				Expect(deployment.Spec.Template.Spec.InitContainers[0].Image).To(Equal(fmt.Sprintf("foundationdb/foundationdb-kubernetes:%s", cluster.Spec.Version)))
+				Expect(deployment.Spec.Template.Spec.InitContainers[0].Args).To(ContainElements("--mode", "init"))
+			})
+
+			When("the backup disables the unified image", func() {
+				BeforeEach(func() {
+					backup.Spec.UseUnifiedImage = pointer.Bool(false)
+					Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+					generationGap = 1
+				})
+
+				It("should use the split image for the backup agents", func() {
+					deployment := &appsv1.Deployment{}
+					Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: backup.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+					Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal(fmt.Sprintf("foundationdb/foundationdb:%s", cluster.Spec.Version)))
+					Expect(deployment.Spec.Template.Spec.InitContainers[0].Image).To(Equal(fmt.Sprintf("foundationdb/foundationdb-kubernetes-sidecar:%s-1", cluster.Spec.Version)))
+				})
+			})
+		})
+
 		Context("with backup agent count of zero", func() {
 			BeforeEach(func() {
 				agentCount := 0
@@ -193,9 +455,55 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should stop the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Status.Running).To(BeFalse())
+			})
+		})
+
+		When("aborting a backup", func() {
+			BeforeEach(func() {
+				backup.Spec.BackupState = fdbv1beta2.BackupStateAborted
+				Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+			})
+
+			It("should abort the backup", func() {
+				Expect(adminClient.BackupAborts).To(Equal(1))
+
+				status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.Status.Running).To(BeFalse())
+				Expect(backup.Status.State).To(Equal(fdbv1beta2.BackupStateAborted))
+			})
+
+			It("should not abort the backup again", func() {
+				_, err := reconcileBackup(backup)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reloadBackup(backup)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(adminClient.BackupAborts).To(Equal(1))
+				Expect(backup.Status.State).To(Equal(fdbv1beta2.BackupStateAborted))
+			})
+
+			When("the backup is started again", func() {
+				JustBeforeEach(func() {
+					backup.Spec.BackupState = fdbv1beta2.BackupStateRunning
+					Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+					_, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should start a new backup", func() {
+					status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Status.Running).To(BeTrue())
+					Expect(adminClient.BackupAborts).To(Equal(1))
+					Expect(backup.Status.State).To(Equal(fdbv1beta2.BackupStateRunning))
+					Expect(backup.Status.Generations.Reconciled).To(Equal(backup.Generation))
+				})
 			})
 		})
 
@@ -207,7 +515,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should pause the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.BackupAgentsPaused).To(BeTrue())
 			})
@@ -224,7 +532,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should resume the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.BackupAgentsPaused).To(BeFalse())
 			})
@@ -239,12 +547,174 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should modify the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.SnapshotIntervalSeconds).To(Equal(100000))
 			})
 		})
 
+		When("changing the key ranges of a backup", func() {
+			var keyRanges []fdbv1beta2.FoundationDBKeyRange
+
+			BeforeEach(func() {
+				Expect(adminClient.BackupKeyRanges[backup.BackupTag()]).To(BeEmpty())
+
+				keyRanges = []fdbv1beta2.FoundationDBKeyRange{
+					{Start: `\x15user1/`, End: `\x15user2/`},
+				}
+				backup.Spec.KeyRanges = keyRanges
+				Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+			})
+
+			It("should discontinue the backup and record the restarting state", func() {
+				Expect(adminClient.BackupKeyRanges[backup.BackupTag()]).To(BeEmpty())
+				Expect(backup.Status.State).To(Equal(fdbv1beta2.BackupStateRestarting))
+			})
+
+			When("the backup is still stopping", func() {
+				JustBeforeEach(func() {
+					// The discontinued backup is reported as running until the current snapshot is complete.
+					adminClient.Backups[backup.BackupTag()] = fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+						URL:                   backup.BackupURL(),
+						Running:               true,
+						SnapshotPeriodSeconds: backup.SnapshotPeriodSeconds(),
+					}
+
+					_, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should not discontinue the backup again", func() {
+					status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Status.Running).To(BeTrue())
+					Expect(adminClient.BackupKeyRanges[backup.BackupTag()]).To(BeEmpty())
+					Expect(backup.Status.State).To(Equal(fdbv1beta2.BackupStateRestarting))
+				})
+			})
+
+			When("the backup is stopped", func() {
+				JustBeforeEach(func() {
+					adminClient.BlobCredentials = ""
+
+					_, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should restart the backup with the new key ranges", func() {
+					Expect(adminClient.BackupKeyRanges[backup.BackupTag()]).To(Equal(keyRanges))
+					Expect(adminClient.BlobCredentials).To(Equal("initial"))
+
+					status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Status.Running).To(BeTrue())
+				})
+
+				It("should record the active key ranges in the status", func() {
+					Expect(backup.Status.KeyRanges).To(Equal(keyRanges))
+					Expect(backup.Status.State).To(Equal(fdbv1beta2.BackupStateRunning))
+					Expect(backup.Status.Generations.Reconciled).To(Equal(backup.Generation))
+				})
+			})
+		})
+
+		When("another backup for the cluster uses a different tag", func() {
+			var otherBackup *fdbv1beta2.FoundationDBBackup
+
+			BeforeEach(func() {
+				otherBackup = internal.CreateDefaultBackup(cluster)
+				otherBackup.Name = cluster.Name + "-other"
+				otherBackup.Spec.Tag = "other"
+				otherBackup.Spec.BlobStoreConfiguration.BackupName = "other-backup"
+				otherBackup.Spec.BlobStoreConfiguration.CredentialsSecret = backup.Spec.BlobStoreConfiguration.CredentialsSecret
+				Expect(k8sClient.Create(context.TODO(), otherBackup)).NotTo(HaveOccurred())
+
+				_, err := reconcileBackup(otherBackup)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reloadBackup(otherBackup)
+				Expect(err).NotTo(HaveOccurred())
+
+				backup.Spec.BackupState = fdbv1beta2.BackupStateStopped
+				Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+			})
+
+			It("should only stop the backup with the matching tag", func() {
+				status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Status.Running).To(BeFalse())
+
+				otherStatus, err := adminClient.GetBackupStatusWithTag("other")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(otherStatus.Status.Running).To(BeTrue())
+				Expect(otherStatus.DestinationURL).To(Equal("blobstore://test@test-service:443/other-backup?bucket=fdb-backups"))
+			})
+
+			It("should report the status of the matching tag", func() {
+				Expect(backup.Status.BackupDetails.URL).To(Equal("blobstore://test@test-service:443/test-backup?bucket=fdb-backups"))
+				Expect(otherBackup.Status.BackupDetails.URL).To(Equal("blobstore://test@test-service:443/other-backup?bucket=fdb-backups"))
+				Expect(otherBackup.Status.BackupDetails.Running).To(BeTrue())
+			})
+		})
+
+		When("a retention policy is defined", func() {
+			BeforeEach(func() {
+				backup.Spec.RetentionPolicy = &fdbv1beta2.BackupRetentionPolicy{
+					ExpireBeforeDays:  30,
+					MinRestorableDays: pointer.Int(7),
+				}
+				backup.Spec.DescribeIntervalSeconds = pointer.Int(0)
+				Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+			})
+
+			It("should expire the backup data", func() {
+				Expect(adminClient.BackupExpirations).To(Equal(1))
+				Expect(adminClient.LastBackupExpireBefore).To(BeTemporally("~", time.Now().AddDate(0, 0, -30), time.Minute))
+				Expect(adminClient.LastBackupRestorableAfter).To(BeTemporally("~", time.Now().AddDate(0, 0, -7), time.Minute))
+				Expect(adminClient.BlobCredentials).To(Equal("initial"))
+				Expect(adminClient.BlobCredentialsPath).NotTo(BeAnExistingFile())
+
+				Expect(backup.Status.LastExpiration).NotTo(BeNil())
+				Expect(backup.Status.LastExpiration.Timestamp.Time).To(BeTemporally("~", time.Now(), time.Minute))
+				Expect(backup.Status.LastExpiration.Output).NotTo(BeEmpty())
+				Expect(backup.Status.LastExpiration.Error).To(BeEmpty())
+			})
+
+			When("the backup is reconciled again within the expiration interval", func() {
+				var result reconcile.Result
+
+				JustBeforeEach(func() {
+					result, err = reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should not expire the backup data again", func() {
+					Expect(adminClient.BackupExpirations).To(Equal(1))
+					Expect(result.RequeueAfter).To(BeNumerically("~", 24*time.Hour, time.Minute))
+				})
+			})
+
+			When("the last expiration is older than the expiration interval", func() {
+				JustBeforeEach(func() {
+					backup.Status.LastExpiration.Timestamp = metav1.NewTime(time.Now().Add(-25 * time.Hour))
+					Expect(k8sClient.Status().Update(context.TODO(), backup)).NotTo(HaveOccurred())
+
+					_, err = reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should expire the backup data again", func() {
+					Expect(adminClient.BackupExpirations).To(Equal(2))
+					Expect(backup.Status.LastExpiration.Timestamp.Time).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+			})
+		})
+
 		Context("when changing labels", func() {
 			BeforeEach(func() {
 				backup.Spec.BackupDeploymentMetadata = &metav1.ObjectMeta{
@@ -292,11 +762,77 @@ var _ = Describe("backup_controller", func() {
 				Expect(deployments.Items[0].ObjectMeta.Annotations).To(Equal(map[string]string{
 					"fdb-test-1":                         "test-value-1",
 					"fdb-test-2":                         "test-value-2",
-					"foundationdb.org/last-applied-spec": "a1d6ee086624f097243eb14aefbedf84a9016ba0f85640f11020bf50ec9e6d9b",
+					"foundationdb.org/last-applied-spec": "015393d57f50862a6407ad62b91ed62752326f524595bc7c588a6e7a87c26dd6",
 				}))
 			})
 		})
 
+		When("changing the scheduling of the backup agents", func() {
+			var initialHash string
+
+			BeforeEach(func() {
+				deployments := &appsv1.DeploymentList{}
+				Expect(k8sClient.List(context.TODO(), deployments)).NotTo(HaveOccurred())
+				Expect(deployments.Items).To(HaveLen(1))
+				initialHash = deployments.Items[0].ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]
+
+				backup.Spec.AgentScheduling = &fdbv1beta2.BackupAgentScheduling{
+					Tolerations: []corev1.Toleration{
+						{
+							Key:      "dedicated",
+							Operator: corev1.TolerationOpEqual,
+							Value:    "backup",
+							Effect:   corev1.TaintEffectNoSchedule,
+						},
+					},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       corev1.LabelHostname,
+							WhenUnsatisfiable: corev1.ScheduleAnyway,
+						},
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+			})
+
+			It("should update the pod template of the deployment", func() {
+				deployments := &appsv1.DeploymentList{}
+				Expect(k8sClient.List(context.TODO(), deployments)).NotTo(HaveOccurred())
+				Expect(deployments.Items).To(HaveLen(1))
+				deployment := deployments.Items[0]
+
+				Expect(deployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]).NotTo(Equal(initialHash))
+				Expect(deployment.Spec.Template.Spec.Tolerations).To(ConsistOf(backup.Spec.AgentScheduling.Tolerations))
+				Expect(deployment.Spec.Template.Spec.TopologySpreadConstraints).To(ConsistOf(corev1.TopologySpreadConstraint{
+					MaxSkew:           1,
+					TopologyKey:       corev1.LabelHostname,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector:     deployment.Spec.Selector,
+				}))
+			})
+		})
+
+		When("the blob credentials are rotated", func() {
+			var initialDeployment *appsv1.Deployment
+
+			BeforeEach(func() {
+				initialDeployment = &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: internal.GetBackupDeploymentName(backup)}, initialDeployment)).NotTo(HaveOccurred())
+
+				credentialsSecret.Data["credentials"] = []byte("rotated")
+				Expect(k8sClient.Update(context.TODO(), credentialsSecret)).NotTo(HaveOccurred())
+				generationGap = 0
+			})
+
+			It("should update the pod template of the deployment", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+				Expect(deployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]).NotTo(Equal(initialDeployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]))
+				Expect(deployment.Spec.Template.ObjectMeta.Annotations[fdbv1beta2.LastBlobCredentialsKey]).NotTo(Equal(initialDeployment.Spec.Template.ObjectMeta.Annotations[fdbv1beta2.LastBlobCredentialsKey]))
+			})
+		})
+
 		When("providing custom parameters", func() {
 			BeforeEach(func() {
 				backup.Spec.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{
@@ -311,5 +847,247 @@ var _ = Describe("backup_controller", func() {
 				Expect(adminClient.Knobs).To(HaveKey("--knob_http_verbose_level=3"))
 			})
 		})
+
+		When("providing a CA bundle for the blobstore", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.TODO(), &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "blob-ca",
+						Namespace: backup.Namespace,
+					},
+					Data: map[string][]byte{
+						"ca.pem": []byte("blob-ca"),
+					},
+				})).NotTo(HaveOccurred())
+
+				backup.Spec.BlobStoreConfiguration.CABundle = &fdbv1beta2.BlobStoreCABundle{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+						Key:                  "ca.pem",
+					},
+				}
+				err = k8sClient.Update(context.TODO(), backup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should pass a copy of the CA bundle to the command", func() {
+				Expect(adminClient.Knobs).To(HaveKey("--tls_ca_file"))
+				caFilePath := filepath.Join(os.TempDir(), "blob-ca-bundle", backup.Namespace, "secret", "blob-ca", "ca.pem")
+				Expect(adminClient.Knobs).To(HaveKey(caFilePath))
+				Expect(os.ReadFile(caFilePath)).To(Equal([]byte("blob-ca")))
+			})
+
+			It("should mount the CA bundle in the backup agents", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: internal.GetBackupDeploymentName(backup)}, deployment)).NotTo(HaveOccurred())
+				Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: "/var/blob-ca-bundle/blob-ca/ca.pem"}))
+			})
+		})
+	})
+
+	When("starting the backup fails", func() {
+		var result reconcile.Result
+		var reconcileErr error
+
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(context.TODO(), credentialsSecret)).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(context.TODO(), backup)).NotTo(HaveOccurred())
+
+			adminClient.StartBackupError = fmt.Errorf("could not connect to blobstore")
+			backupReconciler.requeueBackoff.reset(client.ObjectKeyFromObject(backup).String())
+		})
+
+		JustBeforeEach(func() {
+			result, reconcileErr = backupReconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(backup)})
+		})
+
+		It("should delay the requeue", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(backupOperationRetryDelay))
+
+			events := &corev1.EventList{}
+			Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+			var delayedEvents []corev1.Event
+			for _, event := range events.Items {
+				if event.InvolvedObject.Name == backup.Name && event.Reason == "ReconciliationDelayed" {
+					delayedEvents = append(delayedEvents, event)
+				}
+			}
+
+			Expect(delayedEvents).To(HaveLen(1))
+			Expect(delayedEvents[0].Type).To(Equal(corev1.EventTypeWarning))
+			Expect(delayedEvents[0].Message).To(ContainSubstring("could not connect to blobstore"))
+		})
+
+		It("should record the failed operation", func() {
+			Expect(testutil.ToFloat64(fdbmetrics.BackupOperationFailures.WithLabelValues(backup.Namespace, backup.Name, "start"))).To(BeNumerically(">=", 1))
+		})
+
+		When("the blobstore is reachable again", func() {
+			JustBeforeEach(func() {
+				adminClient.StartBackupError = nil
+				result, reconcileErr = backupReconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(backup)})
+			})
+
+			It("should start the backup and only requeue for the periodic tasks", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeFalse())
+				_, err := reloadBackup(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", getPeriodicRequeueDelay(backup, time.Now()), 5*time.Second))
+
+				status, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Status.Running).To(BeTrue())
+			})
+		})
+	})
+
+	When("the account requires blob credentials but none are provided", func() {
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			backup.Spec.BlobStoreConfiguration.CredentialsSecret = nil
+			Expect(k8sClient.Create(context.TODO(), backup)).NotTo(HaveOccurred())
+		})
+
+		It("should reject the backup spec", func() {
+			_, err := reconcileBackup(backup)
+			Expect(err).To(MatchError(ContainSubstring("BackupSpec is not valid")))
+
+			deployments := &appsv1.DeploymentList{}
+			Expect(k8sClient.List(context.TODO(), deployments)).NotTo(HaveOccurred())
+			Expect(deployments.Items).To(BeEmpty())
+		})
+	})
+
+	When("another backup for the cluster uses the same tag", func() {
+		var duplicateBackup *fdbv1beta2.FoundationDBBackup
+
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(context.TODO(), credentialsSecret)).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(context.TODO(), backup)).NotTo(HaveOccurred())
+
+			duplicateBackup = internal.CreateDefaultBackup(cluster)
+			duplicateBackup.Name = cluster.Name + "-duplicate"
+			duplicateBackup.Spec.BlobStoreConfiguration.CredentialsSecret = backup.Spec.BlobStoreConfiguration.CredentialsSecret
+			Expect(k8sClient.Create(context.TODO(), duplicateBackup)).NotTo(HaveOccurred())
+		})
+
+		It("should reject the newer backup", func() {
+			_, err := reconcileBackup(duplicateBackup)
+			Expect(err).To(MatchError(ContainSubstring("already uses the tag default")))
+
+			_, err = reconcileBackup(backup)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("another backup for the cluster requests a different paused state", func() {
+		var otherBackup *fdbv1beta2.FoundationDBBackup
+
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(context.TODO(), credentialsSecret)).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(context.TODO(), backup)).NotTo(HaveOccurred())
+
+			otherBackup = internal.CreateDefaultBackup(cluster)
+			otherBackup.Name = cluster.Name + "-other"
+			otherBackup.Spec.Tag = "other"
+			otherBackup.Spec.BackupState = fdbv1beta2.BackupStatePaused
+			otherBackup.Spec.BlobStoreConfiguration.CredentialsSecret = backup.Spec.BlobStoreConfiguration.CredentialsSecret
+			Expect(k8sClient.Create(context.TODO(), otherBackup)).NotTo(HaveOccurred())
+		})
+
+		It("should reject the newer backup", func() {
+			_, err := reconcileBackup(otherBackup)
+			Expect(err).To(MatchError(ContainSubstring("requests a different paused state")))
+
+			_, err = reconcileBackup(backup)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	DescribeTable("getting the periodic requeue delay", func(backup *fdbv1beta2.FoundationDBBackup, expected time.Duration) {
+		Expect(getPeriodicRequeueDelay(backup, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))).To(Equal(expected))
+	},
+		Entry("no periodic task is scheduled",
+			&fdbv1beta2.FoundationDBBackup{
+				Spec: fdbv1beta2.FoundationDBBackupSpec{
+					DescribeIntervalSeconds: pointer.Int(0),
+				},
+			},
+			time.Duration(0),
+		),
+		Entry("the restorability details are due in 30 minutes",
+			&fdbv1beta2.FoundationDBBackup{
+				Status: fdbv1beta2.FoundationDBBackupStatus{
+					RestorabilityDetails: &fdbv1beta2.BackupRestorabilityDetails{
+						LastUpdated: metav1.NewTime(time.Date(2024, 3, 1, 11, 30, 0, 0, time.UTC)),
+					},
+				},
+			},
+			30*time.Minute,
+		),
+		Entry("the expiration is due before the restorability details",
+			&fdbv1beta2.FoundationDBBackup{
+				Spec: fdbv1beta2.FoundationDBBackupSpec{
+					RetentionPolicy: &fdbv1beta2.BackupRetentionPolicy{
+						ExpireBeforeDays: 30,
+					},
+				},
+				Status: fdbv1beta2.FoundationDBBackupStatus{
+					LastExpiration: &fdbv1beta2.BackupExpirationStatus{
+						Timestamp: metav1.NewTime(time.Date(2024, 2, 29, 12, 10, 0, 0, time.UTC)),
+					},
+					RestorabilityDetails: &fdbv1beta2.BackupRestorabilityDetails{
+						LastUpdated: metav1.NewTime(time.Date(2024, 3, 1, 11, 30, 0, 0, time.UTC)),
+					},
+				},
+			},
+			10*time.Minute,
+		),
+		Entry("the restorability details are overdue",
+			&fdbv1beta2.FoundationDBBackup{
+				Status: fdbv1beta2.FoundationDBBackupStatus{
+					RestorabilityDetails: &fdbv1beta2.BackupRestorabilityDetails{
+						LastUpdated: metav1.NewTime(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)),
+					},
+				},
+			},
+			time.Second,
+		),
+	)
+})
+
+var _ = Describe("findFoundationDBBackupsForCluster", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var requests []reconcile.Request
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+
+		backup := internal.CreateDefaultBackup(cluster)
+		Expect(k8sClient.Create(context.TODO(), backup)).NotTo(HaveOccurred())
+
+		otherBackup := internal.CreateDefaultBackup(cluster)
+		otherBackup.Name = "other-backup"
+		otherBackup.Spec.ClusterName = "other-cluster"
+		Expect(k8sClient.Create(context.TODO(), otherBackup)).NotTo(HaveOccurred())
+
+		otherNamespaceBackup := internal.CreateDefaultBackup(cluster)
+		otherNamespaceBackup.Namespace = "other-namespace"
+		Expect(k8sClient.Create(context.TODO(), otherNamespaceBackup)).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		requests = backupReconciler.findFoundationDBBackupsForCluster(cluster)
+	})
+
+	It("should only return the backups of the cluster", func() {
+		Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}}))
 	})
 })
@@ -0,0 +1,151 @@
+/*
+ * blob_credentials.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// writeBlobCredentials reads the blob credentials from the secret referenced in the blob store configuration and
+// writes them into a temporary file that can be passed to the fdbbackup and fdbrestore commands of the operator. The
+// credentials secret is only mounted in the backup agent Pods, so the operator must use its own copy. If no secret is
+// referenced an empty path is returned, in this case the commands fall back to the FDB_BLOB_CREDENTIALS of the
+// operator. The returned function removes the temporary file and must be called once the command is done.
+func writeBlobCredentials(ctx context.Context, reader client.Reader, namespace string, blobStoreConfiguration *fdbv1beta2.BlobStoreConfiguration) (string, func(), error) {
+	if blobStoreConfiguration == nil || blobStoreConfiguration.CredentialsSecret == nil {
+		return "", func() {}, nil
+	}
+
+	credentialsSecret := blobStoreConfiguration.CredentialsSecret
+	secret := &corev1.Secret{}
+	err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: credentialsSecret.Name}, secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	credentials, ok := secret.Data[credentialsSecret.Key]
+	if !ok {
+		return "", nil, fmt.Errorf("secret %s/%s has no key %s", namespace, credentialsSecret.Name, credentialsSecret.Key)
+	}
+
+	file, err := os.CreateTemp("", "blob-credentials-*.json")
+	if err != nil {
+		return "", nil, err
+	}
+
+	cleanup := func() {
+		_ = os.Remove(file.Name())
+	}
+
+	_, err = file.Write(credentials)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return file.Name(), cleanup, nil
+}
+
+// writeBlobCABundle reads the CA bundle from the Secret or ConfigMap referenced in the blob store configuration and
+// writes it into a file that can be passed to the fdbbackup and fdbrestore commands of the operator. The CA bundle is
+// only mounted in the backup agent Pods, so the operator must use its own copy. The file is stored at a stable path per
+// reference and is replaced on every call, so it stays available for all commands of the admin client. If no CA bundle
+// is referenced an empty path is returned.
+func writeBlobCABundle(ctx context.Context, reader client.Reader, namespace string, blobStoreConfiguration *fdbv1beta2.BlobStoreConfiguration) (string, error) {
+	if blobStoreConfiguration == nil || blobStoreConfiguration.CABundle == nil {
+		return "", nil
+	}
+
+	var kind, name, key string
+	var bundle []byte
+	caBundle := blobStoreConfiguration.CABundle
+	if caBundle.SecretKeyRef != nil {
+		kind, name, key = "secret", caBundle.SecretKeyRef.Name, caBundle.SecretKeyRef.Key
+		secret := &corev1.Secret{}
+		err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret)
+		if err != nil {
+			return "", err
+		}
+
+		var ok bool
+		bundle, ok = secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("secret %s/%s has no key %s", namespace, name, key)
+		}
+	} else if caBundle.ConfigMapKeyRef != nil {
+		kind, name, key = "configmap", caBundle.ConfigMapKeyRef.Name, caBundle.ConfigMapKeyRef.Key
+		configMap := &corev1.ConfigMap{}
+		err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, configMap)
+		if err != nil {
+			return "", err
+		}
+
+		data, ok := configMap.Data[key]
+		if !ok {
+			return "", fmt.Errorf("config map %s/%s has no key %s", namespace, name, key)
+		}
+		bundle = []byte(data)
+	} else {
+		return "", nil
+	}
+
+	directory := filepath.Join(os.TempDir(), "blob-ca-bundle", namespace, kind, name)
+	err := os.MkdirAll(directory, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	// Write the bundle into a temporary file first and rename it afterwards, so a concurrent command never reads a
+	// partially written bundle.
+	file, err := os.CreateTemp(directory, key+"-*")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = file.Write(bundle)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(file.Name(), filepath.Join(directory, key))
+	}
+
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+
+	return filepath.Join(directory, key), nil
+}
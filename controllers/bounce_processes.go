@@ -38,12 +38,18 @@ import (
 	"k8s.io/utils/pointer"
 )
 
+// monitorRestartGracePeriod defines how long the operator waits for fdbmonitor to restart the processes after a
+// configuration change, before the operator bounces them.
+const monitorRestartGracePeriod = 2 * time.Minute
+
 // bounceProcesses provides a reconciliation step for bouncing fdbserver
 // processes.
 type bounceProcesses struct{}
 
 // reconcile runs the reconciler's work.
-func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (bounceProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	if !pointer.BoolDeref(cluster.Spec.AutomationOptions.KillProcesses, true) {
 		return nil
 	}
@@ -60,6 +66,8 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		cachedStatus.record(status)
 	}
 
 	currentMinimumUptime, addressMap, err := fdbstatus.GetMinimumUptimeAndAddressMap(logger, cluster, status, r.EnableRecoveryState)
@@ -67,11 +75,23 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 		return &requeue{curError: err}
 	}
 
-	addresses, req := getProcessesReadyForRestart(logger, cluster, addressMap)
+	addresses, req := getProcessesReadyForRestart(logger, cluster, addressMap, r.getBounceFilterConditions(cluster))
 	if req != nil {
 		return req
 	}
 
+	var rotatedPods []*corev1.Pod
+	var waitingForSecretSync bool
+	if r.EnableTLSSecretWatch && !cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
+		var rotatedAddresses []fdbv1beta2.ProcessAddress
+		rotatedAddresses, rotatedPods, waitingForSecretSync, err = r.getProcessesWithRotatedTLSSecrets(ctx, logger, cluster, addressMap)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		addresses = append(addresses, rotatedAddresses...)
+	}
+
 	// Only perform the check if the cluster controller must be restarted if the cluster was up long enough. This is an
 	// additional safety guard to reduce the risk of successive restarts in cases where unidirectional partitions occur.
 	if currentMinimumUptime > r.MinimumRequiredUptimeCCBounce.Seconds() {
@@ -87,6 +107,10 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 	}
 
 	if len(addresses) == 0 {
+		if waitingForSecretSync {
+			return &requeue{message: "Waiting for rotated secrets to be synced to the Pods", delay: 15 * time.Second, delayedRequeue: true}
+		}
+
 		return nil
 	}
 
@@ -176,6 +200,14 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 		return &requeue{curError: err}
 	}
 
+	// Mark the Pods as updated once the processes were restarted with the rotated secrets.
+	for _, pod := range rotatedPods {
+		err = r.PodLifecycleManager.UpdateMetadata(ctx, r, cluster, pod)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
 	// If the cluster was upgraded we will requeue and let the update_status command set the correct version.
 	// Updating the version in this method has the drawback that we upgrade the version independent of the success
 	// of the kill command. The kill command is not reliable, which means that some kill request might not be
@@ -184,20 +216,48 @@ func (bounceProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconc
 		return &requeue{message: "fetch latest status after upgrade"}
 	}
 
+	if waitingForSecretSync {
+		return &requeue{message: "Waiting for rotated secrets to be synced to the Pods", delay: 15 * time.Second, delayedRequeue: true}
+	}
+
 	return nil
 }
 
+// getBounceFilterConditions returns the filter conditions to get the processes that should be restarted. If the
+// reconciler defines BounceBlockingConditions, those conditions replace the default set of conditions that prevent a
+// process group from being bounced. During version incompatible upgrades the default conditions are always used, as
+// all processes must be restarted at once with the new binaries in place.
+func (r *FoundationDBClusterReconciler) getBounceFilterConditions(cluster *fdbv1beta2.FoundationDBCluster) map[fdbv1beta2.ProcessGroupConditionType]bool {
+	filterConditions := restarts.GetFilterConditions(cluster)
+	if r.BounceBlockingConditions == nil || cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
+		return filterConditions
+	}
+
+	for conditionType, expected := range filterConditions {
+		if !expected {
+			delete(filterConditions, conditionType)
+		}
+	}
+
+	for _, conditionType := range r.BounceBlockingConditions {
+		filterConditions[conditionType] = false
+	}
+
+	return filterConditions
+}
+
 // getProcessesReadyForRestart returns a slice of process addresses that can be restarted. If addresses are missing or not all processes
 // have the latest configuration this method will return a requeue struct with more details.
-func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, addressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress) ([]fdbv1beta2.ProcessAddress, *requeue) {
+func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, addressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress, filterConditions map[fdbv1beta2.ProcessGroupConditionType]bool) ([]fdbv1beta2.ProcessAddress, *requeue) {
 	addresses := make([]fdbv1beta2.ProcessAddress, 0, len(cluster.Status.ProcessGroups))
 	allSynced := true
 	versionIncompatibleUpgrade := cluster.IsBeingUpgradedWithVersionIncompatibleVersion()
 	var missingAddress []fdbv1beta2.ProcessGroupID
 
-	filterConditions := restarts.GetFilterConditions(cluster)
 	var missingProcesses int
 	var markedForRemoval int
+	waitForMonitorRestart := cluster.KillProcessesOnConfigurationChange()
+	var waitingForMonitor bool
 	for _, processGroup := range cluster.Status.ProcessGroups {
 		// Ignore tester processes in this reconciler as tester processes cannot be restarted with the kill command.
 		if processGroup.ProcessClass == fdbv1beta2.ProcessClassTest {
@@ -256,6 +316,18 @@ func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.Foundat
 			continue
 		}
 
+		// If fdbmonitor restarts the processes on a configuration change, we give fdbmonitor the chance to restart the
+		// processes before we issue the kill command. Once the processes are restarted the IncorrectCommandLine condition
+		// will be removed by the update status reconciler.
+		if waitForMonitorRestart {
+			incorrectCommandLineTime := processGroup.GetConditionTime(fdbv1beta2.IncorrectCommandLine)
+			if incorrectCommandLineTime != nil && time.Since(time.Unix(*incorrectCommandLineTime, 0)) < monitorRestartGracePeriod {
+				logger.V(1).Info("waiting for fdbmonitor to restart the processes", "processGroupID", processGroup.ProcessGroupID)
+				waitingForMonitor = true
+				continue
+			}
+		}
+
 		if addressMap[processGroup.ProcessGroupID] == nil {
 			missingAddress = append(missingAddress, processGroup.ProcessGroupID)
 			continue
@@ -272,6 +344,10 @@ func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.Foundat
 		return nil, &requeue{message: "Waiting for config map to sync to all pods", delayedRequeue: true}
 	}
 
+	if waitingForMonitor {
+		return nil, &requeue{message: "Waiting for fdbmonitor to restart the processes", delay: 15 * time.Second, delayedRequeue: true}
+	}
+
 	// Only if the cluster is upgraded with an incompatible version we have to make sure that all processes are ready to be restarted.
 	// In the case of a patch upgrade we will be recreating the Pods anyway without this bounce step.
 	if cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/buggify"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/restarts"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
 	"k8s.io/utils/pointer"
@@ -97,6 +98,49 @@ var _ = Describe("bounceProcesses", func() {
 		})
 	})
 
+	When("fdbmonitor restarts the processes on configuration changes", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.KillProcessesOnConfigurationChange = pointer.Bool(true)
+			processGroup := cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-4]
+			Expect(processGroup.ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID("storage-1")))
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+		})
+
+		When("the incorrect command line was recently detected", func() {
+			It("should requeue", func() {
+				Expect(requeue).NotTo(BeNil())
+				Expect(requeue.message).To(Equal("Waiting for fdbmonitor to restart the processes"))
+			})
+
+			It("should not kill any processes", func() {
+				Expect(adminClient.KilledAddresses).To(BeEmpty())
+			})
+		})
+
+		When("fdbmonitor didn't restart the processes in time", func() {
+			BeforeEach(func() {
+				processGroup := cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-4]
+				for idx, condition := range processGroup.ProcessGroupConditions {
+					if condition.ProcessGroupConditionType == fdbv1beta2.IncorrectCommandLine {
+						processGroup.ProcessGroupConditions[idx].Timestamp = time.Now().Add(-monitorRestartGracePeriod - time.Minute).Unix()
+					}
+				}
+			})
+
+			It("should not requeue", func() {
+				Expect(requeue).To(BeNil())
+			})
+
+			It("should kill the targeted processes", func() {
+				addresses := make(map[string]fdbv1beta2.None, 1)
+				for _, address := range fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1").Addresses {
+					addresses[fmt.Sprintf("%s:4501", address)] = fdbv1beta2.None{}
+				}
+				Expect(adminClient.KilledAddresses).To(Equal(addresses))
+			})
+		})
+	})
+
 	Context("with incorrect processes and process marked for removal", func() {
 		BeforeEach(func() {
 			processGroup := cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-4]
@@ -125,6 +169,33 @@ var _ = Describe("bounceProcesses", func() {
 		})
 	})
 
+	When("an incorrect process group is a test process group", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.IgnoreTestProcessGroups = pointer.Bool(true)
+			processGroup := cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-4]
+			Expect(processGroup.ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID("storage-1")))
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+
+			processGroup = cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-3]
+			Expect(processGroup.ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID("storage-2")))
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+			processGroup.ProcessClass = fdbv1beta2.ProcessClassTest
+		})
+
+		It("should not requeue", func() {
+			Expect(requeue).To(BeNil())
+		})
+
+		It("should not kill the test process", func() {
+			addresses := make(map[string]fdbv1beta2.None, 1)
+			processGroupAddresses := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1").Addresses
+			for _, address := range processGroupAddresses {
+				addresses[fmt.Sprintf("%s:4501", address)] = fdbv1beta2.None{}
+			}
+			Expect(adminClient.KilledAddresses).To(Equal(addresses))
+		})
+	})
+
 	Context("with a manually excluded process", func() {
 		BeforeEach(func() {
 			processGroup := cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-4]
@@ -178,6 +249,60 @@ var _ = Describe("bounceProcesses", func() {
 		})
 	})
 
+	When("custom bounce blocking conditions are defined", func() {
+		BeforeEach(func() {
+			clusterReconciler.BounceBlockingConditions = []fdbv1beta2.ProcessGroupConditionType{fdbv1beta2.PodFailing}
+
+			processGroup := cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-4]
+			Expect(processGroup.ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID("storage-1")))
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectPodSpec, true)
+
+			processGroup = cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-3]
+			Expect(processGroup.ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID("storage-2")))
+			processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+			processGroup.UpdateCondition(fdbv1beta2.PodFailing, true)
+		})
+
+		AfterEach(func() {
+			clusterReconciler.BounceBlockingConditions = nil
+		})
+
+		It("should not requeue", func() {
+			Expect(requeue).To(BeNil())
+		})
+
+		It("should only kill the process with the non-blocking condition", func() {
+			addresses := make(map[string]fdbv1beta2.None, 1)
+			for _, address := range fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1").Addresses {
+				addresses[fmt.Sprintf("%s:4501", address)] = fdbv1beta2.None{}
+			}
+			Expect(adminClient.KilledAddresses).To(Equal(addresses))
+		})
+
+		When("the cluster is upgraded to a version incompatible version", func() {
+			It("should use the default bounce blocking conditions", func() {
+				cluster.Spec.Version = fdbv1beta2.Versions.NextMajorVersion.String()
+				Expect(cluster.IsBeingUpgradedWithVersionIncompatibleVersion()).To(BeTrue())
+				Expect(clusterReconciler.getBounceFilterConditions(cluster)).To(Equal(restarts.GetFilterConditions(cluster)))
+			})
+		})
+
+		When("the default bounce blocking conditions are used", func() {
+			BeforeEach(func() {
+				clusterReconciler.BounceBlockingConditions = nil
+			})
+
+			It("should only kill the process without a blocking condition", func() {
+				addresses := make(map[string]fdbv1beta2.None, 1)
+				for _, address := range fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2").Addresses {
+					addresses[fmt.Sprintf("%s:4501", address)] = fdbv1beta2.None{}
+				}
+				Expect(adminClient.KilledAddresses).To(Equal(addresses))
+			})
+		})
+	})
+
 	When("a process group has the MissingProcess condition", func() {
 		BeforeEach(func() {
 			processGroup := cluster.Status.ProcessGroups[len(cluster.Status.ProcessGroups)-4]
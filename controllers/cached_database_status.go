@@ -0,0 +1,104 @@
+/*
+ * cached_database_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
+	"github.com/go-logr/logr"
+)
+
+// cachedDatabaseStatus wraps the machine-readable status that is shared between the sub-reconcilers of a single
+// reconciliation loop, together with the information when and how often the status was fetched. All methods can be
+// called on a nil cachedDatabaseStatus, in this case no status is cached.
+type cachedDatabaseStatus struct {
+	// status is the cached machine-readable status.
+	status *fdbv1beta2.FoundationDBStatus
+	// fetchedAt is the time when the cached machine-readable status was fetched.
+	fetchedAt time.Time
+	// fetches counts how often the machine-readable status was fetched during the reconciliation loop.
+	fetches int
+	// cache defines if the fetched machine-readable status should be shared between the sub-reconcilers.
+	cache bool
+}
+
+// newCachedDatabaseStatus returns a new cachedDatabaseStatus. If cache is false, the fetched machine-readable status
+// will only be counted but not shared between the sub-reconcilers.
+func newCachedDatabaseStatus(cache bool) *cachedDatabaseStatus {
+	return &cachedDatabaseStatus{
+		cache: cache,
+	}
+}
+
+// get returns the cached machine-readable status or nil if no status is cached.
+func (cachedStatus *cachedDatabaseStatus) get() *fdbv1beta2.FoundationDBStatus {
+	if cachedStatus == nil {
+		return nil
+	}
+
+	return cachedStatus.status
+}
+
+// record must be called for every fetch of the machine-readable status. If caching is enabled, the provided status
+// will be shared with the following sub-reconcilers.
+func (cachedStatus *cachedDatabaseStatus) record(status *fdbv1beta2.FoundationDBStatus) {
+	if cachedStatus == nil {
+		return
+	}
+
+	cachedStatus.fetches++
+	if !cachedStatus.cache || status == nil {
+		return
+	}
+
+	cachedStatus.status = status
+	cachedStatus.fetchedAt = time.Now()
+}
+
+// getFetchedAt returns the time when the cached machine-readable status was fetched, or nil if no status is cached.
+func (cachedStatus *cachedDatabaseStatus) getFetchedAt() *time.Time {
+	if cachedStatus.get() == nil {
+		return nil
+	}
+
+	return &cachedStatus.fetchedAt
+}
+
+// report logs the age of the cached machine-readable status and how often the status was fetched during the
+// reconciliation loop and records those values in the metrics.
+func (cachedStatus *cachedDatabaseStatus) report(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) {
+	if cachedStatus == nil {
+		return
+	}
+
+	fetchedAt := cachedStatus.getFetchedAt()
+	if fetchedAt == nil {
+		logger.Info("Machine-readable status was not cached", "fetches", cachedStatus.fetches)
+		metrics.RecordCachedStatus(cluster.Namespace, cluster.Name, false, 0, cachedStatus.fetches)
+		return
+	}
+
+	age := time.Since(*fetchedAt)
+	logger.Info("Machine-readable status was cached", "fetches", cachedStatus.fetches, "age_seconds", age.Seconds())
+	metrics.RecordCachedStatus(cluster.Namespace, cluster.Name, true, age, cachedStatus.fetches)
+}
@@ -0,0 +1,138 @@
+/*
+ * cached_database_status_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"k8s.io/utils/pointer"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var _ = Describe("cached_database_status", func() {
+	When("the status is cached", func() {
+		var cachedStatus *cachedDatabaseStatus
+
+		BeforeEach(func() {
+			cachedStatus = newCachedDatabaseStatus(true)
+		})
+
+		It("should share the recorded status", func() {
+			Expect(cachedStatus.get()).To(BeNil())
+			Expect(cachedStatus.getFetchedAt()).To(BeNil())
+
+			status := &fdbv1beta2.FoundationDBStatus{}
+			cachedStatus.record(status)
+			Expect(cachedStatus.get()).To(BeIdenticalTo(status))
+			Expect(cachedStatus.getFetchedAt()).NotTo(BeNil())
+			Expect(cachedStatus.fetches).To(Equal(1))
+		})
+	})
+
+	When("the status is not cached", func() {
+		var cachedStatus *cachedDatabaseStatus
+
+		BeforeEach(func() {
+			cachedStatus = newCachedDatabaseStatus(false)
+		})
+
+		It("should only count the fetches", func() {
+			cachedStatus.record(&fdbv1beta2.FoundationDBStatus{})
+			cachedStatus.record(&fdbv1beta2.FoundationDBStatus{})
+			Expect(cachedStatus.get()).To(BeNil())
+			Expect(cachedStatus.getFetchedAt()).To(BeNil())
+			Expect(cachedStatus.fetches).To(Equal(2))
+		})
+	})
+
+	When("no cached status is provided", func() {
+		It("should not panic", func() {
+			var cachedStatus *cachedDatabaseStatus
+			cachedStatus.record(&fdbv1beta2.FoundationDBStatus{})
+			Expect(cachedStatus.get()).To(BeNil())
+			Expect(cachedStatus.getFetchedAt()).To(BeNil())
+		})
+	})
+
+	When("a sub-reconciler has to fetch the status", func() {
+		var cachedStatus *cachedDatabaseStatus
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			_, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			cachedStatus = newCachedDatabaseStatus(true)
+			Expect(excludeProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster, cachedStatus, globalControllerLogger)).To(BeNil())
+		})
+
+		It("should update the cached status", func() {
+			Expect(cachedStatus.get()).NotTo(BeNil())
+			Expect(cachedStatus.fetches).To(Equal(1))
+		})
+
+		When("the next sub-reconciler runs", func() {
+			BeforeEach(func() {
+				Expect(updateDatabaseConfiguration{}.reconcile(context.TODO(), clusterReconciler, cluster, cachedStatus, globalControllerLogger)).To(BeNil())
+			})
+
+			It("should reuse the cached status", func() {
+				Expect(cachedStatus.fetches).To(Equal(1))
+			})
+		})
+	})
+
+	When("the cluster caches the status", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.AutomationOptions.CacheDatabaseStatusForReconciliation = pointer.Bool(true)
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should expose the time of the cached status", func() {
+			Expect(cluster.Status.DatabaseStatusCachedAt).NotTo(BeNil())
+		})
+	})
+
+	When("the cluster doesn't cache the status", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should not expose the time of the cached status", func() {
+			Expect(cluster.Status.DatabaseStatusCachedAt).To(BeNil())
+		})
+	})
+})
@@ -25,8 +25,11 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/locality"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 	"github.com/go-logr/logr"
 
 	corev1 "k8s.io/api/core/v1"
@@ -39,7 +42,9 @@ import (
 type changeCoordinators struct{}
 
 // reconcile runs the reconciler's work.
-func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	if !cluster.Status.Configured {
 		return nil
 	}
@@ -56,6 +61,21 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		cachedStatus.record(status)
+	}
+
+	var countChangeDeferred bool
+	if cluster.ShouldAutoTuneCoordinatorCount() {
+		countChangeDeferred, err = tuneCoordinatorCount(ctx, r, cluster, status, logger)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	var deferredRequeue *requeue
+	if countChangeDeferred {
+		deferredRequeue = &requeue{message: "coordinator count change is not safe, retry later", delayedRequeue: true}
 	}
 
 	coordinatorStatus := make(map[string]bool, len(status.Client.Coordinators.Coordinators))
@@ -68,14 +88,20 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 		return &requeue{curError: err, delayedRequeue: true}
 	}
 
-	if hasValidCoordinators {
-		return nil
+	requestedRotation := getRequestedCoordinatorRotation(cluster)
+	if hasValidCoordinators && requestedRotation == "" {
+		return deferredRequeue
+	}
+
+	if remaining := getRemainingCoordinatorChangeDelay(r, cluster, status); remaining > 0 {
+		logger.Info("Delaying coordinator change after the exclusion of a coordinator", "remaining", remaining.String())
+		return &requeue{message: "waiting before changing coordinators after the exclusion of a coordinator", delay: remaining, delayedRequeue: true}
 	}
 
 	if !allAddressesValid {
 		logger.Info("Deferring coordinator change")
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "DeferringCoordinatorChange", "Deferring coordinator change until all processes have consistent address TLS settings")
-		return nil
+		return deferredRequeue
 	}
 
 	hasLock, err := r.takeLock(logger, cluster, "changing coordinators")
@@ -90,12 +116,138 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 		}
 	}()
 
+	if requestedRotation != "" {
+		logger.Info("Rotating coordinators", "rotation", requestedRotation, "hasValidCoordinators", hasValidCoordinators)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "RotatingCoordinators", fmt.Sprintf("Rotation of coordinators requested with %s annotation value %s", fdbv1beta2.RotateCoordinatorsAnnotation, requestedRotation))
+	}
+
+	err = selectAndChangeCoordinators(ctx, r, cluster, adminClient, status, requestedRotation != "", logger)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	if requestedRotation != "" {
+		cluster.Status.HandledCoordinatorRotation = requestedRotation
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	return deferredRequeue
+}
+
+// getRemainingCoordinatorChangeDelay returns the remaining time the operator has to wait before changing the
+// coordinators, if a process that serves as coordinator was excluded less than the configured
+// CoordinatorChangeDelayAfterExclusionSeconds ago. If no delay must be respected, 0 will be returned.
+func getRemainingCoordinatorChangeDelay(r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) time.Duration {
+	delay := cluster.GetCoordinatorChangeDelayAfterExclusion()
+	if !cluster.ChangeCoordinatorsAfterExclusion() || delay <= 0 {
+		return 0
+	}
+
+	var remaining time.Duration
+	for _, process := range status.Cluster.Processes {
+		isCoordinator := false
+		for _, role := range process.Roles {
+			if role.Role == string(fdbv1beta2.ProcessRoleCoordinator) {
+				isCoordinator = true
+				break
+			}
+		}
+
+		if !isCoordinator {
+			continue
+		}
+
+		processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]))
+		if processGroup == nil || processGroup.ExclusionStartedTimestamp.IsZero() {
+			continue
+		}
+
+		processRemaining := delay - r.getClock().Since(processGroup.ExclusionStartedTimestamp.Time)
+		if processRemaining > remaining {
+			remaining = processRemaining
+		}
+	}
+
+	return remaining
+}
+
+// getRequestedCoordinatorRotation returns the value of the foundationdb.org/rotate-coordinators annotation if the
+// rotation for this value was not yet handled, otherwise an empty string will be returned.
+func getRequestedCoordinatorRotation(cluster *fdbv1beta2.FoundationDBCluster) string {
+	rotation := strings.TrimSpace(cluster.Annotations[fdbv1beta2.RotateCoordinatorsAnnotation])
+	if rotation == cluster.Status.HandledCoordinatorRotation {
+		return ""
+	}
+
+	return rotation
+}
+
+// tuneCoordinatorCount updates the tuned coordinator count in the cluster status based on the number of zones that
+// can host a coordinator. Changing the coordinator count will cause a coordinator change and therefore a recovery, so
+// the count is only changed if the cluster allows configuration changes. The returned bool is true if a change of the
+// coordinator count was deferred.
+func tuneCoordinatorCount(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) (bool, error) {
+	zones := getCoordinatorZoneCount(cluster)
+	tunedCount := cluster.GetTunedCoordinatorCount(zones)
+	if tunedCount == cluster.Status.TunedCoordinatorCount {
+		return false, nil
+	}
+
+	currentCount := cluster.DesiredCoordinatorCount()
+	if tunedCount != currentCount {
+		runningVersion, err := fdbv1beta2.ParseFdbVersion(cluster.GetRunningVersion())
+		if err != nil {
+			return false, err
+		}
+
+		err = fdbstatus.ConfigurationChangeAllowed(status, runningVersion.SupportsRecoveryState() && r.EnableRecoveryState)
+		if err != nil {
+			logger.Info("Changing the coordinator count is not safe", "error", err, "currentCount", currentCount, "tunedCount", tunedCount)
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "DeferringCoordinatorCountChange",
+				fmt.Sprintf("Coordinator count should be changed from %d to %d, but the change is not safe: %s", currentCount, tunedCount, err.Error()))
+			return true, nil
+		}
+
+		logger.Info("Changing the coordinator count", "currentCount", currentCount, "tunedCount", tunedCount, "zones", zones)
+	}
+
+	cluster.Status.TunedCoordinatorCount = tunedCount
+	return false, r.updateOrApply(ctx, cluster)
+}
+
+// getCoordinatorZoneCount returns the number of zones of the process groups that are eligible to host a coordinator.
+// The zones are based on the process groups in the cluster status and not on the processes that are currently
+// reporting, so the count doesn't change when the processes of a zone are temporarily missing.
+func getCoordinatorZoneCount(cluster *fdbv1beta2.FoundationDBCluster) int {
+	zones := make(map[fdbv1beta2.FaultDomain]fdbv1beta2.None)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.FaultDomain == "" || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if !cluster.IsEligibleAsCandidate(processGroup.ProcessClass) {
+			continue
+		}
+
+		zones[processGroup.FaultDomain] = fdbv1beta2.None{}
+	}
+
+	return len(zones)
+}
+
+// selectAndChangeCoordinators selects a new set of coordinators based on the provided status, changes the coordinators
+// and updates the connection string in the cluster status. If excludeCurrentCoordinators is true, none of the current
+// coordinators will be selected again, as long as enough other candidates are available.
+func selectAndChangeCoordinators(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, adminClient fdbadminclient.AdminClient, status *fdbv1beta2.FoundationDBStatus, excludeCurrentCoordinators bool, logger logr.Logger) error {
 	logger.Info("Changing coordinators")
 	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ChangingCoordinators", "Choosing new coordinators")
 
-	coordinators, err := selectCoordinators(logger, cluster, status)
+	coordinators, err := selectCoordinators(logger, cluster, status, excludeCurrentCoordinators)
 	if err != nil {
-		return &requeue{curError: err, delayedRequeue: true}
+		return err
 	}
 
 	coordinatorAddresses := make([]fdbv1beta2.ProcessAddress, len(coordinators))
@@ -106,15 +258,11 @@ func (c changeCoordinators) reconcile(ctx context.Context, r *FoundationDBCluste
 	logger.Info("Final coordinators candidates", "coordinators", coordinatorAddresses)
 	connectionString, err := adminClient.ChangeCoordinators(coordinatorAddresses)
 	if err != nil {
-		return &requeue{curError: err, delayedRequeue: true}
-	}
-	cluster.Status.ConnectionString = connectionString
-	err = r.updateOrApply(ctx, cluster)
-	if err != nil {
-		return &requeue{curError: err, delayedRequeue: true}
+		return err
 	}
 
-	return nil
+	cluster.Status.ConnectionString = connectionString
+	return r.updateOrApply(ctx, cluster)
 }
 
 // selectCandidates is a helper for Reconcile that picks non-excluded, not-being-removed class-matching process groups.
@@ -174,7 +322,7 @@ func selectCandidates(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta
 	return candidates, nil
 }
 
-func selectCoordinators(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) ([]locality.Info, error) {
+func selectCoordinators(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, excludeCurrentCoordinators bool) ([]locality.Info, error) {
 	var err error
 	coordinatorCount := cluster.DesiredCoordinatorCount()
 
@@ -183,9 +331,24 @@ func selectCoordinators(logger logr.Logger, cluster *fdbv1beta2.FoundationDBClus
 		return nil, err
 	}
 
-	coordinators, err := locality.ChooseDistributedProcesses(cluster, candidates, coordinatorCount, locality.ProcessSelectionConstraint{
+	constraint := locality.ProcessSelectionConstraint{
 		HardLimits: locality.GetHardLimits(cluster),
-	})
+	}
+
+	var coordinators []locality.Info
+	if excludeCurrentCoordinators {
+		coordinators, err = locality.ChooseDistributedProcesses(cluster, removeCurrentCoordinators(cluster, status, candidates), coordinatorCount, constraint)
+		// If not enough candidates are available outside of the current coordinators, fall back to the default
+		// selection to prevent a short list of coordinators.
+		if err != nil || len(coordinators) < coordinatorCount {
+			logger.Info("Not enough candidates outside of the current coordinators, falling back to the default selection", "error", err)
+			excludeCurrentCoordinators = false
+		}
+	}
+
+	if !excludeCurrentCoordinators {
+		coordinators, err = locality.ChooseDistributedProcesses(cluster, candidates, coordinatorCount, constraint)
+	}
 
 	logger.Info("Current coordinators", "coordinators", coordinators, "error", err)
 	if err != nil {
@@ -213,6 +376,27 @@ func selectCoordinators(logger logr.Logger, cluster *fdbv1beta2.FoundationDBClus
 	return coordinators, nil
 }
 
+// removeCurrentCoordinators returns the candidates that are not serving as coordinators in the provided status. The
+// selection of the coordinators is deterministic, so the current coordinators must be removed from the candidates to
+// choose an entirely new set of coordinators.
+func removeCurrentCoordinators(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, candidates []locality.Info) []locality.Info {
+	currentCoordinators := make(map[string]fdbv1beta2.None, len(status.Client.Coordinators.Coordinators))
+	for _, coordinator := range status.Client.Coordinators.Coordinators {
+		currentCoordinators[coordinator.Address.String()] = fdbv1beta2.None{}
+	}
+
+	filteredCandidates := make([]locality.Info, 0, len(candidates))
+	for _, candidate := range candidates {
+		if _, ok := currentCoordinators[getCoordinatorAddress(cluster, candidate).String()]; ok {
+			continue
+		}
+
+		filteredCandidates = append(filteredCandidates, candidate)
+	}
+
+	return filteredCandidates
+}
+
 func getCoordinatorAddress(cluster *fdbv1beta2.FoundationDBCluster, locality locality.Info) fdbv1beta2.ProcessAddress {
 	dnsName := locality.LocalityData[fdbv1beta2.FDBLocalityDNSNameKey]
 
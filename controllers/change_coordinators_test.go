@@ -76,10 +76,31 @@ var _ = Describe("Change coordinators", func() {
 				status, err = adminClient.GetStatus()
 				Expect(err).NotTo(HaveOccurred())
 
-				candidates, err = selectCoordinators(logr.Discard(), cluster, status)
+				candidates, err = selectCoordinators(logr.Discard(), cluster, status, false)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			When("the current coordinators should be excluded but not enough other candidates are available", func() {
+				It("should fall back to the default selection", func() {
+					allCandidates, err := selectCandidates(cluster, status)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(len(allCandidates)).To(BeNumerically(">", 2))
+
+					status.Client.Coordinators.Coordinators = nil
+					for _, candidate := range allCandidates[2:] {
+						status.Client.Coordinators.Coordinators = append(status.Client.Coordinators.Coordinators, fdbv1beta2.FoundationDBStatusCoordinator{
+							Address:   getCoordinatorAddress(cluster, candidate),
+							Reachable: true,
+						})
+					}
+
+					coordinators, err := selectCoordinators(logr.Discard(), cluster, status, true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(coordinators).To(HaveLen(cluster.DesiredCoordinatorCount()))
+					Expect(coordinators).To(Equal(candidates))
+				})
+			})
+
 			When("all processes are healthy", func() {
 				It("should only select storage processes", func() {
 					Expect(cluster.DesiredCoordinatorCount()).To(BeNumerically("==", 3))
@@ -176,7 +197,7 @@ var _ = Describe("Change coordinators", func() {
 					initialCandidates := candidates
 
 					for i := 0; i < 100; i++ {
-						newCandidates, err := selectCoordinators(logr.Discard(), cluster, status)
+						newCandidates, err := selectCoordinators(logr.Discard(), cluster, status, false)
 						Expect(err).NotTo(HaveOccurred())
 						Expect(newCandidates).To(Equal(initialCandidates))
 					}
@@ -250,7 +271,7 @@ var _ = Describe("Change coordinators", func() {
 				Expect(err).NotTo(HaveOccurred())
 				status.Cluster.Processes = generateProcessInfoForMultiRegion(dcCnt, satCnt, excludes)
 
-				candidates, err = selectCoordinators(testLogger, cluster, status)
+				candidates, err = selectCoordinators(testLogger, cluster, status, false)
 				if shouldFail {
 					Expect(err).To(HaveOccurred())
 				} else {
@@ -422,7 +443,7 @@ var _ = Describe("Change coordinators", func() {
 						initialCandidates := candidates
 
 						for i := 0; i < 100; i++ {
-							newCandidates, err := selectCoordinators(logr.Discard(), cluster, status)
+							newCandidates, err := selectCoordinators(logr.Discard(), cluster, status, false)
 							Expect(err).NotTo(HaveOccurred())
 							Expect(newCandidates).To(Equal(initialCandidates))
 						}
@@ -620,7 +641,7 @@ var _ = Describe("Change coordinators", func() {
 						initialCandidates := candidates
 
 						for i := 0; i < 100; i++ {
-							newCandidates, err := selectCoordinators(logr.Discard(), cluster, status)
+							newCandidates, err := selectCoordinators(logr.Discard(), cluster, status, false)
 							Expect(err).NotTo(HaveOccurred())
 							Expect(newCandidates).To(Equal(initialCandidates))
 						}
@@ -643,7 +664,7 @@ var _ = Describe("Change coordinators", func() {
 
 				status.Cluster.Processes = generateProcessInfoForThreeDataHall(3, nil)
 
-				candidates, err = selectCoordinators(logr.Discard(), cluster, status)
+				candidates, err = selectCoordinators(logr.Discard(), cluster, status, false)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -789,6 +810,165 @@ var _ = Describe("Change coordinators", func() {
 				Expect(cluster.Status.ConnectionString).NotTo(ContainSubstring(badCoordinator.Address.IPAddress.String()))
 			})
 		})
+
+		When("a rotation of the coordinators is requested", func() {
+			BeforeEach(func() {
+				if cluster.Annotations == nil {
+					cluster.Annotations = map[string]string{}
+				}
+				cluster.Annotations[fdbv1beta2.RotateCoordinatorsAnnotation] = "1700000000"
+			})
+
+			It("should select an entirely new set of coordinators and record the handled rotation", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.HandledCoordinatorRotation).To(Equal("1700000000"))
+
+				originalConnectionStringData, err := fdbv1beta2.ParseConnectionString(originalConnectionString)
+				Expect(err).NotTo(HaveOccurred())
+				newConnectionStringData, err := fdbv1beta2.ParseConnectionString(cluster.Status.ConnectionString)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newConnectionStringData.Coordinators).To(HaveLen(len(originalConnectionStringData.Coordinators)))
+				for _, coordinator := range originalConnectionStringData.Coordinators {
+					Expect(newConnectionStringData.Coordinators).NotTo(ContainElement(coordinator))
+				}
+			})
+
+			When("the rotation was already handled", func() {
+				BeforeEach(func() {
+					cluster.Status.HandledCoordinatorRotation = "1700000000"
+				})
+
+				It("should not change the coordinators", func() {
+					Expect(requeue).To(BeNil())
+					Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+				})
+			})
+		})
+	})
+
+	Describe("reconcile with automatic coordinator count tuning", func() {
+		var requeue *requeue
+		var status *fdbv1beta2.FoundationDBStatus
+
+		// spreadCandidates places the candidate processes round-robin into the provided number of zones.
+		spreadCandidates := func(zones int) {
+			index := 0
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage && processGroup.ProcessClass != fdbv1beta2.ProcessClassLog {
+					continue
+				}
+
+				zone := fmt.Sprintf("zone-%d", index%zones)
+				adminClient.MockLocalityInfo(processGroup.ProcessGroupID, map[string]string{
+					fdbv1beta2.FDBLocalityZoneIDKey: zone,
+				})
+				processGroup.FaultDomain = fdbv1beta2.FaultDomain(zone)
+				index++
+			}
+		}
+
+		getCoordinatorCount := func() int {
+			connectionString, err := fdbv1beta2.ParseConnectionString(cluster.Status.ConnectionString)
+			Expect(err).NotTo(HaveOccurred())
+			return len(connectionString.Coordinators)
+		}
+
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.AutoTuneCoordinatorCount = pointer.Bool(true)
+			spreadCandidates(3)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			if status == nil {
+				status, err = adminClient.GetStatus()
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			cachedStatus := newCachedDatabaseStatus(true)
+			cachedStatus.record(status)
+			requeue = changeCoordinators{}.reconcile(context.TODO(), clusterReconciler, cluster, cachedStatus, globalControllerLogger)
+		})
+
+		AfterEach(func() {
+			status = nil
+		})
+
+		When("the candidates are spread across 3 zones", func() {
+			It("should use the minimum coordinator count", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.TunedCoordinatorCount).To(Equal(3))
+				Expect(cluster.DesiredCoordinatorCount()).To(Equal(3))
+				Expect(getCoordinatorCount()).To(Equal(3))
+			})
+
+			When("the number of zones grows to 5", func() {
+				BeforeEach(func() {
+					Expect(changeCoordinators{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)).To(BeNil())
+					Expect(getCoordinatorCount()).To(Equal(3))
+					spreadCandidates(5)
+				})
+
+				It("should increase the coordinator count", func() {
+					Expect(requeue).To(BeNil())
+					Expect(cluster.Status.TunedCoordinatorCount).To(Equal(5))
+					Expect(cluster.DesiredCoordinatorCount()).To(Equal(5))
+					Expect(getCoordinatorCount()).To(Equal(5))
+				})
+
+				When("the processes of a zone are missing", func() {
+					BeforeEach(func() {
+						for _, processGroup := range cluster.Status.ProcessGroups {
+							if processGroup.FaultDomain != "zone-4" {
+								continue
+							}
+
+							adminClient.MockMissingProcessGroup(processGroup.ProcessGroupID, true)
+						}
+					})
+
+					It("should base the coordinator count on the zones of the process groups", func() {
+						Expect(cluster.Status.TunedCoordinatorCount).To(Equal(5))
+						Expect(cluster.DesiredCoordinatorCount()).To(Equal(5))
+					})
+				})
+
+				When("configuration changes are not allowed", func() {
+					BeforeEach(func() {
+						var err error
+						status, err = adminClient.GetStatus()
+						Expect(err).NotTo(HaveOccurred())
+						status.Cluster.Data.State.Healthy = false
+					})
+
+					It("should defer the coordinator count change", func() {
+						Expect(requeue).NotTo(BeNil())
+						Expect(requeue.delayedRequeue).To(BeTrue())
+						Expect(cluster.Status.TunedCoordinatorCount).To(Equal(3))
+						Expect(getCoordinatorCount()).To(Equal(3))
+					})
+				})
+			})
+		})
+
+		When("the candidates are spread across more zones than the maximum coordinator count", func() {
+			BeforeEach(func() {
+				cluster.Spec.ProcessCounts.Storage = 12
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				_, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				cluster.Spec.AutomationOptions.AutoTuneCoordinatorCount = pointer.Bool(true)
+				spreadCandidates(16)
+			})
+
+			It("should use the maximum coordinator count", func() {
+				Expect(requeue).To(BeNil())
+				Expect(cluster.Status.TunedCoordinatorCount).To(Equal(fdbv1beta2.MaximumCoordinatorCount))
+				Expect(getCoordinatorCount()).To(Equal(fdbv1beta2.MaximumCoordinatorCount))
+			})
+		})
 	})
 
 	DescribeTable("selecting coordinator candidates", func(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, expected []locality.Info) {
@@ -38,7 +38,9 @@ import (
 type checkClientCompatibility struct{}
 
 // reconcile runs the reconciler's work.
-func (c checkClientCompatibility) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (c checkClientCompatibility) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	if !cluster.Status.Configured && !cluster.IsBeingUpgraded() {
 		return nil
 	}
@@ -77,6 +79,8 @@ func (c checkClientCompatibility) reconcile(_ context.Context, r *FoundationDBCl
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		cachedStatus.record(status)
 	}
 
 	protocolVersion, err := adminClient.GetProtocolVersion(cluster.Spec.Version)
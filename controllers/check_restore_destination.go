@@ -0,0 +1,86 @@
+/*
+ * check_restore_destination.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkRestoreDestination provides a reconciliation step for validating that the destination cluster can accept a
+// restore before the restore is started.
+type checkRestoreDestination struct{}
+
+// reconcile runs the reconciler's work.
+func (s checkRestoreDestination) reconcile(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore) *requeue {
+	// The checks are only required before the restore is started.
+	if restore.Spec.Abort || restore.Status.Running || restore.Status.State != "" {
+		return nil
+	}
+
+	adminClient, err := r.adminClientForRestore(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if !status.Client.DatabaseStatus.Available {
+		return rejectRestore(r, restore, "DestinationClusterUnavailable", fmt.Sprintf("destination cluster %s is not available", restore.Spec.DestinationClusterName))
+	}
+
+	liveStatus, err := adminClient.GetLiveRestoreStatus()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if liveStatus.Tag == fdbv1beta2.DefaultBackupTag && liveStatus.GetState().IsInProgress() {
+		return rejectRestore(r, restore, "RestoreAlreadyRunning", fmt.Sprintf("destination cluster %s is already running a restore with tag %s from %s", restore.Spec.DestinationClusterName, liveStatus.Tag, liveStatus.URL))
+	}
+
+	if restore.Spec.AllowNonEmptyDestination {
+		return nil
+	}
+
+	empty, err := adminClient.IsKeyspaceEmpty(restore.Spec.KeyRanges)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if !empty {
+		return rejectRestore(r, restore, "DestinationNotEmpty", fmt.Sprintf("destination cluster %s contains data in the keyspace that should be restored", restore.Spec.DestinationClusterName))
+	}
+
+	return nil
+}
+
+// rejectRestore emits a warning event for a restore that cannot be started and requeues the restore.
+func rejectRestore(r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore, reason string, message string) *requeue {
+	r.Recorder.Event(restore, corev1.EventTypeWarning, reason, message)
+	return &requeue{message: message, delay: restorePreflightInterval}
+}
@@ -26,9 +26,12 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/locality"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
@@ -37,7 +40,9 @@ import (
 type chooseRemovals struct{}
 
 // reconcile runs the reconciler's work.
-func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	hasNewRemovals := false
 
 	var removals = make(map[fdbv1beta2.ProcessGroupID]bool)
@@ -66,6 +71,8 @@ func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterRec
 		if err != nil {
 			return &requeue{curError: err, delayedRequeue: true}
 		}
+
+		cachedStatus.record(status)
 	}
 
 	localityMap := make(map[string]locality.Info)
@@ -78,28 +85,48 @@ func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterRec
 	}
 
 	remainingProcessMap := make(map[string]bool, len(cluster.Status.ProcessGroups))
+	// The process groups on unschedulable nodes are only fetched if at least one process class must be shrunk.
+	var unschedulableProcessGroups map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
 
 	for _, processClass := range fdbv1beta2.ProcessClasses {
 		desiredCount := desiredCounts[processClass]
 		excessCount := currentCounts[processClass] - desiredCount
 		processClassLocality := make([]locality.Info, 0, currentCounts[processClass])
+		candidates := make(map[string]*fdbv1beta2.ProcessGroupStatus, currentCounts[processClass])
 
-		// TODO (johscheuer): We could add a higher priority to the process groups that have a condition that requires
-		// an automatic replacement.
 		for _, processGroup := range cluster.Status.ProcessGroupsByProcessClass(processClass) {
 			if processGroup.IsMarkedForRemoval() {
 				excessCount--
 				continue
 			}
 			localityInfo, present := localityMap[string(processGroup.ProcessGroupID)]
-			if present {
-				processClassLocality = append(processClassLocality, localityInfo)
+			if !present {
+				// Processes that are missing are not reported in the machine-readable status, so the locality is
+				// created based on the fault domain of the process group.
+				if processGroup.GetConditionTime(fdbv1beta2.MissingProcesses) == nil {
+					continue
+				}
+
+				localityInfo = getLocalityForMissingProcessGroup(cluster, processGroup)
 			}
+
+			processClassLocality = append(processClassLocality, localityInfo)
+			candidates[localityInfo.ID] = processGroup
 		}
 
 		if excessCount > 0 {
 			r.Recorder.Event(cluster, corev1.EventTypeNormal, "ShrinkingProcesses", fmt.Sprintf("Removing %d %s processes", excessCount, processClass))
 
+			if unschedulableProcessGroups == nil {
+				unschedulableProcessGroups, err = getProcessGroupsOnUnschedulableNodes(ctx, r, cluster)
+				if err != nil {
+					logger.Error(err, "Could not fetch the process groups on unschedulable nodes")
+					unschedulableProcessGroups = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+				}
+			}
+
+			setPrioritiesForRemoval(processClassLocality, candidates, unschedulableProcessGroups)
+
 			remainingProcesses, err := locality.ChooseDistributedProcesses(cluster, processClassLocality, desiredCount, locality.ProcessSelectionConstraint{})
 			if err != nil {
 				return &requeue{curError: err, delayedRequeue: true}
@@ -136,3 +163,110 @@ func (c chooseRemovals) reconcile(ctx context.Context, r *FoundationDBClusterRec
 
 	return nil
 }
+
+const (
+	// removalPriorityUnschedulableNode is the priority for process groups running on an unschedulable or tainted node.
+	removalPriorityUnschedulableNode = iota
+	// removalPriorityMissingProcesses is the priority for process groups with the MissingProcesses condition.
+	removalPriorityMissingProcesses
+	// removalPriorityDefault is the priority for all other process groups.
+	removalPriorityDefault
+)
+
+// getLocalityForMissingProcessGroup returns the locality for a process group whose processes are not reported in the
+// machine-readable status. The fault domain of the process group is used as zone, if the fault domain is unknown the
+// process group is treated as its own zone.
+func getLocalityForMissingProcessGroup(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) locality.Info {
+	zone := string(processGroup.FaultDomain)
+	if zone == "" {
+		zone = string(processGroup.ProcessGroupID)
+	}
+
+	return locality.Info{
+		ID:    string(processGroup.ProcessGroupID),
+		Class: processGroup.ProcessClass,
+		LocalityData: map[string]string{
+			fdbv1beta2.FDBLocalityInstanceIDKey: string(processGroup.ProcessGroupID),
+			fdbv1beta2.FDBLocalityZoneIDKey:     zone,
+			fdbv1beta2.FDBLocalityDCIDKey:       cluster.Spec.DataCenter,
+		},
+	}
+}
+
+// setPrioritiesForRemoval sets the priority of the provided localities based on the state of the process groups and
+// the nodes they are running on. Processes with a higher priority are kept during a shrink, so process groups on
+// unschedulable or tainted nodes will be removed first, followed by process groups with the MissingProcesses condition.
+func setPrioritiesForRemoval(localities []locality.Info, processGroups map[string]*fdbv1beta2.ProcessGroupStatus, unschedulableProcessGroups map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None) {
+	for idx, localityInfo := range localities {
+		processGroup, ok := processGroups[localityInfo.ID]
+		if !ok {
+			continue
+		}
+
+		priority := removalPriorityDefault
+		if processGroup.GetConditionTime(fdbv1beta2.MissingProcesses) != nil {
+			priority = removalPriorityMissingProcesses
+		}
+
+		if _, ok := unschedulableProcessGroups[processGroup.ProcessGroupID]; ok {
+			priority = removalPriorityUnschedulableNode
+		}
+
+		localities[idx].Priority = priority
+	}
+}
+
+// getProcessGroupsOnUnschedulableNodes returns the IDs of the process groups whose Pods are running on a node that is
+// cordoned or has a NoSchedule or NoExecute taint. The node of every Pod is fetched based on the Pod's spec.nodeName.
+func getProcessGroupsOnUnschedulableNodes(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, error) {
+	pods, err := r.PodLifecycleManager.GetPods(ctx, r, cluster, internal.GetPodListOptions(cluster, "", "")...)
+	if err != nil {
+		return nil, err
+	}
+
+	unschedulableNodes := map[string]bool{}
+	processGroups := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	for _, pod := range pods {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+
+		unschedulable, ok := unschedulableNodes[nodeName]
+		if !ok {
+			node := &corev1.Node{}
+			err = r.Get(ctx, client.ObjectKey{Name: nodeName}, node)
+			if err != nil {
+				if k8serrors.IsNotFound(err) {
+					continue
+				}
+
+				return nil, err
+			}
+
+			unschedulable = isUnschedulableNode(node)
+			unschedulableNodes[nodeName] = unschedulable
+		}
+
+		if unschedulable {
+			processGroups[internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta)] = fdbv1beta2.None{}
+		}
+	}
+
+	return processGroups, nil
+}
+
+// isUnschedulableNode returns true if the node is cordoned or has a NoSchedule or NoExecute taint.
+func isUnschedulableNode(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return true
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute {
+			return true
+		}
+	}
+
+	return false
+}
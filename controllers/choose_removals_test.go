@@ -28,6 +28,9 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -125,6 +128,105 @@ var _ = Describe("choose_removals", func() {
 				Expect(removals).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
 			})
 		})
+
+		When("a process group is running on a tainted node", func() {
+			BeforeEach(func() {
+				taintNodeOfProcessGroup(cluster, "storage-1", corev1.Taint{Key: "maintenance", Effect: corev1.TaintEffectNoSchedule})
+			})
+
+			It("should mark the process group on the tainted node for removal", func() {
+				Expect(requeue).To(BeNil())
+				Expect(removals).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-1"}))
+			})
+
+			When("the taint has the PreferNoSchedule effect", func() {
+				BeforeEach(func() {
+					taintNodeOfProcessGroup(cluster, "storage-1", corev1.Taint{Key: "maintenance", Effect: corev1.TaintEffectPreferNoSchedule})
+				})
+
+				It("should mark the default process group for removal", func() {
+					Expect(requeue).To(BeNil())
+					Expect(removals).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-4"}))
+				})
+			})
+
+			When("another process group has the MissingProcesses condition", func() {
+				BeforeEach(func() {
+					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+					processGroup.UpdateCondition(fdbv1beta2.MissingProcesses, true)
+					adminClient.MockMissingProcessGroup("storage-2", true)
+				})
+
+				It("should mark the process group on the tainted node for removal", func() {
+					Expect(requeue).To(BeNil())
+					Expect(removals).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-1"}))
+				})
+			})
+
+			When("multiple processes are on one rack", func() {
+				BeforeEach(func() {
+					adminClient.MockLocalityInfo("storage-2", map[string]string{fdbv1beta2.FDBLocalityZoneIDKey: "r1"})
+					adminClient.MockLocalityInfo("storage-3", map[string]string{fdbv1beta2.FDBLocalityZoneIDKey: "r1"})
+				})
+
+				It("should keep the fault domains balanced", func() {
+					Expect(requeue).To(BeNil())
+					Expect(removals).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-3"}))
+				})
+			})
+		})
+
+		When("a process group is running on a cordoned node", func() {
+			BeforeEach(func() {
+				node := getNodeOfProcessGroup(cluster, "storage-2")
+				node.Spec.Unschedulable = true
+				Expect(k8sClient.Update(context.TODO(), node)).NotTo(HaveOccurred())
+			})
+
+			It("should mark the process group on the cordoned node for removal", func() {
+				Expect(requeue).To(BeNil())
+				Expect(removals).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
+			})
+		})
+
+		When("the node of a process group is missing", func() {
+			BeforeEach(func() {
+				node := getNodeOfProcessGroup(cluster, "storage-2")
+				Expect(k8sClient.Delete(context.TODO(), node)).NotTo(HaveOccurred())
+			})
+
+			It("should mark one process group for removal", func() {
+				Expect(requeue).To(BeNil())
+				Expect(removals).To(HaveLen(1))
+			})
+		})
+
+		When("a process group has the MissingProcesses condition", func() {
+			BeforeEach(func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+				processGroup.UpdateCondition(fdbv1beta2.MissingProcesses, true)
+				adminClient.MockMissingProcessGroup("storage-2", true)
+			})
+
+			It("should mark the process group with the MissingProcesses condition for removal", func() {
+				Expect(requeue).To(BeNil())
+				Expect(removals).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
+			})
+
+			When("the process group shares the fault domain with another process group", func() {
+				BeforeEach(func() {
+					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+					processGroup.FaultDomain = "r1"
+					adminClient.MockLocalityInfo("storage-1", map[string]string{fdbv1beta2.FDBLocalityZoneIDKey: "r1"})
+					adminClient.MockLocalityInfo("storage-3", map[string]string{fdbv1beta2.FDBLocalityZoneIDKey: "r1"})
+				})
+
+				It("should keep the fault domains balanced", func() {
+					Expect(requeue).To(BeNil())
+					Expect(removals).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
+				})
+			})
+		})
 	})
 
 	Context("with a decrease to multiple process counts", func() {
@@ -143,3 +245,22 @@ var _ = Describe("choose_removals", func() {
 	})
 
 })
+
+// getNodeOfProcessGroup returns the node the Pod of the provided process group is running on.
+func getNodeOfProcessGroup(cluster *fdbv1beta2.FoundationDBCluster, processGroupID fdbv1beta2.ProcessGroupID) *corev1.Node {
+	processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID)
+	Expect(processGroup).NotTo(BeNil())
+	pod, err := clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, processGroup.GetPodName(cluster))
+	Expect(err).NotTo(HaveOccurred())
+
+	node := &corev1.Node{}
+	Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, node)).NotTo(HaveOccurred())
+	return node
+}
+
+// taintNodeOfProcessGroup replaces the taints of the node the Pod of the provided process group is running on.
+func taintNodeOfProcessGroup(cluster *fdbv1beta2.FoundationDBCluster, processGroupID fdbv1beta2.ProcessGroupID, taint corev1.Taint) {
+	node := getNodeOfProcessGroup(cluster, processGroupID)
+	node.Spec.Taints = []corev1.Taint{taint}
+	Expect(k8sClient.Update(context.TODO(), node)).NotTo(HaveOccurred())
+}
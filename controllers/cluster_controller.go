@@ -23,31 +23,43 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
+	"os"
+	"reflect"
 	"regexp"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -81,13 +93,82 @@ type FoundationDBClusterReconciler struct {
 	// before new exclusions are allowed. The operator issuing frequent exclusions in a short time window
 	// could cause instability for the cluster as each exclusion will/can cause a recovery.
 	MinimumRecoveryTimeForExclusion float64
-	// Namespace for the FoundationDBClusterReconciler, if empty the FoundationDBClusterReconciler will watch all namespaces.
-	Namespace string
-	// ClusterLabelKeyForNodeTrigger if set will trigger a reconciliation for all FoundationDBClusters that host a Pod
-	// on the affected node.
-	ClusterLabelKeyForNodeTrigger string
+	// Namespaces for the FoundationDBClusterReconciler, if empty the FoundationDBClusterReconciler will watch all namespaces.
+	Namespaces []string
+	// ClusterLabelKeysForNodeTrigger if set will trigger a reconciliation for all FoundationDBClusters that host a Pod
+	// on the affected node. The cluster name is read from the first label key that is present on the Pod.
+	ClusterLabelKeysForNodeTrigger []string
+	// NodeTriggerTaintReplacementOptions defines the taint keys and the minimum taint durations that trigger a
+	// reconciliation for a changed node. If empty, all taint changes will trigger a reconciliation. The
+	// TaintReplacementOptions of the FoundationDBClusters override the options with the same key.
+	NodeTriggerTaintReplacementOptions []fdbv1beta2.TaintReplacementOption
+	// NodeTriggerTaintTimeAddedTolerance defines the tolerance in which a change of only the TimeAdded of a node taint
+	// will not trigger a reconciliation.
+	NodeTriggerTaintTimeAddedTolerance time.Duration
+	// TerminateOnNativeConnectionFailure if set will terminate the operator if the native connection of a cluster
+	// could not be recovered by recreating it. This will restart the connections to all clusters managed by the
+	// operator.
+	TerminateOnNativeConnectionFailure bool
+	// EnableTLSSecretWatch if set will watch the secrets that are referenced in the Pod templates of the
+	// FoundationDBClusters and will bounce the processes once the secrets are rotated.
+	EnableTLSSecretWatch bool
+	// BounceBlockingConditions defines the process group conditions that prevent a process group from being bounced. If
+	// unset the default conditions will be used, see restarts.GetFilterConditions. An empty slice means that no condition
+	// will block a bounce. The default conditions will always be used for version incompatible upgrades.
+	BounceBlockingConditions []fdbv1beta2.ProcessGroupConditionType
+	// ReconciliationTimeout defines the maximum duration of a single reconciliation run for a cluster, if the duration is
+	// exceeded the reconciliation will be stopped and requeued. This value can be overwritten per cluster with the
+	// ReconciliationTimeoutSeconds setting. A value of 0 disables the timeout.
+	ReconciliationTimeout time.Duration
+	// DegradedClusterRequeueDelayDivisor defines the divisor for the requeue delays of clusters with a fault tolerance
+	// below the desired fault tolerance, so those clusters will be reconciled before healthy clusters. The fault
+	// tolerance is only checked if the machine-readable status is cached for the reconciliation. A value of 0 or 1
+	// disables the divisor.
+	DegradedClusterRequeueDelayDivisor int
+	// DegradedClusterMaximumRequeueDelay defines the maximum requeue delay for clusters with a fault tolerance below the
+	// desired fault tolerance. Requeues without a delay will use this delay instead of the exponential backoff of the
+	// work queue. A value of 0 disables the maximum delay.
+	DegradedClusterMaximumRequeueDelay time.Duration
+	// DisableCheckClientCompatibility if set will remove the checkClientCompatibility sub-reconciler from the
+	// reconciliation.
+	DisableCheckClientCompatibility bool
+	// DisableDeletePodsForBuggification if set will remove the deletePodsForBuggification sub-reconciler from the
+	// reconciliation.
+	DisableDeletePodsForBuggification bool
+	// DisableUpdatePodDisruptionBudgets if set will remove the updatePodDisruptionBudgets sub-reconciler from the
+	// reconciliation.
+	DisableUpdatePodDisruptionBudgets bool
+	// DisableMaintenanceModeChecker if set will remove the maintenanceModeChecker sub-reconciler from the
+	// reconciliation. Clusters that let the operator reset the maintenance mode will still run the
+	// maintenanceModeChecker, otherwise a maintenance zone set by the operator would never be reset.
+	DisableMaintenanceModeChecker bool
+	// Clock is used by the reconciler to get the current time. If unset the real clock will be used.
+	Clock clock.Clock
+	// delayedRequeueEvents limits the events that are emitted for delayed requeues.
+	delayedRequeueEvents eventRateLimiter
+	// reconcileLoops counts the reconciliation loops of the clusters.
+	reconcileLoops reconcileLoopCounter
+	// nativeConnectionBackoff tracks the recreations of the native connections of the clusters.
+	nativeConnectionBackoff nativeConnectionBackoff
+	// skipReconcilersAnnotations tracks the skip reconcilers annotation of the clusters to only emit events on changes.
+	skipReconcilersAnnotations annotationChangeTracker
 }
 
+// nativeConnectionRecreationAttempts defines how often the operator will recreate the native connection of a cluster
+// before giving up.
+const nativeConnectionRecreationAttempts = 3
+
+// reconcileLoopWindow defines the duration of the window in which the reconciliation loops of a cluster are counted.
+const reconcileLoopWindow = 10 * time.Minute
+
+// reconciliationTimeoutRequeueDelay defines the delay before a cluster is reconciled again after a reconciliation run
+// exceeded the reconciliation timeout.
+const reconciliationTimeoutRequeueDelay = 30 * time.Second
+
+// maxReconciliationErrorMessageLength defines the maximum length of the message that will be stored in the
+// LastReconciliationError of the cluster status.
+const maxReconciliationErrorMessageLength = 1024
+
 // NewFoundationDBClusterReconciler creates a new FoundationDBClusterReconciler with defaults.
 func NewFoundationDBClusterReconciler(podLifecycleManager podmanager.PodLifecycleManager) *FoundationDBClusterReconciler {
 	r := &FoundationDBClusterReconciler{
@@ -102,6 +183,7 @@ func NewFoundationDBClusterReconciler(podLifecycleManager podmanager.PodLifecycl
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=pods;configmaps;persistentvolumeclaims;events;secrets;services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile runs the reconciliation logic.
 func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
@@ -110,6 +192,11 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 	err := r.Get(ctx, request.NamespacedName, cluster)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
+			// The cluster was deleted, so the metrics of this cluster can be removed.
+			metrics.DeleteClusterMetrics(request.Namespace, request.Name)
+			r.reconcileLoops.forget(request.NamespacedName.String())
+			r.skipReconcilersAnnotations.forget(request.NamespacedName.String())
+			r.nativeConnectionBackoff.reset(request.NamespacedName.String())
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -124,12 +211,32 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 		clusterLog.Info("Reconciliation run finished", "duration_seconds", time.Since(startTime).Seconds(), "cacheStatus", cacheStatus)
 	}()
 
+	// The deletion cleanup is performed before the skip check to make sure the finalizer doesn't block the deletion.
+	if !cluster.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(cluster, fdbv1beta2.DeletionCleanupFinalizer) {
+		return r.runDeletionCleanup(ctx, clusterLog, cluster)
+	}
+
 	if cluster.Spec.Skip {
 		clusterLog.Info("Skipping cluster with skip value true", "skip", cluster.Spec.Skip)
 		// Don't requeue
 		return ctrl.Result{}, nil
 	}
 
+	if cluster.DeletionTimestamp.IsZero() {
+		err = r.ensureDeletionCleanupFinalizer(ctx, clusterLog, cluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Track the reconciliation loops before the spec is normalized, as updating the status will reset the spec. The
+	// loops are counted in memory, so the counting continues if the status update fails.
+	cluster.Status.ReconcileLoops = r.reconcileLoops.record(client.ObjectKeyFromObject(cluster).String(), cluster.Status.ReconcileLoops, r.getClock().Now())
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		clusterLog.Error(err, "Could not update the reconcile loop count in the cluster status")
+	}
+
 	err = internal.NormalizeClusterSpec(cluster, r.DeprecationOptions)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -147,6 +254,15 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 		return ctrl.Result{}, fmt.Errorf("ClusterSpec is not valid: %w", err)
 	}
 
+	// Warnings don't stop the reconciliation, the event will only be emitted for a generation that is not yet
+	// reconciled to prevent an event in every reconciliation loop.
+	if cluster.Status.Generations.Reconciled < cluster.ObjectMeta.Generation {
+		for _, warning := range cluster.GetValidationWarnings() {
+			clusterLog.Info("ClusterSpec has a potential issue", "warning", warning)
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "ClusterSpecWarning", warning)
+		}
+	}
+
 	supportedVersion, err := adminClient.VersionSupported(cluster.Spec.Version)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -155,25 +271,161 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 		return ctrl.Result{}, fmt.Errorf("version %s is not supported", cluster.Spec.Version)
 	}
 
-	var status *fdbv1beta2.FoundationDBStatus
+	// The reconciliation context will be used for the sub-reconcilers, the updates of the cluster status after the
+	// timeout was exceeded must use the request context.
+	reconcileCtx := ctx
+	reconciliationTimeout := cluster.GetReconciliationTimeout(r.ReconciliationTimeout)
+	if reconciliationTimeout > 0 {
+		var cancel context.CancelFunc
+		reconcileCtx, cancel = context.WithTimeout(ctx, reconciliationTimeout)
+		defer cancel()
+	}
+
+	cachedStatus := newCachedDatabaseStatus(cacheStatus)
+	defer cachedStatus.report(clusterLog, cluster)
 	if cacheStatus {
 		clusterLog.Info("Fetch machine-readable status for reconcilitation loop", "cacheStatus", cacheStatus)
-		status, err = r.getStatusFromClusterOrDummyStatus(clusterLog, cluster)
+		status, err := r.getStatusFromClusterOrDummyStatus(reconcileCtx, clusterLog, cluster)
+		if err != nil && isNativeConnectionError(cluster, err) {
+			status, err = r.recoverNativeConnection(reconcileCtx, clusterLog, cluster, err)
+		}
+
 		if err != nil {
 			clusterLog.Info("could not fetch machine-readable status and therefore didn't cache the it")
+		} else {
+			cachedStatus.record(status)
+		}
+	}
+
+	subReconcilers := r.getSubReconcilers(cluster)
+	subReconcilers = r.filterSkippedSubReconcilers(clusterLog, cluster, subReconcilers)
+
+	originalGeneration := cluster.ObjectMeta.Generation
+	normalizedSpec := cluster.Spec.DeepCopy()
+	delayedRequeue := false
+	// delayedRequeueAfter contains the shortest delay of the delayed requeues, if one of the delayed requeues has no
+	// delay, the cluster will be requeued without a delay.
+	var delayedRequeueAfter time.Duration
+	delayedWithoutDelay := false
+	var lastDelayedReconciler clusterSubReconciler
+	var lastDelayedRequeue *requeue
+	trace := newReconcileTrace(ctx, cluster)
+
+	for _, subReconciler := range subReconcilers {
+		// We have to set the normalized spec here again otherwise any call to Update() for the status of the cluster
+		// will reset all normalized fields...
+		cluster.Spec = *(normalizedSpec.DeepCopy())
+
+		requeue := runClusterSubReconciler(reconcileCtx, clusterLog, subReconciler, r, cluster, cachedStatus)
+		trace.record(subReconciler, requeue)
+		if errors.Is(reconcileCtx.Err(), context.DeadlineExceeded) {
+			clusterLog.Info("Reconciliation timeout exceeded, stopping reconciliation",
+				"reconciler", fmt.Sprintf("%T", subReconciler),
+				"timeout", reconciliationTimeout.String())
+			// If the sub-reconciler requested a requeue, the requeue was already recorded by runSubReconciler.
+			if requeue == nil {
+				metrics.RecordSubReconcilerRequeue(fmt.Sprintf("%T", subReconciler), cluster.Namespace, cluster.Name, false, false)
+			}
+			requeue = newReconciliationTimeoutRequeue(subReconciler, reconciliationTimeout)
+			r.recordLastRequeue(ctx, clusterLog, cluster, subReconciler, requeue)
+			trace.finish(ctx, r, clusterLog, cluster, fmt.Sprintf("reconciliation timeout exceeded in %T", subReconciler))
+			result, err := processRequeue(requeue, subReconciler, cluster, r.Recorder, clusterLog)
+			return r.prioritizeDegradedCluster(clusterLog, cluster, cachedStatus.get(), result), err
+		}
+
+		if requeue == nil {
+			continue
+		}
+
+		if requeue.delayedRequeue {
+			clusterLog.Info("Delaying requeue for sub-reconciler",
+				"reconciler", fmt.Sprintf("%T", subReconciler),
+				"message", requeue.message,
+				"error", requeue.curError)
+			r.recordDelayedRequeueEvent(cluster, subReconciler, requeue)
+			delayedRequeue = true
+			if requeue.delay > 0 {
+				if delayedRequeueAfter == 0 || requeue.delay < delayedRequeueAfter {
+					delayedRequeueAfter = requeue.delay
+				}
+			} else {
+				delayedWithoutDelay = true
+			}
+			lastDelayedReconciler = subReconciler
+			lastDelayedRequeue = requeue
+			continue
+		}
+
+		r.recordLastRequeue(ctx, clusterLog, cluster, subReconciler, requeue)
+		trace.finish(ctx, r, clusterLog, cluster, fmt.Sprintf("requeue requested by %T", subReconciler))
+		result, err := processRequeue(requeue, subReconciler, cluster, r.Recorder, clusterLog)
+		return r.prioritizeDegradedCluster(clusterLog, cluster, cachedStatus.get(), result), err
+	}
+
+	if delayedRequeue {
+		r.recordLastRequeue(ctx, clusterLog, cluster, lastDelayedReconciler, lastDelayedRequeue)
+	}
+
+	if cluster.Status.Generations.Reconciled < originalGeneration || delayedRequeue {
+		clusterLog.Info("Cluster was not fully reconciled by reconciliation process", "status", cluster.Status.Generations,
+			"CurrentGeneration", cluster.Status.Generations.Reconciled,
+			"OriginalGeneration", originalGeneration, "DelayedRequeue", delayedRequeue)
+		trace.finish(ctx, r, clusterLog, cluster, "not fully reconciled")
+
+		// If Requeue is set, the requeue will happen with the rate limiter of the queue, so only the delay is set
+		// if all delayed requeues requested a delay.
+		result := ctrl.Result{Requeue: true}
+		if delayedRequeue && !delayedWithoutDelay {
+			result = ctrl.Result{RequeueAfter: delayedRequeueAfter}
+		}
+
+		return r.prioritizeDegradedCluster(clusterLog, cluster, cachedStatus.get(), result), nil
+	}
+
+	if cluster.Status.LastReconciliationError != nil {
+		cluster.Status.LastReconciliationError = nil
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			clusterLog.Error(err, "Could not clear the last reconciliation error in the cluster status")
 		}
 	}
 
+	clusterLog.Info("Reconciliation complete", "generation", cluster.Status.Generations.Reconciled)
+	trace.finish(ctx, r, clusterLog, cluster, "reconciled")
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ReconciliationComplete", fmt.Sprintf("Reconciled generation %d", cluster.Status.Generations.Reconciled))
+
+	return ctrl.Result{}, nil
+}
+
+// getSubReconcilers returns the sub-reconcilers in the order they should be run. The sub-reconcilers that are disabled
+// on the reconciler will be removed from the list, the order of the remaining sub-reconcilers will not change.
+func (r *FoundationDBClusterReconciler) getSubReconcilers(cluster *fdbv1beta2.FoundationDBCluster) []clusterSubReconciler {
 	subReconcilers := []clusterSubReconciler{
 		updateStatus{},
 		updateLockConfiguration{},
 		updateConfigMap{},
-		checkClientCompatibility{},
-		deletePodsForBuggification{},
+	}
+
+	if !r.DisableCheckClientCompatibility {
+		subReconcilers = append(subReconcilers, checkClientCompatibility{})
+	}
+
+	if !r.DisableDeletePodsForBuggification {
+		subReconcilers = append(subReconcilers, deletePodsForBuggification{})
+	}
+
+	subReconcilers = append(subReconcilers,
 		replaceMisconfiguredProcessGroups{},
 		replaceFailedProcessGroups{},
 		addProcessGroups{},
 		addServices{},
+	)
+
+	if !r.DisableUpdatePodDisruptionBudgets {
+		subReconcilers = append(subReconcilers, updatePodDisruptionBudgets{})
+	}
+
+	subReconcilers = append(subReconcilers,
 		addPVCs{},
 		addPods{},
 		generateInitialClusterFile{},
@@ -186,55 +438,228 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 		excludeProcesses{},
 		changeCoordinators{},
 		bounceProcesses{},
-		maintenanceModeChecker{},
+	)
+
+	// The maintenanceModeChecker is responsible for resetting the maintenance zone that the operator has set, so it
+	// cannot be disabled for clusters where the operator manages the maintenance mode.
+	if !r.DisableMaintenanceModeChecker || cluster.ResetMaintenanceMode() {
+		subReconcilers = append(subReconcilers, maintenanceModeChecker{})
+	}
+
+	return append(subReconcilers,
 		updatePods{},
 		removeProcessGroups{},
 		removeServices{},
 		updateStatus{},
+	)
+}
+
+// filterSkippedSubReconcilers removes the sub-reconcilers that are listed in the skip reconcilers annotation of the
+// cluster. An event will be emitted for the skipped sub-reconcilers when the annotation changes, so it's visible that the
+// reconciliation is partially disabled. Unknown sub-reconciler names will produce a warning event.
+func (r *FoundationDBClusterReconciler) filterSkippedSubReconcilers(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, subReconcilers []clusterSubReconciler) []clusterSubReconciler {
+	key := client.ObjectKeyFromObject(cluster).String()
+	value, ok := cluster.Annotations[fdbv1beta2.SkipReconcilersAnnotation]
+	if !ok || strings.TrimSpace(value) == "" {
+		r.skipReconcilersAnnotations.forget(key)
+		return subReconcilers
 	}
 
-	originalGeneration := cluster.ObjectMeta.Generation
-	normalizedSpec := cluster.Spec.DeepCopy()
-	delayedRequeue := false
+	skipped := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			skipped[name] = false
+		}
+	}
 
+	filtered := make([]clusterSubReconciler, 0, len(subReconcilers))
 	for _, subReconciler := range subReconcilers {
-		// We have to set the normalized spec here again otherwise any call to Update() for the status of the cluster
-		// will reset all normalized fields...
-		cluster.Spec = *(normalizedSpec.DeepCopy())
-
-		requeue := runClusterSubReconciler(ctx, clusterLog, subReconciler, r, cluster, status)
-		if requeue == nil {
+		name := getSubReconcilerName(subReconciler)
+		if _, skip := skipped[name]; skip {
+			skipped[name] = true
 			continue
 		}
 
-		if requeue.delayedRequeue {
-			clusterLog.Info("Delaying requeue for sub-reconciler",
-				"reconciler", fmt.Sprintf("%T", subReconciler),
-				"message", requeue.message,
-				"error", requeue.curError)
-			delayedRequeue = true
-			continue
+		filtered = append(filtered, subReconciler)
+	}
+
+	skippedNames := make([]string, 0, len(skipped))
+	unknownNames := make([]string, 0)
+	for name, found := range skipped {
+		if found {
+			skippedNames = append(skippedNames, name)
+		} else {
+			unknownNames = append(unknownNames, name)
 		}
+	}
+	sort.Strings(skippedNames)
+	sort.Strings(unknownNames)
 
-		return processRequeue(requeue, subReconciler, cluster, r.Recorder, clusterLog)
+	if !r.skipReconcilersAnnotations.changed(key, value) {
+		return filtered
 	}
 
-	if cluster.Status.Generations.Reconciled < originalGeneration || delayedRequeue {
-		clusterLog.Info("Cluster was not fully reconciled by reconciliation process", "status", cluster.Status.Generations,
-			"CurrentGeneration", cluster.Status.Generations.Reconciled,
-			"OriginalGeneration", originalGeneration, "DelayedRequeue", delayedRequeue)
+	if len(skippedNames) > 0 {
+		logger.Info("Skipping sub-reconcilers", "reconcilers", skippedNames)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "SkippingReconcilers", fmt.Sprintf("reconciliation is partially disabled, skipping: %s", strings.Join(skippedNames, ", ")))
+	}
 
-		return ctrl.Result{Requeue: true}, nil
+	if len(unknownNames) > 0 {
+		logger.Info("Ignoring unknown sub-reconcilers in skip annotation", "reconcilers", unknownNames)
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "UnknownSkippedReconcilers", fmt.Sprintf("unknown reconcilers in %s annotation: %s", fdbv1beta2.SkipReconcilersAnnotation, strings.Join(unknownNames, ", ")))
 	}
 
-	clusterLog.Info("Reconciliation complete", "generation", cluster.Status.Generations.Reconciled)
-	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ReconciliationComplete", fmt.Sprintf("Reconciled generation %d", cluster.Status.Generations.Reconciled))
+	return filtered
+}
 
-	return ctrl.Result{}, nil
+// getSubReconcilerName returns the name of the sub-reconciler type without the package name.
+func getSubReconcilerName(subReconciler interface{}) string {
+	return reflect.TypeOf(subReconciler).Name()
+}
+
+// newReconciliationTimeoutRequeue returns the requeue for a reconciliation run that exceeded the reconciliation timeout
+// while the provided sub-reconciler was running.
+func newReconciliationTimeoutRequeue(subReconciler clusterSubReconciler, timeout time.Duration) *requeue {
+	return &requeue{
+		message: fmt.Sprintf("reconciliation timeout of %s exceeded while running %T", timeout.String(), subReconciler),
+		delay:   reconciliationTimeoutRequeueDelay,
+	}
+}
+
+// recordDelayedRequeueEvent emits an event for the delayed requeue of the provided sub-reconciler. For every cluster
+// and sub-reconciler at most one event per delayedRequeueEventInterval will be emitted. The event will be a warning if
+// the sub-reconciler returned an error.
+func (r *FoundationDBClusterReconciler) recordDelayedRequeueEvent(cluster *fdbv1beta2.FoundationDBCluster, subReconciler clusterSubReconciler, requeue *requeue) {
+	reconciler := fmt.Sprintf("%T", subReconciler)
+	key := fmt.Sprintf("%s/%s/%s", cluster.Namespace, cluster.Name, reconciler)
+	if !r.delayedRequeueEvents.allow(key, time.Now(), delayedRequeueEventInterval) {
+		return
+	}
+
+	eventType := corev1.EventTypeNormal
+	if requeue.curError != nil {
+		eventType = corev1.EventTypeWarning
+	}
+
+	r.Recorder.Event(cluster, eventType, "ReconciliationDelayed", fmt.Sprintf("%s delayed the reconciliation: %s", reconciler, getRequeueMessage(requeue)))
+}
+
+// getRequeueMessage returns the message of the requeue combined with the error of the requeue, if present.
+func getRequeueMessage(requeue *requeue) string {
+	if requeue.curError == nil {
+		return requeue.message
+	}
+
+	if requeue.message == "" || requeue.message == requeue.curError.Error() {
+		return requeue.curError.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", requeue.message, requeue.curError.Error())
+}
+
+// recordLastRequeue stores the information about the sub-reconciler that caused a requeue and the last reconciliation
+// error in the cluster status. The status will only be updated if the sub-reconciler or the reason for the requeue has
+// changed.
+func (r *FoundationDBClusterReconciler) recordLastRequeue(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, subReconciler clusterSubReconciler, requeue *requeue) {
+	reconciler := fmt.Sprintf("%T", subReconciler)
+	now := r.getClock().Now()
+	changed := updateLastRequeue(cluster, reconciler, requeue, now)
+	changed = updateLastReconciliationError(cluster, reconciler, requeue, now) || changed
+	if !changed {
+		return
+	}
+
+	err := r.updateOrApply(ctx, cluster)
+	if err != nil {
+		logger.Error(err, "Could not update the last requeue information in the cluster status")
+	}
+}
+
+// updateLastRequeue stores the information about the sub-reconciler that caused a requeue in the cluster status. The
+// return value will be true if the information has changed.
+func updateLastRequeue(cluster *fdbv1beta2.FoundationDBCluster, reconciler string, requeue *requeue, now time.Time) bool {
+	message := requeue.message
+	if message == "" && requeue.curError != nil {
+		message = requeue.curError.Error()
+	}
+
+	lastRequeue := cluster.Status.LastRequeue
+	if lastRequeue != nil && lastRequeue.Reconciler == reconciler && lastRequeue.Message == message && lastRequeue.Delayed == requeue.delayedRequeue {
+		return false
+	}
+
+	cluster.Status.LastRequeue = &fdbv1beta2.RequeueInfo{
+		Reconciler: reconciler,
+		Message:    message,
+		Delayed:    requeue.delayedRequeue,
+		Timestamp:  &metav1.Time{Time: now},
+	}
+
+	return true
+}
+
+// updateLastReconciliationError stores the information about the sub-reconciler that blocked the reconciliation in the
+// cluster status. The message will be truncated to maxReconciliationErrorMessageLength. The return value will be true if
+// the information has changed.
+func updateLastReconciliationError(cluster *fdbv1beta2.FoundationDBCluster, reconciler string, requeue *requeue, now time.Time) bool {
+	message := truncateMessage(getRequeueMessage(requeue), maxReconciliationErrorMessageLength)
+	severity := fdbv1beta2.ReconciliationErrorSeverityError
+	if requeue.delayedRequeue {
+		severity = fdbv1beta2.ReconciliationErrorSeverityWarning
+	}
+
+	lastError := cluster.Status.LastReconciliationError
+	if lastError != nil && lastError.Reconciler == reconciler && lastError.Message == message && lastError.Severity == severity {
+		return false
+	}
+
+	cluster.Status.LastReconciliationError = &fdbv1beta2.ReconciliationError{
+		Reconciler: reconciler,
+		Message:    message,
+		Severity:   severity,
+		Timestamp:  &metav1.Time{Time: now},
+	}
+
+	return true
+}
+
+// truncateMessage truncates the message to at most maxLength bytes without splitting a multi-byte character. If the
+// message was truncated, it will end with "...".
+func truncateMessage(message string, maxLength int) string {
+	if len(message) <= maxLength {
+		return message
+	}
+
+	end := maxLength - 3
+	for end > 0 && !utf8.RuneStart(message[end]) {
+		end--
+	}
+
+	return message[:end] + "..."
 }
 
-// runClusterSubReconciler will start the subReconciler and will log the duration of the subReconciler.
-func runClusterSubReconciler(ctx context.Context, logger logr.Logger, subReconciler clusterSubReconciler, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) *requeue {
+// recordReconcileLoop increments the number of reconciliation loops in the current window. If the current window has
+// passed, a new window will be started.
+func recordReconcileLoop(loops *fdbv1beta2.ReconcileLoopStatus, now time.Time) {
+	if loops.WindowStart == nil || !now.Before(loops.WindowStart.Add(reconcileLoopWindow)) {
+		previousCount := 0
+		// Only keep the previous count if the previous window directly precedes the new window.
+		if loops.WindowStart != nil && now.Before(loops.WindowStart.Add(2*reconcileLoopWindow)) {
+			previousCount = loops.Count
+		}
+
+		// The window start is truncated to seconds, as the persisted status only contains seconds.
+		loops.WindowStart = &metav1.Time{Time: now.Truncate(time.Second)}
+		loops.PreviousCount = previousCount
+		loops.Count = 0
+	}
+
+	loops.Count++
+}
+
+// runClusterSubReconciler will start the subReconciler and will log and record the duration of the subReconciler.
+func runClusterSubReconciler(ctx context.Context, logger logr.Logger, subReconciler clusterSubReconciler, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus) *requeue {
 	subReconcileLogger := logger.WithValues("reconciler", fmt.Sprintf("%T", subReconciler))
 	startTime := time.Now()
 	subReconcileLogger.Info("Attempting to run sub-reconciler")
@@ -242,12 +667,28 @@ func runClusterSubReconciler(ctx context.Context, logger logr.Logger, subReconci
 		subReconcileLogger.Info("Subreconciler finished run", "duration_seconds", time.Since(startTime).Seconds())
 	}()
 
-	return subReconciler.reconcile(ctx, r, cluster, status, subReconcileLogger)
+	return runSubReconciler(subReconciler, cluster, func() *requeue {
+		return subReconciler.reconcile(ctx, r, cluster, cachedStatus, subReconcileLogger)
+	})
 }
 
 // updateIndexerForManager will set all the required field indexer for the FoundationDBClusterReconciler.
 func (r *FoundationDBClusterReconciler) updateIndexerForManager(mgr ctrl.Manager) error {
-	if r.ClusterLabelKeyForNodeTrigger == "" {
+	if r.EnableTLSSecretWatch {
+		err := mgr.GetFieldIndexer().IndexField(context.Background(), &fdbv1beta2.FoundationDBCluster{}, secretNamesIndexField, func(o client.Object) []string {
+			return getSecretNamesForCluster(o.(*fdbv1beta2.FoundationDBCluster))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podmanager.ProcessGroupIDIndexField, podmanager.GetProcessGroupIDIndexValues)
+	if err != nil {
+		return err
+	}
+
+	if len(r.ClusterLabelKeysForNodeTrigger) == 0 {
 		return nil
 	}
 
@@ -263,6 +704,11 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 		return err
 	}
 
+	err = metrics.Register(ctrlmetrics.Registry)
+	if err != nil {
+		return err
+	}
+
 	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
 	if err != nil {
 		return err
@@ -277,7 +723,10 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 		predicate.Or(
 			predicate.LabelChangedPredicate{},
 			predicate.GenerationChangedPredicate{},
-			predicate.AnnotationChangedPredicate{},
+			// The reconcile trace is written during every reconciliation and must not trigger another reconciliation.
+			internal.AnnotationChangedPredicate{
+				IgnoredAnnotations: []string{fdbv1beta2.LastReconcileTraceKey},
+			},
 		),
 	))
 
@@ -289,20 +738,32 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 		Owns(&corev1.Pod{}, globalPredicate).
 		Owns(&corev1.PersistentVolumeClaim{}, globalPredicate).
 		Owns(&corev1.ConfigMap{}, globalPredicate).
-		Owns(&corev1.Service{}, globalPredicate)
+		Owns(&corev1.Service{}, globalPredicate).
+		Owns(&policyv1.PodDisruptionBudget{}, globalPredicate)
 
-	if r.ClusterLabelKeyForNodeTrigger != "" {
+	if len(r.ClusterLabelKeysForNodeTrigger) > 0 {
 		managerBuilder.Watches(
 			&source.Kind{Type: &corev1.Node{}},
 			handler.EnqueueRequestsFromMapFunc(r.findFoundationDBClusterForNode),
 			builder.WithPredicates(
 				internal.NodeTaintChangedPredicate{
-					Logger: r.Log.WithName("NodeTaintChangedPredicate"),
+					Logger:                            r.Log.WithName("NodeTaintChangedPredicate"),
+					TaintReplacementOptions:           r.NodeTriggerTaintReplacementOptions,
+					GetClusterTaintReplacementOptions: r.getTaintReplacementOptionsForNodeTrigger,
+					TimeAddedTolerance:                r.NodeTriggerTaintTimeAddedTolerance,
 				},
 			),
 		)
 	}
 
+	if r.EnableTLSSecretWatch {
+		managerBuilder.Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.findFoundationDBClusterForSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		)
+	}
+
 	for _, object := range watchedObjects {
 		managerBuilder.Owns(object)
 	}
@@ -311,56 +772,105 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 }
 
 // findFoundationDBClusterForNode will filter out all associated FoundationDBClusters that have a Pod running on that
-// specific node.
+// specific node. The cluster name is taken from the first label key in ClusterLabelKeysForNodeTrigger that is present
+// on the Pod.
 func (r *FoundationDBClusterReconciler) findFoundationDBClusterForNode(node client.Object) []reconcile.Request {
 	logger := r.Log.WithValues("node", node.GetName())
-	podsOnNode := &corev1.PodList{}
+	podsOnNode := map[types.NamespacedName]corev1.Pod{}
 
-	labelSelector := client.HasLabels([]string{r.ClusterLabelKeyForNodeTrigger})
-	var namespaceOption client.ListOption
-	if r.Namespace != "" {
-		namespaceOption = client.InNamespace(r.Namespace)
+	// An empty namespace will list the Pods in all namespaces.
+	namespaces := r.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
 	}
 
-	err := r.List(context.Background(), podsOnNode,
-		client.MatchingFieldsSelector{
-			Selector: fields.OneTermEqualSelector("spec.nodeName", node.GetName()),
-		},
-		labelSelector,
-		namespaceOption)
+	// The HasLabels selector requires all provided label keys to be present, so the Pods are listed for each label key.
+	for _, labelKey := range r.ClusterLabelKeysForNodeTrigger {
+		for _, namespace := range namespaces {
+			pods := &corev1.PodList{}
+			err := r.List(context.Background(), pods,
+				client.MatchingFieldsSelector{
+					Selector: fields.OneTermEqualSelector("spec.nodeName", node.GetName()),
+				},
+				client.HasLabels([]string{labelKey}),
+				client.InNamespace(namespace))
 
-	if err != nil {
-		logger.Error(err, "Processing findFoundationDBClusterForNode could not fetch Pods on node")
-		return []reconcile.Request{}
+			if err != nil {
+				logger.Error(err, "Processing findFoundationDBClusterForNode could not fetch Pods on node", "namespace", namespace, "labelKey", labelKey)
+				return []reconcile.Request{}
+			}
+
+			for _, pod := range pods.Items {
+				podsOnNode[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = pod
+			}
+		}
 	}
 
-	if len(podsOnNode.Items) == 0 {
+	if len(podsOnNode) == 0 {
 		return []reconcile.Request{}
 	}
 
-	logger.V(1).Info("Processing findFoundationDBClusterForNode, found Pods on node that changed", "labelSelector", r.ClusterLabelKeyForNodeTrigger, "podsOnNode", len(podsOnNode.Items))
+	logger.V(1).Info("Processing findFoundationDBClusterForNode, found Pods on node that changed", "labelKeys", r.ClusterLabelKeysForNodeTrigger, "podsOnNode", len(podsOnNode))
 
-	requests := make([]reconcile.Request, len(podsOnNode.Items))
-	for i, item := range podsOnNode.Items {
-		// Since we use a label selector all Pods should have the cluster label.
-		clusterName, ok := item.GetLabels()[r.ClusterLabelKeyForNodeTrigger]
-		if !ok {
+	clusters := map[types.NamespacedName]fdbv1beta2.None{}
+	for _, item := range podsOnNode {
+		clusterName := r.getClusterNameForNodeTrigger(item.GetLabels())
+		if clusterName == "" {
 			logger.V(1).Info("Missing cluster label information", "triggeringPod", item.Name)
 			continue
 		}
 
 		logger.V(1).Info("Processing findFoundationDBClusterForNode, found cluster that needs an update", "triggeringPod", item.Name, "clusterName", clusterName)
-		requests[i] = reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Name:      clusterName,
-				Namespace: item.GetNamespace(),
-			},
-		}
+		clusters[types.NamespacedName{Name: clusterName, Namespace: item.GetNamespace()}] = fdbv1beta2.None{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(clusters))
+	for cluster := range clusters {
+		requests = append(requests, reconcile.Request{NamespacedName: cluster})
 	}
 
 	return requests
 }
 
+// getTaintReplacementOptionsForNodeTrigger returns the taint replacement options of all FoundationDBClusters that are
+// watched by the operator.
+func (r *FoundationDBClusterReconciler) getTaintReplacementOptionsForNodeTrigger() []fdbv1beta2.TaintReplacementOption {
+	// An empty namespace will list the FoundationDBClusters in all namespaces.
+	namespaces := r.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	var options []fdbv1beta2.TaintReplacementOption
+	for _, namespace := range namespaces {
+		clusters := &fdbv1beta2.FoundationDBClusterList{}
+		err := r.List(context.Background(), clusters, client.InNamespace(namespace))
+		if err != nil {
+			r.Log.Error(err, "could not fetch clusters for the taint replacement options", "namespace", namespace)
+			continue
+		}
+
+		for _, cluster := range clusters.Items {
+			options = append(options, cluster.Spec.AutomationOptions.Replacements.TaintReplacementOptions...)
+		}
+	}
+
+	return options
+}
+
+// getClusterNameForNodeTrigger returns the value of the first label key in ClusterLabelKeysForNodeTrigger that is
+// present in the provided labels. If none of the label keys is present an empty string will be returned.
+func (r *FoundationDBClusterReconciler) getClusterNameForNodeTrigger(labels map[string]string) string {
+	for _, labelKey := range r.ClusterLabelKeysForNodeTrigger {
+		clusterName, ok := labels[labelKey]
+		if ok && clusterName != "" {
+			return clusterName
+		}
+	}
+
+	return ""
+}
+
 func (r *FoundationDBClusterReconciler) updatePodDynamicConf(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) (bool, error) {
 	if cluster.ProcessGroupIsBeingRemoved(podmanager.GetProcessGroupID(cluster, pod)) {
 		return true, nil
@@ -382,6 +892,12 @@ func (r *FoundationDBClusterReconciler) updatePodDynamicConf(logger logr.Logger,
 		return false, err
 	}
 
+	err = internal.ValidateSubstitutions(cluster, processClass, pod, serversPerPod)
+	if err != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "MissingSubstitution", err.Error())
+		return false, err
+	}
+
 	var expectedConf string
 
 	imageType := internal.GetImageType(pod)
@@ -438,6 +954,15 @@ func (r *FoundationDBClusterReconciler) getDatabaseClientProvider() fdbadminclie
 	panic("Cluster reconciler does not have a DatabaseClientProvider defined")
 }
 
+// getClock returns the clock that should be used by the reconciler.
+func (r *FoundationDBClusterReconciler) getClock() clock.Clock {
+	if r.Clock != nil {
+		return r.Clock
+	}
+
+	return clock.RealClock{}
+}
+
 func (r *FoundationDBClusterReconciler) getLockClient(cluster *fdbv1beta2.FoundationDBCluster) (fdbadminclient.LockClient, error) {
 	return r.getDatabaseClientProvider().GetLockClient(cluster)
 }
@@ -488,7 +1013,7 @@ type clusterSubReconciler interface {
 	If reconciliation cannot proceed, this should return a requeue object with
 	a `Message` field.
 	*/
-	reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue
+	reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue
 }
 
 // newFdbPodClient builds a client for working with an FDB Pod
@@ -520,9 +1045,59 @@ func (r *FoundationDBClusterReconciler) updateOrApply(ctx context.Context, clust
 	return r.Status().Update(ctx, cluster)
 }
 
+// isNativeConnectionError returns true if the error indicates that the native connection of a cluster that uses DNS
+// names in the cluster file must be recreated.
+func isNativeConnectionError(cluster *fdbv1beta2.FoundationDBCluster, err error) bool {
+	return cluster.UseDNSInClusterFile() && strings.Contains(err.Error(), "FoundationDB error code 1512")
+}
+
+// recoverNativeConnection recreates the native connection of the cluster and tries to fetch the machine-readable status
+// again. The native connection is recreated at most once per call and the delay between two recreations is increased
+// with every failed attempt. If the status can't be fetched after nativeConnectionRecreationAttempts and
+// TerminateOnNativeConnectionFailure is set, the operator will terminate itself as a last resort.
+func (r *FoundationDBClusterReconciler) recoverNativeConnection(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, statusErr error) (*fdbv1beta2.FoundationDBStatus, error) {
+	key := client.ObjectKeyFromObject(cluster).String()
+	attempt := r.nativeConnectionBackoff.next(key, r.getClock().Now())
+	if attempt == 0 {
+		logger.Info("Waiting before recreating the native connection again", "error", statusErr.Error())
+		return nil, statusErr
+	}
+
+	logger.Info("Recreating native connection", "attempt", attempt, "error", statusErr.Error())
+	err := r.getDatabaseClientProvider().RecreateNativeConnection(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := r.getStatusFromClusterOrDummyStatus(ctx, logger, cluster)
+	if err == nil {
+		r.nativeConnectionBackoff.reset(key)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "NativeConnectionRecreated", fmt.Sprintf("recreated native connection after %d attempt(s)", attempt))
+		return status, nil
+	}
+
+	if attempt < nativeConnectionRecreationAttempts {
+		return nil, err
+	}
+
+	if attempt == nativeConnectionRecreationAttempts {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "NativeConnectionRecreationFailed", fmt.Sprintf("could not recover native connection after %d attempts: %s", nativeConnectionRecreationAttempts, err.Error()))
+	}
+
+	if r.TerminateOnNativeConnectionFailure {
+		logger.Info("Could not recover native connection, terminating the operator")
+		killErr := syscall.Kill(os.Getpid(), syscall.SIGTERM)
+		if killErr != nil {
+			logger.Error(killErr, "could not terminate the operator")
+		}
+	}
+
+	return nil, err
+}
+
 // getStatusFromClusterOrDummyStatus will fetch the machine-readable status from the FoundationDBCluster if the cluster is configured. If not a default status is returned indicating, that
 // some configuration is missing.
-func (r *FoundationDBClusterReconciler) getStatusFromClusterOrDummyStatus(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) (*fdbv1beta2.FoundationDBStatus, error) {
+func (r *FoundationDBClusterReconciler) getStatusFromClusterOrDummyStatus(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) (*fdbv1beta2.FoundationDBStatus, error) {
 	if cluster.Status.ConnectionString == "" {
 		return &fdbv1beta2.FoundationDBStatus{
 			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
@@ -558,6 +1133,19 @@ func (r *FoundationDBClusterReconciler) getStatusFromClusterOrDummyStatus(logger
 		adminClient.SetTimeout(10 * time.Second)
 	}
 
+	// If the reconciliation has a deadline, make sure the status call is not running longer than the deadline.
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, context.DeadlineExceeded
+		}
+
+		// The remaining time must only lower the timeout, otherwise the configured timeout would be raised.
+		if remaining < adminClient.GetTimeout() {
+			adminClient.SetTimeout(remaining)
+		}
+	}
+
 	status, err := adminClient.GetStatus()
 	if err == nil {
 		return status, nil
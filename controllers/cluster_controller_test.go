@@ -23,10 +23,14 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
 
@@ -35,9 +39,13 @@ import (
 	"k8s.io/utils/pointer"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	fdbmetrics "github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
 
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/expfmt"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -48,6 +56,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
@@ -438,6 +447,15 @@ var _ = Describe("cluster_controller", func() {
 					removedItem.Status.PodIP: true,
 				}))
 			})
+
+			It("should record the inclusion in the cluster status", func() {
+				removedItem := originalPods.Items[16]
+				Expect(cluster.Status.RecentInclusions).To(HaveLen(1))
+				inclusion := cluster.Status.RecentInclusions[0]
+				Expect(inclusion.Address).To(Equal(removedItem.Status.PodIP))
+				Expect(inclusion.ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID(removedItem.Labels[fdbv1beta2.FDBProcessGroupIDLabel])))
+				Expect(inclusion.IncludedAt).NotTo(BeNil())
+			})
 		})
 
 		Context("with an increased process count", func() {
@@ -851,7 +869,7 @@ var _ = Describe("cluster_controller", func() {
 				// Run a single reconciliation to detect the missing process.
 				result, err := reconcileObject(clusterReconciler, cluster.ObjectMeta, 1)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(result.Requeue).To(BeTrue())
+				Expect(result.IsZero()).To(BeFalse())
 
 				// Tweak the time on the missing process to make it eligible for replacement.
 				_, err = reloadCluster(cluster)
@@ -1564,6 +1582,159 @@ var _ = Describe("cluster_controller", func() {
 			})
 		})
 
+		When("a sub-reconciler delays the requeue", func() {
+			BeforeEach(func() {
+				desiredConfigMap, err := internal.GetConfigMap(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				configMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(desiredConfigMap), configMap)).NotTo(HaveOccurred())
+				if configMap.Annotations == nil {
+					configMap.Annotations = map[string]string{}
+				}
+				configMap.Annotations[fdbv1beta2.LastConfigMapUpdateKey] = strconv.FormatInt(time.Now().Unix(), 10)
+				Expect(k8sClient.Update(context.TODO(), configMap)).NotTo(HaveOccurred())
+
+				cluster.Spec.AutomationOptions.ConfigMapUpdateDebounceSeconds = pointer.Int(600)
+				cluster.Spec.ConfigMap = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"fdb-label": "delayed",
+						},
+					},
+				}
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				shouldCompleteReconciliation = false
+			})
+
+			It("should record the reconciler in the status", func() {
+				_, err := reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Status.LastRequeue).NotTo(BeNil())
+				Expect(cluster.Status.LastRequeue.Reconciler).To(Equal("controllers.updateConfigMap"))
+				Expect(cluster.Status.LastRequeue.Message).To(Equal("Delaying config map update to coalesce changes"))
+				Expect(cluster.Status.LastRequeue.Delayed).To(BeTrue())
+				Expect(cluster.Status.LastRequeue.Timestamp).NotTo(BeNil())
+			})
+		})
+
+		When("the cluster is reconciled multiple times", func() {
+			BeforeEach(func() {
+				generationGap = 0
+			})
+
+			It("should increment the reconcile loop counter in the status", func() {
+				_, err := reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Status.ReconcileLoops).NotTo(BeNil())
+				persistedLoops := cluster.Status.ReconcileLoops.DeepCopy()
+
+				_, err = reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Status.ReconcileLoops.WindowStart.Equal(persistedLoops.WindowStart)).To(BeTrue())
+				Expect(cluster.Status.ReconcileLoops.Count).To(BeNumerically(">", persistedLoops.Count))
+				Expect(clusterReconciler.reconcileLoops.get(client.ObjectKeyFromObject(cluster).String()).Count).To(Equal(cluster.Status.ReconcileLoops.Count))
+			})
+
+			When("the window has passed", func() {
+				var fakeClock *testingclock.FakeClock
+
+				BeforeEach(func() {
+					fakeClock = testingclock.NewFakeClock(time.Now().Add(reconcileLoopWindow + time.Minute))
+					clusterReconciler.Clock = fakeClock
+				})
+
+				AfterEach(func() {
+					clusterReconciler.Clock = nil
+				})
+
+				It("should start a new window", func() {
+					_, err := reloadCluster(cluster)
+					Expect(err).NotTo(HaveOccurred())
+					previousLoops := cluster.Status.ReconcileLoops.DeepCopy()
+
+					_, err = clusterReconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cluster)})
+					Expect(err).NotTo(HaveOccurred())
+					_, err = reloadCluster(cluster)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cluster.Status.ReconcileLoops.WindowStart.Time).To(BeTemporally("==", fakeClock.Now().Truncate(time.Second)))
+					Expect(cluster.Status.ReconcileLoops.Count).To(Equal(1))
+					Expect(cluster.Status.ReconcileLoops.PreviousCount).To(Equal(previousLoops.Count))
+				})
+			})
+		})
+
+		When("the reconcile trace is stored as annotation", func() {
+			BeforeEach(func() {
+				traceMode := fdbv1beta2.ReconcileTraceModeAnnotation
+				cluster.Spec.AutomationOptions.ReconcileTraceMode = &traceMode
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should store the decisions of all sub-reconcilers", func() {
+				_, err := reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Annotations).To(HaveKey(fdbv1beta2.LastReconcileTraceKey))
+
+				trace := &reconcileTrace{}
+				Expect(json.Unmarshal([]byte(cluster.Annotations[fdbv1beta2.LastReconcileTraceKey]), trace)).NotTo(HaveOccurred())
+				Expect(trace.TraceID).NotTo(BeEmpty())
+				Expect(trace.Generation).To(Equal(originalVersion + 1))
+				Expect(trace.Result).To(Equal("reconciled"))
+				Expect(trace.Decisions).To(HaveLen(27))
+				Expect(trace.Decisions[0]).To(Equal(reconcileDecision{
+					Reconciler: "controllers.updateStatus",
+					Decision:   reconcileDecisionCompleted,
+				}))
+				Expect(trace.Decisions[len(trace.Decisions)-1].Reconciler).To(Equal("controllers.updateStatus"))
+				for _, decision := range trace.Decisions {
+					Expect(decision.Decision).To(Equal(reconcileDecisionCompleted))
+				}
+			})
+		})
+
+		When("the reconcile trace is stored as annotation and a sub-reconciler delays the requeue", func() {
+			BeforeEach(func() {
+				traceMode := fdbv1beta2.ReconcileTraceModeAnnotation
+				cluster.Spec.AutomationOptions.ReconcileTraceMode = &traceMode
+				cluster.Spec.AutomationOptions.ConfigMapUpdateDebounceSeconds = pointer.Int(600)
+				cluster.Spec.ConfigMap = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"fdb-label": "delayed",
+						},
+					},
+				}
+
+				desiredConfigMap, err := internal.GetConfigMap(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				configMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(desiredConfigMap), configMap)).NotTo(HaveOccurred())
+				if configMap.Annotations == nil {
+					configMap.Annotations = map[string]string{}
+				}
+				configMap.Annotations[fdbv1beta2.LastConfigMapUpdateKey] = strconv.FormatInt(time.Now().Unix(), 10)
+				Expect(k8sClient.Update(context.TODO(), configMap)).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				shouldCompleteReconciliation = false
+			})
+
+			It("should record the delayed requeue in the trace", func() {
+				_, err := reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+
+				trace := &reconcileTrace{}
+				Expect(json.Unmarshal([]byte(cluster.Annotations[fdbv1beta2.LastReconcileTraceKey]), trace)).NotTo(HaveOccurred())
+				Expect(trace.Result).To(Equal("not fully reconciled"))
+				Expect(trace.Decisions[2].Reconciler).To(Equal("controllers.updateConfigMap"))
+				Expect(trace.Decisions[2].Decision).To(Equal(reconcileDecisionDelayedRequeue))
+				Expect(trace.Decisions[2].Message).To(Equal("Delaying config map update to coalesce changes"))
+				Expect(trace.Decisions[2].Delay).NotTo(BeEmpty())
+			})
+		})
+
 		Context("with a change to environment variables", func() {
 			BeforeEach(func() {
 				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{fdbv1beta2.ProcessClassGeneral: {PodTemplate: &corev1.PodTemplateSpec{
@@ -3695,8 +3866,667 @@ var _ = Describe("cluster_controller", func() {
 			})
 		})
 	})
+
+	When("sub-reconcilers are skipped with the annotation", func() {
+		var skippedCalls, invokedCalls int
+
+		countEvents := func(reason string) int {
+			events := &corev1.EventList{}
+			Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+			count := 0
+			for _, event := range events.Items {
+				if event.InvolvedObject.UID == cluster.UID && event.Reason == reason {
+					count++
+				}
+			}
+
+			return count
+		}
+
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+			skippedCalls = 0
+			invokedCalls = 0
+			clusterReconciler.skipReconcilersAnnotations.values = nil
+			if cluster.Annotations == nil {
+				cluster.Annotations = map[string]string{}
+			}
+		})
+
+		When("the annotation lists a known sub-reconciler", func() {
+			var initialEvents int
+
+			BeforeEach(func() {
+				initialEvents = countEvents("SkippingReconcilers")
+				cluster.Annotations[fdbv1beta2.SkipReconcilersAnnotation] = "skippedTestSubReconciler"
+
+				subReconcilers := clusterReconciler.filterSkippedSubReconcilers(globalControllerLogger, cluster, []clusterSubReconciler{
+					skippedTestSubReconciler{calls: &skippedCalls},
+					invokedTestSubReconciler{calls: &invokedCalls},
+					skippedTestSubReconciler{calls: &skippedCalls},
+				})
+
+				for _, subReconciler := range subReconcilers {
+					Expect(runClusterSubReconciler(context.TODO(), globalControllerLogger, subReconciler, clusterReconciler, cluster, nil)).To(BeNil())
+				}
+			})
+
+			It("should never invoke the skipped sub-reconciler", func() {
+				Expect(skippedCalls).To(BeZero())
+				Expect(invokedCalls).To(Equal(1))
+			})
+
+			It("should emit an event", func() {
+				Expect(countEvents("SkippingReconcilers") - initialEvents).To(Equal(1))
+			})
+
+			When("the sub-reconcilers are filtered again with the same annotation", func() {
+				BeforeEach(func() {
+					clusterReconciler.filterSkippedSubReconcilers(globalControllerLogger, cluster, []clusterSubReconciler{
+						skippedTestSubReconciler{calls: &skippedCalls},
+					})
+				})
+
+				It("should not emit another event", func() {
+					Expect(countEvents("SkippingReconcilers") - initialEvents).To(Equal(1))
+				})
+			})
+
+			When("the annotation is changed", func() {
+				BeforeEach(func() {
+					cluster.Annotations[fdbv1beta2.SkipReconcilersAnnotation] = "skippedTestSubReconciler,invokedTestSubReconciler"
+					clusterReconciler.filterSkippedSubReconcilers(globalControllerLogger, cluster, []clusterSubReconciler{
+						skippedTestSubReconciler{calls: &skippedCalls},
+						invokedTestSubReconciler{calls: &invokedCalls},
+					})
+				})
+
+				It("should emit another event", func() {
+					Expect(countEvents("SkippingReconcilers") - initialEvents).To(Equal(2))
+				})
+			})
+		})
+
+		When("the annotation lists an unknown sub-reconciler", func() {
+			var initialEvents int
+
+			BeforeEach(func() {
+				initialEvents = countEvents("UnknownSkippedReconcilers")
+				cluster.Annotations[fdbv1beta2.SkipReconcilersAnnotation] = "unknownReconciler, skippedTestSubReconciler"
+
+				subReconcilers := clusterReconciler.filterSkippedSubReconcilers(globalControllerLogger, cluster, []clusterSubReconciler{
+					skippedTestSubReconciler{calls: &skippedCalls},
+					invokedTestSubReconciler{calls: &invokedCalls},
+				})
+
+				for _, subReconciler := range subReconcilers {
+					Expect(runClusterSubReconciler(context.TODO(), globalControllerLogger, subReconciler, clusterReconciler, cluster, nil)).To(BeNil())
+				}
+			})
+
+			It("should skip the known sub-reconciler", func() {
+				Expect(skippedCalls).To(BeZero())
+				Expect(invokedCalls).To(Equal(1))
+			})
+
+			It("should emit a warning event", func() {
+				Expect(countEvents("UnknownSkippedReconcilers") - initialEvents).To(Equal(1))
+			})
+		})
+
+		When("the cluster is reconciled with the annotation", func() {
+			var initialEvents int
+
+			BeforeEach(func() {
+				initialEvents = countEvents("SkippingReconcilers")
+				cluster.Annotations[fdbv1beta2.SkipReconcilersAnnotation] = "updatePods"
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				_, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should emit an event for the skipped sub-reconciler", func() {
+				Expect(countEvents("SkippingReconcilers") - initialEvents).To(BeNumerically(">=", 1))
+			})
+		})
+	})
+
+	When("assembling the sub-reconcilers", func() {
+		getNames := func(reconciler *FoundationDBClusterReconciler) []string {
+			subReconcilers := reconciler.getSubReconcilers(cluster)
+			names := make([]string, 0, len(subReconcilers))
+			for _, subReconciler := range subReconcilers {
+				names = append(names, getSubReconcilerName(subReconciler))
+			}
+
+			return names
+		}
+
+		defaultNames := []string{
+			"updateStatus",
+			"updateLockConfiguration",
+			"updateConfigMap",
+			"checkClientCompatibility",
+			"deletePodsForBuggification",
+			"replaceMisconfiguredProcessGroups",
+			"replaceFailedProcessGroups",
+			"addProcessGroups",
+			"addServices",
+			"updatePodDisruptionBudgets",
+			"addPVCs",
+			"addPods",
+			"generateInitialClusterFile",
+			"removeIncompatibleProcesses",
+			"updateSidecarVersions",
+			"updatePodConfig",
+			"updateMetadata",
+			"updateDatabaseConfiguration",
+			"chooseRemovals",
+			"excludeProcesses",
+			"changeCoordinators",
+			"bounceProcesses",
+			"maintenanceModeChecker",
+			"updatePods",
+			"removeProcessGroups",
+			"removeServices",
+			"updateStatus",
+		}
+
+		It("should return all sub-reconcilers in a stable order", func() {
+			reconciler := &FoundationDBClusterReconciler{}
+			Expect(getNames(reconciler)).To(Equal(defaultNames))
+			Expect(getNames(reconciler)).To(Equal(defaultNames))
+		})
+
+		DescribeTable("disabling a sub-reconciler", func(reconciler *FoundationDBClusterReconciler, disabled string) {
+			expected := make([]string, 0, len(defaultNames)-1)
+			for _, name := range defaultNames {
+				if name != disabled {
+					expected = append(expected, name)
+				}
+			}
+
+			Expect(getNames(reconciler)).To(Equal(expected))
+		},
+			Entry("checkClientCompatibility is disabled", &FoundationDBClusterReconciler{DisableCheckClientCompatibility: true}, "checkClientCompatibility"),
+			Entry("deletePodsForBuggification is disabled", &FoundationDBClusterReconciler{DisableDeletePodsForBuggification: true}, "deletePodsForBuggification"),
+			Entry("updatePodDisruptionBudgets is disabled", &FoundationDBClusterReconciler{DisableUpdatePodDisruptionBudgets: true}, "updatePodDisruptionBudgets"),
+			Entry("maintenanceModeChecker is disabled", &FoundationDBClusterReconciler{DisableMaintenanceModeChecker: true}, "maintenanceModeChecker"),
+		)
+
+		When("the operator resets the maintenance mode of the cluster", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaintenanceModeOptions.ResetMaintenanceMode = pointer.Bool(true)
+			})
+
+			It("should not remove the maintenanceModeChecker", func() {
+				Expect(getNames(&FoundationDBClusterReconciler{DisableMaintenanceModeChecker: true})).To(Equal(defaultNames))
+			})
+		})
+	})
+
+	DescribeTable("recording the reconcile loops", func(loops *fdbv1beta2.ReconcileLoopStatus, now time.Time, expected fdbv1beta2.ReconcileLoopStatus) {
+		recordReconcileLoop(loops, now)
+		Expect(*loops).To(Equal(expected))
+	},
+		Entry("no loops were recorded before",
+			&fdbv1beta2.ReconcileLoopStatus{},
+			time.Unix(1000, 0),
+			fdbv1beta2.ReconcileLoopStatus{
+				WindowStart: &metav1.Time{Time: time.Unix(1000, 0)},
+				Count:       1,
+			},
+		),
+		Entry("the current window has not passed",
+			&fdbv1beta2.ReconcileLoopStatus{
+				WindowStart:   &metav1.Time{Time: time.Unix(1000, 0)},
+				Count:         5,
+				PreviousCount: 2,
+			},
+			time.Unix(1000, 0).Add(reconcileLoopWindow-time.Second),
+			fdbv1beta2.ReconcileLoopStatus{
+				WindowStart:   &metav1.Time{Time: time.Unix(1000, 0)},
+				Count:         6,
+				PreviousCount: 2,
+			},
+		),
+		Entry("the current window has passed",
+			&fdbv1beta2.ReconcileLoopStatus{
+				WindowStart:   &metav1.Time{Time: time.Unix(1000, 0)},
+				Count:         5,
+				PreviousCount: 2,
+			},
+			time.Unix(1000, 0).Add(reconcileLoopWindow),
+			fdbv1beta2.ReconcileLoopStatus{
+				WindowStart:   &metav1.Time{Time: time.Unix(1000, 0).Add(reconcileLoopWindow)},
+				Count:         1,
+				PreviousCount: 5,
+			},
+		),
+		Entry("more than one window has passed",
+			&fdbv1beta2.ReconcileLoopStatus{
+				WindowStart:   &metav1.Time{Time: time.Unix(1000, 0)},
+				Count:         5,
+				PreviousCount: 2,
+			},
+			time.Unix(1000, 0).Add(3*reconcileLoopWindow),
+			fdbv1beta2.ReconcileLoopStatus{
+				WindowStart: &metav1.Time{Time: time.Unix(1000, 0).Add(3 * reconcileLoopWindow)},
+				Count:       1,
+			},
+		),
+	)
+})
+
+var _ = Describe("recoverNativeConnection", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var status *fdbv1beta2.FoundationDBStatus
+	var err error
+	var fakeClock *testingclock.FakeClock
+	connectionErr := fmt.Errorf("FoundationDB error code 1512 (Unable to connect)")
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClock = testingclock.NewFakeClock(time.Now())
+		clusterReconciler.Clock = fakeClock
+		clusterReconciler.nativeConnectionBackoff.recreations = nil
+	})
+
+	AfterEach(func() {
+		adminClient.MockError(nil)
+		clusterReconciler.Clock = nil
+	})
+
+	JustBeforeEach(func() {
+		status, err = clusterReconciler.recoverNativeConnection(context.TODO(), globalControllerLogger, cluster, connectionErr)
+	})
+
+	When("recreating the native connection fixes the error", func() {
+		BeforeEach(func() {
+			adminClient.MockRecoverableError(connectionErr)
+		})
+
+		It("should return the status after a single recreation", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).NotTo(BeNil())
+			Expect(adminClient.NativeConnectionRecreations).To(Equal(1))
+		})
+	})
+
+	When("recreating the native connection doesn't fix the error", func() {
+		BeforeEach(func() {
+			adminClient.MockError(connectionErr)
+		})
+
+		It("should return the error after a single recreation", func() {
+			Expect(err).To(MatchError(connectionErr))
+			Expect(status).To(BeNil())
+			Expect(adminClient.NativeConnectionRecreations).To(Equal(1))
+		})
+
+		It("should not recreate the native connection again before the delay has passed", func() {
+			_, err = clusterReconciler.recoverNativeConnection(context.TODO(), globalControllerLogger, cluster, connectionErr)
+			Expect(err).To(MatchError(connectionErr))
+			Expect(adminClient.NativeConnectionRecreations).To(Equal(1))
+
+			fakeClock.Step(nativeConnectionRecreationBaseDelay)
+			_, err = clusterReconciler.recoverNativeConnection(context.TODO(), globalControllerLogger, cluster, connectionErr)
+			Expect(err).To(MatchError(connectionErr))
+			Expect(adminClient.NativeConnectionRecreations).To(Equal(2))
+		})
+	})
+})
+
+var _ = Describe("reconciliation timeout", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var result reconcile.Result
+	var err error
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+		clusterReconciler.ReconciliationTimeout = time.Nanosecond
+		fdbmetrics.SubReconcilerRequeues.Reset()
+	})
+
+	AfterEach(func() {
+		clusterReconciler.ReconciliationTimeout = 0
+	})
+
+	JustBeforeEach(func() {
+		result, err = clusterReconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cluster)})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("the reconciliation timeout is exceeded", func() {
+		It("should stop the reconciliation and requeue with a delay", func() {
+			Expect(result.Requeue).To(BeTrue())
+			Expect(result.RequeueAfter).To(Equal(reconciliationTimeoutRequeueDelay))
+			Expect(cluster.Status.LastRequeue).NotTo(BeNil())
+			Expect(cluster.Status.LastRequeue.Reconciler).To(Equal("controllers.updateStatus"))
+			Expect(cluster.Status.LastRequeue.Message).To(Equal("reconciliation timeout of 1ns exceeded while running controllers.updateStatus"))
+		})
+
+		It("should record the requeue once", func() {
+			var requeues float64
+			for _, delayed := range []string{"true", "false"} {
+				requeues += testutil.ToFloat64(fdbmetrics.SubReconcilerRequeues.WithLabelValues("controllers.updateStatus", cluster.Namespace, cluster.Name, delayed))
+			}
+			Expect(requeues).To(BeNumerically("==", 1))
+		})
+	})
+
+	When("the timeout is disabled for the cluster", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.ReconciliationTimeoutSeconds = pointer.Int(0)
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should reconcile the cluster", func() {
+			Expect(result.Requeue).To(BeFalse())
+			Expect(cluster.Status.Generations.Reconciled).To(Equal(cluster.Generation))
+		})
+	})
+})
+
+var _ = Describe("getStatusFromClusterOrDummyStatus with a reconciliation deadline", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		adminClient.SetTimeout(0)
+	})
+
+	When("the remaining time is longer than the configured timeout", func() {
+		It("should keep the configured timeout", func() {
+			ctx, cancel := context.WithTimeout(context.TODO(), time.Hour)
+			defer cancel()
+
+			_, err := clusterReconciler.getStatusFromClusterOrDummyStatus(ctx, globalControllerLogger, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adminClient.GetTimeout()).To(Equal(40 * time.Second))
+		})
+	})
+
+	When("the remaining time is shorter than the configured timeout", func() {
+		It("should lower the timeout to the remaining time", func() {
+			ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Second)
+			defer cancel()
+
+			_, err := clusterReconciler.getStatusFromClusterOrDummyStatus(ctx, globalControllerLogger, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adminClient.GetTimeout()).To(BeNumerically("<=", 20*time.Second))
+		})
+	})
+})
+
+var _ = Describe("last reconciliation error", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("the exclusion of a process is deferred", func() {
+		BeforeEach(func() {
+			// Skip the removal, otherwise the removeProcessGroups sub-reconciler would report the missing exclusion.
+			cluster.Annotations = map[string]string{
+				fdbv1beta2.SkipReconcilersAnnotation: "removeProcessGroups",
+			}
+			cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{cluster.Status.ProcessGroups[0].ProcessGroupID}
+			cluster.Spec.AutomationOptions.ExclusionMaintenanceWindows = []fdbv1beta2.MaintenanceWindow{
+				{
+					Start: "00:00",
+					End:   "00:00",
+				},
+			}
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			result, err := clusterReconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cluster)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IsZero()).To(BeFalse())
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should record the blocking sub-reconciler", func() {
+			Expect(cluster.Status.LastReconciliationError).NotTo(BeNil())
+			Expect(cluster.Status.LastReconciliationError.Reconciler).To(Equal("controllers.excludeProcesses"))
+			Expect(cluster.Status.LastReconciliationError.Severity).To(Equal(fdbv1beta2.ReconciliationErrorSeverityWarning))
+			Expect(cluster.Status.LastReconciliationError.Message).To(Equal("exclusions are deferred during the exclusion maintenance window"))
+			Expect(cluster.Status.LastReconciliationError.Timestamp).NotTo(BeNil())
+		})
+
+		When("the reconciliation completes", func() {
+			BeforeEach(func() {
+				cluster.Annotations = nil
+				cluster.Spec.AutomationOptions.ExclusionMaintenanceWindows = nil
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				_, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should clear the last reconciliation error", func() {
+				Expect(cluster.Status.LastReconciliationError).To(BeNil())
+			})
+		})
+	})
+
+	When("a call to the admin client fails", func() {
+		BeforeEach(func() {
+			adminClient.MockError(fmt.Errorf("mocked"))
+			requeue := excludeProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			Expect(requeue).NotTo(BeNil())
+			clusterReconciler.recordLastRequeue(context.TODO(), globalControllerLogger, cluster, excludeProcesses{}, requeue)
+			_, err := reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			adminClient.MockError(nil)
+		})
+
+		It("should record the blocking sub-reconciler", func() {
+			Expect(cluster.Status.LastReconciliationError).NotTo(BeNil())
+			Expect(cluster.Status.LastReconciliationError.Reconciler).To(Equal("controllers.excludeProcesses"))
+			Expect(cluster.Status.LastReconciliationError.Severity).To(Equal(fdbv1beta2.ReconciliationErrorSeverityError))
+			Expect(cluster.Status.LastReconciliationError.Message).To(Equal("mocked"))
+		})
+	})
 })
 
+var _ = Describe("delayed requeue events", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+
+	getDelayedRequeueEvents := func() []corev1.Event {
+		events := &corev1.EventList{}
+		Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+		var matchingEvents []corev1.Event
+		for _, event := range events.Items {
+			if event.InvolvedObject.UID == cluster.UID && event.Reason == "ReconciliationDelayed" {
+				matchingEvents = append(matchingEvents, event)
+			}
+		}
+
+		return matchingEvents
+	}
+
+	BeforeEach(func() {
+		clusterReconciler.delayedRequeueEvents.lastEvents = nil
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+	})
+
+	When("a sub-reconciler delays the requeue multiple times", func() {
+		BeforeEach(func() {
+			desiredConfigMap, err := internal.GetConfigMap(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			configMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(desiredConfigMap), configMap)).NotTo(HaveOccurred())
+			if configMap.Annotations == nil {
+				configMap.Annotations = map[string]string{}
+			}
+			configMap.Annotations[fdbv1beta2.LastConfigMapUpdateKey] = strconv.FormatInt(time.Now().Unix(), 10)
+			Expect(k8sClient.Update(context.TODO(), configMap)).NotTo(HaveOccurred())
+
+			cluster.Spec.AutomationOptions.ConfigMapUpdateDebounceSeconds = pointer.Int(600)
+			cluster.Spec.ConfigMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"fdb-label": "delayed",
+					},
+				},
+			}
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			for i := 0; i < 3; i++ {
+				_, err = clusterReconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cluster)})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("should emit a single event", func() {
+			events := getDelayedRequeueEvents()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Type).To(Equal(corev1.EventTypeNormal))
+			Expect(events[0].Message).To(Equal("controllers.updateConfigMap delayed the reconciliation: Delaying config map update to coalesce changes"))
+		})
+	})
+})
+
+var _ = DescribeTable("updateLastReconciliationError", func(current *fdbv1beta2.ReconciliationError, requeue *requeue, expectedChange bool, expected fdbv1beta2.ReconciliationError) {
+	cluster := &fdbv1beta2.FoundationDBCluster{
+		Status: fdbv1beta2.FoundationDBClusterStatus{
+			LastReconciliationError: current,
+		},
+	}
+
+	now := time.Unix(2000, 0)
+	Expect(updateLastReconciliationError(cluster, "controllers.excludeProcesses", requeue, now)).To(Equal(expectedChange))
+	Expect(cluster.Status.LastReconciliationError).NotTo(BeNil())
+	Expect(cluster.Status.LastReconciliationError.Reconciler).To(Equal(expected.Reconciler))
+	Expect(cluster.Status.LastReconciliationError.Message).To(Equal(expected.Message))
+	Expect(cluster.Status.LastReconciliationError.Severity).To(Equal(expected.Severity))
+	if expectedChange {
+		Expect(cluster.Status.LastReconciliationError.Timestamp.Time).To(Equal(now))
+	} else {
+		Expect(cluster.Status.LastReconciliationError.Timestamp).To(Equal(current.Timestamp))
+	}
+},
+	Entry("an error is recorded",
+		nil,
+		&requeue{curError: fmt.Errorf("mocked")},
+		true,
+		fdbv1beta2.ReconciliationError{Reconciler: "controllers.excludeProcesses", Message: "mocked", Severity: fdbv1beta2.ReconciliationErrorSeverityError},
+	),
+	Entry("a message and an error are recorded",
+		nil,
+		&requeue{message: "could not exclude", curError: fmt.Errorf("mocked")},
+		true,
+		fdbv1beta2.ReconciliationError{Reconciler: "controllers.excludeProcesses", Message: "could not exclude: mocked", Severity: fdbv1beta2.ReconciliationErrorSeverityError},
+	),
+	Entry("a delayed requeue is recorded",
+		nil,
+		&requeue{message: "waiting", delayedRequeue: true},
+		true,
+		fdbv1beta2.ReconciliationError{Reconciler: "controllers.excludeProcesses", Message: "waiting", Severity: fdbv1beta2.ReconciliationErrorSeverityWarning},
+	),
+	Entry("a long message is truncated",
+		nil,
+		&requeue{message: strings.Repeat("a", 2*maxReconciliationErrorMessageLength)},
+		true,
+		fdbv1beta2.ReconciliationError{Reconciler: "controllers.excludeProcesses", Message: strings.Repeat("a", maxReconciliationErrorMessageLength-3) + "...", Severity: fdbv1beta2.ReconciliationErrorSeverityError},
+	),
+	Entry("the same error was already recorded",
+		&fdbv1beta2.ReconciliationError{Reconciler: "controllers.excludeProcesses", Message: "mocked", Severity: fdbv1beta2.ReconciliationErrorSeverityError, Timestamp: &metav1.Time{Time: time.Unix(1000, 0)}},
+		&requeue{curError: fmt.Errorf("mocked")},
+		false,
+		fdbv1beta2.ReconciliationError{Reconciler: "controllers.excludeProcesses", Message: "mocked", Severity: fdbv1beta2.ReconciliationErrorSeverityError},
+	),
+	Entry("the same error only delays the requeue",
+		&fdbv1beta2.ReconciliationError{Reconciler: "controllers.excludeProcesses", Message: "mocked", Severity: fdbv1beta2.ReconciliationErrorSeverityError, Timestamp: &metav1.Time{Time: time.Unix(1000, 0)}},
+		&requeue{curError: fmt.Errorf("mocked"), delayedRequeue: true},
+		true,
+		fdbv1beta2.ReconciliationError{Reconciler: "controllers.excludeProcesses", Message: "mocked", Severity: fdbv1beta2.ReconciliationErrorSeverityWarning},
+	),
+)
+
+var _ = DescribeTable("truncateMessage", func(message string, maxLength int, expected string) {
+	truncated := truncateMessage(message, maxLength)
+	Expect(truncated).To(Equal(expected))
+	Expect(len(truncated)).To(BeNumerically("<=", maxLength))
+	Expect(utf8.ValidString(truncated)).To(BeTrue())
+},
+	Entry("a short message is not truncated", "mocked", 10, "mocked"),
+	Entry("a message with the maximum length is not truncated", "0123456789", 10, "0123456789"),
+	Entry("a long message is truncated", "0123456789a", 10, "0123456..."),
+	// Each "ü" is encoded with two bytes, so the cut at 7 bytes would split the fourth character.
+	Entry("a multi-byte character is not split", "üüüüüüüü", 10, "üüü..."),
+)
+
+var _ = DescribeTable("isNativeConnectionError", func(useDNS bool, err error, expected bool) {
+	cluster := &fdbv1beta2.FoundationDBCluster{
+		Spec: fdbv1beta2.FoundationDBClusterSpec{
+			Routing: fdbv1beta2.RoutingConfig{
+				UseDNSInClusterFile: pointer.Bool(useDNS),
+			},
+		},
+		Status: fdbv1beta2.FoundationDBClusterStatus{
+			RunningVersion: "7.1.25",
+		},
+	}
+
+	Expect(isNativeConnectionError(cluster, err)).To(Equal(expected))
+},
+	Entry("DNS is used and the error matches", true, fmt.Errorf("FoundationDB error code 1512 (Unable to connect)"), true),
+	Entry("DNS is not used", false, fmt.Errorf("FoundationDB error code 1512 (Unable to connect)"), false),
+	Entry("DNS is used and the error doesn't match", true, fmt.Errorf("FoundationDB error code 1031 (Operation aborted because the transaction timed out)"), false),
+)
+
+// skippedTestSubReconciler is a sub-reconciler for testing that counts how often it was invoked.
+type skippedTestSubReconciler struct {
+	calls *int
+}
+
+func (s skippedTestSubReconciler) reconcile(_ context.Context, _ *FoundationDBClusterReconciler, _ *fdbv1beta2.FoundationDBCluster, _ *cachedDatabaseStatus, _ logr.Logger) *requeue {
+	*s.calls++
+	return nil
+}
+
+// invokedTestSubReconciler is a sub-reconciler for testing that counts how often it was invoked.
+type invokedTestSubReconciler struct {
+	calls *int
+}
+
+func (s invokedTestSubReconciler) reconcile(_ context.Context, _ *FoundationDBClusterReconciler, _ *fdbv1beta2.FoundationDBCluster, _ *cachedDatabaseStatus, _ logr.Logger) *requeue {
+	*s.calls++
+	return nil
+}
+
 func getProcessClassMap(cluster *fdbv1beta2.FoundationDBCluster, pods []corev1.Pod) map[fdbv1beta2.ProcessClass]int {
 	counts := make(map[fdbv1beta2.ProcessClass]int)
 	for _, pod := range pods {
@@ -3722,3 +4552,129 @@ func getConfigMapHash(cluster *fdbv1beta2.FoundationDBCluster, pClass fdbv1beta2
 
 	return internal.GetDynamicConfHash(configMap, pClass, imageType, serversPerPod)
 }
+
+var _ = Describe("getClusterNameForNodeTrigger", func() {
+	DescribeTable("getting the cluster name from the Pod labels", func(labelKeys []string, labels map[string]string, expected string) {
+		reconciler := &FoundationDBClusterReconciler{ClusterLabelKeysForNodeTrigger: labelKeys}
+		Expect(reconciler.getClusterNameForNodeTrigger(labels)).To(Equal(expected))
+	},
+		Entry("no label key is present", []string{"cluster", "fdb-cluster"}, map[string]string{"app": "test"}, ""),
+		Entry("the first label key is present", []string{"cluster", "fdb-cluster"}, map[string]string{"cluster": "test"}, "test"),
+		Entry("the second label key is present", []string{"cluster", "fdb-cluster"}, map[string]string{"fdb-cluster": "test"}, "test"),
+		Entry("both label keys are present", []string{"cluster", "fdb-cluster"}, map[string]string{"cluster": "test", "fdb-cluster": "other"}, "test"),
+		Entry("the first label key has an empty value", []string{"cluster", "fdb-cluster"}, map[string]string{"cluster": "", "fdb-cluster": "other"}, "other"),
+	)
+})
+
+// nodeLocalityPodLifecycleManager simulates the init container that waits for the node locality annotations: new Pods
+// stay pending until all annotations the init container waits for are present.
+type nodeLocalityPodLifecycleManager struct {
+	podmanager.StandardPodLifecycleManager
+}
+
+// CreatePod creates the Pod in the pending phase.
+func (manager nodeLocalityPodLifecycleManager) CreatePod(ctx context.Context, r client.Client, pod *corev1.Pod) error {
+	pod.Status.Phase = corev1.PodPending
+	return manager.StandardPodLifecycleManager.CreatePod(ctx, r, pod)
+}
+
+// UpdateMetadata updates the Pod's metadata and starts the Pod once the node locality annotations are present.
+func (manager nodeLocalityPodLifecycleManager) UpdateMetadata(ctx context.Context, r client.Client, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) error {
+	if nodeLocalityAnnotationsPresent(pod) {
+		pod.Status.Phase = corev1.PodRunning
+	}
+
+	return manager.StandardPodLifecycleManager.UpdateMetadata(ctx, r, cluster, pod)
+}
+
+func nodeLocalityAnnotationsPresent(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name != fdbv1beta2.NodeLabelsContainerName {
+			continue
+		}
+
+		for _, env := range container.Env {
+			if env.Name != "FDB_NODE_LABEL_ANNOTATIONS" {
+				continue
+			}
+
+			for _, annotation := range strings.Fields(env.Value) {
+				if _, ok := pod.Annotations[annotation]; !ok {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+var _ = Describe("reconciling a new cluster with node locality annotations", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var result reconcile.Result
+	var err error
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		clusterReconciler.PodLifecycleManager = nodeLocalityPodLifecycleManager{}
+	})
+
+	AfterEach(func() {
+		clusterReconciler.PodLifecycleManager = podmanager.StandardPodLifecycleManager{}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err = reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("a rack key is defined", func() {
+		BeforeEach(func() {
+			cluster.Spec.FaultDomain.RackKey = "topology.kubernetes.io/rack"
+		})
+
+		It("should reconcile the cluster", func() {
+			Expect(result.Requeue).To(BeFalse())
+			Expect(cluster.Status.ConnectionString).NotTo(BeEmpty())
+			Expect(cluster.Status.Generations.Reconciled).To(Equal(cluster.Generation))
+		})
+
+		It("should set the rack annotation on all Pods and start them", func() {
+			pods := &corev1.PodList{}
+			Expect(k8sClient.List(context.TODO(), pods, getListOptions(cluster)...)).NotTo(HaveOccurred())
+			Expect(pods.Items).To(HaveLen(17))
+
+			for _, pod := range pods.Items {
+				Expect(pod.Annotations).To(HaveKey(fdbv1beta2.RackAnnotation))
+				Expect(pod.Status.Phase).To(Equal(corev1.PodRunning))
+			}
+		})
+	})
+
+	When("the machine ID is taken from a node label", func() {
+		BeforeEach(func() {
+			cluster.Spec.MachineIDSource = &fdbv1beta2.MachineIDSource{NodeLabel: corev1.LabelHostname}
+		})
+
+		It("should reconcile the cluster", func() {
+			Expect(result.Requeue).To(BeFalse())
+			Expect(cluster.Status.ConnectionString).NotTo(BeEmpty())
+			Expect(cluster.Status.Generations.Reconciled).To(Equal(cluster.Generation))
+		})
+
+		It("should set the machine ID annotation on all Pods and start them", func() {
+			pods := &corev1.PodList{}
+			Expect(k8sClient.List(context.TODO(), pods, getListOptions(cluster)...)).NotTo(HaveOccurred())
+			Expect(pods.Items).To(HaveLen(17))
+
+			for _, pod := range pods.Items {
+				Expect(pod.Annotations).To(HaveKey(fdbv1beta2.MachineIDAnnotation))
+				Expect(pod.Status.Phase).To(Equal(corev1.PodRunning))
+			}
+		})
+	})
+})
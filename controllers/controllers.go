@@ -30,9 +30,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
 )
 
 var globalControllerLogger = logf.Log.WithName("controller")
@@ -94,8 +96,23 @@ type requeue struct {
 	delayedRequeue bool
 }
 
+// runSubReconciler runs the provided sub-reconciler function and records the duration of the run and the requested
+// requeue in the metrics.
+func runSubReconciler(subReconciler interface{}, object client.Object, run func() *requeue) *requeue {
+	startTime := time.Now()
+	result := run()
+	reconciler := fmt.Sprintf("%T", subReconciler)
+	metrics.ObserveSubReconcilerDuration(reconciler, object.GetNamespace(), object.GetName(), time.Since(startTime))
+
+	if result != nil {
+		metrics.RecordSubReconcilerRequeue(reconciler, object.GetNamespace(), object.GetName(), result.delayedRequeue, result.curError != nil)
+	}
+
+	return result
+}
+
 // processRequeue interprets a requeue result from a subreconciler.
-func processRequeue(requeue *requeue, subReconciler interface{}, object runtime.Object, recorder record.EventRecorder, logger logr.Logger) (ctrl.Result, error) {
+func processRequeue(requeue *requeue, subReconciler interface{}, object client.Object, recorder record.EventRecorder, logger logr.Logger) (ctrl.Result, error) {
 	curLog := logger.WithValues("reconciler", fmt.Sprintf("%T", subReconciler), "requeueAfter", requeue.delay)
 	if requeue.message == "" && requeue.curError != nil {
 		requeue.message = requeue.curError.Error()
@@ -0,0 +1,74 @@
+/*
+ * degraded_cluster_priority.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// isFaultToleranceDegraded returns true if the fault tolerance reported in the machine-readable status is below the
+// desired fault tolerance of the cluster. Clusters that are not yet configured are never reported as degraded.
+func isFaultToleranceDegraded(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) bool {
+	if status == nil || !cluster.Status.Configured {
+		return false
+	}
+
+	return status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingData < cluster.DesiredFaultTolerance()
+}
+
+// prioritizeDegradedCluster shortens the requeue delay of the provided result if the fault tolerance of the cluster is
+// degraded, this makes sure that degraded clusters are reconciled before healthy clusters. The requeue delay will be
+// divided by the DegradedClusterRequeueDelayDivisor and capped at the DegradedClusterMaximumRequeueDelay. Requeues
+// without a delay will use the DegradedClusterMaximumRequeueDelay instead of the exponential backoff of the work queue.
+func (r *FoundationDBClusterReconciler) prioritizeDegradedCluster(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, result ctrl.Result) ctrl.Result {
+	if !result.Requeue && result.RequeueAfter == 0 {
+		return result
+	}
+
+	if r.DegradedClusterRequeueDelayDivisor <= 1 && r.DegradedClusterMaximumRequeueDelay <= 0 {
+		return result
+	}
+
+	if !isFaultToleranceDegraded(cluster, status) {
+		return result
+	}
+
+	delay := result.RequeueAfter
+	if r.DegradedClusterRequeueDelayDivisor > 1 {
+		delay /= time.Duration(r.DegradedClusterRequeueDelayDivisor)
+	}
+
+	if r.DegradedClusterMaximumRequeueDelay > 0 && (delay == 0 || delay > r.DegradedClusterMaximumRequeueDelay) {
+		delay = r.DegradedClusterMaximumRequeueDelay
+	}
+
+	logger.V(1).Info("Prioritizing requeue for cluster with degraded fault tolerance",
+		"faultTolerance", status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingData,
+		"desiredFaultTolerance", cluster.DesiredFaultTolerance(),
+		"originalRequeueAfter", result.RequeueAfter,
+		"requeueAfter", delay)
+
+	return ctrl.Result{Requeue: true, RequeueAfter: delay}
+}
@@ -0,0 +1,111 @@
+/*
+ * degraded_cluster_priority_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var _ = Describe("degraded_cluster_priority", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var reconciler *FoundationDBClusterReconciler
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Status.Configured = true
+		reconciler = &FoundationDBClusterReconciler{
+			DegradedClusterRequeueDelayDivisor: 4,
+			DegradedClusterMaximumRequeueDelay: 10 * time.Second,
+		}
+	})
+
+	getStatus := func(faultTolerance int) *fdbv1beta2.FoundationDBStatus {
+		return &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				FaultTolerance: fdbv1beta2.FaultTolerance{
+					MaxZoneFailuresWithoutLosingData: faultTolerance,
+				},
+			},
+		}
+	}
+
+	DescribeTable("prioritizing the requeue of a cluster",
+		func(status *fdbv1beta2.FoundationDBStatus, configured bool, result ctrl.Result, expected ctrl.Result) {
+			cluster.Status.Configured = configured
+			Expect(reconciler.prioritizeDegradedCluster(globalControllerLogger, cluster, status, result)).To(Equal(expected))
+		},
+		Entry("the fault tolerance is degraded",
+			getStatus(0), true,
+			ctrl.Result{Requeue: true, RequeueAfter: 20 * time.Second},
+			ctrl.Result{Requeue: true, RequeueAfter: 5 * time.Second},
+		),
+		Entry("the fault tolerance is degraded and the divided delay exceeds the maximum delay",
+			getStatus(0), true,
+			ctrl.Result{Requeue: true, RequeueAfter: time.Minute},
+			ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+		),
+		Entry("the fault tolerance is degraded and the requeue has no delay",
+			getStatus(0), true,
+			ctrl.Result{Requeue: true},
+			ctrl.Result{Requeue: true, RequeueAfter: 10 * time.Second},
+		),
+		Entry("the fault tolerance is degraded and no requeue is requested",
+			getStatus(0), true,
+			ctrl.Result{},
+			ctrl.Result{},
+		),
+		Entry("the fault tolerance is the desired fault tolerance",
+			getStatus(1), true,
+			ctrl.Result{Requeue: true, RequeueAfter: time.Minute},
+			ctrl.Result{Requeue: true, RequeueAfter: time.Minute},
+		),
+		Entry("the status is not cached",
+			nil, true,
+			ctrl.Result{Requeue: true, RequeueAfter: time.Minute},
+			ctrl.Result{Requeue: true, RequeueAfter: time.Minute},
+		),
+		Entry("the cluster is not yet configured",
+			getStatus(0), false,
+			ctrl.Result{Requeue: true, RequeueAfter: time.Minute},
+			ctrl.Result{Requeue: true, RequeueAfter: time.Minute},
+		),
+	)
+
+	When("the priority boost is disabled", func() {
+		BeforeEach(func() {
+			reconciler.DegradedClusterRequeueDelayDivisor = 1
+			reconciler.DegradedClusterMaximumRequeueDelay = 0
+		})
+
+		It("should not change the requeue delay", func() {
+			result := ctrl.Result{Requeue: true, RequeueAfter: time.Minute}
+			Expect(reconciler.prioritizeDegradedCluster(globalControllerLogger, cluster, getStatus(0), result)).To(Equal(result))
+		})
+	})
+})
@@ -0,0 +1,192 @@
+/*
+ * deletion_cleanup.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ensureDeletionCleanupFinalizer adds the deletion cleanup finalizer if the deletion cleanup is enabled and removes it
+// if the deletion cleanup is disabled.
+func (r *FoundationDBClusterReconciler) ensureDeletionCleanupFinalizer(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) error {
+	enabled := cluster.ShouldRunDeletionCleanup()
+	if enabled == controllerutil.ContainsFinalizer(cluster, fdbv1beta2.DeletionCleanupFinalizer) {
+		return nil
+	}
+
+	if enabled {
+		logger.Info("Adding deletion cleanup finalizer")
+		controllerutil.AddFinalizer(cluster, fdbv1beta2.DeletionCleanupFinalizer)
+	} else {
+		logger.Info("Removing deletion cleanup finalizer")
+		controllerutil.RemoveFinalizer(cluster, fdbv1beta2.DeletionCleanupFinalizer)
+	}
+
+	return r.Update(ctx, cluster)
+}
+
+// runDeletionCleanup performs the configured cleanup steps for a cluster that is being deleted and removes the
+// deletion cleanup finalizer once all steps are done or the cleanup timeout is exceeded.
+func (r *FoundationDBClusterReconciler) runDeletionCleanup(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) (ctrl.Result, error) {
+	timeout := cluster.GetDeletionCleanupTimeout()
+	if time.Since(cluster.DeletionTimestamp.Time) > timeout {
+		logger.Info("Deletion cleanup was not finished in time, removing finalizer", "timeout", timeout.String())
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "DeletionCleanupTimedOut", fmt.Sprintf("deletion cleanup was not finished after %s", timeout.String()))
+		controllerutil.RemoveFinalizer(cluster, fdbv1beta2.DeletionCleanupFinalizer)
+		return ctrl.Result{}, r.Update(ctx, cluster)
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer adminClient.Close()
+
+	for _, step := range cluster.GetDeletionCleanupSteps() {
+		logger.Info("Performing deletion cleanup step", "step", step)
+		switch step {
+		case fdbv1beta2.DeletionCleanupStepStopBackups:
+			err = stopBackupsForDeletion(logger, adminClient)
+		case fdbv1beta2.DeletionCleanupStepStatusSnapshot:
+			err = snapshotStatusForDeletion(logger, adminClient)
+		case fdbv1beta2.DeletionCleanupStepIncludeProcesses:
+			err = r.includeProcessesForDeletion(logger, cluster, adminClient)
+		case fdbv1beta2.DeletionCleanupStepReleaseLocks:
+			err = r.releaseLocksForDeletion(logger, cluster)
+		default:
+			err = fmt.Errorf("unknown deletion cleanup step %s", step)
+		}
+
+		if err != nil {
+			logger.Error(err, "Deletion cleanup step failed", "step", step)
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.Info("Deletion cleanup finished, removing finalizer")
+	controllerutil.RemoveFinalizer(cluster, fdbv1beta2.DeletionCleanupFinalizer)
+	return ctrl.Result{}, r.Update(ctx, cluster)
+}
+
+// stopBackupsForDeletion stops the currently running backups of the cluster, if any.
+func stopBackupsForDeletion(logger logr.Logger, adminClient fdbadminclient.AdminClient) error {
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	for tag, backupStatus := range status.Cluster.Layers.Backup.Tags {
+		if !backupStatus.RunningBackup {
+			continue
+		}
+
+		logger.Info("Stopping backup", "tag", tag, "url", backupStatus.CurrentContainer)
+		err = adminClient.StopBackupWithTag(tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// snapshotStatusForDeletion fetches the machine-readable status and logs it, so the last known state of the cluster
+// can be inspected after the deletion.
+func snapshotStatusForDeletion(logger logr.Logger, adminClient fdbadminclient.AdminClient) error {
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return err
+	}
+
+	output, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Final status snapshot before deletion", "status", string(output))
+	return nil
+}
+
+// includeProcessesForDeletion includes all excluded processes that belong to the process groups of the cluster.
+// Exclusions of processes that are not managed by this cluster resource will not be changed.
+func (r *FoundationDBClusterReconciler) includeProcessesForDeletion(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, adminClient fdbadminclient.AdminClient) error {
+	exclusions, err := adminClient.GetExclusions()
+	if err != nil {
+		return err
+	}
+
+	excludedServers := make(map[string]fdbv1beta2.ProcessAddress, len(exclusions))
+	for _, exclusion := range exclusions {
+		excludedServers[exclusion.String()] = exclusion
+	}
+
+	processesToInclude := make([]fdbv1beta2.ProcessAddress, 0)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if address, ok := excludedServers[processGroup.GetExclusionString()]; ok {
+			processesToInclude = append(processesToInclude, address)
+		}
+
+		for _, addr := range processGroup.Addresses {
+			if address, ok := excludedServers[addr]; ok {
+				processesToInclude = append(processesToInclude, address)
+			}
+		}
+	}
+
+	if len(processesToInclude) == 0 {
+		return nil
+	}
+
+	logger.Info("Including processes", "processes", processesToInclude)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "IncludingProcesses", fmt.Sprintf("Including processes before deletion: %v", processesToInclude))
+	return adminClient.IncludeProcesses(processesToInclude)
+}
+
+// releaseLocksForDeletion clears the pending upgrades of the cluster and releases the lock if it is held by this
+// operator instance.
+func (r *FoundationDBClusterReconciler) releaseLocksForDeletion(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster) error {
+	if !cluster.ShouldUseLocks() {
+		return nil
+	}
+
+	lockClient, err := r.getLockClient(cluster)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Clearing pending upgrades and releasing lock")
+	err = lockClient.ClearPendingUpgrades()
+	if err != nil {
+		return err
+	}
+
+	return lockClient.ReleaseLock()
+}
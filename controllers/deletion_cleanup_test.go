@@ -0,0 +1,160 @@
+/*
+ * deletion_cleanup_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var _ = Describe("deletion_cleanup", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var ownAddress fdbv1beta2.ProcessAddress
+	backupURL := "blobstore://test@test-service/test-backup?bucket=fdb-backups"
+	foreignAddress := fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP("192.168.0.1")}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.AutomationOptions.DeletionCleanup.Enabled = pointer.Bool(true)
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(adminClient.StartBackup(backupURL, 10)).NotTo(HaveOccurred())
+
+		ownAddress = fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(cluster.Status.ProcessGroups[0].Addresses[0])}
+		Expect(adminClient.ExcludeProcesses([]fdbv1beta2.ProcessAddress{ownAddress, foreignAddress})).NotTo(HaveOccurred())
+	})
+
+	It("should add the finalizer", func() {
+		Expect(cluster.Finalizers).To(ContainElement(fdbv1beta2.DeletionCleanupFinalizer))
+	})
+
+	When("the deletion cleanup is disabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.DeletionCleanup.Enabled = pointer.Bool(false)
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			_, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should remove the finalizer", func() {
+			Expect(cluster.Finalizers).NotTo(ContainElement(fdbv1beta2.DeletionCleanupFinalizer))
+		})
+	})
+
+	When("the cluster is deleted", func() {
+		var reconcileErr error
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Delete(context.TODO(), cluster)).NotTo(HaveOccurred())
+			_, reconcileErr = reconcileCluster(cluster)
+		})
+
+		It("should stop the backup and remove the cluster", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+
+			backupStatus, err := adminClient.GetBackupStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backupStatus.Status.Running).To(BeFalse())
+
+			err = k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), &fdbv1beta2.FoundationDBCluster{})
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should only include the processes of the cluster", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(adminClient.ExcludedAddresses).NotTo(HaveKey(ownAddress.String()))
+			Expect(adminClient.ExcludedAddresses).To(HaveKey(foreignAddress.String()))
+		})
+
+		When("only the status snapshot step is configured", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.DeletionCleanup.Steps = []fdbv1beta2.DeletionCleanupStep{
+					fdbv1beta2.DeletionCleanupStepStatusSnapshot,
+				}
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should not stop the backup and remove the cluster", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+
+				backupStatus, err := adminClient.GetBackupStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(backupStatus.Status.Running).To(BeTrue())
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(ownAddress.String()))
+
+				err = k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), &fdbv1beta2.FoundationDBCluster{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("the cleanup steps fail", func() {
+			BeforeEach(func() {
+				adminClient.MockError(fmt.Errorf("mocked"))
+			})
+
+			AfterEach(func() {
+				adminClient.MockError(nil)
+			})
+
+			When("the timeout is not exceeded", func() {
+				It("should keep the cluster with the finalizer", func() {
+					Expect(reconcileErr).To(HaveOccurred())
+					_, err := reloadCluster(cluster)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cluster.DeletionTimestamp.IsZero()).To(BeFalse())
+					Expect(cluster.Finalizers).To(ContainElement(fdbv1beta2.DeletionCleanupFinalizer))
+				})
+			})
+
+			When("the timeout is exceeded", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.DeletionCleanup.TimeoutSeconds = pointer.Int(0)
+					Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				})
+
+				It("should remove the finalizer and the cluster", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+
+					err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), &fdbv1beta2.FoundationDBCluster{})
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,100 @@
+/*
+ * event_rate_limiter.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"sync"
+	"time"
+)
+
+// delayedRequeueEventInterval defines how often an event for a delayed requeue of the same sub-reconciler will be
+// emitted for a cluster.
+const delayedRequeueEventInterval = 5 * time.Minute
+
+// eventRateLimiter limits how often an event with the same key will be emitted. The zero value is ready to use.
+type eventRateLimiter struct {
+	lock sync.Mutex
+	// lastEvents contains the time of the last emitted event per key.
+	lastEvents map[string]time.Time
+}
+
+// allow returns true if no event for the provided key was emitted during the provided interval. If true is returned,
+// the event will be recorded as emitted at the provided time.
+func (limiter *eventRateLimiter) allow(key string, now time.Time, interval time.Duration) bool {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+
+	if limiter.lastEvents == nil {
+		limiter.lastEvents = map[string]time.Time{}
+	}
+
+	lastEvent, ok := limiter.lastEvents[key]
+	if ok && now.Sub(lastEvent) < interval {
+		return false
+	}
+
+	// Remove outdated entries, e.g. from clusters that were deleted.
+	for eventKey, eventTime := range limiter.lastEvents {
+		if now.Sub(eventTime) >= interval {
+			delete(limiter.lastEvents, eventKey)
+		}
+	}
+
+	limiter.lastEvents[key] = now
+
+	return true
+}
+
+// annotationChangeTracker remembers the last observed value of an annotation per key, so events for the annotation
+// are only emitted when the value changes. The zero value is ready to use.
+type annotationChangeTracker struct {
+	lock sync.Mutex
+	// values contains the last observed value per key.
+	values map[string]string
+}
+
+// changed returns true if the provided value differs from the last observed value of the provided key. The provided
+// value will be recorded as the last observed value.
+func (tracker *annotationChangeTracker) changed(key string, value string) bool {
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	if tracker.values == nil {
+		tracker.values = map[string]string{}
+	}
+
+	lastValue, ok := tracker.values[key]
+	if ok && lastValue == value {
+		return false
+	}
+
+	tracker.values[key] = value
+
+	return true
+}
+
+// forget removes the last observed value of the provided key, e.g. after the annotation was removed.
+func (tracker *annotationChangeTracker) forget(key string) {
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	delete(tracker.values, key)
+}
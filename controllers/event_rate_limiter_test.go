@@ -0,0 +1,56 @@
+/*
+ * event_rate_limiter_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("event_rate_limiter", func() {
+	var limiter *eventRateLimiter
+	now := time.Unix(1000, 0)
+
+	BeforeEach(func() {
+		limiter = &eventRateLimiter{}
+		Expect(limiter.allow("test", now, time.Minute)).To(BeTrue())
+	})
+
+	It("should not allow the same event within the interval", func() {
+		Expect(limiter.allow("test", now.Add(30*time.Second), time.Minute)).To(BeFalse())
+	})
+
+	It("should allow the same event after the interval", func() {
+		Expect(limiter.allow("test", now.Add(time.Minute), time.Minute)).To(BeTrue())
+	})
+
+	It("should allow events with a different key", func() {
+		Expect(limiter.allow("other", now, time.Minute)).To(BeTrue())
+	})
+
+	It("should remove outdated entries", func() {
+		Expect(limiter.allow("other", now.Add(2*time.Minute), time.Minute)).To(BeTrue())
+		Expect(limiter.lastEvents).To(HaveLen(1))
+		Expect(limiter.lastEvents).To(HaveKey("other"))
+	})
+})
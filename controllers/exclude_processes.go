@@ -24,8 +24,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 	"github.com/go-logr/logr"
 
@@ -34,17 +37,14 @@ import (
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
 
-// ignoreMissingProcessDuration defines the duration a Process Group must have the MissingProcess condition to be
-// ignored in the exclusion check and let the exclusions potentially move forward.
-// We should consider to make this configurable in the long term.
-const ignoreMissingProcessDuration = 5 * time.Minute
-
 // excludeProcesses provides a reconciliation step for excluding processes from
 // the database.
 type excludeProcesses struct{}
 
 // reconcile runs the reconciler's work.
-func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (e excludeProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
 	if err != nil {
 		return &requeue{curError: err}
@@ -57,6 +57,8 @@ func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterRec
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		cachedStatus.record(status)
 	}
 
 	exclusions, err := fdbstatus.GetExclusions(status)
@@ -65,12 +67,24 @@ func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterRec
 	}
 	logger.Info("current exclusions", "exclusions", exclusions)
 	fdbProcessesToExcludeByClass, ongoingExclusionsByClass := getProcessesToExclude(exclusions, cluster)
+	recordExclusionMetrics(cluster, fdbProcessesToExcludeByClass, ongoingExclusionsByClass)
 
 	// No processes have to be excluded we can directly return.
 	if len(fdbProcessesToExcludeByClass) == 0 {
 		return nil
 	}
 
+	// Defer the exclusions if the operator is currently inside an exclusion maintenance window.
+	inMaintenanceWindow, err := cluster.IsInExclusionMaintenanceWindow(r.getClock().Now())
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	if inMaintenanceWindow {
+		logger.Info("Deferring exclusions because of the exclusion maintenance window", "processesToExclude", fdbProcessesToExcludeByClass)
+		return &requeue{message: "exclusions are deferred during the exclusion maintenance window", delay: time.Minute, delayedRequeue: true}
+	}
+
 	// Make sure the exclusions are coordinated across multiple operator instances.
 	if cluster.ShouldUseLocks() {
 		lockClient, err := r.getLockClient(cluster)
@@ -92,7 +106,13 @@ func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterRec
 	}
 
 	// Make sure it's safe to exclude processes.
-	err = fdbstatus.CanSafelyExcludeProcessesWithRecoveryState(cluster, status, r.MinimumRecoveryTimeForExclusion)
+	processGroupsByAddress := getProcessGroupsByExclusionAddress(cluster)
+	err = fdbstatus.CanSafelyExcludeProcessesWithRecoveryState(cluster, status, cluster.GetMinimumRecoveryTimeForExclusion(r.MinimumRecoveryTimeForExclusion), getProcessGroupsToExclude(fdbProcessesToExcludeByClass, processGroupsByAddress))
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	failedAddresses, err := getAddressesForFailedExclusion(ctx, r, cluster, logger)
 	if err != nil {
 		return &requeue{curError: err, delayedRequeue: true}
 	}
@@ -103,20 +123,27 @@ func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterRec
 		return &requeue{curError: err, delayedRequeue: true}
 	}
 
+	// If not all processes can be excluded in this reconciliation, the operator has to requeue.
+	var additionalExclusionsNeeded bool
 	desiredProcessesMap := desiredProcesses.Map()
 	for processClass := range fdbProcessesToExcludeByClass {
 		contextLogger := logger.WithValues("processClass", processClass)
 		ongoingExclusions := ongoingExclusionsByClass[processClass]
 		processesToExclude := fdbProcessesToExcludeByClass[processClass]
+		sortExclusionsByPriority(processesToExclude, processGroupsByAddress, failedAddresses)
 
-		allowedExclusions, missingProcesses := getAllowedExclusionsAndMissingProcesses(contextLogger, cluster, processClass, desiredProcessesMap[processClass], ongoingExclusions, r.InSimulation)
+		candidateZones := getZonesForExclusion(processesToExclude, processGroupsByAddress)
+		allowedExclusions, missingProcesses := getAllowedExclusionsAndMissingProcesses(contextLogger, cluster, processClass, desiredProcessesMap[processClass], ongoingExclusions, candidateZones, r.InSimulation)
+		metrics.RecordAllowedExclusions(cluster.Namespace, cluster.Name, string(processClass), allowedExclusions)
 		if allowedExclusions <= 0 {
+			additionalExclusionsNeeded = true
 			contextLogger.Info("Waiting for missing processes before continuing with the exclusion", "missingProcesses", missingProcesses, "addressesToExclude", processesToExclude, "allowedExclusions", allowedExclusions, "ongoingExclusions", ongoingExclusions)
 			continue
 		}
 
 		// If we are not able to exclude all processes at once print a log message.
 		if len(processesToExclude) > allowedExclusions {
+			additionalExclusionsNeeded = true
 			contextLogger.Info("Some processes are still missing but continuing with the exclusion", "missingProcesses", missingProcesses, "addressesToExclude", processesToExclude, "allowedExclusions", allowedExclusions, "ongoingExclusions", ongoingExclusions)
 		}
 
@@ -139,15 +166,293 @@ func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterRec
 		}
 	}
 
-	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ExcludingProcesses", fmt.Sprintf("Excluding %v", fdbProcessesToExclude))
-	err = adminClient.ExcludeProcesses(fdbProcessesToExclude)
-	if err != nil {
-		return &requeue{curError: err, delayedRequeue: true}
+	// Limit the number of process groups that are excluded at once to reduce the impact of the data movement. The
+	// process groups that are currently excluded but haven't finished the exclusion count against this limit.
+	var ongoingExclusions int
+	for _, ongoingExclusionsForClass := range ongoingExclusionsByClass {
+		ongoingExclusions += ongoingExclusionsForClass
+	}
+
+	allowedExclusions := cluster.GetMaxConcurrentExclusions() - ongoingExclusions
+	if allowedExclusions <= 0 {
+		logger.Info("Waiting for ongoing exclusions before excluding additional process groups", "maxConcurrentExclusions", cluster.GetMaxConcurrentExclusions(), "ongoingExclusions", ongoingExclusions, "addressesToExclude", fdbProcessesToExclude)
+		return &requeue{
+			message:        "more exclusions needed but the maximum number of concurrent exclusions is reached, have to wait for ongoing exclusions to finish",
+			delayedRequeue: true,
+		}
+	}
+
+	sortExclusionsByPriority(fdbProcessesToExclude, processGroupsByAddress, failedAddresses)
+	var limited bool
+	fdbProcessesToExclude, limited = limitExclusionsToProcessGroups(fdbProcessesToExclude, processGroupsByAddress, allowedExclusions)
+	if limited {
+		additionalExclusionsNeeded = true
+		logger.Info("Limiting the number of process groups that will be excluded", "maxConcurrentExclusions", cluster.GetMaxConcurrentExclusions(), "ongoingExclusions", ongoingExclusions, "addressesToExclude", fdbProcessesToExclude)
+	}
+
+	// The failed flag applies to all addresses of a single exclude command, so the processes that will never come back
+	// are excluded separately from the other processes.
+	var failedProcessesToExclude, processesToExclude []fdbv1beta2.ProcessAddress
+	for _, address := range fdbProcessesToExclude {
+		if _, ok := failedAddresses[address.String()]; ok {
+			failedProcessesToExclude = append(failedProcessesToExclude, address)
+			continue
+		}
+
+		processesToExclude = append(processesToExclude, address)
+	}
+
+	if len(failedProcessesToExclude) > 0 {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "ExcludingFailedProcesses", fmt.Sprintf("Excluding failed %v", failedProcessesToExclude))
+		err = adminClient.ExcludeProcessesWithFailed(failedProcessesToExclude, true)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	if len(processesToExclude) > 0 {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "ExcludingProcesses", fmt.Sprintf("Excluding %v", processesToExclude))
+		err = adminClient.ExcludeProcesses(processesToExclude)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	// Track when the exclusion was started to be able to report how long the exclusion took.
+	var exclusionsStarted bool
+	for _, address := range fdbProcessesToExclude {
+		processGroup, ok := processGroupsByAddress[address.String()]
+		if !ok || !processGroup.ExclusionStartedTimestamp.IsZero() {
+			continue
+		}
+
+		processGroup.MarkExclusionStarted()
+		exclusionsStarted = true
+	}
+
+	if exclusionsStarted {
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	if cluster.ChangeCoordinatorsAfterExclusion() && excludesCoordinator(cluster, status, fdbProcessesToExclude) {
+		delay := cluster.GetCoordinatorChangeDelayAfterExclusion()
+		// Waiting inside the reconciliation would block a worker, so the coordinators will be changed by the
+		// changeCoordinators subreconciler once the delay has passed.
+		if delay > 0 {
+			logger.Info("Excluded a coordinator, delaying the coordinator change", "delay", delay.String())
+			return &requeue{message: "Excluded a coordinator, waiting before changing coordinators", delay: delay, delayedRequeue: true}
+		}
+
+		// Fetch the status again to make sure the excluded coordinators are not selected again.
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		err = selectAndChangeCoordinators(ctx, r, cluster, adminClient, status, false, logger)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	if additionalExclusionsNeeded {
+		return &requeue{message: "Additional processes must be excluded", delayedRequeue: true}
 	}
 
 	return nil
 }
 
+// getProcessGroupsByExclusionAddress returns a map of all addresses, that could be used to exclude the processes of a
+// process group, to the process group.
+func getProcessGroupsByExclusionAddress(cluster *fdbv1beta2.FoundationDBCluster) map[string]*fdbv1beta2.ProcessGroupStatus {
+	processGroups := make(map[string]*fdbv1beta2.ProcessGroupStatus, len(cluster.Status.ProcessGroups))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		processGroups[processGroup.GetExclusionString()] = processGroup
+		for _, address := range processGroup.Addresses {
+			processGroups[fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(address)}.String()] = processGroup
+		}
+	}
+
+	return processGroups
+}
+
+// recordExclusionMetrics updates the metrics for the ongoing exclusions and the processes that should be excluded for
+// every process class.
+func recordExclusionMetrics(cluster *fdbv1beta2.FoundationDBCluster, processesToExcludeByClass map[fdbv1beta2.ProcessClass][]fdbv1beta2.ProcessAddress, ongoingExclusionsByClass map[fdbv1beta2.ProcessClass]int) {
+	metrics.ResetExclusions(cluster.Namespace, cluster.Name)
+
+	processClasses := map[fdbv1beta2.ProcessClass]fdbv1beta2.None{}
+	for processClass := range processesToExcludeByClass {
+		processClasses[processClass] = fdbv1beta2.None{}
+	}
+	for processClass := range ongoingExclusionsByClass {
+		processClasses[processClass] = fdbv1beta2.None{}
+	}
+
+	for processClass := range processClasses {
+		metrics.RecordPendingExclusions(cluster.Namespace, cluster.Name, string(processClass), ongoingExclusionsByClass[processClass], len(processesToExcludeByClass[processClass]))
+	}
+}
+
+// getProcessGroupsToExclude returns the process groups of the provided addresses, every process group will be
+// returned only once.
+func getProcessGroupsToExclude(processesToExcludeByClass map[fdbv1beta2.ProcessClass][]fdbv1beta2.ProcessAddress, processGroupsByAddress map[string]*fdbv1beta2.ProcessGroupStatus) []*fdbv1beta2.ProcessGroupStatus {
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0)
+	seen := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	for _, addresses := range processesToExcludeByClass {
+		for _, address := range addresses {
+			processGroup, ok := processGroupsByAddress[address.String()]
+			if !ok {
+				continue
+			}
+
+			if _, ok := seen[processGroup.ProcessGroupID]; ok {
+				continue
+			}
+
+			seen[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+			processGroups = append(processGroups, processGroup)
+		}
+	}
+
+	return processGroups
+}
+
+// isPreferredForExclusion returns true if the process group should be excluded before other process groups, because
+// the processes are missing or will be excluded with the failed flag.
+func isPreferredForExclusion(processGroup *fdbv1beta2.ProcessGroupStatus, failedAddresses map[string]fdbv1beta2.None) bool {
+	if processGroup == nil {
+		return false
+	}
+
+	if processGroup.GetConditionTime(fdbv1beta2.MissingProcesses) != nil || processGroup.GetConditionTime(fdbv1beta2.MissingPod) != nil {
+		return true
+	}
+
+	_, ok := failedAddresses[processGroup.GetExclusionString()]
+	return ok
+}
+
+// sortExclusionsByPriority sorts the addresses so that the addresses of the preferred process groups are at the
+// beginning. Otherwise, the order of the addresses is kept.
+func sortExclusionsByPriority(addresses []fdbv1beta2.ProcessAddress, processGroups map[string]*fdbv1beta2.ProcessGroupStatus, failedAddresses map[string]fdbv1beta2.None) {
+	sort.SliceStable(addresses, func(i, j int) bool {
+		return isPreferredForExclusion(processGroups[addresses[i].String()], failedAddresses) && !isPreferredForExclusion(processGroups[addresses[j].String()], failedAddresses)
+	})
+}
+
+// limitExclusionsToProcessGroups returns the addresses of at most maxExclusions process groups. A process group can
+// have multiple addresses that must be excluded, e.g. if the Pod IP changed. The returned bool will be true if at least
+// one address was removed.
+func limitExclusionsToProcessGroups(addresses []fdbv1beta2.ProcessAddress, processGroups map[string]*fdbv1beta2.ProcessGroupStatus, maxExclusions int) ([]fdbv1beta2.ProcessAddress, bool) {
+	selectedProcessGroups := map[string]fdbv1beta2.None{}
+	result := make([]fdbv1beta2.ProcessAddress, 0, len(addresses))
+	var limited bool
+
+	for _, address := range addresses {
+		key := address.String()
+		if processGroup, ok := processGroups[key]; ok {
+			key = string(processGroup.ProcessGroupID)
+		}
+
+		if _, ok := selectedProcessGroups[key]; !ok {
+			if len(selectedProcessGroups) >= maxExclusions {
+				limited = true
+				continue
+			}
+
+			selectedProcessGroups[key] = fdbv1beta2.None{}
+		}
+
+		result = append(result, address)
+	}
+
+	return result, limited
+}
+
+// excludesCoordinator returns true if at least one of the provided addresses belongs to a process that currently
+// serves as coordinator.
+func excludesCoordinator(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, addresses []fdbv1beta2.ProcessAddress) bool {
+	exclusions := make(map[string]fdbv1beta2.None, len(addresses))
+	for _, address := range addresses {
+		exclusions[address.String()] = fdbv1beta2.None{}
+	}
+
+	for _, process := range status.Cluster.Processes {
+		isCoordinator := false
+		for _, role := range process.Roles {
+			if role.Role == string(fdbv1beta2.ProcessRoleCoordinator) {
+				isCoordinator = true
+				break
+			}
+		}
+
+		if !isCoordinator {
+			continue
+		}
+
+		if _, ok := exclusions[process.Address.MachineAddress()]; ok {
+			return true
+		}
+
+		processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]))
+		if processGroup == nil {
+			continue
+		}
+
+		if _, ok := exclusions[processGroup.GetExclusionString()]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getAddressesForFailedExclusion returns the addresses of the process groups that should be excluded with the failed
+// flag. Those are process groups that are marked for removal and that have neither a Pod nor a PVC for longer than
+// the duration defined in ExcludeFailedProcessesAfterSeconds.
+func getAddressesForFailedExclusion(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, logger logr.Logger) (map[string]fdbv1beta2.None, error) {
+	addresses := map[string]fdbv1beta2.None{}
+	if !cluster.ShouldExcludeFailedProcesses() {
+		return addresses, nil
+	}
+
+	duration := cluster.GetExcludeFailedProcessesDuration()
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.IsMarkedForRemoval() || processGroup.IsExcluded() {
+			continue
+		}
+
+		missingTimestamp := processGroup.GetConditionTime(fdbv1beta2.MissingPod)
+		if missingTimestamp == nil || time.Since(time.Unix(*missingTimestamp, 0)) < duration {
+			continue
+		}
+
+		if processGroup.ProcessClass.IsStateful() {
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			err := r.List(ctx, pvcs, internal.GetSinglePodListOptions(cluster, processGroup.ProcessGroupID)...)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(pvcs.Items) > 0 {
+				continue
+			}
+		}
+
+		logger.Info("Process group has no Pod and PVC, processes will be excluded with the failed flag", "processGroupID", processGroup.ProcessGroupID, "missingTime", time.Unix(*missingTimestamp, 0).String())
+		addresses[processGroup.GetExclusionString()] = fdbv1beta2.None{}
+		for _, address := range processGroup.Addresses {
+			addresses[fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(address)}.String()] = fdbv1beta2.None{}
+		}
+	}
+
+	return addresses, nil
+}
+
 func getProcessesToExclude(exclusions []fdbv1beta2.ProcessAddress, cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1beta2.ProcessClass][]fdbv1beta2.ProcessAddress, map[fdbv1beta2.ProcessClass]int) {
 	fdbProcessesToExcludeByClass := make(map[fdbv1beta2.ProcessClass][]fdbv1beta2.ProcessAddress)
 	// This map keeps track on how many processes are currently excluded but haven't finished the exclusion yet.
@@ -220,15 +525,20 @@ func getProcessesToExclude(exclusions []fdbv1beta2.ProcessAddress, cluster *fdbv
 // getAllowedExclusionsAndMissingProcesses will check if new processes for the specified process class can be excluded. The calculation takes
 // the current ongoing exclusions into account and the desired process count. If there are process groups that have
 // the MissingProcesses condition this method will forbid exclusions until all process groups with this condition have
-// this condition for longer than ignoreMissingProcessDuration. The idea behind this is to try to exclude as many processes
-// at once e.g. to reduce the number of recoveries and data movement.
-func getAllowedExclusionsAndMissingProcesses(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, desiredProcessCount int, ongoingExclusions int, inSimulation bool) (int, []fdbv1beta2.ProcessGroupID) {
+// this condition for longer than the duration defined in IgnoreMissingProcessesForExclusionSeconds. The idea behind this is to try to exclude as many processes
+// at once e.g. to reduce the number of recoveries and data movement. The candidateZones are the zones of the processes
+// that should be excluded.
+func getAllowedExclusionsAndMissingProcesses(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, desiredProcessCount int, ongoingExclusions int, candidateZones map[fdbv1beta2.FaultDomain]fdbv1beta2.None, inSimulation bool) (int, []fdbv1beta2.ProcessGroupID) {
 	// Block excludes on missing processes not marked for removal unless they are missing for a long time and the process might be broken
 	// or the namespace quota was hit.
 	missingProcesses := make([]fdbv1beta2.ProcessGroupID, 0)
+	// Keep track of the zones that have at least one missing process and no process that should be excluded. Excluding
+	// processes in those zones would put an additional zone below the number of processes it should have.
+	shortZones := map[fdbv1beta2.FaultDomain]fdbv1beta2.None{}
 	var validProcesses int
 
 	exclusionsAllowed := true
+	ignoreMissingProcessDuration := cluster.GetIgnoreMissingProcessesForExclusionDuration()
 	for _, processGroup := range cluster.Status.ProcessGroups {
 		if processGroup.ProcessClass != processClass {
 			continue
@@ -243,6 +553,10 @@ func getAllowedExclusionsAndMissingProcesses(logger logr.Logger, cluster *fdbv1b
 		if missingTimestamp != nil && !inSimulation {
 			missingTime := time.Unix(*missingTimestamp, 0)
 			missingProcesses = append(missingProcesses, processGroup.ProcessGroupID)
+			zone := getZoneForExclusion(processGroup)
+			if _, ok := candidateZones[zone]; !ok {
+				shortZones[zone] = fdbv1beta2.None{}
+			}
 			logger.V(1).Info("Missing processes", "processGroupID", processGroup.ProcessGroupID, "missingTime", missingTime.String())
 
 			if time.Since(missingTime) < ignoreMissingProcessDuration {
@@ -255,11 +569,72 @@ func getAllowedExclusionsAndMissingProcesses(logger logr.Logger, cluster *fdbv1b
 	}
 
 	if !exclusionsAllowed {
-		logger.Info("Found at least one missing process, that was not missing for more than 5 minutes", "missingProcesses", missingProcesses)
+		logger.Info("Found at least one missing process, that was not missing for longer than the ignore duration", "missingProcesses", missingProcesses, "ignoreMissingProcessDuration", ignoreMissingProcessDuration.String())
 		return 0, missingProcesses
 	}
 
-	return getAllowedExclusions(logger, validProcesses, desiredProcessCount, ongoingExclusions, cluster.DesiredFaultTolerance()), missingProcesses
+	faultTolerance := cluster.DesiredFaultTolerance()
+	allowedExclusions := getAllowedExclusions(logger, validProcesses, desiredProcessCount, ongoingExclusions, faultTolerance)
+
+	return getZoneAwareAllowedExclusions(logger, allowedExclusions, validProcesses, desiredProcessCount, ongoingExclusions, faultTolerance, len(shortZones)), missingProcesses
+}
+
+// getZoneForExclusion returns the zone of the process group. If the fault domain of the process group is unknown, the
+// process group is treated as its own zone.
+func getZoneForExclusion(processGroup *fdbv1beta2.ProcessGroupStatus) fdbv1beta2.FaultDomain {
+	if processGroup.FaultDomain != "" {
+		return processGroup.FaultDomain
+	}
+
+	return fdbv1beta2.FaultDomain(processGroup.ProcessGroupID)
+}
+
+// getZonesForExclusion returns the zones of the process groups that the provided addresses belong to. Addresses
+// without a known process group are ignored.
+func getZonesForExclusion(addresses []fdbv1beta2.ProcessAddress, processGroups map[string]*fdbv1beta2.ProcessGroupStatus) map[fdbv1beta2.FaultDomain]fdbv1beta2.None {
+	zones := make(map[fdbv1beta2.FaultDomain]fdbv1beta2.None, len(addresses))
+	for _, address := range addresses {
+		processGroup, ok := processGroups[address.String()]
+		if !ok {
+			continue
+		}
+
+		zones[getZoneForExclusion(processGroup)] = fdbv1beta2.None{}
+	}
+
+	return zones
+}
+
+// getZoneAwareAllowedExclusions limits the allowed exclusions based on the zones that are already short of processes.
+// Exclusions that have a replacement process ready are always allowed. All additional exclusions make use of the fault
+// tolerance buffer and could put another zone below the number of processes it should have, in the worst case one
+// zone per exclusion. Zones with missing processes that don't contain any process that should be excluded are already
+// short, so they reduce the fault tolerance buffer. Zones with missing processes that contain a process that should be
+// excluded, e.g. the missing process that is replaced, are not counted as the exclusion doesn't put another zone below
+// the number of processes it should have. This prevents the operator from excluding processes without replacement
+// while other zones are already short, which would reduce the effective fault tolerance below the desired fault
+// tolerance.
+func getZoneAwareAllowedExclusions(logger logr.Logger, allowedExclusions int, validProcesses int, desiredProcessCount int, ongoingExclusions int, faultTolerance int, shortZones int) int {
+	spareProcesses := validProcesses - desiredProcessCount - ongoingExclusions
+	if spareProcesses < 0 {
+		spareProcesses = 0
+	}
+
+	if allowedExclusions <= spareProcesses {
+		return allowedExclusions
+	}
+
+	zoneBudget := faultTolerance - shortZones
+	if zoneBudget < 0 {
+		zoneBudget = 0
+	}
+
+	if allowedExclusions-spareProcesses > zoneBudget {
+		logger.Info("Limiting the allowed exclusions because some zones are already short of processes", "allowedExclusions", allowedExclusions, "spareProcesses", spareProcesses, "shortZones", shortZones, "faultTolerance", faultTolerance)
+		return spareProcesses + zoneBudget
+	}
+
+	return allowedExclusions
 }
 
 // getAllowedExclusions will return the number of allowed exclusions. If no exclusions are allowed this method will return a 0.
@@ -24,10 +24,17 @@ import (
 	"context"
 	"fmt"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	fdbmetrics "github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 	"net"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"time"
 
+	testingclock "k8s.io/utils/clock/testing"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -63,7 +70,7 @@ var _ = Describe("exclude_processes", func() {
 		JustBeforeEach(func() {
 			processCounts, err := cluster.GetProcessCountsWithDefaults()
 			Expect(err).NotTo(HaveOccurred())
-			allowedExclusions, missingProcesses = getAllowedExclusionsAndMissingProcesses(globalControllerLogger, cluster, processClass, processCounts.Map()[processClass], ongoingExclusions, false)
+			allowedExclusions, missingProcesses = getAllowedExclusionsAndMissingProcesses(globalControllerLogger, cluster, processClass, processCounts.Map()[processClass], ongoingExclusions, nil, false)
 		})
 
 		When("using a small cluster", func() {
@@ -706,6 +713,605 @@ var _ = Describe("exclude_processes", func() {
 		})
 	})
 
+	When("an exclusion maintenance window is configured", func() {
+		var adminClient *mock.AdminClient
+		var reconciler *FoundationDBClusterReconciler
+		var result *requeue
+
+		BeforeEach(func() {
+			// Monday, 1st January 2024 at 12:00 UTC.
+			reconciler = createTestClusterReconciler()
+			reconciler.Clock = testingclock.NewFakeClock(time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC))
+
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			var err error
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1")
+			Expect(processGroup).NotTo(BeNil())
+			processGroup.MarkForRemoval()
+		})
+
+		JustBeforeEach(func() {
+			result = excludeProcesses{}.reconcile(context.TODO(), reconciler, cluster, nil, globalControllerLogger)
+		})
+
+		When("the current time is inside the window", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ExclusionMaintenanceWindows = []fdbv1beta2.MaintenanceWindow{
+					{
+						Start: "00:00",
+						End:   "00:00",
+					},
+				}
+			})
+
+			It("should defer the exclusion", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.delayedRequeue).To(BeTrue())
+				Expect(result.message).To(Equal("exclusions are deferred during the exclusion maintenance window"))
+				Expect(adminClient.ExcludedAddresses).To(BeEmpty())
+			})
+		})
+
+		When("the current time is outside the window", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ExclusionMaintenanceWindows = []fdbv1beta2.MaintenanceWindow{
+					{
+						Start: "00:00",
+						End:   "00:00",
+						Days:  []string{time.Wednesday.String()},
+					},
+				}
+			})
+
+			It("should exclude the process", func() {
+				Expect(result).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).NotTo(BeEmpty())
+			})
+
+			It("should record the exclusion metrics", func() {
+				Expect(testutil.ToFloat64(fdbmetrics.OngoingExclusions.WithLabelValues(cluster.Namespace, cluster.Name, string(fdbv1beta2.ProcessClassStorage)))).To(BeNumerically("==", 0))
+				Expect(testutil.ToFloat64(fdbmetrics.PendingExclusions.WithLabelValues(cluster.Namespace, cluster.Name, string(fdbv1beta2.ProcessClassStorage)))).To(BeNumerically("==", 1))
+				Expect(testutil.ToFloat64(fdbmetrics.AllowedExclusions.WithLabelValues(cluster.Namespace, cluster.Name, string(fdbv1beta2.ProcessClassStorage)))).To(BeNumerically(">", 0))
+			})
+		})
+	})
+
+	When("a zone is under maintenance", func() {
+		var adminClient *mock.AdminClient
+		var processGroup *fdbv1beta2.ProcessGroupStatus
+		var result *requeue
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			var err error
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			processGroup = fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1")
+			Expect(processGroup).NotTo(BeNil())
+			processGroup.MarkForRemoval()
+		})
+
+		JustBeforeEach(func() {
+			result = excludeProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+		})
+
+		When("the storage process group is in a different zone", func() {
+			BeforeEach(func() {
+				adminClient.MaintenanceZone = "maintenance-zone"
+			})
+
+			It("should not exclude the process", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.delayedRequeue).To(BeTrue())
+				Expect(result.curError).To(MatchError(ContainSubstring("zone maintenance-zone is under maintenance")))
+				Expect(adminClient.ExcludedAddresses).To(BeEmpty())
+			})
+		})
+
+		When("the storage process group is in the maintenance zone", func() {
+			BeforeEach(func() {
+				adminClient.MaintenanceZone = processGroup.FaultDomain
+			})
+
+			It("should exclude the process", func() {
+				Expect(result).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).NotTo(BeEmpty())
+			})
+		})
+	})
+
+	When("a coordinator is excluded", func() {
+		var adminClient *mock.AdminClient
+		var reconciler *FoundationDBClusterReconciler
+		var fakeClock *testingclock.FakeClock
+		var result *requeue
+		var coordinatorIP string
+		var initialConnectionString string
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			var err error
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			status, err := adminClient.GetStatus()
+			Expect(err).NotTo(HaveOccurred())
+
+			var coordinatorID fdbv1beta2.ProcessGroupID
+			for _, process := range status.Cluster.Processes {
+				for _, role := range process.Roles {
+					if role.Role != string(fdbv1beta2.ProcessRoleCoordinator) {
+						continue
+					}
+
+					coordinatorID = fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+					coordinatorIP = process.Address.MachineAddress()
+					break
+				}
+
+				if coordinatorID != "" {
+					break
+				}
+			}
+			Expect(coordinatorID).NotTo(BeEmpty())
+
+			processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, coordinatorID)
+			Expect(processGroup).NotTo(BeNil())
+			processGroup.MarkForRemoval()
+
+			initialConnectionString = cluster.Status.ConnectionString
+			fakeClock = testingclock.NewFakeClock(time.Now())
+			reconciler = createTestClusterReconciler()
+			reconciler.Clock = fakeClock
+		})
+
+		JustBeforeEach(func() {
+			result = excludeProcesses{}.reconcile(context.TODO(), reconciler, cluster, nil, globalControllerLogger)
+		})
+
+		When("no coordinator change delay is configured", func() {
+			It("should exclude the coordinator without changing the coordinators", func() {
+				Expect(result).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(coordinatorIP))
+				Expect(cluster.Status.ConnectionString).To(Equal(initialConnectionString))
+			})
+		})
+
+		When("the coordinator change delay is 0", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.CoordinatorChangeDelayAfterExclusionSeconds = pointer.Int(0)
+			})
+
+			It("should change the coordinators directly after the exclusion", func() {
+				Expect(result).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(coordinatorIP))
+				Expect(cluster.Status.ConnectionString).NotTo(Equal(initialConnectionString))
+				Expect(cluster.Status.ConnectionString).NotTo(ContainSubstring(coordinatorIP + ":"))
+			})
+		})
+
+		When("a coordinator change delay is configured", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.CoordinatorChangeDelayAfterExclusionSeconds = pointer.Int(30)
+			})
+
+			It("should requeue with the delay instead of changing the coordinators", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.delayedRequeue).To(BeTrue())
+				Expect(result.delay).To(Equal(30 * time.Second))
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(coordinatorIP))
+				Expect(cluster.Status.ConnectionString).To(Equal(initialConnectionString))
+			})
+
+			When("the coordinators are checked before the delay has passed", func() {
+				var coordinatorResult *requeue
+
+				JustBeforeEach(func() {
+					fakeClock.Step(10 * time.Second)
+					coordinatorResult = changeCoordinators{}.reconcile(context.TODO(), reconciler, cluster, nil, globalControllerLogger)
+				})
+
+				It("should not change the coordinators", func() {
+					Expect(coordinatorResult).NotTo(BeNil())
+					Expect(coordinatorResult.delayedRequeue).To(BeTrue())
+					Expect(coordinatorResult.delay).To(BeNumerically(">", 0))
+					Expect(coordinatorResult.delay).To(BeNumerically("<=", 21*time.Second))
+					Expect(cluster.Status.ConnectionString).To(Equal(initialConnectionString))
+				})
+			})
+
+			When("the coordinators are checked after the delay has passed", func() {
+				var coordinatorResult *requeue
+
+				JustBeforeEach(func() {
+					fakeClock.Step(31 * time.Second)
+					coordinatorResult = changeCoordinators{}.reconcile(context.TODO(), reconciler, cluster, nil, globalControllerLogger)
+				})
+
+				It("should change the coordinators", func() {
+					Expect(coordinatorResult).To(BeNil())
+					Expect(cluster.Status.ConnectionString).NotTo(Equal(initialConnectionString))
+					Expect(cluster.Status.ConnectionString).NotTo(ContainSubstring(coordinatorIP + ":"))
+				})
+			})
+		})
+	})
+
+	When("excluding process groups without Pod and PVC", func() {
+		var adminClient *mock.AdminClient
+		var result *requeue
+		var failedProcessGroup, processGroup *fdbv1beta2.ProcessGroupStatus
+
+		getExclusionAddress := func(processGroup *fdbv1beta2.ProcessGroupStatus) string {
+			if cluster.UseLocalitiesForExclusion() {
+				return processGroup.GetExclusionString()
+			}
+
+			return processGroup.Addresses[0]
+		}
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			var err error
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, current := range cluster.Status.ProcessGroups {
+				if failedProcessGroup == nil && current.ProcessClass == fdbv1beta2.ProcessClassStorage {
+					failedProcessGroup = current
+				}
+
+				if processGroup == nil && current.ProcessClass == fdbv1beta2.ProcessClassLog {
+					processGroup = current
+				}
+			}
+
+			failedProcessGroup.MarkForRemoval()
+			processGroup.MarkForRemoval()
+
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			Expect(k8sClient.List(context.TODO(), pvcs, internal.GetSinglePodListOptions(cluster, failedProcessGroup.ProcessGroupID)...)).NotTo(HaveOccurred())
+			Expect(pvcs.Items).NotTo(BeEmpty())
+			for _, pvc := range pvcs.Items {
+				Expect(k8sClient.Delete(context.TODO(), &pvc)).NotTo(HaveOccurred())
+			}
+
+			failedProcessGroup.ProcessGroupConditions = append(failedProcessGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+				ProcessGroupConditionType: fdbv1beta2.MissingPod,
+				Timestamp:                 time.Now().Add(-1 * time.Hour).Unix(),
+			})
+		})
+
+		AfterEach(func() {
+			failedProcessGroup = nil
+			processGroup = nil
+		})
+
+		JustBeforeEach(func() {
+			result = excludeProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+		})
+
+		When("failed exclusions are disabled", func() {
+			It("should exclude all processes without the failed flag", func() {
+				Expect(result).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(failedProcessGroup)))
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(processGroup)))
+				Expect(adminClient.FailedExcludedAddresses).To(BeEmpty())
+			})
+		})
+
+		When("failed exclusions are enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ExcludeFailedProcessesAfterSeconds = pointer.Int(600)
+			})
+
+			It("should only exclude the process group without Pod and PVC with the failed flag", func() {
+				Expect(result).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(failedProcessGroup)))
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(processGroup)))
+				Expect(adminClient.FailedExcludedAddresses).To(HaveLen(1))
+				Expect(adminClient.FailedExcludedAddresses).To(HaveKey(getExclusionAddress(failedProcessGroup)))
+			})
+
+			When("the Pod is missing for less than the configured duration", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.ExcludeFailedProcessesAfterSeconds = pointer.Int(7200)
+				})
+
+				It("should exclude all processes without the failed flag", func() {
+					Expect(result).To(BeNil())
+					Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(failedProcessGroup)))
+					Expect(adminClient.FailedExcludedAddresses).To(BeEmpty())
+				})
+			})
+
+			When("the PVC still exists", func() {
+				BeforeEach(func() {
+					pvc, err := internal.GetPvc(cluster, failedProcessGroup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(k8sClient.Create(context.TODO(), pvc)).NotTo(HaveOccurred())
+				})
+
+				It("should exclude all processes without the failed flag", func() {
+					Expect(result).To(BeNil())
+					Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(failedProcessGroup)))
+					Expect(adminClient.FailedExcludedAddresses).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	When("the number of concurrent exclusions is limited", func() {
+		var adminClient *mock.AdminClient
+		var result *requeue
+		var processGroups []*fdbv1beta2.ProcessGroupStatus
+
+		getExclusionAddress := func(processGroup *fdbv1beta2.ProcessGroupStatus) string {
+			if cluster.UseLocalitiesForExclusion() {
+				return processGroup.GetExclusionString()
+			}
+
+			return processGroup.Addresses[0]
+		}
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			var err error
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			processGroups = nil
+			for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-1", "log-1", "stateless-1"} {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID)
+				Expect(processGroup).NotTo(BeNil())
+				processGroup.MarkForRemoval()
+				processGroups = append(processGroups, processGroup)
+			}
+		})
+
+		JustBeforeEach(func() {
+			result = excludeProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+		})
+
+		When("no limit is configured", func() {
+			It("should exclude all process groups", func() {
+				Expect(result).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(HaveLen(3))
+				for _, processGroup := range processGroups {
+					Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(processGroup)))
+				}
+			})
+
+			It("should track the start of the exclusions", func() {
+				storedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), storedCluster)).NotTo(HaveOccurred())
+
+				for _, processGroup := range processGroups {
+					Expect(processGroup.ExclusionStartedTimestamp.IsZero()).To(BeFalse())
+					storedProcessGroup := fdbv1beta2.FindProcessGroupByID(storedCluster.Status.ProcessGroups, processGroup.ProcessGroupID)
+					Expect(storedProcessGroup).NotTo(BeNil())
+					Expect(storedProcessGroup.ExclusionStartedTimestamp.IsZero()).To(BeFalse())
+				}
+			})
+		})
+
+		When("the limit is lower than the process groups to exclude", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentExclusions = pointer.Int(1)
+			})
+
+			It("should only exclude one process group", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.delayedRequeue).To(BeTrue())
+				Expect(result.message).To(Equal("Additional processes must be excluded"))
+				Expect(adminClient.ExcludedAddresses).To(HaveLen(1))
+
+				var startedExclusions int
+				for _, processGroup := range processGroups {
+					if !processGroup.ExclusionStartedTimestamp.IsZero() {
+						startedExclusions++
+					}
+				}
+				Expect(startedExclusions).To(Equal(1))
+			})
+
+			When("one of the process groups has no Pod", func() {
+				BeforeEach(func() {
+					processGroups[2].ProcessGroupConditions = append(processGroups[2].ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+						ProcessGroupConditionType: fdbv1beta2.MissingPod,
+						Timestamp:                 time.Now().Unix(),
+					})
+				})
+
+				It("should prefer the process group without Pod", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(result.message).To(Equal("Additional processes must be excluded"))
+					Expect(adminClient.ExcludedAddresses).To(HaveLen(1))
+					Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(processGroups[2])))
+				})
+			})
+
+			When("another process group has an ongoing exclusion", func() {
+				var ongoingExclusion *fdbv1beta2.ProcessGroupStatus
+
+				BeforeEach(func() {
+					ongoingExclusion = fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+					Expect(ongoingExclusion).NotTo(BeNil())
+					ongoingExclusion.MarkForRemoval()
+
+					address := fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(ongoingExclusion.Addresses[0])}
+					if cluster.UseLocalitiesForExclusion() {
+						address = fdbv1beta2.ProcessAddress{StringAddress: ongoingExclusion.GetExclusionString()}
+					}
+					Expect(adminClient.ExcludeProcesses([]fdbv1beta2.ProcessAddress{address})).To(Succeed())
+				})
+
+				It("should not exclude additional process groups", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(result.delayedRequeue).To(BeTrue())
+					Expect(result.message).To(Equal("more exclusions needed but the maximum number of concurrent exclusions is reached, have to wait for ongoing exclusions to finish"))
+					Expect(adminClient.ExcludedAddresses).To(HaveLen(1))
+					Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(ongoingExclusion)))
+				})
+			})
+		})
+
+		When("the fault tolerance based limit is stricter than the configured limit", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentExclusions = pointer.Int(5)
+				for _, processGroupID := range []fdbv1beta2.ProcessGroupID{"storage-2", "storage-3"} {
+					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID)
+					Expect(processGroup).NotTo(BeNil())
+					processGroup.MarkForRemoval()
+				}
+			})
+
+			It("should only exclude one process group per process class", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.delayedRequeue).To(BeTrue())
+				Expect(result.message).To(Equal("Additional processes must be excluded"))
+				Expect(adminClient.ExcludedAddresses).To(HaveLen(3))
+				for _, processGroup := range processGroups {
+					Expect(adminClient.ExcludedAddresses).To(HaveKey(getExclusionAddress(processGroup)))
+				}
+			})
+		})
+
+		When("the configured limit is stricter than the fault tolerance based limit", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentExclusions = pointer.Int(2)
+			})
+
+			It("should only exclude two process groups", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.message).To(Equal("Additional processes must be excluded"))
+				Expect(adminClient.ExcludedAddresses).To(HaveLen(2))
+			})
+
+			When("another process group has an ongoing exclusion", func() {
+				BeforeEach(func() {
+					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+					Expect(processGroup).NotTo(BeNil())
+					processGroup.MarkForRemoval()
+
+					address := fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(processGroup.Addresses[0])}
+					if cluster.UseLocalitiesForExclusion() {
+						address = fdbv1beta2.ProcessAddress{StringAddress: processGroup.GetExclusionString()}
+					}
+					Expect(adminClient.ExcludeProcesses([]fdbv1beta2.ProcessAddress{address})).To(Succeed())
+				})
+
+				It("should only exclude one additional process group", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(result.message).To(Equal("Additional processes must be excluded"))
+					Expect(adminClient.ExcludedAddresses).To(HaveLen(2))
+				})
+			})
+		})
+	})
+
+	DescribeTable("limiting the exclusions to process groups", func(addresses []fdbv1beta2.ProcessAddress, maxExclusions int, expected []fdbv1beta2.ProcessAddress, expectedLimited bool) {
+		processGroups := map[string]*fdbv1beta2.ProcessGroupStatus{
+			"192.168.0.1": {ProcessGroupID: "storage-1"},
+			"192.168.0.2": {ProcessGroupID: "storage-1"},
+			"192.168.0.3": {ProcessGroupID: "storage-2"},
+		}
+
+		result, limited := limitExclusionsToProcessGroups(addresses, processGroups, maxExclusions)
+		Expect(result).To(Equal(expected))
+		Expect(limited).To(Equal(expectedLimited))
+	},
+		Entry("no addresses",
+			[]fdbv1beta2.ProcessAddress{},
+			1,
+			[]fdbv1beta2.ProcessAddress{},
+			false,
+		),
+		Entry("multiple addresses of the same process group",
+			[]fdbv1beta2.ProcessAddress{{IPAddress: net.ParseIP("192.168.0.1")}, {IPAddress: net.ParseIP("192.168.0.2")}, {IPAddress: net.ParseIP("192.168.0.3")}},
+			1,
+			[]fdbv1beta2.ProcessAddress{{IPAddress: net.ParseIP("192.168.0.1")}, {IPAddress: net.ParseIP("192.168.0.2")}},
+			true,
+		),
+		Entry("the limit is not reached",
+			[]fdbv1beta2.ProcessAddress{{IPAddress: net.ParseIP("192.168.0.1")}, {IPAddress: net.ParseIP("192.168.0.2")}, {IPAddress: net.ParseIP("192.168.0.3")}},
+			2,
+			[]fdbv1beta2.ProcessAddress{{IPAddress: net.ParseIP("192.168.0.1")}, {IPAddress: net.ParseIP("192.168.0.2")}, {IPAddress: net.ParseIP("192.168.0.3")}},
+			false,
+		),
+		Entry("an address without process group",
+			[]fdbv1beta2.ProcessAddress{{IPAddress: net.ParseIP("192.168.0.4")}, {IPAddress: net.ParseIP("192.168.0.3")}},
+			1,
+			[]fdbv1beta2.ProcessAddress{{IPAddress: net.ParseIP("192.168.0.4")}},
+			true,
+		),
+	)
+
+	DescribeTable("ignoring missing processes when getting the allowed exclusions", func(ignoreMissingProcessesSeconds *int, missingDuration time.Duration, expectedAllowedExclusions int) {
+		cluster := internal.CreateDefaultCluster()
+		cluster.Spec.AutomationOptions.IgnoreMissingProcessesForExclusionSeconds = ignoreMissingProcessesSeconds
+		cluster.Status.ProcessGroups = make([]*fdbv1beta2.ProcessGroupStatus, 0, 5)
+		for i := 1; i <= 5; i++ {
+			processGroup := fdbv1beta2.NewProcessGroupStatus(fdbv1beta2.ProcessGroupID(fmt.Sprintf("storage-%d", i)), fdbv1beta2.ProcessClassStorage, nil)
+			processGroup.ProcessGroupConditions = nil
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+		}
+
+		cluster.Status.ProcessGroups[0].ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+			{
+				ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+				Timestamp:                 time.Now().Add(-missingDuration).Unix(),
+			},
+		}
+
+		// The missing process group is the one that should be excluded.
+		candidateZones := map[fdbv1beta2.FaultDomain]fdbv1beta2.None{
+			getZoneForExclusion(cluster.Status.ProcessGroups[0]): {},
+		}
+		allowedExclusions, missingProcesses := getAllowedExclusionsAndMissingProcesses(GinkgoLogr, cluster, fdbv1beta2.ProcessClassStorage, 4, 0, candidateZones, false)
+		Expect(allowedExclusions).To(Equal(expectedAllowedExclusions))
+		Expect(missingProcesses).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+	},
+		Entry("the process is missing for less than the default duration",
+			nil,
+			5*time.Minute-2*time.Second,
+			0),
+		Entry("the process is missing for longer than the default duration",
+			nil,
+			5*time.Minute+time.Second,
+			1),
+		Entry("the process is missing for less than the configured duration",
+			pointer.Int(60),
+			58*time.Second,
+			0),
+		Entry("the process is missing for longer than the configured duration",
+			pointer.Int(60),
+			61*time.Second,
+			1),
+		Entry("the process is missing for longer than the default duration but less than the configured duration",
+			pointer.Int(1800),
+			10*time.Minute,
+			0),
+		Entry("the configured duration is 0",
+			pointer.Int(0),
+			time.Duration(0),
+			1),
+	)
+
 	DescribeTable("when getting the allowed exclusions", func(validProcesses int, desiredProcessCount int, ongoingExclusions int, faultTolerance int, expected int) {
 		Expect(getAllowedExclusions(GinkgoLogr, validProcesses, desiredProcessCount, ongoingExclusions, faultTolerance)).To(BeNumerically("==", expected))
 	},
@@ -752,6 +1358,111 @@ var _ = Describe("exclude_processes", func() {
 			2,
 			0),
 	)
+
+	DescribeTable("when getting the zone aware allowed exclusions", func(allowedExclusions int, validProcesses int, desiredProcessCount int, ongoingExclusions int, faultTolerance int, shortZones int, expected int) {
+		Expect(getZoneAwareAllowedExclusions(GinkgoLogr, allowedExclusions, validProcesses, desiredProcessCount, ongoingExclusions, faultTolerance, shortZones)).To(BeNumerically("==", expected))
+	},
+		Entry("when no zone is short",
+			2,
+			10,
+			10,
+			0,
+			2,
+			0,
+			2),
+		Entry("when one zone is short",
+			2,
+			10,
+			10,
+			0,
+			2,
+			1,
+			1),
+		Entry("when as many zones are short as the fault tolerance",
+			2,
+			10,
+			10,
+			0,
+			2,
+			2,
+			0),
+		Entry("when more zones are short than the fault tolerance",
+			2,
+			10,
+			10,
+			0,
+			2,
+			3,
+			0),
+		Entry("when more valid processes are running than desired and zones are short",
+			12,
+			20,
+			10,
+			0,
+			2,
+			3,
+			10),
+		Entry("when more valid processes are running than desired, exclusions are ongoing and one zone is short",
+			2,
+			20,
+			10,
+			10,
+			2,
+			1,
+			1),
+	)
+
+	DescribeTable("getting the allowed exclusions with missing processes in different zones", func(faultDomains []fdbv1beta2.FaultDomain, candidateZones map[fdbv1beta2.FaultDomain]fdbv1beta2.None, expectedAllowedExclusions int) {
+		cluster := internal.CreateDefaultCluster()
+		cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+		cluster.Status.ProcessGroups = make([]*fdbv1beta2.ProcessGroupStatus, 0, 6)
+		for i := 1; i <= 6; i++ {
+			processGroup := fdbv1beta2.NewProcessGroupStatus(fdbv1beta2.ProcessGroupID(fmt.Sprintf("storage-%d", i)), fdbv1beta2.ProcessClassStorage, nil)
+			processGroup.ProcessGroupConditions = nil
+			processGroup.FaultDomain = fdbv1beta2.FaultDomain(fmt.Sprintf("zone-%d", i))
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+		}
+
+		// The first two process groups are missing for longer than the ignore duration.
+		for idx, faultDomain := range faultDomains {
+			cluster.Status.ProcessGroups[idx].FaultDomain = faultDomain
+			cluster.Status.ProcessGroups[idx].ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{
+				{
+					ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+					Timestamp:                 time.Now().Add(-10 * time.Minute).Unix(),
+				},
+			}
+		}
+
+		allowedExclusions, missingProcesses := getAllowedExclusionsAndMissingProcesses(GinkgoLogr, cluster, fdbv1beta2.ProcessClassStorage, 4, 0, candidateZones, false)
+		Expect(allowedExclusions).To(Equal(expectedAllowedExclusions))
+		Expect(missingProcesses).To(HaveLen(len(faultDomains)))
+	},
+		Entry("no processes are missing",
+			[]fdbv1beta2.FaultDomain{},
+			map[fdbv1beta2.FaultDomain]fdbv1beta2.None{"zone-6": {}},
+			4),
+		Entry("the missing processes are in the same zone",
+			[]fdbv1beta2.FaultDomain{"zone-1", "zone-1"},
+			map[fdbv1beta2.FaultDomain]fdbv1beta2.None{"zone-6": {}},
+			1),
+		Entry("the missing processes are in different zones",
+			[]fdbv1beta2.FaultDomain{"zone-1", "zone-2"},
+			map[fdbv1beta2.FaultDomain]fdbv1beta2.None{"zone-6": {}},
+			0),
+		Entry("the missing processes have no fault domain",
+			[]fdbv1beta2.FaultDomain{"", ""},
+			map[fdbv1beta2.FaultDomain]fdbv1beta2.None{"zone-6": {}},
+			0),
+		Entry("the missing processes are in the zones of the processes that should be excluded",
+			[]fdbv1beta2.FaultDomain{"zone-1", "zone-2"},
+			map[fdbv1beta2.FaultDomain]fdbv1beta2.None{"zone-1": {}, "zone-2": {}},
+			2),
+		Entry("one of the missing processes is in the zone of a process that should be excluded",
+			[]fdbv1beta2.FaultDomain{"zone-1", "zone-2"},
+			map[fdbv1beta2.FaultDomain]fdbv1beta2.None{"zone-1": {}},
+			1),
+	)
 })
 
 func createMissingProcesses(cluster *fdbv1beta2.FoundationDBCluster, count int, processClass fdbv1beta2.ProcessClass) {
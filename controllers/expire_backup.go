@@ -0,0 +1,82 @@
+/*
+ * expire_backup.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// expireBackup provides a reconciliation step for expiring the backup data
+// based on the retention policy.
+type expireBackup struct{}
+
+// reconcile runs the reconciler's work.
+func (s expireBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	if backup.Status.BackupDetails == nil || backup.Status.BackupDetails.URL == "" {
+		return nil
+	}
+
+	now := time.Now()
+	if !backup.NeedsExpiration(now) {
+		return nil
+	}
+
+	adminClient, err := r.adminClientForBackup(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	blobCredentialsPath, cleanup, err := writeBlobCredentials(ctx, r, backup.Namespace, backup.Spec.BlobStoreConfiguration)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer cleanup()
+
+	expireBefore, restorableAfter := backup.GetExpirationTimestamps(now)
+	output, err := adminClient.ExpireBackup(backup.BackupURL(), expireBefore, restorableAfter, blobCredentialsPath)
+
+	// The expiration is recorded even if it failed, to make sure we only try to expire the backup data once per
+	// expiration interval.
+	expiration := &fdbv1beta2.BackupExpirationStatus{
+		Timestamp:    metav1.NewTime(now),
+		ExpireBefore: metav1.NewTime(expireBefore),
+		Output:       output,
+	}
+
+	if err != nil {
+		expiration.Error = err.Error()
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "ExpireBackup", err.Error())
+	}
+
+	backup.Status.LastExpiration = expiration
+	err = r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
@@ -33,7 +33,9 @@ import (
 type maintenanceModeChecker struct{}
 
 // reconcile runs the reconciler's work.
-func (maintenanceModeChecker) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (maintenanceModeChecker) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	if !cluster.ResetMaintenanceMode() {
 		return nil
 	}
@@ -49,6 +51,8 @@ func (maintenanceModeChecker) reconcile(_ context.Context, r *FoundationDBCluste
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		cachedStatus.record(status)
 	}
 
 	// If the cluster is not available we skip any further checks.
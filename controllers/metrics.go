@@ -25,6 +25,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
@@ -100,6 +101,20 @@ var (
 		append(descClusterDefaultLabels, "process_class"),
 		nil,
 	)
+
+	descReconcileLoops = prometheus.NewDesc(
+		"fdb_operator_cluster_reconcile_loops",
+		"the number of reconciliation loops of the Fdb Cluster in the current window.",
+		append(descClusterDefaultLabels, "window"),
+		nil,
+	)
+
+	descBackupLag = prometheus.NewDesc(
+		"fdb_operator_backup_lag_seconds",
+		"the time in seconds since the latest restorable point of the backup.",
+		descClusterDefaultLabels,
+		nil,
+	)
 )
 
 type fdbClusterCollector struct {
@@ -124,6 +139,12 @@ func (c *fdbClusterCollector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 	for _, cluster := range clusters.Items {
+		// The loops counted in memory are preferred, as the status might not contain the latest count if the
+		// status update failed.
+		if loops := c.reconciler.reconcileLoops.get(client.ObjectKeyFromObject(&cluster).String()); loops != nil {
+			cluster.Status.ReconcileLoops = loops
+		}
+
 		collectMetrics(ch, &cluster)
 	}
 }
@@ -147,6 +168,11 @@ func collectMetrics(ch chan<- prometheus.Metric, cluster *fdbv1beta2.FoundationD
 	addGauge(descProcessGroupsToRemove, float64(len(cluster.Spec.ProcessGroupsToRemove)))
 	addGauge(descProcessGroupsToRemoveWithoutExclusion, float64(len(cluster.Spec.ProcessGroupsToRemoveWithoutExclusion)))
 
+	if cluster.Status.ReconcileLoops != nil {
+		addGauge(descReconcileLoops, float64(cluster.Status.ReconcileLoops.Count), "current")
+		addGauge(descReconcileLoops, float64(cluster.Status.ReconcileLoops.PreviousCount), "previous")
+	}
+
 	// Calculate the process group metrics
 	conditionMap, removals, exclusions := getProcessGroupMetrics(cluster)
 
@@ -169,6 +195,39 @@ func collectMetrics(ch chan<- prometheus.Metric, cluster *fdbv1beta2.FoundationD
 	}
 }
 
+type fdbBackupCollector struct {
+	reconciler *FoundationDBBackupReconciler
+}
+
+func newFDBBackupCollector(reconciler *FoundationDBBackupReconciler) *fdbBackupCollector {
+	return &fdbBackupCollector{reconciler: reconciler}
+}
+
+// Describe implements the prometheus.Collector interface
+func (c *fdbBackupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descBackupLag
+}
+
+// Collect implements the prometheus.Collector interface
+func (c *fdbBackupCollector) Collect(ch chan<- prometheus.Metric) {
+	backups := &fdbv1beta2.FoundationDBBackupList{}
+	err := c.reconciler.List(context.Background(), backups)
+	if err != nil {
+		return
+	}
+	for _, backup := range backups.Items {
+		collectBackupMetrics(ch, &backup)
+	}
+}
+
+func collectBackupMetrics(ch chan<- prometheus.Metric, backup *fdbv1beta2.FoundationDBBackup) {
+	if backup.Status.BackupDetails == nil || backup.Status.BackupDetails.Lag == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(descBackupLag, prometheus.GaugeValue, backup.Status.BackupDetails.Lag.Seconds(), backup.Namespace, backup.Name)
+}
+
 func getProcessGroupMetrics(cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1beta2.ProcessClass]map[fdbv1beta2.ProcessGroupConditionType]int, map[fdbv1beta2.ProcessClass]int, map[fdbv1beta2.ProcessClass]int) {
 	metricMap := map[fdbv1beta2.ProcessClass]map[fdbv1beta2.ProcessGroupConditionType]int{}
 	removals := map[fdbv1beta2.ProcessClass]int{}
@@ -221,6 +280,13 @@ func InitCustomMetrics(reconciler *FoundationDBClusterReconciler) {
 	)
 }
 
+// InitCustomBackupMetrics initializes the metrics collectors for the backups.
+func InitCustomBackupMetrics(reconciler *FoundationDBBackupReconciler) {
+	metrics.Registry.MustRegister(
+		newFDBBackupCollector(reconciler),
+	)
+}
+
 func boolFloat64(b bool) float64 {
 	if b {
 		return 1
@@ -21,12 +21,21 @@
 package controllers
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	fdbmetrics "github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 var _ = Describe("metrics", func() {
@@ -75,4 +84,168 @@ var _ = Describe("metrics", func() {
 			Expect(exclusions[fdbv1beta2.ProcessClassStateless]).To(BeNumerically("==", 1))
 		})
 	})
+
+	Context("Collecting the reconcile loop metrics", func() {
+		collect := func() []prometheus.Metric {
+			ch := make(chan prometheus.Metric, 100)
+			collectMetrics(ch, cluster)
+			close(ch)
+
+			var result []prometheus.Metric
+			for metric := range ch {
+				if metric.Desc() == descReconcileLoops {
+					result = append(result, metric)
+				}
+			}
+
+			return result
+		}
+
+		When("no reconcile loops are recorded", func() {
+			It("should not report the reconcile loops", func() {
+				Expect(collect()).To(BeEmpty())
+			})
+		})
+
+		When("reconcile loops are recorded", func() {
+			BeforeEach(func() {
+				cluster.Status.ReconcileLoops = &fdbv1beta2.ReconcileLoopStatus{
+					WindowStart:   &metav1.Time{Time: time.Now()},
+					Count:         42,
+					PreviousCount: 7,
+				}
+			})
+
+			It("should report the current and the previous window", func() {
+				metrics := collect()
+				Expect(metrics).To(HaveLen(2))
+
+				values := map[string]float64{}
+				for _, current := range metrics {
+					metric := &dto.Metric{}
+					Expect(current.Write(metric)).NotTo(HaveOccurred())
+					for _, label := range metric.GetLabel() {
+						if label.GetName() == "window" {
+							values[label.GetValue()] = metric.GetGauge().GetValue()
+						}
+					}
+				}
+
+				Expect(values).To(Equal(map[string]float64{
+					"current":  42,
+					"previous": 7,
+				}))
+			})
+		})
+	})
+
+	Context("Collecting the backup metrics", func() {
+		var backup *fdbv1beta2.FoundationDBBackup
+
+		BeforeEach(func() {
+			backup = &fdbv1beta2.FoundationDBBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "backup",
+					Namespace: "test",
+				},
+			}
+		})
+
+		collect := func() []prometheus.Metric {
+			ch := make(chan prometheus.Metric, 10)
+			collectBackupMetrics(ch, backup)
+			close(ch)
+
+			var result []prometheus.Metric
+			for metric := range ch {
+				result = append(result, metric)
+			}
+
+			return result
+		}
+
+		When("the backup has no lag information", func() {
+			It("should not report the backup lag", func() {
+				Expect(collect()).To(BeEmpty())
+			})
+		})
+
+		When("the backup has lag information", func() {
+			BeforeEach(func() {
+				backup.Status.BackupDetails = &fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+					Lag: &metav1.Duration{Duration: 90 * time.Second},
+				}
+			})
+
+			It("should report the backup lag in seconds", func() {
+				metrics := collect()
+				Expect(metrics).To(HaveLen(1))
+
+				metric := &dto.Metric{}
+				Expect(metrics[0].Write(metric)).NotTo(HaveOccurred())
+				Expect(metric.GetGauge().GetValue()).To(BeNumerically("==", 90))
+				Expect(metric.GetLabel()).To(HaveLen(2))
+			})
+		})
+	})
+
+	When("running a sub-reconciler", func() {
+		var object *fdbv1beta2.FoundationDBCluster
+		reconciler := "controllers.updateStatus"
+
+		BeforeEach(func() {
+			object = &fdbv1beta2.FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "metrics-test",
+					Namespace: "metrics",
+				},
+			}
+			fdbmetrics.SubReconcilerRequeues.Reset()
+			fdbmetrics.SubReconcilerErrors.Reset()
+		})
+
+		It("should record delayed requeues with errors", func() {
+			result := runSubReconciler(updateStatus{}, object, func() *requeue {
+				return &requeue{curError: fmt.Errorf("test"), delayedRequeue: true}
+			})
+			Expect(result).NotTo(BeNil())
+			Expect(testutil.ToFloat64(fdbmetrics.SubReconcilerRequeues.WithLabelValues(reconciler, object.Namespace, object.Name, "true"))).To(BeNumerically("==", 1))
+			Expect(testutil.ToFloat64(fdbmetrics.SubReconcilerErrors.WithLabelValues(reconciler, object.Namespace, object.Name))).To(BeNumerically("==", 1))
+		})
+
+		It("should not record a requeue if the sub-reconciler finished", func() {
+			Expect(runSubReconciler(updateStatus{}, object, func() *requeue {
+				return nil
+			})).To(BeNil())
+			Expect(testutil.CollectAndCount(fdbmetrics.SubReconcilerRequeues)).To(BeZero())
+		})
+
+		It("should record immediate requeues once", func() {
+			result := runSubReconciler(updateStatus{}, object, func() *requeue {
+				return &requeue{message: "test"}
+			})
+			Expect(result).NotTo(BeNil())
+			_, err := processRequeue(result, updateStatus{}, object, record.NewFakeRecorder(10), globalControllerLogger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testutil.ToFloat64(fdbmetrics.SubReconcilerRequeues.WithLabelValues(reconciler, object.Namespace, object.Name, "false"))).To(BeNumerically("==", 1))
+			Expect(testutil.CollectAndCount(fdbmetrics.SubReconcilerErrors)).To(BeZero())
+		})
+	})
+
+	When("a cluster is deleted", func() {
+		namespace := "metrics"
+		name := "deleted-cluster"
+
+		BeforeEach(func() {
+			fdbmetrics.PendingExclusions.Reset()
+			fdbmetrics.RecordPendingExclusions(namespace, name, string(fdbv1beta2.ProcessClassStorage), 0, 1)
+
+			_, err := clusterReconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should remove the metrics of the cluster", func() {
+			Expect(testutil.CollectAndCount(fdbmetrics.PendingExclusions)).To(BeZero())
+		})
+	})
 })
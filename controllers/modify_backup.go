@@ -24,6 +24,8 @@ import (
 	"context"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 )
 
 // modifyBackup provides a reconciliation step for modifying a backup's
@@ -33,23 +35,59 @@ type modifyBackup struct {
 
 // reconcile runs the reconciler's work.
 func (s modifyBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
-	if backup.Status.BackupDetails == nil || !backup.ShouldRun() {
+	// Only a running backup can be modified, e.g. if the backup couldn't be started the backup details will be empty.
+	if backup.Status.BackupDetails == nil || !backup.Status.BackupDetails.Running || !backup.ShouldRun() {
 		return nil
 	}
 
 	snapshotPeriod := backup.SnapshotPeriodSeconds()
-	if backup.Status.BackupDetails.SnapshotPeriodSeconds != snapshotPeriod {
-		adminClient, err := r.adminClientForBackup(ctx, backup)
-		if err != nil {
-			return &requeue{curError: err}
-		}
-		defer adminClient.Close()
+	keyRangesChanged := backup.KeyRangesChanged()
+	if !keyRangesChanged && backup.Status.BackupDetails.SnapshotPeriodSeconds == snapshotPeriod {
+		return nil
+	}
+
+	// The backup is discontinued asynchronously, so we have to wait until the backup is stopped. The startBackup
+	// reconciler will start the backup with the new key ranges once the backup is stopped.
+	if backup.Status.State == fdbv1beta2.BackupStateRestarting {
+		return &requeue{message: "waiting for the backup to stop before restarting it with the new key ranges", delayedRequeue: true}
+	}
+
+	adminClient, err := r.adminClientForBackup(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	// The key ranges of a running backup cannot be modified, so the backup has to be discontinued and started again
+	// with the new key ranges.
+	if keyRangesChanged {
+		return discontinueBackupForRestart(ctx, r, adminClient, backup)
+	}
 
-		err = adminClient.ModifyBackup(snapshotPeriod)
-		if err != nil {
-			return &requeue{curError: err}
-		}
+	err = adminClient.ModifyBackupWithTag(backup.BackupTag(), snapshotPeriod)
+	if err != nil {
+		metrics.RecordBackupOperationFailure(backup.Namespace, backup.Name, "modify")
+		return &requeue{curError: err}
 	}
 
 	return nil
 }
+
+// discontinueBackupForRestart discontinues the running backup and records the restarting state, so the backup is only
+// discontinued once. The backup will be started with the key ranges from the spec by the startBackup reconciler once
+// the backup is stopped.
+func discontinueBackupForRestart(ctx context.Context, r *FoundationDBBackupReconciler, adminClient fdbadminclient.AdminClient, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	err := adminClient.StopBackupWithTag(backup.BackupTag())
+	if err != nil {
+		metrics.RecordBackupOperationFailure(backup.Namespace, backup.Name, "stop")
+		return &requeue{curError: err}
+	}
+
+	backup.Status.State = fdbv1beta2.BackupStateRestarting
+	err = r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return &requeue{message: "waiting for the backup to stop before restarting it with the new key ranges", delayedRequeue: true}
+}
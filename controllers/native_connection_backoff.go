@@ -0,0 +1,99 @@
+/*
+ * native_connection_backoff.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// nativeConnectionRecreationBaseDelay defines the delay after the first recreation of the native connection of a
+	// cluster. The delay is doubled for every further recreation.
+	nativeConnectionRecreationBaseDelay = 10 * time.Second
+
+	// nativeConnectionRecreationMaxDelay defines the maximum delay between two recreations of the native connection of
+	// a cluster.
+	nativeConnectionRecreationMaxDelay = 5 * time.Minute
+)
+
+// nativeConnectionBackoff tracks the recreations of the native connections per cluster, to prevent that a broken
+// connection is recreated in every reconciliation loop. The zero value is ready to use.
+type nativeConnectionBackoff struct {
+	lock sync.Mutex
+	// recreations contains the recreations of the native connection per cluster key.
+	recreations map[string]*nativeConnectionRecreations
+}
+
+// nativeConnectionRecreations contains the number of consecutive recreations of a native connection and the time of
+// the last recreation.
+type nativeConnectionRecreations struct {
+	count int
+	last  time.Time
+}
+
+// next returns the number of the next recreation attempt for the provided key, if the delay since the last recreation
+// has passed. If the delay has not passed yet 0 will be returned. If an attempt is returned, it will be recorded at the
+// provided time.
+func (backoff *nativeConnectionBackoff) next(key string, now time.Time) int {
+	backoff.lock.Lock()
+	defer backoff.lock.Unlock()
+
+	if backoff.recreations == nil {
+		backoff.recreations = map[string]*nativeConnectionRecreations{}
+	}
+
+	recreations, ok := backoff.recreations[key]
+	if !ok {
+		recreations = &nativeConnectionRecreations{}
+		backoff.recreations[key] = recreations
+	}
+
+	if recreations.count > 0 && now.Sub(recreations.last) < getNativeConnectionRecreationDelay(recreations.count) {
+		return 0
+	}
+
+	recreations.count++
+	recreations.last = now
+
+	return recreations.count
+}
+
+// reset removes the recreations of the provided key, e.g. once the native connection was recovered.
+func (backoff *nativeConnectionBackoff) reset(key string) {
+	backoff.lock.Lock()
+	defer backoff.lock.Unlock()
+
+	delete(backoff.recreations, key)
+}
+
+// getNativeConnectionRecreationDelay returns the delay after the provided number of consecutive recreations.
+func getNativeConnectionRecreationDelay(count int) time.Duration {
+	delay := nativeConnectionRecreationBaseDelay
+	for i := 1; i < count; i++ {
+		delay *= 2
+		if delay >= nativeConnectionRecreationMaxDelay {
+			return nativeConnectionRecreationMaxDelay
+		}
+	}
+
+	return delay
+}
@@ -0,0 +1,69 @@
+/*
+ * native_connection_backoff_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("native_connection_backoff", func() {
+	var backoff *nativeConnectionBackoff
+	now := time.Unix(1000, 0)
+
+	BeforeEach(func() {
+		backoff = &nativeConnectionBackoff{}
+	})
+
+	It("should allow the first recreation", func() {
+		Expect(backoff.next("test", now)).To(Equal(1))
+	})
+
+	It("should wait before the next recreation", func() {
+		Expect(backoff.next("test", now)).To(Equal(1))
+		Expect(backoff.next("test", now.Add(nativeConnectionRecreationBaseDelay-time.Second))).To(BeZero())
+		Expect(backoff.next("test", now.Add(nativeConnectionRecreationBaseDelay))).To(Equal(2))
+		Expect(backoff.next("test", now.Add(2*nativeConnectionRecreationBaseDelay))).To(BeZero())
+		Expect(backoff.next("test", now.Add(3*nativeConnectionRecreationBaseDelay))).To(Equal(3))
+	})
+
+	It("should track the keys independently", func() {
+		Expect(backoff.next("test", now)).To(Equal(1))
+		Expect(backoff.next("other", now)).To(Equal(1))
+	})
+
+	It("should start again after a reset", func() {
+		Expect(backoff.next("test", now)).To(Equal(1))
+		backoff.reset("test")
+		Expect(backoff.next("test", now)).To(Equal(1))
+	})
+
+	DescribeTable("getting the delay", func(count int, expected time.Duration) {
+		Expect(getNativeConnectionRecreationDelay(count)).To(Equal(expected))
+	},
+		Entry("after the first recreation", 1, 10*time.Second),
+		Entry("after the second recreation", 2, 20*time.Second),
+		Entry("after the third recreation", 3, 40*time.Second),
+		Entry("after many recreations", 20, nativeConnectionRecreationMaxDelay),
+	)
+})
@@ -0,0 +1,77 @@
+/*
+ * reconcile_loop_counter.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+// reconcileLoopCounter counts the reconciliation loops per cluster in memory. The zero value is ready to use.
+type reconcileLoopCounter struct {
+	lock sync.Mutex
+	// loops contains the reconciliation loops per cluster key.
+	loops map[string]*fdbv1beta2.ReconcileLoopStatus
+}
+
+// record increments the reconciliation loops of the provided key and returns a copy of the updated loops. If no loops
+// were recorded for the key, e.g. after a restart of the operator, the counting will be continued from the provided
+// persisted loops.
+func (counter *reconcileLoopCounter) record(key string, persisted *fdbv1beta2.ReconcileLoopStatus, now time.Time) *fdbv1beta2.ReconcileLoopStatus {
+	counter.lock.Lock()
+	defer counter.lock.Unlock()
+
+	if counter.loops == nil {
+		counter.loops = map[string]*fdbv1beta2.ReconcileLoopStatus{}
+	}
+
+	loops, ok := counter.loops[key]
+	if !ok {
+		loops = &fdbv1beta2.ReconcileLoopStatus{}
+		if persisted != nil {
+			loops = persisted.DeepCopy()
+		}
+
+		counter.loops[key] = loops
+	}
+
+	recordReconcileLoop(loops, now)
+
+	return loops.DeepCopy()
+}
+
+// get returns a copy of the reconciliation loops of the provided key or nil if no loops were recorded.
+func (counter *reconcileLoopCounter) get(key string) *fdbv1beta2.ReconcileLoopStatus {
+	counter.lock.Lock()
+	defer counter.lock.Unlock()
+
+	return counter.loops[key].DeepCopy()
+}
+
+// forget removes the reconciliation loops of the provided key, e.g. after the cluster was deleted.
+func (counter *reconcileLoopCounter) forget(key string) {
+	counter.lock.Lock()
+	defer counter.lock.Unlock()
+
+	delete(counter.loops, key)
+}
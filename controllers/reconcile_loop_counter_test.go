@@ -0,0 +1,66 @@
+/*
+ * reconcile_loop_counter_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("reconcile_loop_counter", func() {
+	var counter *reconcileLoopCounter
+	now := time.Unix(1000, 0)
+
+	BeforeEach(func() {
+		counter = &reconcileLoopCounter{}
+	})
+
+	It("should count the loops in memory", func() {
+		counter.record("test", nil, now)
+		loops := counter.record("test", nil, now.Add(time.Second))
+		Expect(loops.Count).To(Equal(2))
+		Expect(loops.WindowStart.Equal(&metav1.Time{Time: now})).To(BeTrue())
+		Expect(counter.get("test")).To(Equal(loops))
+	})
+
+	It("should continue counting from the persisted loops", func() {
+		persisted := &fdbv1beta2.ReconcileLoopStatus{
+			WindowStart:   &metav1.Time{Time: now},
+			Count:         5,
+			PreviousCount: 2,
+		}
+
+		loops := counter.record("test", persisted, now.Add(time.Second))
+		Expect(loops.Count).To(Equal(6))
+		Expect(loops.PreviousCount).To(Equal(2))
+		Expect(persisted.Count).To(Equal(5))
+	})
+
+	It("should forget the loops", func() {
+		counter.record("test", nil, now)
+		counter.forget("test")
+		Expect(counter.get("test")).To(BeNil())
+	})
+})
@@ -0,0 +1,148 @@
+/*
+ * reconcile_trace.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	// reconcileDecisionCompleted is recorded when a sub-reconciler finished without requesting a requeue.
+	reconcileDecisionCompleted = "Completed"
+	// reconcileDecisionDelayedRequeue is recorded when a sub-reconciler requested a delayed requeue.
+	reconcileDecisionDelayedRequeue = "DelayedRequeue"
+	// reconcileDecisionRequeue is recorded when a sub-reconciler requested a requeue and stopped the reconciliation loop.
+	reconcileDecisionRequeue = "Requeue"
+)
+
+// reconcileTrace contains the decisions of all sub-reconcilers that ran during a single reconciliation loop.
+type reconcileTrace struct {
+	// TraceID is the ID of the reconciliation loop.
+	TraceID string `json:"traceID"`
+	// Generation is the generation of the cluster when the reconciliation loop started.
+	Generation int64 `json:"generation"`
+	// Decisions contains the decisions of the sub-reconcilers in the order they ran.
+	Decisions []reconcileDecision `json:"decisions"`
+	// Result describes the outcome of the reconciliation loop.
+	Result string `json:"result,omitempty"`
+}
+
+// reconcileDecision contains the decision of a single sub-reconciler.
+type reconcileDecision struct {
+	// Reconciler is the name of the sub-reconciler.
+	Reconciler string `json:"reconciler"`
+	// Decision is the outcome of the sub-reconciler.
+	Decision string `json:"decision"`
+	// Message contains the reason for a requeue.
+	Message string `json:"message,omitempty"`
+	// Error contains the error that caused a requeue.
+	Error string `json:"error,omitempty"`
+	// Delay contains the delay that was requested for the requeue.
+	Delay string `json:"delay,omitempty"`
+}
+
+// newReconcileTrace returns a new reconcileTrace if the reconcile trace is enabled for the cluster, otherwise nil will
+// be returned. The trace ID is taken from the reconcile ID in the context. If no reconcile ID is present a new ID will
+// be generated.
+func newReconcileTrace(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster) *reconcileTrace {
+	if cluster.GetReconcileTraceMode() == fdbv1beta2.ReconcileTraceModeDisabled {
+		return nil
+	}
+
+	traceID := string(controller.ReconcileIDFromContext(ctx))
+	if traceID == "" {
+		traceID = string(uuid.NewUUID())
+	}
+
+	return &reconcileTrace{
+		TraceID:    traceID,
+		Generation: cluster.ObjectMeta.Generation,
+	}
+}
+
+// record adds the decision of the provided sub-reconciler to the trace.
+func (trace *reconcileTrace) record(subReconciler clusterSubReconciler, requeue *requeue) {
+	if trace == nil {
+		return
+	}
+
+	decision := reconcileDecision{
+		Reconciler: fmt.Sprintf("%T", subReconciler),
+		Decision:   reconcileDecisionCompleted,
+	}
+
+	if requeue != nil {
+		decision.Decision = reconcileDecisionRequeue
+		if requeue.delayedRequeue {
+			decision.Decision = reconcileDecisionDelayedRequeue
+		}
+
+		decision.Message = requeue.message
+		if requeue.curError != nil {
+			decision.Error = requeue.curError.Error()
+		}
+
+		if requeue.delay > 0 {
+			decision.Delay = requeue.delay.String()
+		}
+	}
+
+	trace.Decisions = append(trace.Decisions, decision)
+}
+
+// finish sets the result of the reconciliation loop and logs the trace. If the cluster uses the Annotation trace mode,
+// the trace will be stored as annotation on the cluster. Errors are only logged as the trace must not affect the
+// reconciliation.
+func (trace *reconcileTrace) finish(ctx context.Context, r *FoundationDBClusterReconciler, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, result string) {
+	if trace == nil {
+		return
+	}
+
+	trace.Result = result
+	output, err := json.Marshal(trace)
+	if err != nil {
+		logger.Error(err, "Could not marshal the reconcile trace")
+		return
+	}
+
+	logger.Info("Reconcile decision trace", "traceID", trace.TraceID, "trace", string(output))
+	if cluster.GetReconcileTraceMode() != fdbv1beta2.ReconcileTraceModeAnnotation {
+		return
+	}
+
+	// Only patch the annotation to make sure the normalized spec is not written back.
+	annotatedCluster := cluster.DeepCopy()
+	patch := client.MergeFrom(annotatedCluster.DeepCopy())
+	metav1.SetMetaDataAnnotation(&annotatedCluster.ObjectMeta, fdbv1beta2.LastReconcileTraceKey, string(output))
+	err = r.Patch(ctx, annotatedCluster, patch)
+	if err != nil {
+		logger.Error(err, "Could not store the reconcile trace in the cluster annotations")
+	}
+}
@@ -36,8 +36,8 @@ import (
 type removeIncompatibleProcesses struct{}
 
 // reconcile runs the reconciler's work.
-func (removeIncompatibleProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
-	err := processIncompatibleProcesses(ctx, r, logger, cluster, status)
+func (removeIncompatibleProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	err := processIncompatibleProcesses(ctx, r, logger, cluster, cachedStatus)
 
 	if err != nil {
 		return &requeue{curError: err, delay: 15 * time.Second, delayedRequeue: true}
@@ -46,8 +46,8 @@ func (removeIncompatibleProcesses) reconcile(ctx context.Context, r *FoundationD
 	return nil
 }
 
-func processIncompatibleProcesses(ctx context.Context, r *FoundationDBClusterReconciler, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) error {
-	if !r.EnableRestartIncompatibleProcesses {
+func processIncompatibleProcesses(ctx context.Context, r *FoundationDBClusterReconciler, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus) error {
+	if !cluster.ShouldRestartIncompatibleProcesses(r.EnableRestartIncompatibleProcesses) {
 		logger.Info("skipping disabled subreconciler")
 		return nil
 	}
@@ -63,6 +63,7 @@ func processIncompatibleProcesses(ctx context.Context, r *FoundationDBClusterRec
 	}
 
 	// If the status is not cached, we have to fetch it.
+	status := cachedStatus.get()
 	if status == nil {
 		adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r.Client)
 		if err != nil {
@@ -74,6 +75,8 @@ func processIncompatibleProcesses(ctx context.Context, r *FoundationDBClusterRec
 		if err != nil {
 			return err
 		}
+
+		cachedStatus.record(status)
 	}
 
 	if len(status.Cluster.IncompatibleConnections) == 0 {
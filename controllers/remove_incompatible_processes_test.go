@@ -28,6 +28,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
@@ -298,6 +299,51 @@ var _ = Describe("restart_incompatible_pods", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(pods.Items)).To(BeNumerically("==", initialCount))
 			})
+
+			When("the cluster enables the restart of incompatible processes", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.RestartIncompatibleProcesses = pointer.Bool(true)
+				})
+
+				It("should have one deletion", func() {
+					pods := &corev1.PodList{}
+					err := k8sClient.List(context.TODO(), pods, getListOptions(cluster)...)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(len(pods.Items)).To(BeNumerically("==", initialCount-1))
+				})
+			})
+		})
+
+		When("matching incompatible processes are reported and the cluster disables the subreconciler", func() {
+			BeforeEach(func() {
+				clusterReconciler.EnableRestartIncompatibleProcesses = true
+				cluster.Spec.AutomationOptions.RestartIncompatibleProcesses = pointer.Bool(false)
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				adminClient.FrozenStatus = &fdbv1beta2.FoundationDBStatus{
+					Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+						DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+							Available: true,
+						},
+					},
+					Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+						FaultTolerance: fdbv1beta2.FaultTolerance{
+							MaxZoneFailuresWithoutLosingAvailability: 2,
+							MaxZoneFailuresWithoutLosingData:         2,
+						},
+						IncompatibleConnections: []string{
+							cluster.Status.ProcessGroups[0].Addresses[0] + ":4500:tls",
+						},
+					},
+				}
+			})
+
+			It("should have no deletions", func() {
+				pods := &corev1.PodList{}
+				err := k8sClient.List(context.TODO(), pods, getListOptions(cluster)...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(pods.Items)).To(BeNumerically("==", initialCount))
+			})
 		})
 	})
 })
@@ -25,9 +25,11 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
@@ -38,6 +40,7 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
@@ -48,7 +51,9 @@ import (
 type removeProcessGroups struct{}
 
 // reconcile runs the reconciler's work.
-func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	adminClient, err := r.DatabaseClientProvider.GetAdminClient(cluster, r)
 	if err != nil {
 		return &requeue{curError: err}
@@ -61,15 +66,18 @@ func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClust
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		cachedStatus.record(status)
 	}
 
-	remainingMap, err := removals.GetRemainingMap(logger, adminClient, cluster, status, r.MinimumRecoveryTimeForExclusion)
+	remainingMap, err := removals.GetRemainingMap(logger, adminClient, cluster, status, cluster.GetMinimumRecoveryTimeForExclusion(r.MinimumRecoveryTimeForExclusion))
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
 	coordinators := fdbstatus.GetCoordinatorsFromStatus(status)
-	allExcluded, newExclusions, processGroupsToRemove := r.getProcessGroupsToRemove(logger, cluster, remainingMap, coordinators)
+	activeProcessGroups := fdbstatus.GetProcessGroupsWithActiveRoles(status)
+	allExcluded, newExclusions, processGroupsToRemove := r.getProcessGroupsToRemove(logger, cluster, remainingMap, coordinators, activeProcessGroups)
 	// If no process groups are marked to remove we have to check if all process groups are excluded.
 	if len(processGroupsToRemove) == 0 {
 		if !allExcluded {
@@ -132,22 +140,28 @@ func (u removeProcessGroups) reconcile(ctx context.Context, r *FoundationDBClust
 	logger.Info("Removing process groups", "zone", zone, "count", len(zoneRemovals), "deletionMode", cluster.GetRemovalMode())
 
 	// This will return a map of the newly removed ProcessGroups and the ProcessGroups with the ResourcesTerminating condition
-	removedProcessGroups := r.removeProcessGroups(ctx, logger, cluster, zoneRemovals, zonedRemovals[removals.TerminatingZone])
+	removedProcessGroups, remainingRetention := r.removeProcessGroups(ctx, logger, cluster, zoneRemovals, zonedRemovals[removals.TerminatingZone])
 	err = includeProcessGroup(ctx, logger, r, cluster, removedProcessGroups, status)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
+	if remainingRetention > 0 {
+		return &requeue{message: fmt.Sprintf("waiting for the PVC retention of removed process groups: %v", remainingRetention), delay: remainingRetention, delayedRequeue: true}
+	}
+
 	return nil
 }
 
-func removeProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) error {
+// removeProcessGroup deletes the resources of the provided process group. If the PVCs of the process group must be
+// retained, the remaining retention time will be returned.
+func removeProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) (time.Duration, error) {
 	podName := processGroup.GetPodName(cluster)
 	var deletionError error
 
-	pod, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, podName)
+	pod, err := podmanager.GetPodForProcessGroup(ctx, r.PodLifecycleManager, r, cluster, processGroup)
 	if err != nil && !k8serrors.IsNotFound(err) {
-		return err
+		return 0, err
 	}
 
 	if err == nil && pod.DeletionTimestamp.IsZero() {
@@ -161,22 +175,49 @@ func removeProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, c
 	pvcs := &corev1.PersistentVolumeClaimList{}
 	err = r.List(ctx, pvcs, internal.GetSinglePodListOptions(cluster, processGroup.ProcessGroupID)...)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if countDataPVCs(pvcs) > 1 {
+		return 0, fmt.Errorf("multiple PVCs found for cluster %s, processGroupID %s", cluster.Name, processGroup.ProcessGroupID)
 	}
-	if len(pvcs.Items) == 1 && pvcs.Items[0].DeletionTimestamp.IsZero() {
-		logr.FromContextOrDiscard(ctx).Info("Deleting pvc", "name", pvcs.Items[0].Name)
-		err = r.Delete(ctx, &pvcs.Items[0])
+
+	retentionDuration := cluster.GetPVCRetentionDuration()
+	var remainingRetention time.Duration
+	// The data PVC and the PVC for the trace logs, if present, will be deleted together.
+	for idx := range pvcs.Items {
+		if !pvcs.Items[idx].DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		// The retention starts when the Pod of the process group is deleted. The PVC will be retained until the
+		// retention duration has passed, to allow the data to be recovered, e.g. after an accidental shrink.
+		if retentionDuration > 0 {
+			remaining, err := getRemainingPVCRetention(ctx, r, &pvcs.Items[idx], retentionDuration)
+			if err != nil {
+				deletionError = errors.Join(deletionError, fmt.Errorf("could not check PVC retention: %w", err))
+				continue
+			}
+
+			if remaining > 0 {
+				logr.FromContextOrDiscard(ctx).V(1).Info("Retaining pvc", "name", pvcs.Items[idx].Name, "remaining", remaining)
+				if remainingRetention == 0 || remaining < remainingRetention {
+					remainingRetention = remaining
+				}
+				continue
+			}
+		}
+
+		logr.FromContextOrDiscard(ctx).Info("Deleting pvc", "name", pvcs.Items[idx].Name)
+		err = r.Delete(ctx, &pvcs.Items[idx])
 		if err != nil {
 			deletionError = errors.Join(deletionError, fmt.Errorf("could not delete PVC: %w", err))
 		}
-	} else if len(pvcs.Items) > 1 {
-		return fmt.Errorf("multiple PVCs found for cluster %s, processGroupID %s", cluster.Name, processGroup.ProcessGroupID)
 	}
 
 	service := &corev1.Service{}
 	err = r.Get(ctx, client.ObjectKey{Name: podName, Namespace: cluster.Namespace}, service)
 	if err != nil && !k8serrors.IsNotFound(err) {
-		return err
+		return remainingRetention, err
 	}
 
 	if err == nil && service.DeletionTimestamp.IsZero() {
@@ -186,14 +227,45 @@ func removeProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, c
 		}
 	}
 
-	return deletionError
+	return remainingRetention, deletionError
+}
+
+// getRemainingPVCRetention returns the remaining time the provided PVC must be retained before it can be deleted. If
+// the start of the retention is not yet stored in the annotations of the PVC, the current time will be stored.
+func getRemainingPVCRetention(ctx context.Context, r *FoundationDBClusterReconciler, pvc *corev1.PersistentVolumeClaim, retentionDuration time.Duration) (time.Duration, error) {
+	if _, ok := pvc.Annotations[fdbv1beta2.SkipPVCRetentionKey]; ok {
+		return 0, nil
+	}
+
+	retentionStart, ok := pvc.Annotations[fdbv1beta2.PVCRetentionStartKey]
+	if !ok {
+		patch := client.MergeFrom(pvc.DeepCopy())
+		if pvc.Annotations == nil {
+			pvc.Annotations = map[string]string{}
+		}
+		pvc.Annotations[fdbv1beta2.PVCRetentionStartKey] = strconv.FormatInt(time.Now().Unix(), 10)
+
+		return retentionDuration, r.Patch(ctx, pvc, patch)
+	}
+
+	timestamp, err := strconv.ParseInt(retentionStart, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := time.Until(time.Unix(timestamp, 0).Add(retentionDuration))
+	if remaining < 0 {
+		return 0, nil
+	}
+
+	return remaining, nil
 }
 
 func confirmRemoval(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) (bool, bool, error) {
 	canBeIncluded := true
 
 	podName := processGroup.GetPodName(cluster)
-	pod, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, podName)
+	pod, err := podmanager.GetPodForProcessGroup(ctx, r.PodLifecycleManager, r, cluster, processGroup)
 	// If we get an error different from not found we will return the error.
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return false, false, err
@@ -217,16 +289,18 @@ func confirmRemoval(ctx context.Context, logger logr.Logger, r *FoundationDBClus
 		return false, canBeIncluded, err
 	}
 
-	if len(pvcs.Items) == 1 {
-		if pvcs.Items[0].DeletionTimestamp == nil {
-			logger.Info("Waiting for volume claim to get torn down", "processGroupID", processGroup.ProcessGroupID, "pvc", pvcs.Items[0].Name)
+	if countDataPVCs(pvcs) > 1 {
+		return false, false, fmt.Errorf("multiple PVCs found for cluster %s, processGroupID %s", cluster.Name, processGroup.ProcessGroupID)
+	}
+
+	for _, pvc := range pvcs.Items {
+		if pvc.DeletionTimestamp == nil {
+			logger.Info("Waiting for volume claim to get torn down", "processGroupID", processGroup.ProcessGroupID, "pvc", pvc.Name)
 			return false, false, nil
 		}
 
 		// PVC is in terminating state so we don't want to block but we also don't want to include it
 		canBeIncluded = false
-	} else if len(pvcs.Items) > 1 {
-		return false, false, fmt.Errorf("multiple PVCs found for cluster %s, processGroupID %s", cluster.Name, processGroup.ProcessGroupID)
 	}
 
 	service := &corev1.Service{}
@@ -250,7 +324,24 @@ func confirmRemoval(ctx context.Context, logger logr.Logger, r *FoundationDBClus
 	return true, canBeIncluded, nil
 }
 
+// countDataPVCs returns the number of PVCs in the list that are not used for the trace logs.
+func countDataPVCs(pvcs *corev1.PersistentVolumeClaimList) int {
+	count := 0
+	for _, pvc := range pvcs.Items {
+		if internal.IsLogPvc(pvc) {
+			continue
+		}
+
+		count++
+	}
+
+	return count
+}
+
 func includeProcessGroup(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, removedProcessGroups map[fdbv1beta2.ProcessGroupID]bool, status *fdbv1beta2.FoundationDBStatus) error {
+	// getProcessesToInclude removes the included process groups from the status, so we have to remember which process
+	// group an address belongs to before.
+	processGroupsByAddress := getProcessGroupsByAddress(cluster, removedProcessGroups)
 	fdbProcessesToInclude, err := getProcessesToInclude(logger, cluster, removedProcessGroups, status)
 	if err != nil {
 		return err
@@ -281,7 +372,7 @@ func includeProcessGroup(ctx context.Context, logger logr.Logger, r *FoundationD
 	}
 
 	// Make sure it's safe to include processes.
-	err = fdbstatus.CanSafelyIncludeProcesses(cluster, status, r.MinimumRecoveryTimeForInclusion)
+	err = fdbstatus.CanSafelyIncludeProcesses(cluster, status, cluster.GetMinimumRecoveryTimeForInclusion(r.MinimumRecoveryTimeForInclusion))
 	if err != nil {
 		return err
 	}
@@ -292,14 +383,78 @@ func includeProcessGroup(ctx context.Context, logger logr.Logger, r *FoundationD
 	}
 	defer adminClient.Close()
 
-	r.Recorder.Event(cluster, corev1.EventTypeNormal, "IncludingProcesses", fmt.Sprintf("Including removed processes: %v", fdbProcessesToInclude))
+	results, inclusionErr := adminClient.IncludeProcessesWithResults(fdbProcessesToInclude)
+	if len(results) == 0 {
+		return inclusionErr
+	}
+
+	includedAt := metav1.Now()
+	records := make([]fdbv1beta2.InclusionRecord, 0, len(results))
+	included := make([]string, 0, len(results))
+	failed := make([]string, 0, len(results))
+	failedProcessGroups := map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.ProcessGroupStatus{}
+	for _, result := range results {
+		address := result.Address.String()
+		processGroup := processGroupsByAddress[address]
+
+		if result.Err != nil {
+			logger.Info("could not include address", "address", address, "error", result.Err.Error())
+			failed = append(failed, address)
+			if processGroup != nil {
+				failedProcessGroups[processGroup.ProcessGroupID] = processGroup
+			}
+			continue
+		}
+
+		record := fdbv1beta2.InclusionRecord{
+			Address:    address,
+			IncludedAt: &includedAt,
+		}
+		if processGroup != nil {
+			record.ProcessGroupID = processGroup.ProcessGroupID
+		}
+
+		records = append(records, record)
+		included = append(included, address)
+	}
+
+	// Keep the process groups with addresses that couldn't be included in the status, so the inclusion will be retried.
+	for _, processGroup := range failedProcessGroups {
+		cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+	}
+
+	cluster.Status.AddRecentInclusions(records...)
 
-	err = adminClient.IncludeProcesses(fdbProcessesToInclude)
+	if len(failed) > 0 {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "IncludedProcesses", fmt.Sprintf("Included %d of %d removed processes: %v, failed: %v", len(included), len(results), included, failed))
+	} else {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "IncludedProcesses", fmt.Sprintf("Included %d removed processes: %v", len(included), included))
+	}
+
+	err = r.updateOrApply(ctx, cluster)
 	if err != nil {
 		return err
 	}
 
-	return r.updateOrApply(ctx, cluster)
+	return inclusionErr
+}
+
+// getProcessGroupsByAddress returns a map of the addresses that could be included for the removed process groups to the
+// corresponding process group.
+func getProcessGroupsByAddress(cluster *fdbv1beta2.FoundationDBCluster, removedProcessGroups map[fdbv1beta2.ProcessGroupID]bool) map[string]*fdbv1beta2.ProcessGroupStatus {
+	processGroupsByAddress := map[string]*fdbv1beta2.ProcessGroupStatus{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.IsMarkedForRemoval() || !removedProcessGroups[processGroup.ProcessGroupID] {
+			continue
+		}
+
+		processGroupsByAddress[fdbv1beta2.ProcessAddress{StringAddress: processGroup.GetExclusionString()}.String()] = processGroup
+		for _, address := range processGroup.Addresses {
+			processGroupsByAddress[fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(address)}.String()] = processGroup
+		}
+	}
+
+	return processGroupsByAddress
 }
 
 func getProcessesToInclude(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, removedProcessGroups map[fdbv1beta2.ProcessGroupID]bool, status *fdbv1beta2.FoundationDBStatus) ([]fdbv1beta2.ProcessAddress, error) {
@@ -351,7 +506,7 @@ func getProcessesToInclude(logger logr.Logger, cluster *fdbv1beta2.FoundationDBC
 	return fdbProcessesToInclude, nil
 }
 
-func (r *FoundationDBClusterReconciler) getProcessGroupsToRemove(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, remainingMap map[string]bool, cordSet map[string]fdbv1beta2.None) (bool, bool, []*fdbv1beta2.ProcessGroupStatus) {
+func (r *FoundationDBClusterReconciler) getProcessGroupsToRemove(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, remainingMap map[string]bool, cordSet map[string]fdbv1beta2.None, activeSet map[string]fdbv1beta2.None) (bool, bool, []*fdbv1beta2.ProcessGroupStatus) {
 	allExcluded := true
 	newExclusions := false
 	processGroupsToRemove := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(cluster.Status.ProcessGroups))
@@ -368,6 +523,15 @@ func (r *FoundationDBClusterReconciler) getProcessGroupsToRemove(logger logr.Log
 			continue
 		}
 
+		// A process can be reported as excluded while it still serves roles for a short time. Until the process is not
+		// serving any roles anymore, we treat the exclusion as not done to prevent a premature removal. Process groups
+		// that are removed without an exclusion are expected to still serve roles, so they will not be blocked.
+		if _, ok := activeSet[string(processGroup.ProcessGroupID)]; ok && !processGroup.ExclusionSkipped {
+			logger.Info("Block removal of process group that still serves roles", "processGroupID", processGroup.ProcessGroupID)
+			allExcluded = false
+			continue
+		}
+
 		// ProcessGroup is already marked as excluded we can add it to the processGroupsToRemove and skip further checks.
 		if processGroup.IsExcluded() {
 			processGroupsToRemove = append(processGroupsToRemove, processGroup)
@@ -390,7 +554,7 @@ func (r *FoundationDBClusterReconciler) getProcessGroupsToRemove(logger logr.Log
 	return allExcluded, newExclusions, processGroupsToRemove
 }
 
-func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroupsToRemove []*fdbv1beta2.ProcessGroupStatus, terminatingProcessGroups []*fdbv1beta2.ProcessGroupStatus) map[fdbv1beta2.ProcessGroupID]bool {
+func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroupsToRemove []*fdbv1beta2.ProcessGroupStatus, terminatingProcessGroups []*fdbv1beta2.ProcessGroupStatus) (map[fdbv1beta2.ProcessGroupID]bool, time.Duration) {
 	processGroupNames := make([]fdbv1beta2.ProcessGroupID, len(processGroupsToRemove))
 	for i, processGroup := range processGroupsToRemove {
 		processGroupNames[i] = processGroup.ProcessGroupID
@@ -398,9 +562,14 @@ func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context,
 
 	r.Recorder.Event(cluster, corev1.EventTypeNormal, "RemovingProcesses", fmt.Sprintf("Removing process groups: %v", processGroupNames))
 
+	var remainingRetention time.Duration
 	processGroups := append(processGroupsToRemove, terminatingProcessGroups...)
 	for _, processGroup := range processGroups {
-		err := removeProcessGroup(logr.NewContext(ctx, logger), r, cluster, processGroup)
+		remaining, err := removeProcessGroup(logr.NewContext(ctx, logger), r, cluster, processGroup)
+		if remaining > 0 && (remainingRetention == 0 || remaining < remainingRetention) {
+			remainingRetention = remaining
+		}
+
 		if err != nil {
 			logger.Error(err, "Error during remove process group", "processGroupID", processGroup.ProcessGroupID)
 			continue
@@ -425,5 +594,5 @@ func (r *FoundationDBClusterReconciler) removeProcessGroups(ctx context.Context,
 		}
 	}
 
-	return removedProcessGroups
+	return removedProcessGroups, remainingRetention
 }
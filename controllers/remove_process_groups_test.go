@@ -23,11 +23,18 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/go-logr/logr"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 
@@ -83,7 +90,7 @@ var _ = Describe("remove_process_groups", func() {
 					coordinatorIP: {},
 				}
 
-				allExcluded, newExclusions, processes := clusterReconciler.getProcessGroupsToRemove(globalControllerLogger, cluster, remaining, coordSet)
+				allExcluded, newExclusions, processes := clusterReconciler.getProcessGroupsToRemove(globalControllerLogger, cluster, remaining, coordSet, map[string]fdbv1beta2.None{})
 				Expect(allExcluded).To(BeFalse())
 				Expect(processes).To(BeEmpty())
 				Expect(newExclusions).To(BeFalse())
@@ -116,6 +123,131 @@ var _ = Describe("remove_process_groups", func() {
 						Expect(removed).To(BeTrue())
 						Expect(include).To(BeTrue())
 					})
+
+					It("should record the inclusion in the cluster status", func() {
+						Expect(fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, removedProcessGroup.ProcessGroupID)).To(BeNil())
+						Expect(cluster.Status.RecentInclusions).To(HaveLen(1))
+						inclusion := cluster.Status.RecentInclusions[0]
+						Expect(inclusion.Address).To(Equal(removedProcessGroup.Addresses[0]))
+						Expect(inclusion.ProcessGroupID).To(Equal(removedProcessGroup.ProcessGroupID))
+						Expect(inclusion.IncludedAt).NotTo(BeNil())
+						Expect(getInclusionEvents(cluster)).To(ConsistOf(fmt.Sprintf("Included 1 removed processes: [%s]", removedProcessGroup.Addresses[0])))
+					})
+				})
+
+				When("the address of the removed process group cannot be included", func() {
+					BeforeEach(func() {
+						adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+						Expect(err).NotTo(HaveOccurred())
+						adminClient.MockInclusionError(removedProcessGroup.Addresses[0], fmt.Errorf("mocked inclusion error"))
+					})
+
+					It("should keep the process group in the status to retry the inclusion", func() {
+						Expect(result).NotTo(BeNil())
+						Expect(result.curError).To(MatchError(ContainSubstring(removedProcessGroup.Addresses[0])))
+						Expect(fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, removedProcessGroup.ProcessGroupID)).NotTo(BeNil())
+						Expect(cluster.Status.RecentInclusions).To(BeEmpty())
+						Expect(getInclusionEvents(cluster)).To(ConsistOf(fmt.Sprintf("Included 0 of 1 removed processes: [], failed: [%s]", removedProcessGroup.Addresses[0])))
+					})
+				})
+
+				When("the process group has a PVC for the trace logs", func() {
+					var logPVC *corev1.PersistentVolumeClaim
+
+					BeforeEach(func() {
+						cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+							fdbv1beta2.ProcessClassGeneral: {LogVolumeClaimTemplate: &corev1.PersistentVolumeClaim{}},
+						}
+
+						var err error
+						logPVC, err = internal.GetLogPvc(cluster, removedProcessGroup)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(k8sClient.Create(context.TODO(), logPVC)).NotTo(HaveOccurred())
+					})
+
+					It("should remove the PVC for the trace logs together with the process group", func() {
+						Expect(result).To(BeNil())
+						removed, include, err := confirmRemoval(context.Background(), globalControllerLogger, clusterReconciler, cluster, removedProcessGroup)
+						Expect(err).To(BeNil())
+						Expect(removed).To(BeTrue())
+						Expect(include).To(BeTrue())
+
+						err = k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(logPVC), &corev1.PersistentVolumeClaim{})
+						Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					})
+				})
+
+				When("a PVC retention duration is defined", func() {
+					getPVCs := func() []corev1.PersistentVolumeClaim {
+						pvcs := &corev1.PersistentVolumeClaimList{}
+						Expect(k8sClient.List(context.TODO(), pvcs, internal.GetSinglePodListOptions(cluster, removedProcessGroup.ProcessGroupID)...)).NotTo(HaveOccurred())
+						return pvcs.Items
+					}
+
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.Removals.PVCRetentionDuration = &metav1.Duration{Duration: time.Hour}
+						cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+							fdbv1beta2.ProcessClassGeneral: {LogVolumeClaimTemplate: &corev1.PersistentVolumeClaim{}},
+						}
+
+						pvc, err := internal.GetLogPvc(cluster, removedProcessGroup)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(k8sClient.Create(context.TODO(), pvc)).NotTo(HaveOccurred())
+						Expect(getPVCs()).To(HaveLen(1))
+					})
+
+					It("should retain the PVC until the retention duration has passed", func() {
+						Expect(result).NotTo(BeNil())
+						Expect(result.delayedRequeue).To(BeTrue())
+						Expect(result.delay).To(BeNumerically(">", 59*time.Minute))
+						Expect(result.delay).To(BeNumerically("<=", time.Hour))
+
+						pvcs := getPVCs()
+						Expect(pvcs).To(HaveLen(1))
+						Expect(pvcs[0].DeletionTimestamp.IsZero()).To(BeTrue())
+						Expect(pvcs[0].Annotations).To(HaveKey(fdbv1beta2.PVCRetentionStartKey))
+
+						removed, include, err := confirmRemoval(context.Background(), globalControllerLogger, clusterReconciler, cluster, removedProcessGroup)
+						Expect(err).To(BeNil())
+						Expect(removed).To(BeFalse())
+						Expect(include).To(BeFalse())
+					})
+
+					When("the retention duration has passed", func() {
+						BeforeEach(func() {
+							pvc := getPVCs()[0]
+							pvc.Annotations[fdbv1beta2.PVCRetentionStartKey] = strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)
+							Expect(k8sClient.Update(context.TODO(), &pvc)).NotTo(HaveOccurred())
+						})
+
+						It("should remove the PVC", func() {
+							Expect(result).To(BeNil())
+							Expect(getPVCs()).To(BeEmpty())
+
+							removed, include, err := confirmRemoval(context.Background(), globalControllerLogger, clusterReconciler, cluster, removedProcessGroup)
+							Expect(err).To(BeNil())
+							Expect(removed).To(BeTrue())
+							Expect(include).To(BeTrue())
+						})
+					})
+
+					When("the PVC is annotated to skip the retention", func() {
+						BeforeEach(func() {
+							pvc := getPVCs()[0]
+							pvc.Annotations[fdbv1beta2.SkipPVCRetentionKey] = "true"
+							Expect(k8sClient.Update(context.TODO(), &pvc)).NotTo(HaveOccurred())
+						})
+
+						It("should remove the PVC without waiting", func() {
+							Expect(result).To(BeNil())
+							Expect(getPVCs()).To(BeEmpty())
+
+							removed, include, err := confirmRemoval(context.Background(), globalControllerLogger, clusterReconciler, cluster, removedProcessGroup)
+							Expect(err).To(BeNil())
+							Expect(removed).To(BeTrue())
+							Expect(include).To(BeTrue())
+						})
+					})
 				})
 
 				When("the cluster has degraded storage fault tolerance", func() {
@@ -286,7 +418,8 @@ var _ = Describe("remove_process_groups", func() {
 					When("a process group is marked as terminating and all resources are removed it should be removed", func() {
 						BeforeEach(func() {
 							secondRemovedProcessGroup.ProcessGroupConditions = append(secondRemovedProcessGroup.ProcessGroupConditions, fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.ResourcesTerminating))
-							Expect(removeProcessGroup(context.Background(), clusterReconciler, cluster, secondRemovedProcessGroup)).NotTo(HaveOccurred())
+							_, err := removeProcessGroup(context.Background(), clusterReconciler, cluster, secondRemovedProcessGroup)
+							Expect(err).NotTo(HaveOccurred())
 						})
 
 						It("should remove the process group and the terminated process group", func() {
@@ -389,7 +522,7 @@ var _ = Describe("remove_process_groups", func() {
 					When("a process group is marked as terminating and all resources are removed it should be removed", func() {
 						BeforeEach(func() {
 							secondRemovedProcessGroup.ProcessGroupConditions = append(secondRemovedProcessGroup.ProcessGroupConditions, fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.ResourcesTerminating))
-							err := removeProcessGroup(context.Background(), clusterReconciler, cluster, secondRemovedProcessGroup)
+							_, err := removeProcessGroup(context.Background(), clusterReconciler, cluster, secondRemovedProcessGroup)
 							Expect(err).NotTo(HaveOccurred())
 						})
 
@@ -461,6 +594,90 @@ var _ = Describe("remove_process_groups", func() {
 		})
 	})
 
+	Context("validating the removal of process groups that still serve roles", func() {
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			_, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("trying to remove an excluded process group that still serves roles", func() {
+			var removedProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+			BeforeEach(func() {
+				removedProcessGroup = cluster.Status.ProcessGroups[0]
+				removedProcessGroup.MarkForRemoval()
+			})
+
+			When("the process group is not yet marked as excluded", func() {
+				It("should not mark the process group as excluded or remove it", func() {
+					remaining := map[string]bool{}
+					for _, address := range removedProcessGroup.Addresses {
+						remaining[address] = false
+					}
+
+					activeSet := map[string]fdbv1beta2.None{
+						string(removedProcessGroup.ProcessGroupID): {},
+					}
+
+					allExcluded, newExclusions, processes := clusterReconciler.getProcessGroupsToRemove(globalControllerLogger, cluster, remaining, map[string]fdbv1beta2.None{}, activeSet)
+					Expect(allExcluded).To(BeFalse())
+					Expect(processes).To(BeEmpty())
+					Expect(newExclusions).To(BeFalse())
+					Expect(removedProcessGroup.IsExcluded()).To(BeFalse())
+				})
+			})
+
+			When("the process group is already marked as excluded", func() {
+				BeforeEach(func() {
+					removedProcessGroup.SetExclude()
+				})
+
+				It("should not remove the process group", func() {
+					activeSet := map[string]fdbv1beta2.None{
+						string(removedProcessGroup.ProcessGroupID): {},
+					}
+
+					allExcluded, newExclusions, processes := clusterReconciler.getProcessGroupsToRemove(globalControllerLogger, cluster, map[string]bool{}, map[string]fdbv1beta2.None{}, activeSet)
+					Expect(allExcluded).To(BeFalse())
+					Expect(processes).To(BeEmpty())
+					Expect(newExclusions).To(BeFalse())
+				})
+
+				When("the process group doesn't serve any roles anymore", func() {
+					It("should remove the process group", func() {
+						allExcluded, newExclusions, processes := clusterReconciler.getProcessGroupsToRemove(globalControllerLogger, cluster, map[string]bool{}, map[string]fdbv1beta2.None{}, map[string]fdbv1beta2.None{})
+						Expect(allExcluded).To(BeTrue())
+						Expect(processes).To(ConsistOf(removedProcessGroup))
+						Expect(newExclusions).To(BeFalse())
+					})
+				})
+			})
+
+			When("the process group is removed without exclusion", func() {
+				BeforeEach(func() {
+					removedProcessGroup.ExclusionSkipped = true
+				})
+
+				It("should remove the process group", func() {
+					activeSet := map[string]fdbv1beta2.None{
+						string(removedProcessGroup.ProcessGroupID): {},
+					}
+
+					allExcluded, newExclusions, processes := clusterReconciler.getProcessGroupsToRemove(globalControllerLogger, cluster, map[string]bool{}, map[string]fdbv1beta2.None{}, activeSet)
+					Expect(allExcluded).To(BeTrue())
+					Expect(processes).To(ConsistOf(removedProcessGroup))
+					Expect(newExclusions).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Context("validating getProcessesToInclude", func() {
 		var removedProcessGroups map[fdbv1beta2.ProcessGroupID]bool
 		var status *fdbv1beta2.FoundationDBStatus
@@ -624,3 +841,17 @@ var _ = Describe("remove_process_groups", func() {
 		})
 	})
 })
+
+func getInclusionEvents(cluster *fdbv1beta2.FoundationDBCluster) []string {
+	events := &corev1.EventList{}
+	Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+	messages := make([]string, 0)
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID == cluster.UID && event.Reason == "IncludedProcesses" {
+			messages = append(messages, event.Message)
+		}
+	}
+
+	return messages
+}
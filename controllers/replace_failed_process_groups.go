@@ -22,10 +22,13 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/replacements"
@@ -36,7 +39,9 @@ import (
 type replaceFailedProcessGroups struct{}
 
 // return non-nil requeue if a process has been replaced
-func (c replaceFailedProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (c replaceFailedProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	// If the EmptyMonitorConf setting is set we expect that all fdb processes in this part of the cluster are missing. In order
 	// to prevent the operator from replacing any process groups we skip this reconciliation here.
 	if cluster.Spec.Buggify.EmptyMonitorConf {
@@ -56,11 +61,17 @@ func (c replaceFailedProcessGroups) reconcile(ctx context.Context, r *Foundation
 		if err != nil {
 			return &requeue{curError: err, delayedRequeue: true}
 		}
+
+		cachedStatus.record(status)
 	}
 
 	// Only replace process groups without an address, if the cluster has the desired fault tolerance and is available.
 	hasDesiredFaultTolerance := fdbstatus.HasDesiredFaultToleranceFromStatus(logger, status, cluster)
-	hasReplacement, hasMoreFailedProcesses := replacements.ReplaceFailedProcessGroups(logger, cluster, status, hasDesiredFaultTolerance)
+	hasReplacement, hasMoreFailedProcesses, deferredFaultDomains := replacements.ReplaceFailedProcessGroups(logger, cluster, status, hasDesiredFaultTolerance)
+	for _, faultDomain := range sortedFaultDomains(deferredFaultDomains) {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "ReplacementDeferred", fmt.Sprintf("Deferred automatic replacement in fault domain %s: %s", faultDomain, deferredFaultDomains[faultDomain]))
+	}
+
 	// If the reconciler replaced at least one process group we want to update the status and requeue.
 	if hasReplacement {
 		err := r.updateOrApply(ctx, cluster)
@@ -79,3 +90,17 @@ func (c replaceFailedProcessGroups) reconcile(ctx context.Context, r *Foundation
 
 	return nil
 }
+
+// sortedFaultDomains returns the fault domains of the provided map in sorted order.
+func sortedFaultDomains(faultDomains map[fdbv1beta2.FaultDomain]string) []fdbv1beta2.FaultDomain {
+	keys := make([]fdbv1beta2.FaultDomain, 0, len(faultDomains))
+	for faultDomain := range faultDomains {
+		keys = append(keys, faultDomain)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
@@ -26,6 +26,8 @@ import (
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
@@ -335,6 +337,80 @@ var _ = Describe("replace_failed_process_groups", func() {
 							Expect(pg.ExclusionSkipped).To(BeFalse())
 						}
 					})
+
+					When("both failed process groups are in the same fault domain and the replacements per fault domain are limited", func() {
+						BeforeEach(func() {
+							cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacements = pointer.Int(2)
+							cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacementsPerFaultDomain = pointer.Int(1)
+							processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-3")
+							processGroup.FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-storage-2")
+						})
+
+						It("should only mark one process group for removal", func() {
+							Expect(result).NotTo(BeNil())
+							Expect(result.message).To(Equal("Removals have been updated in the cluster status"))
+							Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
+						})
+
+						It("should emit an event for the deferred replacement", func() {
+							Expect(getReplacementDeferredEvents(cluster)).To(ConsistOf(ContainSubstring("fault domain " + cluster.Name + "-storage-2")))
+						})
+
+						When("the process groups are in different fault domains", func() {
+							BeforeEach(func() {
+								processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-3")
+								processGroup.FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-storage-3")
+							})
+
+							It("should mark both process groups for removal", func() {
+								Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2", "storage-3"}))
+								Expect(getReplacementDeferredEvents(cluster)).To(BeEmpty())
+							})
+						})
+					})
+
+					When("both failed process groups are in the same fault domain and a cool-down is defined", func() {
+						BeforeEach(func() {
+							cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacements = pointer.Int(2)
+							cluster.Spec.AutomationOptions.Replacements.FaultDomainReplacementCooldownSeconds = pointer.Int(600)
+							processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-3")
+							processGroup.FaultDomain = fdbv1beta2.FaultDomain(cluster.Name + "-storage-2")
+						})
+
+						It("should only mark one process group for removal", func() {
+							Expect(result).NotTo(BeNil())
+							Expect(result.message).To(Equal("Removals have been updated in the cluster status"))
+							Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
+							Expect(getReplacementDeferredEvents(cluster)).To(ConsistOf(ContainSubstring("cool-down")))
+						})
+
+						When("the last replacement in the fault domain is older than the cool-down", func() {
+							BeforeEach(func() {
+								processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+								processGroup.RemovalTimestamp = &metav1.Time{Time: time.Now().Add(-20 * time.Minute)}
+								processGroup.ExclusionTimestamp = &metav1.Time{Time: time.Now().Add(-15 * time.Minute)}
+							})
+
+							It("should mark the other process group for removal", func() {
+								Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2", "storage-3"}))
+								Expect(getReplacementDeferredEvents(cluster)).To(BeEmpty())
+							})
+						})
+
+						When("the last replaced process group in the fault domain was already removed", func() {
+							BeforeEach(func() {
+								cluster.Spec.AutomationOptions.Replacements.MaxConcurrentReplacements = pointer.Int(1)
+								cluster.Status.LastFaultDomainReplacements = map[fdbv1beta2.FaultDomain]metav1.Time{
+									fdbv1beta2.FaultDomain(cluster.Name + "-storage-2"): metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+								}
+							})
+
+							It("should not mark any process group for removal", func() {
+								Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+								Expect(getReplacementDeferredEvents(cluster)).To(ConsistOf(ContainSubstring("cool-down")))
+							})
+						})
+					})
 				})
 
 				Context("with another in-flight exclusion", func() {
@@ -510,6 +586,22 @@ var _ = Describe("replace_failed_process_groups", func() {
 					})
 				})
 
+				When("the process group is a test process group that should be ignored", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.IgnoreTestProcessGroups = pointer.Bool(true)
+						processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+						processGroup.ProcessClass = fdbv1beta2.ProcessClassTest
+					})
+
+					It("should return nil", func() {
+						Expect(result).To(BeNil())
+					})
+
+					It("should not mark the process group for removal", func() {
+						Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+					})
+				})
+
 				Context("with maintenance mode enabled", func() {
 					BeforeEach(func() {
 						adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
@@ -523,6 +615,32 @@ var _ = Describe("replace_failed_process_groups", func() {
 				})
 			})
 
+			Context("with a process group that has a failed PVC", func() {
+				BeforeEach(func() {
+					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+					processGroup.UpdateCondition(fdbv1beta2.PVCFailed, true)
+				})
+
+				It("should mark the process group for removal without waiting for the failure detection time", func() {
+					Expect(result).NotTo(BeNil())
+					Expect(result.message).To(Equal("Removals have been updated in the cluster status"))
+					Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
+				})
+
+				When("another replacement is in-flight", func() {
+					BeforeEach(func() {
+						processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-3")
+						processGroup.MarkForRemoval()
+					})
+
+					It("should respect the replacement limits", func() {
+						Expect(result).NotTo(BeNil())
+						Expect(result.message).To(Equal("More failed process groups are detected"))
+						Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-3"}))
+					})
+				})
+			})
+
 			Context("with a process that has been missing for a brief time", func() {
 				BeforeEach(func() {
 					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
@@ -1068,3 +1186,18 @@ func getRemovedProcessGroupIDs(cluster *fdbv1beta2.FoundationDBCluster) []fdbv1b
 
 	return results
 }
+
+// getReplacementDeferredEvents returns the messages of the ReplacementDeferred events for the provided cluster.
+func getReplacementDeferredEvents(cluster *fdbv1beta2.FoundationDBCluster) []string {
+	events := &corev1.EventList{}
+	Expect(k8sClient.List(ctx.TODO(), events)).NotTo(HaveOccurred())
+
+	messages := make([]string, 0)
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID == cluster.UID && event.Reason == "ReplacementDeferred" {
+			messages = append(messages, event.Message)
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,66 @@
+/*
+ * requeue_backoff.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"sync"
+	"time"
+)
+
+// requeueBackoff computes exponentially increasing requeue delays for objects that are repeatedly not fully
+// reconciled.
+type requeueBackoff struct {
+	lock sync.Mutex
+	// attempts contains the number of consecutive requeues per key.
+	attempts map[string]int
+}
+
+// next returns the delay for the next requeue of the provided key and increases the number of attempts. The delay
+// starts at the provided minimum and is doubled for every consecutive attempt until the provided maximum is reached.
+func (backoff *requeueBackoff) next(key string, minDelay time.Duration, maxDelay time.Duration) time.Duration {
+	backoff.lock.Lock()
+	defer backoff.lock.Unlock()
+
+	if backoff.attempts == nil {
+		backoff.attempts = map[string]int{}
+	}
+
+	attempts := backoff.attempts[key]
+	backoff.attempts[key] = attempts + 1
+
+	delay := minDelay
+	for i := 0; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+
+	return delay
+}
+
+// reset removes the attempts of the provided key, the next requeue will use the minimum delay again.
+func (backoff *requeueBackoff) reset(key string) {
+	backoff.lock.Lock()
+	defer backoff.lock.Unlock()
+
+	delete(backoff.attempts, key)
+}
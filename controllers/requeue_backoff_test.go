@@ -0,0 +1,63 @@
+/*
+ * requeue_backoff_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("requeue_backoff", func() {
+	var backoff *requeueBackoff
+
+	BeforeEach(func() {
+		backoff = &requeueBackoff{}
+	})
+
+	It("should double the delay until the maximum is reached", func() {
+		var delays []time.Duration
+		for i := 0; i < 5; i++ {
+			delays = append(delays, backoff.next("test", time.Second, 5*time.Second))
+		}
+
+		Expect(delays).To(Equal([]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}))
+	})
+
+	It("should track the attempts per key", func() {
+		Expect(backoff.next("test", time.Second, time.Minute)).To(Equal(time.Second))
+		Expect(backoff.next("test", time.Second, time.Minute)).To(Equal(2 * time.Second))
+		Expect(backoff.next("other", time.Second, time.Minute)).To(Equal(time.Second))
+	})
+
+	When("the attempts are reset", func() {
+		BeforeEach(func() {
+			backoff.next("test", time.Second, time.Minute)
+			backoff.next("test", time.Second, time.Minute)
+			backoff.reset("test")
+		})
+
+		It("should use the minimum delay again", func() {
+			Expect(backoff.next("test", time.Second, time.Minute)).To(Equal(time.Second))
+		})
+	})
+})
@@ -22,23 +22,37 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// restoreProgressInterval defines the delay after which a restore that is still in progress will be reconciled again
+// to update the progress in the status.
+const restoreProgressInterval = 30 * time.Second
+
+// restorePreflightInterval defines the delay after which the checks of the destination cluster will be retried if the
+// destination cluster cannot accept a restore.
+const restorePreflightInterval = time.Minute
+
 // FoundationDBRestoreReconciler reconciles a FoundationDBRestore object
 type FoundationDBRestoreReconciler struct {
 	client.Client
@@ -69,12 +83,37 @@ func (r *FoundationDBRestoreReconciler) Reconcile(ctx context.Context, request c
 
 	restoreLog := globalControllerLogger.WithValues("namespace", restore.Namespace, "restore", restore.Name)
 
+	// The restore is aborted before the validation to make sure an invalid restore spec doesn't block the deletion.
+	if !restore.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(restore, fdbv1beta2.RestoreAbortFinalizer) {
+			return r.abortRestoreForDeletion(ctx, restoreLog, restore)
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	err = r.ensureRestoreAbortFinalizer(ctx, restoreLog, restore)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = restore.Validate()
+	if err != nil {
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "RestoreSpec not valid", err.Error())
+		return ctrl.Result{}, fmt.Errorf("RestoreSpec is not valid: %w", err)
+	}
+
 	subReconcilers := []restoreSubReconciler{
+		abortRestore{},
+		checkRestoreDestination{},
 		startRestore{},
+		updateRestoreStatus{},
 	}
 
 	for _, subReconciler := range subReconcilers {
-		requeue := subReconciler.reconcile(ctx, r, restore)
+		requeue := runSubReconciler(subReconciler, restore, func() *requeue {
+			return subReconciler.reconcile(ctx, r, restore)
+		})
 		if requeue == nil {
 			continue
 		}
@@ -82,6 +121,11 @@ func (r *FoundationDBRestoreReconciler) Reconcile(ctx context.Context, request c
 		return processRequeue(requeue, subReconciler, restore, r.Recorder, restoreLog)
 	}
 
+	if restore.Status.State.IsInProgress() {
+		restoreLog.Info("Restore is in progress", "state", restore.Status.State, "progressPercentage", restore.Status.ProgressPercentage)
+		return ctrl.Result{RequeueAfter: restoreProgressInterval}, nil
+	}
+
 	restoreLog.Info("Reconciliation complete")
 
 	return ctrl.Result{}, nil
@@ -103,18 +147,28 @@ func (r *FoundationDBRestoreReconciler) adminClientForRestore(ctx context.Contex
 		return nil, err
 	}
 
+	caFilePath, err := writeBlobCABundle(ctx, r, restore.Namespace, restore.Spec.BlobStoreConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
 	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
 	if err != nil {
 		return nil, err
 	}
 
-	adminClient.SetKnobs(restore.Spec.CustomParameters.GetKnobsForCLI())
+	adminClient.SetKnobs(append(restore.Spec.CustomParameters.GetKnobsForCLI(), restore.Spec.BlobStoreConfiguration.GetTLSArgsForCLI(caFilePath)...))
 
 	return adminClient, nil
 }
 
 // SetupWithManager prepares a reconciler for use.
 func (r *FoundationDBRestoreReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int, selector metav1.LabelSelector) error {
+	err := metrics.Register(ctrlmetrics.Registry)
+	if err != nil {
+		return err
+	}
+
 	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
 	if err != nil {
 		return err
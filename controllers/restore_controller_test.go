@@ -21,6 +21,11 @@
 package controllers
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
 	. "github.com/onsi/ginkgo/v2"
@@ -29,13 +34,33 @@ import (
 	"context"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func reloadRestore(restore *fdbv1beta2.FoundationDBRestore) error {
 	return k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: restore.Name}, restore)
 }
 
+func getRestoreEvents(restore *fdbv1beta2.FoundationDBRestore, reason string) []corev1.Event {
+	events := &corev1.EventList{}
+	Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+	var matchingEvents []corev1.Event
+	for _, event := range events.Items {
+		if event.InvolvedObject.Name == restore.Name && event.Reason == reason {
+			matchingEvents = append(matchingEvents, event)
+		}
+	}
+
+	return matchingEvents
+}
+
 var _ = Describe("restore_controller", func() {
 	var cluster *fdbv1beta2.FoundationDBCluster
 	var restore *fdbv1beta2.FoundationDBRestore
@@ -96,6 +121,71 @@ var _ = Describe("restore_controller", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status).To(Equal("blobstore://test@test-service:443/test-backup?bucket=fdb-backups\n"))
 			})
+
+			It("should report the state of the restore", func() {
+				Expect(restore.Status.State).To(Equal(fdbv1beta2.RestoreStateRunning))
+				Expect(restore.Status.Tag).To(Equal(fdbv1beta2.DefaultBackupTag))
+				Expect(getRestoreEvents(restore, "RestoreRunning")).To(HaveLen(1))
+			})
+
+			It("should requeue while the restore is in progress", func() {
+				result, err := reconcileRestore(restore)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(restoreProgressInterval))
+			})
+		})
+
+		When("the restore makes progress", func() {
+			var result reconcile.Result
+
+			BeforeEach(func() {
+				adminClient.RestoreProgress = []fdbv1beta2.FoundationDBLiveRestoreStatus{
+					{State: "running", BlocksCompleted: 5, BlocksTotal: 10, BytesWritten: 1024},
+					{State: "completed", BlocksCompleted: 10, BlocksTotal: 10, BytesWritten: 2048},
+				}
+			})
+
+			It("should report the progress", func() {
+				Expect(restore.Status.Running).To(BeTrue())
+				Expect(restore.Status.State).To(Equal(fdbv1beta2.RestoreStateRunning))
+				Expect(restore.Status.ProgressPercentage).To(Equal(50))
+				Expect(restore.Status.BytesWritten).To(Equal(int64(1024)))
+			})
+
+			When("the restore completes", func() {
+				JustBeforeEach(func() {
+					result, err = reconcileRestore(restore)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+				})
+
+				It("should report the completed restore", func() {
+					Expect(restore.Status.Running).To(BeFalse())
+					Expect(restore.Status.State).To(Equal(fdbv1beta2.RestoreStateCompleted))
+					Expect(restore.Status.ProgressPercentage).To(Equal(100))
+					Expect(restore.Status.BytesWritten).To(Equal(int64(2048)))
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(getRestoreEvents(restore, "RestoreCompleted")).To(HaveLen(1))
+				})
+			})
+		})
+
+		When("the restore fails", func() {
+			BeforeEach(func() {
+				adminClient.RestoreProgress = []fdbv1beta2.FoundationDBLiveRestoreStatus{
+					{State: "aborted", LastError: "'restore_missing_data' 30s ago."},
+				}
+			})
+
+			It("should report the failed restore", func() {
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(restore.Status.State).To(Equal(fdbv1beta2.RestoreStateFailed))
+
+				events := getRestoreEvents(restore, "RestoreFailed")
+				Expect(events).To(HaveLen(1))
+				Expect(events[0].Type).To(Equal(corev1.EventTypeWarning))
+				Expect(events[0].Message).To(ContainSubstring("restore_missing_data"))
+			})
 		})
 
 		When("providing custom parameters", func() {
@@ -112,5 +202,400 @@ var _ = Describe("restore_controller", func() {
 				Expect(adminClient.Knobs).To(HaveKey("--knob_http_verbose_level=3"))
 			})
 		})
+
+		When("providing TLS settings for the blobstore", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.TODO(), &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "blob-ca",
+						Namespace: restore.Namespace,
+					},
+					Data: map[string]string{
+						"ca.pem": "blob-ca",
+					},
+				})).NotTo(HaveOccurred())
+
+				restore.Spec.BlobStoreConfiguration.CABundle = &fdbv1beta2.BlobStoreCABundle{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+						Key:                  "ca.pem",
+					},
+				}
+				restore.Spec.BlobStoreConfiguration.TLSVerifyPeers = pointer.Bool(false)
+				err = k8sClient.Update(context.TODO(), restore)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should pass the TLS settings to the command", func() {
+				Expect(adminClient.Knobs).To(HaveKey("--tls_ca_file"))
+				caFilePath := filepath.Join(os.TempDir(), "blob-ca-bundle", restore.Namespace, "configmap", "blob-ca", "ca.pem")
+				Expect(adminClient.Knobs).To(HaveKey(caFilePath))
+				Expect(os.ReadFile(caFilePath)).To(Equal([]byte("blob-ca")))
+				Expect(adminClient.Knobs).To(HaveKey("--tls_verify_peers"))
+				Expect(adminClient.Knobs).To(HaveKey("Check.Valid=0"))
+			})
+		})
+	})
+
+	When("the restore defines key ranges", func() {
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			restore.Spec.KeyRanges = []fdbv1beta2.FoundationDBKeyRange{
+				{Start: `\x15user1/`, End: `\x15user2/`},
+				{Start: `\x15user3/`, End: `\x15user4/`},
+			}
+			Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+
+			_, err := reconcileRestore(restore)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+		})
+
+		It("should pass the key ranges to the restore", func() {
+			Expect(adminClient.RestoreKeyRanges).To(Equal(restore.Spec.KeyRanges))
+		})
+
+		It("should report the submitted key ranges in the status", func() {
+			Expect(restore.Status.KeyRanges).To(Equal(restore.Spec.KeyRanges))
+		})
+	})
+
+	When("the restore defines overlapping key ranges", func() {
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			restore.Spec.KeyRanges = []fdbv1beta2.FoundationDBKeyRange{
+				{Start: `\x15user1/`, End: `\x15user3/`},
+				{Start: `\x15user2/`, End: `\x15user4/`},
+			}
+			Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+		})
+
+		It("should reject the restore spec", func() {
+			_, err := reconcileRestore(restore)
+			Expect(err).To(MatchError(ContainSubstring("RestoreSpec is not valid")))
+
+			status, err := adminClient.GetRestoreStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal("\n"))
+		})
+	})
+
+	When("the restore defines a version", func() {
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			restore.Spec.RestoreVersion = pointer.Int64(1500000000)
+			Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+
+			_, err := reconcileRestore(restore)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+		})
+
+		It("should restore the version", func() {
+			Expect(adminClient.RestoreVersion).To(Equal(int64(1500000000)))
+			Expect(restore.Status.RestoreVersion).To(Equal(int64(1500000000)))
+		})
+	})
+
+	When("the restore defines a timestamp", func() {
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			restore.Spec.RestoreTimestamp = &metav1.Time{Time: time.Unix(1709280100, 0)}
+			adminClient.RestoreProgress = []fdbv1beta2.FoundationDBLiveRestoreStatus{
+				{State: "running", Version: 1100000000},
+			}
+		})
+
+		When("fdbrestore resolves the timestamp", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+
+				_, err := reconcileRestore(restore)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reconcileRestore(restore)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+			})
+
+			It("should restore the timestamp with the version history of the destination cluster", func() {
+				Expect(adminClient.RestoreTimestamp).NotTo(BeNil())
+				Expect(adminClient.RestoreTimestamp.Equal(time.Unix(1709280100, 0))).To(BeTrue())
+				Expect(adminClient.RestoreSourceCluster).To(Equal(cluster.Name))
+			})
+
+			It("should report the version reported by fdbrestore", func() {
+				Expect(restore.Status.RestoreVersion).To(Equal(int64(1100000000)))
+			})
+		})
+
+		When("the source cluster does not exist", func() {
+			BeforeEach(func() {
+				restore.Spec.SourceClusterName = "missing"
+				Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+			})
+
+			It("should not start the restore", func() {
+				_, err := reconcileRestore(restore)
+				Expect(err).To(HaveOccurred())
+				Expect(adminClient.RestoreTimestamp).To(BeNil())
+			})
+		})
+
+		When("fdbrestore cannot resolve the timestamp", func() {
+			BeforeEach(func() {
+				adminClient.StartRestoreError = fmt.Errorf("timestamp is outside of the restorable window")
+				Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+			})
+
+			It("should not start the restore", func() {
+				_, err := reconcileRestore(restore)
+				Expect(err).To(MatchError(ContainSubstring("outside of the restorable window")))
+
+				status, err := adminClient.GetRestoreStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal("\n"))
+				Expect(getRestoreEvents(restore, "RestoreVersionNotResolved")).NotTo(BeEmpty())
+			})
+		})
+	})
+
+	When("checking the destination cluster before starting the restore", func() {
+		var result reconcile.Result
+		var reconcileErr error
+
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+			result, reconcileErr = restoreReconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(restore)})
+			Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+		})
+
+		When("the destination cluster is empty", func() {
+			It("should start the restore", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(restore.Status.Running).To(BeTrue())
+			})
+		})
+
+		When("the destination cluster is unavailable", func() {
+			BeforeEach(func() {
+				status, err := adminClient.GetStatus()
+				Expect(err).NotTo(HaveOccurred())
+				status.Client.DatabaseStatus.Available = false
+				adminClient.FrozenStatus = status
+			})
+
+			It("should not start the restore", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(restorePreflightInterval))
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(getRestoreEvents(restore, "DestinationClusterUnavailable")).To(HaveLen(1))
+
+				status, err := adminClient.GetRestoreStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal("\n"))
+			})
+		})
+
+		When("the destination cluster is running another restore with the same tag", func() {
+			otherURL := "blobstore://test@test-service:443/other-backup?bucket=fdb-backups"
+
+			BeforeEach(func() {
+				Expect(adminClient.StartRestore(otherURL, nil, 0)).NotTo(HaveOccurred())
+			})
+
+			It("should not start the restore", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(restorePreflightInterval))
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(getRestoreEvents(restore, "RestoreAlreadyRunning")).To(HaveLen(1))
+
+				status, err := adminClient.GetRestoreStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal(otherURL + "\n"))
+			})
+		})
+
+		When("the destination cluster contains data", func() {
+			BeforeEach(func() {
+				adminClient.DatabaseKeys = []string{"\x15user1/data"}
+			})
+
+			It("should not start the restore", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(restorePreflightInterval))
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(getRestoreEvents(restore, "DestinationNotEmpty")).To(HaveLen(1))
+
+				status, err := adminClient.GetRestoreStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal("\n"))
+			})
+
+			When("the data is outside of the restored key ranges", func() {
+				BeforeEach(func() {
+					restore.Spec.KeyRanges = []fdbv1beta2.FoundationDBKeyRange{
+						{Start: `\x15user2/`, End: `\x15user3/`},
+					}
+				})
+
+				It("should start the restore", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(restore.Status.Running).To(BeTrue())
+				})
+			})
+
+			When("a non-empty destination is allowed", func() {
+				BeforeEach(func() {
+					restore.Spec.AllowNonEmptyDestination = true
+				})
+
+				It("should start the restore", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(restore.Status.Running).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	When("aborting a restore", func() {
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+
+			_, err := reconcileRestore(restore)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+		})
+
+		It("should add the finalizer", func() {
+			Expect(restore.Finalizers).To(ContainElement(fdbv1beta2.RestoreAbortFinalizer))
+		})
+
+		When("the restore spec requests the abort", func() {
+			BeforeEach(func() {
+				restore.Spec.Abort = true
+				Expect(k8sClient.Update(context.TODO(), restore)).NotTo(HaveOccurred())
+
+				_, err := reconcileRestore(restore)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+			})
+
+			It("should abort the restore", func() {
+				Expect(adminClient.RestoreAborts).To(Equal(1))
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(restore.Status.State).To(Equal(fdbv1beta2.RestoreStateAborted))
+				Expect(getRestoreEvents(restore, "RestoreAborted")).To(HaveLen(1))
+			})
+		})
+
+		When("the restore is deleted", func() {
+			var reconcileErr error
+
+			JustBeforeEach(func() {
+				Expect(k8sClient.Delete(context.TODO(), restore)).NotTo(HaveOccurred())
+				_, reconcileErr = reconcileRestore(restore)
+			})
+
+			When("the restore is running", func() {
+				It("should abort the restore and remove the restore", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(adminClient.RestoreAborts).To(Equal(1))
+
+					err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(restore), &fdbv1beta2.FoundationDBRestore{})
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the restore is completed", func() {
+				BeforeEach(func() {
+					adminClient.RestoreProgress = []fdbv1beta2.FoundationDBLiveRestoreStatus{
+						{State: "completed", BlocksCompleted: 10, BlocksTotal: 10},
+					}
+				})
+
+				It("should not abort the restore and remove the restore", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(adminClient.RestoreAborts).To(BeZero())
+
+					err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(restore), &fdbv1beta2.FoundationDBRestore{})
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the running restore was started by another restore", func() {
+				BeforeEach(func() {
+					adminClient.RestoreProgress = []fdbv1beta2.FoundationDBLiveRestoreStatus{
+						{State: "running", URL: "blobstore://test@test-service:443/other-backup?bucket=fdb-backups"},
+					}
+				})
+
+				It("should not abort the restore and remove the restore", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(adminClient.RestoreAborts).To(BeZero())
+
+					err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(restore), &fdbv1beta2.FoundationDBRestore{})
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the restore was already completed", func() {
+				BeforeEach(func() {
+					restore.Status.Running = false
+					restore.Status.State = fdbv1beta2.RestoreStateCompleted
+					Expect(k8sClient.Status().Update(context.TODO(), restore)).NotTo(HaveOccurred())
+				})
+
+				It("should not abort the running restore and remove the restore", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(adminClient.RestoreAborts).To(BeZero())
+
+					err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(restore), &fdbv1beta2.FoundationDBRestore{})
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+
+			When("the cluster is unreachable", func() {
+				BeforeEach(func() {
+					adminClient.MockError(fmt.Errorf("mocked"))
+				})
+
+				AfterEach(func() {
+					adminClient.MockError(nil)
+				})
+
+				When("the timeout is not exceeded", func() {
+					It("should keep the restore with the finalizer", func() {
+						Expect(reconcileErr).To(HaveOccurred())
+						Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+						Expect(restore.DeletionTimestamp.IsZero()).To(BeFalse())
+						Expect(restore.Finalizers).To(ContainElement(fdbv1beta2.RestoreAbortFinalizer))
+					})
+				})
+
+				When("the timeout is exceeded", func() {
+					BeforeEach(func() {
+						restore.Spec.AbortTimeoutSeconds = pointer.Int(0)
+						Expect(k8sClient.Update(context.TODO(), restore)).NotTo(HaveOccurred())
+					})
+
+					It("should remove the finalizer and the restore", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(adminClient.RestoreAborts).To(BeZero())
+
+						err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(restore), &fdbv1beta2.FoundationDBRestore{})
+						Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					})
+				})
+			})
+		})
 	})
 })
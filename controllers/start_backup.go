@@ -24,6 +24,7 @@ import (
 	"context"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
 )
 
 // startBackup provides a reconciliation step for starting a new backup.
@@ -42,10 +43,27 @@ func (s startBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconci
 	}
 	defer adminClient.Close()
 
-	err = adminClient.StartBackup(backup.BackupURL(), backup.SnapshotPeriodSeconds())
+	blobCredentialsPath, cleanup, err := writeBlobCredentials(ctx, r, backup.Namespace, backup.Spec.BlobStoreConfiguration)
 	if err != nil {
 		return &requeue{curError: err}
 	}
+	defer cleanup()
+
+	err = adminClient.StartBackupWithTag(backup.BackupURL(), backup.BackupTag(), backup.SnapshotPeriodSeconds(), blobCredentialsPath, backup.Spec.KeyRanges)
+	if err != nil {
+		metrics.RecordBackupOperationFailure(backup.Namespace, backup.Name, "start")
+		// Failures to start the backup are mostly caused by issues with the blobstore, e.g. an unreachable blobstore
+		// or missing credentials. Retrying those immediately won't help, so the requeue is delayed.
+		return &requeue{curError: err, delay: backupOperationRetryDelay, delayedRequeue: true}
+	}
+
+	// The live status doesn't contain the key ranges of the backup, so we keep track of the submitted key ranges to
+	// detect changes in the spec.
+	backup.Status.KeyRanges = backup.Spec.KeyRanges
+	// A backup that was discontinued to change the key ranges is running again.
+	if backup.Status.State == fdbv1beta2.BackupStateRestarting {
+		backup.Status.State = fdbv1beta2.BackupStateRunning
+	}
 
 	return nil
 }
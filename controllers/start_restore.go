@@ -25,6 +25,10 @@ import (
 	"strings"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 )
 
 // startRestore provides a reconciliation step for starting a new restore.
@@ -33,6 +37,10 @@ type startRestore struct {
 
 // reconcile runs the reconciler's work.
 func (s startRestore) reconcile(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore) *requeue {
+	if restore.Spec.Abort {
+		return nil
+	}
+
 	adminClient, err := r.adminClientForRestore(ctx, restore)
 	if err != nil {
 		return &requeue{curError: err}
@@ -45,12 +53,16 @@ func (s startRestore) reconcile(ctx context.Context, r *FoundationDBRestoreRecon
 	}
 
 	if len(strings.TrimSpace(status)) == 0 {
-		err = adminClient.StartRestore(restore.BackupURL(), restore.Spec.KeyRanges)
+		err = submitRestore(ctx, r, adminClient, restore)
 		if err != nil {
 			return &requeue{curError: err}
 		}
 
 		restore.Status.Running = true
+		restore.Status.KeyRanges = restore.Spec.KeyRanges
+		// The version of a restore at a timestamp is resolved by fdbrestore, in this case the version will be
+		// updated from the restore status.
+		restore.Status.RestoreVersion = pointer.Int64Deref(restore.Spec.RestoreVersion, 0)
 		err = r.updateOrApply(ctx, restore)
 		if err != nil {
 			return &requeue{curError: err}
@@ -59,3 +71,30 @@ func (s startRestore) reconcile(ctx context.Context, r *FoundationDBRestoreRecon
 
 	return nil
 }
+
+// submitRestore starts the restore. If the restore defines a timestamp, fdbrestore resolves the timestamp to a version
+// based on the version history of the source cluster. If neither a version nor a timestamp is defined, the latest
+// restorable version will be restored.
+func submitRestore(ctx context.Context, r *FoundationDBRestoreReconciler, adminClient fdbadminclient.AdminClient, restore *fdbv1beta2.FoundationDBRestore) error {
+	if restore.Spec.RestoreTimestamp == nil {
+		return adminClient.StartRestore(restore.BackupURL(), restore.Spec.KeyRanges, pointer.Int64Deref(restore.Spec.RestoreVersion, 0))
+	}
+
+	sourceClusterName := restore.Spec.SourceClusterName
+	if sourceClusterName == "" {
+		sourceClusterName = restore.Spec.DestinationClusterName
+	}
+
+	sourceCluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: sourceClusterName}, sourceCluster)
+	if err != nil {
+		return err
+	}
+
+	err = adminClient.StartRestoreAtTimestamp(restore.BackupURL(), restore.Spec.KeyRanges, restore.Spec.RestoreTimestamp.Time, sourceCluster)
+	if err != nil {
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "RestoreVersionNotResolved", err.Error())
+	}
+
+	return err
+}
@@ -24,6 +24,7 @@ import (
 	"context"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
 )
 
 // stopBackup provides a reconciliation step for stopping backup.
@@ -32,7 +33,7 @@ type stopBackup struct {
 
 // reconcile runs the reconciler's work.
 func (s stopBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
-	if backup.ShouldRun() || backup.Status.BackupDetails == nil || !backup.Status.BackupDetails.Running {
+	if backup.ShouldRun() || backup.ShouldBeAborted() || backup.Status.BackupDetails == nil || !backup.Status.BackupDetails.Running {
 		return nil
 	}
 
@@ -42,8 +43,9 @@ func (s stopBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconcil
 	}
 	defer adminClient.Close()
 
-	err = adminClient.StopBackup(backup.BackupURL())
+	err = adminClient.StopBackupWithTag(backup.BackupTag())
 	if err != nil {
+		metrics.RecordBackupOperationFailure(backup.Namespace, backup.Name, "stop")
 		return &requeue{curError: err}
 	}
 
@@ -77,7 +77,7 @@ var _ = BeforeSuite(func() {
 	Expect(fdbv1beta2.AddToScheme(scheme.Scheme)).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:scheme
-	k8sClient = mockclient.NewMockClient(scheme.Scheme)
+	k8sClient = mockclient.NewMockClientWithHooksAndIndexes(scheme.Scheme, nil, nil, true)
 
 	clusterReconciler = createTestClusterReconciler()
 
@@ -141,8 +141,11 @@ func reconcileRestore(restore *fdbv1beta2.FoundationDBRestore) (reconcile.Result
 func reconcileObject(reconciler reconcile.Reconciler, metadata metav1.ObjectMeta, requeueLimit int) (reconcile.Result, error) {
 	attempts := requeueLimit + 1
 	result := reconcile.Result{Requeue: true}
+	// The cluster reconciler only sets the delay for delayed requeues, those requeues must be handled like any other
+	// requeue to reconcile the cluster completely.
+	_, isClusterReconciler := reconciler.(*FoundationDBClusterReconciler)
 	var err error
-	for result.Requeue && attempts > 0 {
+	for (result.Requeue || isClusterReconciler && result.RequeueAfter > 0) && attempts > 0 {
 		globalControllerLogger.Info("Running test reconciliation", "Attempts", attempts)
 		attempts--
 
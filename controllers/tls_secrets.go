@@ -0,0 +1,246 @@
+/*
+ * tls_secrets.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// secretNamesIndexField defines the field that is used to index the FoundationDBClusters by the secrets referenced in
+// the Pod templates.
+const secretNamesIndexField = "spec.processes.podTemplate.secretNames"
+
+// tlsSecretSyncPeriod defines how long the operator waits after observing a rotated secret before the processes are
+// bounced. This gives the kubelet the chance to update the mounted files.
+const tlsSecretSyncPeriod = 2 * time.Minute
+
+// getSecretNamesFromPodSpec returns the sorted names of all secrets that are mounted as volume in the provided Pod spec.
+func getSecretNamesFromPodSpec(spec *corev1.PodSpec) []string {
+	secretNames := map[string]fdbv1beta2.None{}
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil {
+			secretNames[volume.Secret.SecretName] = fdbv1beta2.None{}
+		}
+
+		if volume.Projected == nil {
+			continue
+		}
+
+		for _, source := range volume.Projected.Sources {
+			if source.Secret != nil {
+				secretNames[source.Secret.Name] = fdbv1beta2.None{}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(secretNames))
+	for secretName := range secretNames {
+		result = append(result, secretName)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// getSecretNamesForCluster returns the names of all secrets that are referenced in the Pod templates of the cluster.
+func getSecretNamesForCluster(cluster *fdbv1beta2.FoundationDBCluster) []string {
+	secretNames := map[string]fdbv1beta2.None{}
+	for _, settings := range cluster.Spec.Processes {
+		if settings.PodTemplate == nil {
+			continue
+		}
+
+		for _, secretName := range getSecretNamesFromPodSpec(&settings.PodTemplate.Spec) {
+			secretNames[secretName] = fdbv1beta2.None{}
+		}
+	}
+
+	result := make([]string, 0, len(secretNames))
+	for secretName := range secretNames {
+		result = append(result, secretName)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// findFoundationDBClusterForSecret will return all FoundationDBClusters in the namespace of the secret that reference
+// the secret in one of their Pod templates.
+func (r *FoundationDBClusterReconciler) findFoundationDBClusterForSecret(secret client.Object) []reconcile.Request {
+	logger := r.Log.WithValues("namespace", secret.GetNamespace(), "secret", secret.GetName())
+	clusters := &fdbv1beta2.FoundationDBClusterList{}
+
+	err := r.List(context.Background(), clusters,
+		client.MatchingFieldsSelector{
+			Selector: fields.OneTermEqualSelector(secretNamesIndexField, secret.GetName()),
+		},
+		client.InNamespace(secret.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "Processing findFoundationDBClusterForSecret could not fetch clusters for secret")
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		logger.V(1).Info("Processing findFoundationDBClusterForSecret, found cluster that needs an update", "clusterName", cluster.Name)
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      cluster.Name,
+				Namespace: cluster.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+// getTLSSecretsHash returns the hash of the data of all secrets that are mounted in the provided Pod. The secrets
+// cache is used to prevent fetching the same secret multiple times. Secrets that don't exist are ignored.
+func (r *FoundationDBClusterReconciler) getTLSSecretsHash(ctx context.Context, pod *corev1.Pod, secrets map[string]*corev1.Secret) (string, error) {
+	secretNames := getSecretNamesFromPodSpec(&pod.Spec)
+	if len(secretNames) == 0 {
+		return "", nil
+	}
+
+	secretData := make(map[string]map[string][]byte, len(secretNames))
+	for _, secretName := range secretNames {
+		secret, ok := secrets[secretName]
+		if !ok {
+			secret = &corev1.Secret{}
+			err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: secretName}, secret)
+			if err != nil {
+				if !k8serrors.IsNotFound(err) {
+					return "", err
+				}
+
+				secret = nil
+			}
+
+			secrets[secretName] = secret
+		}
+
+		if secret == nil {
+			continue
+		}
+
+		secretData[secretName] = secret.Data
+	}
+
+	return internal.GetJSONHash(secretData)
+}
+
+// getProcessesWithRotatedTLSSecrets returns the addresses of the processes that should be bounced because the secrets
+// mounted in their Pods were rotated, together with the Pods that must be marked as updated once the processes were
+// bounced. The processes are only returned once the tlsSecretSyncPeriod has passed since the rotation was observed.
+// The returned bool will be true if at least one Pod is waiting for the tlsSecretSyncPeriod.
+func (r *FoundationDBClusterReconciler) getProcessesWithRotatedTLSSecrets(ctx context.Context, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, addressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress) ([]fdbv1beta2.ProcessAddress, []*corev1.Pod, bool, error) {
+	addresses := make([]fdbv1beta2.ProcessAddress, 0)
+	var rotatedPods []*corev1.Pod
+	var waiting bool
+	secrets := map[string]*corev1.Secret{}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass == fdbv1beta2.ProcessClassTest || processGroup.IsMarkedForRemoval() || cluster.SkipProcessGroup(processGroup) {
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.ResourcesTerminating) != nil {
+			continue
+		}
+
+		pod, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
+		// Missing Pods are handled by other reconcilers.
+		if err != nil {
+			continue
+		}
+
+		secretsHash, err := r.getTLSSecretsHash(ctx, pod, secrets)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		if pod.ObjectMeta.Annotations[fdbv1beta2.LastTLSSecretsKey] == secretsHash {
+			continue
+		}
+
+		if pod.ObjectMeta.Annotations == nil {
+			pod.ObjectMeta.Annotations = map[string]string{}
+		}
+
+		// If the Pod has no hash annotation yet, the processes were started with the current secrets.
+		if pod.ObjectMeta.Annotations[fdbv1beta2.LastTLSSecretsKey] == "" {
+			pod.ObjectMeta.Annotations[fdbv1beta2.LastTLSSecretsKey] = secretsHash
+			err = r.PodLifecycleManager.UpdateMetadata(ctx, r, cluster, pod)
+			if err != nil {
+				return nil, nil, false, err
+			}
+
+			continue
+		}
+
+		outdatedSince, ok := pod.ObjectMeta.Annotations[fdbv1beta2.OutdatedTLSSecretsKey]
+		if !ok {
+			logger.Info("Observed rotated secrets for process group", "processGroupID", processGroup.ProcessGroupID)
+			pod.ObjectMeta.Annotations[fdbv1beta2.OutdatedTLSSecretsKey] = strconv.FormatInt(time.Now().Unix(), 10)
+			err = r.PodLifecycleManager.UpdateMetadata(ctx, r, cluster, pod)
+			if err != nil {
+				return nil, nil, false, err
+			}
+
+			waiting = true
+			continue
+		}
+
+		timestamp, err := strconv.ParseInt(outdatedSince, 10, 64)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		if time.Since(time.Unix(timestamp, 0)) < tlsSecretSyncPeriod {
+			waiting = true
+			continue
+		}
+
+		if addressMap[processGroup.ProcessGroupID] == nil {
+			continue
+		}
+
+		addresses = append(addresses, addressMap[processGroup.ProcessGroupID]...)
+		pod.ObjectMeta.Annotations[fdbv1beta2.LastTLSSecretsKey] = secretsHash
+		delete(pod.ObjectMeta.Annotations, fdbv1beta2.OutdatedTLSSecretsKey)
+		rotatedPods = append(rotatedPods, pod)
+	}
+
+	return addresses, rotatedPods, waiting, nil
+}
@@ -0,0 +1,197 @@
+/*
+ * tls_secrets_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var _ = Describe("tls_secrets", func() {
+	When("getting the secret names for a cluster", func() {
+		It("should return the secrets from all Pod templates", func() {
+			cluster := &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					Processes: map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+						fdbv1beta2.ProcessClassGeneral: {
+							PodTemplate: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Volumes: []corev1.Volume{
+										{
+											Name: "fdb-certs",
+											VolumeSource: corev1.VolumeSource{
+												Secret: &corev1.SecretVolumeSource{SecretName: "fdb-certs"},
+											},
+										},
+										{
+											Name:         "data",
+											VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+										},
+									},
+								},
+							},
+						},
+						fdbv1beta2.ProcessClassStorage: {
+							PodTemplate: &corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Volumes: []corev1.Volume{
+										{
+											Name: "projected",
+											VolumeSource: corev1.VolumeSource{
+												Projected: &corev1.ProjectedVolumeSource{
+													Sources: []corev1.VolumeProjection{
+														{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "sidecar-certs"}}},
+														{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "fdb-certs"}}},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						fdbv1beta2.ProcessClassLog: {},
+					},
+				},
+			}
+
+			Expect(getSecretNamesForCluster(cluster)).To(ConsistOf("fdb-certs", "sidecar-certs"))
+		})
+	})
+
+	When("the secrets of a cluster are rotated", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var secret *corev1.Secret
+		var adminClient *mock.AdminClient
+		var result *requeue
+
+		getPods := func() []corev1.Pod {
+			pods := &corev1.PodList{}
+			Expect(k8sClient.List(context.TODO(), pods, client.InNamespace(cluster.Namespace), client.MatchingLabels(cluster.GetMatchLabels()))).NotTo(HaveOccurred())
+			return pods.Items
+		}
+
+		BeforeEach(func() {
+			clusterReconciler.EnableTLSSecretWatch = true
+
+			cluster = internal.CreateDefaultCluster()
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fdb-certs",
+					Namespace: cluster.Namespace,
+				},
+				Data: map[string][]byte{
+					"tls.crt": []byte("initial"),
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), secret)).NotTo(HaveOccurred())
+
+			cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+				fdbv1beta2.ProcessClassGeneral: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Volumes: []corev1.Volume{
+								{
+									Name: "fdb-certs",
+									VolumeSource: corev1.VolumeSource{
+										Secret: &corev1.SecretVolumeSource{SecretName: secret.Name},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+			var err error
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			clusterReconciler.EnableTLSSecretWatch = false
+		})
+
+		It("should store the hash of the secrets on all Pods", func() {
+			for _, pod := range getPods() {
+				Expect(pod.Annotations).To(HaveKey(fdbv1beta2.LastTLSSecretsKey))
+				Expect(pod.Annotations).NotTo(HaveKey(fdbv1beta2.OutdatedTLSSecretsKey))
+			}
+		})
+
+		When("the secret data changes", func() {
+			BeforeEach(func() {
+				secret.Data["tls.crt"] = []byte("rotated")
+				Expect(k8sClient.Update(context.TODO(), secret)).NotTo(HaveOccurred())
+
+				result = bounceProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			})
+
+			It("should wait for the secrets to be synced", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.message).To(Equal("Waiting for rotated secrets to be synced to the Pods"))
+				Expect(adminClient.KilledAddresses).To(BeEmpty())
+
+				for _, pod := range getPods() {
+					Expect(pod.Annotations).To(HaveKey(fdbv1beta2.OutdatedTLSSecretsKey))
+				}
+			})
+
+			When("the sync period has passed", func() {
+				var initialHashes map[string]string
+
+				BeforeEach(func() {
+					initialHashes = map[string]string{}
+					for _, pod := range getPods() {
+						initialHashes[pod.Name] = pod.Annotations[fdbv1beta2.LastTLSSecretsKey]
+						pod.Annotations[fdbv1beta2.OutdatedTLSSecretsKey] = strconv.FormatInt(time.Now().Add(-2*tlsSecretSyncPeriod).Unix(), 10)
+						Expect(k8sClient.Update(context.TODO(), &pod)).NotTo(HaveOccurred())
+					}
+
+					result = bounceProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+				})
+
+				It("should bounce the processes and update the hashes", func() {
+					Expect(result).To(BeNil())
+					Expect(adminClient.KilledAddresses).To(HaveLen(len(cluster.Status.ProcessGroups)))
+
+					for _, pod := range getPods() {
+						Expect(pod.Annotations).NotTo(HaveKey(fdbv1beta2.OutdatedTLSSecretsKey))
+						Expect(pod.Annotations[fdbv1beta2.LastTLSSecretsKey]).NotTo(Equal(initialHashes[pod.Name]))
+					}
+				})
+			})
+		})
+	})
+})
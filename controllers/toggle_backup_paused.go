@@ -27,7 +27,9 @@ import (
 )
 
 // toggleBackupPaused provides a reconciliation step for pausing an unpausing
-// backups.
+// backups. Pausing the backup agents is not limited to a tag, so this will
+// pause or resume all backups of the cluster. Backups for the same cluster
+// that request a different paused state are rejected during the validation.
 type toggleBackupPaused struct{}
 
 // reconcile runs the reconciler's work.
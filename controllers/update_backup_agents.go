@@ -33,6 +33,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -56,7 +57,7 @@ func (u updateBackupAgents) reconcile(ctx context.Context, r *FoundationDBBackup
 		}
 	}
 
-	deployment, err := internal.GetBackupDeployment(backup)
+	deployment, err := r.getBackupDeployment(ctx, backup)
 	if err != nil {
 		r.Recorder.Event(backup, corev1.EventTypeWarning, "GetBackupDeployment", err.Error())
 		return &requeue{curError: err}
@@ -95,3 +96,61 @@ func (u updateBackupAgents) reconcile(ctx context.Context, r *FoundationDBBackup
 
 	return nil
 }
+
+// getBackupDeployment returns the desired deployment for the backup agents. If a blob credentials secret is defined,
+// the hash of the credentials will be stored in the pod template, so that a rotation of the credentials secret will
+// roll the backup agent pods. If the agent count is derived from the size of the cluster, the desired agent count
+// will be updated in the status of the backup. If the backup doesn't define whether the unified image should be used,
+// the image type of the cluster will be used.
+func (r *FoundationDBBackupReconciler) getBackupDeployment(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deployment, error) {
+	desiredBackup := backup
+	if backup.UsesAgentScaling() || backup.Spec.UseUnifiedImage == nil {
+		cluster := &fdbv1beta2.FoundationDBCluster{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.ClusterName}, cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		if backup.UsesAgentScaling() {
+			backup.Status.DesiredAgentCount, err = backup.GetDesiredAgentCountForCluster(cluster)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if backup.Spec.UseUnifiedImage == nil {
+			desiredBackup = backup.DeepCopy()
+			desiredBackup.Spec.UseUnifiedImage = pointer.Bool(cluster.GetUseUnifiedImage())
+		}
+	}
+
+	deployment, err := internal.GetBackupDeployment(desiredBackup)
+	if err != nil || deployment == nil || backup.BlobCredentialsPath() == "" {
+		return deployment, err
+	}
+
+	credentialsSecret := backup.Spec.BlobStoreConfiguration.CredentialsSecret
+	secret := &corev1.Secret{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: credentialsSecret.Name}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialsHash, err := internal.GetJSONHash(secret.Data[credentialsSecret.Key])
+	if err != nil {
+		return nil, err
+	}
+
+	if deployment.Spec.Template.ObjectMeta.Annotations == nil {
+		deployment.Spec.Template.ObjectMeta.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.ObjectMeta.Annotations[fdbv1beta2.LastBlobCredentialsKey] = credentialsHash
+
+	specHash, err := internal.GetJSONHash(deployment.Spec)
+	if err != nil {
+		return nil, err
+	}
+	deployment.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = specHash
+
+	return deployment, nil
+}
@@ -22,12 +22,19 @@ package controllers
 
 import (
 	"context"
+	"math"
+	"time"
+
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/metrics"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -39,11 +46,15 @@ type updateBackupStatus struct{}
 func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
 	status := fdbv1beta2.FoundationDBBackupStatus{}
 	status.Generations.Reconciled = backup.Status.Generations.Reconciled
+	status.LastExpiration = backup.Status.LastExpiration
 
-	desiredBackupDeployment, err := internal.GetBackupDeployment(backup)
+	desiredBackupDeployment, err := r.getBackupDeployment(ctx, backup)
 	if err != nil {
 		return &requeue{curError: err}
 	}
+	if backup.UsesAgentScaling() {
+		status.DesiredAgentCount = backup.Status.DesiredAgentCount
+	}
 
 	currentBackupDeployment := &appsv1.Deployment{}
 	err = r.Get(ctx, client.ObjectKey{Namespace: backup.Namespace, Name: internal.GetBackupDeploymentName(backup)}, currentBackupDeployment)
@@ -85,18 +96,45 @@ func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackup
 	}
 	defer adminClient.Close()
 
-	liveStatus, err := adminClient.GetBackupStatus()
+	liveStatus, err := adminClient.GetBackupStatusWithTag(backup.BackupTag())
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
+	// Make sure we only report the status of the backup with the tag of this backup, otherwise multiple backups for
+	// the same cluster would report the same backup.
+	if liveStatus.Tag != "" && liveStatus.Tag != backup.BackupTag() {
+		liveStatus = &fdbv1beta2.FoundationDBLiveBackupStatus{
+			BackupAgentsPaused: liveStatus.BackupAgentsPaused,
+		}
+	}
+
+	now := time.Now()
 	status.BackupDetails = &fdbv1beta2.FoundationDBBackupStatusBackupDetails{
 		URL:                   liveStatus.DestinationURL,
 		Running:               liveStatus.Status.Running,
 		Paused:                liveStatus.BackupAgentsPaused,
 		SnapshotPeriodSeconds: liveStatus.SnapshotIntervalSeconds,
+		Lag:                   getBackupLag(liveStatus, now),
+	}
+	status.State = getBackupState(backup, liveStatus)
+
+	if status.BackupDetails.Running {
+		status.KeyRanges = backup.Status.KeyRanges
 	}
 
+	if backup.DescribeIntervalSeconds() > 0 {
+		status.RestorabilityDetails = backup.Status.RestorabilityDetails
+	}
+
+	// Describing the backup data requires reading from the blob store, so we only update the restorability details
+	// once per describe interval.
+	if liveStatus.DestinationURL != "" && backup.NeedsDescription(now) {
+		status.RestorabilityDetails = describeBackup(ctx, r, adminClient, backup, now)
+	}
+
+	recordBackupMetrics(backup, status, liveStatus)
+
 	originalStatus := backup.Status.DeepCopy()
 
 	backup.Status = status
@@ -116,3 +154,137 @@ func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackup
 
 	return nil
 }
+
+// recordBackupMetrics updates the Prometheus metrics of the backup with the observed status.
+func recordBackupMetrics(backup *fdbv1beta2.FoundationDBBackup, status fdbv1beta2.FoundationDBBackupStatus, liveStatus *fdbv1beta2.FoundationDBLiveBackupStatus) {
+	metrics.RecordBackupStatus(
+		backup.Namespace,
+		backup.Name,
+		status.BackupDetails.Running,
+		status.BackupDetails.Running && status.BackupDetails.Paused,
+		status.AgentCount,
+		backup.GetDesiredAgentCount(),
+	)
+
+	// The latest restorable point is taken from the live status, so the metric doesn't depend on the describe interval.
+	var latestRestorablePoint *time.Time
+	if restorableTime := getRestorablePointTime(liveStatus.LatestRestorablePoint); restorableTime != nil {
+		latestRestorablePoint = &restorableTime.Time
+	}
+	metrics.RecordBackupRestorablePoint(backup.Namespace, backup.Name, latestRestorablePoint)
+}
+
+// getBackupState returns the observed state of the backup. The live status doesn't distinguish between a stopped and an
+// aborted backup, so the aborted state is kept until the backup is started again. The restarting state is kept until
+// the backup was started again with the new key ranges.
+func getBackupState(backup *fdbv1beta2.FoundationDBBackup, liveStatus *fdbv1beta2.FoundationDBLiveBackupStatus) fdbv1beta2.BackupState {
+	if backup.Status.State == fdbv1beta2.BackupStateRestarting && backup.ShouldRun() {
+		return fdbv1beta2.BackupStateRestarting
+	}
+
+	if liveStatus.Status.Running {
+		if liveStatus.BackupAgentsPaused {
+			return fdbv1beta2.BackupStatePaused
+		}
+
+		return fdbv1beta2.BackupStateRunning
+	}
+
+	if backup.Status.State == fdbv1beta2.BackupStateAborted {
+		return fdbv1beta2.BackupStateAborted
+	}
+
+	if liveStatus.DestinationURL == "" {
+		return ""
+	}
+
+	return fdbv1beta2.BackupStateStopped
+}
+
+// getBackupLag returns the time between the latest restorable point of the backup and now, truncated to seconds. If
+// the backup has no restorable point yet, nil will be returned.
+func getBackupLag(liveStatus *fdbv1beta2.FoundationDBLiveBackupStatus, now time.Time) *metav1.Duration {
+	restorableTime := getRestorablePointTime(liveStatus.LatestRestorablePoint)
+	if restorableTime == nil {
+		return nil
+	}
+
+	lag := now.Sub(restorableTime.Time).Truncate(time.Second)
+	if lag < 0 {
+		lag = 0
+	}
+
+	return &metav1.Duration{Duration: lag}
+}
+
+// describeBackup describes the backup data and returns the updated restorability details. The attempt is recorded even
+// if it failed, to make sure the backup is only described once per describe interval. In this case the previous details
+// are kept and the error is recorded.
+func describeBackup(ctx context.Context, r *FoundationDBBackupReconciler, adminClient fdbadminclient.AdminClient, backup *fdbv1beta2.FoundationDBBackup, now time.Time) *fdbv1beta2.BackupRestorabilityDetails {
+	blobCredentialsPath, cleanup, err := writeBlobCredentials(ctx, r, backup.Namespace, backup.Spec.BlobStoreConfiguration)
+	if err == nil {
+		defer cleanup()
+
+		var description *fdbv1beta2.FoundationDBBackupDescription
+		description, err = adminClient.DescribeBackup(backup.BackupURL(), blobCredentialsPath)
+		if err == nil {
+			details := getRestorabilityDetails(description, now)
+			details.LastAttempt = metav1.NewTime(now)
+			return details
+		}
+	}
+
+	// An error here should not block the reconciliation, the restorability details will be updated after the next
+	// describe interval.
+	globalControllerLogger.Error(err, "Error describing backup", "namespace", backup.Namespace, "backup", backup.Name)
+	r.Recorder.Event(backup, corev1.EventTypeWarning, "DescribeBackup", err.Error())
+
+	details := &fdbv1beta2.BackupRestorabilityDetails{}
+	if backup.Status.RestorabilityDetails != nil {
+		details = backup.Status.RestorabilityDetails.DeepCopy()
+	}
+	details.LastAttempt = metav1.NewTime(now)
+	details.Error = err.Error()
+
+	return details
+}
+
+// getRestorabilityDetails returns the restorability details for the provided description of the backup data.
+func getRestorabilityDetails(description *fdbv1beta2.FoundationDBBackupDescription, now time.Time) *fdbv1beta2.BackupRestorabilityDetails {
+	details := &fdbv1beta2.BackupRestorabilityDetails{
+		LastUpdated:   metav1.NewTime(now),
+		Restorable:    description.Restorable,
+		SnapshotBytes: description.TotalSnapshotBytes,
+	}
+
+	if description.MaxRestorablePoint != nil {
+		details.LatestRestorableVersion = description.MaxRestorablePoint.Version
+		details.LatestRestorableTimestamp = getRestorablePointTime(description.MaxRestorablePoint)
+	}
+
+	var latestSnapshot *fdbv1beta2.FoundationDBBackupDescriptionSnapshot
+	for idx, snapshot := range description.Snapshots {
+		if latestSnapshot == nil || snapshot.Start.Version > latestSnapshot.Start.Version {
+			latestSnapshot = &description.Snapshots[idx]
+		}
+	}
+
+	if latestSnapshot != nil {
+		details.LatestSnapshotStartTimestamp = getRestorablePointTime(&latestSnapshot.Start)
+	}
+
+	return details
+}
+
+// getRestorablePointTime returns the time of the provided point of a backup. If the point has no timestamp, nil will
+// be returned.
+func getRestorablePointTime(point *fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint) *metav1.Time {
+	if point == nil || point.EpochSeconds <= 0 {
+		return nil
+	}
+
+	seconds, fraction := math.Modf(point.EpochSeconds)
+	pointTime := metav1.NewTime(time.Unix(int64(seconds), int64(fraction*float64(time.Second))))
+
+	return &pointTime
+}
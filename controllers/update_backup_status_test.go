@@ -0,0 +1,123 @@
+/*
+ * update_backup_status_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var _ = Describe("update_backup_status", func() {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable("calculating the backup lag", func(restorablePoint *fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint, expected *metav1.Duration) {
+		liveStatus := &fdbv1beta2.FoundationDBLiveBackupStatus{
+			LatestRestorablePoint: restorablePoint,
+		}
+
+		Expect(getBackupLag(liveStatus, now)).To(Equal(expected))
+	},
+		Entry("no restorable point is present",
+			nil,
+			nil,
+		),
+		Entry("the restorable point has no timestamp",
+			&fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{
+				Version: 1000,
+			},
+			nil,
+		),
+		Entry("the restorable point is 90 seconds old",
+			&fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{
+				Version:      1000,
+				EpochSeconds: float64(now.Add(-90 * time.Second).Unix()),
+			},
+			&metav1.Duration{Duration: 90 * time.Second},
+		),
+		Entry("the restorable point has a fractional timestamp",
+			&fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{
+				Version:      1000,
+				EpochSeconds: float64(now.Add(-10*time.Second).Unix()) + 0.25,
+			},
+			&metav1.Duration{Duration: 9 * time.Second},
+		),
+		Entry("the restorable point is in the future",
+			&fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{
+				Version:      1000,
+				EpochSeconds: float64(now.Add(time.Minute).Unix()),
+			},
+			&metav1.Duration{Duration: 0},
+		),
+	)
+
+	DescribeTable("getting the restorability details", func(description *fdbv1beta2.FoundationDBBackupDescription, expected *fdbv1beta2.BackupRestorabilityDetails) {
+		Expect(getRestorabilityDetails(description, now)).To(Equal(expected))
+	},
+		Entry("the backup has no data",
+			&fdbv1beta2.FoundationDBBackupDescription{},
+			&fdbv1beta2.BackupRestorabilityDetails{
+				LastUpdated: metav1.NewTime(now),
+			},
+		),
+		Entry("the backup has multiple snapshots",
+			&fdbv1beta2.FoundationDBBackupDescription{
+				Restorable: true,
+				Snapshots: []fdbv1beta2.FoundationDBBackupDescriptionSnapshot{
+					{
+						Start:      fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{Version: 3000, EpochSeconds: float64(now.Add(-1 * time.Hour).Unix())},
+						TotalBytes: 2048,
+					},
+					{
+						Start:      fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{Version: 1000, EpochSeconds: float64(now.Add(-24 * time.Hour).Unix())},
+						Restorable: true,
+						TotalBytes: 1024,
+					},
+				},
+				TotalSnapshotBytes: 3072,
+				MaxRestorablePoint: &fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{Version: 2500, EpochSeconds: float64(now.Add(-2 * time.Hour).Unix())},
+			},
+			&fdbv1beta2.BackupRestorabilityDetails{
+				LastUpdated:                  metav1.NewTime(now),
+				Restorable:                   true,
+				LatestRestorableTimestamp:    &metav1.Time{Time: time.Unix(now.Add(-2*time.Hour).Unix(), 0)},
+				LatestRestorableVersion:      2500,
+				LatestSnapshotStartTimestamp: &metav1.Time{Time: time.Unix(now.Add(-1*time.Hour).Unix(), 0)},
+				SnapshotBytes:                3072,
+			},
+		),
+		Entry("the restorable point has no timestamp",
+			&fdbv1beta2.FoundationDBBackupDescription{
+				Restorable:         true,
+				MaxRestorablePoint: &fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint{Version: 2500},
+			},
+			&fdbv1beta2.BackupRestorabilityDetails{
+				LastUpdated:             metav1.NewTime(now),
+				Restorable:              true,
+				LatestRestorableVersion: 2500,
+			},
+		),
+	)
+})
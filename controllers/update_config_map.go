@@ -23,6 +23,8 @@ package controllers
 import (
 	"context"
 	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -41,7 +43,7 @@ import (
 type updateConfigMap struct{}
 
 // reconcile runs the reconciler's work.
-func (u updateConfigMap) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbtypes.FoundationDBCluster, _ *fdbtypes.FoundationDBStatus, logger logr.Logger) *requeue {
+func (u updateConfigMap) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbtypes.FoundationDBCluster, _ *cachedDatabaseStatus, logger logr.Logger) *requeue {
 	configMap, err := internal.GetConfigMap(cluster)
 	if err != nil {
 		return &requeue{curError: err}
@@ -70,6 +72,20 @@ func (u updateConfigMap) reconcile(ctx context.Context, r *FoundationDBClusterRe
 	}
 
 	if !equality.Semantic.DeepEqual(existing.Data, configMap.Data) || !metadataCorrect {
+		debounce := cluster.GetConfigMapUpdateDebounceDuration()
+		if debounce > 0 {
+			remaining := getRemainingConfigMapDebounce(existing, debounce)
+			if remaining > 0 {
+				logger.Info("Delaying config map update", "remaining", remaining.String())
+				return &requeue{message: "Delaying config map update to coalesce changes", delay: remaining, delayedRequeue: true}
+			}
+
+			if existing.ObjectMeta.Annotations == nil {
+				existing.ObjectMeta.Annotations = map[string]string{}
+			}
+			existing.ObjectMeta.Annotations[fdbtypes.LastConfigMapUpdateKey] = strconv.FormatInt(time.Now().Unix(), 10)
+		}
+
 		logger.Info("Updating config map")
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpdatingConfigMap", "")
 		existing.Data = configMap.Data
@@ -81,3 +97,19 @@ func (u updateConfigMap) reconcile(ctx context.Context, r *FoundationDBClusterRe
 
 	return nil
 }
+
+// getRemainingConfigMapDebounce returns how long the config map update should be delayed, based on the timestamp of the
+// last update that is stored in the config map annotations.
+func getRemainingConfigMapDebounce(configMap *corev1.ConfigMap, debounce time.Duration) time.Duration {
+	lastUpdate, ok := configMap.ObjectMeta.Annotations[fdbtypes.LastConfigMapUpdateKey]
+	if !ok {
+		return 0
+	}
+
+	timestamp, err := strconv.ParseInt(lastUpdate, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return time.Until(time.Unix(timestamp, 0).Add(debounce))
+}
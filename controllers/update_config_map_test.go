@@ -0,0 +1,149 @@
+/*
+ * update_config_map_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var _ = Describe("update_config_map", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var configMap *corev1.ConfigMap
+
+	setCustomParameter := func(value string) {
+		settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+		settings.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{
+			fdbv1beta2.FoundationDBCustomParameter("knob_test=" + value),
+		}
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = settings
+	}
+
+	getConfigMap := func() *corev1.ConfigMap {
+		desired, err := internal.GetConfigMap(cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		current := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(desired), current)).NotTo(HaveOccurred())
+		return current
+	}
+
+	countUpdateEvents := func() int {
+		events := &corev1.EventList{}
+		Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+		count := 0
+		for _, event := range events.Items {
+			if event.InvolvedObject.UID == cluster.UID && event.Reason == "UpdatingConfigMap" {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+	})
+
+	When("no debounce is configured", func() {
+		It("should update the config map for every change", func() {
+			initialEvents := countUpdateEvents()
+			for _, value := range []string{"1", "2", "3"} {
+				setCustomParameter(value)
+				Expect(updateConfigMap{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)).To(BeNil())
+			}
+
+			Expect(countUpdateEvents() - initialEvents).To(Equal(3))
+			configMap = getConfigMap()
+			Expect(configMap.Annotations).NotTo(HaveKey(fdbv1beta2.LastConfigMapUpdateKey))
+		})
+	})
+
+	When("a debounce is configured", func() {
+		var initialEvents int
+
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.ConfigMapUpdateDebounceSeconds = pointer.Int(60)
+			initialEvents = countUpdateEvents()
+
+			setCustomParameter("1")
+			Expect(updateConfigMap{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)).To(BeNil())
+		})
+
+		It("should update the config map directly if no update was done before", func() {
+			Expect(countUpdateEvents() - initialEvents).To(Equal(1))
+			configMap = getConfigMap()
+			Expect(configMap.Annotations).To(HaveKey(fdbv1beta2.LastConfigMapUpdateKey))
+		})
+
+		When("multiple changes happen within the debounce window", func() {
+			var expectedData map[string]string
+
+			BeforeEach(func() {
+				for _, value := range []string{"2", "3"} {
+					setCustomParameter(value)
+					result := updateConfigMap{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+					Expect(result).NotTo(BeNil())
+					Expect(result.delayedRequeue).To(BeTrue())
+					Expect(result.delay).To(BeNumerically(">", 0))
+					Expect(result.delay).To(BeNumerically("<=", time.Minute))
+				}
+
+				desired, err := internal.GetConfigMap(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				expectedData = desired.Data
+			})
+
+			It("should not update the config map", func() {
+				Expect(countUpdateEvents() - initialEvents).To(Equal(1))
+				Expect(getConfigMap().Data).NotTo(Equal(expectedData))
+			})
+
+			When("the debounce window has passed", func() {
+				BeforeEach(func() {
+					configMap = getConfigMap()
+					configMap.Annotations[fdbv1beta2.LastConfigMapUpdateKey] = strconv.FormatInt(time.Now().Add(-2*time.Minute).Unix(), 10)
+					Expect(k8sClient.Update(context.TODO(), configMap)).NotTo(HaveOccurred())
+
+					Expect(updateConfigMap{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)).To(BeNil())
+				})
+
+				It("should apply the latest changes in a single update", func() {
+					Expect(countUpdateEvents() - initialEvents).To(Equal(2))
+					Expect(getConfigMap().Data).To(Equal(expectedData))
+				})
+			})
+		})
+	})
+})
@@ -34,6 +34,7 @@ import (
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // updateDatabaseConfiguration provides a reconciliation step for changing the
@@ -41,7 +42,9 @@ import (
 type updateDatabaseConfiguration struct{}
 
 // reconcile runs the reconciler's work.
-func (u updateDatabaseConfiguration) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (u updateDatabaseConfiguration) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	if !pointer.BoolDeref(cluster.Spec.AutomationOptions.ConfigureDatabase, true) {
 		return nil
 	}
@@ -58,6 +61,8 @@ func (u updateDatabaseConfiguration) reconcile(_ context.Context, r *FoundationD
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		cachedStatus.record(status)
 	}
 
 	initialConfig := !cluster.Status.Configured
@@ -113,6 +118,13 @@ func (u updateDatabaseConfiguration) reconcile(_ context.Context, r *FoundationD
 			}()
 		}
 
+		if !initialConfig && nextConfiguration.StorageEngine != currentConfiguration.StorageEngine {
+			err = startStorageEngineMigration(ctx, r, logger, cluster, currentConfiguration.StorageEngine, nextConfiguration.StorageEngine)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+		}
+
 		logger.Info("Configuring database", "current configuration", currentConfiguration, "desired configuration", desiredConfiguration)
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "ConfiguringDatabase",
 			fmt.Sprintf("Setting database configuration to `%s`", configurationString),
@@ -134,3 +146,34 @@ func (u updateDatabaseConfiguration) reconcile(_ context.Context, r *FoundationD
 
 	return nil
 }
+
+// startStorageEngineMigration records all storage process groups that use the current storage engine in the cluster
+// status. Changing the storage engine will only affect newly recruited storage servers, so those process groups will
+// be replaced to migrate the data to storage servers with the new storage engine.
+func startStorageEngineMigration(ctx context.Context, r *FoundationDBClusterReconciler, logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, from fdbv1beta2.StorageEngine, to fdbv1beta2.StorageEngine) error {
+	if cluster.Status.StorageEngineMigration != nil && cluster.Status.StorageEngineMigration.To == to {
+		return nil
+	}
+
+	pendingProcessGroups := make([]fdbv1beta2.ProcessGroupID, 0, len(cluster.Status.ProcessGroups))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		pendingProcessGroups = append(pendingProcessGroups, processGroup.ProcessGroupID)
+	}
+
+	logger.Info("Starting storage engine migration", "from", from, "to", to, "pendingProcessGroups", pendingProcessGroups)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "StorageEngineMigrationStarted",
+		fmt.Sprintf("Migrating storage engine from %s to %s by replacing %d storage process groups", from, to, len(pendingProcessGroups)))
+
+	cluster.Status.StorageEngineMigration = &fdbv1beta2.StorageEngineMigrationStatus{
+		From:                 from,
+		To:                   to,
+		StartTimestamp:       &metav1.Time{Time: time.Now()},
+		PendingProcessGroups: pendingProcessGroups,
+	}
+
+	return r.updateOrApply(ctx, cluster)
+}
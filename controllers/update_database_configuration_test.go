@@ -0,0 +1,136 @@
+/*
+ * update_database_configuration_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var _ = Describe("update_database_configuration", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("the storage engine is changed", func() {
+		var storageProcessGroups []fdbv1beta2.ProcessGroupID
+
+		BeforeEach(func() {
+			storageProcessGroups = nil
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage {
+					storageProcessGroups = append(storageProcessGroups, processGroup.ProcessGroupID)
+				}
+			}
+			Expect(storageProcessGroups).NotTo(BeEmpty())
+
+			cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineMemory2
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			result := updateDatabaseConfiguration{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			Expect(result).To(BeNil())
+			_, err := reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should change the storage engine and start the migration", func() {
+			Expect(adminClient.DatabaseConfiguration.StorageEngine).To(Equal(fdbv1beta2.StorageEngineMemory2))
+
+			migration := cluster.Status.StorageEngineMigration
+			Expect(migration).NotTo(BeNil())
+			Expect(migration.From).To(Equal(fdbv1beta2.StorageEngineSSD2))
+			Expect(migration.To).To(Equal(fdbv1beta2.StorageEngineMemory2))
+			Expect(migration.StartTimestamp).NotTo(BeNil())
+			Expect(migration.PendingProcessGroups).To(ConsistOf(storageProcessGroups))
+		})
+
+		It("should mark the storage process groups for replacement", func() {
+			Expect(replaceMisconfiguredProcessGroups{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)).To(BeNil())
+
+			var markedForRemoval []fdbv1beta2.ProcessGroupID
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.IsMarkedForRemoval() {
+					Expect(processGroup.ProcessClass).To(Equal(fdbv1beta2.ProcessClassStorage))
+					markedForRemoval = append(markedForRemoval, processGroup.ProcessGroupID)
+				}
+			}
+
+			Expect(markedForRemoval).NotTo(BeEmpty())
+			Expect(storageProcessGroups).To(ContainElements(markedForRemoval))
+		})
+
+		When("the cluster is reconciled", func() {
+			BeforeEach(func() {
+				_, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should replace all storage process groups and finish the migration", func() {
+				Expect(cluster.Status.StorageEngineMigration).To(BeNil())
+				Expect(cluster.Status.DatabaseConfiguration.StorageEngine).To(Equal(fdbv1beta2.StorageEngineMemory2))
+
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					Expect(storageProcessGroups).NotTo(ContainElement(processGroup.ProcessGroupID))
+				}
+
+				events := &corev1.EventList{}
+				Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+				var completed bool
+				for _, event := range events.Items {
+					if event.InvolvedObject.UID == cluster.UID && event.Reason == "StorageEngineMigrationCompleted" {
+						completed = true
+					}
+				}
+				Expect(completed).To(BeTrue())
+			})
+		})
+	})
+
+	When("the storage engine is not changed", func() {
+		BeforeEach(func() {
+			result := updateDatabaseConfiguration{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			Expect(result).To(BeNil())
+		})
+
+		It("should not start a migration", func() {
+			Expect(cluster.Status.StorageEngineMigration).To(BeNil())
+		})
+	})
+})
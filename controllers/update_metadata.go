@@ -26,7 +26,9 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
@@ -36,7 +38,7 @@ import (
 type updateMetadata struct{}
 
 // reconcile runs the reconciler's work.
-func (updateMetadata) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (updateMetadata) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *cachedDatabaseStatus, logger logr.Logger) *requeue {
 	// TODO(johscheuer): Remove the use of the pvc map and directly make a get request.
 	pvcs := &corev1.PersistentVolumeClaimList{}
 	err := r.List(ctx, pvcs, internal.GetPodListOptions(cluster, "", "")...)
@@ -61,6 +63,15 @@ func (updateMetadata) reconcile(ctx context.Context, r *FoundationDBClusterRecon
 
 			if pod.Spec.NodeName != "" {
 				metadata.Annotations[fdbv1beta2.NodeAnnotation] = pod.Spec.NodeName
+
+				nodeAnnotations, err := getNodeLocalityAnnotations(ctx, logger, r, cluster, pod.Spec.NodeName)
+				if err != nil {
+					return &requeue{curError: err}
+				}
+
+				for key, value := range nodeAnnotations {
+					metadata.Annotations[key] = value
+				}
 			}
 
 			if !metadataCorrect(metadata, &pod.ObjectMeta) {
@@ -102,6 +113,60 @@ func (updateMetadata) reconcile(ctx context.Context, r *FoundationDBClusterRecon
 	return nil
 }
 
+// getNodeLocalityAnnotations returns the annotations that are copied from the labels of the provided node and are used
+// for the localities of the processes. If the node doesn't exist, no annotations will be returned.
+func getNodeLocalityAnnotations(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, nodeName string) (map[string]string, error) {
+	if !cluster.UseRackLocality() && !cluster.UseMachineIDFromNodeLabel() {
+		return nil, nil
+	}
+
+	node := &corev1.Node{}
+	err := r.Get(ctx, client.ObjectKey{Name: nodeName}, node)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.V(1).Info("Could not find node for Pod to read the node labels", "node", nodeName)
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	annotations := map[string]string{}
+	if cluster.UseRackLocality() {
+		annotations[fdbv1beta2.RackAnnotation] = node.Labels[cluster.Spec.FaultDomain.RackKey]
+	}
+
+	if cluster.UseMachineIDFromNodeLabel() {
+		annotations[fdbv1beta2.MachineIDAnnotation] = node.Labels[cluster.Spec.MachineIDSource.NodeLabel]
+	}
+
+	return annotations, nil
+}
+
+// updateNodeLocalityAnnotations sets the annotations from getNodeLocalityAnnotations on the provided Pod. The init
+// container of the Pod waits until those annotations are present, so they must be set before the Pod is running and
+// can't wait for the updateMetadata reconciler.
+func updateNodeLocalityAnnotations(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) error {
+	if pod.Spec.NodeName == "" {
+		return nil
+	}
+
+	annotations, err := getNodeLocalityAnnotations(ctx, logger, r, cluster, pod.Spec.NodeName)
+	if err != nil || len(annotations) == 0 {
+		return err
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string, len(annotations))
+	}
+
+	if !mergeAnnotations(&pod.ObjectMeta, metav1.ObjectMeta{Annotations: annotations}) {
+		return nil
+	}
+
+	return r.PodLifecycleManager.UpdateMetadata(ctx, r, cluster, pod)
+}
+
 func metadataCorrect(desiredMetadata metav1.ObjectMeta, currentMetadata *metav1.ObjectMeta) bool {
 	desiredMetadata.Annotations[fdbv1beta2.LastSpecKey] = currentMetadata.Annotations[fdbv1beta2.LastSpecKey]
 	// If the annotations or labels have changed the metadata has to be updated.
@@ -21,11 +21,15 @@
 package controllers
 
 import (
+	"context"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Update metadata", func() {
@@ -273,4 +277,76 @@ var _ = Describe("Update metadata", func() {
 			},
 		),
 	)
+
+	When("a rack key is defined", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var pod *corev1.Pod
+		rackKey := "topology.kubernetes.io/rack"
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+			cluster.Spec.FaultDomain.RackKey = rackKey
+
+			processGroup := cluster.Status.ProcessGroups[0]
+			var err error
+			pod, err = clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, processGroup.GetPodName(cluster))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pod.Spec.NodeName).NotTo(BeEmpty())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, node)).NotTo(HaveOccurred())
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[rackKey] = "rack-1"
+			Expect(k8sClient.Update(context.TODO(), node)).NotTo(HaveOccurred())
+
+			Expect(updateMetadata{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)).To(BeNil())
+			pod, err = clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, processGroup.GetPodName(cluster))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should add the rack of the node as annotation", func() {
+			Expect(pod.Annotations).To(HaveKeyWithValue(fdbv1beta2.RackAnnotation, "rack-1"))
+		})
+	})
+
+	When("the machine ID is taken from a node label", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var pod *corev1.Pod
+		machineIDKey := "kubernetes.io/hostname"
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+			cluster.Spec.MachineIDSource = &fdbv1beta2.MachineIDSource{NodeLabel: machineIDKey}
+
+			processGroup := cluster.Status.ProcessGroups[0]
+			var err error
+			pod, err = clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, processGroup.GetPodName(cluster))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pod.Spec.NodeName).NotTo(BeEmpty())
+
+			node := &corev1.Node{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, node)).NotTo(HaveOccurred())
+			if node.Labels == nil {
+				node.Labels = map[string]string{}
+			}
+			node.Labels[machineIDKey] = "host-1"
+			Expect(k8sClient.Update(context.TODO(), node)).NotTo(HaveOccurred())
+
+			Expect(updateMetadata{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)).To(BeNil())
+			pod, err = clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, processGroup.GetPodName(cluster))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should add the machine ID of the node as annotation", func() {
+			Expect(pod.Annotations).To(HaveKeyWithValue(fdbv1beta2.MachineIDAnnotation, "host-1"))
+		})
+
+		It("should not add the rack annotation", func() {
+			Expect(pod.Annotations).NotTo(HaveKey(fdbv1beta2.RackAnnotation))
+		})
+	})
 })
@@ -93,4 +93,28 @@ var _ = Describe("updatePodConfig", func() {
 			Expect(req).To(BeNil())
 		})
 	})
+
+	When("a custom parameter references a missing substitution", func() {
+		BeforeEach(func() {
+			settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+			settings.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{"knob_foo=$FDB_FOO"}
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = settings
+		})
+
+		It("should requeue and emit an event", func() {
+			Expect(req).NotTo(BeNil())
+
+			events := &corev1.EventList{}
+			Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+			var found bool
+			for _, event := range events.Items {
+				if event.InvolvedObject.UID == cluster.UID && event.Reason == "MissingSubstitution" {
+					found = true
+					break
+				}
+			}
+			Expect(found).To(BeTrue())
+		})
+	})
 })
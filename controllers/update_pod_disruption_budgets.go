@@ -0,0 +1,111 @@
+/*
+ * update_pod_disruption_budgets.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updatePodDisruptionBudgets provides a reconciliation step for creating, updating and removing the
+// PodDisruptionBudget of a cluster.
+type updatePodDisruptionBudgets struct{}
+
+// reconcile runs the reconciler's work.
+func (u updatePodDisruptionBudgets) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, _ *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	var desiredBudget *policyv1.PodDisruptionBudget
+	if cluster.ShouldManagePodDisruptionBudgets() {
+		desiredBudget = internal.GetPodDisruptionBudget(cluster)
+	}
+
+	existingBudgets := &policyv1.PodDisruptionBudgetList{}
+	err := r.List(ctx, existingBudgets, client.InNamespace(cluster.Namespace), client.MatchingLabels(cluster.GetMatchLabels()))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	budgetExists := false
+	for idx := range existingBudgets.Items {
+		existingBudget := &existingBudgets.Items[idx]
+		// Only the PodDisruptionBudgets that were created by the operator will be updated or removed.
+		if !metav1.IsControlledBy(existingBudget, cluster) {
+			continue
+		}
+
+		// All other PodDisruptionBudgets, e.g. the PodDisruptionBudgets per process class created by previous
+		// versions of the operator, will be removed.
+		if desiredBudget == nil || existingBudget.Name != desiredBudget.Name {
+			logger.Info("Deleting PodDisruptionBudget", "name", existingBudget.Name)
+			err = r.Delete(ctx, existingBudget)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+
+			continue
+		}
+
+		err = updatePodDisruptionBudget(ctx, logger, r, existingBudget, desiredBudget)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		budgetExists = true
+	}
+
+	if desiredBudget == nil || budgetExists {
+		return nil
+	}
+
+	logger.Info("Creating PodDisruptionBudget", "name", desiredBudget.Name)
+	err = r.Create(ctx, desiredBudget)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	return nil
+}
+
+// updatePodDisruptionBudget updates the spec and the metadata of the current PodDisruptionBudget based on the desired
+// PodDisruptionBudget.
+func updatePodDisruptionBudget(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, currentBudget *policyv1.PodDisruptionBudget, desiredBudget *policyv1.PodDisruptionBudget) error {
+	needsUpdate := !equality.Semantic.DeepEqual(currentBudget.Spec, desiredBudget.Spec)
+	currentBudget.Spec = desiredBudget.Spec
+
+	metadata := currentBudget.ObjectMeta
+	if mergeLabelsInMetadata(&metadata, desiredBudget.ObjectMeta) {
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		return nil
+	}
+
+	currentBudget.ObjectMeta = metadata
+	logger.Info("Updating PodDisruptionBudget", "name", currentBudget.Name)
+	return r.Update(ctx, currentBudget)
+}
@@ -0,0 +1,160 @@
+/*
+ * update_pod_disruption_budgets_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+var _ = Describe("update_pod_disruption_budgets", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var result *requeue
+
+	getPodDisruptionBudgets := func() map[string]policyv1.PodDisruptionBudget {
+		budgets := &policyv1.PodDisruptionBudgetList{}
+		Expect(k8sClient.List(context.TODO(), budgets, client.InNamespace(cluster.Namespace))).NotTo(HaveOccurred())
+
+		result := make(map[string]policyv1.PodDisruptionBudget, len(budgets.Items))
+		for _, budget := range budgets.Items {
+			result[budget.Name] = budget
+		}
+
+		return result
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		result = updatePodDisruptionBudgets{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+	})
+
+	When("the PodDisruptionBudgets are disabled", func() {
+		It("should not create any PodDisruptionBudgets", func() {
+			Expect(result).To(BeNil())
+			Expect(getPodDisruptionBudgets()).To(BeEmpty())
+		})
+	})
+
+	When("the PodDisruptionBudgets are enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.PodDisruptionBudget.Enabled = pointer.Bool(true)
+		})
+
+		It("should create a single PodDisruptionBudget for the cluster", func() {
+			Expect(result).To(BeNil())
+
+			budgets := getPodDisruptionBudgets()
+			Expect(budgets).To(HaveLen(1))
+			Expect(budgets).To(HaveKey(cluster.Name))
+
+			budget := budgets[cluster.Name]
+			Expect(budget.Spec.MaxUnavailable.IntValue()).To(Equal(cluster.DesiredFaultTolerance()))
+			Expect(budget.Spec.Selector.MatchLabels).To(Equal(cluster.GetMatchLabels()))
+			Expect(budget.Labels).To(HaveKeyWithValue(fdbv1beta2.FDBClusterLabel, cluster.Name))
+			Expect(metav1.IsControlledBy(&budget, cluster)).To(BeTrue())
+		})
+
+		When("the redundancy mode is changed", func() {
+			JustBeforeEach(func() {
+				Expect(result).To(BeNil())
+				cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+				result = updatePodDisruptionBudgets{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			})
+
+			It("should update the maxUnavailable of the PodDisruptionBudget", func() {
+				Expect(result).To(BeNil())
+
+				budgets := getPodDisruptionBudgets()
+				Expect(budgets).To(HaveLen(1))
+				Expect(budgets[cluster.Name].Spec.MaxUnavailable.IntValue()).To(Equal(2))
+			})
+		})
+
+		When("a PodDisruptionBudget per process class was created by the operator", func() {
+			BeforeEach(func() {
+				maxUnavailable := intstr.FromInt(cluster.DesiredFaultTolerance())
+				budget := &policyv1.PodDisruptionBudget{
+					ObjectMeta: internal.GetObjectMetadata(cluster, nil, fdbv1beta2.ProcessClassStorage, ""),
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						MaxUnavailable: &maxUnavailable,
+						Selector: &metav1.LabelSelector{
+							MatchLabels: internal.GetPodMatchLabels(cluster, fdbv1beta2.ProcessClassStorage, ""),
+						},
+					},
+				}
+				budget.Name = cluster.Name + "-storage"
+				budget.OwnerReferences = internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+				Expect(k8sClient.Create(context.TODO(), budget)).NotTo(HaveOccurred())
+			})
+
+			It("should replace it with the PodDisruptionBudget for the cluster", func() {
+				Expect(result).To(BeNil())
+
+				budgets := getPodDisruptionBudgets()
+				Expect(budgets).To(HaveLen(1))
+				Expect(budgets).To(HaveKey(cluster.Name))
+			})
+		})
+
+		When("the PodDisruptionBudgets are disabled again", func() {
+			var unmanagedBudget *policyv1.PodDisruptionBudget
+
+			JustBeforeEach(func() {
+				Expect(result).To(BeNil())
+
+				unmanagedBudget = &policyv1.PodDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "unmanaged",
+						Namespace: cluster.Namespace,
+						Labels:    cluster.GetMatchLabels(),
+					},
+				}
+				Expect(k8sClient.Create(context.TODO(), unmanagedBudget)).NotTo(HaveOccurred())
+
+				cluster.Spec.AutomationOptions.PodDisruptionBudget.Enabled = pointer.Bool(false)
+				result = updatePodDisruptionBudgets{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+			})
+
+			It("should only remove the PodDisruptionBudgets created by the operator", func() {
+				Expect(result).To(BeNil())
+
+				budgets := getPodDisruptionBudgets()
+				Expect(budgets).To(HaveLen(1))
+				Expect(budgets).To(HaveKey(unmanagedBudget.Name))
+			})
+		})
+	})
+})
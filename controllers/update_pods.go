@@ -32,6 +32,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -41,7 +42,9 @@ import (
 type updatePods struct{}
 
 // reconcile runs the reconciler's work.
-func (updatePods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (updatePods) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	status := cachedStatus.get()
+
 	// TODO(johscheuer): Remove the pvc map an make direct calls.
 	pvcs := &corev1.PersistentVolumeClaimList{}
 	err := r.List(ctx, pvcs, internal.GetPodListOptions(cluster, "", "")...)
@@ -83,6 +86,8 @@ func (updatePods) reconcile(ctx context.Context, r *FoundationDBClusterReconcile
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		cachedStatus.record(status)
 	}
 
 	return deletePodsForUpdates(ctx, r, cluster, updates, logger, status, adminClient)
@@ -118,7 +123,7 @@ func getFaultDomainsWithUnavailablePods(ctx context.Context, logger logr.Logger,
 			faultDomainsWithUnavailablePods[processGroup.FaultDomain] = fdbv1beta2.None{}
 			continue
 		}
-		pod, err := reconciler.PodLifecycleManager.GetPod(ctx, reconciler, cluster, processGroup.GetPodName(cluster))
+		pod, err := podmanager.GetPodForProcessGroup(ctx, reconciler.PodLifecycleManager, reconciler, cluster, processGroup)
 		if err != nil {
 			logger.V(1).Info("Could not find Pod for process group ID",
 				"processGroupID", processGroup.ProcessGroupID, "error", err)
@@ -176,13 +181,19 @@ func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *Founda
 			continue
 		}
 
+		if cluster.IgnoreProcessGroupForAutomation(processGroup) {
+			logger.V(1).Info("Ignore test process group",
+				"processGroupID", processGroup.ProcessGroupID)
+			continue
+		}
+
 		if cluster.NeedsReplacement(processGroup) {
 			logger.V(1).Info("Skip process group for deletion, requires a replacement",
 				"processGroupID", processGroup.ProcessGroupID)
 			continue
 		}
 
-		pod, err := reconciler.PodLifecycleManager.GetPod(ctx, reconciler, cluster, processGroup.GetPodName(cluster))
+		pod, err := podmanager.GetPodForProcessGroup(ctx, reconciler.PodLifecycleManager, reconciler, cluster, processGroup)
 		// If a Pod is not found ignore it for now.
 		if err != nil {
 			logger.V(1).Info("Could not find Pod for process group ID",
@@ -202,9 +213,14 @@ func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *Founda
 			continue
 		}
 
-		// The Pod is updated, so we can continue.
+		reason := fmt.Sprintf("specHash has changed from %s to %s", specHash, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey])
+		// The Pod is updated, so we can continue, unless the Pod is running a mismatched image and should be recreated.
 		if pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] == specHash {
-			continue
+			if !cluster.ShouldReplaceMismatchedImages() || processGroup.GetConditionTime(fdbv1beta2.IncorrectImage) == nil {
+				continue
+			}
+
+			reason = "Pod is running a mismatched image"
 		}
 
 		needsRemoval, err := replacements.ProcessGroupNeedsRemoval(ctx, reconciler.PodLifecycleManager, reconciler, logger, cluster, processGroup, pvcMap)
@@ -223,7 +239,7 @@ func getPodsToUpdate(ctx context.Context, logger logr.Logger, reconciler *Founda
 
 		logger.Info("Update Pod",
 			"processGroupID", processGroup.ProcessGroupID,
-			"reason", fmt.Sprintf("specHash has changed from %s to %s", specHash, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]))
+			"reason", reason)
 
 		podClient, message := reconciler.getPodClient(cluster, pod)
 		if podClient == nil {
@@ -372,7 +372,51 @@ var _ = Describe("update_pods", func() {
 				// We only have one zone in this case, the simulation zone
 				Expect(updates).To(HaveLen(1))
 			})
+
+			When("all process groups are test process groups that should be ignored", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.IgnoreTestProcessGroups = pointer.Bool(true)
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						processGroup.ProcessClass = fdbv1beta2.ProcessClassTest
+					}
+				})
+
+				It("should return no updates", func() {
+					Expect(updates).To(HaveLen(0))
+				})
+			})
 		})
+		When("a Pod is running a mismatched image", func() {
+			BeforeEach(func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1")
+				pod, err := clusterReconciler.PodLifecycleManager.GetPod(context.TODO(), clusterReconciler, cluster, processGroup.GetPodName(cluster))
+				Expect(err).NotTo(HaveOccurred())
+				pod.Spec.Containers[0].Image = "foundationdb/foundationdb:6.2.20"
+				Expect(k8sClient.Update(context.TODO(), pod)).NotTo(HaveOccurred())
+				processGroup.UpdateCondition(fdbv1beta2.IncorrectImage, true)
+			})
+
+			When("replacing mismatched images is disabled", func() {
+				It("should return no updates", func() {
+					Expect(updates).To(HaveLen(0))
+				})
+			})
+
+			When("replacing mismatched images is enabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.ReplaceMismatchedImages = pointer.Bool(true)
+				})
+
+				It("should return the Pod with the mismatched image", func() {
+					Expect(updates).To(HaveLen(1))
+					for _, pods := range updates {
+						Expect(pods).To(HaveLen(1))
+						Expect(internal.GetProcessGroupIDFromMeta(cluster, pods[0].ObjectMeta)).To(Equal(fdbv1beta2.ProcessGroupID("storage-1")))
+					}
+				})
+			})
+		})
+
 		When("there is a spec change requiring a removal", func() {
 			BeforeEach(func() {
 				storageSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
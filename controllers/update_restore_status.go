@@ -0,0 +1,97 @@
+/*
+ * update_restore_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// updateRestoreStatus provides a reconciliation step for updating the status of the restore with the progress reported
+// by fdbrestore.
+type updateRestoreStatus struct{}
+
+// reconcile runs the reconciler's work.
+func (s updateRestoreStatus) reconcile(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore) *requeue {
+	adminClient, err := r.adminClientForRestore(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	liveStatus, err := adminClient.GetLiveRestoreStatus()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	state := liveStatus.GetState()
+	// If fdbrestore doesn't report a restore, we keep the last observed status.
+	if state == "" {
+		return nil
+	}
+
+	if state == fdbv1beta2.RestoreStateFailed && restore.Spec.Abort {
+		state = fdbv1beta2.RestoreStateAborted
+	}
+
+	originalStatus := restore.Status.DeepCopy()
+	restore.Status.State = state
+	restore.Status.Running = state.IsInProgress()
+	restore.Status.Tag = liveStatus.Tag
+	restore.Status.ProgressPercentage = liveStatus.GetProgressPercentage()
+	restore.Status.BytesWritten = liveStatus.BytesWritten
+	if liveStatus.Version > 0 {
+		restore.Status.RestoreVersion = liveStatus.Version
+	}
+
+	if equality.Semantic.DeepEqual(restore.Status, *originalStatus) {
+		return nil
+	}
+
+	err = r.updateOrApply(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if originalStatus.State != state {
+		recordRestoreStateChange(r, restore, liveStatus)
+	}
+
+	return nil
+}
+
+// recordRestoreStateChange emits an event for the new state of the restore.
+func recordRestoreStateChange(r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore, liveStatus *fdbv1beta2.FoundationDBLiveRestoreStatus) {
+	eventType := corev1.EventTypeNormal
+	message := fmt.Sprintf("Restore with tag %s is %s", restore.Status.Tag, restore.Status.State)
+	if restore.Status.State == fdbv1beta2.RestoreStateFailed {
+		eventType = corev1.EventTypeWarning
+		if liveStatus.LastError != "" {
+			message = fmt.Sprintf("%s, last error: %s", message, liveStatus.LastError)
+		}
+	}
+
+	r.Recorder.Event(restore, eventType, fmt.Sprintf("Restore%s", restore.Status.State), message)
+}
@@ -25,11 +25,13 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal/locality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 	"github.com/go-logr/logr"
 
@@ -40,6 +42,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 )
@@ -49,11 +53,21 @@ import (
 type updateStatus struct{}
 
 // reconcile runs the reconciler's work.
-func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, databaseStatus *fdbv1beta2.FoundationDBStatus, logger logr.Logger) *requeue {
+func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, cachedStatus *cachedDatabaseStatus, logger logr.Logger) *requeue {
+	databaseStatus := cachedStatus.get()
+
 	originalStatus := cluster.Status.DeepCopy()
 	clusterStatus := fdbv1beta2.FoundationDBClusterStatus{}
 	clusterStatus.Generations.Reconciled = cluster.Status.Generations.Reconciled
 	clusterStatus.ProcessGroups = cluster.Status.ProcessGroups
+	clusterStatus.LastRequeue = cluster.Status.LastRequeue
+	clusterStatus.LastReconciliationError = cluster.Status.LastReconciliationError
+	clusterStatus.Conditions = cluster.Status.Conditions
+	clusterStatus.ReconcileLoops = cluster.Status.ReconcileLoops
+	clusterStatus.TunedCoordinatorCount = cluster.Status.TunedCoordinatorCount
+	clusterStatus.HandledCoordinatorRotation = cluster.Status.HandledCoordinatorRotation
+	clusterStatus.LastFaultDomainReplacements = cluster.Status.LastFaultDomainReplacements
+	clusterStatus.RecentInclusions = cluster.Status.RecentInclusions
 	// Initialize with the current desired storage servers per Pod
 	clusterStatus.StorageServersPerDisk = []int{cluster.GetStorageServersPerPod()}
 	clusterStatus.LogServersPerDisk = []int{cluster.GetLogServersPerPod()}
@@ -62,10 +76,16 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 
 	if databaseStatus == nil {
 		var err error
-		databaseStatus, err = r.getStatusFromClusterOrDummyStatus(logger, cluster)
+		databaseStatus, err = r.getStatusFromClusterOrDummyStatus(ctx, logger, cluster)
 		if err != nil {
 			return &requeue{curError: fmt.Errorf("update_status error fetching status: %w", err), delayedRequeue: true}
 		}
+
+		cachedStatus.record(databaseStatus)
+	}
+
+	if fetchedAt := cachedStatus.getFetchedAt(); fetchedAt != nil {
+		clusterStatus.DatabaseStatusCachedAt = &metav1.Time{Time: *fetchedAt}
 	}
 
 	versionMap := map[string]int{}
@@ -124,8 +144,16 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 			} else {
 				clusterStatus.RequiredAddresses.NonTLS = true
 			}
+
+			if coordinator.Reachable {
+				clusterStatus.CoordinatorAvailability.Reachable++
+			} else {
+				clusterStatus.CoordinatorAvailability.Unreachable++
+			}
 		}
 
+		clusterStatus.CoordinatorAvailability.Total = len(databaseStatus.Client.Coordinators.Coordinators)
+
 		clusterStatus.Health.Available = databaseStatus.Client.DatabaseStatus.Available
 		clusterStatus.Health.Healthy = databaseStatus.Client.DatabaseStatus.Healthy
 		clusterStatus.Health.FullReplication = databaseStatus.Cluster.FullReplication
@@ -151,6 +179,11 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		return &requeue{curError: fmt.Errorf("update_status skipped due to error in validateProcessGroups: %w", err)}
 	}
 
+	updateCoordinatorTimestamps(logger, databaseStatus, &clusterStatus)
+	updateExclusionProgress(logger, databaseStatus, &clusterStatus)
+	checkCoordinatorAge(logger, r, cluster, &clusterStatus, time.Now())
+	clusterStatus.StorageEngineMigration = updateStorageEngineMigration(logger, r, cluster, clusterStatus.ProcessGroups)
+
 	existingConfigMap := &corev1.ConfigMap{}
 	err = r.Get(ctx, types.NamespacedName{Namespace: configMap.Namespace, Name: configMap.Name}, existingConfigMap)
 	if err != nil && k8serrors.IsNotFound(err) {
@@ -244,6 +277,8 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		return &requeue{curError: err}
 	}
 
+	updateClusterConditions(cluster, reconciled, r.getClock().Now())
+
 	if reconciled {
 		// Once the cluster is reconciled the operator will release any pending locks for this cluster.
 		lockErr := r.releaseLock(logger, cluster)
@@ -263,9 +298,67 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		}
 	}
 
+	// A Node taint doesn't trigger a new reconciliation once it was present for the configured duration, so the
+	// operator must requeue itself to replace the process groups at that time.
+	if delay := getTaintReplacementDelay(cluster, cluster.Status.ProcessGroups, time.Now()); delay > 0 {
+		return &requeue{message: "waiting for the taint duration of process groups on tainted nodes", delay: delay, delayedRequeue: true}
+	}
+
 	return nil
 }
 
+// updateClusterConditions updates the conditions in the cluster status based on the reconciliation state and the
+// health of the cluster. The last transition time of a condition will only be updated if the status of the condition
+// changes.
+func updateClusterConditions(cluster *fdbv1beta2.FoundationDBCluster, reconciled bool, now time.Time) {
+	setCondition := func(conditionType string, status bool, reason string, message string) {
+		conditionStatus := metav1.ConditionFalse
+		if status {
+			conditionStatus = metav1.ConditionTrue
+		}
+
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             conditionStatus,
+			ObservedGeneration: cluster.ObjectMeta.Generation,
+			LastTransitionTime: metav1.NewTime(now),
+			Reason:             reason,
+			Message:            message,
+		})
+	}
+
+	if reconciled {
+		setCondition(fdbv1beta2.ClusterConditionTypeReconciled, true, "Reconciled", fmt.Sprintf("generation %d is reconciled", cluster.ObjectMeta.Generation))
+	} else {
+		setCondition(fdbv1beta2.ClusterConditionTypeReconciled, false, "ReconciliationInProgress", fmt.Sprintf("generation %d is not yet reconciled", cluster.ObjectMeta.Generation))
+	}
+
+	if cluster.Status.Health.Available {
+		setCondition(fdbv1beta2.ClusterConditionTypeAvailable, true, "DatabaseAvailable", "the database is available")
+	} else {
+		setCondition(fdbv1beta2.ClusterConditionTypeAvailable, false, "DatabaseUnavailable", "the database is not available")
+	}
+
+	if cluster.Status.Health.FullReplication {
+		setCondition(fdbv1beta2.ClusterConditionTypeFullReplication, true, "FullyReplicated", "the data is fully replicated")
+	} else {
+		setCondition(fdbv1beta2.ClusterConditionTypeFullReplication, false, "NotFullyReplicated", "the data is not fully replicated")
+	}
+
+	// The reconciliation is only stalled if the last requeue was observed while the cluster was not reconciled,
+	// otherwise a requeue from a previous reconciliation would mark the cluster as stalled.
+	lastRequeue := cluster.Status.LastRequeue
+	reconciledCondition := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeReconciled)
+	stalledDuration := cluster.GetReconciliationStalledDuration()
+	if !reconciled && lastRequeue != nil && lastRequeue.Delayed && lastRequeue.Timestamp != nil &&
+		!reconciledCondition.LastTransitionTime.After(lastRequeue.Timestamp.Time) &&
+		now.Sub(lastRequeue.Timestamp.Time) >= stalledDuration {
+		setCondition(fdbv1beta2.ClusterConditionTypeReconciliationStalled, true, "DelayedRequeue", fmt.Sprintf("%s has requested a delayed requeue for more than %s: %s", lastRequeue.Reconciler, stalledDuration.String(), lastRequeue.Message))
+	} else {
+		setCondition(fdbv1beta2.ClusterConditionTypeReconciliationStalled, false, "NotStalled", "the reconciliation is not stalled")
+	}
+}
+
 // containsAll determines if one map contains all the keys and matching values
 // from another map.
 func containsAll(current map[string]string, desired map[string]string) bool {
@@ -394,7 +487,10 @@ func checkAndSetProcessStatus(logger logr.Logger, r *FoundationDBClusterReconcil
 
 			commandLine, err := internal.GetStartCommandWithSubstitutions(cluster, processGroupStatus.ProcessClass, substitutions, processNumber, processCount)
 			if err != nil {
-				return err
+				// If the start command can't be generated, the process is not running with the desired command line.
+				logger.Info("could not generate the start command for the process", "processGroupID", processGroupStatus.ProcessGroupID, "error", err.Error())
+				hasIncorrectCommandLine = true
+				continue
 			}
 
 			// If a version compatible upgrade is in progress, skip the version check since we will run a mixed set of versions
@@ -440,6 +536,11 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 		processGroupsWithoutExclusion[processGroupID] = fdbv1beta2.None{}
 	}
 
+	processGroupsToRemove := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(cluster.Spec.ProcessGroupsToRemove))
+	for _, processGroupID := range cluster.Spec.ProcessGroupsToRemove {
+		processGroupsToRemove[processGroupID] = fdbv1beta2.None{}
+	}
+
 	pvcMap := internal.CreatePVCMap(cluster, pvcs)
 
 	disableTaintFeature := cluster.IsTaintFeatureDisabled()
@@ -448,6 +549,23 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 	}
 
 	for _, processGroup := range status.ProcessGroups {
+		_, removalRequested := processGroupsToRemove[processGroup.ProcessGroupID]
+		if !removalRequested {
+			_, removalRequested = processGroupsWithoutExclusion[processGroup.ProcessGroupID]
+		}
+
+		if removalRequested && !processGroup.IsMarkedForRemoval() {
+			processGroup.RemovalRequested = true
+		}
+
+		// If the process group was removed from the remove lists before it was excluded, e.g. because the node was
+		// uncordoned, the removal will be canceled. Process groups that were marked for removal by the operator itself
+		// are not affected.
+		if !removalRequested && processGroup.RemovalRequested && !processGroup.IsExcluded() {
+			logger.Info("Cancel removal of process group that is no longer listed in the remove lists", "processGroupID", processGroup.ProcessGroupID)
+			processGroup.CancelRemoval()
+		}
+
 		// If the process group should be removed mark it for removal.
 		if cluster.ProcessGroupIsBeingRemoved(processGroup.ProcessGroupID) {
 			processGroup.MarkForRemoval()
@@ -469,6 +587,12 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 			continue
 		}
 
+		var pvc *corev1.PersistentVolumeClaim
+		pvcValue, pvcExists := pvcMap[processGroup.ProcessGroupID]
+		if pvcExists {
+			pvc = &pvcValue
+		}
+
 		pod, podError := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
 		if podError != nil {
 			// If the process group is not being removed and the Pod is not set we need to put it into
@@ -483,6 +607,8 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 				}
 
 				processGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, false)
+				// A Pod that uses a lost PVC can't be scheduled again, so the PVC must be checked without a Pod.
+				updatePVCFailedCondition(processGroup, pvc, logger)
 				continue
 			}
 
@@ -558,12 +684,6 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 			return err
 		}
 
-		var pvc *corev1.PersistentVolumeClaim
-		pvcValue, pvcExists := pvcMap[processGroup.ProcessGroupID]
-		if pvcExists {
-			pvc = &pvcValue
-		}
-
 		err = validateProcessGroup(ctx, r, cluster, pod, pvc, configMapHash, processGroup, disableTaintFeature, logger)
 		if err != nil {
 			return err
@@ -578,6 +698,8 @@ func validateProcessGroups(ctx context.Context, r *FoundationDBClusterReconciler
 func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster,
 	pod *corev1.Pod, currentPVC *corev1.PersistentVolumeClaim, configMapHash string, processGroupStatus *fdbv1beta2.ProcessGroupStatus,
 	disableTaintFeature bool, logger logr.Logger) error {
+	updatePVCFailedCondition(processGroupStatus, currentPVC, logger)
+
 	if pod == nil {
 		processGroupStatus.UpdateCondition(fdbv1beta2.MissingPod, true)
 		return nil
@@ -599,6 +721,16 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 
 	processGroupStatus.UpdateCondition(fdbv1beta2.IncorrectPodSpec, incorrectPod)
 
+	incorrectImage := false
+	if cluster.ShouldReplaceMismatchedImages() {
+		incorrectImage = podHasMismatchedImages(pod)
+		if incorrectImage {
+			logger.Info("Pod is running an unexpected image", "processGroupID", processGroupStatus.ProcessGroupID)
+		}
+	}
+
+	processGroupStatus.UpdateCondition(fdbv1beta2.IncorrectImage, incorrectImage)
+
 	// If we do a cluster version incompatible upgrade we use the fdbv1beta2.IncorrectConfigMap to signal when the operator
 	// can restart fdbserver processes. Since the ConfigMap itself won't change during the upgrade we have to run the updatePodDynamicConf
 	// to make sure all process groups have the required files ready. In the future we will use a different condition to indicate that a
@@ -679,6 +811,63 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 	return nil
 }
 
+// podHasMismatchedImages returns true if at least one container or init container of the Pod is running a different
+// image than the image defined in the Pod spec. The images are compared against the observed images in the container
+// statuses and not against the desired images from the cluster spec, as an admission webhook could rewrite the images,
+// e.g. to use a registry mirror, which would otherwise result in Pods being recreated over and over again.
+func podHasMismatchedImages(pod *corev1.Pod) bool {
+	return containerImagesMismatch(pod.Spec.InitContainers, pod.Status.InitContainerStatuses) ||
+		containerImagesMismatch(pod.Spec.Containers, pod.Status.ContainerStatuses)
+}
+
+// containerImagesMismatch returns true if a container is running a different image than the one defined in its spec.
+// Containers without a reported image or with an image reported by digest are ignored, as the image cannot be compared.
+func containerImagesMismatch(containers []corev1.Container, statuses []corev1.ContainerStatus) bool {
+	specImages := make(map[string]string, len(containers))
+	for _, container := range containers {
+		specImages[container.Name] = container.Image
+	}
+
+	for _, status := range statuses {
+		if status.Image == "" || strings.Contains(status.Image, "@") || strings.HasPrefix(status.Image, "sha256:") {
+			continue
+		}
+
+		image, ok := specImages[status.Name]
+		if ok && normalizeImageName(image) != normalizeImageName(status.Image) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeImageName removes the default registry prefixes that the container runtime might add to the reported image.
+func normalizeImageName(image string) string {
+	image = strings.TrimPrefix(image, "docker.io/")
+	return strings.TrimPrefix(image, "library/")
+}
+
+// pvcHasFailed returns true if the provided PVC lost its volume. The CSI volume health monitor reports abnormal
+// volumes only as events and not as PVC conditions, so those volumes are not detected here.
+func pvcHasFailed(pvc *corev1.PersistentVolumeClaim) bool {
+	if pvc == nil {
+		return false
+	}
+
+	return pvc.Status.Phase == corev1.ClaimLost
+}
+
+// updatePVCFailedCondition sets the PVCFailed condition of the process group based on the phase of its PVC.
+func updatePVCFailedCondition(processGroupStatus *fdbv1beta2.ProcessGroupStatus, pvc *corev1.PersistentVolumeClaim, logger logr.Logger) {
+	pvcFailed := pvcHasFailed(pvc)
+	if pvcFailed {
+		logger.Info("PVC of process group has failed", "processGroupID", processGroupStatus.ProcessGroupID, "pvc", pvc.Name, "phase", pvc.Status.Phase)
+	}
+
+	processGroupStatus.UpdateCondition(fdbv1beta2.PVCFailed, pvcFailed)
+}
+
 // updateTaintCondition checks pod's node taint label and update pod's taint-related condition accordingly
 func updateTaintCondition(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster,
 	pod *corev1.Pod, processGroup *fdbv1beta2.ProcessGroupStatus, logger logr.Logger) error {
@@ -879,6 +1068,45 @@ func getRunningVersion(logger logr.Logger, versionMap map[string]int, currentRun
 	return candidateString, nil
 }
 
+// getTaintReplacementDelay returns the duration until the next process group with the NodeTaintDetected condition
+// reaches one of the configured taint durations. If no process group is waiting for a taint replacement, 0 will be
+// returned.
+func getTaintReplacementDelay(cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus, now time.Time) time.Duration {
+	var delay time.Duration
+	for _, processGroup := range processGroups {
+		if processGroup.GetConditionTime(fdbv1beta2.NodeTaintReplacing) != nil {
+			continue
+		}
+
+		taintDetectedTime := processGroup.GetConditionTime(fdbv1beta2.NodeTaintDetected)
+		if taintDetectedTime == nil {
+			continue
+		}
+
+		elapsedSeconds := now.Unix() - *taintDetectedTime
+		for _, option := range cluster.Spec.AutomationOptions.Replacements.TaintReplacementOptions {
+			durationInSeconds := pointer.Int64Deref(option.DurationInSeconds, math.MinInt64)
+			// Options with an empty key or a negative duration are disabled and options with a very large duration,
+			// e.g. the maximum of int64, will never be reached.
+			if pointer.StringDeref(option.Key, "") == "" || durationInSeconds < 0 || durationInSeconds >= int64(math.MaxInt32) {
+				continue
+			}
+
+			// The NodeTaintReplacing condition is added once the elapsed time is greater than the configured duration.
+			if elapsedSeconds > durationInSeconds {
+				continue
+			}
+
+			remaining := time.Duration(durationInSeconds-elapsedSeconds+1) * time.Second
+			if delay == 0 || remaining < delay {
+				delay = remaining
+			}
+		}
+	}
+
+	return delay
+}
+
 func hasExactMatchedTaintKey(taintReplacementOptions []fdbv1beta2.TaintReplacementOption, nodeTaintKey string) bool {
 	for _, configuredTaintKey := range taintReplacementOptions {
 		if *configuredTaintKey.Key == nodeTaintKey {
@@ -948,3 +1176,141 @@ func updateFaultDomains(logger logr.Logger, processes map[fdbv1beta2.ProcessGrou
 		status.ProcessGroups[idx].FaultDomain = fdbv1beta2.FaultDomain(faultDomain)
 	}
 }
+
+// updateCoordinatorTimestamps will update the coordinator timestamp of the process groups, based on the coordinator
+// roles in the cluster status.
+func updateCoordinatorTimestamps(logger logr.Logger, databaseStatus *fdbv1beta2.FoundationDBStatus, status *fdbv1beta2.FoundationDBClusterStatus) {
+	// If the database is not available, the status contains no information about the coordinators.
+	if !databaseStatus.Client.DatabaseStatus.Available {
+		return
+	}
+
+	coordinators := fdbstatus.GetCoordinatorsFromStatus(databaseStatus)
+	for _, processGroup := range status.ProcessGroups {
+		if _, ok := coordinators[string(processGroup.ProcessGroupID)]; !ok {
+			processGroup.CoordinatorTimestamp = nil
+			continue
+		}
+
+		if processGroup.CoordinatorTimestamp == nil {
+			logger.V(1).Info("Process group started to serve as coordinator", "processGroupID", processGroup.ProcessGroupID)
+			processGroup.CoordinatorTimestamp = &metav1.Time{Time: time.Now()}
+		}
+	}
+}
+
+// updateExclusionProgress will update the exclusion progress of the process groups that are marked for removal but are
+// not yet fully excluded, based on the bytes that are still stored on the excluded processes in the cluster status.
+func updateExclusionProgress(logger logr.Logger, databaseStatus *fdbv1beta2.FoundationDBStatus, status *fdbv1beta2.FoundationDBClusterStatus) {
+	// If the database is not available, the status contains no information about the stored bytes.
+	if !databaseStatus.Client.DatabaseStatus.Available {
+		return
+	}
+
+	remainingBytes := fdbstatus.GetRemainingBytesForExcludedProcessGroups(databaseStatus)
+	for _, processGroup := range status.ProcessGroups {
+		if !processGroup.IsMarkedForRemoval() || processGroup.IsExcluded() {
+			processGroup.ExclusionProgress = nil
+			continue
+		}
+
+		bytes, ok := remainingBytes[processGroup.ProcessGroupID]
+		if !ok {
+			processGroup.ExclusionProgress = nil
+			continue
+		}
+
+		if processGroup.ExclusionProgress == nil {
+			logger.V(1).Info("Observed exclusion progress for process group", "processGroupID", processGroup.ProcessGroupID, "remainingBytes", bytes)
+			processGroup.ExclusionProgress = &fdbv1beta2.ExclusionProgress{
+				InitialBytes: bytes,
+			}
+		}
+
+		// The stored bytes could grow during the data movement, so make sure the initial bytes are never smaller
+		// than the remaining bytes.
+		if bytes > processGroup.ExclusionProgress.InitialBytes {
+			processGroup.ExclusionProgress.InitialBytes = bytes
+		}
+
+		processGroup.ExclusionProgress.RemainingBytes = bytes
+	}
+}
+
+// updateStorageEngineMigration removes all process groups from the pending storage engine migration that are not
+// part of the cluster anymore. If no process groups are pending, the migration is done and nil will be returned.
+func updateStorageEngineMigration(logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroups []*fdbv1beta2.ProcessGroupStatus) *fdbv1beta2.StorageEngineMigrationStatus {
+	migration := cluster.Status.StorageEngineMigration
+	if migration == nil {
+		return nil
+	}
+
+	existingProcessGroups := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(processGroups))
+	for _, processGroup := range processGroups {
+		existingProcessGroups[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+	}
+
+	pendingProcessGroups := make([]fdbv1beta2.ProcessGroupID, 0, len(migration.PendingProcessGroups))
+	for _, processGroupID := range migration.PendingProcessGroups {
+		if _, ok := existingProcessGroups[processGroupID]; ok {
+			pendingProcessGroups = append(pendingProcessGroups, processGroupID)
+		}
+	}
+
+	if len(pendingProcessGroups) == 0 {
+		logger.Info("Storage engine migration is done", "from", migration.From, "to", migration.To)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "StorageEngineMigrationCompleted",
+			fmt.Sprintf("Migrated storage engine from %s to %s", migration.From, migration.To))
+		return nil
+	}
+
+	updatedMigration := migration.DeepCopy()
+	updatedMigration.PendingProcessGroups = pendingProcessGroups
+
+	return updatedMigration
+}
+
+// checkCoordinatorAge will update the CoordinatorRotationRecommended condition if at least one process group has been
+// serving as a coordinator for longer than the configured coordinator age warning duration. The event that recommends
+// to rotate the coordinators is only emitted when the condition changes, e.g. if a new coordinator exceeds the duration.
+func checkCoordinatorAge(logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBClusterStatus, now time.Time) {
+	maxAge := cluster.GetCoordinatorAgeWarningDuration()
+	if maxAge <= 0 {
+		meta.RemoveStatusCondition(&status.Conditions, fdbv1beta2.ClusterConditionTypeCoordinatorRotationRecommended)
+		return
+	}
+
+	var oldCoordinators []fdbv1beta2.ProcessGroupID
+	for _, processGroup := range status.ProcessGroups {
+		if processGroup.CoordinatorTimestamp == nil {
+			continue
+		}
+
+		if now.Sub(processGroup.CoordinatorTimestamp.Time) > maxAge {
+			oldCoordinators = append(oldCoordinators, processGroup.ProcessGroupID)
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               fdbv1beta2.ClusterConditionTypeCoordinatorRotationRecommended,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cluster.ObjectMeta.Generation,
+		LastTransitionTime: metav1.NewTime(now),
+		Reason:             "CoordinatorsWithinMaximumAge",
+		Message:            fmt.Sprintf("no process group has been a coordinator for longer than %s", maxAge.String()),
+	}
+
+	if len(oldCoordinators) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "CoordinatorsExceedMaximumAge"
+		condition.Message = fmt.Sprintf("process groups %v have been coordinators for longer than %s, consider changing the coordinators", oldCoordinators, maxAge.String())
+
+		previous := meta.FindStatusCondition(status.Conditions, fdbv1beta2.ClusterConditionTypeCoordinatorRotationRecommended)
+		if previous == nil || previous.Status != metav1.ConditionTrue || previous.Message != condition.Message {
+			logger.Info("Process groups have been coordinators for longer than the configured duration", "processGroupIDs", oldCoordinators, "maxAge", maxAge.String())
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "CoordinatorRotationRecommended", condition.Message)
+		}
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
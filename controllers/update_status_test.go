@@ -22,9 +22,11 @@ package controllers
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -43,6 +45,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
 )
 
 var _ = Describe("update_status", func() {
@@ -325,6 +328,15 @@ var _ = Describe("update_status", func() {
 				Expect(len(processGroupStatus.ProcessGroupConditions)).To(Equal(1))
 				Expect(processGroupStatus.ProcessGroupConditions[0].ProcessGroupConditionType).To(Equal(fdbv1beta2.MissingPod))
 			})
+
+			It("should check the PVC of the process group", func() {
+				processGroupStatus := fdbv1beta2.NewProcessGroupStatus("storage-1337", fdbv1beta2.ProcessClassStorage, []string{"1.1.1.1"})
+				processGroupStatus.ProcessGroupConditions = []*fdbv1beta2.ProcessGroupCondition{}
+				pvc := &corev1.PersistentVolumeClaim{Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimLost}}
+				Expect(validateProcessGroup(context.TODO(), clusterReconciler, cluster, nil, pvc, "", processGroupStatus, cluster.IsTaintFeatureDisabled(), logger)).NotTo(HaveOccurred())
+				Expect(processGroupStatus.GetConditionTime(fdbv1beta2.PVCFailed)).NotTo(BeNil())
+				Expect(processGroupStatus.GetConditionTime(fdbv1beta2.MissingPod)).NotTo(BeNil())
+			})
 		})
 
 		When("a process group is fine", func() {
@@ -359,6 +371,54 @@ var _ = Describe("update_status", func() {
 			})
 		})
 
+		When("the PVC of the process group has failed", func() {
+			var pvc *corev1.PersistentVolumeClaim
+
+			BeforeEach(func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, storageOneProcessGroupID)
+				pvcs := &corev1.PersistentVolumeClaimList{}
+				Expect(k8sClient.List(context.TODO(), pvcs, internal.GetSinglePodListOptions(cluster, processGroup.ProcessGroupID)...)).NotTo(HaveOccurred())
+				Expect(pvcs.Items).To(HaveLen(1))
+				pvc = &pvcs.Items[0]
+			})
+
+			When("the PVC is in the Lost phase", func() {
+				BeforeEach(func() {
+					pvc.Status.Phase = corev1.ClaimLost
+					Expect(k8sClient.Update(context.TODO(), pvc)).NotTo(HaveOccurred())
+				})
+
+				It("should get the PVCFailed condition", func() {
+					Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+					Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.PVCFailed, false)).To(Equal([]fdbv1beta2.ProcessGroupID{storageOneProcessGroupID}))
+				})
+
+				When("the Pod of the process group is missing", func() {
+					BeforeEach(func() {
+						Expect(k8sClient.Delete(context.TODO(), storagePod)).NotTo(HaveOccurred())
+					})
+
+					It("should get the PVCFailed and the MissingPod condition", func() {
+						Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+						Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.PVCFailed, false)).To(Equal([]fdbv1beta2.ProcessGroupID{storageOneProcessGroupID}))
+						Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.MissingPod, false)).To(Equal([]fdbv1beta2.ProcessGroupID{storageOneProcessGroupID}))
+					})
+				})
+			})
+
+			When("the PVC is bound again", func() {
+				BeforeEach(func() {
+					pvc.Status.Phase = corev1.ClaimBound
+					Expect(k8sClient.Update(context.TODO(), pvc)).NotTo(HaveOccurred())
+				})
+
+				It("should not get the PVCFailed condition", func() {
+					Expect(validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")).NotTo(HaveOccurred())
+					Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.PVCFailed, false)).To(BeEmpty())
+				})
+			})
+		})
+
 		When("a process group has a process that is excluded", func() {
 			BeforeEach(func() {
 				adminClient.ExcludedAddresses[storagePod.Status.PodIP] = fdbv1beta2.None{}
@@ -375,6 +435,63 @@ var _ = Describe("update_status", func() {
 			})
 		})
 
+		When("a process group has a Pod running a stale image", func() {
+			BeforeEach(func() {
+				for idx, status := range storagePod.Status.ContainerStatuses {
+					if status.Name != fdbv1beta2.MainContainerName {
+						continue
+					}
+
+					storagePod.Status.ContainerStatuses[idx].Image = "docker.io/foundationdb/foundationdb:6.2.20"
+				}
+				Expect(k8sClient.Update(context.TODO(), storagePod)).NotTo(HaveOccurred())
+			})
+
+			When("replacing mismatched images is disabled", func() {
+				It("should not get the IncorrectImage condition", func() {
+					err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")
+					Expect(err).NotTo(HaveOccurred())
+
+					incorrectProcesses := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.IncorrectImage, false)
+					Expect(incorrectProcesses).To(BeEmpty())
+				})
+			})
+
+			When("replacing mismatched images is enabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.ReplaceMismatchedImages = pointer.Bool(true)
+				})
+
+				It("should get the IncorrectImage condition", func() {
+					err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")
+					Expect(err).NotTo(HaveOccurred())
+
+					incorrectProcesses := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.IncorrectImage, false)
+					Expect(incorrectProcesses).To(Equal([]fdbv1beta2.ProcessGroupID{storageOneProcessGroupID}))
+				})
+			})
+		})
+
+		When("the images of a process group were rewritten by a webhook", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ReplaceMismatchedImages = pointer.Bool(true)
+				for idx, container := range storagePod.Spec.Containers {
+					image := "mirror.example.com/" + container.Image
+					storagePod.Spec.Containers[idx].Image = image
+					storagePod.Status.ContainerStatuses[idx].Image = image
+				}
+				Expect(k8sClient.Update(context.TODO(), storagePod)).NotTo(HaveOccurred())
+			})
+
+			It("should not get the IncorrectImage condition", func() {
+				err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPvcs, logger, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				incorrectProcesses := fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.IncorrectImage, false)
+				Expect(incorrectProcesses).To(BeEmpty())
+			})
+		})
+
 		When("a process group has the wrong command line", func() {
 			BeforeEach(func() {
 				adminClient.MockIncorrectCommandLine(storageOneProcessGroupID, true)
@@ -726,17 +843,226 @@ var _ = Describe("update_status", func() {
 			Expect(cluster.Status.Generations.Reconciled).To(Equal(cluster.ObjectMeta.Generation))
 		})
 
+		When("the last replacements per fault domain are recorded", func() {
+			var lastReplacement metav1.Time
+
+			BeforeEach(func() {
+				lastReplacement = metav1.NewTime(time.Now().Add(-5 * time.Minute).Truncate(time.Second))
+				cluster.Status.LastFaultDomainReplacements = map[fdbv1beta2.FaultDomain]metav1.Time{
+					"zone-1": lastReplacement,
+				}
+				Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should keep the last replacements", func() {
+				Expect(cluster.Status.LastFaultDomainReplacements).To(HaveLen(1))
+				Expect(cluster.Status.LastFaultDomainReplacements["zone-1"].Time).To(BeTemporally("==", lastReplacement.Time))
+			})
+		})
+
+		When("a process group is listed in the remove list", func() {
+			var processGroupID fdbv1beta2.ProcessGroupID
+
+			BeforeEach(func() {
+				processGroupID = cluster.Status.ProcessGroups[0].ProcessGroupID
+				cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{processGroupID}
+			})
+
+			It("should mark the process group for removal as requested", func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID)
+				Expect(processGroup.IsMarkedForRemoval()).To(BeTrue())
+				Expect(processGroup.RemovalRequested).To(BeTrue())
+			})
+		})
+
+		When("a process group was removed from the remove lists before it was excluded", func() {
+			var processGroupID fdbv1beta2.ProcessGroupID
+
+			BeforeEach(func() {
+				processGroup := cluster.Status.ProcessGroups[0]
+				processGroupID = processGroup.ProcessGroupID
+				processGroup.MarkForRemoval()
+				processGroup.RemovalRequested = true
+				Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should cancel the removal", func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID)
+				Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				Expect(processGroup.RemovalRequested).To(BeFalse())
+			})
+
+			When("the process group is already excluded", func() {
+				BeforeEach(func() {
+					fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID).SetExclude()
+					Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				})
+
+				It("should keep the process group marked for removal", func() {
+					processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID)
+					Expect(processGroup.IsMarkedForRemoval()).To(BeTrue())
+					Expect(processGroup.IsExcluded()).To(BeTrue())
+				})
+			})
+		})
+
+		When("a process group was marked for removal by the operator", func() {
+			var processGroupID fdbv1beta2.ProcessGroupID
+
+			BeforeEach(func() {
+				processGroup := cluster.Status.ProcessGroups[0]
+				processGroupID = processGroup.ProcessGroupID
+				processGroup.MarkForRemoval()
+				Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should keep the process group marked for removal", func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID)
+				Expect(processGroup.IsMarkedForRemoval()).To(BeTrue())
+				Expect(processGroup.RemovalRequested).To(BeFalse())
+			})
+		})
+
 		It("should set the fault domain for all process groups", func() {
 			for _, processGroup := range cluster.Status.ProcessGroups {
 				Expect(processGroup.FaultDomain).NotTo(BeEmpty())
 			}
 		})
 
+		It("should report all coordinators as reachable", func() {
+			Expect(cluster.Status.CoordinatorAvailability).To(Equal(fdbv1beta2.CoordinatorAvailability{
+				Reachable:   cluster.DesiredCoordinatorCount(),
+				Unreachable: 0,
+				Total:       cluster.DesiredCoordinatorCount(),
+			}))
+		})
+
+		When("one coordinator is not reachable", func() {
+			BeforeEach(func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+
+				status, err := adminClient.GetStatus()
+				Expect(err).NotTo(HaveOccurred())
+
+				var coordinatorID fdbv1beta2.ProcessGroupID
+				for _, process := range status.Cluster.Processes {
+					for _, role := range process.Roles {
+						if role.Role != string(fdbv1beta2.ProcessRoleCoordinator) {
+							continue
+						}
+
+						coordinatorID = fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+					}
+				}
+
+				Expect(coordinatorID).NotTo(BeEmpty())
+				adminClient.MockMissingProcessGroup(coordinatorID, true)
+			})
+
+			It("should report the unreachable coordinator", func() {
+				Expect(cluster.Status.CoordinatorAvailability).To(Equal(fdbv1beta2.CoordinatorAvailability{
+					Reachable:   cluster.DesiredCoordinatorCount() - 1,
+					Unreachable: 1,
+					Total:       cluster.DesiredCoordinatorCount(),
+				}))
+			})
+		})
+
+		It("should track since when the process groups are coordinators", func() {
+			var coordinators int
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.CoordinatorTimestamp != nil {
+					coordinators++
+				}
+			}
+
+			Expect(coordinators).To(Equal(cluster.DesiredCoordinatorCount()))
+		})
+
+		When("a coordinator age warning is configured", func() {
+			var getCoordinatorRotationEvents func() []corev1.Event
+
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.CoordinatorAgeWarningSeconds = pointer.Int(3600)
+
+				getCoordinatorRotationEvents = func() []corev1.Event {
+					events := &corev1.EventList{}
+					Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+					var matchingEvents []corev1.Event
+					for _, event := range events.Items {
+						if event.InvolvedObject.UID == cluster.ObjectMeta.UID && event.Reason == "CoordinatorRotationRecommended" {
+							matchingEvents = append(matchingEvents, event)
+						}
+					}
+
+					return matchingEvents
+				}
+			})
+
+			When("the coordinators were recently selected", func() {
+				It("should not emit a warning", func() {
+					Expect(getCoordinatorRotationEvents()).To(BeEmpty())
+					Expect(meta.IsStatusConditionFalse(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeCoordinatorRotationRecommended)).To(BeTrue())
+				})
+			})
+
+			When("the coordinators are older than the configured period", func() {
+				var oldTimestamp metav1.Time
+
+				BeforeEach(func() {
+					oldTimestamp = metav1.Time{Time: time.Now().Add(-2 * time.Hour).Truncate(time.Second)}
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						if processGroup.CoordinatorTimestamp == nil {
+							continue
+						}
+
+						processGroup.CoordinatorTimestamp = oldTimestamp.DeepCopy()
+					}
+
+					Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				})
+
+				It("should emit a warning", func() {
+					Expect(getCoordinatorRotationEvents()).To(HaveLen(1))
+					Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeCoordinatorRotationRecommended)).To(BeTrue())
+				})
+
+				When("the status is updated again", func() {
+					JustBeforeEach(func() {
+						requeue = updateStatus{}.reconcile(context.TODO(), clusterReconciler, cluster, nil, globalControllerLogger)
+						if requeue != nil {
+							Expect(requeue.curError).NotTo(HaveOccurred())
+						}
+						_, err = reloadCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("should not emit another warning", func() {
+						Expect(getCoordinatorRotationEvents()).To(HaveLen(1))
+					})
+				})
+
+				It("should keep the coordinator timestamps", func() {
+					for _, processGroup := range cluster.Status.ProcessGroups {
+						if processGroup.CoordinatorTimestamp == nil {
+							continue
+						}
+
+						Expect(processGroup.CoordinatorTimestamp.Time).To(BeTemporally("==", oldTimestamp.Time))
+					}
+				})
+			})
+		})
+
 		When("disabling an explicit listen address", func() {
 			BeforeEach(func() {
 				result, err := reconcileCluster(cluster)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(result.Requeue).To(BeFalse())
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
 				cluster.Spec.UseExplicitListenAddress = pointer.Bool(false)
 			})
 
@@ -950,4 +1276,337 @@ var _ = Describe("update_status", func() {
 			})
 		})
 	})
+
+	When("updating the exclusion progress based on the cluster status", func() {
+		var status *fdbv1beta2.FoundationDBClusterStatus
+		var databaseStatus *fdbv1beta2.FoundationDBStatus
+		var removedProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+		setStoredBytes := func(storedBytes int) {
+			databaseStatus.Cluster.Processes["storage-1"] = fdbv1beta2.FoundationDBStatusProcessInfo{
+				Excluded: true,
+				Locality: map[string]string{
+					fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+				},
+				Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+					{
+						Role:        string(fdbv1beta2.ProcessRoleStorage),
+						StoredBytes: storedBytes,
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			removedProcessGroup = &fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage}
+			removedProcessGroup.MarkForRemoval()
+			status = &fdbv1beta2.FoundationDBClusterStatus{
+				ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+					removedProcessGroup,
+					{ProcessGroupID: "storage-2", ProcessClass: fdbv1beta2.ProcessClassStorage},
+				},
+			}
+
+			databaseStatus = &fdbv1beta2.FoundationDBStatus{
+				Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+					DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+						Available: true,
+					},
+				},
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"storage-2": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-2",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleStorage),
+									StoredBytes: 4096,
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		When("the process group is not yet excluded in the database", func() {
+			BeforeEach(func() {
+				updateExclusionProgress(globalControllerLogger, databaseStatus, status)
+			})
+
+			It("should not set the exclusion progress", func() {
+				for _, processGroup := range status.ProcessGroups {
+					Expect(processGroup.ExclusionProgress).To(BeNil())
+				}
+			})
+		})
+
+		When("the exclusion of the process group is ongoing", func() {
+			BeforeEach(func() {
+				setStoredBytes(2048)
+				updateExclusionProgress(globalControllerLogger, databaseStatus, status)
+			})
+
+			It("should set the exclusion progress for the removed process group", func() {
+				Expect(removedProcessGroup.ExclusionProgress).To(Equal(&fdbv1beta2.ExclusionProgress{
+					InitialBytes:   2048,
+					RemainingBytes: 2048,
+				}))
+				Expect(status.ProcessGroups[1].ExclusionProgress).To(BeNil())
+			})
+
+			When("data was moved off the process group", func() {
+				BeforeEach(func() {
+					setStoredBytes(512)
+					updateExclusionProgress(globalControllerLogger, databaseStatus, status)
+				})
+
+				It("should update the remaining bytes", func() {
+					Expect(removedProcessGroup.ExclusionProgress).To(Equal(&fdbv1beta2.ExclusionProgress{
+						InitialBytes:   2048,
+						RemainingBytes: 512,
+					}))
+				})
+			})
+
+			When("the database is not available", func() {
+				BeforeEach(func() {
+					setStoredBytes(512)
+					databaseStatus.Client.DatabaseStatus.Available = false
+					updateExclusionProgress(globalControllerLogger, databaseStatus, status)
+				})
+
+				It("should keep the last exclusion progress", func() {
+					Expect(removedProcessGroup.ExclusionProgress).To(Equal(&fdbv1beta2.ExclusionProgress{
+						InitialBytes:   2048,
+						RemainingBytes: 2048,
+					}))
+				})
+			})
+
+			When("the process group is fully excluded", func() {
+				BeforeEach(func() {
+					removedProcessGroup.SetExclude()
+					updateExclusionProgress(globalControllerLogger, databaseStatus, status)
+				})
+
+				It("should remove the exclusion progress", func() {
+					Expect(removedProcessGroup.ExclusionProgress).To(BeNil())
+				})
+			})
+		})
+	})
+})
+
+var _ = DescribeTable("getTaintReplacementDelay", func(options []fdbv1beta2.TaintReplacementOption, conditions []*fdbv1beta2.ProcessGroupCondition, expected time.Duration) {
+	now := time.Unix(10000, 0)
+	cluster := &fdbv1beta2.FoundationDBCluster{
+		Spec: fdbv1beta2.FoundationDBClusterSpec{
+			AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+				Replacements: fdbv1beta2.AutomaticReplacementOptions{
+					TaintReplacementOptions: options,
+				},
+			},
+		},
+	}
+
+	processGroups := []*fdbv1beta2.ProcessGroupStatus{
+		{
+			ProcessGroupID:         "storage-1",
+			ProcessGroupConditions: conditions,
+		},
+	}
+
+	Expect(getTaintReplacementDelay(cluster, processGroups, now)).To(Equal(expected))
+},
+	Entry("no taint was detected",
+		[]fdbv1beta2.TaintReplacementOption{{Key: pointer.String("*"), DurationInSeconds: pointer.Int64(300)}},
+		nil,
+		time.Duration(0),
+	),
+	Entry("a taint was detected",
+		[]fdbv1beta2.TaintReplacementOption{{Key: pointer.String("*"), DurationInSeconds: pointer.Int64(300)}},
+		[]*fdbv1beta2.ProcessGroupCondition{{ProcessGroupConditionType: fdbv1beta2.NodeTaintDetected, Timestamp: 9900}},
+		201*time.Second,
+	),
+	Entry("a taint was detected with multiple options",
+		[]fdbv1beta2.TaintReplacementOption{
+			{Key: pointer.String("*"), DurationInSeconds: pointer.Int64(300)},
+			{Key: pointer.String("example.org/maintenance"), DurationInSeconds: pointer.Int64(50)},
+			{Key: pointer.String("example.org/disabled"), DurationInSeconds: pointer.Int64(-1)},
+			{Key: pointer.String("example.org/never"), DurationInSeconds: pointer.Int64(math.MaxInt64)},
+		},
+		[]*fdbv1beta2.ProcessGroupCondition{{ProcessGroupConditionType: fdbv1beta2.NodeTaintDetected, Timestamp: 9900}},
+		201*time.Second,
+	),
+	Entry("the taint duration has passed",
+		[]fdbv1beta2.TaintReplacementOption{{Key: pointer.String("*"), DurationInSeconds: pointer.Int64(60)}},
+		[]*fdbv1beta2.ProcessGroupCondition{{ProcessGroupConditionType: fdbv1beta2.NodeTaintDetected, Timestamp: 9900}},
+		time.Duration(0),
+	),
+	Entry("the process group is already being replaced",
+		[]fdbv1beta2.TaintReplacementOption{{Key: pointer.String("*"), DurationInSeconds: pointer.Int64(300)}},
+		[]*fdbv1beta2.ProcessGroupCondition{
+			{ProcessGroupConditionType: fdbv1beta2.NodeTaintDetected, Timestamp: 9900},
+			{ProcessGroupConditionType: fdbv1beta2.NodeTaintReplacing, Timestamp: 9950},
+		},
+		time.Duration(0),
+	),
+)
+
+var _ = Describe("updateClusterConditions", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var initialTime time.Time
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Status.Health.Available = true
+		cluster.Status.Health.FullReplication = true
+		initialTime = time.Now().Add(-time.Hour).Truncate(time.Second)
+		updateClusterConditions(cluster, true, initialTime)
+	})
+
+	When("the status of a condition does not change", func() {
+		BeforeEach(func() {
+			updateClusterConditions(cluster, true, time.Now())
+		})
+
+		It("should keep the last transition time", func() {
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeAvailable)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.LastTransitionTime.Time).To(BeTemporally("==", initialTime))
+		})
+	})
+
+	When("the database becomes unavailable", func() {
+		BeforeEach(func() {
+			cluster.Status.Health.Available = false
+			updateClusterConditions(cluster, true, time.Now())
+		})
+
+		It("should update the available condition", func() {
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeAvailable)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("DatabaseUnavailable"))
+			Expect(condition.LastTransitionTime.Time).To(BeTemporally(">", initialTime))
+		})
+
+		It("should not change the other conditions", func() {
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeFullReplication)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.LastTransitionTime.Time).To(BeTemporally("==", initialTime))
+		})
+	})
+})
+
+var _ = Describe("cluster conditions", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var fakeClock *testingclock.FakeClock
+	var startTime time.Time
+
+	BeforeEach(func() {
+		startTime = time.Now().Truncate(time.Second)
+		fakeClock = testingclock.NewFakeClock(startTime)
+		clusterReconciler.Clock = fakeClock
+
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		clusterReconciler.Clock = nil
+	})
+
+	It("should set the cluster conditions", func() {
+		Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeReconciled)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeAvailable)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeFullReplication)).To(BeTrue())
+		Expect(meta.IsStatusConditionFalse(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeReconciliationStalled)).To(BeTrue())
+
+		for _, condition := range cluster.Status.Conditions {
+			Expect(condition.ObservedGeneration).To(Equal(cluster.ObjectMeta.Generation))
+			Expect(condition.LastTransitionTime.Time).To(BeTemporally("==", startTime))
+		}
+	})
+
+	When("a sub-reconciler delays the requeue", func() {
+		BeforeEach(func() {
+			// Skip the removal, otherwise the removeProcessGroups sub-reconciler would report the missing exclusion.
+			cluster.Annotations = map[string]string{
+				fdbv1beta2.SkipReconcilersAnnotation: "removeProcessGroups",
+			}
+			cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{cluster.Status.ProcessGroups[0].ProcessGroupID}
+			cluster.Spec.AutomationOptions.ReconciliationStalledSeconds = pointer.Int(60)
+			cluster.Spec.AutomationOptions.ExclusionMaintenanceWindows = []fdbv1beta2.MaintenanceWindow{
+				{
+					Start: "00:00",
+					End:   "00:00",
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			_, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should mark the cluster as not reconciled", func() {
+			condition := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeReconciled)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.LastTransitionTime.Time).To(BeTemporally("==", startTime))
+			Expect(meta.IsStatusConditionFalse(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeReconciliationStalled)).To(BeTrue())
+		})
+
+		When("the requeue is delayed for longer than the stalled duration", func() {
+			JustBeforeEach(func() {
+				fakeClock.Step(2 * time.Minute)
+				_, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should mark the reconciliation as stalled", func() {
+				condition := meta.FindStatusCondition(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeReconciliationStalled)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Message).To(ContainSubstring("controllers.excludeProcesses"))
+				Expect(condition.LastTransitionTime.Time).To(BeTemporally("==", startTime.Add(2*time.Minute)))
+			})
+
+			When("the stalled duration is longer", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.ReconciliationStalledSeconds = pointer.Int(3600)
+				})
+
+				It("should not mark the reconciliation as stalled", func() {
+					Expect(meta.IsStatusConditionFalse(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeReconciliationStalled)).To(BeTrue())
+				})
+			})
+
+			When("the requeue was observed before the cluster became unreconciled", func() {
+				BeforeEach(func() {
+					cluster.Status.LastRequeue = &fdbv1beta2.RequeueInfo{
+						Reconciler: "controllers.excludeProcesses",
+						Message:    "exclusions are deferred during the exclusion maintenance window",
+						Delayed:    true,
+						Timestamp:  &metav1.Time{Time: startTime.Add(-5 * time.Minute)},
+					}
+					Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+				})
+
+				It("should not mark the reconciliation as stalled", func() {
+					Expect(meta.IsStatusConditionFalse(cluster.Status.Conditions, fdbv1beta2.ClusterConditionTypeReconciliationStalled)).To(BeTrue())
+				})
+			})
+		})
+	})
 })
@@ -107,6 +107,12 @@ type ClusterConfig struct {
 	CustomParameters map[fdbv1beta2.ProcessClass]fdbv1beta2.FoundationDBCustomParameters
 	// CreationCallback allows to specify a method that will be called after the cluster was created.
 	CreationCallback func(fdbCluster *FdbCluster)
+	// MinimumRecoveryTimeForExclusionSeconds if set overrides the operator default for the minimum time since the last
+	// recovery before exclusions are allowed.
+	MinimumRecoveryTimeForExclusionSeconds *int
+	// MinimumRecoveryTimeForInclusionSeconds if set overrides the operator default for the minimum time since the last
+	// recovery before inclusions are allowed.
+	MinimumRecoveryTimeForInclusionSeconds *int
 }
 
 // DefaultClusterConfigWithHaMode returns the default cluster configuration with the provided HA Mode.
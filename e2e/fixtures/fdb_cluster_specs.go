@@ -84,7 +84,9 @@ func (factory *Factory) createFDBClusterSpec(
 				IgnoreLogGroupsForUpgrade: []fdbv1beta2.LogGroup{
 					"fdb-kubernetes-operator",
 				},
-				UseLocalitiesForExclusion: pointer.Bool(config.UseLocalityBasedExclusions),
+				UseLocalitiesForExclusion:              pointer.Bool(config.UseLocalityBasedExclusions),
+				MinimumRecoveryTimeForExclusionSeconds: config.MinimumRecoveryTimeForExclusionSeconds,
+				MinimumRecoveryTimeForInclusionSeconds: config.MinimumRecoveryTimeForInclusionSeconds,
 			},
 			Routing: fdbv1beta2.RoutingConfig{
 				UseDNSInClusterFile: pointer.Bool(config.UseDNS),
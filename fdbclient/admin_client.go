@@ -51,6 +51,9 @@ const (
 	fdbrestoreStr = "fdbrestore"
 )
 
+// backupTimestampFormat defines the timestamp format that is accepted by fdbbackup.
+const backupTimestampFormat = "2006/01/02.15:04:05-0700"
+
 var maxCommandOutput = parseMaxCommandOutput()
 
 func parseMaxCommandOutput() int {
@@ -67,6 +70,16 @@ func parseMaxCommandOutput() int {
 
 var protocolVersionRegex = regexp.MustCompile(`(?m)^protocol (\w+)$`)
 
+var (
+	restoreTagRegex          = regexp.MustCompile(`Tag: (\S+)`)
+	restoreStateRegex        = regexp.MustCompile(`State: (\S+)`)
+	restoreBlocksRegex       = regexp.MustCompile(`Blocks: (\d+)/(\d+)`)
+	restoreBytesWrittenRegex = regexp.MustCompile(`BytesWritten: (\d+)`)
+	restoreURLRegex          = regexp.MustCompile(`URL: (\S+)`)
+	restoreLastErrorRegex    = regexp.MustCompile(`(?s)LastError: (.*?)\s*(?:URL: |$)`)
+	restoreVersionRegex      = regexp.MustCompile(`(?:^|\s)Version: (\d+)`)
+)
+
 // cliAdminClient provides an implementation of the admin interface using the FDB CLI.
 type cliAdminClient struct {
 	// Cluster is the reference to the cluster model.
@@ -278,7 +291,7 @@ func (client *cliAdminClient) runCommand(command cliCommand) (string, error) {
 func (client *cliAdminClient) getStatusFromCli() (*fdbv1beta2.FoundationDBStatus, error) {
 	// Always use the max timeout here. Otherwise we will retry multiple times with an increasing timeout. As the
 	// timeout is only the upper bound using directly the max timeout reduces the calls to a single call.
-	output, err := client.runCommand(cliCommand{command: "status json", timeout: client.getTimeout()})
+	output, err := client.runCommand(cliCommand{command: "status json", timeout: client.GetTimeout()})
 	if err != nil {
 		return nil, err
 	}
@@ -315,7 +328,7 @@ func (client *cliAdminClient) getStatus() (*fdbv1beta2.FoundationDBStatus, error
 func (client *cliAdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 	startTime := time.Now()
 	// This will call directly the database and fetch the status information from the system key space.
-	status, err := getStatusFromDB(client.fdbLibClient, client.log, client.getTimeout())
+	status, err := getStatusFromDB(client.fdbLibClient, client.log, client.GetTimeout())
 	// There is a limitation in the multi version client if the cluster is only partially upgraded e.g. because not
 	// all fdbserver processes are restarted, then the multi version client sometimes picks the wrong version
 	// to connect to the cluster. This will result in an empty status only reporting the unreachable coordinators.
@@ -349,7 +362,7 @@ func (client *cliAdminClient) ConfigureDatabase(configuration fdbv1beta2.Databas
 
 // GetMaintenanceZone gets current maintenance zone, if any. Returns empty string if maintenance mode is off
 func (client *cliAdminClient) GetMaintenanceZone() (string, error) {
-	mode, err := client.fdbLibClient.getValueFromDBUsingKey("\xff/maintenance", client.getTimeout())
+	mode, err := client.fdbLibClient.getValueFromDBUsingKey("\xff/maintenance", client.GetTimeout())
 	if err != nil {
 		return "", err
 	}
@@ -378,6 +391,12 @@ func (client *cliAdminClient) ResetMaintenanceMode() error {
 
 // ExcludeProcesses starts evacuating processes so that they can be removed from the database.
 func (client *cliAdminClient) ExcludeProcesses(addresses []fdbv1beta2.ProcessAddress) error {
+	return client.ExcludeProcessesWithFailed(addresses, false)
+}
+
+// ExcludeProcessesWithFailed starts evacuating processes so that they can be removed from the database. If failed is
+// true, the processes will be excluded with the failed flag.
+func (client *cliAdminClient) ExcludeProcessesWithFailed(addresses []fdbv1beta2.ProcessAddress, failed bool) error {
 	if len(addresses) == 0 {
 		return nil
 	}
@@ -389,13 +408,16 @@ func (client *cliAdminClient) ExcludeProcesses(addresses []fdbv1beta2.ProcessAdd
 
 	var excludeCommand strings.Builder
 	excludeCommand.WriteString("exclude ")
+	if failed {
+		excludeCommand.WriteString("failed ")
+	}
 	if version.HasNonBlockingExcludes(client.Cluster.GetUseNonBlockingExcludes()) {
 		excludeCommand.WriteString("no_wait ")
 	}
 
 	excludeCommand.WriteString(fdbv1beta2.ProcessAddressesString(addresses, " "))
 
-	_, err = client.runCommand(cliCommand{command: excludeCommand.String(), timeout: client.getTimeout()})
+	_, err = client.runCommand(cliCommand{command: excludeCommand.String(), timeout: client.GetTimeout()})
 
 	return err
 }
@@ -412,6 +434,63 @@ func (client *cliAdminClient) IncludeProcesses(addresses []fdbv1beta2.ProcessAdd
 	return err
 }
 
+// includeProcessWithTimeout removes a single process from the exclusion list. The timeout of the command is capped at the
+// provided remaining time.
+func (client *cliAdminClient) includeProcessWithTimeout(address fdbv1beta2.ProcessAddress, remaining time.Duration) error {
+	timeout := DefaultCLITimeout
+	if remaining < timeout {
+		timeout = remaining
+	}
+
+	// The --timeout argument only accepts full seconds.
+	if timeout < time.Second {
+		timeout = time.Second
+	}
+
+	_, err := client.runCommand(cliCommand{
+		command: fmt.Sprintf("include %s", address.String()),
+		timeout: timeout,
+	})
+
+	return err
+}
+
+// IncludeProcessesWithResults removes processes from the exclusion list and reports the result for every address. All
+// addresses are included with a single command, if this command fails the addresses are included one by one to find
+// the addresses that cannot be included. The one by one inclusion is bounded by MaxCliTimeout, addresses that were not
+// tried in this time are reported with an error and will be included in a later reconciliation.
+func (client *cliAdminClient) IncludeProcessesWithResults(addresses []fdbv1beta2.ProcessAddress) ([]fdbadminclient.InclusionResult, error) {
+	results := make([]fdbadminclient.InclusionResult, 0, len(addresses))
+	err := client.IncludeProcesses(addresses)
+	if err == nil || len(addresses) == 1 {
+		for _, address := range addresses {
+			results = append(results, fdbadminclient.InclusionResult{Address: address, Err: err})
+		}
+
+		return results, fdbadminclient.GetInclusionError(results)
+	}
+
+	client.log.Info("could not include all addresses with a single command, including addresses one by one", "error", err.Error())
+	deadline := time.Now().Add(MaxCliTimeout)
+	for _, address := range addresses {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			results = append(results, fdbadminclient.InclusionResult{
+				Address: address,
+				Err:     fmt.Errorf("skipped inclusion of %s, the one by one inclusion exceeded %s", address.String(), MaxCliTimeout.String()),
+			})
+			continue
+		}
+
+		results = append(results, fdbadminclient.InclusionResult{
+			Address: address,
+			Err:     client.includeProcessWithTimeout(address, remaining),
+		})
+	}
+
+	return results, fdbadminclient.GetInclusionError(results)
+}
+
 // GetExclusions gets a list of the addresses currently excluded from the
 // database.
 func (client *cliAdminClient) GetExclusions() ([]fdbv1beta2.ProcessAddress, error) {
@@ -446,7 +525,7 @@ func (client *cliAdminClient) KillProcesses(addresses []fdbv1beta2.ProcessAddres
 		fdbv1beta2.ProcessAddressesStringWithoutFlags(addresses, " "),
 	)
 	// Run the kill command once with the max timeout to reduce the risk of multiple recoveries happening.
-	_, err := client.runCommand(cliCommand{command: killCommand, timeout: client.getTimeout()})
+	_, err := client.runCommand(cliCommand{command: killCommand, timeout: client.GetTimeout()})
 
 	return err
 }
@@ -489,7 +568,7 @@ func cleanConnectionStringOutput(input string) string {
 func (client *cliAdminClient) GetConnectionString() (string, error) {
 	// This will call directly the database and fetch the connection string
 	// from the system key space.
-	outputBytes, err := getConnectionStringFromDB(client.fdbLibClient, client.getTimeout())
+	outputBytes, err := getConnectionStringFromDB(client.fdbLibClient, client.GetTimeout())
 
 	if err != nil {
 		return "", err
@@ -531,27 +610,81 @@ func (client *cliAdminClient) GetProtocolVersion(version string) (string, error)
 	return protocolVersionMatch[1], nil
 }
 
+// StartBackup starts a new backup with the default tag.
 func (client *cliAdminClient) StartBackup(url string, snapshotPeriodSeconds int) error {
+	return client.StartBackupWithTag(url, fdbv1beta2.DefaultBackupTag, snapshotPeriodSeconds, "", nil)
+}
+
+// StartBackupWithTag starts a new backup with the provided tag.
+func (client *cliAdminClient) StartBackupWithTag(url string, tag string, snapshotPeriodSeconds int, blobCredentialsPath string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error {
 	_, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
-		args: []string{
-			"start",
-			"-d",
-			url,
-			"-s",
-			fmt.Sprintf("%d", snapshotPeriodSeconds),
-			"-z",
-		},
+		args:   getStartBackupArgs(url, tag, snapshotPeriodSeconds, blobCredentialsPath, keyRanges),
 	})
 	return err
 }
 
-// StopBackup stops a backup.
+// getStartBackupArgs returns the arguments for the backup start command. Every key range is passed with a separate
+// -k option, in the same escaped form that is used for the restore start command.
+func getStartBackupArgs(url string, tag string, snapshotPeriodSeconds int, blobCredentialsPath string, keyRanges []fdbv1beta2.FoundationDBKeyRange) []string {
+	args := []string{
+		"start",
+		"-d",
+		url,
+		"-t",
+		tag,
+		"-s",
+		fmt.Sprintf("%d", snapshotPeriodSeconds),
+		"-z",
+	}
+
+	if blobCredentialsPath != "" {
+		args = append(args, "--blob-credentials", blobCredentialsPath)
+	}
+
+	for _, keyRange := range keyRanges {
+		args = append(args, "-k", keyRange.Start+" "+keyRange.End)
+	}
+
+	return args
+}
+
+// StopBackup stops the backup with the default tag.
 func (client *cliAdminClient) StopBackup(_ string) error {
+	return client.StopBackupWithTag(fdbv1beta2.DefaultBackupTag)
+}
+
+// StopBackupWithTag stops the backup with the provided tag.
+func (client *cliAdminClient) StopBackupWithTag(tag string) error {
 	_, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"discontinue",
+			"-t",
+			tag,
+		},
+	})
+	return err
+}
+
+// AbortBackup aborts the backup with the provided tag. If no backup is running for the tag, this is a no-op.
+func (client *cliAdminClient) AbortBackup(url string, tag string) error {
+	status, err := client.GetBackupStatusWithTag(tag)
+	if err != nil {
+		return err
+	}
+
+	if !status.Status.Running {
+		return nil
+	}
+
+	client.log.Info("Aborting backup", "tag", tag, "url", url)
+	_, err = client.runCommand(cliCommand{
+		binary: fdbbackupStr,
+		args: []string{
+			"abort",
+			"-t",
+			tag,
 		},
 	})
 	return err
@@ -579,12 +712,19 @@ func (client *cliAdminClient) ResumeBackups() error {
 	return err
 }
 
-// ModifyBackup updates the backup parameters.
+// ModifyBackup updates the backup parameters of the backup with the default tag.
 func (client *cliAdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
+	return client.ModifyBackupWithTag(fdbv1beta2.DefaultBackupTag, snapshotPeriodSeconds)
+}
+
+// ModifyBackupWithTag updates the backup parameters of the backup with the provided tag.
+func (client *cliAdminClient) ModifyBackupWithTag(tag string, snapshotPeriodSeconds int) error {
 	_, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"modify",
+			"-t",
+			tag,
 			"-s",
 			fmt.Sprintf("%d", snapshotPeriodSeconds),
 		},
@@ -592,12 +732,43 @@ func (client *cliAdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
 	return err
 }
 
-// GetBackupStatus gets the status of the current backup.
+// ExpireBackup deletes the backup data that is older than expireBefore.
+func (client *cliAdminClient) ExpireBackup(url string, expireBefore time.Time, restorableAfter time.Time, blobCredentialsPath string) (string, error) {
+	args := []string{
+		"expire",
+		"-d",
+		url,
+		"--expire_before_timestamp",
+		expireBefore.Format(backupTimestampFormat),
+		"--restorable_after_timestamp",
+		restorableAfter.Format(backupTimestampFormat),
+	}
+
+	if blobCredentialsPath != "" {
+		args = append(args, "--blob-credentials", blobCredentialsPath)
+	}
+
+	// Expiring the backup data could take some time, so we use the max timeout here.
+	return client.runCommand(cliCommand{
+		binary:  fdbbackupStr,
+		args:    args,
+		timeout: client.GetTimeout(),
+	})
+}
+
+// GetBackupStatus gets the status of the backup with the default tag.
 func (client *cliAdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
+	return client.GetBackupStatusWithTag(fdbv1beta2.DefaultBackupTag)
+}
+
+// GetBackupStatusWithTag gets the status of the backup with the provided tag.
+func (client *cliAdminClient) GetBackupStatusWithTag(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
 	statusString, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"status",
+			"-t",
+			tag,
 			"--json",
 		},
 	})
@@ -620,31 +791,117 @@ func (client *cliAdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBac
 	return status, nil
 }
 
-// StartRestore starts a new restore.
-func (client *cliAdminClient) StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error {
+// DescribeBackup describes the data of the backup in the blob store.
+func (client *cliAdminClient) DescribeBackup(url string, blobCredentialsPath string) (*fdbv1beta2.FoundationDBBackupDescription, error) {
+	descriptionString, err := client.runCommand(cliCommand{
+		binary: fdbbackupStr,
+		args:   getDescribeBackupArgs(url, blobCredentialsPath),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBackupDescription(descriptionString)
+}
+
+// getDescribeBackupArgs returns the arguments for the backup describe command. The versions are converted into
+// timestamps with the help of the cluster, otherwise the description would only contain versions.
+func getDescribeBackupArgs(url string, blobCredentialsPath string) []string {
+	args := []string{
+		"describe",
+		"-d",
+		url,
+		"--version_timestamps",
+		"--json",
+	}
+
+	if blobCredentialsPath != "" {
+		args = append(args, "--blob-credentials", blobCredentialsPath)
+	}
+
+	return args
+}
+
+// parseBackupDescription parses the JSON output of the backup describe command.
+func parseBackupDescription(descriptionString string) (*fdbv1beta2.FoundationDBBackupDescription, error) {
+	descriptionBytes, err := fdbstatus.RemoveWarningsInJSON(descriptionString)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &fdbv1beta2.FoundationDBBackupDescription{}
+	err = json.Unmarshal(descriptionBytes, description)
+	if err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+// StartRestore starts a new restore. If the version is 0, the latest restorable version will be restored.
+func (client *cliAdminClient) StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, version int64) error {
+	_, err := client.runCommand(cliCommand{
+		binary: fdbrestoreStr,
+		args:   getStartRestoreArgs(url, keyRanges, version),
+	})
+	return err
+}
+
+// getStartRestoreArgs returns the arguments for the restore start command. Every key range is passed with a separate
+// -k option. The keys are passed in their escaped form, fdbrestore will convert the `\xBB` escapes into the raw bytes.
+func getStartRestoreArgs(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, version int64) []string {
 	args := []string{
 		"start",
 		"-r",
 		url,
 	}
 
-	if keyRanges != nil {
-		keyRangeString := ""
-		for _, keyRange := range keyRanges {
-			if keyRangeString != "" {
-				keyRangeString += ";"
-			}
-			keyRangeString += keyRange.Start + " " + keyRange.End
-		}
-		args = append(args, "-k", keyRangeString)
+	if version > 0 {
+		args = append(args, "-v", strconv.FormatInt(version, 10))
 	}
-	_, err := client.runCommand(cliCommand{
+
+	for _, keyRange := range keyRanges {
+		args = append(args, "-k", keyRange.Start+" "+keyRange.End)
+	}
+
+	return args
+}
+
+// StartRestoreAtTimestamp starts a new restore of the backup data at the provided timestamp. fdbrestore resolves the
+// timestamp to a version based on the version history of the source cluster.
+func (client *cliAdminClient) StartRestoreAtTimestamp(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, timestamp time.Time, sourceCluster *fdbv1beta2.FoundationDBCluster) error {
+	sourceClusterFile, err := createClusterFile(sourceCluster)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.runCommand(cliCommand{
 		binary: fdbrestoreStr,
-		args:   args,
+		args:   getStartRestoreAtTimestampArgs(url, keyRanges, timestamp, sourceClusterFile),
 	})
 	return err
 }
 
+// getStartRestoreAtTimestampArgs returns the arguments for the restore start command with a timestamp. The keys are
+// passed in the same form as for getStartRestoreArgs.
+func getStartRestoreAtTimestampArgs(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, timestamp time.Time, sourceClusterFile string) []string {
+	args := []string{
+		"start",
+		"-r",
+		url,
+		"--timestamp",
+		timestamp.UTC().Format(backupTimestampFormat),
+		"--orig_cluster_file",
+		sourceClusterFile,
+	}
+
+	for _, keyRange := range keyRanges {
+		args = append(args, "-k", keyRange.Start+" "+keyRange.End)
+	}
+
+	return args
+}
+
 // GetRestoreStatus gets the status of the current restore.
 func (client *cliAdminClient) GetRestoreStatus() (string, error) {
 	return client.runCommand(cliCommand{
@@ -655,6 +912,165 @@ func (client *cliAdminClient) GetRestoreStatus() (string, error) {
 	})
 }
 
+// GetLiveRestoreStatus gets the parsed status of the current restore.
+func (client *cliAdminClient) GetLiveRestoreStatus() (*fdbv1beta2.FoundationDBLiveRestoreStatus, error) {
+	statusString, err := client.GetRestoreStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRestoreStatus(statusString)
+}
+
+// AbortRestore aborts the restore with the provided tag. If no restore is in progress for the tag, this is a no-op.
+func (client *cliAdminClient) AbortRestore(tag string) error {
+	status, err := client.GetLiveRestoreStatus()
+	if err != nil {
+		return err
+	}
+
+	if status.Tag != tag || !status.GetState().IsInProgress() {
+		return nil
+	}
+
+	client.log.Info("Aborting restore", "tag", tag, "url", status.URL)
+	_, err = client.runCommand(cliCommand{
+		binary: fdbrestoreStr,
+		args: []string{
+			"abort",
+			"-t",
+			tag,
+		},
+	})
+	return err
+}
+
+// IsKeyspaceEmpty checks if the provided key ranges contain no keys. If no key ranges are provided, the whole user
+// keyspace will be checked.
+func (client *cliAdminClient) IsKeyspaceEmpty(keyRanges []fdbv1beta2.FoundationDBKeyRange) (bool, error) {
+	fdbKeyRanges := make([]fdb.KeyRange, 0, len(keyRanges))
+	for _, keyRange := range keyRanges {
+		start, end, err := keyRange.GetRawKeys()
+		if err != nil {
+			return false, err
+		}
+
+		fdbKeyRanges = append(fdbKeyRanges, fdb.KeyRange{Begin: fdb.Key(start), End: fdb.Key(end)})
+	}
+
+	if len(fdbKeyRanges) == 0 {
+		fdbKeyRanges = append(fdbKeyRanges, fdb.KeyRange{Begin: fdb.Key(""), End: fdb.Key("\xff")})
+	}
+
+	db, err := getFDBDatabase(client.Cluster)
+	if err != nil {
+		return false, err
+	}
+
+	empty, err := db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		err := tr.Options().SetTimeout(client.GetTimeout().Milliseconds())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, keyRange := range fdbKeyRanges {
+			results, err := tr.GetRange(keyRange, fdb.RangeOptions{Limit: 1}).GetSliceWithError()
+			if err != nil {
+				return nil, err
+			}
+
+			if len(results) > 0 {
+				client.log.Info("found key in keyspace", "begin", keyRange.Begin.FDBKey().String(), "end", keyRange.End.FDBKey().String())
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	isEmpty, isBool := empty.(bool)
+	if !isBool {
+		return false, fmt.Errorf("invalid return value from transaction in IsKeyspaceEmpty: %v", empty)
+	}
+
+	return isEmpty, nil
+}
+
+// parseRestoreStatus parses the output of the restore status command. fdbrestore doesn't support a JSON output for the
+// status, so the fields are parsed from the text output, e.g.:
+//
+//	Tag: default  UID: 0123  State: running  Blocks: 10/20  BlocksInProgress: 2  Files: 5  BytesWritten: 1024 ... LastError:   URL: blobstore://...
+//
+// If no restore was submitted, an empty status will be returned.
+func parseRestoreStatus(statusString string) (*fdbv1beta2.FoundationDBLiveRestoreStatus, error) {
+	status := &fdbv1beta2.FoundationDBLiveRestoreStatus{}
+
+	tag := restoreTagRegex.FindStringSubmatch(statusString)
+	if tag == nil {
+		return status, nil
+	}
+	status.Tag = tag[1]
+
+	state := restoreStateRegex.FindStringSubmatch(statusString)
+	if state != nil {
+		status.State = state[1]
+	}
+
+	blocks := restoreBlocksRegex.FindStringSubmatch(statusString)
+	if blocks != nil {
+		blocksCompleted, err := strconv.ParseInt(blocks[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		blocksTotal, err := strconv.ParseInt(blocks[2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		status.BlocksCompleted = blocksCompleted
+		status.BlocksTotal = blocksTotal
+	}
+
+	bytesWritten := restoreBytesWrittenRegex.FindStringSubmatch(statusString)
+	if bytesWritten != nil {
+		parsedBytes, err := strconv.ParseInt(bytesWritten[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		status.BytesWritten = parsedBytes
+	}
+
+	url := restoreURLRegex.FindStringSubmatch(statusString)
+	if url != nil {
+		status.URL = url[1]
+	}
+
+	lastError := restoreLastErrorRegex.FindStringSubmatch(statusString)
+	if lastError != nil {
+		status.LastError = strings.TrimSpace(lastError[1])
+	}
+
+	// The status also contains the CurrentVersion and the FirstConsistentVersion, so the version must be preceded by
+	// a whitespace.
+	version := restoreVersionRegex.FindStringSubmatch(statusString)
+	if version != nil {
+		parsedVersion, err := strconv.ParseInt(version[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		status.Version = parsedVersion
+	}
+
+	return status, nil
+}
+
 // Close cleans up any pending resources.
 func (client *cliAdminClient) Close() error {
 	// Allow to reuse the same file.
@@ -701,8 +1117,8 @@ func (client *cliAdminClient) SetTimeout(timeout time.Duration) {
 	client.timeout = timeout
 }
 
-// getTimeout will return the timeout that is specified for the admin client or otherwise the MaxCliTimeout.
-func (client *cliAdminClient) getTimeout() time.Duration {
+// GetTimeout will return the timeout that is specified for the admin client or otherwise the MaxCliTimeout.
+func (client *cliAdminClient) GetTimeout() time.Duration {
 	if client.timeout == 0 {
 		return MaxCliTimeout
 	}
@@ -34,6 +34,7 @@ import (
 	"github.com/go-logr/logr"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -770,6 +771,7 @@ protocol fdb00b071010000`,
 	When("excluding a set of processes", func() {
 		var mockRunner *mockCommandRunner
 		var useNonBlockingExcludes bool
+		var failed bool
 
 		JustBeforeEach(func() {
 			cluster := &fdbv1beta2.FoundationDBCluster{
@@ -788,13 +790,21 @@ protocol fdb00b071010000`,
 				cmdRunner:       mockRunner,
 			}
 
-			Expect(cliClient.ExcludeProcesses([]fdbv1beta2.ProcessAddress{{
+			addresses := []fdbv1beta2.ProcessAddress{{
 				IPAddress: net.ParseIP("127.0.0.1"),
 				Port:      4500,
-			}})).NotTo(HaveOccurred())
+			}}
+
+			if failed {
+				Expect(cliClient.ExcludeProcessesWithFailed(addresses, true)).NotTo(HaveOccurred())
+			} else {
+				Expect(cliClient.ExcludeProcesses(addresses)).NotTo(HaveOccurred())
+			}
 		})
 
 		BeforeEach(func() {
+			useNonBlockingExcludes = false
+			failed = false
 			tmpDir := GinkgoT().TempDir()
 			GinkgoT().Setenv("FDB_BINARY_DIR", tmpDir)
 
@@ -824,6 +834,16 @@ protocol fdb00b071010000`,
 				Expect(mockRunner.receivedArgs[0]).To(ContainElement("exclude no_wait 127.0.0.1:4500"))
 			})
 		})
+
+		When("the processes are excluded with the failed flag", func() {
+			BeforeEach(func() {
+				failed = true
+			})
+
+			It("should return that the exclusion command is called with failed", func() {
+				Expect(mockRunner.receivedArgs[0]).To(ContainElement("exclude failed 127.0.0.1:4500"))
+			})
+		})
 	})
 
 	When("checking if processes can safely be removed", func() {
@@ -1167,5 +1187,172 @@ protocol fdb00b071010000`,
 		})
 	})
 
+	DescribeTable("getting the args for the backup describe command", func(blobCredentialsPath string, expected []string) {
+		Expect(getDescribeBackupArgs("blobstore://test@test-service:443/test-backup?bucket=fdb-backups", blobCredentialsPath)).To(Equal(expected))
+	},
+		Entry("no blob credentials are defined",
+			"",
+			[]string{"describe", "-d", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "--version_timestamps", "--json"}),
+		Entry("blob credentials are defined",
+			"/tmp/blob_credentials.json",
+			[]string{"describe", "-d", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "--version_timestamps", "--json", "--blob-credentials", "/tmp/blob_credentials.json"}),
+	)
+
+	DescribeTable("getting the args for the backup start command", func(blobCredentialsPath string, keyRanges []fdbv1beta2.FoundationDBKeyRange, expected []string) {
+		Expect(getStartBackupArgs("blobstore://test@test-service:443/test-backup?bucket=fdb-backups", fdbv1beta2.DefaultBackupTag, 10, blobCredentialsPath, keyRanges)).To(Equal(expected))
+	},
+		Entry("no key ranges are defined",
+			"",
+			nil,
+			[]string{"start", "-d", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "-t", "default", "-s", "10", "-z"}),
+		Entry("blob credentials are defined",
+			"/tmp/blob_credentials.json",
+			nil,
+			[]string{"start", "-d", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "-t", "default", "-s", "10", "-z", "--blob-credentials", "/tmp/blob_credentials.json"}),
+		Entry("key ranges with escaped bytes are defined",
+			"",
+			[]fdbv1beta2.FoundationDBKeyRange{
+				{Start: `\x15user1/`, End: `\x15user2/`},
+				{Start: `\x15user3/`, End: `\x15user3/\xff`},
+			},
+			[]string{"start", "-d", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "-t", "default", "-s", "10", "-z", "-k", `\x15user1/ \x15user2/`, "-k", `\x15user3/ \x15user3/\xff`}),
+	)
+
+	DescribeTable("getting the args for the restore start command", func(keyRanges []fdbv1beta2.FoundationDBKeyRange, version int64, expected []string) {
+		Expect(getStartRestoreArgs("blobstore://test@test-service:443/test-backup?bucket=fdb-backups", keyRanges, version)).To(Equal(expected))
+	},
+		Entry("no key ranges are defined",
+			nil,
+			int64(0),
+			[]string{"start", "-r", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups"}),
+		Entry("a single key range is defined",
+			[]fdbv1beta2.FoundationDBKeyRange{{Start: "a", End: "b"}},
+			int64(0),
+			[]string{"start", "-r", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "-k", "a b"}),
+		Entry("key ranges with escaped bytes are defined",
+			[]fdbv1beta2.FoundationDBKeyRange{
+				{Start: `\x15user1/`, End: `\x15user2/`},
+				{Start: `\x15user3/`, End: `\x15user3/\xff`},
+			},
+			int64(0),
+			[]string{"start", "-r", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "-k", `\x15user1/ \x15user2/`, "-k", `\x15user3/ \x15user3/\xff`}),
+		Entry("a version is defined",
+			[]fdbv1beta2.FoundationDBKeyRange{{Start: "a", End: "b"}},
+			int64(123456),
+			[]string{"start", "-r", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "-v", "123456", "-k", "a b"}),
+	)
+
+	DescribeTable("getting the args for the restore start command with a timestamp", func(keyRanges []fdbv1beta2.FoundationDBKeyRange, expected []string) {
+		Expect(getStartRestoreAtTimestampArgs("blobstore://test@test-service:443/test-backup?bucket=fdb-backups", keyRanges, time.Date(2024, 3, 1, 8, 15, 0, 0, time.FixedZone("CET", 3600)), "/tmp/source.cluster")).To(Equal(expected))
+	},
+		Entry("no key ranges are defined",
+			nil,
+			[]string{"start", "-r", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "--timestamp", "2024/03/01.07:15:00+0000", "--orig_cluster_file", "/tmp/source.cluster"}),
+		Entry("a single key range is defined",
+			[]fdbv1beta2.FoundationDBKeyRange{{Start: "a", End: "b"}},
+			[]string{"start", "-r", "blobstore://test@test-service:443/test-backup?bucket=fdb-backups", "--timestamp", "2024/03/01.07:15:00+0000", "--orig_cluster_file", "/tmp/source.cluster", "-k", "a b"}),
+	)
+
+	DescribeTable("parsing the restore status", func(statusString string, expected *fdbv1beta2.FoundationDBLiveRestoreStatus) {
+		status, err := parseRestoreStatus(statusString)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(expected))
+	},
+		Entry("no restore was submitted",
+			"",
+			&fdbv1beta2.FoundationDBLiveRestoreStatus{}),
+		Entry("a running restore",
+			"Tag: default  UID: 3f1a0b4c5d6e7f80  State: running  Blocks: 25/100  BlocksInProgress: 4  Files: 12  BytesWritten: 4096  CurrentVersion: 1000 FirstConsistentVersion: 900  ApplyVersionLag: 10  LastError:   URL: blobstore://test@test-service:443/test-backup?bucket=fdb-backups  Range: '' - '\xff'  AddPrefix: ''  RemovePrefix: ''  Version: 1200\n",
+			&fdbv1beta2.FoundationDBLiveRestoreStatus{
+				Tag:             "default",
+				State:           "running",
+				BlocksCompleted: 25,
+				BlocksTotal:     100,
+				BytesWritten:    4096,
+				URL:             "blobstore://test@test-service:443/test-backup?bucket=fdb-backups",
+				Version:         1200,
+			}),
+		Entry("an aborted restore with an error",
+			"Tag: default  UID: 3f1a0b4c5d6e7f80  State: aborted  Blocks: 5/100  BlocksInProgress: 0  Files: 12  BytesWritten: 1024  CurrentVersion: 1000 FirstConsistentVersion: 900  ApplyVersionLag: 10  LastError: 'restore_missing_data' 30s ago.\n  URL: blobstore://test@test-service:443/test-backup?bucket=fdb-backups  Range: '' - '\xff'  AddPrefix: ''  RemovePrefix: ''  Version: 1200\n",
+			&fdbv1beta2.FoundationDBLiveRestoreStatus{
+				Tag:             "default",
+				State:           "aborted",
+				BlocksCompleted: 5,
+				BlocksTotal:     100,
+				BytesWritten:    1024,
+				URL:             "blobstore://test@test-service:443/test-backup?bucket=fdb-backups",
+				LastError:       "'restore_missing_data' 30s ago.",
+				Version:         1200,
+			}),
+	)
+
+	When("including processes with results", func() {
+		var mockRunner *mockCommandRunner
+		var results []fdbadminclient.InclusionResult
+		var err error
+		var addresses []fdbv1beta2.ProcessAddress
+
+		BeforeEach(func() {
+			addresses = []fdbv1beta2.ProcessAddress{
+				{IPAddress: net.ParseIP("192.168.0.1"), Port: 4500},
+				{IPAddress: net.ParseIP("192.168.0.2"), Port: 4500},
+				{IPAddress: net.ParseIP("192.168.0.3"), Port: 4500},
+			}
+
+			mockRunner = &mockCommandRunner{
+				mockedError:  []error{errors.New("boom"), nil, errors.New("boom"), nil},
+				mockedOutput: []string{"", "", "", ""},
+			}
+		})
+
+		JustBeforeEach(func() {
+			cliClient := &cliAdminClient{
+				Cluster: &fdbv1beta2.FoundationDBCluster{
+					Spec: fdbv1beta2.FoundationDBClusterSpec{
+						Version: fdbv1beta2.Versions.Default.String(),
+					},
+				},
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner:       mockRunner,
+			}
+
+			results, err = cliClient.IncludeProcessesWithResults(addresses)
+		})
+
+		When("the single command fails", func() {
+			It("should include the addresses one by one", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(mockRunner.receivedArgs).To(HaveLen(4))
+				Expect(results).To(HaveLen(3))
+				Expect(results[0].Err).NotTo(HaveOccurred())
+				Expect(results[1].Err).To(HaveOccurred())
+				Expect(results[2].Err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the time for the one by one inclusion is exceeded", func() {
+			var previousTimeout time.Duration
+
+			BeforeEach(func() {
+				previousTimeout = MaxCliTimeout
+				MaxCliTimeout = 0
+			})
+
+			AfterEach(func() {
+				MaxCliTimeout = previousTimeout
+			})
+
+			It("should report the skipped addresses with an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(mockRunner.receivedArgs).To(HaveLen(1))
+				Expect(results).To(HaveLen(3))
+				for _, result := range results {
+					Expect(result.Err).To(HaveOccurred())
+				}
+			})
+		})
+	})
+
 	// TODO(johscheuer): Add test case for timeout.
 })
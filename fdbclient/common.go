@@ -27,12 +27,14 @@ import (
 	"io/fs"
 	"os"
 	"path"
+	"sync"
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	"github.com/apple/foundationdb/bindings/go/src/fdb"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -103,9 +105,44 @@ func getFDBDatabase(cluster *fdbv1beta2.FoundationDBCluster) (fdb.Database, erro
 	return database, nil
 }
 
+// clusterFileGenerations tracks how often the native connection of a cluster was recreated. The FDB bindings cache the
+// database handles by the cluster file path, so a new cluster file name results in a new database handle.
+var clusterFileGenerations = map[types.UID]int{}
+var clusterFileGenerationsMutex sync.Mutex
+
+// getClusterFileName returns the name of the cluster file for the specified cluster.
+func getClusterFileName(cluster *fdbv1beta2.FoundationDBCluster) string {
+	clusterFileGenerationsMutex.Lock()
+	defer clusterFileGenerationsMutex.Unlock()
+
+	generation := clusterFileGenerations[cluster.UID]
+	if generation == 0 {
+		return string(cluster.UID)
+	}
+
+	return fmt.Sprintf("%s-%d", cluster.UID, generation)
+}
+
 // createClusterFile will create or update the cluster file for the specified cluster.
 func createClusterFile(cluster *fdbv1beta2.FoundationDBCluster) (string, error) {
-	return ensureClusterFileIsPresent(os.TempDir(), string(cluster.UID), cluster.Status.ConnectionString)
+	return ensureClusterFileIsPresent(os.TempDir(), getClusterFileName(cluster), cluster.Status.ConnectionString)
+}
+
+// recreateNativeConnection makes sure that the next database handle for the specified cluster will be created with a
+// new cluster file, which results in a new native connection. The previous cluster file will be removed.
+func recreateNativeConnection(cluster *fdbv1beta2.FoundationDBCluster) error {
+	previousClusterFile := path.Join(os.TempDir(), getClusterFileName(cluster))
+
+	clusterFileGenerationsMutex.Lock()
+	clusterFileGenerations[cluster.UID]++
+	clusterFileGenerationsMutex.Unlock()
+
+	err := os.Remove(previousClusterFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
 }
 
 // ensureClusterFileIsPresent will ensure that the cluster file with the specified connection string is present.
@@ -160,6 +197,13 @@ func (p *realDatabaseClientProvider) GetAdminClient(cluster *fdbv1beta2.Foundati
 	return NewCliAdminClient(cluster, kubernetesClient, p.log)
 }
 
+// RecreateNativeConnection drops the native connection for the specified cluster. Subsequent clients will create a new
+// native connection.
+func (p *realDatabaseClientProvider) RecreateNativeConnection(cluster *fdbv1beta2.FoundationDBCluster) error {
+	p.log.Info("Recreating native connection", "namespace", cluster.Namespace, "cluster", cluster.Name)
+	return recreateNativeConnection(cluster)
+}
+
 // NewDatabaseClientProvider generates a client provider for talking to real
 // databases.
 func NewDatabaseClientProvider(log logr.Logger) fdbadminclient.DatabaseClientProvider {
@@ -24,6 +24,9 @@ import (
 	"os"
 	"path"
 
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -79,4 +82,33 @@ var _ = Describe("common_test", func() {
 			})
 		})
 	})
+
+	When("recreating the native connection", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var previousClusterFile string
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					UID: "recreate-testuid",
+				},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ConnectionString: "test@test:127.0.0.1:4500",
+				},
+			}
+
+			var err error
+			previousClusterFile, err = createClusterFile(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recreateNativeConnection(cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should use a new cluster file and remove the previous one", func() {
+			clusterFile, err := createClusterFile(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterFile).NotTo(Equal(previousClusterFile))
+			Expect(previousClusterFile).NotTo(BeAnExistingFile())
+			Expect(clusterFile).To(BeAnExistingFile())
+		})
+	})
 })
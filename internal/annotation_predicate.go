@@ -0,0 +1,62 @@
+/*
+ * annotation_predicate.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+var _ predicate.Predicate = (*AnnotationChangedPredicate)(nil)
+
+// AnnotationChangedPredicate filters update events before enqueuing the keys. Only if the annotations of an object
+// have changed a reconciliation will be triggered. Changes to the annotations defined in IgnoredAnnotations will be
+// ignored, e.g. for annotations that are written by the operator during every reconciliation.
+type AnnotationChangedPredicate struct {
+	predicate.Funcs
+	// IgnoredAnnotations defines the annotations that will not trigger a reconciliation if changed.
+	IgnoredAnnotations []string
+}
+
+// Update returns true if the Update event should be processed. This is the case if any annotation that is not part of
+// the ignored annotations has been changed.
+func (a AnnotationChangedPredicate) Update(event event.UpdateEvent) bool {
+	if event.ObjectOld == nil || event.ObjectNew == nil {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(a.filterAnnotations(event.ObjectOld.GetAnnotations()), a.filterAnnotations(event.ObjectNew.GetAnnotations()))
+}
+
+// filterAnnotations returns a copy of the provided annotations without the ignored annotations.
+func (a AnnotationChangedPredicate) filterAnnotations(annotations map[string]string) map[string]string {
+	filtered := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		filtered[key] = value
+	}
+
+	for _, key := range a.IgnoredAnnotations {
+		delete(filtered, key)
+	}
+
+	return filtered
+}
@@ -0,0 +1,49 @@
+/*
+ * annotation_predicate_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AnnotationChangedPredicate", func() {
+	DescribeTable("filtering update events", func(oldAnnotations map[string]string, newAnnotations map[string]string, expected bool) {
+		annotationPredicate := AnnotationChangedPredicate{
+			IgnoredAnnotations: []string{"ignored"},
+		}
+
+		Expect(annotationPredicate.Update(event.UpdateEvent{
+			ObjectOld: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: oldAnnotations}},
+			ObjectNew: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: newAnnotations}},
+		})).To(Equal(expected))
+	},
+		Entry("no annotation changed", map[string]string{"test": "1"}, map[string]string{"test": "1"}, false),
+		Entry("an annotation changed", map[string]string{"test": "1"}, map[string]string{"test": "2"}, true),
+		Entry("an annotation was added", nil, map[string]string{"test": "1"}, true),
+		Entry("only the ignored annotation changed", map[string]string{"test": "1", "ignored": "1"}, map[string]string{"test": "1", "ignored": "2"}, false),
+		Entry("the ignored annotation was added", nil, map[string]string{"ignored": "1"}, false),
+	)
+})
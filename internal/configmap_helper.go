@@ -21,7 +21,6 @@
 package internal
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -40,7 +39,9 @@ func GetConfigMap(cluster *fdbv1beta2.FoundationDBCluster) (*corev1.ConfigMap, e
 	data := make(map[string]string)
 
 	connectionString := cluster.Status.ConnectionString
-	data[ClusterFileKey] = connectionString
+	if cluster.UseSeedClusterFile() {
+		data[ClusterFileKey] = connectionString
+	}
 	data["running-version"] = cluster.Status.RunningVersion
 
 	var caFile strings.Builder
@@ -145,8 +146,7 @@ func getConfigMapMetadata(cluster *fdbv1beta2.FoundationDBCluster) metav1.Object
 }
 
 func getDataForMonitorConf(cluster *fdbv1beta2.FoundationDBCluster, imageType FDBImageType, pClass fdbv1beta2.ProcessClass, serversPerPod int) (string, []byte, error) {
-	config := GetMonitorProcessConfiguration(cluster, pClass, serversPerPod, imageType)
-	jsonData, err := json.Marshal(config)
+	jsonData, err := marshalMonitorProcessConfiguration(cluster, pClass, serversPerPod, imageType)
 	if err != nil {
 		return "", nil, err
 	}
@@ -30,6 +30,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("configmap_helper", func() {
@@ -275,6 +276,17 @@ var _ = Describe("configmap_helper", func() {
 			})
 		})
 
+		When("the seed cluster file is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.UseSeedClusterFile = pointer.Bool(false)
+			})
+
+			It("should not include the cluster file", func() {
+				Expect(configMap.Data).NotTo(HaveKey(ClusterFileKey))
+				Expect(configMap.Data["fdbmonitor-conf-storage"]).NotTo(ContainSubstring("seed_cluster_file"))
+			})
+		})
+
 		Context("with a custom label", func() {
 			BeforeEach(func() {
 				cluster.Spec.ConfigMap = &corev1.ConfigMap{
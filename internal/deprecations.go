@@ -46,6 +46,13 @@ func NormalizeClusterSpec(cluster *fdbv1beta2.FoundationDBCluster, options Depre
 	// Validate customParameters
 	for processClass := range cluster.Spec.Processes {
 		if setting, ok := cluster.Spec.Processes[processClass]; ok {
+			for _, entry := range setting.CustomParametersPerProcess {
+				err := entry.CustomParameters.ValidateCustomParameters()
+				if err != nil {
+					return err
+				}
+			}
+
 			if setting.CustomParameters == nil {
 				continue
 			}
@@ -57,6 +64,11 @@ func NormalizeClusterSpec(cluster *fdbv1beta2.FoundationDBCluster, options Depre
 		}
 	}
 
+	err := validateCustomParametersAgainstGeneratedArguments(cluster)
+	if err != nil {
+		return err
+	}
+
 	if !options.OnlyShowChanges {
 		// Set up resource requirements for the main container.
 		updatePodTemplates(&cluster.Spec, func(template *corev1.PodTemplateSpec) {
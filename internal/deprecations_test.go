@@ -281,6 +281,22 @@ var _ = Describe("[internal] deprecations", func() {
 			})
 		})
 
+		When("a log group is defined for a process class", func() {
+			BeforeEach(func() {
+				spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassStorage: {
+						LogGroup: "storage-log-group",
+					},
+				}
+			})
+
+			It("should keep the log group", func() {
+				Expect(NormalizeClusterSpec(cluster, DeprecationOptions{})).NotTo(HaveOccurred())
+				Expect(spec.Processes[fdbv1beta2.ProcessClassStorage].LogGroup).To(Equal("storage-log-group"))
+				Expect(cluster.GetLogGroupForProcessClass(fdbv1beta2.ProcessClassStorage)).To(Equal("storage-log-group"))
+			})
+		})
+
 		When("adding an image config", func() {
 			When("no image config is set", func() {
 				It("should be added", func() {
@@ -0,0 +1,301 @@
+/*
+ * metrics.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	subReconcilerLabels = []string{"reconciler", "namespace", "name"}
+	clusterLabels       = []string{"namespace", "name"}
+	processClassLabels  = []string{"namespace", "name", "process_class"}
+	backupLabels        = []string{"namespace", "name"}
+
+	// SubReconcilerDuration tracks the duration of the sub-reconciler runs.
+	SubReconcilerDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fdb_operator_subreconciler_duration_seconds",
+			Help:    "the duration of the sub-reconciler runs in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+		subReconcilerLabels,
+	)
+
+	// SubReconcilerRequeues counts the requeues requested by the sub-reconcilers.
+	SubReconcilerRequeues = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fdb_operator_subreconciler_requeues_total",
+			Help: "the number of requeues requested by the sub-reconcilers.",
+		},
+		append(subReconcilerLabels, "delayed"),
+	)
+
+	// SubReconcilerErrors counts the errors returned by the sub-reconcilers.
+	SubReconcilerErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fdb_operator_subreconciler_errors_total",
+			Help: "the number of errors returned by the sub-reconcilers.",
+		},
+		subReconcilerLabels,
+	)
+
+	// CachedStatusAge tracks the age of the cached machine-readable status at the end of the last reconciliation loop.
+	CachedStatusAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_cached_status_age_seconds",
+			Help: "the age of the cached machine-readable status at the end of the last reconciliation loop in seconds.",
+		},
+		clusterLabels,
+	)
+
+	// StatusFetches counts how often the machine-readable status was fetched during the reconciliation loops.
+	StatusFetches = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fdb_operator_status_fetches_total",
+			Help: "the number of times the machine-readable status was fetched during the reconciliation loops.",
+		},
+		clusterLabels,
+	)
+
+	// OngoingExclusions tracks the number of ongoing exclusions per process class.
+	OngoingExclusions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_ongoing_exclusions",
+			Help: "the number of processes that are currently being excluded.",
+		},
+		processClassLabels,
+	)
+
+	// PendingExclusions tracks the number of processes per process class that should be excluded.
+	PendingExclusions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_pending_exclusions",
+			Help: "the number of processes that are waiting to be excluded.",
+		},
+		processClassLabels,
+	)
+
+	// AllowedExclusions tracks the number of processes per process class that are allowed to be excluded.
+	AllowedExclusions = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_allowed_exclusions",
+			Help: "the number of processes that are allowed to be excluded in the last reconciliation.",
+		},
+		processClassLabels,
+	)
+
+	// BackupRunning tracks whether the backup is running.
+	BackupRunning = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_backup_running",
+			Help: "whether the backup is running, 1 if the backup is running and 0 otherwise.",
+		},
+		backupLabels,
+	)
+
+	// BackupPaused tracks whether the backup is paused.
+	BackupPaused = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_backup_paused",
+			Help: "whether the backup is paused, 1 if the backup agents are paused and 0 otherwise.",
+		},
+		backupLabels,
+	)
+
+	// BackupLatestRestorableTimestamp tracks the latest restorable point of the backup.
+	BackupLatestRestorableTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_backup_latest_restorable_timestamp_seconds",
+			Help: "the latest restorable point of the backup as Unix timestamp in seconds.",
+		},
+		backupLabels,
+	)
+
+	// BackupAgentsReady tracks the number of ready backup agents.
+	BackupAgentsReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_backup_agents_ready",
+			Help: "the number of backup agents that are up-to-date and ready.",
+		},
+		backupLabels,
+	)
+
+	// BackupAgentsDesired tracks the number of desired backup agents.
+	BackupAgentsDesired = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fdb_operator_backup_agents_desired",
+			Help: "the number of backup agents that should be running.",
+		},
+		backupLabels,
+	)
+
+	// BackupOperationFailures counts the failed operations on a backup.
+	BackupOperationFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fdb_operator_backup_operation_failures_total",
+			Help: "the number of failed operations on a backup, e.g. starting or stopping the backup.",
+		},
+		append(backupLabels, "operation"),
+	)
+)
+
+// Register registers all collectors of this package in the provided registerer. Collectors that are already registered
+// will be ignored, so every controller can call this method during the setup.
+func Register(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{
+		SubReconcilerDuration,
+		SubReconcilerRequeues,
+		SubReconcilerErrors,
+		CachedStatusAge,
+		StatusFetches,
+		OngoingExclusions,
+		PendingExclusions,
+		AllowedExclusions,
+		BackupRunning,
+		BackupPaused,
+		BackupLatestRestorableTimestamp,
+		BackupAgentsReady,
+		BackupAgentsDesired,
+		BackupOperationFailures,
+	} {
+		err := registerer.Register(collector)
+		if err == nil {
+			continue
+		}
+
+		var alreadyRegisteredError prometheus.AlreadyRegisteredError
+		if !errors.As(err, &alreadyRegisteredError) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ObserveSubReconcilerDuration records the duration of a sub-reconciler run.
+func ObserveSubReconcilerDuration(reconciler string, namespace string, name string, duration time.Duration) {
+	SubReconcilerDuration.WithLabelValues(reconciler, namespace, name).Observe(duration.Seconds())
+}
+
+// RecordSubReconcilerRequeue records a requeue requested by a sub-reconciler. If the requeue was caused by an error,
+// the error will be recorded too.
+func RecordSubReconcilerRequeue(reconciler string, namespace string, name string, delayed bool, hasError bool) {
+	SubReconcilerRequeues.WithLabelValues(reconciler, namespace, name, strconv.FormatBool(delayed)).Inc()
+
+	if hasError {
+		SubReconcilerErrors.WithLabelValues(reconciler, namespace, name).Inc()
+	}
+}
+
+// RecordCachedStatus records the age of the cached machine-readable status and the number of times the status was
+// fetched during a reconciliation loop. The age will only be recorded if a status was cached.
+func RecordCachedStatus(namespace string, name string, cached bool, age time.Duration, fetches int) {
+	StatusFetches.WithLabelValues(namespace, name).Add(float64(fetches))
+
+	if cached {
+		CachedStatusAge.WithLabelValues(namespace, name).Set(age.Seconds())
+	}
+}
+
+// RecordPendingExclusions records the number of ongoing exclusions and the number of processes that should be excluded
+// for the provided process class.
+func RecordPendingExclusions(namespace string, name string, processClass string, ongoing int, pending int) {
+	OngoingExclusions.WithLabelValues(namespace, name, processClass).Set(float64(ongoing))
+	PendingExclusions.WithLabelValues(namespace, name, processClass).Set(float64(pending))
+}
+
+// RecordAllowedExclusions records the number of processes that are allowed to be excluded for the provided process
+// class.
+func RecordAllowedExclusions(namespace string, name string, processClass string, allowed int) {
+	AllowedExclusions.WithLabelValues(namespace, name, processClass).Set(float64(allowed))
+}
+
+// ResetExclusions removes the exclusion metrics of the provided cluster, this makes sure that process classes without
+// exclusions are not reported with outdated values.
+func ResetExclusions(namespace string, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	for _, vec := range []*prometheus.GaugeVec{OngoingExclusions, PendingExclusions, AllowedExclusions} {
+		vec.DeletePartialMatch(labels)
+	}
+}
+
+// DeleteClusterMetrics removes all metrics of the provided cluster. This should be called once the cluster is deleted,
+// to prevent that the label sets of deleted clusters are reported.
+func DeleteClusterMetrics(namespace string, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	SubReconcilerDuration.DeletePartialMatch(labels)
+	SubReconcilerRequeues.DeletePartialMatch(labels)
+	SubReconcilerErrors.DeletePartialMatch(labels)
+	CachedStatusAge.DeletePartialMatch(labels)
+	StatusFetches.DeletePartialMatch(labels)
+	ResetExclusions(namespace, name)
+}
+
+// RecordBackupStatus records the state of the backup and the number of ready and desired backup agents.
+func RecordBackupStatus(namespace string, name string, running bool, paused bool, readyAgents int, desiredAgents int) {
+	BackupRunning.WithLabelValues(namespace, name).Set(boolToFloat(running))
+	BackupPaused.WithLabelValues(namespace, name).Set(boolToFloat(paused))
+	BackupAgentsReady.WithLabelValues(namespace, name).Set(float64(readyAgents))
+	BackupAgentsDesired.WithLabelValues(namespace, name).Set(float64(desiredAgents))
+}
+
+// RecordBackupRestorablePoint records the latest restorable point of the backup as timestamp, the time since the
+// latest restorable point can be calculated at query time. If the latest restorable point is unknown, the metric will
+// be removed.
+func RecordBackupRestorablePoint(namespace string, name string, latestRestorablePoint *time.Time) {
+	if latestRestorablePoint == nil {
+		BackupLatestRestorableTimestamp.DeleteLabelValues(namespace, name)
+		return
+	}
+
+	BackupLatestRestorableTimestamp.WithLabelValues(namespace, name).Set(float64(latestRestorablePoint.Unix()))
+}
+
+// RecordBackupOperationFailure records a failed operation on a backup.
+func RecordBackupOperationFailure(namespace string, name string, operation string) {
+	BackupOperationFailures.WithLabelValues(namespace, name, operation).Inc()
+}
+
+// DeleteBackupMetrics removes all backup metrics of the provided backup. This should be called once the backup is
+// deleted, to prevent that the label sets of deleted backups are reported.
+func DeleteBackupMetrics(namespace string, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	BackupRunning.DeletePartialMatch(labels)
+	BackupPaused.DeletePartialMatch(labels)
+	BackupLatestRestorableTimestamp.DeletePartialMatch(labels)
+	BackupAgentsReady.DeletePartialMatch(labels)
+	BackupAgentsDesired.DeletePartialMatch(labels)
+	BackupOperationFailures.DeletePartialMatch(labels)
+}
+
+// boolToFloat converts the provided bool into a value for a gauge.
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
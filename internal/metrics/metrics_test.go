@@ -0,0 +1,233 @@
+/*
+ * metrics_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("metrics", func() {
+	When("registering the collectors", func() {
+		var registry *prometheus.Registry
+
+		BeforeEach(func() {
+			registry = prometheus.NewRegistry()
+			Expect(Register(registry)).NotTo(HaveOccurred())
+		})
+
+		It("should ignore collectors that are already registered", func() {
+			Expect(Register(registry)).NotTo(HaveOccurred())
+		})
+	})
+
+	When("recording the sub-reconciler metrics", func() {
+		reconciler := "controllers.updateStatus"
+		namespace := "test"
+		name := "cluster"
+
+		BeforeEach(func() {
+			SubReconcilerDuration.Reset()
+			SubReconcilerRequeues.Reset()
+			SubReconcilerErrors.Reset()
+
+			ObserveSubReconcilerDuration(reconciler, namespace, name, time.Second)
+			RecordSubReconcilerRequeue(reconciler, namespace, name, false, false)
+			RecordSubReconcilerRequeue(reconciler, namespace, name, true, true)
+			RecordSubReconcilerRequeue(reconciler, namespace, name, true, false)
+		})
+
+		It("should record the duration", func() {
+			Expect(testutil.CollectAndCount(SubReconcilerDuration)).To(Equal(1))
+		})
+
+		It("should record the requeues split by delayed and immediate", func() {
+			Expect(testutil.ToFloat64(SubReconcilerRequeues.WithLabelValues(reconciler, namespace, name, "false"))).To(BeNumerically("==", 1))
+			Expect(testutil.ToFloat64(SubReconcilerRequeues.WithLabelValues(reconciler, namespace, name, "true"))).To(BeNumerically("==", 2))
+		})
+
+		It("should record the errors", func() {
+			Expect(testutil.ToFloat64(SubReconcilerErrors.WithLabelValues(reconciler, namespace, name))).To(BeNumerically("==", 1))
+		})
+	})
+
+	When("recording the cached status metrics", func() {
+		namespace := "test"
+		name := "cluster"
+
+		BeforeEach(func() {
+			CachedStatusAge.Reset()
+			StatusFetches.Reset()
+		})
+
+		When("a status was cached", func() {
+			BeforeEach(func() {
+				RecordCachedStatus(namespace, name, true, 5*time.Second, 2)
+			})
+
+			It("should record the age and the fetches", func() {
+				Expect(testutil.ToFloat64(CachedStatusAge.WithLabelValues(namespace, name))).To(BeNumerically("==", 5))
+				Expect(testutil.ToFloat64(StatusFetches.WithLabelValues(namespace, name))).To(BeNumerically("==", 2))
+			})
+		})
+
+		When("no status was cached", func() {
+			BeforeEach(func() {
+				RecordCachedStatus(namespace, name, false, 0, 3)
+			})
+
+			It("should only record the fetches", func() {
+				Expect(testutil.CollectAndCount(CachedStatusAge)).To(Equal(0))
+				Expect(testutil.ToFloat64(StatusFetches.WithLabelValues(namespace, name))).To(BeNumerically("==", 3))
+			})
+		})
+	})
+
+	When("recording the exclusion metrics", func() {
+		namespace := "test"
+		name := "cluster"
+
+		BeforeEach(func() {
+			OngoingExclusions.Reset()
+			PendingExclusions.Reset()
+			AllowedExclusions.Reset()
+
+			RecordPendingExclusions(namespace, name, "storage", 1, 3)
+			RecordAllowedExclusions(namespace, name, "storage", 2)
+			RecordPendingExclusions(namespace, "other", "storage", 0, 1)
+		})
+
+		It("should record the exclusions per process class", func() {
+			Expect(testutil.ToFloat64(OngoingExclusions.WithLabelValues(namespace, name, "storage"))).To(BeNumerically("==", 1))
+			Expect(testutil.ToFloat64(PendingExclusions.WithLabelValues(namespace, name, "storage"))).To(BeNumerically("==", 3))
+			Expect(testutil.ToFloat64(AllowedExclusions.WithLabelValues(namespace, name, "storage"))).To(BeNumerically("==", 2))
+		})
+
+		When("the exclusion metrics are reset", func() {
+			BeforeEach(func() {
+				ResetExclusions(namespace, name)
+			})
+
+			It("should only remove the metrics of the cluster", func() {
+				Expect(testutil.CollectAndCount(OngoingExclusions)).To(Equal(1))
+				Expect(testutil.CollectAndCount(PendingExclusions)).To(Equal(1))
+				Expect(testutil.CollectAndCount(AllowedExclusions)).To(Equal(0))
+			})
+		})
+	})
+
+	When("deleting the metrics of a cluster", func() {
+		namespace := "test"
+		name := "cluster"
+
+		BeforeEach(func() {
+			SubReconcilerRequeues.Reset()
+			StatusFetches.Reset()
+			PendingExclusions.Reset()
+
+			RecordSubReconcilerRequeue("controllers.updateStatus", namespace, name, false, false)
+			RecordSubReconcilerRequeue("controllers.updateStatus", namespace, "other", false, false)
+			RecordCachedStatus(namespace, name, false, 0, 1)
+			RecordPendingExclusions(namespace, name, "storage", 0, 1)
+
+			DeleteClusterMetrics(namespace, name)
+		})
+
+		It("should remove all metrics of the cluster", func() {
+			Expect(testutil.CollectAndCount(SubReconcilerRequeues)).To(Equal(1))
+			Expect(testutil.ToFloat64(SubReconcilerRequeues.WithLabelValues("controllers.updateStatus", namespace, "other", "false"))).To(BeNumerically("==", 1))
+			Expect(testutil.CollectAndCount(StatusFetches)).To(Equal(0))
+			Expect(testutil.CollectAndCount(PendingExclusions)).To(Equal(0))
+		})
+	})
+
+	When("recording the backup metrics", func() {
+		namespace := "test"
+		name := "backup"
+		now := time.Now()
+
+		BeforeEach(func() {
+			BackupRunning.Reset()
+			BackupPaused.Reset()
+			BackupLatestRestorableTimestamp.Reset()
+			BackupAgentsReady.Reset()
+			BackupAgentsDesired.Reset()
+			BackupOperationFailures.Reset()
+
+			RecordBackupStatus(namespace, name, true, false, 1, 3)
+			RecordBackupOperationFailure(namespace, name, "start")
+			RecordBackupOperationFailure(namespace, name, "start")
+			RecordBackupOperationFailure(namespace, "other", "stop")
+		})
+
+		It("should record the state and the agents", func() {
+			Expect(testutil.ToFloat64(BackupRunning.WithLabelValues(namespace, name))).To(BeNumerically("==", 1))
+			Expect(testutil.ToFloat64(BackupPaused.WithLabelValues(namespace, name))).To(BeNumerically("==", 0))
+			Expect(testutil.ToFloat64(BackupAgentsReady.WithLabelValues(namespace, name))).To(BeNumerically("==", 1))
+			Expect(testutil.ToFloat64(BackupAgentsDesired.WithLabelValues(namespace, name))).To(BeNumerically("==", 3))
+		})
+
+		It("should record the failed operations", func() {
+			Expect(testutil.ToFloat64(BackupOperationFailures.WithLabelValues(namespace, name, "start"))).To(BeNumerically("==", 2))
+			Expect(testutil.ToFloat64(BackupOperationFailures.WithLabelValues(namespace, "other", "stop"))).To(BeNumerically("==", 1))
+		})
+
+		When("the backup has a restorable point", func() {
+			BeforeEach(func() {
+				restorablePoint := now.Add(-30 * time.Second)
+				RecordBackupRestorablePoint(namespace, name, &restorablePoint)
+			})
+
+			It("should record the timestamp of the restorable point", func() {
+				Expect(testutil.ToFloat64(BackupLatestRestorableTimestamp.WithLabelValues(namespace, name))).To(BeNumerically("==", now.Add(-30*time.Second).Unix()))
+			})
+
+			When("the restorable point is no longer known", func() {
+				BeforeEach(func() {
+					RecordBackupRestorablePoint(namespace, name, nil)
+				})
+
+				It("should remove the metric", func() {
+					Expect(testutil.CollectAndCount(BackupLatestRestorableTimestamp)).To(Equal(0))
+				})
+			})
+		})
+
+		When("the metrics of the backup are deleted", func() {
+			BeforeEach(func() {
+				DeleteBackupMetrics(namespace, name)
+			})
+
+			It("should only remove the metrics of the backup", func() {
+				Expect(testutil.CollectAndCount(BackupRunning)).To(Equal(0))
+				Expect(testutil.CollectAndCount(BackupPaused)).To(Equal(0))
+				Expect(testutil.CollectAndCount(BackupAgentsReady)).To(Equal(0))
+				Expect(testutil.CollectAndCount(BackupAgentsDesired)).To(Equal(0))
+				Expect(testutil.CollectAndCount(BackupOperationFailures)).To(Equal(1))
+			})
+		})
+	})
+})
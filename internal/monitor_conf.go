@@ -21,13 +21,16 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
 	monitorapi "github.com/apple/foundationdb/fdbkubernetesmonitor/api"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 )
 
@@ -47,7 +50,17 @@ func GetStartCommandWithSubstitutions(cluster *fdbv1beta2.FoundationDBCluster, p
 		return "", nil
 	}
 
-	imageType := GetDesiredImageType(cluster)
+	err := checkCustomParameterSubstitutions(cluster, processClass, substitutions, processNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return getStartCommandWithSubstitutions(cluster, processClass, substitutions, processNumber, processCount, GetDesiredImageType(cluster))
+}
+
+// getStartCommandWithSubstitutions builds the start command for the provided process and image type, based on the
+// provided substitutions.
+func getStartCommandWithSubstitutions(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, substitutions map[string]string, processNumber int, processCount int, imageType FDBImageType) (string, error) {
 	config := GetMonitorProcessConfiguration(cluster, processClass, processCount, imageType)
 
 	extractPlaceholderEnvVars(substitutions, config.Arguments)
@@ -71,6 +84,63 @@ func GetStartCommandWithSubstitutions(cluster *fdbv1beta2.FoundationDBCluster, p
 	return command + " " + strings.Join(arguments, " "), nil
 }
 
+// ValidateSubstitutions returns an error if a custom parameter of any process in the provided process class references
+// an environment variable that is not defined in the container of the Pod that provides the substitutions. For the
+// unified image this is the main container, for the split image the sidecar container. The validation is based on the
+// Pod spec, as the substitutions reported by the Pod only contain the variables of the current configuration. If the
+// container reads environment variables from a ConfigMap or Secret, the variables can't be validated.
+func ValidateSubstitutions(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, pod *corev1.Pod, processCount int) error {
+	containerName := fdbv1beta2.SidecarContainerName
+	if GetImageType(pod) == FDBImageTypeUnified {
+		containerName = fdbv1beta2.MainContainerName
+	}
+
+	var container *corev1.Container
+	for index := range pod.Spec.Containers {
+		if pod.Spec.Containers[index].Name == containerName {
+			container = &pod.Spec.Containers[index]
+			break
+		}
+	}
+
+	if container == nil || len(container.EnvFrom) > 0 {
+		return nil
+	}
+
+	environment := make(map[string]string, len(container.Env))
+	for _, envVar := range container.Env {
+		environment[envVar.Name] = envVar.Value
+	}
+
+	for processNumber := 1; processNumber <= processCount; processNumber++ {
+		err := checkCustomParameterSubstitutions(cluster, processClass, environment, processNumber)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkCustomParameterSubstitutions returns an error if a custom parameter of the provided process references an
+// environment variable that is not present in the substitutions. Otherwise the generated start command would contain
+// the unresolved variable.
+func checkCustomParameterSubstitutions(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, substitutions map[string]string, processNumber int) error {
+	for _, parameter := range cluster.GetProcessSettings(processClass).GetCustomParametersForProcess(processNumber) {
+		for _, argument := range generateMonitorArgumentFromCustomParameter(parameter) {
+			if argument.ArgumentType != monitorapi.EnvironmentArgumentType {
+				continue
+			}
+
+			if _, present := substitutions[argument.Source]; !present {
+				return fmt.Errorf("custom parameter \"%s\" for process class %s and process number %d references the environment variable %s, which is not present in the substitutions", parameter, processClass, processNumber, argument.Source)
+			}
+		}
+	}
+
+	return nil
+}
+
 // extractPlaceholderEnvVars builds a map of every environment variable
 // referenced in the monitor conf.
 func extractPlaceholderEnvVars(env map[string]string, arguments []monitorapi.Argument) {
@@ -91,13 +161,6 @@ func GetMonitorConf(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1b
 		return "", nil
 	}
 
-	confLines := make([]string, 0, 20)
-	confLines = append(confLines,
-		"[general]",
-		"kill_on_configuration_change = false",
-		"restart_delay = 60",
-	)
-
 	var substitutions map[string]string
 	var err error
 
@@ -108,6 +171,101 @@ func GetMonitorConf(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1b
 		}
 	}
 
+	return renderMonitorConf(cluster, processClass, substitutions, serversPerPod)
+}
+
+// RenderedProcessConfiguration contains the configuration the operator would generate for the processes of a process
+// class.
+type RenderedProcessConfiguration struct {
+	// MonitorConf contains the monitor conf for the split image.
+	MonitorConf string
+	// ProcessConfiguration contains the marshaled process configuration for the fdb-kubernetes-monitor.
+	ProcessConfiguration []byte
+	// StartCommands contains the fully resolved start command for every process in the pod. This will only be set if
+	// substitutions are provided.
+	StartCommands []string
+}
+
+// RenderProcessConfiguration renders the configuration the operator would generate for the processes of the provided
+// process class, without requiring a running cluster. In contrast to GetMonitorConf the monitor conf will be rendered
+// even if the cluster has no connection string. If substitutions are provided, the monitor conf will contain the
+// resolved values and the fully resolved start commands for all processes will be returned.
+func RenderProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, serversPerPod int, imageType FDBImageType, substitutions map[string]string) (*RenderedProcessConfiguration, error) {
+	monitorConf, err := renderMonitorConf(cluster, processClass, copySubstitutions(substitutions), serversPerPod)
+	if err != nil {
+		return nil, err
+	}
+
+	processConfiguration, err := marshalMonitorProcessConfiguration(cluster, processClass, serversPerPod, imageType)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := &RenderedProcessConfiguration{
+		MonitorConf:          monitorConf,
+		ProcessConfiguration: processConfiguration,
+	}
+
+	if substitutions == nil {
+		return rendered, nil
+	}
+
+	rendered.StartCommands = make([]string, 0, serversPerPod)
+	for processNumber := 1; processNumber <= serversPerPod; processNumber++ {
+		err = checkCustomParameterSubstitutions(cluster, processClass, substitutions, processNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		command, err := getStartCommandWithSubstitutions(cluster, processClass, copySubstitutions(substitutions), processNumber, serversPerPod, imageType)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered.StartCommands = append(rendered.StartCommands, command)
+	}
+
+	return rendered, nil
+}
+
+// marshalMonitorProcessConfiguration returns the marshaled process configuration for the fdb-kubernetes-monitor.
+func marshalMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, serversPerPod int, imageType FDBImageType) ([]byte, error) {
+	return json.Marshal(GetMonitorProcessConfiguration(cluster, processClass, serversPerPod, imageType))
+}
+
+// copySubstitutions returns a copy of the provided substitutions, since the placeholders for missing environment
+// variables are added to the substitutions while generating the arguments.
+func copySubstitutions(substitutions map[string]string) map[string]string {
+	if substitutions == nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(substitutions))
+	for key, value := range substitutions {
+		result[key] = value
+	}
+
+	return result
+}
+
+// renderMonitorConf builds the monitor conf based on the provided substitutions. Missing substitutions will be replaced
+// with a placeholder for the environment variable.
+func renderMonitorConf(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, substitutions map[string]string, serversPerPod int) (string, error) {
+	confLines := make([]string, 0, 20)
+	confLines = append(confLines,
+		"[general]",
+		fmt.Sprintf("kill_on_configuration_change = %t", cluster.KillProcessesOnConfigurationChange()),
+		fmt.Sprintf("restart_delay = %d", cluster.GetRestartDelaySeconds()),
+	)
+
+	if cluster.Spec.FdbMonitor.RestartBackoff != nil {
+		confLines = append(confLines, fmt.Sprintf("restart_backoff = %d", *cluster.Spec.FdbMonitor.RestartBackoff))
+	}
+
+	if cluster.Spec.FdbMonitor.RestartDelayResetIntervalSeconds != nil {
+		confLines = append(confLines, fmt.Sprintf("restart_delay_reset_interval = %d", *cluster.Spec.FdbMonitor.RestartDelayResetIntervalSeconds))
+	}
+
 	// Don't instantiate any servers if the `EmptyMonitorConf` buggify option is engaged.
 	if !cluster.Spec.Buggify.EmptyMonitorConf {
 		for i := 1; i <= serversPerPod; i++ {
@@ -123,10 +281,57 @@ func GetMonitorConf(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1b
 	return strings.Join(confLines, "\n"), nil
 }
 
+// TLSTransitionPhase represents a phase of a transition between TLS and non-TLS listeners.
+type TLSTransitionPhase string
+
+const (
+	// TLSTransitionPhasePre represents the phase before the transition, where the processes only listen on the
+	// addresses for the current setting.
+	TLSTransitionPhasePre TLSTransitionPhase = "pre"
+	// TLSTransitionPhaseDual represents the phase during the transition, where the processes listen on the TLS and the
+	// non-TLS addresses.
+	TLSTransitionPhaseDual TLSTransitionPhase = "dual"
+	// TLSTransitionPhasePost represents the phase after the transition, where the processes only listen on the
+	// addresses for the desired setting.
+	TLSTransitionPhasePost TLSTransitionPhase = "post"
+)
+
+// GetRequiredAddressesForTLSTransitionPhase returns the addresses that are required in the provided phase of a TLS
+// transition. The target of the transition is defined by the TLS setting of the main container in the cluster spec.
+func GetRequiredAddressesForTLSTransitionPhase(cluster *fdbv1beta2.FoundationDBCluster, phase TLSTransitionPhase) (fdbv1beta2.RequiredAddressSet, error) {
+	targetTLS := cluster.Spec.MainContainer.EnableTLS
+
+	switch phase {
+	case TLSTransitionPhasePre:
+		return fdbv1beta2.RequiredAddressSet{TLS: !targetTLS, NonTLS: targetTLS}, nil
+	case TLSTransitionPhaseDual:
+		return fdbv1beta2.RequiredAddressSet{TLS: true, NonTLS: true}, nil
+	case TLSTransitionPhasePost:
+		return fdbv1beta2.RequiredAddressSet{TLS: targetTLS, NonTLS: !targetTLS}, nil
+	}
+
+	return fdbv1beta2.RequiredAddressSet{}, fmt.Errorf("unknown TLS transition phase: %s", phase)
+}
+
+// GetMonitorConfForTLSTransitionPhase builds the monitor conf template for the provided phase of a TLS transition.
+// In contrast to GetMonitorConf the addresses are based on the requested phase instead of the required addresses in
+// the cluster status, which makes the output independent of the current state of the transition.
+func GetMonitorConfForTLSTransitionPhase(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, podClient podclient.FdbPodClient, serversPerPod int, phase TLSTransitionPhase) (string, error) {
+	requiredAddresses, err := GetRequiredAddressesForTLSTransitionPhase(cluster, phase)
+	if err != nil {
+		return "", err
+	}
+
+	phaseCluster := cluster.DeepCopy()
+	phaseCluster.Status.RequiredAddresses = requiredAddresses
+
+	return GetMonitorConf(phaseCluster, processClass, podClient, serversPerPod)
+}
+
 func getMonitorConfStartCommandLines(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, substitutions map[string]string, processNumber int, processCount int) ([]string, error) {
 	confLines := make([]string, 0, 20)
 
-	config := GetMonitorProcessConfiguration(cluster, processClass, processCount, FDBImageTypeSplit)
+	config := getMonitorProcessConfiguration(cluster, processClass, processCount, FDBImageTypeSplit, processNumber)
 
 	if substitutions == nil {
 		substitutions = make(map[string]string)
@@ -156,20 +361,27 @@ func getMonitorConfStartCommandLines(cluster *fdbv1beta2.FoundationDBCluster, pr
 
 // GetMonitorProcessConfiguration builds the monitor conf template for the unified image.
 func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, processCount int, imageType FDBImageType) monitorapi.ProcessConfiguration {
+	return getMonitorProcessConfiguration(cluster, processClass, processCount, imageType, 0)
+}
+
+// getMonitorProcessConfiguration builds the monitor conf template. If a process number is provided, the process specific
+// custom parameters for this process will be added. The process specific custom parameters are only supported for the
+// split image, since the unified image uses the same configuration for all processes.
+func getMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, processCount int, imageType FDBImageType, processNumber int) monitorapi.ProcessConfiguration {
 	configuration := monitorapi.ProcessConfiguration{
 		Version: cluster.Spec.Version,
 	}
 
+	// If the version can't be parsed, none of the version specific arguments will be added.
+	version, _ := fdbv1beta2.ParseFdbVersion(cluster.Spec.Version)
+
 	if cluster.Status.ConnectionString == "" {
 		// Return a placeholder configuration with the servers off until we
 		// have the initial connection string.
 		configuration.RunServers = pointer.Bool(false)
 	}
 
-	logGroup := cluster.Spec.LogGroup
-	if logGroup == "" {
-		logGroup = cluster.Name
-	}
+	logGroup := cluster.GetLogGroupForProcessClass(processClass)
 
 	var zoneVariable string
 	if strings.HasPrefix(cluster.Spec.FaultDomain.ValueFrom, "$") {
@@ -179,12 +391,15 @@ func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, pro
 	}
 
 	sampleAddresses := cluster.GetFullAddressList(fdbv1beta2.EnvNamePublicIP, false, 1)
+	configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{Value: "--cluster_file=/var/fdb/data/fdb.cluster"})
+	if cluster.UseSeedClusterFile() {
+		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{Value: "--seed_cluster_file=/var/dynamic-conf/fdb.cluster"})
+	}
+
 	configuration.Arguments = append(configuration.Arguments,
-		monitorapi.Argument{Value: "--cluster_file=/var/fdb/data/fdb.cluster"},
-		monitorapi.Argument{Value: "--seed_cluster_file=/var/dynamic-conf/fdb.cluster"},
-		monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: buildIPArgument("public_address", fdbv1beta2.EnvNamePublicIP, imageType, sampleAddresses, cluster.Spec.Routing.PodIPFamily)},
+		monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: buildIPArgument("public_address", fdbv1beta2.EnvNamePublicIP, imageType, sampleAddresses, cluster.GetIPFamilies())},
 		monitorapi.Argument{Value: fmt.Sprintf("--class=%s", processClass)},
-		monitorapi.Argument{Value: "--logdir=/var/log/fdb-trace-logs"},
+		monitorapi.Argument{Value: fmt.Sprintf("--logdir=%s", getTraceLogDir(cluster, processClass))},
 		monitorapi.Argument{Value: fmt.Sprintf("--loggroup=%s", logGroup)},
 	)
 
@@ -212,7 +427,7 @@ func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, pro
 		}},
 		monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
 			{Value: getKnobParameter(fdbv1beta2.FDBLocalityMachineIDKey, true)},
-			{ArgumentType: monitorapi.EnvironmentArgumentType, Source: "FDB_MACHINE_ID"},
+			{ArgumentType: monitorapi.EnvironmentArgumentType, Source: cluster.GetMachineIDVariable()},
 		}},
 		monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
 			{Value: getKnobParameter(fdbv1beta2.FDBLocalityZoneIDKey, true)},
@@ -221,15 +436,26 @@ func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, pro
 	)
 
 	if cluster.NeedsExplicitListenAddress() && cluster.Status.HasListenIPsForAllPods {
-		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: buildIPArgument("listen_address", "FDB_POD_IP", imageType, sampleAddresses, cluster.Spec.Routing.PodIPFamily)})
+		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: buildIPArgument("listen_address", "FDB_POD_IP", imageType, sampleAddresses, cluster.GetIPFamilies())})
 	}
 
 	if cluster.Spec.MainContainer.PeerVerificationRules != "" {
 		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{Value: getKnobParameterWithValue("tls_verify_peers", cluster.Spec.MainContainer.PeerVerificationRules, false)})
 	}
 
-	podSettings := cluster.GetProcessSettings(processClass)
-	for _, argument := range podSettings.CustomParameters {
+	customParameters := cluster.GetProcessSettings(processClass).GetCustomParametersForProcess(processNumber)
+	// If the trace format is defined as custom parameter the custom parameter takes precedence.
+	if cluster.Spec.TraceFormat != "" && !customParameters.HasParameter("trace_format") {
+		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{Value: getKnobParameterWithValue("trace_format", string(cluster.Spec.TraceFormat), false)})
+	}
+
+	configuration.Arguments = append(configuration.Arguments, getTracingArguments(cluster, version, customParameters)...)
+
+	if cluster.DeriveMemoryKnobsFromResources() {
+		configuration.Arguments = append(configuration.Arguments, getDerivedMemoryKnobArguments(cluster, processClass, processCount, customParameters)...)
+	}
+
+	for _, argument := range customParameters {
 		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{
 			ArgumentType: monitorapi.ConcatenateArgumentType,
 			Values:       generateMonitorArgumentFromCustomParameter(argument),
@@ -244,7 +470,14 @@ func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, pro
 		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{Value: getKnobParameterWithValue(fdbv1beta2.FDBLocalityDataHallKey, cluster.Spec.DataHall, true)})
 	}
 
-	if cluster.DefineDNSLocalityFields() {
+	if cluster.UseRackLocality() {
+		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
+			{Value: getKnobParameter(fdbv1beta2.FDBLocalityRackKey, true)},
+			{ArgumentType: monitorapi.EnvironmentArgumentType, Source: fdbv1beta2.EnvNameRackID},
+		}})
+	}
+
+	if cluster.DefineDNSLocalityFields() && versionSupportsArgument(version, "locality_dns_name") {
 		configuration.Arguments = append(configuration.Arguments, monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
 			{Value: "--locality_dns_name="},
 			{ArgumentType: monitorapi.EnvironmentArgumentType, Source: "FDB_DNS_NAME"},
@@ -254,6 +487,167 @@ func GetMonitorProcessConfiguration(cluster *fdbv1beta2.FoundationDBCluster, pro
 	return configuration
 }
 
+// versionGatedArguments contains the arguments that are generated by the operator and are only supported by newer
+// versions of FDB, together with the minimum version that supports them. The key is the argument name without the
+// leading dashes.
+var versionGatedArguments = map[string]fdbv1beta2.Version{
+	"locality_dns_name":              fdbv1beta2.Versions.SupportsDNSInClusterFile,
+	"tracer":                         fdbv1beta2.Versions.SupportsTracing,
+	"knob_tracing_udp_listener_addr": fdbv1beta2.Versions.SupportsTracing,
+}
+
+// versionSupportsArgument returns true if the provided version supports the argument with the provided name. Arguments
+// that have no minimum version are supported by all versions.
+func versionSupportsArgument(version fdbv1beta2.Version, argument string) bool {
+	minimumVersion, ok := versionGatedArguments[argument]
+	if !ok {
+		return true
+	}
+
+	return version.IsAtLeast(minimumVersion)
+}
+
+// getTraceLogDir returns the directory where the fdbserver processes of the provided process class should write their
+// trace logs to.
+func getTraceLogDir(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) string {
+	traceLogDir := cluster.GetProcessSettings(processClass).TraceLogDir
+	if traceLogDir == "" {
+		return "/var/log/fdb-trace-logs"
+	}
+
+	return traceLogDir
+}
+
+// getDerivedMemoryKnobArguments returns the arguments for the memory knobs, based on the memory limit of the main
+// container in the pod template of the process class. If no memory limit is defined, no arguments will be returned.
+// Knobs that are already defined in the custom parameters will be skipped.
+func getDerivedMemoryKnobArguments(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, processCount int, customParameters fdbv1beta2.FoundationDBCustomParameters) []monitorapi.Argument {
+	podTemplate := cluster.GetProcessSettings(processClass).PodTemplate
+	if podTemplate == nil {
+		return nil
+	}
+
+	var memoryLimit int64
+	for _, container := range podTemplate.Spec.Containers {
+		if container.Name != fdbv1beta2.MainContainerName {
+			continue
+		}
+
+		limit, ok := container.Resources.Limits[corev1.ResourceMemory]
+		if ok {
+			memoryLimit = limit.Value()
+		}
+		break
+	}
+
+	if memoryLimit <= 0 {
+		return nil
+	}
+
+	if processCount < 1 {
+		processCount = 1
+	}
+
+	memory := memoryLimit * int64(100-cluster.GetMemoryKnobsHeadroomPercentage()) / 100 / int64(processCount)
+	arguments := make([]monitorapi.Argument, 0, 2)
+	if !customParameters.HasParameter("memory") {
+		arguments = append(arguments, monitorapi.Argument{Value: getKnobParameterWithValue("memory", strconv.FormatInt(memory, 10), false)})
+	}
+
+	if processClass == fdbv1beta2.ProcessClassStorage && strings.HasPrefix(string(cluster.Spec.DatabaseConfiguration.StorageEngine), string(fdbv1beta2.StorageEngineMemory)) && !customParameters.HasParameter("storage_memory") {
+		arguments = append(arguments, monitorapi.Argument{Value: getKnobParameterWithValue("storage_memory", strconv.FormatInt(memory/2, 10), false)})
+	}
+
+	return arguments
+}
+
+// getTracingArguments returns the arguments for distributed tracing based on the tracing settings of the cluster. If
+// the version doesn't support tracing no arguments will be returned. Arguments that are defined as custom parameters
+// take precedence.
+func getTracingArguments(cluster *fdbv1beta2.FoundationDBCluster, version fdbv1beta2.Version, customParameters fdbv1beta2.FoundationDBCustomParameters) []monitorapi.Argument {
+	var arguments []monitorapi.Argument
+	if cluster.Spec.Tracing.Tracer != "" && versionSupportsArgument(version, "tracer") && !customParameters.HasParameter("tracer") {
+		arguments = append(arguments, monitorapi.Argument{Value: getKnobParameterWithValue("tracer", string(cluster.Spec.Tracing.Tracer), false)})
+	}
+
+	if cluster.Spec.Tracing.UDPListenerAddress != "" && versionSupportsArgument(version, "knob_tracing_udp_listener_addr") && !customParameters.HasParameter("knob_tracing_udp_listener_addr") {
+		arguments = append(arguments, monitorapi.Argument{Value: getKnobParameterWithValue("knob_tracing_udp_listener_addr", cluster.Spec.Tracing.UDPListenerAddress, false)})
+	}
+
+	return arguments
+}
+
+// getGeneratedArgumentNames returns the names of all arguments that the operator generates for the provided process
+// class based on the effective cluster spec, without the arguments coming from the custom parameters.
+func getGeneratedArgumentNames(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) map[string]fdbv1beta2.None {
+	generatedCluster := cluster.DeepCopy()
+	generatedCluster.Spec.Processes = nil
+	// The trace format, the tracing settings and the derived memory knobs can be overwritten by a custom parameter.
+	generatedCluster.Spec.TraceFormat = ""
+	generatedCluster.Spec.Tracing = fdbv1beta2.TracingOptions{}
+	generatedCluster.Spec.AutomationOptions.DeriveMemoryKnobsFromResources = nil
+
+	configuration := GetMonitorProcessConfiguration(generatedCluster, processClass, cluster.GetDesiredServersPerPod(processClass), GetDesiredImageType(cluster))
+	names := make(map[string]fdbv1beta2.None, len(configuration.Arguments))
+	for _, argument := range configuration.Arguments {
+		value := argument.Value
+		if argument.ArgumentType == monitorapi.ConcatenateArgumentType && len(argument.Values) > 0 {
+			value = argument.Values[0].Value
+		}
+
+		names[strings.SplitN(strings.TrimPrefix(value, "--"), "=", 2)[0]] = fdbv1beta2.None{}
+	}
+
+	return names
+}
+
+// validateCustomParametersAgainstGeneratedArguments ensures that the custom parameters don't define any argument that
+// is already generated by the operator, otherwise fdbserver would receive the argument twice.
+func validateCustomParametersAgainstGeneratedArguments(cluster *fdbv1beta2.FoundationDBCluster) error {
+	violations := make([]string, 0)
+
+	for processClass, settings := range cluster.Spec.Processes {
+		parameters := make(fdbv1beta2.FoundationDBCustomParameters, 0, len(settings.CustomParameters))
+		parameters = append(parameters, settings.CustomParameters...)
+		for _, entry := range settings.CustomParametersPerProcess {
+			parameters = append(parameters, entry.CustomParameters...)
+		}
+
+		if len(parameters) == 0 {
+			continue
+		}
+
+		// The general process settings are used for all process classes, the storage class is used as reference
+		// since it generates the most arguments.
+		referenceClass := processClass
+		if referenceClass == fdbv1beta2.ProcessClassGeneral {
+			referenceClass = fdbv1beta2.ProcessClassStorage
+		}
+
+		generatedNames := getGeneratedArgumentNames(cluster, referenceClass)
+		conflicts := make([]string, 0)
+		for name := range generatedNames {
+			if parameters.HasParameter(name) {
+				conflicts = append(conflicts, name)
+			}
+		}
+
+		if len(conflicts) == 0 {
+			continue
+		}
+
+		sort.Strings(conflicts)
+		violations = append(violations, fmt.Sprintf("process class %s: %s", processClass, strings.Join(conflicts, ", ")))
+	}
+
+	if len(violations) > 0 {
+		sort.Strings(violations)
+		return fmt.Errorf("found customParameters that conflict with the arguments generated by the operator:\n%s", strings.Join(violations, "\n"))
+	}
+
+	return nil
+}
+
 // Generate the monitor API configuration based on the provided custom parameter
 func generateMonitorArgumentFromCustomParameter(argument fdbv1beta2.FoundationDBCustomParameter) []monitorapi.Argument {
 	splitArgument := strings.Split(string(argument), "=")
@@ -301,8 +695,9 @@ func getKnobParameterWithValue(key string, value string, isLocality bool) string
 	return getKnobParameter(key, isLocality) + value
 }
 
-// buildIPArgument builds an argument that takes an IP address from an environment variable
-func buildIPArgument(parameter string, environmentVariable string, imageType FDBImageType, sampleAddresses []fdbv1beta2.ProcessAddress, podIPFamily *int) []monitorapi.Argument {
+// buildIPArgument builds an argument that takes an IP address from an environment variable. If multiple IP families
+// are provided, an address for every IP family will be added for each of the sample addresses.
+func buildIPArgument(parameter string, environmentVariable string, imageType FDBImageType, sampleAddresses []fdbv1beta2.ProcessAddress, ipFamilies []int) []monitorapi.Argument {
 	var leftIPWrap string
 	var rightIPWrap string
 	if imageType == FDBImageTypeUnified {
@@ -314,31 +709,37 @@ func buildIPArgument(parameter string, environmentVariable string, imageType FDB
 	}
 	arguments := []monitorapi.Argument{{Value: fmt.Sprintf("--%s=%s", parameter, leftIPWrap)}}
 
-	for indexOfAddress, address := range sampleAddresses {
-		if indexOfAddress != 0 {
-			arguments = append(arguments, monitorapi.Argument{Value: fmt.Sprintf(",%s", leftIPWrap)})
+	var ipArguments []monitorapi.Argument
+	if len(ipFamilies) > 0 && imageType == FDBImageTypeUnified {
+		ipArguments = make([]monitorapi.Argument, 0, len(ipFamilies))
+		for _, ipFamily := range ipFamilies {
+			ipArguments = append(ipArguments, monitorapi.Argument{
+				ArgumentType: monitorapi.IPListArgumentType,
+				Source:       environmentVariable,
+				IPFamily:     ipFamily,
+			})
 		}
+	} else {
+		ipArguments = []monitorapi.Argument{{ArgumentType: monitorapi.EnvironmentArgumentType, Source: environmentVariable}}
+	}
 
-		ipArgument := monitorapi.Argument{
-			Source: environmentVariable,
-		}
-		if podIPFamily != nil && imageType == FDBImageTypeUnified {
-			ipArgument.ArgumentType = monitorapi.IPListArgumentType
-			ipArgument.IPFamily = *podIPFamily
-		} else {
-			ipArgument.ArgumentType = monitorapi.EnvironmentArgumentType
-		}
+	for indexOfAddress, address := range sampleAddresses {
+		flags := address.SortedFlags()
 
-		arguments = append(arguments,
-			ipArgument,
-			monitorapi.Argument{Value: fmt.Sprintf("%s:", rightIPWrap)},
-			monitorapi.Argument{ArgumentType: monitorapi.ProcessNumberArgumentType, Offset: address.Port - 2, Multiplier: 2},
-		)
+		for indexOfIP, ipArgument := range ipArguments {
+			if indexOfAddress != 0 || indexOfIP != 0 {
+				arguments = append(arguments, monitorapi.Argument{Value: fmt.Sprintf(",%s", leftIPWrap)})
+			}
 
-		flags := address.SortedFlags()
+			arguments = append(arguments,
+				ipArgument,
+				monitorapi.Argument{Value: fmt.Sprintf("%s:", rightIPWrap)},
+				monitorapi.Argument{ArgumentType: monitorapi.ProcessNumberArgumentType, Offset: address.Port - 2, Multiplier: 2},
+			)
 
-		if len(flags) > 0 {
-			arguments = append(arguments, monitorapi.Argument{Value: fmt.Sprintf(":%s", strings.Join(flags, ":"))})
+			if len(flags) > 0 {
+				arguments = append(arguments, monitorapi.Argument{Value: fmt.Sprintf(":%s", strings.Join(flags, ":"))})
+			}
 		}
 	}
 	return arguments
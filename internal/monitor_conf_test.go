@@ -21,6 +21,7 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -31,6 +32,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 )
 
@@ -215,6 +217,49 @@ var _ = Describe("monitor_conf", func() {
 				}}))
 			})
 
+			When("using IPv6 as PodIPFamily", func() {
+				BeforeEach(func() {
+					cluster.Spec.Routing.PodIPFamily = pointer.Int(6)
+				})
+
+				It("specifies the IP family for the public and the listen address", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+					Expect(config.Arguments[2]).To(Equal(monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
+						{Value: "--public_address=["},
+						{ArgumentType: monitorapi.IPListArgumentType, Source: fdbv1beta2.EnvNamePublicIP, IPFamily: 6},
+						{Value: "]:"},
+						{ArgumentType: monitorapi.ProcessNumberArgumentType, Offset: 4499, Multiplier: 2},
+					}}))
+					Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
+						{Value: "--listen_address=["},
+						{ArgumentType: monitorapi.IPListArgumentType, Source: "FDB_POD_IP", IPFamily: 6},
+						{Value: "]:"},
+						{ArgumentType: monitorapi.ProcessNumberArgumentType, Offset: 4499, Multiplier: 2},
+					}}))
+				})
+
+				It("selects the IPv6 address for the listen address", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					argument, err := config.Arguments[10].GenerateArgument(1, map[string]string{"FDB_POD_IP": "192.168.0.2,2001:db8::1"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(argument).To(Equal("--listen_address=[2001:db8::1]:4501"))
+				})
+
+				When("the split image is used", func() {
+					It("uses the environment variable for the listen address", func() {
+						config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeSplit)
+						Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+						Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
+							{Value: "--listen_address="},
+							{ArgumentType: monitorapi.EnvironmentArgumentType, Source: "FDB_POD_IP"},
+							{Value: ":"},
+							{ArgumentType: monitorapi.ProcessNumberArgumentType, Offset: 4499, Multiplier: 2},
+						}}))
+					})
+				})
+			})
+
 			When("some pods do not have the listen IP environment variable", func() {
 				BeforeEach(func() {
 					cluster.Status.HasListenIPsForAllPods = false
@@ -393,6 +438,66 @@ var _ = Describe("monitor_conf", func() {
 					}}))
 				})
 			})
+
+			When("using dual-stack addresses", func() {
+				var env map[string]string
+
+				BeforeEach(func() {
+					cluster.Spec.Routing.DualStack = pointer.Bool(true)
+					env = map[string]string{
+						fdbv1beta2.EnvNamePublicIP: "2001:db8::1,192.168.0.2",
+					}
+				})
+
+				It("specifies an address for both IP families", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+					Expect(config.Arguments[2]).To(Equal(monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
+						{Value: "--public_address=["},
+						{ArgumentType: monitorapi.IPListArgumentType, Source: fdbv1beta2.EnvNamePublicIP, IPFamily: 4},
+						{Value: "]:"},
+						{ArgumentType: monitorapi.ProcessNumberArgumentType, Offset: 4499, Multiplier: 2},
+						{Value: ",["},
+						{ArgumentType: monitorapi.IPListArgumentType, Source: fdbv1beta2.EnvNamePublicIP, IPFamily: 6},
+						{Value: "]:"},
+						{ArgumentType: monitorapi.ProcessNumberArgumentType, Offset: 4499, Multiplier: 2},
+					}}))
+				})
+
+				It("generates the public address for both IP families", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					argument, err := config.Arguments[2].GenerateArgument(1, env)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(argument).To(Equal("--public_address=[192.168.0.2]:4501,[2001:db8::1]:4501"))
+				})
+
+				When("IPv6 is used as PodIPFamily", func() {
+					BeforeEach(func() {
+						cluster.Spec.Routing.PodIPFamily = pointer.Int(6)
+					})
+
+					It("uses the IPv6 address as primary address", func() {
+						config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+						argument, err := config.Arguments[2].GenerateArgument(1, env)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(argument).To(Equal("--public_address=[2001:db8::1]:4501,[192.168.0.2]:4501"))
+					})
+				})
+
+				When("the cluster is transitioning to TLS", func() {
+					BeforeEach(func() {
+						cluster.Status.RequiredAddresses.NonTLS = true
+						cluster.Status.RequiredAddresses.TLS = true
+					})
+
+					It("generates the TLS and non-TLS addresses for both IP families", func() {
+						config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 2, FDBImageTypeUnified)
+						argument, err := config.Arguments[2].GenerateArgument(2, env)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(argument).To(Equal("--public_address=[192.168.0.2]:4502:tls,[2001:db8::1]:4502:tls,[192.168.0.2]:4503,[2001:db8::1]:4503"))
+					})
+				})
+			})
 		})
 
 		When("the cluster has an alternative fault domain variable", func() {
@@ -426,6 +531,185 @@ var _ = Describe("monitor_conf", func() {
 			})
 		})
 
+		When("the spec has a trace format", func() {
+			BeforeEach(func() {
+				cluster.Spec.TraceFormat = fdbv1beta2.TraceFormatJSON
+			})
+
+			It("includes the trace format", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+				Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{Value: "--trace_format=json"}))
+			})
+
+			When("the trace format is also defined as custom parameter", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{fdbv1beta2.ProcessClassGeneral: {CustomParameters: fdbv1beta2.FoundationDBCustomParameters{
+						"trace_format = xml",
+					}}}
+				})
+
+				It("uses the custom parameter", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+					Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{
+						ArgumentType: monitorapi.ConcatenateArgumentType,
+						Values: []monitorapi.Argument{
+							{
+								ArgumentType: monitorapi.LiteralArgumentType,
+								Value:        "--trace_format=",
+							},
+							{
+								ArgumentType: monitorapi.LiteralArgumentType,
+								Value:        "xml",
+							},
+						}}))
+				})
+			})
+		})
+
+		When("the spec has tracing settings", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = fdbv1beta2.Versions.SupportsTracing.String()
+				cluster.Spec.Tracing = fdbv1beta2.TracingOptions{
+					Tracer:             fdbv1beta2.TracerNetworkLossy,
+					UDPListenerAddress: "127.0.0.1:8889",
+				}
+			})
+
+			It("includes the tracing arguments", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength + 2))
+				Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{Value: "--tracer=network_lossy"}))
+				Expect(config.Arguments[11]).To(Equal(monitorapi.Argument{Value: "--knob_tracing_udp_listener_addr=127.0.0.1:8889"}))
+			})
+
+			When("the version doesn't support tracing", func() {
+				BeforeEach(func() {
+					cluster.Spec.Version = fdbv1beta2.Versions.Default.String()
+				})
+
+				It("doesn't include the tracing arguments", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+				})
+			})
+		})
+
+		When("the memory knobs should be derived from the resources", func() {
+			var podTemplate *corev1.PodTemplateSpec
+
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.DeriveMemoryKnobsFromResources = pointer.Bool(true)
+				podTemplate = &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: fdbv1beta2.MainContainerName,
+								Resources: corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{
+										corev1.ResourceMemory: resource.MustParse("10Gi"),
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				settings.PodTemplate = podTemplate
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = settings
+			})
+
+			It("includes the memory knob for a single server per Pod", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+				Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{Value: "--memory=9663676416"}))
+			})
+
+			It("divides the memory knob for multiple servers per Pod", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 2, FDBImageTypeUnified)
+				Expect(config.Arguments).To(ContainElement(monitorapi.Argument{Value: "--memory=4831838208"}))
+			})
+
+			When("a custom headroom is defined", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.MemoryKnobsHeadroomPercentage = pointer.Int(20)
+				})
+
+				It("uses the custom headroom", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(ContainElement(monitorapi.Argument{Value: "--memory=8589934592"}))
+				})
+			})
+
+			When("the memory storage engine is used", func() {
+				BeforeEach(func() {
+					cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineMemory
+				})
+
+				It("includes the storage memory knob for storage processes", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 2))
+					Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{Value: "--memory=9663676416"}))
+					Expect(config.Arguments[11]).To(Equal(monitorapi.Argument{Value: "--storage_memory=4831838208"}))
+				})
+
+				It("doesn't include the storage memory knob for log processes", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassLog, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+					Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{Value: "--memory=9663676416"}))
+				})
+			})
+
+			When("the memory knob is also defined as custom parameter", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{fdbv1beta2.ProcessClassGeneral: {CustomParameters: fdbv1beta2.FoundationDBCustomParameters{
+						"memory = 4GiB",
+					}}}
+				})
+
+				It("uses the custom parameter", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+					Expect(config.Arguments[10].Values).To(Equal([]monitorapi.Argument{
+						{ArgumentType: monitorapi.LiteralArgumentType, Value: "--memory="},
+						{ArgumentType: monitorapi.LiteralArgumentType, Value: "4GiB"},
+					}))
+				})
+			})
+
+			When("the pod template has no memory limit", func() {
+				BeforeEach(func() {
+					podTemplate.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+				})
+
+				It("doesn't include the memory knob", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+				})
+			})
+		})
+
+		When("the process class has a custom trace log dir", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassLog] = fdbv1beta2.ProcessSettings{TraceLogDir: "/var/log/fdb-trace-logs/log"}
+			})
+
+			It("includes the trace log dir for the process class", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassLog, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+				Expect(config.Arguments[4]).To(Equal(monitorapi.Argument{Value: "--logdir=/var/log/fdb-trace-logs/log"}))
+			})
+
+			It("uses the default trace log dir for other process classes", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				Expect(config.Arguments[4]).To(Equal(monitorapi.Argument{Value: "--logdir=/var/log/fdb-trace-logs"}))
+			})
+		})
+
 		When("the spec has a custom log group", func() {
 			BeforeEach(func() {
 				cluster.Spec.LogGroup = "test-fdb-cluster"
@@ -438,6 +722,51 @@ var _ = Describe("monitor_conf", func() {
 			})
 		})
 
+		When("the process class has a custom log group", func() {
+			BeforeEach(func() {
+				cluster.Spec.LogGroup = "test-fdb-cluster"
+				settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage]
+				settings.LogGroup = "storage-tier"
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage] = settings
+			})
+
+			It("includes the log group of the process class", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+				Expect(config.Arguments[5]).To(Equal(monitorapi.Argument{Value: "--loggroup=storage-tier"}))
+			})
+
+			It("falls back to the log group of the cluster for other process classes", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStateless, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+				Expect(config.Arguments[5]).To(Equal(monitorapi.Argument{Value: "--loggroup=test-fdb-cluster"}))
+			})
+		})
+
+		When("the automatic log group prefix is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomaticLogGroupPrefix = pointer.Bool(true)
+			})
+
+			It("includes the prefixed log group", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+				Expect(config.Arguments[5]).To(Equal(monitorapi.Argument{Value: "--loggroup=" + cluster.Namespace + "." + cluster.Name}))
+			})
+
+			When("the spec has a custom log group", func() {
+				BeforeEach(func() {
+					cluster.Spec.LogGroup = "test-fdb-cluster"
+				})
+
+				It("includes the custom log group", func() {
+					config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+					Expect(config.Arguments).To(HaveLen(baseArgumentLength))
+					Expect(config.Arguments[5]).To(Equal(monitorapi.Argument{Value: "--loggroup=test-fdb-cluster"}))
+				})
+			})
+		})
+
 		When("the spec has a data center", func() {
 			BeforeEach(func() {
 				cluster.Spec.DataCenter = "dc01"
@@ -461,6 +790,34 @@ var _ = Describe("monitor_conf", func() {
 				Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{Value: "--locality_data_hall=dh01"}))
 			})
 		})
+
+		When("the seed cluster file is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.UseSeedClusterFile = pointer.Bool(false)
+			})
+
+			It("doesn't include the seed cluster file", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength - 1))
+				Expect(config.Arguments[0]).To(Equal(monitorapi.Argument{Value: "--cluster_file=/var/fdb/data/fdb.cluster"}))
+				Expect(config.Arguments).NotTo(ContainElement(monitorapi.Argument{Value: "--seed_cluster_file=/var/dynamic-conf/fdb.cluster"}))
+			})
+		})
+
+		When("the spec has a rack key", func() {
+			BeforeEach(func() {
+				cluster.Spec.FaultDomain.RackKey = "topology.kubernetes.io/rack"
+			})
+
+			It("adds an argument for the rack", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength + 1))
+				Expect(config.Arguments[10]).To(Equal(monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
+					{Value: "--locality_rack="},
+					{ArgumentType: monitorapi.EnvironmentArgumentType, Source: fdbv1beta2.EnvNameRackID},
+				}}))
+			})
+		})
 	})
 
 	Describe("GetStartCommand", func() {
@@ -528,6 +885,53 @@ var _ = Describe("monitor_conf", func() {
 					}, " ")))
 				})
 			})
+
+			Context("with a custom parameter that references the Pod IP", func() {
+				BeforeEach(func() {
+					settings := cluster.Spec.Processes["general"]
+					settings.CustomParameters = []fdbv1beta2.FoundationDBCustomParameter{"locality_listen_ip=$FDB_POD_IP"}
+					cluster.Spec.Processes["general"] = settings
+				})
+
+				It("should substitute the Pod IP", func() {
+					substitutions, err := GetSubstitutionsFromClusterAndPod(logr.Discard(), cluster, pod)
+					Expect(err).NotTo(HaveOccurred())
+					command, err = GetStartCommandWithSubstitutions(cluster, processClass, substitutions, 1, 1)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(command).To(ContainSubstring(fmt.Sprintf("--locality_listen_ip=%s ", address)))
+					Expect(command).To(ContainSubstring(fmt.Sprintf("--public_address=%s:4501", address)))
+				})
+
+				When("the public IP comes from the service", func() {
+					BeforeEach(func() {
+						source := fdbv1beta2.PublicIPSourceService
+						cluster.Spec.Routing.PublicIPSource = &source
+						pod.Annotations[fdbv1beta2.PublicIPAnnotation] = "192.168.0.1"
+					})
+
+					It("should substitute the Pod IP and use the service IP as public address", func() {
+						substitutions, err := GetSubstitutionsFromClusterAndPod(logr.Discard(), cluster, pod)
+						Expect(err).NotTo(HaveOccurred())
+						command, err = GetStartCommandWithSubstitutions(cluster, processClass, substitutions, 1, 1)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(command).To(ContainSubstring(fmt.Sprintf("--locality_listen_ip=%s ", address)))
+						Expect(command).To(ContainSubstring("--public_address=192.168.0.1:4501"))
+					})
+				})
+			})
+
+			Context("with a custom parameter that references a missing substitution", func() {
+				It("should return an error", func() {
+					settings := cluster.Spec.Processes["general"]
+					settings.CustomParameters = []fdbv1beta2.FoundationDBCustomParameter{"knob_foo=$FDB_FOO"}
+					cluster.Spec.Processes["general"] = settings
+
+					substitutions, err := GetSubstitutionsFromClusterAndPod(logr.Discard(), cluster, pod)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = GetStartCommandWithSubstitutions(cluster, processClass, substitutions, 1, 1)
+					Expect(err).To(MatchError("custom parameter \"knob_foo=$FDB_FOO\" for process class storage and process number 1 references the environment variable FDB_FOO, which is not present in the substitutions"))
+				})
+			})
 		})
 
 		When("using the unified image", func() {
@@ -743,6 +1147,128 @@ var _ = Describe("monitor_conf", func() {
 			})
 		})
 
+		Context("with a rack key", func() {
+			BeforeEach(func() {
+				cluster.Spec.FaultDomain.RackKey = "topology.kubernetes.io/rack"
+				pod.Annotations[fdbv1beta2.RackAnnotation] = "rack-1"
+				substitutions, err := GetSubstitutionsFromClusterAndPod(logr.Discard(), cluster, pod)
+				Expect(err).NotTo(HaveOccurred())
+				command, err = GetStartCommandWithSubstitutions(cluster, processClass, substitutions, 1, 1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("includes the rack from the Pod annotation in the start command", func() {
+				Expect(command).To(Equal(strings.Join([]string{
+					"/usr/bin/fdbserver",
+					"--class=storage",
+					"--cluster_file=/var/fdb/data/fdb.cluster",
+					"--datadir=/var/fdb/data",
+					fmt.Sprintf("--locality_instance_id=%s", processGroupID),
+					fmt.Sprintf("--locality_machineid=%s-%s", cluster.Name, processGroupID),
+					"--locality_rack=rack-1",
+					fmt.Sprintf("--locality_zoneid=%s-%s", cluster.Name, processGroupID),
+					"--logdir=/var/log/fdb-trace-logs",
+					"--loggroup=" + cluster.Name,
+					fmt.Sprintf("--public_address=%s:4501", address),
+					"--seed_cluster_file=/var/dynamic-conf/fdb.cluster",
+				}, " ")))
+			})
+		})
+
+		DescribeTable("generating the machine ID",
+			func(faultDomain fdbv1beta2.FoundationDBClusterFaultDomain, threeDataHall bool, source *fdbv1beta2.MachineIDSource, expectedMachineID string) {
+				pod.Spec.NodeName = "machine1"
+				pod.Annotations[fdbv1beta2.MachineIDAnnotation] = "host-1"
+				pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{Name: "CUSTOM_MACHINE_ID", Value: "custom-1"})
+				cluster.Spec.FaultDomain = faultDomain
+				cluster.Spec.MachineIDSource = source
+				if threeDataHall {
+					cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeThreeDataHall
+					cluster.Spec.DataHall = "az1"
+				}
+
+				substitutions, err := GetSubstitutionsFromClusterAndPod(logr.Discard(), cluster, pod)
+				Expect(err).NotTo(HaveOccurred())
+				command, err = GetStartCommandWithSubstitutions(cluster, processClass, substitutions, 1, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(command).To(ContainSubstring(" --locality_machineid=" + expectedMachineID + " "))
+			},
+			Entry("without a fault domain",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: fdbv1beta2.NoneFaultDomainKey},
+				false,
+				nil,
+				"operator-test-1-storage-1",
+			),
+			Entry("with the hostname fault domain",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: corev1.LabelHostname},
+				false,
+				nil,
+				"machine1",
+			),
+			Entry("with three data hall replication",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: corev1.LabelHostname},
+				true,
+				nil,
+				"machine1",
+			),
+			Entry("with cross-Kubernetes replication",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: "foundationdb.org/kubernetes-cluster", Value: "kc2"},
+				false,
+				nil,
+				"machine1",
+			),
+			Entry("with a node label source and without a fault domain",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: fdbv1beta2.NoneFaultDomainKey},
+				false,
+				&fdbv1beta2.MachineIDSource{NodeLabel: corev1.LabelHostname},
+				"host-1",
+			),
+			Entry("with a node label source and the hostname fault domain",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: corev1.LabelHostname},
+				false,
+				&fdbv1beta2.MachineIDSource{NodeLabel: corev1.LabelHostname},
+				"host-1",
+			),
+			Entry("with a node label source and three data hall replication",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: corev1.LabelHostname},
+				true,
+				&fdbv1beta2.MachineIDSource{NodeLabel: corev1.LabelHostname},
+				"host-1",
+			),
+			Entry("with a node label source and cross-Kubernetes replication",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: "foundationdb.org/kubernetes-cluster", Value: "kc2"},
+				false,
+				&fdbv1beta2.MachineIDSource{NodeLabel: corev1.LabelHostname},
+				"host-1",
+			),
+			Entry("with an environment variable source and the hostname fault domain",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: corev1.LabelHostname},
+				false,
+				&fdbv1beta2.MachineIDSource{EnvironmentVariable: "CUSTOM_MACHINE_ID"},
+				"custom-1",
+			),
+			Entry("with an environment variable source and three data hall replication",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: corev1.LabelHostname},
+				true,
+				&fdbv1beta2.MachineIDSource{EnvironmentVariable: "CUSTOM_MACHINE_ID"},
+				"custom-1",
+			),
+			Entry("with an environment variable source and cross-Kubernetes replication",
+				fdbv1beta2.FoundationDBClusterFaultDomain{Key: "foundationdb.org/kubernetes-cluster", Value: "kc2"},
+				false,
+				&fdbv1beta2.MachineIDSource{EnvironmentVariable: "CUSTOM_MACHINE_ID"},
+				"custom-1",
+			),
+		)
+
+		When("the machine ID environment variable is missing in the Pod", func() {
+			It("should return an error", func() {
+				cluster.Spec.MachineIDSource = &fdbv1beta2.MachineIDSource{EnvironmentVariable: "MISSING_MACHINE_ID"}
+				_, err := GetSubstitutionsFromClusterAndPod(logr.Discard(), cluster, pod)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
 		Context("with binaries from the main container", func() {
 			BeforeEach(func() {
 				cluster.Spec.Version = fdbv1beta2.Versions.Default.String()
@@ -807,6 +1333,134 @@ var _ = Describe("monitor_conf", func() {
 			})
 		})
 
+		Context("with custom fdbmonitor settings", func() {
+			BeforeEach(func() {
+				cluster.Spec.FdbMonitor = fdbv1beta2.FdbMonitorSettings{
+					RestartDelaySeconds:              pointer.Int(10),
+					RestartBackoff:                   pointer.Int(2),
+					RestartDelayResetIntervalSeconds: pointer.Int(120),
+				}
+				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should use the settings in the general section", func() {
+				Expect(conf).To(HavePrefix(strings.Join([]string{
+					"[general]",
+					"kill_on_configuration_change = false",
+					"restart_delay = 10",
+					"restart_backoff = 2",
+					"restart_delay_reset_interval = 120",
+					"[fdbserver.1]",
+				}, "\n")))
+			})
+
+			It("should not change the unified image configuration", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+				cluster.Spec.FdbMonitor = fdbv1beta2.FdbMonitorSettings{}
+				Expect(config).To(Equal(GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)))
+			})
+		})
+
+		Context("with a custom process port base", func() {
+			BeforeEach(func() {
+				cluster.Spec.Routing.ProcessPortBase = pointer.Int(5001)
+				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, 2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should use the process port base for the public address", func() {
+				Expect(conf).To(ContainSubstring("[fdbserver.1]\ncommand = $BINARY_DIR/fdbserver\ncluster_file = /var/fdb/data/fdb.cluster\nseed_cluster_file = /var/dynamic-conf/fdb.cluster\npublic_address = $FDB_PUBLIC_IP:5001\n"))
+				Expect(conf).To(ContainSubstring("[fdbserver.2]\ncommand = $BINARY_DIR/fdbserver\ncluster_file = /var/fdb/data/fdb.cluster\nseed_cluster_file = /var/dynamic-conf/fdb.cluster\npublic_address = $FDB_PUBLIC_IP:5003\n"))
+			})
+		})
+
+		Context("with custom parameters for a specific process", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassStorage: {
+						CustomParameters: fdbv1beta2.FoundationDBCustomParameters{
+							"knob_cache_memory = 1000",
+							"knob_disable_posix_kernel_aio = 1",
+						},
+						CustomParametersPerProcess: []fdbv1beta2.ProcessCustomParameters{
+							{
+								ProcessNumber: 1,
+								CustomParameters: fdbv1beta2.FoundationDBCustomParameters{
+									"knob_cache_memory = 2000",
+								},
+							},
+						},
+					},
+				}
+				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, 2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should use the process specific parameters for the first process", func() {
+				sections := strings.Split(conf, "[fdbserver.2]")
+				Expect(sections).To(HaveLen(2))
+				Expect(sections[0]).To(ContainSubstring("knob_disable_posix_kernel_aio = 1\nknob_cache_memory = 2000\n"))
+				Expect(sections[0]).NotTo(ContainSubstring("knob_cache_memory = 1000"))
+				Expect(sections[1]).To(ContainSubstring("knob_cache_memory = 1000\nknob_disable_posix_kernel_aio = 1"))
+				Expect(sections[1]).NotTo(ContainSubstring("knob_cache_memory = 2000"))
+			})
+
+			It("should not change the unified image configuration", func() {
+				config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 2, FDBImageTypeUnified)
+				settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage]
+				settings.CustomParametersPerProcess = nil
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage] = settings
+				Expect(config).To(Equal(GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 2, FDBImageTypeUnified)))
+			})
+		})
+
+		Context("with a trace format", func() {
+			BeforeEach(func() {
+				cluster.Spec.TraceFormat = fdbv1beta2.TraceFormatJSON
+				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the trace format", func() {
+				Expect(conf).To(HaveSuffix("\ntrace_format = json"))
+			})
+		})
+
+		Context("with a tracer", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = fdbv1beta2.Versions.SupportsTracing.String()
+				cluster.Spec.Tracing.Tracer = fdbv1beta2.TracerLogFile
+				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the tracer", func() {
+				Expect(conf).To(HaveSuffix("\ntracer = log_file"))
+			})
+		})
+
+		Context("with a log group for the storage process class", func() {
+			BeforeEach(func() {
+				cluster.Spec.LogGroup = "cluster-log-group"
+				settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage]
+				settings.LogGroup = "storage-log-group"
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage] = settings
+			})
+
+			It("should use the log group of the process class for storage processes", func() {
+				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf).To(ContainSubstring("loggroup = storage-log-group\n"))
+			})
+
+			It("should use the log group of the cluster for other processes", func() {
+				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStateless, nil, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf).To(ContainSubstring("loggroup = cluster-log-group\n"))
+			})
+		})
+
 		Context("with a test instance", func() {
 			BeforeEach(func() {
 				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassTest, nil, cluster.GetStorageServersPerPod())
@@ -837,6 +1491,7 @@ var _ = Describe("monitor_conf", func() {
 		Context("with DNS names enabled", func() {
 			BeforeEach(func() {
 				cluster.Spec.Routing.UseDNSInClusterFile = pointer.Bool(true)
+				cluster.Spec.Version = fdbv1beta2.Versions.SupportsDNSInClusterFile.String()
 				cluster.Status.RunningVersion = fdbv1beta2.Versions.SupportsDNSInClusterFile.String()
 				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod())
 				Expect(err).NotTo(HaveOccurred())
@@ -867,6 +1522,7 @@ var _ = Describe("monitor_conf", func() {
 		Context("with DNS names in locality fields", func() {
 			BeforeEach(func() {
 				cluster.Spec.Routing.DefineDNSLocalityFields = pointer.Bool(true)
+				cluster.Spec.Version = fdbv1beta2.Versions.SupportsDNSInClusterFile.String()
 				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod())
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -893,6 +1549,30 @@ var _ = Describe("monitor_conf", func() {
 			})
 		})
 
+		Context("with processes killed on configuration changes", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.KillProcessesOnConfigurationChange = pointer.Bool(true)
+				conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should enable kill_on_configuration_change", func() {
+				Expect(conf).To(HavePrefix("[general]\nkill_on_configuration_change = true\n"))
+			})
+
+			When("a version incompatible upgrade is in progress", func() {
+				BeforeEach(func() {
+					cluster.Spec.Version = fdbv1beta2.Versions.NextMajorVersion.String()
+					conf, err = GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod())
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should disable kill_on_configuration_change", func() {
+					Expect(conf).To(HavePrefix("[general]\nkill_on_configuration_change = false\n"))
+				})
+			})
+		})
+
 		Context("with a basic storage instance with multiple storage servers per Pod", func() {
 			BeforeEach(func() {
 				cluster.Spec.StorageServersPerPod = 2
@@ -1305,4 +1985,312 @@ var _ = Describe("monitor_conf", func() {
 		})
 	})
 
+	Describe("GetMonitorConfForTLSTransitionPhase", func() {
+		BeforeEach(func() {
+			cluster.Status.ConnectionString = fakeConnectionString
+			// The required addresses in the status should be ignored.
+			cluster.Status.RequiredAddresses.NonTLS = true
+			cluster.Status.RequiredAddresses.TLS = false
+		})
+
+		DescribeTable("generating the conf for a phase",
+			func(enableTLS bool, phase TLSTransitionPhase, expectedAddress string) {
+				cluster.Spec.MainContainer.EnableTLS = enableTLS
+				conf, err := GetMonitorConfForTLSTransitionPhase(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod(), phase)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf).To(Equal(strings.Join([]string{
+					"[general]",
+					"kill_on_configuration_change = false",
+					"restart_delay = 60",
+					"[fdbserver.1]",
+					"command = $BINARY_DIR/fdbserver",
+					"cluster_file = /var/fdb/data/fdb.cluster",
+					"seed_cluster_file = /var/dynamic-conf/fdb.cluster",
+					"public_address = " + expectedAddress,
+					"class = storage",
+					"logdir = /var/log/fdb-trace-logs",
+					"loggroup = " + cluster.Name,
+					"datadir = /var/fdb/data",
+					"locality_instance_id = $FDB_INSTANCE_ID",
+					"locality_machineid = $FDB_MACHINE_ID",
+					"locality_zoneid = $FDB_ZONE_ID",
+				}, "\n")))
+				// The cluster status must not be modified.
+				Expect(cluster.Status.RequiredAddresses).To(Equal(fdbv1beta2.RequiredAddressSet{NonTLS: true}))
+			},
+			Entry("transition to TLS before the transition", true, TLSTransitionPhasePre, "$FDB_PUBLIC_IP:4501"),
+			Entry("transition to TLS during the transition", true, TLSTransitionPhaseDual, "$FDB_PUBLIC_IP:4500:tls,$FDB_PUBLIC_IP:4501"),
+			Entry("transition to TLS after the transition", true, TLSTransitionPhasePost, "$FDB_PUBLIC_IP:4500:tls"),
+			Entry("transition to non-TLS before the transition", false, TLSTransitionPhasePre, "$FDB_PUBLIC_IP:4500:tls"),
+			Entry("transition to non-TLS during the transition", false, TLSTransitionPhaseDual, "$FDB_PUBLIC_IP:4500:tls,$FDB_PUBLIC_IP:4501"),
+			Entry("transition to non-TLS after the transition", false, TLSTransitionPhasePost, "$FDB_PUBLIC_IP:4501"),
+		)
+
+		When("an unknown phase is provided", func() {
+			It("should return an error", func() {
+				_, err := GetMonitorConfForTLSTransitionPhase(cluster, fdbv1beta2.ProcessClassStorage, nil, cluster.GetStorageServersPerPod(), "unknown")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("RenderProcessConfiguration", func() {
+		var rendered *RenderedProcessConfiguration
+
+		When("no substitutions are provided", func() {
+			BeforeEach(func() {
+				cluster.Status.ConnectionString = ""
+				rendered, err = RenderProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should render the monitor conf without a connection string", func() {
+				cluster.Status.ConnectionString = fakeConnectionString
+				expected, err := GetMonitorConf(cluster, fdbv1beta2.ProcessClassStorage, nil, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rendered.MonitorConf).To(Equal(expected))
+			})
+
+			It("should render the same process configuration as the config map", func() {
+				config := monitorapi.ProcessConfiguration{}
+				Expect(json.Unmarshal(rendered.ProcessConfiguration, &config)).NotTo(HaveOccurred())
+				Expect(config).To(Equal(GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)))
+			})
+
+			It("should not render any start commands", func() {
+				Expect(rendered.StartCommands).To(BeNil())
+			})
+		})
+
+		When("substitutions are provided", func() {
+			var substitutions map[string]string
+
+			BeforeEach(func() {
+				substitutions = map[string]string{
+					"BINARY_DIR":      "/usr/bin",
+					"FDB_PUBLIC_IP":   "192.168.0.1",
+					"FDB_POD_IP":      "192.168.0.1",
+					"FDB_MACHINE_ID":  "machine1",
+					"FDB_ZONE_ID":     "zone1",
+					"FDB_INSTANCE_ID": "storage-1",
+				}
+				rendered, err = RenderProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 2, FDBImageTypeSplit, substitutions)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should resolve the substitutions in the monitor conf", func() {
+				Expect(rendered.MonitorConf).To(ContainSubstring("command = /usr/bin/fdbserver"))
+				Expect(rendered.MonitorConf).To(ContainSubstring("public_address = 192.168.0.1:4501"))
+				Expect(rendered.MonitorConf).To(ContainSubstring("public_address = 192.168.0.1:4503"))
+				Expect(rendered.MonitorConf).To(ContainSubstring("locality_zoneid = zone1"))
+			})
+
+			It("should render the start commands for all processes", func() {
+				Expect(rendered.StartCommands).To(HaveLen(2))
+				for idx, command := range rendered.StartCommands {
+					expected, err := GetStartCommandWithSubstitutions(cluster, fdbv1beta2.ProcessClassStorage, map[string]string{
+						"BINARY_DIR":      "/usr/bin",
+						"FDB_PUBLIC_IP":   "192.168.0.1",
+						"FDB_POD_IP":      "192.168.0.1",
+						"FDB_MACHINE_ID":  "machine1",
+						"FDB_ZONE_ID":     "zone1",
+						"FDB_INSTANCE_ID": "storage-1",
+					}, idx+1, 2)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(command).To(Equal(expected))
+				}
+			})
+
+			It("should not modify the provided substitutions", func() {
+				Expect(substitutions).To(HaveLen(6))
+			})
+		})
+
+		When("a custom parameter references a missing substitution", func() {
+			BeforeEach(func() {
+				settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				settings.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{"locality_test=$MISSING"}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = settings
+			})
+
+			It("should return an error", func() {
+				_, err = RenderProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeSplit, map[string]string{"BINARY_DIR": "/usr/bin"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ValidateSubstitutions", func() {
+		var pod *corev1.Pod
+
+		BeforeEach(func() {
+			settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+			settings.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{"knob_foo=$FDB_FOO"}
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = settings
+		})
+
+		When("the variable is not defined in the container", func() {
+			BeforeEach(func() {
+				pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should return an error", func() {
+				Expect(ValidateSubstitutions(cluster, fdbv1beta2.ProcessClassStorage, pod, 1)).To(MatchError("custom parameter \"knob_foo=$FDB_FOO\" for process class storage and process number 1 references the environment variable FDB_FOO, which is not present in the substitutions"))
+			})
+		})
+
+		When("the variable is defined in the main container of the unified image", func() {
+			BeforeEach(func() {
+				cluster.Spec.UseUnifiedImage = pointer.Bool(true)
+				pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+				pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{Name: "FDB_FOO", Value: "bar"})
+			})
+
+			It("should not return an error", func() {
+				Expect(ValidateSubstitutions(cluster, fdbv1beta2.ProcessClassStorage, pod, 1)).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the container reads the variables from a ConfigMap", func() {
+			BeforeEach(func() {
+				pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+				pod.Spec.Containers[1].EnvFrom = []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{}}}
+			})
+
+			It("should not return an error", func() {
+				Expect(ValidateSubstitutions(cluster, fdbv1beta2.ProcessClassStorage, pod, 1)).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	DescribeTable("validating the custom parameters against the generated arguments",
+		func(processSettings fdbv1beta2.ProcessSettings, dataHall string, expectedErr error) {
+			cluster.Spec.DataHall = dataHall
+			cluster.Spec.TraceFormat = fdbv1beta2.TraceFormatJSON
+			cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+				fdbv1beta2.ProcessClassGeneral: processSettings,
+			}
+
+			err := validateCustomParametersAgainstGeneratedArguments(cluster)
+			if expectedErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(Equal(expectedErr))
+			}
+		},
+		Entry("no conflicting parameters",
+			fdbv1beta2.ProcessSettings{CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"knob_disable_posix_kernel_aio = 1"}},
+			"",
+			nil,
+		),
+		Entry("parameters that conflict with generated arguments",
+			fdbv1beta2.ProcessSettings{CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"public_address = 127.0.0.1:4501", "locality_zoneid = test"}},
+			"",
+			fmt.Errorf("found customParameters that conflict with the arguments generated by the operator:\nprocess class general: locality_zoneid, public_address"),
+		),
+		Entry("the data hall locality without a data hall",
+			fdbv1beta2.ProcessSettings{CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"locality_data_hall = az1"}},
+			"",
+			nil,
+		),
+		Entry("the data hall locality with a data hall",
+			fdbv1beta2.ProcessSettings{CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"locality_data_hall = az1"}},
+			"az2",
+			fmt.Errorf("found customParameters that conflict with the arguments generated by the operator:\nprocess class general: locality_data_hall"),
+		),
+		Entry("the trace format as custom parameter",
+			fdbv1beta2.ProcessSettings{CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"trace_format = xml"}},
+			"",
+			nil,
+		),
+		Entry("a process specific parameter that conflicts with generated arguments",
+			fdbv1beta2.ProcessSettings{CustomParametersPerProcess: []fdbv1beta2.ProcessCustomParameters{
+				{
+					ProcessNumber:    1,
+					CustomParameters: fdbv1beta2.FoundationDBCustomParameters{"class = log"},
+				},
+			}},
+			"",
+			fmt.Errorf("found customParameters that conflict with the arguments generated by the operator:\nprocess class general: class"),
+		),
+	)
+
+	DescribeTable("checking if a version supports an argument",
+		func(version fdbv1beta2.Version, argument string, expected bool) {
+			Expect(versionSupportsArgument(version, argument)).To(Equal(expected))
+		},
+		Entry("an argument without a minimum version",
+			fdbv1beta2.Versions.MinimumVersion,
+			"class",
+			true,
+		),
+		Entry("the DNS locality with the minimum version",
+			fdbv1beta2.Versions.MinimumVersion,
+			"locality_dns_name",
+			false,
+		),
+		Entry("the DNS locality with the default version",
+			fdbv1beta2.Versions.Default,
+			"locality_dns_name",
+			false,
+		),
+		Entry("the DNS locality with the version that supports DNS in the cluster file",
+			fdbv1beta2.Versions.SupportsDNSInClusterFile,
+			"locality_dns_name",
+			true,
+		),
+		Entry("the DNS locality with a newer version",
+			fdbv1beta2.Versions.SupportsDualStackAddresses,
+			"locality_dns_name",
+			true,
+		),
+		Entry("the tracer with the default version",
+			fdbv1beta2.Versions.Default,
+			"tracer",
+			false,
+		),
+		Entry("the tracer with the version that supports tracing",
+			fdbv1beta2.Versions.SupportsTracing,
+			"tracer",
+			true,
+		),
+		Entry("the tracing listener address with the previous patch version",
+			fdbv1beta2.Versions.PreviousPatchVersion,
+			"knob_tracing_udp_listener_addr",
+			false,
+		),
+		Entry("the tracing listener address with the version that supports tracing",
+			fdbv1beta2.Versions.SupportsTracing,
+			"knob_tracing_udp_listener_addr",
+			true,
+		),
+	)
+
+	DescribeTable("generating the DNS locality",
+		func(version fdbv1beta2.Version, expected bool) {
+			cluster.Spec.Version = version.String()
+			cluster.Spec.Routing.DefineDNSLocalityFields = pointer.Bool(true)
+
+			config := GetMonitorProcessConfiguration(cluster, fdbv1beta2.ProcessClassStorage, 1, FDBImageTypeUnified)
+			dnsArgument := monitorapi.Argument{ArgumentType: monitorapi.ConcatenateArgumentType, Values: []monitorapi.Argument{
+				{Value: "--locality_dns_name="},
+				{ArgumentType: monitorapi.EnvironmentArgumentType, Source: "FDB_DNS_NAME"},
+			}}
+
+			if expected {
+				Expect(config.Arguments).To(ContainElement(dnsArgument))
+			} else {
+				Expect(config.Arguments).NotTo(ContainElement(dnsArgument))
+			}
+		},
+		Entry("with the minimum version", fdbv1beta2.Versions.MinimumVersion, false),
+		Entry("with the default version", fdbv1beta2.Versions.Default, false),
+		Entry("with the next patch version", fdbv1beta2.Versions.NextPatchVersion, false),
+		Entry("with the version that supports DNS in the cluster file", fdbv1beta2.Versions.SupportsDNSInClusterFile, true),
+		Entry("with the version that supports the recovery state", fdbv1beta2.Versions.SupportsRecoveryState, true),
+		Entry("with the version that supports dual stack addresses", fdbv1beta2.Versions.SupportsDualStackAddresses, true),
+	)
 })
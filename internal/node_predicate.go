@@ -21,9 +21,14 @@
 package internal
 
 import (
+	"math"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -34,6 +39,16 @@ var _ predicate.Predicate = (*NodeTaintChangedPredicate)(nil)
 // a reconciliation will be triggered.
 type NodeTaintChangedPredicate struct {
 	Logger logr.Logger
+	// TaintReplacementOptions defines the taint keys and the minimum duration a taint must be present before the taint
+	// triggers a reconciliation. The wildcard key "*" matches all taint keys without an exact match. If no options
+	// are defined, all taint changes will trigger a reconciliation.
+	TaintReplacementOptions []fdbv1beta2.TaintReplacementOption
+	// GetClusterTaintReplacementOptions if set returns the taint replacement options defined in the
+	// FoundationDBClusters. Those options override the TaintReplacementOptions with the same key and are only used if
+	// TaintReplacementOptions is not empty.
+	GetClusterTaintReplacementOptions func() []fdbv1beta2.TaintReplacementOption
+	// TimeAddedTolerance defines the tolerance in which a change of only the TimeAdded of a taint will be ignored.
+	TimeAddedTolerance time.Duration
 }
 
 // Create implements Predicate.
@@ -46,8 +61,8 @@ func (n NodeTaintChangedPredicate) Delete(_ event.DeleteEvent) bool {
 	return false
 }
 
-// Update returns true if the Update event should be processed. This is the case if the taints for the provided
-// node has been changed.
+// Update returns true if the Update event should be processed. This is the case if the relevant taints for the provided
+// node have been changed.
 func (n NodeTaintChangedPredicate) Update(event event.UpdateEvent) bool {
 	if event.ObjectOld == nil || event.ObjectNew == nil {
 		return false
@@ -63,7 +78,11 @@ func (n NodeTaintChangedPredicate) Update(event event.UpdateEvent) bool {
 		return false
 	}
 
-	taintsChanged := !equality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
+	taintDurations := n.getTaintDurations()
+	oldTaints := getRelevantTaints(oldNode.Spec.Taints, taintDurations, getNodeObservedTime(oldNode))
+	newTaints := getRelevantTaints(newNode.Spec.Taints, taintDurations, time.Now())
+
+	taintsChanged := n.taintsChanged(oldTaints, newTaints)
 	n.Logger.V(1).Info("Got an UpdateEvent", "node", oldNode.Name, "taintsChanged", taintsChanged, "oldTaints", oldNode.Spec.Taints, "newTaints", newNode.Spec.Taints)
 
 	return taintsChanged
@@ -73,3 +92,136 @@ func (n NodeTaintChangedPredicate) Update(event event.UpdateEvent) bool {
 func (n NodeTaintChangedPredicate) Generic(_ event.GenericEvent) bool {
 	return false
 }
+
+// getTaintDurations returns the minimum taint duration for each configured taint key. The taint replacement options
+// of the clusters override the configured options, if multiple clusters define the same key the smallest duration
+// will be used. A nil map means that all taints are relevant.
+func (n NodeTaintChangedPredicate) getTaintDurations() map[string]time.Duration {
+	if len(n.TaintReplacementOptions) == 0 {
+		return nil
+	}
+
+	taintDurations := getTaintDurationsFromOptions(n.TaintReplacementOptions)
+	if n.GetClusterTaintReplacementOptions != nil {
+		for key, duration := range getTaintDurationsFromOptions(n.GetClusterTaintReplacementOptions()) {
+			taintDurations[key] = duration
+		}
+	}
+
+	return taintDurations
+}
+
+// getTaintDurationsFromOptions returns the durations of the enabled taint replacement options. If multiple options
+// define the same key, the smallest duration will be used.
+func getTaintDurationsFromOptions(options []fdbv1beta2.TaintReplacementOption) map[string]time.Duration {
+	taintDurations := make(map[string]time.Duration, len(options))
+	for _, option := range options {
+		key := pointer.StringDeref(option.Key, "")
+		durationInSeconds := pointer.Int64Deref(option.DurationInSeconds, math.MinInt64)
+		// Same as for the replacements, an empty key or a negative duration disables the option.
+		if key == "" || durationInSeconds < 0 {
+			continue
+		}
+
+		// Prevent an overflow for large durations, e.g. the maximum of int64 that is used to disable a taint key.
+		duration := time.Duration(math.MaxInt64)
+		if durationInSeconds < int64(math.MaxInt64/time.Second) {
+			duration = time.Duration(durationInSeconds) * time.Second
+		}
+
+		current, ok := taintDurations[key]
+		if !ok || duration < current {
+			taintDurations[key] = duration
+		}
+	}
+
+	return taintDurations
+}
+
+// getRelevantTaints returns the taints that match a taint key in taintDurations and that have been present for at
+// least the configured duration at the provided time. An exact match of the taint key takes precedence over the
+// wildcard key. Taints without a TimeAdded are only relevant if the configured duration is 0. If taintDurations is nil
+// all taints will be returned.
+func getRelevantTaints(taints []corev1.Taint, taintDurations map[string]time.Duration, now time.Time) []corev1.Taint {
+	if taintDurations == nil {
+		return taints
+	}
+
+	relevantTaints := make([]corev1.Taint, 0, len(taints))
+	for _, taint := range taints {
+		duration, ok := taintDurations[taint.Key]
+		if !ok {
+			duration, ok = taintDurations["*"]
+		}
+
+		if !ok {
+			continue
+		}
+
+		if duration > 0 && (taint.TimeAdded == nil || now.Sub(taint.TimeAdded.Time) < duration) {
+			continue
+		}
+
+		relevantTaints = append(relevantTaints, taint)
+	}
+
+	return relevantTaints
+}
+
+// getNodeObservedTime returns the latest heartbeat time of the node conditions, which is used as the time the node
+// object was observed. If the node has no conditions, the current time will be returned.
+func getNodeObservedTime(node *corev1.Node) time.Time {
+	var observedTime time.Time
+	for _, condition := range node.Status.Conditions {
+		if condition.LastHeartbeatTime.Time.After(observedTime) {
+			observedTime = condition.LastHeartbeatTime.Time
+		}
+	}
+
+	if observedTime.IsZero() {
+		return time.Now()
+	}
+
+	return observedTime
+}
+
+// taintsChanged returns true if the provided taints differ. Taints that only differ in their TimeAdded within the
+// TimeAddedTolerance are treated as equal.
+func (n NodeTaintChangedPredicate) taintsChanged(oldTaints []corev1.Taint, newTaints []corev1.Taint) bool {
+	if len(oldTaints) != len(newTaints) {
+		return true
+	}
+
+	for _, newTaint := range newTaints {
+		found := false
+		for _, oldTaint := range oldTaints {
+			if newTaint.Key != oldTaint.Key || newTaint.Value != oldTaint.Value || newTaint.Effect != oldTaint.Effect {
+				continue
+			}
+
+			found = n.timeAddedEqual(oldTaint.TimeAdded, newTaint.TimeAdded)
+			break
+		}
+
+		if !found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// timeAddedEqual returns true if both times are unset or if the difference between both times is within the
+// TimeAddedTolerance.
+func (n NodeTaintChangedPredicate) timeAddedEqual(oldTime *metav1.Time, newTime *metav1.Time) bool {
+	if oldTime == nil || newTime == nil {
+		return oldTime == nil && newTime == nil
+	}
+
+	difference := newTime.Sub(oldTime.Time)
+	if difference < 0 {
+		difference = -difference
+	}
+
+	return difference <= n.TimeAddedTolerance
+}
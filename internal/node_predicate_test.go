@@ -0,0 +1,232 @@
+/*
+ * node_predicate_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"math"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NodeTaintChangedPredicate", func() {
+	now := time.Now()
+
+	newTaint := func(key string, timeAdded *time.Time) corev1.Taint {
+		taint := corev1.Taint{
+			Key:    key,
+			Effect: corev1.TaintEffectNoExecute,
+		}
+
+		if timeAdded != nil {
+			taint.TimeAdded = &metav1.Time{Time: *timeAdded}
+		}
+
+		return taint
+	}
+
+	newNode := func(taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node"},
+			Spec: corev1.NodeSpec{
+				Taints: taints,
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:              corev1.NodeReady,
+						LastHeartbeatTime: metav1.Time{Time: now.Add(-1 * time.Minute)},
+					},
+				},
+			},
+		}
+	}
+
+	newOption := func(key string, durationInSeconds int64) fdbv1beta2.TaintReplacementOption {
+		return fdbv1beta2.TaintReplacementOption{
+			Key:               pointer.String(key),
+			DurationInSeconds: pointer.Int64(durationInSeconds),
+		}
+	}
+
+	timeAgo := func(duration time.Duration) *time.Time {
+		timestamp := now.Add(-duration)
+		return &timestamp
+	}
+
+	DescribeTable("filtering update events", func(nodeTaintPredicate NodeTaintChangedPredicate, oldNode *corev1.Node, newNode *corev1.Node, expected bool) {
+		Expect(nodeTaintPredicate.Update(event.UpdateEvent{
+			ObjectOld: oldNode,
+			ObjectNew: newNode,
+		})).To(Equal(expected))
+	},
+		Entry("no taints changed without options",
+			NodeTaintChangedPredicate{},
+			newNode(newTaint("test", timeAgo(time.Minute))),
+			newNode(newTaint("test", timeAgo(time.Minute))),
+			false,
+		),
+		Entry("a taint was added without options",
+			NodeTaintChangedPredicate{},
+			newNode(),
+			newNode(newTaint("test", nil)),
+			true,
+		),
+		Entry("a taint was added that matches an exact key without a duration",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("test", 0)},
+			},
+			newNode(),
+			newNode(newTaint("test", nil)),
+			true,
+		),
+		Entry("a taint was added that matches no key",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("test", 0)},
+			},
+			newNode(),
+			newNode(newTaint("other", nil)),
+			false,
+		),
+		Entry("a taint was added that matches the wildcard key",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 0)},
+			},
+			newNode(),
+			newNode(newTaint("other", nil)),
+			true,
+		),
+		Entry("a taint was added that has not reached the minimum duration",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 300)},
+			},
+			newNode(),
+			newNode(newTaint("node.kubernetes.io/unreachable", timeAgo(10*time.Second))),
+			false,
+		),
+		Entry("a taint was removed before it reached the minimum duration",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 300)},
+			},
+			newNode(newTaint("node.kubernetes.io/unreachable", timeAgo(30*time.Second))),
+			newNode(),
+			false,
+		),
+		Entry("a taint reached the minimum duration since the node was observed",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 300)},
+			},
+			newNode(newTaint("node.kubernetes.io/unreachable", timeAgo(330*time.Second))),
+			newNode(newTaint("node.kubernetes.io/unreachable", timeAgo(330*time.Second))),
+			true,
+		),
+		Entry("a taint had reached the minimum duration before the node was observed",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 300)},
+			},
+			newNode(newTaint("node.kubernetes.io/unreachable", timeAgo(time.Hour))),
+			newNode(newTaint("node.kubernetes.io/unreachable", timeAgo(time.Hour))),
+			false,
+		),
+		Entry("a taint was removed after it reached the minimum duration",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 300)},
+			},
+			newNode(newTaint("node.kubernetes.io/unreachable", timeAgo(time.Hour))),
+			newNode(),
+			true,
+		),
+		Entry("a taint without time added was added with a minimum duration",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 300)},
+			},
+			newNode(),
+			newNode(newTaint("test", nil)),
+			false,
+		),
+		Entry("the exact key takes precedence over the wildcard key",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 0), newOption("test", 300)},
+			},
+			newNode(),
+			newNode(newTaint("test", timeAgo(10*time.Second))),
+			false,
+		),
+		Entry("the exact key is disabled with the maximum duration",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("*", 0), newOption("test", math.MaxInt64)},
+			},
+			newNode(),
+			newNode(newTaint("test", timeAgo(time.Hour))),
+			false,
+		),
+		Entry("the cluster options override the options with the same key",
+			NodeTaintChangedPredicate{
+				TaintReplacementOptions: []fdbv1beta2.TaintReplacementOption{newOption("test", 300)},
+				GetClusterTaintReplacementOptions: func() []fdbv1beta2.TaintReplacementOption {
+					return []fdbv1beta2.TaintReplacementOption{newOption("test", 600), newOption("test", 0)}
+				},
+			},
+			newNode(),
+			newNode(newTaint("test", timeAgo(10*time.Second))),
+			true,
+		),
+		Entry("the cluster options are ignored without options",
+			NodeTaintChangedPredicate{
+				GetClusterTaintReplacementOptions: func() []fdbv1beta2.TaintReplacementOption {
+					return []fdbv1beta2.TaintReplacementOption{newOption("test", 300)}
+				},
+			},
+			newNode(),
+			newNode(newTaint("test", timeAgo(10*time.Second))),
+			true,
+		),
+		Entry("only the time added changed within the tolerance",
+			NodeTaintChangedPredicate{
+				TimeAddedTolerance: time.Minute,
+			},
+			newNode(newTaint("test", timeAgo(time.Hour))),
+			newNode(newTaint("test", timeAgo(time.Hour-30*time.Second))),
+			false,
+		),
+		Entry("only the time added changed outside of the tolerance",
+			NodeTaintChangedPredicate{
+				TimeAddedTolerance: time.Minute,
+			},
+			newNode(newTaint("test", timeAgo(time.Hour))),
+			newNode(newTaint("test", timeAgo(time.Minute))),
+			true,
+		),
+		Entry("the time added changed without a tolerance",
+			NodeTaintChangedPredicate{},
+			newNode(newTaint("test", timeAgo(time.Hour))),
+			newNode(newTaint("test", timeAgo(time.Hour-30*time.Second))),
+			true,
+		),
+	)
+})
@@ -0,0 +1,49 @@
+/*
+ * pdb_helper.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GetPodDisruptionBudget builds a single PodDisruptionBudget that selects all Pods of the cluster. The maxUnavailable of
+// the PodDisruptionBudget is set to the desired fault tolerance of the cluster. A PodDisruptionBudget per process class
+// would allow the desired fault tolerance to be exceeded, as Pods of different process classes could be evicted at the
+// same time.
+func GetPodDisruptionBudget(cluster *fdbv1beta2.FoundationDBCluster) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt(cluster.DesiredFaultTolerance())
+	budget := &policyv1.PodDisruptionBudget{
+		ObjectMeta: GetObjectMetadata(cluster, nil, "", ""),
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: GetPodMatchLabels(cluster, "", ""),
+			},
+		},
+	}
+	budget.ObjectMeta.Name = cluster.Name
+	budget.ObjectMeta.OwnerReferences = BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+
+	return budget
+}
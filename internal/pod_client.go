@@ -449,19 +449,21 @@ func GetSubstitutionsFromClusterAndPod(logger logr.Logger, cluster *fdbv1beta2.F
 		}
 	}
 
-	ipString := GetPublicIPsForPod(pod, logger)[0]
-	substitutions[fdbv1beta2.EnvNamePublicIP] = ipString
-	if ipString != "" {
-		ip := net.ParseIP(ipString)
-		if ip == nil {
-			return nil, fmt.Errorf("failed to parse IP from pod: %s", ipString)
-		}
+	podIP, err := formatIPForSubstitution(GetPublicIPsForPod(pod, logger)[0])
+	if err != nil {
+		return nil, err
+	}
+	substitutions["FDB_POD_IP"] = podIP
+	substitutions[fdbv1beta2.EnvNamePublicIP] = podIP
 
-		if ip.To4() == nil {
-			substitutions[fdbv1beta2.EnvNamePublicIP] = fmt.Sprintf("[%s]", ipString)
+	// If the public IP comes from the service, the public IP is taken from the annotation, the Pod IP is still
+	// available for the listen address and custom parameters.
+	if cluster.GetPublicIPSource() == fdbv1beta2.PublicIPSourceService && pod.Annotations[fdbv1beta2.PublicIPAnnotation] != "" {
+		substitutions[fdbv1beta2.EnvNamePublicIP], err = formatIPForSubstitution(pod.Annotations[fdbv1beta2.PublicIPAnnotation])
+		if err != nil {
+			return nil, err
 		}
 	}
-	substitutions["FDB_POD_IP"] = substitutions[fdbv1beta2.EnvNamePublicIP]
 
 	if cluster.Spec.FaultDomain.Key == fdbv1beta2.NoneFaultDomainKey {
 		substitutions["FDB_MACHINE_ID"] = pod.Name
@@ -483,8 +485,24 @@ func GetSubstitutionsFromClusterAndPod(logger logr.Logger, cluster *fdbv1beta2.F
 		}
 	}
 
+	machineIDVariable := cluster.GetMachineIDVariable()
+	if cluster.UseMachineIDFromNodeLabel() {
+		substitutions[fdbv1beta2.EnvNameMachineID] = pod.Annotations[fdbv1beta2.MachineIDAnnotation]
+	} else if machineIDVariable != fdbv1beta2.EnvNameMachineID {
+		delete(substitutions, fdbv1beta2.EnvNameMachineID)
+		machineID, found := getEnvValueFromPod(pod, machineIDVariable)
+		if !found {
+			return nil, fmt.Errorf("could not find a value for the machine ID environment variable %s in the Pod", machineIDVariable)
+		}
+		substitutions[machineIDVariable] = machineID
+	}
+
 	substitutions["FDB_INSTANCE_ID"] = string(GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta))
 
+	if cluster.UseRackLocality() {
+		substitutions[fdbv1beta2.EnvNameRackID] = pod.Annotations[fdbv1beta2.RackAnnotation]
+	}
+
 	if cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
 		substitutions["BINARY_DIR"] = fmt.Sprintf("/var/dynamic-conf/bin/%s", cluster.Spec.Version)
 	} else {
@@ -506,3 +524,35 @@ func GetSubstitutionsFromClusterAndPod(logger logr.Logger, cluster *fdbv1beta2.F
 
 	return substitutions, nil
 }
+
+// getEnvValueFromPod returns the value of the provided environment variable from the containers of the Pod. Only
+// environment variables with a static value are supported.
+func getEnvValueFromPod(pod *corev1.Pod, name string) (string, bool) {
+	for _, container := range pod.Spec.Containers {
+		for _, envVar := range container.Env {
+			if envVar.Name == name && envVar.ValueFrom == nil {
+				return envVar.Value, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// formatIPForSubstitution validates the provided IP address and wraps IPv6 addresses in brackets.
+func formatIPForSubstitution(ipString string) (string, error) {
+	if ipString == "" {
+		return ipString, nil
+	}
+
+	ip := net.ParseIP(ipString)
+	if ip == nil {
+		return "", fmt.Errorf("failed to parse IP from pod: %s", ipString)
+	}
+
+	if ip.To4() == nil {
+		return fmt.Sprintf("[%s]", ipString), nil
+	}
+
+	return ipString, nil
+}
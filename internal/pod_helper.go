@@ -236,3 +236,17 @@ func GetPublicIPSource(pod *corev1.Pod) (fdbv1beta2.PublicIPSource, error) {
 	}
 	return fdbv1beta2.PublicIPSource(source), nil
 }
+
+// GetProcessPortBase determines the process port base that was used when creating the Pod.
+func GetProcessPortBase(pod *corev1.Pod) (int, error) {
+	if pod == nil {
+		return 0, fmt.Errorf("failed to fetch process port base from nil Pod")
+	}
+
+	portBase, ok := pod.ObjectMeta.Annotations[fdbv1beta2.ProcessPortBaseAnnotation]
+	if !ok {
+		return fdbv1beta2.DefaultProcessPortBase, nil
+	}
+
+	return strconv.Atoi(portBase)
+}
@@ -22,10 +22,12 @@ package internal
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	monitorapi "github.com/apple/foundationdb/fdbkubernetesmonitor/api"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -45,7 +47,7 @@ func GetProcessGroupIDFromPodName(cluster *fdbv1beta2.FoundationDBCluster, podNa
 	return fdbv1beta2.ProcessGroupID(tmpName)
 }
 
-func generateServicePorts(processesPerPod int) []corev1.ServicePort {
+func generateServicePorts(cluster *fdbv1beta2.FoundationDBCluster, processesPerPod int) []corev1.ServicePort {
 	ports := make([]corev1.ServicePort, 0, processesPerPod*2)
 
 	for i := 1; i <= processesPerPod; i++ {
@@ -61,10 +63,10 @@ func generateServicePorts(processesPerPod int) []corev1.ServicePort {
 
 		ports = append(ports, corev1.ServicePort{
 			Name: tlsPortName,
-			Port: int32(fdbv1beta2.GetProcessPort(i, true)),
+			Port: int32(cluster.GetProcessPort(i, true)),
 		}, corev1.ServicePort{
 			Name: nonTlSPortName,
-			Port: int32(fdbv1beta2.GetProcessPort(i, false)),
+			Port: int32(cluster.GetProcessPort(i, false)),
 		})
 	}
 
@@ -91,7 +93,7 @@ func GetService(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 		ObjectMeta: metadata,
 		Spec: corev1.ServiceSpec{
 			Type:                     corev1.ServiceTypeClusterIP,
-			Ports:                    generateServicePorts(processesPerPod),
+			Ports:                    generateServicePorts(cluster, processesPerPod),
 			PublishNotReadyAddresses: true,
 			Selector:                 GetPodMatchLabels(cluster, "", string(processGroup.ProcessGroupID)),
 			IPFamilies:               ipFamilies,
@@ -180,9 +182,10 @@ func getContainers(podSpec *corev1.PodSpec) (*corev1.Container, *corev1.Containe
 }
 
 func configureContainersForUnifiedImages(cluster *fdbv1beta2.FoundationDBCluster, mainContainer *corev1.Container, sidecarContainer *corev1.Container, processGroup *fdbv1beta2.ProcessGroupStatus, desiredVersion string) error {
+	traceLogDir := getTraceLogDir(cluster, processGroup.ProcessClass)
 	mainContainer.Args = []string{
 		"--input-dir", "/var/dynamic-conf",
-		"--log-path", "/var/log/fdb-trace-logs/monitor.log",
+		"--log-path", path.Join(traceLogDir, "monitor.log"),
 	}
 
 	serversPerPod := cluster.GetDesiredServersPerPod(processGroup.ProcessClass)
@@ -196,7 +199,7 @@ func configureContainersForUnifiedImages(cluster *fdbv1beta2.FoundationDBCluster
 		corev1.VolumeMount{Name: "data", MountPath: "/var/fdb/data"},
 		corev1.VolumeMount{Name: "config-map", MountPath: "/var/dynamic-conf"},
 		corev1.VolumeMount{Name: "shared-binaries", MountPath: "/var/fdb/shared-binaries"},
-		corev1.VolumeMount{Name: "fdb-trace-logs", MountPath: "/var/log/fdb-trace-logs"},
+		corev1.VolumeMount{Name: "fdb-trace-logs", MountPath: traceLogDir},
 	)
 
 	mainContainer.Env = append(mainContainer.Env, getEnvForMonitorConfigSubstitution(cluster, processGroup.ProcessGroupID)...)
@@ -294,7 +297,7 @@ func setAffinityForFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, podSpec
 	}
 }
 
-func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, volumeClaimTemplate *corev1.PersistentVolumeClaim, podName string, processClass fdbv1beta2.ProcessClass) {
+func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, volumeClaimTemplate *corev1.PersistentVolumeClaim, logVolumeClaimTemplate *corev1.PersistentVolumeClaim, podName string, processClass fdbv1beta2.ProcessClass) {
 	useUnifiedImages := pointer.BoolDeref(cluster.Spec.UseUnifiedImage, false)
 	monitorConfKey := GetConfigMapMonitorConfEntry(processClass, GetDesiredImageType(cluster), cluster.GetDesiredServersPerPod(processClass))
 
@@ -307,7 +310,10 @@ func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podS
 
 	configMapItems := []corev1.KeyToPath{
 		{Key: monitorConfKey, Path: monitorConfFile},
-		{Key: ClusterFileKey, Path: "fdb.cluster"},
+	}
+
+	if cluster.UseSeedClusterFile() {
+		configMapItems = append(configMapItems, corev1.KeyToPath{Key: ClusterFileKey, Path: "fdb.cluster"})
 	}
 
 	if len(cluster.Spec.TrustedCAs) > 0 {
@@ -336,6 +342,15 @@ func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podS
 		mainVolumeSource.EmptyDir = &corev1.EmptyDirVolumeSource{}
 	}
 
+	var logVolumeSource corev1.VolumeSource
+	if logVolumeClaimTemplate != nil {
+		logVolumeSource.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: getLogPvcName(podName, logVolumeClaimTemplate),
+		}
+	} else {
+		logVolumeSource.EmptyDir = &corev1.EmptyDirVolumeSource{}
+	}
+
 	volumes := []corev1.Volume{
 		{Name: "data", VolumeSource: mainVolumeSource},
 	}
@@ -350,7 +365,7 @@ func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podS
 			LocalObjectReference: corev1.LocalObjectReference{Name: configMapRefName},
 			Items:                configMapItems,
 		}}},
-		corev1.Volume{Name: "fdb-trace-logs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		corev1.Volume{Name: "fdb-trace-logs", VolumeSource: logVolumeSource},
 	)
 
 	podSpec.Volumes = append(podSpec.Volumes, volumes...)
@@ -419,10 +434,7 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 		extendEnv(mainContainer, corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: "/var/dynamic-conf/ca.pem"})
 	}
 
-	logGroup := cluster.Spec.LogGroup
-	if logGroup == "" {
-		logGroup = cluster.Name
-	}
+	logGroup := cluster.GetLogGroupForProcessClass(processGroup.ProcessClass)
 
 	podName := processGroup.GetPodName(cluster)
 	if useUnifiedImage {
@@ -433,10 +445,11 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 	} else {
 		mainContainer.Command = []string{"sh", "-c"}
 
+		traceLogDir := getTraceLogDir(cluster, processGroup.ProcessClass)
 		args := "fdbmonitor --conffile /var/dynamic-conf/fdbmonitor.conf" +
 			" --lockfile /var/dynamic-conf/fdbmonitor.lockfile" +
 			" --loggroup " + logGroup +
-			" >> /var/log/fdb-trace-logs/fdbmonitor-$(date '+%Y-%m-%d').log 2>&1"
+			" >> " + traceLogDir + "/fdbmonitor-$(date '+%Y-%m-%d').log 2>&1"
 
 		for _, crashObjs := range cluster.Spec.Buggify.CrashLoopContainers {
 			for _, pid := range crashObjs.Targets {
@@ -451,7 +464,7 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 		mainContainer.VolumeMounts = append(mainContainer.VolumeMounts,
 			corev1.VolumeMount{Name: "data", MountPath: "/var/fdb/data"},
 			corev1.VolumeMount{Name: "dynamic-conf", MountPath: "/var/dynamic-conf"},
-			corev1.VolumeMount{Name: "fdb-trace-logs", MountPath: "/var/log/fdb-trace-logs"},
+			corev1.VolumeMount{Name: "fdb-trace-logs", MountPath: traceLogDir},
 		)
 
 		err = configureSidecarContainerForCluster(cluster, podName, initContainer, true, processGroup.ProcessGroupID, desiredVersion)
@@ -473,7 +486,7 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 	ensureSecurityContextIsPresent(mainContainer)
 	ensureSecurityContextIsPresent(sidecarContainer)
 	setAffinityForFaultDomain(cluster, podSpec, processGroup.ProcessClass)
-	configureVolumesForContainers(cluster, podSpec, processSettings.VolumeClaimTemplate, podName, processGroup.ProcessClass)
+	configureVolumesForContainers(cluster, podSpec, processSettings.VolumeClaimTemplate, processSettings.LogVolumeClaimTemplate, podName, processGroup.ProcessClass)
 	configureNoSchedule(podSpec, processGroup.ProcessGroupID, cluster.Spec.Buggify.NoSchedule)
 
 	if !useUnifiedImage {
@@ -481,6 +494,20 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 	}
 	replaceContainers(podSpec.Containers, mainContainer, sidecarContainer)
 
+	if cluster.UseConnectivityCheck() {
+		configureConnectivityCheckContainer(cluster, podSpec, mainContainer.Image, processGroup.GetPodName(cluster))
+	}
+
+	nodeLabelAnnotations := getNodeLabelAnnotations(cluster)
+	if len(nodeLabelAnnotations) > 0 {
+		configureNodeLabelsContainer(podSpec, mainContainer.Image, nodeLabelAnnotations)
+	}
+
+	err = applyProbeOverrides(podSpec, processSettings.ProbeOverrides)
+	if err != nil {
+		return nil, err
+	}
+
 	headlessService := GetHeadlessService(cluster)
 
 	if headlessService != nil {
@@ -491,6 +518,182 @@ func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta
 	return podSpec, nil
 }
 
+// applyProbeOverrides sets the probes defined in the probe overrides on the matching containers. If a container is
+// missing an error will be returned.
+func applyProbeOverrides(podSpec *corev1.PodSpec, overrides []fdbv1beta2.ContainerProbeOverride) error {
+	for _, override := range overrides {
+		var container *corev1.Container
+		for index := range podSpec.Containers {
+			if podSpec.Containers[index].Name == override.ContainerName {
+				container = &podSpec.Containers[index]
+				break
+			}
+		}
+
+		if container == nil {
+			return fmt.Errorf("could not find container %s for probe override", override.ContainerName)
+		}
+
+		if override.LivenessProbe != nil {
+			container.LivenessProbe = override.LivenessProbe.DeepCopy()
+		}
+
+		if override.ReadinessProbe != nil {
+			container.ReadinessProbe = override.ReadinessProbe.DeepCopy()
+		}
+
+		if override.StartupProbe != nil {
+			container.StartupProbe = override.StartupProbe.DeepCopy()
+		}
+	}
+
+	return nil
+}
+
+// connectivityCheckScript waits until at least one coordinator from the cluster file is reachable or until the timeout
+// is exceeded. The check is skipped if the cluster file contains no coordinators, e.g. for a new cluster, or if the Pod
+// is a coordinator itself, otherwise the coordinators of a new or restarted cluster would wait for each other.
+const connectivityCheckScript = `coordinators=$(cut -s -d@ -f2 /var/input-files/fdb.cluster 2>/dev/null | tr ',' ' ')
+if [ -z "${coordinators}" ]; then
+  echo "no coordinators found in the cluster file, skipping the connectivity check"
+  exit 0
+fi
+for coordinator in ${coordinators}; do
+  address=${coordinator%:tls}
+  host=${address%:*}
+  host=${host#[}
+  host=${host%]}
+  if [ "${host}" = "${FDB_POD_IP}" ] || [ "${host}" = "${FDB_DNS_NAME}" ]; then
+    echo "this Pod is the coordinator ${address}, skipping the connectivity check"
+    exit 0
+  fi
+done
+deadline=$(( $(date +%s) + ${FDB_CONNECTIVITY_CHECK_TIMEOUT} ))
+while true; do
+  for coordinator in ${coordinators}; do
+    address=${coordinator%:tls}
+    host=${address%:*}
+    host=${host#[}
+    host=${host%]}
+    port=${address##*:}
+    if timeout 5 bash -c "echo > /dev/tcp/${host}/${port}" 2>/dev/null; then
+      echo "coordinator ${address} is reachable"
+      exit 0
+    fi
+  done
+  if [ "$(date +%s)" -ge "${deadline}" ]; then
+    echo "no coordinator was reachable after ${FDB_CONNECTIVITY_CHECK_TIMEOUT} seconds"
+    exit 0
+  fi
+  sleep 5
+done`
+
+// configureConnectivityCheckContainer adds the init container that waits until a coordinator is reachable. If the Pod
+// template already contains an init container with the same name, only the missing fields will be set.
+func configureConnectivityCheckContainer(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, image string, podName string) {
+	var container *corev1.Container
+	for index, initContainer := range podSpec.InitContainers {
+		if initContainer.Name == fdbv1beta2.ConnectivityCheckContainerName {
+			container = &podSpec.InitContainers[index]
+			break
+		}
+	}
+
+	if container == nil {
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{Name: fdbv1beta2.ConnectivityCheckContainerName})
+		container = &podSpec.InitContainers[len(podSpec.InitContainers)-1]
+	}
+
+	if container.Image == "" {
+		container.Image = image
+	}
+
+	if len(container.Command) == 0 {
+		container.Command = []string{"bash", "-c"}
+		container.Args = []string{connectivityCheckScript}
+	}
+
+	extendEnv(container,
+		corev1.EnvVar{Name: "FDB_CONNECTIVITY_CHECK_TIMEOUT", Value: strconv.Itoa(cluster.GetConnectivityCheckTimeoutSeconds())},
+		corev1.EnvVar{Name: "FDB_POD_IP", ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+		}},
+		corev1.EnvVar{Name: "FDB_DNS_NAME", Value: GetPodDNSName(cluster, podName)},
+	)
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "config-map", MountPath: "/var/input-files", ReadOnly: true})
+	ensureSecurityContextIsPresent(container)
+}
+
+// nodeLabelsScript waits until all annotations in FDB_NODE_LABEL_ANNOTATIONS are present in the Pod annotations. The
+// annotations are read from a downward API volume, as the kubelet refreshes the volume content, while environment
+// variables are only resolved once when the container is started.
+const nodeLabelsScript = `for annotation in ${FDB_NODE_LABEL_ANNOTATIONS}; do
+  until grep -q "^${annotation}=" /var/pod-annotations/annotations 2>/dev/null; do
+    echo "waiting for the annotation ${annotation}"
+    sleep 5
+  done
+done`
+
+// getNodeLabelAnnotations returns the annotations that the operator sets from the node labels and that must be present
+// before the FoundationDB containers are started.
+func getNodeLabelAnnotations(cluster *fdbv1beta2.FoundationDBCluster) []string {
+	var annotations []string
+	if cluster.UseRackLocality() {
+		annotations = append(annotations, fdbv1beta2.RackAnnotation)
+	}
+
+	if cluster.UseMachineIDFromNodeLabel() {
+		annotations = append(annotations, fdbv1beta2.MachineIDAnnotation)
+	}
+
+	return annotations
+}
+
+// configureNodeLabelsContainer adds the init container that waits until the operator has copied the node labels into
+// the Pod annotations. The container is added as the first init container, so all other containers will resolve the
+// annotations in their environment variables when they are started.
+func configureNodeLabelsContainer(podSpec *corev1.PodSpec, image string, annotations []string) {
+	var container *corev1.Container
+	for index, initContainer := range podSpec.InitContainers {
+		if initContainer.Name == fdbv1beta2.NodeLabelsContainerName {
+			container = &podSpec.InitContainers[index]
+			break
+		}
+	}
+
+	if container == nil {
+		podSpec.InitContainers = append([]corev1.Container{{Name: fdbv1beta2.NodeLabelsContainerName}}, podSpec.InitContainers...)
+		container = &podSpec.InitContainers[0]
+	}
+
+	if container.Image == "" {
+		container.Image = image
+	}
+
+	if len(container.Command) == 0 {
+		container.Command = []string{"bash", "-c"}
+		container.Args = []string{nodeLabelsScript}
+	}
+
+	extendEnv(container, corev1.EnvVar{Name: "FDB_NODE_LABEL_ANNOTATIONS", Value: strings.Join(annotations, " ")})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "pod-annotations", MountPath: "/var/pod-annotations", ReadOnly: true})
+	ensureSecurityContextIsPresent(container)
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: "pod-annotations",
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{
+					{
+						Path:     "annotations",
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations"},
+					},
+				},
+			},
+		},
+	})
+}
+
 // configureSidecarContainerForCluster sets up a sidecar container for a sidecar
 // in the FDB cluster.
 func configureSidecarContainerForCluster(cluster *fdbv1beta2.FoundationDBCluster, podName string, container *corev1.Container, initMode bool, processGroupID fdbv1beta2.ProcessGroupID, fdbVersion string) error {
@@ -500,12 +703,39 @@ func configureSidecarContainerForCluster(cluster *fdbv1beta2.FoundationDBCluster
 // configureSidecarContainerForBackup sets up a sidecar container for the init
 // container for a backup process.
 func configureSidecarContainerForBackup(backup *fdbv1beta2.FoundationDBBackup, container *corev1.Container) error {
-	imageConfigs := backup.Spec.SidecarContainer.ImageConfigs
 	if backup.UseUnifiedImage() {
-		imageConfigs = backup.Spec.MainContainer.ImageConfigs
+		return configureInitContainerForUnifiedBackup(backup, container)
 	}
 
-	return configureSidecarContainer(container, true, "", "", backup.Spec.Version, nil, imageConfigs, pointer.BoolDeref(backup.Spec.AllowTagOverride, false))
+	return configureSidecarContainer(container, true, "", "", backup.Spec.Version, nil, backup.Spec.SidecarContainer.ImageConfigs, pointer.BoolDeref(backup.Spec.AllowTagOverride, false))
+}
+
+// configureInitContainerForUnifiedBackup sets up the init container for the backup agents with the unified image. The
+// init container copies the cluster file into the dynamic conf volume, so the backup agents are able to update the
+// cluster file if the coordinators change.
+func configureInitContainerForUnifiedBackup(backup *fdbv1beta2.FoundationDBBackup, container *corev1.Container) error {
+	image, err := GetImage(container.Image, backup.Spec.MainContainer.ImageConfigs, backup.Spec.Version, pointer.BoolDeref(backup.Spec.AllowTagOverride, false))
+	if err != nil {
+		return err
+	}
+
+	container.Image = image
+	container.Args = []string{
+		"--mode", "init",
+		"--input-dir", "/var/input-files",
+		"--output-dir", "/var/output-files",
+		"--copy-file", "fdb.cluster",
+		"--require-not-empty", "fdb.cluster",
+	}
+
+	container.VolumeMounts = append(container.VolumeMounts,
+		corev1.VolumeMount{Name: "config-map", MountPath: "/var/input-files"},
+		corev1.VolumeMount{Name: "dynamic-conf", MountPath: "/var/output-files"},
+	)
+
+	ensureSecurityContextIsPresent(container)
+
+	return nil
 }
 
 // configureSidecarContainer sets up a foundationdb-kubernetes-sidecar container.
@@ -516,8 +746,9 @@ func configureSidecarContainer(container *corev1.Container, initMode bool, proce
 
 	var sidecarArgs []string
 
-	sidecarArgs = []string{
-		"--copy-file", "fdb.cluster",
+	// If the seed cluster file is disabled, the cluster file is not present in the dynamic config.
+	if optionalCluster == nil || optionalCluster.UseSeedClusterFile() {
+		sidecarArgs = append(sidecarArgs, "--copy-file", "fdb.cluster")
 	}
 	if hasTrustedCAs {
 		sidecarArgs = append(sidecarArgs, "--copy-file", "ca.pem")
@@ -549,10 +780,18 @@ func configureSidecarContainer(container *corev1.Container, initMode bool, proce
 			}
 		}
 
-		if cluster.NeedsExplicitListenAddress() {
+		if usePodIPSubstitution(cluster) {
 			sidecarArgs = append(sidecarArgs, "--substitute-variable", "FDB_POD_IP")
 		}
 
+		if cluster.UseRackLocality() {
+			sidecarArgs = append(sidecarArgs, "--substitute-variable", fdbv1beta2.EnvNameRackID)
+		}
+
+		if cluster.GetMachineIDVariable() != fdbv1beta2.EnvNameMachineID {
+			sidecarArgs = append(sidecarArgs, "--substitute-variable", cluster.GetMachineIDVariable())
+		}
+
 		for _, substitution := range cluster.Spec.SidecarVariables {
 			sidecarArgs = append(sidecarArgs, "--substitute-variable", substitution)
 		}
@@ -656,6 +895,32 @@ func configureSidecarContainer(container *corev1.Container, initMode bool, proce
 
 // getEnvForMonitorConfigSubstitution provides the environment variables that
 // are used for substituting variables into the monitor config.
+// usePodIPSubstitution returns true if the FDB_POD_IP variable should be provided for the monitor conf substitutions.
+// This is the case if an explicit listen address is required or if a custom parameter references the Pod IP. The
+// variable is not added otherwise, as changing the environment of the containers would recreate all Pods.
+func usePodIPSubstitution(cluster *fdbv1beta2.FoundationDBCluster) bool {
+	if cluster.NeedsExplicitListenAddress() {
+		return true
+	}
+
+	for _, settings := range cluster.Spec.Processes {
+		parameters := append(fdbv1beta2.FoundationDBCustomParameters{}, settings.CustomParameters...)
+		for _, entry := range settings.CustomParametersPerProcess {
+			parameters = append(parameters, entry.CustomParameters...)
+		}
+
+		for _, parameter := range parameters {
+			for _, argument := range generateMonitorArgumentFromCustomParameter(parameter) {
+				if argument.ArgumentType == monitorapi.EnvironmentArgumentType && argument.Source == "FDB_POD_IP" {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
 func getEnvForMonitorConfigSubstitution(cluster *fdbv1beta2.FoundationDBCluster, processGroupID fdbv1beta2.ProcessGroupID) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 
@@ -666,8 +931,7 @@ func getEnvForMonitorConfigSubstitution(cluster *fdbv1beta2.FoundationDBCluster,
 	if usePublicIPFromService {
 		publicIPKey = fmt.Sprintf("metadata.annotations['%s']", fdbv1beta2.PublicIPAnnotation)
 	} else {
-		family := cluster.Spec.Routing.PodIPFamily
-		if family == nil {
+		if cluster.GetIPFamilies() == nil {
 			publicIPKey = "status.podIP"
 		} else {
 			publicIPKey = "status.podIPs"
@@ -677,12 +941,9 @@ func getEnvForMonitorConfigSubstitution(cluster *fdbv1beta2.FoundationDBCluster,
 		FieldRef: &corev1.ObjectFieldSelector{FieldPath: publicIPKey},
 	}})
 
-	if cluster.NeedsExplicitListenAddress() {
-		podIPKey := ""
-		family := cluster.Spec.Routing.PodIPFamily
-		if family == nil {
-			podIPKey = "status.podIP"
-		} else {
+	if usePodIPSubstitution(cluster) {
+		podIPKey := "status.podIP"
+		if cluster.GetIPFamilies() != nil {
 			podIPKey = "status.podIPs"
 		}
 		env = append(env, corev1.EnvVar{Name: "FDB_POD_IP", ValueFrom: &corev1.EnvVarSource{
@@ -701,21 +962,15 @@ func getEnvForMonitorConfigSubstitution(cluster *fdbv1beta2.FoundationDBCluster,
 	}
 
 	if faultDomainKey == fdbv1beta2.NoneFaultDomainKey {
-		env = append(env, corev1.EnvVar{Name: "FDB_MACHINE_ID", ValueFrom: &corev1.EnvVarSource{
-			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
-		}})
+		env = append(env, getMachineIDEnv(cluster, "metadata.name")...)
 		env = append(env, corev1.EnvVar{Name: "FDB_ZONE_ID", ValueFrom: &corev1.EnvVarSource{
 			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
 		}})
 	} else if faultDomainKey == "foundationdb.org/kubernetes-cluster" {
-		env = append(env, corev1.EnvVar{Name: "FDB_MACHINE_ID", ValueFrom: &corev1.EnvVarSource{
-			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
-		}})
+		env = append(env, getMachineIDEnv(cluster, "spec.nodeName")...)
 		env = append(env, corev1.EnvVar{Name: "FDB_ZONE_ID", Value: cluster.Spec.FaultDomain.Value})
 	} else {
-		env = append(env, corev1.EnvVar{Name: "FDB_MACHINE_ID", ValueFrom: &corev1.EnvVarSource{
-			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
-		}})
+		env = append(env, getMachineIDEnv(cluster, "spec.nodeName")...)
 		if !strings.HasPrefix(faultDomainSource, "$") {
 			env = append(env, corev1.EnvVar{Name: "FDB_ZONE_ID", ValueFrom: &corev1.EnvVarSource{
 				FieldRef: &corev1.ObjectFieldSelector{FieldPath: faultDomainSource},
@@ -723,11 +978,33 @@ func getEnvForMonitorConfigSubstitution(cluster *fdbv1beta2.FoundationDBCluster,
 		}
 	}
 
+	if cluster.UseRackLocality() {
+		// The rack is set as annotation by the operator, based on the labels of the node the Pod is running on.
+		env = append(env, corev1.EnvVar{Name: fdbv1beta2.EnvNameRackID, ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.annotations['%s']", fdbv1beta2.RackAnnotation)},
+		}})
+	}
+
 	env = append(env, corev1.EnvVar{Name: "FDB_INSTANCE_ID", Value: string(processGroupID)})
 
 	return env
 }
 
+// getMachineIDEnv provides the environment variable for the machine ID. If the machine ID is taken from a node label,
+// the value is read from the annotation the operator sets on the Pod. If the machine ID is taken from a user defined
+// environment variable, no environment variable will be returned. Otherwise the provided field path will be used.
+func getMachineIDEnv(cluster *fdbv1beta2.FoundationDBCluster, fieldPath string) []corev1.EnvVar {
+	if cluster.UseMachineIDFromNodeLabel() {
+		fieldPath = fmt.Sprintf("metadata.annotations['%s']", fdbv1beta2.MachineIDAnnotation)
+	} else if cluster.GetMachineIDVariable() != fdbv1beta2.EnvNameMachineID {
+		return nil
+	}
+
+	return []corev1.EnvVar{{Name: fdbv1beta2.EnvNameMachineID, ValueFrom: &corev1.EnvVarSource{
+		FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath},
+	}}}
+}
+
 // GetPvc builds a persistent volume claim for a FoundationDB process group.
 func GetPvc(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) (*corev1.PersistentVolumeClaim, error) {
 	if !processGroup.ProcessClass.IsStateful() {
@@ -776,6 +1053,54 @@ func GetPvc(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.Pr
 	return pvc, nil
 }
 
+// GetLogPvc builds a persistent volume claim for the trace logs of a FoundationDB process group. If no log volume claim
+// template is defined for the process class, nil will be returned.
+func GetLogPvc(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) (*corev1.PersistentVolumeClaim, error) {
+	logVolumeClaimTemplate := cluster.GetProcessSettings(processGroup.ProcessClass).LogVolumeClaimTemplate
+	if logVolumeClaimTemplate == nil {
+		return nil, nil
+	}
+
+	pvc := logVolumeClaimTemplate.DeepCopy()
+	pvc.ObjectMeta = GetObjectMetadata(cluster, &logVolumeClaimTemplate.ObjectMeta, processGroup.ProcessClass, processGroup.ProcessGroupID)
+	pvc.ObjectMeta.Name = getLogPvcName(processGroup.GetPodName(cluster), logVolumeClaimTemplate)
+	pvc.ObjectMeta.Labels[fdbv1beta2.LogVolumeLabel] = "true"
+
+	if pvc.Spec.AccessModes == nil {
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+
+	storage := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if (&storage).IsZero() {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("16G")
+	}
+
+	specHash, err := GetJSONHash(pvc.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if pvc.ObjectMeta.Annotations == nil {
+		pvc.ObjectMeta.Annotations = make(map[string]string, 1)
+	}
+	pvc.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = specHash
+
+	return pvc, nil
+}
+
+// getLogPvcName returns the name of the persistent volume claim for the trace logs of the provided Pod.
+func getLogPvcName(podName string, logVolumeClaimTemplate *corev1.PersistentVolumeClaim) string {
+	if logVolumeClaimTemplate.Name == "" {
+		return fmt.Sprintf("%s-logs", podName)
+	}
+
+	return fmt.Sprintf("%s-%s", podName, logVolumeClaimTemplate.Name)
+}
+
 // replaceContainers overwrites the containers in a list with new containers
 // that have the same name.
 func replaceContainers(containers []corev1.Container, newContainers ...*corev1.Container) {
@@ -804,6 +1129,32 @@ func extendEnv(container *corev1.Container, env ...corev1.EnvVar) {
 	}
 }
 
+// getBlobCABundleVolume returns the volume for the CA bundle of the blobstore, the volume only contains the referenced
+// key of the Secret or ConfigMap.
+func getBlobCABundleVolume(bundle *fdbv1beta2.BlobStoreCABundle) corev1.Volume {
+	if bundle.SecretKeyRef != nil {
+		return corev1.Volume{
+			Name: "blob-ca-bundle",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: bundle.SecretKeyRef.Name,
+				Items: []corev1.KeyToPath{
+					{Key: bundle.SecretKeyRef.Key, Path: bundle.SecretKeyRef.Key},
+				},
+			}},
+		}
+	}
+
+	return corev1.Volume{
+		Name: "blob-ca-bundle",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: bundle.ConfigMapKeyRef.Name},
+			Items: []corev1.KeyToPath{
+				{Key: bundle.ConfigMapKeyRef.Key, Path: bundle.ConfigMapKeyRef.Key},
+			},
+		}},
+	}
+}
+
 // GetBackupDeploymentName returns the name for the associated deployment for the FoundationDBBackup.
 func GetBackupDeploymentName(backup *fdbv1beta2.FoundationDBBackup) string {
 	return fmt.Sprintf("%s-backup-agents", backup.ObjectMeta.Name)
@@ -928,6 +1279,38 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 		corev1.VolumeMount{Name: "dynamic-conf", MountPath: "/var/dynamic-conf"},
 	)
 
+	credentialsPath := backup.BlobCredentialsPath()
+	if credentialsPath != "" {
+		credentialsSecret := backup.Spec.BlobStoreConfiguration.CredentialsSecret
+		extendEnv(mainContainer, corev1.EnvVar{Name: "FDB_BLOB_CREDENTIALS", Value: credentialsPath})
+		mainContainer.VolumeMounts = append(mainContainer.VolumeMounts,
+			corev1.VolumeMount{Name: "blob-credentials", MountPath: path.Dir(credentialsPath), ReadOnly: true},
+		)
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+			Name: "blob-credentials",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: credentialsSecret.Name,
+				Items: []corev1.KeyToPath{
+					{Key: credentialsSecret.Key, Path: credentialsSecret.Key},
+				},
+			}},
+		})
+	}
+
+	caFilePath := backup.Spec.BlobStoreConfiguration.CAFilePath()
+	if caFilePath != "" {
+		extendEnv(mainContainer, corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: caFilePath})
+		mainContainer.VolumeMounts = append(mainContainer.VolumeMounts,
+			corev1.VolumeMount{Name: "blob-ca-bundle", MountPath: path.Dir(caFilePath), ReadOnly: true},
+		)
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, getBlobCABundleVolume(backup.Spec.BlobStoreConfiguration.CABundle))
+	}
+
+	verifyPeers := backup.Spec.BlobStoreConfiguration.VerifyPeers()
+	if verifyPeers != "" {
+		extendEnv(mainContainer, corev1.EnvVar{Name: "FDB_TLS_VERIFY_PEERS", Value: verifyPeers})
+	}
+
 	if mainContainer.Resources.Requests == nil {
 		mainContainer.Resources.Requests = corev1.ResourceList{
 			"cpu":    resource.MustParse("1"),
@@ -957,6 +1340,10 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 		fdbv1beta2.BackupDeploymentPodLabel: deployment.ObjectMeta.Name,
 	}}
 
+	if backup.Spec.AgentScheduling != nil {
+		applyBackupAgentScheduling(podTemplate, backup.Spec.AgentScheduling, deployment.Spec.Selector)
+	}
+
 	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes,
 		corev1.Volume{
 			Name: "logs",
@@ -995,6 +1382,27 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 	return deployment, nil
 }
 
+// applyBackupAgentScheduling merges the scheduling settings of the backup agents into the pod template. Topology spread
+// constraints without a label selector will use the selector of the backup agent pods.
+func applyBackupAgentScheduling(podTemplate *corev1.PodTemplateSpec, scheduling *fdbv1beta2.BackupAgentScheduling, selector *metav1.LabelSelector) {
+	if scheduling.Affinity != nil {
+		podTemplate.Spec.Affinity = scheduling.Affinity.DeepCopy()
+	}
+
+	for _, toleration := range scheduling.Tolerations {
+		podTemplate.Spec.Tolerations = append(podTemplate.Spec.Tolerations, *toleration.DeepCopy())
+	}
+
+	for _, constraint := range scheduling.TopologySpreadConstraints {
+		spreadConstraint := constraint.DeepCopy()
+		if spreadConstraint.LabelSelector == nil {
+			spreadConstraint.LabelSelector = selector.DeepCopy()
+		}
+
+		podTemplate.Spec.TopologySpreadConstraints = append(podTemplate.Spec.TopologySpreadConstraints, *spreadConstraint)
+	}
+}
+
 // GetServersPerPodForPod returns the count of servers per Pod based on the processClass from the sidecar or 1
 func GetServersPerPodForPod(pod *corev1.Pod, pClass fdbv1beta2.ProcessClass) (int, error) {
 	// If not specified we will default to 1
@@ -1032,6 +1440,9 @@ func GetPodMetadata(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1b
 	}
 	metadata.Annotations[fdbv1beta2.LastSpecKey] = specHash
 	metadata.Annotations[fdbv1beta2.PublicIPSourceAnnotation] = string(cluster.GetPublicIPSource())
+	if cluster.Spec.Routing.ProcessPortBase != nil {
+		metadata.Annotations[fdbv1beta2.ProcessPortBaseAnnotation] = strconv.Itoa(cluster.GetProcessPortBase())
+	}
 
 	return metadata
 }
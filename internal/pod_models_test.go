@@ -144,6 +144,18 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with a custom process port base", func() {
+			BeforeEach(func() {
+				cluster.Spec.Routing.ProcessPortBase = pointer.Int(5001)
+				pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the process port base annotation", func() {
+				Expect(pod.ObjectMeta.Annotations).To(HaveKeyWithValue(fdbv1beta2.ProcessPortBaseAnnotation, "5001"))
+			})
+		})
+
 		Context("with custom labels", func() {
 			BeforeEach(func() {
 				cluster = CreateDefaultCluster()
@@ -170,6 +182,130 @@ var _ = Describe("pod_models", func() {
 				}))
 			})
 		})
+
+		When("the connectivity check is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.ConnectivityCheck.Enabled = pointer.Bool(true)
+				pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the connectivity check after the init container", func() {
+				Expect(pod.Spec.InitContainers).To(HaveLen(2))
+				Expect(pod.Spec.InitContainers[0].Name).To(Equal(fdbv1beta2.InitContainerName))
+
+				container := pod.Spec.InitContainers[1]
+				Expect(container.Name).To(Equal(fdbv1beta2.ConnectivityCheckContainerName))
+				Expect(container.Image).To(Equal(pod.Spec.Containers[0].Image))
+				Expect(container.Command).To(Equal([]string{"bash", "-c"}))
+				Expect(container.Args).To(Equal([]string{connectivityCheckScript}))
+				Expect(container.Env).To(ConsistOf(
+					corev1.EnvVar{Name: "FDB_CONNECTIVITY_CHECK_TIMEOUT", Value: "300"},
+					corev1.EnvVar{Name: "FDB_POD_IP", ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+					}},
+					corev1.EnvVar{Name: "FDB_DNS_NAME", Value: GetPodDNSName(cluster, pod.Name)},
+				))
+				Expect(container.VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: "config-map", MountPath: "/var/input-files", ReadOnly: true}))
+			})
+
+			When("a custom connectivity check container is defined", func() {
+				BeforeEach(func() {
+					cluster.Spec.ConnectivityCheck.TimeoutSeconds = pointer.Int(60)
+					settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+					settings.PodTemplate.Spec.InitContainers = append(settings.PodTemplate.Spec.InitContainers, corev1.Container{
+						Name:  fdbv1beta2.ConnectivityCheckContainerName,
+						Image: "custom-image:1.0",
+					})
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = settings
+
+					pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should keep the custom image and set the missing fields", func() {
+					Expect(pod.Spec.InitContainers).To(HaveLen(2))
+
+					container := pod.Spec.InitContainers[1]
+					Expect(container.Name).To(Equal(fdbv1beta2.ConnectivityCheckContainerName))
+					Expect(container.Image).To(Equal("custom-image:1.0"))
+					Expect(container.Args).To(Equal([]string{connectivityCheckScript}))
+					Expect(container.Env).To(ConsistOf(
+						corev1.EnvVar{Name: "FDB_CONNECTIVITY_CHECK_TIMEOUT", Value: "60"},
+						corev1.EnvVar{Name: "FDB_POD_IP", ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.podIP"},
+						}},
+						corev1.EnvVar{Name: "FDB_DNS_NAME", Value: GetPodDNSName(cluster, pod.Name)},
+					))
+				})
+			})
+		})
+
+		When("a probe override is defined for the storage class", func() {
+			var probe *corev1.Probe
+
+			BeforeEach(func() {
+				probe = &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromInt(4501),
+						},
+					},
+					PeriodSeconds:    30,
+					FailureThreshold: 5,
+				}
+
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage] = fdbv1beta2.ProcessSettings{
+					ProbeOverrides: []fdbv1beta2.ContainerProbeOverride{
+						{
+							ContainerName: fdbv1beta2.MainContainerName,
+							LivenessProbe: probe,
+						},
+					},
+				}
+			})
+
+			It("should add the probe to the main container of the storage pods", func() {
+				pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Name).To(Equal(fdbv1beta2.MainContainerName))
+				Expect(pod.Spec.Containers[0].LivenessProbe).To(Equal(probe))
+				Expect(pod.Spec.Containers[1].Name).To(Equal(fdbv1beta2.SidecarContainerName))
+				Expect(pod.Spec.Containers[1].LivenessProbe).NotTo(Equal(probe))
+			})
+
+			It("should not add the probe to the pods of other process classes", func() {
+				pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassLog, 1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Spec.Containers[0].LivenessProbe).To(BeNil())
+			})
+
+			When("the probe override references an unknown container", func() {
+				BeforeEach(func() {
+					settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage]
+					settings.ProbeOverrides[0].ContainerName = "missing"
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage] = settings
+				})
+
+				It("should return an error", func() {
+					_, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+					Expect(err).To(MatchError("could not find container missing for probe override"))
+				})
+			})
+		})
+
+		When("the connectivity check is disabled", func() {
+			BeforeEach(func() {
+				pod, err = GetPod(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not add the connectivity check", func() {
+				for _, container := range pod.Spec.InitContainers {
+					Expect(container.Name).NotTo(Equal(fdbv1beta2.ConnectivityCheckContainerName))
+				}
+			})
+		})
 	})
 
 	Describe("GetPodSpec", func() {
@@ -929,6 +1065,145 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		When("the seed cluster file is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.UseSeedClusterFile = pointer.Bool(false)
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+			})
+
+			It("should not copy the cluster file", func() {
+				Expect(spec.InitContainers[0].Args).NotTo(ContainElement("fdb.cluster"))
+				Expect(spec.Containers[1].Args).NotTo(ContainElement("fdb.cluster"))
+				for _, volume := range spec.Volumes {
+					if volume.Name != "config-map" {
+						continue
+					}
+
+					Expect(volume.ConfigMap.Items).NotTo(ContainElement(corev1.KeyToPath{Key: ClusterFileKey, Path: "fdb.cluster"}))
+				}
+			})
+		})
+
+		When("a rack key is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.FaultDomain.RackKey = "topology.kubernetes.io/rack"
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+			})
+
+			It("should substitute the rack in the sidecar container", func() {
+				sidecarContainer := spec.Containers[1]
+				Expect(sidecarContainer.Name).To(Equal(fdbv1beta2.SidecarContainerName))
+				Expect(sidecarContainer.Args).To(ContainElements("--substitute-variable", fdbv1beta2.EnvNameRackID))
+				Expect(sidecarContainer.Env).To(ContainElement(corev1.EnvVar{Name: fdbv1beta2.EnvNameRackID, ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['foundationdb.org/rack']"},
+				}}))
+			})
+
+			It("should wait for the rack annotation before starting the other containers", func() {
+				Expect(spec.InitContainers).To(HaveLen(2))
+				container := spec.InitContainers[0]
+				Expect(container.Name).To(Equal(fdbv1beta2.NodeLabelsContainerName))
+				Expect(container.Image).To(Equal(spec.Containers[0].Image))
+				Expect(container.Command).To(Equal([]string{"bash", "-c"}))
+				Expect(container.Args).To(Equal([]string{nodeLabelsScript}))
+				Expect(container.Env).To(ConsistOf(corev1.EnvVar{Name: "FDB_NODE_LABEL_ANNOTATIONS", Value: fdbv1beta2.RackAnnotation}))
+				Expect(container.VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: "pod-annotations", MountPath: "/var/pod-annotations", ReadOnly: true}))
+				Expect(spec.InitContainers[1].Name).To(Equal(fdbv1beta2.InitContainerName))
+				Expect(spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "pod-annotations",
+					VolumeSource: corev1.VolumeSource{
+						DownwardAPI: &corev1.DownwardAPIVolumeSource{
+							Items: []corev1.DownwardAPIVolumeFile{
+								{
+									Path:     "annotations",
+									FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations"},
+								},
+							},
+						},
+					},
+				}))
+			})
+		})
+
+		When("the machine ID is taken from a node label", func() {
+			BeforeEach(func() {
+				cluster.Spec.MachineIDSource = &fdbv1beta2.MachineIDSource{NodeLabel: corev1.LabelHostname}
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+			})
+
+			It("should read the machine ID from the Pod annotation", func() {
+				sidecarContainer := spec.Containers[1]
+				Expect(sidecarContainer.Name).To(Equal(fdbv1beta2.SidecarContainerName))
+				Expect(sidecarContainer.Env).To(ContainElement(corev1.EnvVar{Name: fdbv1beta2.EnvNameMachineID, ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['foundationdb.org/machine-id']"},
+				}}))
+			})
+
+			It("should wait for the machine ID annotation before starting the other containers", func() {
+				Expect(spec.InitContainers[0].Name).To(Equal(fdbv1beta2.NodeLabelsContainerName))
+				Expect(spec.InitContainers[0].Env).To(ConsistOf(corev1.EnvVar{Name: "FDB_NODE_LABEL_ANNOTATIONS", Value: fdbv1beta2.MachineIDAnnotation}))
+			})
+
+			When("a rack key is defined", func() {
+				BeforeEach(func() {
+					cluster.Spec.FaultDomain.RackKey = "topology.kubernetes.io/rack"
+					spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				})
+
+				It("should wait for both annotations in a single init container", func() {
+					Expect(spec.InitContainers).To(HaveLen(2))
+					Expect(spec.InitContainers[0].Name).To(Equal(fdbv1beta2.NodeLabelsContainerName))
+					Expect(spec.InitContainers[0].Env).To(ConsistOf(corev1.EnvVar{Name: "FDB_NODE_LABEL_ANNOTATIONS", Value: fdbv1beta2.RackAnnotation + " " + fdbv1beta2.MachineIDAnnotation}))
+				})
+			})
+		})
+
+		When("the machine ID is taken from an environment variable", func() {
+			BeforeEach(func() {
+				cluster.Spec.MachineIDSource = &fdbv1beta2.MachineIDSource{EnvironmentVariable: "CUSTOM_MACHINE_ID"}
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+			})
+
+			It("should substitute the environment variable in the sidecar container", func() {
+				sidecarContainer := spec.Containers[1]
+				Expect(sidecarContainer.Name).To(Equal(fdbv1beta2.SidecarContainerName))
+				Expect(sidecarContainer.Args).To(ContainElements("--substitute-variable", "CUSTOM_MACHINE_ID"))
+				for _, envVar := range sidecarContainer.Env {
+					Expect(envVar.Name).NotTo(Equal(fdbv1beta2.EnvNameMachineID))
+				}
+			})
+		})
+
+		When("a custom trace log dir is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage] = fdbv1beta2.ProcessSettings{TraceLogDir: "/var/log/custom"}
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should mount the trace logs volume at the trace log dir", func() {
+				mainContainer := spec.Containers[0]
+				Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+				Expect(mainContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "fdb-trace-logs", MountPath: "/var/log/custom"}))
+				Expect(mainContainer.Args[0]).To(HaveSuffix(" >> /var/log/custom/fdbmonitor-$(date '+%Y-%m-%d').log 2>&1"))
+			})
+
+			When("the unified image is used", func() {
+				BeforeEach(func() {
+					cluster.Spec.UseUnifiedImage = pointer.Bool(true)
+					spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should mount the trace logs volume at the trace log dir", func() {
+					mainContainer := spec.Containers[0]
+					Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+					Expect(mainContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "fdb-trace-logs", MountPath: "/var/log/custom"}))
+					Expect(mainContainer.Args).To(ContainElements("--log-path", "/var/log/custom/monitor.log"))
+				})
+			})
+		})
+
 		When("enabling DNS in the cluster file", func() {
 			BeforeEach(func() {
 				cluster.Spec.Routing.UseDNSInClusterFile = pointer.Bool(true)
@@ -1517,6 +1792,61 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with a the public IP from the pod and without an explicit listen address", func() {
+			BeforeEach(func() {
+				var source = fdbv1beta2.PublicIPSourcePod
+				cluster.Spec.Routing.PublicIPSource = &source
+				cluster.Spec.UseExplicitListenAddress = pointer.Bool(false)
+				spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not have the pod IP in the sidecar container", func() {
+				sidecarContainer := spec.Containers[1]
+				Expect(sidecarContainer.Name).To(Equal(fdbv1beta2.SidecarContainerName))
+				Expect(sidecarContainer.Args).NotTo(ContainElement("FDB_POD_IP"))
+				Expect(GetEnvVars(sidecarContainer)).NotTo(HaveKey("FDB_POD_IP"))
+			})
+
+			When("a custom parameter references the pod IP", func() {
+				BeforeEach(func() {
+					settings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+					settings.CustomParameters = fdbv1beta2.FoundationDBCustomParameters{"locality_listen_ip=$FDB_POD_IP"}
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = settings
+					spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should have the pod IP in the sidecar container args", func() {
+					sidecarContainer := spec.Containers[1]
+					Expect(sidecarContainer.Name).To(Equal(fdbv1beta2.SidecarContainerName))
+					Expect(sidecarContainer.Args).To(ContainElements("--substitute-variable", "FDB_POD_IP"))
+				})
+
+				It("should have the environment variable for the pod IP in the sidecar container", func() {
+					sidecarEnv := GetEnvVars(spec.Containers[1])
+					Expect(sidecarEnv["FDB_POD_IP"]).NotTo(BeNil())
+					Expect(sidecarEnv["FDB_POD_IP"].ValueFrom).NotTo(BeNil())
+					Expect(sidecarEnv["FDB_POD_IP"].ValueFrom.FieldRef.FieldPath).To(Equal("status.podIP"))
+				})
+
+				When("the unified image is used", func() {
+					BeforeEach(func() {
+						cluster.Spec.UseUnifiedImage = pointer.Bool(true)
+						spec, err = GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("should have the environment variable for the pod IP in the main container", func() {
+						mainEnv := GetEnvVars(spec.Containers[0])
+						Expect(mainEnv["FDB_POD_IP"]).NotTo(BeNil())
+						Expect(mainEnv["FDB_POD_IP"].ValueFrom).NotTo(BeNil())
+						Expect(mainEnv["FDB_POD_IP"].ValueFrom.FieldRef.FieldPath).To(Equal("status.podIP"))
+					})
+				})
+			})
+		})
+
 		Context("with a the public IP from the pod and an explicit listen address", func() {
 			BeforeEach(func() {
 				var source = fdbv1beta2.PublicIPSourcePod
@@ -2625,6 +2955,27 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with a custom process port base", func() {
+			BeforeEach(func() {
+				cluster.Spec.Routing.ProcessPortBase = pointer.Int(5001)
+				cluster.Spec.StorageServersPerPod = 2
+				service, err = GetService(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should use the process port base for the ports", func() {
+				Expect(service.Spec.Ports).To(HaveLen(4))
+				Expect(service.Spec.Ports[0].Name).To(Equal("tls"))
+				Expect(service.Spec.Ports[0].Port).To(Equal(int32(5000)))
+				Expect(service.Spec.Ports[1].Name).To(Equal("non-tls"))
+				Expect(service.Spec.Ports[1].Port).To(Equal(int32(5001)))
+				Expect(service.Spec.Ports[2].Name).To(Equal("tls-2"))
+				Expect(service.Spec.Ports[2].Port).To(Equal(int32(5002)))
+				Expect(service.Spec.Ports[3].Name).To(Equal("non-tls-2"))
+				Expect(service.Spec.Ports[3].Port).To(Equal(int32(5003)))
+			})
+		})
+
 		Context("with custom resource labels", func() {
 			BeforeEach(func() {
 				cluster.Spec.LabelConfig = fdbv1beta2.LabelConfig{
@@ -2870,6 +3221,87 @@ var _ = Describe("pod_models", func() {
 		})
 	})
 
+	Describe("GetLogPvc", func() {
+		var pvc *corev1.PersistentVolumeClaim
+
+		When("no log volume claim template is defined", func() {
+			BeforeEach(func() {
+				pvc, err = GetLogPvc(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassLog, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not return a PVC", func() {
+				Expect(pvc).To(BeNil())
+			})
+		})
+
+		When("a log volume claim template is defined for the log process class", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassLog] = fdbv1beta2.ProcessSettings{
+					LogVolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}
+				pvc, err = GetLogPvc(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassLog, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should set the metadata on the PVC", func() {
+				Expect(pvc.Namespace).To(Equal("my-ns"))
+				Expect(pvc.Name).To(Equal(fmt.Sprintf("%s-log-1-logs", cluster.Name)))
+				Expect(pvc.ObjectMeta.Labels).To(Equal(map[string]string{
+					fdbv1beta2.FDBClusterLabel:        cluster.Name,
+					fdbv1beta2.FDBProcessClassLabel:   string(fdbv1beta2.ProcessClassLog),
+					fdbv1beta2.FDBProcessGroupIDLabel: "log-1",
+					fdbv1beta2.LogVolumeLabel:         "true",
+				}))
+				Expect(pvc.ObjectMeta.Annotations).To(HaveKey(fdbv1beta2.LastSpecKey))
+			})
+
+			It("should set the spec on the PVC", func() {
+				Expect(pvc.Spec).To(Equal(corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("16G"),
+						},
+					},
+				}))
+			})
+
+			It("should use the PVC for the trace logs volume", func() {
+				spec, err := GetPodSpec(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassLog, 1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "fdb-trace-logs",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+					},
+				}))
+			})
+
+			It("should not return a PVC for the storage process class", func() {
+				storagePVC, err := GetLogPvc(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(storagePVC).To(BeNil())
+			})
+		})
+
+		When("a log volume claim template with a name is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassLog] = fdbv1beta2.ProcessSettings{
+					LogVolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{Name: "trace-logs"},
+					},
+				}
+				pvc, err = GetLogPvc(cluster, GetProcessGroup(cluster, fdbv1beta2.ProcessClassLog, 1))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should include the name in the suffix", func() {
+				Expect(pvc.Name).To(Equal(fmt.Sprintf("%s-log-1-trace-logs", cluster.Name)))
+			})
+		})
+	})
+
 	Describe("GetHeadlessService", func() {
 		var service *corev1.Service
 		var enabled = true
@@ -3336,6 +3768,153 @@ var _ = Describe("pod_models", func() {
 				Expect(deployment.Spec.Template.Spec.InitContainers[0].Image).To(HavePrefix("foundationdb/foundationdb-kubernetes"))
 				Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(HavePrefix("foundationdb/foundationdb-kubernetes"))
 			})
+
+			It("should start the backup agent in the main container", func() {
+				container := deployment.Spec.Template.Spec.Containers[0]
+				Expect(container.Command).To(Equal([]string{"backup_agent"}))
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "FDB_CLUSTER_FILE", Value: "/var/dynamic-conf/fdb.cluster"}))
+				Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "dynamic-conf", MountPath: "/var/dynamic-conf"}))
+			})
+
+			It("should copy the cluster file with the init container", func() {
+				container := deployment.Spec.Template.Spec.InitContainers[0]
+				Expect(container.Image).To(Equal(fmt.Sprintf("foundationdb/foundationdb-kubernetes:%s", cluster.Spec.Version)))
+				Expect(container.Args).To(Equal([]string{
+					"--mode", "init",
+					"--input-dir", "/var/input-files",
+					"--output-dir", "/var/output-files",
+					"--copy-file", "fdb.cluster",
+					"--require-not-empty", "fdb.cluster",
+				}))
+				Expect(container.VolumeMounts).To(Equal([]corev1.VolumeMount{
+					{Name: "config-map", MountPath: "/var/input-files"},
+					{Name: "dynamic-conf", MountPath: "/var/output-files"},
+				}))
+			})
+		})
+
+		When("the scheduling of the backup agents is customized", func() {
+			var affinity *corev1.Affinity
+			var templateToleration, toleration corev1.Toleration
+			var customSelector *metav1.LabelSelector
+
+			BeforeEach(func() {
+				templateToleration = corev1.Toleration{Key: "template", Operator: corev1.TolerationOpExists}
+				toleration = corev1.Toleration{Key: "scheduling", Operator: corev1.TolerationOpExists}
+				customSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"custom": "selector"}}
+				affinity = &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+							{
+								Weight: 1,
+								PodAffinityTerm: corev1.PodAffinityTerm{
+									TopologyKey: corev1.LabelHostname,
+								},
+							},
+						},
+					},
+				}
+
+				backup.Spec.PodTemplateSpec = &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Affinity: &corev1.Affinity{
+							NodeAffinity: &corev1.NodeAffinity{},
+						},
+						Tolerations: []corev1.Toleration{templateToleration},
+					},
+				}
+				backup.Spec.AgentScheduling = &fdbv1beta2.BackupAgentScheduling{
+					Affinity:    affinity,
+					Tolerations: []corev1.Toleration{toleration},
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       corev1.LabelHostname,
+							WhenUnsatisfiable: corev1.DoNotSchedule,
+						},
+						{
+							MaxSkew:           1,
+							TopologyKey:       corev1.LabelTopologyZone,
+							WhenUnsatisfiable: corev1.ScheduleAnyway,
+							LabelSelector:     customSelector,
+						},
+					},
+				}
+
+				deployment, err = GetBackupDeployment(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should merge the scheduling settings into the pod template", func() {
+				Expect(deployment.Spec.Template.Spec.Affinity).To(Equal(affinity))
+				Expect(deployment.Spec.Template.Spec.Tolerations).To(Equal([]corev1.Toleration{templateToleration, toleration}))
+				Expect(deployment.Spec.Template.Spec.TopologySpreadConstraints).To(HaveLen(2))
+				Expect(deployment.Spec.Template.Spec.TopologySpreadConstraints[0].LabelSelector).To(Equal(deployment.Spec.Selector))
+				Expect(deployment.Spec.Template.Spec.TopologySpreadConstraints[1].LabelSelector).To(Equal(customSelector))
+			})
+		})
+
+		When("a blob credentials secret is defined", func() {
+			BeforeEach(func() {
+				backup.Spec.BlobStoreConfiguration.CredentialsSecret = &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "blob-secret"},
+					Key:                  "credentials.json",
+				}
+
+				deployment, err = GetBackupDeployment(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should mount the credentials in the backup agents", func() {
+				mainContainer := deployment.Spec.Template.Spec.Containers[0]
+				Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+				Expect(mainContainer.Env).To(ContainElement(corev1.EnvVar{Name: "FDB_BLOB_CREDENTIALS", Value: "/var/blob-credentials/blob-secret/credentials.json"}))
+				Expect(mainContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "blob-credentials", MountPath: "/var/blob-credentials/blob-secret", ReadOnly: true}))
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "blob-credentials",
+					VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+						SecretName: "blob-secret",
+						Items: []corev1.KeyToPath{
+							{Key: "credentials.json", Path: "credentials.json"},
+						},
+					}},
+				}))
+			})
+		})
+
+		When("a CA bundle for the blobstore is defined", func() {
+			BeforeEach(func() {
+				backup.Spec.BlobStoreConfiguration.CABundle = &fdbv1beta2.BlobStoreCABundle{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+						Key:                  "ca.pem",
+					},
+				}
+				backup.Spec.BlobStoreConfiguration.TLSVerifyPeers = pointer.Bool(false)
+
+				deployment, err = GetBackupDeployment(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should mount the CA bundle in the backup agents", func() {
+				mainContainer := deployment.Spec.Template.Spec.Containers[0]
+				Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+				Expect(mainContainer.Env).To(ContainElement(corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: "/var/blob-ca-bundle/blob-ca/ca.pem"}))
+				Expect(mainContainer.Env).To(ContainElement(corev1.EnvVar{Name: "FDB_TLS_VERIFY_PEERS", Value: "Check.Valid=0"}))
+				Expect(mainContainer.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "blob-ca-bundle", MountPath: "/var/blob-ca-bundle/blob-ca", ReadOnly: true}))
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "blob-ca-bundle",
+					VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "blob-ca"},
+						Items: []corev1.KeyToPath{
+							{Key: "ca.pem", Path: "ca.pem"},
+						},
+					}},
+				}))
+			})
 		})
 	})
 
@@ -34,8 +34,18 @@ func CreatePVCMap(cluster *fdbv1beta2.FoundationDBCluster, pvcs *corev1.Persiste
 			continue
 		}
 
+		// The persistent volume claims for the trace logs are managed separately.
+		if IsLogPvc(pvc) {
+			continue
+		}
+
 		pvcMap[processGroupID] = pvc
 	}
 
 	return pvcMap
 }
+
+// IsLogPvc returns true if the provided persistent volume claim holds the trace logs of a process group.
+func IsLogPvc(pvc corev1.PersistentVolumeClaim) bool {
+	return pvc.Labels[fdbv1beta2.LogVolumeLabel] == "true"
+}
@@ -133,8 +133,9 @@ func GetRemainingMap(logger logr.Logger, adminClient fdbadminclient.AdminClient,
 	// The CanSafelyExcludeProcessesWithRecoveryState will run the exclusion command for processes that are either assumed to be fully excluded
 	// and processes that are currently missing from the machine-readable status. If it's not safe to run the exclude command
 	// we will block all further checks and assume that those processes are not yet excluded. This should reduce the risk
-	// of successive recoveries because of the exclusion call.
-	err := fdbstatus.CanSafelyExcludeProcessesWithRecoveryState(cluster, status, minRecoverySeconds)
+	// of successive recoveries because of the exclusion call. Those processes are already excluded, so no process groups
+	// are passed for the maintenance zone check.
+	err := fdbstatus.CanSafelyExcludeProcessesWithRecoveryState(cluster, status, minRecoverySeconds, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +26,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // getReplacementInformation will return the maximum allowed replacements for process group based replacements and the
@@ -47,6 +48,82 @@ func getReplacementInformation(cluster *fdbv1beta2.FoundationDBCluster, maxRepla
 	return maxReplacements - removalCount, faultDomains
 }
 
+// getFaultDomainReplacementInformation returns the number of ongoing replacements per fault domain and the latest
+// removal timestamp per fault domain. The latest removal timestamp is based on the last replacements recorded in the
+// cluster status and the removal timestamps of the process groups that are still present.
+func getFaultDomainReplacementInformation(cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1beta2.FaultDomain]int, map[fdbv1beta2.FaultDomain]time.Time) {
+	ongoingReplacements := map[fdbv1beta2.FaultDomain]int{}
+	lastRemovals := make(map[fdbv1beta2.FaultDomain]time.Time, len(cluster.Status.LastFaultDomainReplacements))
+	for faultDomain, lastReplacement := range cluster.Status.LastFaultDomainReplacements {
+		lastRemovals[faultDomain] = lastReplacement.Time
+	}
+
+	for _, processGroupStatus := range cluster.Status.ProcessGroups {
+		if !processGroupStatus.IsMarkedForRemoval() {
+			continue
+		}
+
+		if !processGroupStatus.IsExcluded() {
+			ongoingReplacements[processGroupStatus.FaultDomain]++
+		}
+
+		if processGroupStatus.RemovalTimestamp.Time.After(lastRemovals[processGroupStatus.FaultDomain]) {
+			lastRemovals[processGroupStatus.FaultDomain] = processGroupStatus.RemovalTimestamp.Time
+		}
+	}
+
+	return ongoingReplacements, lastRemovals
+}
+
+// updateLastFaultDomainReplacements records the provided replacement time for the fault domain in the cluster status
+// and removes all entries for which the cool-down has passed. If no cool-down is defined, all entries will be removed.
+func updateLastFaultDomainReplacements(cluster *fdbv1beta2.FoundationDBCluster, faultDomain fdbv1beta2.FaultDomain, replacementTime time.Time, now time.Time) {
+	cooldown := cluster.GetFaultDomainReplacementCooldown()
+	for key, lastReplacement := range cluster.Status.LastFaultDomainReplacements {
+		if !lastReplacement.Add(cooldown).After(now) {
+			delete(cluster.Status.LastFaultDomainReplacements, key)
+		}
+	}
+
+	if cooldown > 0 && faultDomain != "" {
+		if cluster.Status.LastFaultDomainReplacements == nil {
+			cluster.Status.LastFaultDomainReplacements = map[fdbv1beta2.FaultDomain]metav1.Time{}
+		}
+
+		cluster.Status.LastFaultDomainReplacements[faultDomain] = metav1.NewTime(replacementTime)
+	}
+
+	if len(cluster.Status.LastFaultDomainReplacements) == 0 {
+		cluster.Status.LastFaultDomainReplacements = nil
+	}
+}
+
+// faultDomainReplacementAllowed returns an empty string if the replacement of a process group in the provided fault
+// domain is allowed based on the per fault domain limit and the cool-down between replacements in the same fault
+// domain. Otherwise, the reason why the replacement must be deferred is returned. Process groups without a fault
+// domain are not limited.
+func faultDomainReplacementAllowed(cluster *fdbv1beta2.FoundationDBCluster, ongoingReplacements map[fdbv1beta2.FaultDomain]int, lastRemovals map[fdbv1beta2.FaultDomain]time.Time, faultDomain fdbv1beta2.FaultDomain, now time.Time) string {
+	if faultDomain == "" {
+		return ""
+	}
+
+	maxReplacements := cluster.GetMaxConcurrentReplacementsPerFaultDomain()
+	if ongoingReplacements[faultDomain] >= maxReplacements {
+		return fmt.Sprintf("%d ongoing replacements, limit is %d", ongoingReplacements[faultDomain], maxReplacements)
+	}
+
+	cooldown := cluster.GetFaultDomainReplacementCooldown()
+	lastRemoval, ok := lastRemovals[faultDomain]
+	if cooldown > 0 && ok {
+		remaining := lastRemoval.Add(cooldown).Sub(now)
+		if remaining > 0 {
+			return fmt.Sprintf("cool-down after the last replacement, remaining: %s", remaining.Round(time.Second))
+		}
+	}
+
+	return ""
+}
+
 // removalAllowed will return true if the removal is allowed based on the clusters automatic replacement configuration.
 func removalAllowed(cluster *fdbv1beta2.FoundationDBCluster, maxReplacements int, faultDomainsWithReplacements map[fdbv1beta2.FaultDomain]fdbv1beta2.None, faultDomain fdbv1beta2.FaultDomain) bool {
 	if !cluster.FaultDomainBasedReplacements() {
@@ -120,11 +197,13 @@ func nodeTaintReplacementsAllowed(logger logr.Logger, cluster *fdbv1beta2.Founda
 
 // ReplaceFailedProcessGroups flags failed processes groups for removal. The first return value will indicate if any
 // new Process Group was removed and the second return value will indicate if there are more Process Groups that
-// needs a replacement, but the operator is not allowed to replace those as the limit is reached.
-func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, hasDesiredFaultTolerance bool) (bool, bool) {
+// needs a replacement, but the operator is not allowed to replace those as the limit is reached. The third return
+// value contains the fault domains where a replacement was deferred because of the per fault domain limits, together
+// with the reason.
+func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, hasDesiredFaultTolerance bool) (bool, bool, map[fdbv1beta2.FaultDomain]string) {
 	// Automatic replacements are disabled or set to 0, so we don't have to check anything further
 	if !cluster.GetEnableAutomaticReplacements() || cluster.GetMaxConcurrentAutomaticReplacements() == 0 {
-		return false, false
+		return false, false, nil
 	}
 
 	ignore := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
@@ -132,13 +211,16 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 	for _, targets := range crashLoopContainerProcessGroups {
 		// If all Process Groups are targeted to be crash looping, we can skip any further work.
 		if _, ok := targets["*"]; ok {
-			return false, false
+			return false, false, nil
 		}
 
 		ignore = targets
 	}
 
 	maxReplacements, faultDomainsWithReplacements := getReplacementInformation(cluster, cluster.GetMaxConcurrentAutomaticReplacements())
+	ongoingReplacements, lastRemovals := getFaultDomainReplacementInformation(cluster)
+	deferredFaultDomains := map[fdbv1beta2.FaultDomain]string{}
+	now := time.Now()
 	hasReplacement := false
 	hasMoreFailedProcesses := false
 	localitiesUsedForExclusion := cluster.UseLocalitiesForExclusion()
@@ -163,6 +245,11 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 			continue
 		}
 
+		if cluster.IgnoreProcessGroupForAutomation(processGroup) {
+			logger.V(1).Info("Skip test process group", "processGroupID", processGroup.ProcessGroupID)
+			continue
+		}
+
 		if processGroup.IsUnderMaintenance(status.Cluster.MaintenanceZone) {
 			logger.Info(
 				"Skip process group that is in maintenance zone",
@@ -218,6 +305,18 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 			continue
 		}
 
+		// We are not allowed to replace additional process groups in this fault domain.
+		if reason := faultDomainReplacementAllowed(cluster, ongoingReplacements, lastRemovals, processGroup.FaultDomain, now); reason != "" {
+			hasMoreFailedProcesses = true
+			deferredFaultDomains[processGroup.FaultDomain] = reason
+			logger.Info("Detected replace process group but cannot replace it because of the fault domain limits",
+				"processGroupID", processGroup.ProcessGroupID,
+				"failureCondition", failureCondition,
+				"faultDomain", processGroup.FaultDomain,
+				"reason", reason)
+			continue
+		}
+
 		logger.Info("Replace process group",
 			"processGroupID", processGroup.ProcessGroupID,
 			"failureCondition", failureCondition,
@@ -229,7 +328,10 @@ func ReplaceFailedProcessGroups(logger logr.Logger, cluster *fdbv1beta2.Foundati
 		processGroup.ExclusionSkipped = skipExclusion
 		maxReplacements--
 		faultDomainsWithReplacements[processGroup.FaultDomain] = fdbv1beta2.None{}
+		ongoingReplacements[processGroup.FaultDomain]++
+		lastRemovals[processGroup.FaultDomain] = processGroup.RemovalTimestamp.Time
+		updateLastFaultDomainReplacements(cluster, processGroup.FaultDomain, processGroup.RemovalTimestamp.Time, now)
 	}
 
-	return hasReplacement, hasMoreFailedProcesses
+	return hasReplacement, hasMoreFailedProcesses, deferredFaultDomains
 }
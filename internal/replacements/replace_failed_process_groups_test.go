@@ -21,13 +21,18 @@
 package replacements
 
 import (
+	"time"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("replace_failed_process_groups", func() {
+	now := time.Now()
+
 	DescribeTable("check if removal is allowed", func(cluster *fdbv1beta2.FoundationDBCluster, maxReplacements int, faultDomainsWithReplacements map[fdbv1beta2.FaultDomain]fdbv1beta2.None, faultDomain fdbv1beta2.FaultDomain, expected bool) {
 		Expect(removalAllowed(cluster, maxReplacements, faultDomainsWithReplacements, faultDomain)).To(Equal(expected))
 	},
@@ -260,4 +265,123 @@ var _ = Describe("replace_failed_process_groups", func() {
 			true,
 		),
 	)
+
+	DescribeTable("check if a replacement in a fault domain is allowed", func(replacements fdbv1beta2.AutomaticReplacementOptions, ongoingReplacements map[fdbv1beta2.FaultDomain]int, lastRemovals map[fdbv1beta2.FaultDomain]time.Time, faultDomain fdbv1beta2.FaultDomain, expected string) {
+		cluster := &fdbv1beta2.FoundationDBCluster{
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+					Replacements: replacements,
+				},
+			},
+		}
+
+		Expect(faultDomainReplacementAllowed(cluster, ongoingReplacements, lastRemovals, faultDomain, now)).To(Equal(expected))
+	},
+		Entry("no limits are defined",
+			fdbv1beta2.AutomaticReplacementOptions{},
+			map[fdbv1beta2.FaultDomain]int{"zone1": 5},
+			map[fdbv1beta2.FaultDomain]time.Time{"zone1": now},
+			fdbv1beta2.FaultDomain("zone1"),
+			"",
+		),
+		Entry("the limit per fault domain is reached",
+			fdbv1beta2.AutomaticReplacementOptions{
+				MaxConcurrentReplacementsPerFaultDomain: pointer.Int(1),
+			},
+			map[fdbv1beta2.FaultDomain]int{"zone1": 1},
+			nil,
+			fdbv1beta2.FaultDomain("zone1"),
+			"1 ongoing replacements, limit is 1",
+		),
+		Entry("the limit per fault domain is reached in another fault domain",
+			fdbv1beta2.AutomaticReplacementOptions{
+				MaxConcurrentReplacementsPerFaultDomain: pointer.Int(1),
+			},
+			map[fdbv1beta2.FaultDomain]int{"zone2": 1},
+			nil,
+			fdbv1beta2.FaultDomain("zone1"),
+			"",
+		),
+		Entry("the cool-down has not passed",
+			fdbv1beta2.AutomaticReplacementOptions{
+				FaultDomainReplacementCooldownSeconds: pointer.Int(600),
+			},
+			nil,
+			map[fdbv1beta2.FaultDomain]time.Time{"zone1": now.Add(-5 * time.Minute)},
+			fdbv1beta2.FaultDomain("zone1"),
+			"cool-down after the last replacement, remaining: 5m0s",
+		),
+		Entry("the cool-down has passed",
+			fdbv1beta2.AutomaticReplacementOptions{
+				FaultDomainReplacementCooldownSeconds: pointer.Int(600),
+			},
+			nil,
+			map[fdbv1beta2.FaultDomain]time.Time{"zone1": now.Add(-15 * time.Minute)},
+			fdbv1beta2.FaultDomain("zone1"),
+			"",
+		),
+		Entry("the process group has no fault domain",
+			fdbv1beta2.AutomaticReplacementOptions{
+				MaxConcurrentReplacementsPerFaultDomain: pointer.Int(1),
+			},
+			map[fdbv1beta2.FaultDomain]int{"": 1},
+			nil,
+			fdbv1beta2.FaultDomain(""),
+			"",
+		),
+	)
+
+	DescribeTable("updating the last replacements per fault domain", func(cooldownSeconds *int, lastReplacements map[fdbv1beta2.FaultDomain]metav1.Time, faultDomain fdbv1beta2.FaultDomain, expected map[fdbv1beta2.FaultDomain]metav1.Time) {
+		cluster := &fdbv1beta2.FoundationDBCluster{
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+					Replacements: fdbv1beta2.AutomaticReplacementOptions{
+						FaultDomainReplacementCooldownSeconds: cooldownSeconds,
+					},
+				},
+			},
+			Status: fdbv1beta2.FoundationDBClusterStatus{
+				LastFaultDomainReplacements: lastReplacements,
+			},
+		}
+
+		updateLastFaultDomainReplacements(cluster, faultDomain, now, now)
+		Expect(cluster.Status.LastFaultDomainReplacements).To(Equal(expected))
+
+		_, lastRemovals := getFaultDomainReplacementInformation(cluster)
+		for key, value := range expected {
+			Expect(lastRemovals).To(HaveKeyWithValue(key, value.Time))
+		}
+	},
+		Entry("no cool-down is defined",
+			nil,
+			map[fdbv1beta2.FaultDomain]metav1.Time{"zone2": metav1.NewTime(now.Add(-time.Minute))},
+			fdbv1beta2.FaultDomain("zone1"),
+			nil,
+		),
+		Entry("a cool-down is defined",
+			pointer.Int(600),
+			nil,
+			fdbv1beta2.FaultDomain("zone1"),
+			map[fdbv1beta2.FaultDomain]metav1.Time{"zone1": metav1.NewTime(now)},
+		),
+		Entry("a cool-down is defined and another fault domain is in the cool-down",
+			pointer.Int(600),
+			map[fdbv1beta2.FaultDomain]metav1.Time{"zone2": metav1.NewTime(now.Add(-time.Minute))},
+			fdbv1beta2.FaultDomain("zone1"),
+			map[fdbv1beta2.FaultDomain]metav1.Time{"zone1": metav1.NewTime(now), "zone2": metav1.NewTime(now.Add(-time.Minute))},
+		),
+		Entry("a cool-down is defined and the cool-down of another fault domain has passed",
+			pointer.Int(600),
+			map[fdbv1beta2.FaultDomain]metav1.Time{"zone2": metav1.NewTime(now.Add(-15 * time.Minute))},
+			fdbv1beta2.FaultDomain("zone1"),
+			map[fdbv1beta2.FaultDomain]metav1.Time{"zone1": metav1.NewTime(now)},
+		),
+		Entry("the process group has no fault domain",
+			pointer.Int(600),
+			nil,
+			fdbv1beta2.FaultDomain(""),
+			nil,
+		),
+	)
 })
@@ -49,7 +49,7 @@ func ReplaceMisconfiguredProcessGroups(ctx context.Context, podManager podmanage
 			break
 		}
 
-		if processGroup.IsMarkedForRemoval() {
+		if processGroup.IsMarkedForRemoval() || cluster.IgnoreProcessGroupForAutomation(processGroup) {
 			continue
 		}
 
@@ -72,6 +72,13 @@ func ReplaceMisconfiguredProcessGroups(ctx context.Context, podManager podmanage
 
 // ProcessGroupNeedsRemoval checks if a process group needs to be removed.
 func ProcessGroupNeedsRemoval(ctx context.Context, podManager podmanager.PodLifecycleManager, client client.Client, log logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus, pvcMap map[fdbv1beta2.ProcessGroupID]corev1.PersistentVolumeClaim) (bool, error) {
+	if cluster.Status.StorageEngineMigration.IsPending(processGroup.ProcessGroupID) {
+		log.Info("Replace process group",
+			"processGroupID", processGroup.ProcessGroupID,
+			"reason", fmt.Sprintf("storage engine is migrated from %s to %s", cluster.Status.StorageEngineMigration.From, cluster.Status.StorageEngineMigration.To))
+		return true, nil
+	}
+
 	// TODO(johscheuer): Fix how we fetch the pvc to make better use of the controller runtime cache.
 	pvc, hasPVC := pvcMap[processGroup.ProcessGroupID]
 	pod, podErr := podManager.GetPod(ctx, client, cluster, processGroup.GetPodName(cluster))
@@ -171,6 +178,17 @@ func processGroupNeedsRemovalForPod(cluster *fdbv1beta2.FoundationDBCluster, pod
 			"reason", fmt.Sprintf("publicIP source has changed from %s to %s", ipSource, cluster.GetPublicIPSource()))
 		return true, nil
 	}
+
+	portBase, err := internal.GetProcessPortBase(pod)
+	if err != nil {
+		return false, err
+	}
+	if portBase != cluster.GetProcessPortBase() {
+		logger.Info("Replace process group",
+			"reason", fmt.Sprintf("process port base has changed from %d to %d", portBase, cluster.GetProcessPortBase()))
+		return true, nil
+	}
+
 	serversPerPod, err := internal.GetServersPerPodForPod(pod, processGroupStatus.ProcessClass)
 	if err != nil {
 		return false, err
@@ -225,6 +243,12 @@ func processGroupNeedsRemovalForPod(cluster *fdbv1beta2.FoundationDBCluster, pod
 			)
 			return true, nil
 		}
+
+		if cluster.ShouldReplaceMismatchedImages() && processGroupStatus.GetConditionTime(fdbv1beta2.IncorrectImage) != nil {
+			logger.Info("Replace process group",
+				"reason", "Pod is running a mismatched image")
+			return true, nil
+		}
 	}
 
 	if pointer.BoolDeref(cluster.Spec.ReplaceInstancesWhenResourcesChange, false) {
@@ -202,6 +202,24 @@ var _ = Describe("replace_misconfigured_pods", func() {
 			})
 		})
 
+		When("the process port base changes", func() {
+			BeforeEach(func() {
+				pClass = fdbv1beta2.ProcessClassStorage
+				remove = false
+			})
+
+			It("should need a removal", func() {
+				needsRemoval, err := processGroupNeedsRemovalForPod(cluster, pod, processGroup, log)
+				Expect(needsRemoval).To(BeFalse())
+				Expect(err).NotTo(HaveOccurred())
+
+				cluster.Spec.Routing.ProcessPortBase = pointer.Int(5001)
+				needsRemoval, err = processGroupNeedsRemovalForPod(cluster, pod, processGroup, log)
+				Expect(needsRemoval).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
 		Context("when the public IP source is set to default", func() {
 			BeforeEach(func() {
 				pClass = fdbv1beta2.ProcessClassStorage
@@ -389,6 +407,43 @@ var _ = Describe("replace_misconfigured_pods", func() {
 			})
 		})
 
+		Context("when a transaction process group is running a mismatched image", func() {
+			var status *fdbv1beta2.ProcessGroupStatus
+			var pod *corev1.Pod
+
+			BeforeEach(func() {
+				err := internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{UseFutureDefaults: true})
+				Expect(err).NotTo(HaveOccurred())
+				status = &fdbv1beta2.ProcessGroupStatus{
+					ProcessGroupID: fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%d", fdbv1beta2.ProcessClassLog, 1337)),
+					ProcessClass:   fdbv1beta2.ProcessClassLog,
+				}
+				pod, err = internal.GetPod(cluster, status)
+				Expect(err).NotTo(HaveOccurred())
+				status.UpdateCondition(fdbv1beta2.IncorrectImage, true)
+			})
+
+			When("replacing mismatched images is disabled", func() {
+				It("should not need a removal", func() {
+					needsRemoval, err := processGroupNeedsRemovalForPod(cluster, pod, status, log)
+					Expect(needsRemoval).To(BeFalse())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("replacing mismatched images is enabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.ReplaceMismatchedImages = pointer.Bool(true)
+				})
+
+				It("should need a removal", func() {
+					needsRemoval, err := processGroupNeedsRemovalForPod(cluster, pod, status, log)
+					Expect(needsRemoval).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 		Context("when the memory resources are changed", func() {
 			var status *fdbv1beta2.ProcessGroupStatus
 			var pod *corev1.Pod
@@ -697,6 +752,42 @@ var _ = Describe("replace_misconfigured_pods", func() {
 			})
 		})
 
+		When("test process groups should be ignored", func() {
+			var testProcessGroupID fdbv1beta2.ProcessGroupID
+
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.IgnoreTestProcessGroups = pointer.Bool(true)
+				_, testProcessGroupID = cluster.GetProcessGroupID(fdbv1beta2.ProcessClassTest, 1)
+				processGroup := &fdbv1beta2.ProcessGroupStatus{
+					ProcessClass:   fdbv1beta2.ProcessClassTest,
+					ProcessGroupID: testProcessGroupID,
+				}
+
+				newPod, err := internal.GetPod(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Create(context.Background(), newPod)).NotTo(HaveOccurred())
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, fdbv1beta2.NewProcessGroupStatus(testProcessGroupID, fdbv1beta2.ProcessClassTest, nil))
+			})
+
+			It("should replace all process groups except the test process group", func() {
+				hasReplacement, err := ReplaceMisconfiguredProcessGroups(context.Background(), podmanager.StandardPodLifecycleManager{}, k8sClient, log, cluster, pvcMap)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacement).To(BeTrue())
+
+				cntReplacements := 0
+				for _, pGroup := range cluster.Status.ProcessGroups {
+					if !pGroup.IsMarkedForRemoval() {
+						continue
+					}
+
+					Expect(pGroup.ProcessGroupID).NotTo(Equal(testProcessGroupID))
+					cntReplacements++
+				}
+
+				Expect(cntReplacements).To(BeNumerically("==", len(cluster.Status.ProcessGroups)-1))
+			})
+		})
+
 		When("the image doesn't match with the desired image", func() {
 			BeforeEach(func() {
 				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{}
@@ -274,6 +274,9 @@ func analyzeCluster(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1
 			removedProcessGroups[processGroup.ProcessGroupID] = fdbv1beta2.None{}
 			if !ignoreRemovals {
 				statement := fmt.Sprintf("ProcessGroup: %s is marked for removal, excluded state: %t", processGroup.ProcessGroupID, processGroup.IsExcluded())
+				if exclusionDuration := processGroup.GetExclusionDuration(); exclusionDuration > 0 {
+					statement += fmt.Sprintf(", exclusion duration: %s", exclusionDuration.Round(time.Second).String())
+				}
 				printStatement(cmd, statement, warnMessage)
 			}
 
@@ -251,6 +251,30 @@ var _ = Describe("[plugin] analyze cluster", func() {
 ✔ Cluster is fully replicated
 ✔ Cluster is reconciled
 ✔ ProcessGroups are all in ready condition
+✔ Pods are all running and available`,
+					AutoFix:        false,
+					HasErrors:      false,
+					IgnoreRemovals: false,
+				}),
+			Entry("ProcessGroup is marked for removal and the exclusion is completed",
+				testCase{
+					cluster: getCluster(clusterName, namespace, true, true, true, 1, []*fdbv1beta2.ProcessGroupStatus{
+						{
+							ProcessGroupID:            "storage-1",
+							RemovalTimestamp:          &metav1.Time{Time: time.Now()},
+							ExclusionStartedTimestamp: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+							ExclusionTimestamp:        &metav1.Time{Time: time.Now().Add(-1 * time.Hour)},
+						},
+					}),
+					podList: getPodList(clusterName, namespace, corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					}, nil),
+					ExpectedErrMsg: "⚠ ProcessGroup: storage-1 is marked for removal, excluded state: true, exclusion duration: 1h0m0s",
+					ExpectedStdoutMsg: `Checking cluster: test/test
+✔ Cluster is available
+✔ Cluster is fully replicated
+✔ Cluster is reconciled
+✔ ProcessGroups are all in ready condition
 ✔ Pods are all running and available`,
 					AutoFix:        false,
 					HasErrors:      false,
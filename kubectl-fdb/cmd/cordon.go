@@ -21,8 +21,10 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -60,6 +62,22 @@ func newCordonCmd(streams genericclioptions.IOStreams) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			customLabels, err := cmd.Flags().GetStringSlice("custom-labels")
+			if err != nil {
+				return err
+			}
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+
+			if output != "" && output != "json" {
+				return fmt.Errorf("unsupported output format: %s, only json is supported", output)
+			}
 
 			kubeClient, err := getKubeClient(cmd.Context(), o)
 			if err != nil {
@@ -71,20 +89,16 @@ func newCordonCmd(streams genericclioptions.IOStreams) *cobra.Command {
 				return err
 			}
 
-			if len(nodeSelector) != 0 && len(args) != 0 {
-				return fmt.Errorf("it's not allowed to use the node-selector and pass nodes")
+			nodes, err := getNodesFromArgs(kubeClient, nodeSelector, args)
+			if err != nil {
+				return err
 			}
 
-			if len(nodeSelector) != 0 {
-				nodes, err := getNodes(kubeClient, nodeSelector)
-				if err != nil {
-					return err
-				}
-
-				return cordonNode(cmd, kubeClient, clusterName, nodes, namespace, withExclusion, wait, clusterLabel)
+			if dryRun {
+				return cordonNodeDryRun(cmd, kubeClient, clusterName, nodes, namespace, withExclusion, clusterLabel, customLabels, output)
 			}
 
-			return cordonNode(cmd, kubeClient, clusterName, args, namespace, withExclusion, wait, clusterLabel)
+			return cordonNode(cmd, kubeClient, clusterName, nodes, namespace, withExclusion, wait, clusterLabel, customLabels)
 		},
 		Example: `
 # Evacuate all process groups for a cluster in the current namespace that are hosted on node-1
@@ -107,6 +121,9 @@ kubectl fdb cordon -c cluster --node-selector machine=a,disk=fast
 
 # Evacuate all process groups in the current namespace that are hosted on nodes with the labels machine=a,disk=fast with cluster-label
 kubectl fdb cordon --node-selector machine=a,disk=fast -l fdb-cluster-label
+
+# Print the process groups that would be added to the remove list of the clusters without modifying the clusters
+kubectl fdb cordon --dry-run --output json node-1
 `,
 	}
 	cmd.SetOut(o.Out)
@@ -117,13 +134,16 @@ kubectl fdb cordon --node-selector machine=a,disk=fast -l fdb-cluster-label
 	cmd.Flags().StringToStringVarP(&nodeSelectors, "node-selector", "", nil, "node-selector to select all nodes that should be cordoned. Can't be used with specific nodes.")
 	cmd.Flags().BoolP("exclusion", "e", true, "define if the process groups should be removed with exclusion.")
 	cmd.Flags().StringP("cluster-label", "l", fdbv1beta2.FDBClusterLabel, "cluster label to fetch the appropriate Pods and identify the according cluster.")
+	cmd.Flags().StringSlice("custom-labels", nil, "additional labels to identify the according cluster of a Pod, if the cluster label is not present. If none of the labels is present, the cluster will be identified by the owner reference of the Pod.")
+	cmd.Flags().Bool("dry-run", false, "only print the process groups that would be added to the remove list of each cluster, without modifying the clusters.")
+	cmd.Flags().String("output", "", "output format of the dry run. Supported values: json. If empty, a human readable output will be printed.")
 	o.configFlags.AddFlags(cmd.Flags())
 
 	return cmd
 }
 
 // cordonNode gets all process groups of this cluster that run on the given nodes and add them to the remove list
-func cordonNode(cmd *cobra.Command, kubeClient client.Client, inputClusterName string, nodes []string, namespace string, withExclusion bool, wait bool, clusterLabel string) error {
+func cordonNode(cmd *cobra.Command, kubeClient client.Client, inputClusterName string, nodes []string, namespace string, withExclusion bool, wait bool, clusterLabel string, customLabels []string) error {
 	cmd.Printf("Starting to cordon %d nodes\n", len(nodes))
 	if len(nodes) == 0 {
 		return errors.New("no nodes were provided for cordoning")
@@ -131,29 +151,23 @@ func cordonNode(cmd *cobra.Command, kubeClient client.Client, inputClusterName s
 
 	var totalRemoved int
 
+	selectionOpts := processGroupSelectionOptions{
+		namespace:    namespace,
+		clusterName:  inputClusterName,
+		clusterLabel: clusterLabel,
+		customLabels: customLabels,
+	}
+
 	for _, node := range nodes {
-		pods, err := fetchPodsOnNode(kubeClient, inputClusterName, namespace, node, clusterLabel)
+		podNames, err := getPodNamesOnNode(kubeClient, inputClusterName, namespace, node, selectionOpts.getClusterLabels())
 		if err != nil {
-			return fmt.Errorf("issue fetching Pods running on node %s. Error: %w", node, err)
-		}
-		if len(pods.Items) == 0 {
-			return fmt.Errorf("no pods were found that were running on node %s", node)
-		}
-		var podNames []string
-		for _, pod := range pods.Items {
-			podNames = append(podNames, pod.Name)
+			return err
 		}
 
 		cmd.Printf("\nCordoning node: %s\n", node)
+		selectionOpts.ids = podNames
 		removedFromNode, err := replaceProcessGroups(cmd, kubeClient,
-			processGroupSelectionOptions{
-				ids:               podNames,
-				namespace:         namespace,
-				clusterName:       inputClusterName,
-				clusterLabel:      clusterLabel,
-				processClass:      "",
-				useProcessGroupID: false,
-			},
+			selectionOpts,
 			replaceProcessGroupsOptions{
 				withExclusion:   withExclusion,
 				wait:            wait,
@@ -167,3 +181,135 @@ func cordonNode(cmd *cobra.Command, kubeClient client.Client, inputClusterName s
 	cmd.Printf("\nCompleted removal of %d Pods\n", totalRemoved)
 	return nil
 }
+
+// getNodesFromArgs returns the nodes that match the node selector, or the nodes passed as arguments if no node selector
+// is defined. Defining both is not allowed.
+func getNodesFromArgs(kubeClient client.Client, nodeSelector map[string]string, args []string) ([]string, error) {
+	if len(nodeSelector) != 0 && len(args) != 0 {
+		return nil, fmt.Errorf("it's not allowed to use the node-selector and pass nodes")
+	}
+
+	if len(nodeSelector) != 0 {
+		return getNodes(kubeClient, nodeSelector)
+	}
+
+	return args, nil
+}
+
+// getPodNamesOnNode returns the names of all Pods of the targeted clusters that run on the given node.
+func getPodNamesOnNode(kubeClient client.Client, inputClusterName string, namespace string, node string, clusterLabels []string) ([]string, error) {
+	pods, err := fetchPodsOnNode(kubeClient, inputClusterName, namespace, node, clusterLabels)
+	if err != nil {
+		return nil, fmt.Errorf("issue fetching Pods running on node %s. Error: %w", node, err)
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no pods were found that were running on node %s", node)
+	}
+
+	podNames := make([]string, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		podNames = append(podNames, pod.Name)
+	}
+
+	return podNames, nil
+}
+
+// cordonDryRunResult describes the process groups of a cluster that would be added to the remove list by the cordon
+// command.
+type cordonDryRunResult struct {
+	Namespace       string                      `json:"namespace"`
+	ClusterName     string                      `json:"clusterName"`
+	ProcessGroupIDs []fdbv1beta2.ProcessGroupID `json:"processGroupIDs"`
+	WithExclusion   bool                        `json:"withExclusion"`
+}
+
+// cordonNodeDryRun resolves the process groups that run on the given nodes in the same way as cordonNode, but only
+// prints the process groups that would be added to the remove list of each cluster without modifying the clusters.
+func cordonNodeDryRun(cmd *cobra.Command, kubeClient client.Client, inputClusterName string, nodes []string, namespace string, withExclusion bool, clusterLabel string, customLabels []string, output string) error {
+	if len(nodes) == 0 {
+		return errors.New("no nodes were provided for cordoning")
+	}
+
+	selectionOpts := processGroupSelectionOptions{
+		namespace:    namespace,
+		clusterName:  inputClusterName,
+		clusterLabel: clusterLabel,
+		customLabels: customLabels,
+	}
+
+	resultsByCluster := map[string]*cordonDryRunResult{}
+	for _, node := range nodes {
+		podNames, err := getPodNamesOnNode(kubeClient, inputClusterName, namespace, node, selectionOpts.getClusterLabels())
+		if err != nil {
+			return err
+		}
+
+		selectionOpts.ids = podNames
+		processGroupsByCluster, err := getProcessGroupsByCluster(cmd, kubeClient, selectionOpts)
+		if err != nil {
+			return fmt.Errorf("unable to resolve the process groups running on node %s. Error: %w", node, err)
+		}
+
+		for cluster, processGroupIDs := range processGroupsByCluster {
+			result, ok := resultsByCluster[cluster.Name]
+			if !ok {
+				result = &cordonDryRunResult{
+					Namespace:       namespace,
+					ClusterName:     cluster.Name,
+					ProcessGroupIDs: []fdbv1beta2.ProcessGroupID{},
+					WithExclusion:   withExclusion,
+				}
+				resultsByCluster[cluster.Name] = result
+			}
+
+			// Process groups that are already in the remove list won't be added again.
+			skip := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+			removals := cluster.Spec.ProcessGroupsToRemove
+			if !withExclusion {
+				removals = cluster.Spec.ProcessGroupsToRemoveWithoutExclusion
+			}
+			for _, processGroupID := range removals {
+				skip[processGroupID] = fdbv1beta2.None{}
+			}
+			for _, processGroupID := range result.ProcessGroupIDs {
+				skip[processGroupID] = fdbv1beta2.None{}
+			}
+
+			for _, processGroupID := range processGroupIDs {
+				if _, ok := skip[processGroupID]; ok {
+					continue
+				}
+
+				result.ProcessGroupIDs = append(result.ProcessGroupIDs, processGroupID)
+			}
+		}
+	}
+
+	results := make([]cordonDryRunResult, 0, len(resultsByCluster))
+	for _, result := range resultsByCluster {
+		sort.Slice(result.ProcessGroupIDs, func(i, j int) bool {
+			return result.ProcessGroupIDs[i] < result.ProcessGroupIDs[j]
+		})
+		results = append(results, *result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ClusterName < results[j].ClusterName
+	})
+
+	if output == "json" {
+		content, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		cmd.Println(string(content))
+		return nil
+	}
+
+	for _, result := range results {
+		cmd.Printf("Cluster %v/%v:\n", result.Namespace, result.ClusterName)
+		cmd.Printf("would remove %v (exclude: %t)\n", result.ProcessGroupIDs, result.WithExclusion)
+	}
+
+	return nil
+}
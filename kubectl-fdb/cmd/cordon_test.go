@@ -21,12 +21,15 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -58,7 +61,7 @@ var _ = Describe("[plugin] cordon command", func() {
 		DescribeTable("should cordon all targeted processes",
 			func(input testCase) {
 				cmd := newCordonCmd(genericclioptions.IOStreams{})
-				err := cordonNode(cmd, k8sClient, input.clusterName, input.nodes, namespace, input.WithExclusion, false, input.clusterLabel)
+				err := cordonNode(cmd, k8sClient, input.clusterName, input.nodes, namespace, input.WithExclusion, false, input.clusterLabel, nil)
 				if input.wantErrorContains != "" {
 					Expect(err).To(Not(BeNil()))
 					Expect(err.Error()).To(ContainSubstring(input.wantErrorContains))
@@ -201,6 +204,154 @@ var _ = Describe("[plugin] cordon command", func() {
 				}),
 		)
 	})
+
+	When("the Pods use a custom cluster label", func() {
+		var customCluster *fdbv1beta2.FoundationDBCluster
+		customClusterName := "custom"
+		customClusterLabel := "custom-cluster-name"
+
+		BeforeEach(func() {
+			customCluster = generateClusterStruct(customClusterName, namespace)
+			customCluster.Spec.LabelConfig.MatchLabels = map[string]string{customClusterLabel: customClusterName}
+			Expect(k8sClient.Create(context.TODO(), customCluster)).NotTo(HaveOccurred())
+
+			// The first Pod only has the custom label, the second Pod can only be identified by the owner reference.
+			pods := []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s-%s-1", customClusterName, fdbv1beta2.ProcessClassStorage),
+						Namespace: namespace,
+						Labels: map[string]string{
+							customClusterLabel: customClusterName,
+						},
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "node-1",
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s-%s-2", customClusterName, fdbv1beta2.ProcessClassStorage),
+						Namespace: namespace,
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: fdbv1beta2.GroupVersion.String(),
+								Kind:       "FoundationDBCluster",
+								Name:       customClusterName,
+							},
+						},
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "node-2",
+					},
+				},
+			}
+
+			for _, pod := range pods {
+				Expect(k8sClient.Create(context.TODO(), &pod)).NotTo(HaveOccurred())
+			}
+		})
+
+		DescribeTable("should cordon the process groups of the cluster",
+			func(nodes []string, customLabels []string, expected []fdbv1beta2.ProcessGroupID) {
+				cmd := newCordonCmd(genericclioptions.IOStreams{})
+				Expect(cordonNode(cmd, k8sClient, "", nodes, namespace, true, false, fdbv1beta2.FDBClusterLabel, customLabels)).NotTo(HaveOccurred())
+
+				var resCluster fdbv1beta2.FoundationDBCluster
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: customClusterName}, &resCluster)).NotTo(HaveOccurred())
+				Expect(resCluster.Spec.ProcessGroupsToRemove).To(ConsistOf(expected))
+			},
+			Entry("the custom label is passed",
+				[]string{"node-1"},
+				[]string{"custom-cluster-name"},
+				[]fdbv1beta2.ProcessGroupID{"custom-storage-1"}),
+			Entry("the cluster is identified by the owner reference",
+				[]string{"node-2"},
+				nil,
+				[]fdbv1beta2.ProcessGroupID{"custom-storage-2"}),
+		)
+	})
+
+	When("running cordon command in dry-run mode", func() {
+		var outBuffer bytes.Buffer
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			Expect(createPods(clusterName, namespace)).NotTo(HaveOccurred())
+
+			secondCluster = generateClusterStruct(secondClusterName, namespace)
+			Expect(k8sClient.Create(context.TODO(), secondCluster)).NotTo(HaveOccurred())
+			Expect(createPods(secondClusterName, namespace)).NotTo(HaveOccurred())
+
+			outBuffer = bytes.Buffer{}
+			cmd = newCordonCmd(genericclioptions.IOStreams{Out: &outBuffer, ErrOut: &bytes.Buffer{}, In: &bytes.Buffer{}})
+		})
+
+		When("printing the result as json", func() {
+			var results []cordonDryRunResult
+
+			JustBeforeEach(func() {
+				Expect(cordonNodeDryRun(cmd, k8sClient, "", []string{"node-1", "node-2"}, namespace, true, fdbv1beta2.FDBClusterLabel, nil, "json")).NotTo(HaveOccurred())
+				Expect(json.Unmarshal(outBuffer.Bytes(), &results)).NotTo(HaveOccurred())
+			})
+
+			It("should print the process groups per cluster", func() {
+				Expect(results).To(Equal([]cordonDryRunResult{
+					{
+						Namespace:   namespace,
+						ClusterName: clusterName,
+						ProcessGroupIDs: []fdbv1beta2.ProcessGroupID{
+							fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-1", clusterName, fdbv1beta2.ProcessClassStorage)),
+							fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-2", clusterName, fdbv1beta2.ProcessClassStorage)),
+						},
+						WithExclusion: true,
+					},
+					{
+						Namespace:   namespace,
+						ClusterName: secondClusterName,
+						ProcessGroupIDs: []fdbv1beta2.ProcessGroupID{
+							fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-1", secondClusterName, fdbv1beta2.ProcessClassStorage)),
+							fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-2", secondClusterName, fdbv1beta2.ProcessClassStorage)),
+						},
+						WithExclusion: true,
+					},
+				}))
+			})
+
+			It("should not modify the clusters", func() {
+				for _, name := range []string{clusterName, secondClusterName} {
+					var resCluster fdbv1beta2.FoundationDBCluster
+					Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name}, &resCluster)).NotTo(HaveOccurred())
+					Expect(resCluster.Spec.ProcessGroupsToRemove).To(BeEmpty())
+					Expect(resCluster.Spec.ProcessGroupsToRemoveWithoutExclusion).To(BeEmpty())
+				}
+			})
+		})
+
+		When("a process group is already in the remove list", func() {
+			BeforeEach(func() {
+				cluster.Spec.ProcessGroupsToRemoveWithoutExclusion = []fdbv1beta2.ProcessGroupID{
+					fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-1", clusterName, fdbv1beta2.ProcessClassStorage)),
+				}
+			})
+
+			JustBeforeEach(func() {
+				Expect(cordonNodeDryRun(cmd, k8sClient, clusterName, []string{"node-1", "node-2"}, namespace, false, "", nil, "")).NotTo(HaveOccurred())
+			})
+
+			It("should only print the process groups that would be added", func() {
+				Expect(outBuffer.String()).To(Equal(fmt.Sprintf("Cluster %s/%s:\nwould remove [%s-%s-2] (exclude: false)\n", namespace, clusterName, clusterName, fdbv1beta2.ProcessClassStorage)))
+			})
+		})
+
+		When("no pods are running on the node", func() {
+			It("should return an error", func() {
+				err := cordonNodeDryRun(cmd, k8sClient, clusterName, []string{"node-4"}, namespace, true, "", nil, "json")
+				Expect(err).To(MatchError(ContainSubstring("no pods were found that were running on node node-4")))
+				Expect(outBuffer.String()).To(BeEmpty())
+			})
+		})
+	})
 })
 
 func createPods(clusterName string, namespace string) error {
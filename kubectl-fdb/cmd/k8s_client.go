@@ -362,8 +362,8 @@ func getPodsMatchingLabels(kubeClient client.Client, cluster *fdbv1beta2.Foundat
 }
 
 // fetchProcessGroupsCrossCluster fetches the list of process groups matching the given podNames and returns the
-// processGroupIDs mapped by clusterName matching the given clusterLabel.
-func fetchProcessGroupsCrossCluster(kubeClient client.Client, namespace string, clusterLabel string, podNames ...string) (map[*fdbv1beta2.FoundationDBCluster][]fdbv1beta2.ProcessGroupID, error) {
+// processGroupIDs mapped by clusterName matching the given clusterLabels.
+func fetchProcessGroupsCrossCluster(kubeClient client.Client, namespace string, clusterLabels []string, podNames ...string) (map[*fdbv1beta2.FoundationDBCluster][]fdbv1beta2.ProcessGroupID, error) {
 	var pod corev1.Pod
 	podsByClusterName := map[string][]string{} // start with grouping by cluster-label values and load clusters later
 	for _, podName := range podNames {
@@ -374,9 +374,9 @@ func fetchProcessGroupsCrossCluster(kubeClient client.Client, namespace string,
 			}
 			return nil, err
 		}
-		clusterName, ok := pod.Labels[clusterLabel]
-		if !ok {
-			return nil, fmt.Errorf("no cluster-label '%s' found for pod '%s'", clusterLabel, podName)
+		clusterName, err := getClusterNameFromPod(&pod, clusterLabels)
+		if err != nil {
+			return nil, err
 		}
 		podsByClusterName[clusterName] = append(podsByClusterName[clusterName], podName)
 	}
@@ -397,8 +397,8 @@ func fetchProcessGroupsCrossCluster(kubeClient client.Client, namespace string,
 	return processGroupsByCluster, nil
 }
 
-// fetchPodNamesCrossCluster fetches the given podNames and returns them mapped by clusterName from the given clusterLabel.
-func fetchPodNamesCrossCluster(kubeClient client.Client, namespace string, clusterLabel string, podNames ...string) (map[*fdbv1beta2.FoundationDBCluster][]string, error) {
+// fetchPodNamesCrossCluster fetches the given podNames and returns them mapped by clusterName from the given clusterLabels.
+func fetchPodNamesCrossCluster(kubeClient client.Client, namespace string, clusterLabels []string, podNames ...string) (map[*fdbv1beta2.FoundationDBCluster][]string, error) {
 	var pod corev1.Pod
 	podsByClusterName := map[string][]string{} // start with grouping by cluster-label values and load clusters later
 	for _, podName := range podNames {
@@ -409,9 +409,9 @@ func fetchPodNamesCrossCluster(kubeClient client.Client, namespace string, clust
 			}
 			return nil, err
 		}
-		clusterName, ok := pod.Labels[clusterLabel]
-		if !ok {
-			return nil, fmt.Errorf("no cluster-label '%s' found for pod '%s'", clusterLabel, podName)
+		clusterName, err := getClusterNameFromPod(&pod, clusterLabels)
+		if err != nil {
+			return nil, err
 		}
 		podsByClusterName[clusterName] = append(podsByClusterName[clusterName], podName)
 	}
@@ -446,36 +446,72 @@ func chooseRandomPod(pods *corev1.PodList) (*corev1.Pod, error) {
 	return candidate, nil
 }
 
-func fetchPodsOnNode(kubeClient client.Client, clusterName string, namespace string, node string, clusterLabel string) (corev1.PodList, error) {
+// fetchPodsOnNode fetches the Pods running on the given node. If no cluster name is provided, all Pods will be returned
+// that can be assigned to a cluster with the given clusterLabels or by their owner references.
+func fetchPodsOnNode(kubeClient client.Client, clusterName string, namespace string, node string, clusterLabels []string) (corev1.PodList, error) {
 	var pods corev1.PodList
 	var err error
 
-	var podLabelSelector client.ListOption
+	listOptions := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingFieldsSelector{
+			Selector: fields.OneTermEqualSelector("spec.nodeName", node),
+		},
+	}
 
-	if clusterName == "" {
-		podLabelSelector = client.HasLabels([]string{clusterLabel})
-	} else {
+	if clusterName != "" {
 		cluster, err := loadCluster(kubeClient, namespace, clusterName)
 		if err != nil {
 			return pods, fmt.Errorf("unable to load cluster: %s. Error: %w", clusterName, err)
 		}
 
-		podLabelSelector = client.MatchingLabels(cluster.GetMatchLabels())
+		listOptions = append(listOptions, client.MatchingLabels(cluster.GetMatchLabels()))
 	}
 
-	err = kubeClient.List(context.Background(), &pods,
-		client.InNamespace(namespace),
-		podLabelSelector,
-		client.MatchingFieldsSelector{
-			Selector: fields.OneTermEqualSelector("spec.nodeName", node),
-		})
+	err = kubeClient.List(context.Background(), &pods, listOptions...)
 	if err != nil {
 		return pods, fmt.Errorf("unable to fetch pods. Error: %w", err)
 	}
 
+	if clusterName != "" {
+		return pods, nil
+	}
+
+	// The cluster label can't be used as a label selector, as the clusters could use different labels. Pods that
+	// can't be assigned to a cluster will be ignored.
+	clusterPods := make([]corev1.Pod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if _, err := getClusterNameFromPod(&pod, clusterLabels); err != nil {
+			continue
+		}
+
+		clusterPods = append(clusterPods, pod)
+	}
+	pods.Items = clusterPods
+
 	return pods, nil
 }
 
+// getClusterNameFromPod returns the name of the cluster that the Pod belongs to. The value of the first label in
+// clusterLabels that is present on the Pod will be used. If none of the labels is present, the cluster will be
+// resolved from the owner references of the Pod.
+func getClusterNameFromPod(pod *corev1.Pod, clusterLabels []string) (string, error) {
+	for _, clusterLabel := range clusterLabels {
+		clusterName, ok := pod.Labels[clusterLabel]
+		if ok {
+			return clusterName, nil
+		}
+	}
+
+	for _, ownerReference := range pod.OwnerReferences {
+		if ownerReference.Kind == "FoundationDBCluster" {
+			return ownerReference.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no cluster-label '%s' found for pod '%s'", strings.Join(clusterLabels, ","), pod.Name)
+}
+
 // getPodNamesByCluster returns a map of pod names (strings) by FDB cluster that match the criteria in the provided
 // processSelectionOptions.
 func getPodNamesByCluster(cmd *cobra.Command, kubeClient client.Client, opts processGroupSelectionOptions) (map[*fdbv1beta2.FoundationDBCluster][]string, error) {
@@ -483,7 +519,7 @@ func getPodNamesByCluster(cmd *cobra.Command, kubeClient client.Client, opts pro
 		return nil, errors.New("useProcessGroupID is not supported by getPodNamesByCluster")
 	}
 	// option compatibility checks
-	if opts.clusterName == "" && opts.clusterLabel == "" {
+	if opts.clusterName == "" && len(opts.getClusterLabels()) == 0 {
 		return nil, errors.New("podNames will not be selected without cluster specification")
 	}
 	if opts.clusterName == "" { // cli has a default for clusterLabel
@@ -503,7 +539,7 @@ func getPodNamesByCluster(cmd *cobra.Command, kubeClient client.Client, opts pro
 
 	// cross-cluster logic: given a list of Pod names, we can look up the FDB clusters by pod label, and work across clusters
 	if !opts.useProcessGroupID && opts.clusterName == "" {
-		return fetchPodNamesCrossCluster(kubeClient, opts.namespace, opts.clusterLabel, opts.ids...)
+		return fetchPodNamesCrossCluster(kubeClient, opts.namespace, opts.getClusterLabels(), opts.ids...)
 	}
 
 	// single-cluster logic
@@ -545,7 +581,7 @@ func getPodNamesByCluster(cmd *cobra.Command, kubeClient client.Client, opts pro
 // processSelectionOptions.
 func getProcessGroupsByCluster(cmd *cobra.Command, kubeClient client.Client, opts processGroupSelectionOptions) (map[*fdbv1beta2.FoundationDBCluster][]fdbv1beta2.ProcessGroupID, error) {
 	// option compatibility checks
-	if opts.clusterName == "" && opts.clusterLabel == "" && len(opts.matchLabels) == 0 {
+	if opts.clusterName == "" && len(opts.getClusterLabels()) == 0 && len(opts.matchLabels) == 0 {
 		return nil, errors.New("processGroups will not be selected without cluster specification")
 	}
 	if opts.clusterName == "" { // cli has a default for clusterLabel
@@ -564,7 +600,7 @@ func getProcessGroupsByCluster(cmd *cobra.Command, kubeClient client.Client, opt
 
 	// cross-cluster logic: given a list of Pod names, we can look up the FDB clusters by pod label, and work across clusters
 	if !opts.useProcessGroupID && opts.clusterName == "" {
-		return fetchProcessGroupsCrossCluster(kubeClient, opts.namespace, opts.clusterLabel, opts.ids...)
+		return fetchProcessGroupsCrossCluster(kubeClient, opts.namespace, opts.getClusterLabels(), opts.ids...)
 	}
 
 	// single-cluster logic
@@ -841,4 +841,52 @@ var _ = Describe("[plugin] using the Kubernetes client", func() {
 			),
 		)
 	})
+
+	DescribeTable("getting the cluster name from a Pod",
+		func(labels map[string]string, ownerReferences []metav1.OwnerReference, clusterLabels []string, expected string, expectedErr string) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "storage-1",
+					Labels:          labels,
+					OwnerReferences: ownerReferences,
+				},
+			}
+
+			clusterName, err := getClusterNameFromPod(pod, clusterLabels)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(expectedErr))
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterName).To(Equal(expected))
+		},
+		Entry("the default cluster label is present",
+			map[string]string{fdbv1beta2.FDBClusterLabel: "test"},
+			nil,
+			[]string{fdbv1beta2.FDBClusterLabel, "custom-cluster-name"},
+			"test",
+			""),
+		Entry("only a custom cluster label is present",
+			map[string]string{"custom-cluster-name": "custom"},
+			nil,
+			[]string{fdbv1beta2.FDBClusterLabel, "custom-cluster-name"},
+			"custom",
+			""),
+		Entry("no label is present but the Pod is owned by a cluster",
+			nil,
+			[]metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "other"},
+				{Kind: "FoundationDBCluster", Name: "owner"},
+			},
+			[]string{fdbv1beta2.FDBClusterLabel},
+			"owner",
+			""),
+		Entry("no label is present and the Pod is not owned by a cluster",
+			nil,
+			[]metav1.OwnerReference{{Kind: "ReplicaSet", Name: "other"}},
+			[]string{fdbv1beta2.FDBClusterLabel, "custom-cluster-name"},
+			"",
+			fmt.Sprintf("no cluster-label '%s,custom-cluster-name' found for pod 'storage-1'", fdbv1beta2.FDBClusterLabel)),
+	)
 })
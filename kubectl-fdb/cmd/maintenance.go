@@ -0,0 +1,471 @@
+/*
+ * maintenance.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newMaintenanceCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Subcommand to manage the maintenance zone of a given cluster",
+		Long: "Subcommand to manage the maintenance zone of a given cluster. " +
+			"Supported options: set, clear, status.",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		Example: `
+kubectl fdb -n <namespace> maintenance <option> -c <cluster>
+
+# Set the maintenance zone zone-1 for 30 minutes for a cluster in the current namespace
+kubectl fdb maintenance set --zone zone-1 --duration 30m -c cluster
+
+# Clear the maintenance zone of a cluster in the current namespace
+kubectl fdb maintenance clear -c cluster
+
+# Show the current maintenance zone of a cluster in the current namespace
+kubectl fdb maintenance status -c cluster
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.AddCommand(
+		newMaintenanceSetCmd(streams),
+		newMaintenanceClearCmd(streams),
+		newMaintenanceStatusCmd(streams),
+	)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newMaintenanceSetCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Sets the maintenance zone for the given cluster",
+		Long:  "Sets the maintenance zone for the given cluster, the processes in this zone can be taken down without triggering data movement",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+			zone, err := cmd.Flags().GetString("zone")
+			if err != nil {
+				return err
+			}
+			duration, err := cmd.Flags().GetDuration("duration")
+			if err != nil {
+				return err
+			}
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			clientSet, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			return setMaintenanceZone(cmd, config, clientSet, kubeClient, clusterName, namespace, fdbv1beta2.FaultDomain(zone), duration, wait, force)
+		},
+		Example: `
+# Set the maintenance zone zone-1 for 30 minutes for a cluster in the current namespace
+kubectl fdb maintenance set --zone zone-1 --duration 30m -c cluster
+
+# Set the maintenance zone zone-1 for a cluster in the namespace default, even if the zone contains coordinators
+kubectl fdb -n default maintenance set --zone zone-1 --force -c cluster
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "sets the maintenance zone for the provided cluster.")
+	cmd.Flags().String("zone", "", "the zone ID that should be put under maintenance.")
+	cmd.Flags().Duration("duration", time.Hour, "the duration of the maintenance, FDB will reset the maintenance zone afterwards.")
+	cmd.Flags().Bool("force", false, "set the maintenance zone even if the zone contains coordinators.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = cmd.MarkFlagRequired("zone")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newMaintenanceClearCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Clears the maintenance zone of the given cluster",
+		Long:  "Clears the maintenance zone of the given cluster",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			clientSet, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			return clearMaintenanceZone(cmd, config, clientSet, kubeClient, clusterName, namespace, wait)
+		},
+		Example: `
+# Clear the maintenance zone of a cluster in the current namespace
+kubectl fdb maintenance clear -c cluster
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "clears the maintenance zone of the provided cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newMaintenanceStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows the maintenance zone of the given cluster",
+		Long:  "Shows the maintenance zone of the given cluster, the remaining time and the process groups in that zone",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			clientSet, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			return printMaintenanceStatus(cmd, config, clientSet, kubeClient, clusterName, namespace)
+		},
+		Example: `
+# Show the current maintenance zone of a cluster in the current namespace
+kubectl fdb maintenance status -c cluster
+`,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "shows the maintenance zone of the provided cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getClusterAndStatus loads the cluster and fetches the machine-readable status from a random Pod of the cluster. The
+// Pod is returned too, so the caller can issue further fdbcli commands from it.
+func getClusterAndStatus(restConfig *rest.Config, clientSet *kubernetes.Clientset, kubeClient client.Client, clusterName string, namespace string) (*fdbv1beta2.FoundationDBCluster, *fdbv1beta2.FoundationDBStatus, *corev1.Pod, error) {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pods, err := getPodsForCluster(kubeClient, cluster)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	clientPod, err := chooseRandomPod(pods)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	status, err := getStatus(restConfig, clientSet, clientPod)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return cluster, status, clientPod, nil
+}
+
+// setMaintenanceZone sets the maintenance zone for the provided cluster with fdbcli.
+//
+//nolint:interfacer // golint has a false-positive here -> `cmd` can be `github.com/hashicorp/go-retryablehttp.Logger`
+func setMaintenanceZone(cmd *cobra.Command, restConfig *rest.Config, clientSet *kubernetes.Clientset, kubeClient client.Client, clusterName string, namespace string, zone fdbv1beta2.FaultDomain, duration time.Duration, wait bool, force bool) error {
+	if zone == "" {
+		return fmt.Errorf("the zone for the maintenance must not be empty")
+	}
+
+	if duration < time.Second {
+		return fmt.Errorf("the duration of the maintenance must be at least one second, got %s", duration)
+	}
+
+	cluster, status, clientPod, err := getClusterAndStatus(restConfig, clientSet, kubeClient, clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	err = checkOperatorManagedMaintenance(cluster)
+	if err != nil {
+		return err
+	}
+
+	err = checkMaintenanceZoneCoordinators(status, zone, force)
+	if err != nil {
+		return err
+	}
+
+	if len(getProcessGroupsInZone(cluster, zone)) == 0 {
+		printStatement(cmd, fmt.Sprintf("no process groups found in zone %s", zone), warnMessage)
+	}
+
+	if wait {
+		confirmed := confirmAction(fmt.Sprintf("Set maintenance zone %s for %s in cluster %s/%s", zone, duration, namespace, clusterName))
+		if !confirmed {
+			return fmt.Errorf("user aborted the maintenance")
+		}
+	}
+
+	_, stderr, err := executeCmd(restConfig, clientSet, clientPod.Name, clientPod.Namespace, fmt.Sprintf("fdbcli --exec 'maintenance on %s %d'", zone, int64(duration.Seconds())))
+	if err != nil {
+		return fmt.Errorf("error setting maintenance zone %s: %s, %w", zone, stderr, err)
+	}
+
+	cmd.Printf("Set maintenance zone %s for %s in cluster %s/%s\n", zone, duration, namespace, clusterName)
+
+	return nil
+}
+
+// clearMaintenanceZone resets the maintenance zone of the provided cluster with fdbcli.
+//
+//nolint:interfacer // golint has a false-positive here -> `cmd` can be `github.com/hashicorp/go-retryablehttp.Logger`
+func clearMaintenanceZone(cmd *cobra.Command, restConfig *rest.Config, clientSet *kubernetes.Clientset, kubeClient client.Client, clusterName string, namespace string, wait bool) error {
+	_, status, clientPod, err := getClusterAndStatus(restConfig, clientSet, kubeClient, clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	zone := status.Cluster.MaintenanceZone
+	if zone == "" {
+		cmd.Printf("No maintenance zone is active in cluster %s/%s\n", namespace, clusterName)
+		return nil
+	}
+
+	if wait {
+		confirmed := confirmAction(fmt.Sprintf("Clear maintenance zone %s in cluster %s/%s", zone, namespace, clusterName))
+		if !confirmed {
+			return fmt.Errorf("user aborted the maintenance reset")
+		}
+	}
+
+	_, stderr, err := executeCmd(restConfig, clientSet, clientPod.Name, clientPod.Namespace, "fdbcli --exec 'maintenance off'")
+	if err != nil {
+		return fmt.Errorf("error clearing maintenance zone %s: %s, %w", zone, stderr, err)
+	}
+
+	cmd.Printf("Cleared maintenance zone %s in cluster %s/%s\n", zone, namespace, clusterName)
+
+	return nil
+}
+
+// printMaintenanceStatus prints the active maintenance zone of the provided cluster, the remaining time and the
+// process groups in that zone.
+//
+//nolint:interfacer // golint has a false-positive here -> `cmd` can be `github.com/hashicorp/go-retryablehttp.Logger`
+func printMaintenanceStatus(cmd *cobra.Command, restConfig *rest.Config, clientSet *kubernetes.Clientset, kubeClient client.Client, clusterName string, namespace string) error {
+	cluster, status, _, err := getClusterAndStatus(restConfig, clientSet, kubeClient, clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	printMaintenanceZone(cmd, cluster, status)
+
+	return nil
+}
+
+// printMaintenanceZone prints the maintenance information of the provided cluster based on the machine-readable status.
+func printMaintenanceZone(cmd *cobra.Command, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus) {
+	zone := status.Cluster.MaintenanceZone
+	if zone == "" {
+		cmd.Printf("No maintenance zone is active in cluster %s/%s\n", cluster.Namespace, cluster.Name)
+		return
+	}
+
+	remaining := time.Duration(status.Cluster.MaintenanceSecondsRemaining * float64(time.Second)).Round(time.Second)
+	cmd.Printf("Maintenance zone: %s\n", zone)
+	cmd.Printf("Remaining time: %s\n", remaining)
+	cmd.Println("Process groups in the maintenance zone:")
+	for _, processGroupID := range getProcessGroupsInZone(cluster, zone) {
+		cmd.Printf("\t%s\n", processGroupID)
+	}
+}
+
+// getProcessGroupsInZone returns the sorted IDs of the process groups that were last seen in the provided zone.
+func getProcessGroupsInZone(cluster *fdbv1beta2.FoundationDBCluster, zone fdbv1beta2.FaultDomain) []fdbv1beta2.ProcessGroupID {
+	var processGroupIDs []fdbv1beta2.ProcessGroupID
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.FaultDomain != zone {
+			continue
+		}
+
+		processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+	}
+
+	sort.Slice(processGroupIDs, func(i, j int) bool {
+		return processGroupIDs[i] < processGroupIDs[j]
+	})
+
+	return processGroupIDs
+}
+
+// checkOperatorManagedMaintenance returns an error if the operator manages the maintenance mode of the cluster. In this
+// case the operator resets every maintenance zone without processes under maintenance in its maintenance list, so a
+// maintenance zone set with fdbcli would be reset on the next reconciliation.
+func checkOperatorManagedMaintenance(cluster *fdbv1beta2.FoundationDBCluster) error {
+	if !cluster.ResetMaintenanceMode() {
+		return nil
+	}
+
+	return fmt.Errorf("the operator manages the maintenance mode of cluster %s/%s and would reset the maintenance zone, disable resetMaintenanceMode and UseMaintenanceModeChecker in the maintenanceModeOptions to set the maintenance zone manually", cluster.Namespace, cluster.Name)
+}
+
+// checkMaintenanceZoneCoordinators returns an error if any of the coordinators is running in the provided zone, unless
+// force is set. Coordinators are matched to the processes either by their address or by their DNS name.
+func checkMaintenanceZoneCoordinators(status *fdbv1beta2.FoundationDBStatus, zone fdbv1beta2.FaultDomain, force bool) error {
+	if force {
+		return nil
+	}
+
+	addresses := map[string]fdbv1beta2.None{}
+	for _, process := range status.Cluster.Processes {
+		if fdbv1beta2.FaultDomain(process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]) != zone {
+			continue
+		}
+
+		addresses[process.Address.MachineAddress()] = fdbv1beta2.None{}
+		if dnsName, ok := process.Locality[fdbv1beta2.FDBLocalityDNSNameKey]; ok {
+			addresses[dnsName] = fdbv1beta2.None{}
+		}
+	}
+
+	var coordinators []string
+	for _, coordinator := range status.Client.Coordinators.Coordinators {
+		if _, ok := addresses[coordinator.Address.MachineAddress()]; !ok {
+			continue
+		}
+
+		coordinators = append(coordinators, coordinator.Address.String())
+	}
+
+	if len(coordinators) > 0 {
+		return fmt.Errorf("zone %s contains the coordinators %v, use --force to set the maintenance zone anyway", zone, coordinators)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+ * maintenance_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("[plugin] maintenance command", func() {
+	var status *fdbv1beta2.FoundationDBStatus
+	var maintenanceCluster *fdbv1beta2.FoundationDBCluster
+
+	BeforeEach(func() {
+		status = &fdbv1beta2.FoundationDBStatus{
+			Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+				Coordinators: fdbv1beta2.FoundationDBStatusCoordinatorInfo{
+					Coordinators: []fdbv1beta2.FoundationDBStatusCoordinator{
+						{
+							Address: fdbv1beta2.ProcessAddress{StringAddress: "1.1.1.1", Port: 4501},
+						},
+					},
+				},
+			},
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"1": {
+						Address: fdbv1beta2.ProcessAddress{StringAddress: "1.1.1.1", Port: 4501},
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+							fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+						},
+					},
+					"2": {
+						Address: fdbv1beta2.ProcessAddress{StringAddress: "1.1.1.2", Port: 4501},
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: "storage-2",
+							fdbv1beta2.FDBLocalityZoneIDKey:     "zone-2",
+						},
+					},
+				},
+			},
+		}
+
+		maintenanceCluster = &fdbv1beta2.FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test",
+			},
+			Status: fdbv1beta2.FoundationDBClusterStatus{
+				ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+					{
+						ProcessGroupID: "storage-3",
+						FaultDomain:    "zone-2",
+					},
+					{
+						ProcessGroupID: "storage-1",
+						FaultDomain:    "zone-1",
+					},
+					{
+						ProcessGroupID: "storage-2",
+						FaultDomain:    "zone-2",
+					},
+				},
+			},
+		}
+	})
+
+	DescribeTable("checking the coordinators in the zone",
+		func(zone fdbv1beta2.FaultDomain, force bool, expectedErr string) {
+			err := checkMaintenanceZoneCoordinators(status, zone, force)
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+
+			Expect(err).To(MatchError(expectedErr))
+		},
+		Entry("the zone contains no coordinators",
+			fdbv1beta2.FaultDomain("zone-2"),
+			false,
+			""),
+		Entry("the zone contains a coordinator",
+			fdbv1beta2.FaultDomain("zone-1"),
+			false,
+			"zone zone-1 contains the coordinators [1.1.1.1:4501], use --force to set the maintenance zone anyway"),
+		Entry("the zone contains a coordinator and the maintenance is forced",
+			fdbv1beta2.FaultDomain("zone-1"),
+			true,
+			""),
+	)
+
+	When("the coordinators use DNS names", func() {
+		BeforeEach(func() {
+			status.Client.Coordinators.Coordinators[0].Address = fdbv1beta2.ProcessAddress{StringAddress: "storage-2.test.svc.cluster.local", Port: 4501}
+			process := status.Cluster.Processes["2"]
+			process.Locality[fdbv1beta2.FDBLocalityDNSNameKey] = "storage-2.test.svc.cluster.local"
+		})
+
+		It("should match the coordinator by the DNS name", func() {
+			Expect(checkMaintenanceZoneCoordinators(status, "zone-2", false)).To(HaveOccurred())
+			Expect(checkMaintenanceZoneCoordinators(status, "zone-1", false)).NotTo(HaveOccurred())
+		})
+	})
+
+	When("checking if the operator manages the maintenance mode", func() {
+		It("should allow the maintenance if the operator doesn't reset the maintenance mode", func() {
+			Expect(checkOperatorManagedMaintenance(maintenanceCluster)).NotTo(HaveOccurred())
+		})
+
+		It("should refuse the maintenance if the operator resets the maintenance mode", func() {
+			maintenanceCluster.Spec.AutomationOptions.MaintenanceModeOptions.ResetMaintenanceMode = pointer.Bool(true)
+			Expect(checkOperatorManagedMaintenance(maintenanceCluster)).To(MatchError("the operator manages the maintenance mode of cluster test/test and would reset the maintenance zone, disable resetMaintenanceMode and UseMaintenanceModeChecker in the maintenanceModeOptions to set the maintenance zone manually"))
+		})
+
+		It("should refuse the maintenance if the operator uses the maintenance mode checker", func() {
+			maintenanceCluster.Spec.AutomationOptions.MaintenanceModeOptions.UseMaintenanceModeChecker = pointer.Bool(true)
+			Expect(checkOperatorManagedMaintenance(maintenanceCluster)).To(HaveOccurred())
+		})
+	})
+
+	It("should return the sorted process groups in the zone", func() {
+		Expect(getProcessGroupsInZone(maintenanceCluster, "zone-2")).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2", "storage-3"}))
+		Expect(getProcessGroupsInZone(maintenanceCluster, "zone-3")).To(BeEmpty())
+	})
+
+	When("printing the maintenance status", func() {
+		var outBuffer bytes.Buffer
+		var cmd *cobra.Command
+
+		BeforeEach(func() {
+			outBuffer = bytes.Buffer{}
+			cmd = &cobra.Command{}
+			cmd.SetOut(&outBuffer)
+		})
+
+		When("no maintenance zone is active", func() {
+			It("should print that no maintenance zone is active", func() {
+				printMaintenanceZone(cmd, maintenanceCluster, status)
+				Expect(outBuffer.String()).To(Equal("No maintenance zone is active in cluster test/test\n"))
+			})
+		})
+
+		When("a maintenance zone is active", func() {
+			BeforeEach(func() {
+				status.Cluster.MaintenanceZone = "zone-2"
+				status.Cluster.MaintenanceSecondsRemaining = 1799.6
+			})
+
+			It("should print the zone, the remaining time and the process groups", func() {
+				printMaintenanceZone(cmd, maintenanceCluster, status)
+				Expect(outBuffer.String()).To(Equal("Maintenance zone: zone-2\nRemaining time: 30m0s\nProcess groups in the maintenance zone:\n\tstorage-2\n\tstorage-3\n"))
+			})
+		})
+	})
+})
@@ -0,0 +1,115 @@
+/*
+ * process_counts.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func newProcessCountsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "process-counts",
+		Short: "Show the desired process counts per process class that are computed by the operator",
+		Long:  "Show the desired process counts per process class that are computed by the operator",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			output, err := getProcessCounts(cluster)
+			if err != nil {
+				return err
+			}
+
+			cmd.Print(output)
+			return nil
+		},
+		Example: `
+  # Show the desired process counts for the cluster
+  kubectl fdb process-counts -c cluster
+  `,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "show the process counts of the provided cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getProcessCounts returns the desired process counts of the cluster, including the defaults computed by the operator,
+// formatted with one process class per line.
+func getProcessCounts(cluster *fdbv1beta2.FoundationDBCluster) (string, error) {
+	counts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return "", err
+	}
+
+	countMap := counts.Map()
+	processClasses := make([]fdbv1beta2.ProcessClass, 0, len(countMap))
+	for processClass := range countMap {
+		processClasses = append(processClasses, processClass)
+	}
+
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Desired process counts for cluster %s/%s:\n", cluster.Namespace, cluster.Name))
+	for _, processClass := range processClasses {
+		sb.WriteString(fmt.Sprintf("%s: %d\n", processClass, countMap[processClass]))
+	}
+
+	return sb.String(), nil
+}
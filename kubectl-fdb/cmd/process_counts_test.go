@@ -0,0 +1,75 @@
+/*
+ * process_counts_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[plugin] process-counts command", func() {
+	DescribeTable("should format the desired process counts",
+		func(counts fdbv1beta2.ProcessCounts, expected string) {
+			cluster.Spec.ProcessCounts = counts
+			output, err := getProcessCounts(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal(expected))
+		},
+		Entry("with explicit process counts",
+			fdbv1beta2.ProcessCounts{
+				Storage:   5,
+				Log:       3,
+				Stateless: 4,
+			},
+			`Desired process counts for cluster test/test:
+log: 3
+stateless: 4
+storage: 5
+`,
+		),
+		Entry("with additional process classes",
+			fdbv1beta2.ProcessCounts{
+				Storage:           5,
+				Log:               3,
+				Stateless:         4,
+				ClusterController: 1,
+			},
+			`Desired process counts for cluster test/test:
+cluster_controller: 1
+log: 3
+stateless: 4
+storage: 5
+`,
+		),
+		Entry("with a disabled process class",
+			fdbv1beta2.ProcessCounts{
+				Storage:   5,
+				Log:       3,
+				Stateless: -1,
+			},
+			`Desired process counts for cluster test/test:
+log: 3
+storage: 5
+`,
+		),
+	)
+})
@@ -0,0 +1,148 @@
+/*
+ * refresh_connection_string.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"log"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newRefreshConnectionStringCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "refresh-connection-string",
+		Short: "Update the connection string in the cluster status with the connection string from the database",
+		Long:  "Update the connection string in the cluster status with the connection string from the database",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			clientSet, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsForCluster(kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			pod, err := chooseRandomPod(pods)
+			if err != nil {
+				return err
+			}
+
+			status, err := getStatus(config, clientSet, pod)
+			if err != nil {
+				return err
+			}
+
+			return runRefreshConnectionString(cmd, kubeClient, cluster, status, wait)
+		},
+		Example: `
+  # Update the connection string for the cluster with the connection string from the database
+  kubectl fdb refresh-connection-string -c cluster
+  `,
+	}
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "update the provided cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// runRefreshConnectionString updates the connection string in the cluster status with the connection string reported
+// in the provided machine-readable status.
+func runRefreshConnectionString(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, wait bool) error {
+	connectionString := status.Cluster.ConnectionString
+	if connectionString == "" {
+		return fmt.Errorf("could not get the connection string for cluster %s/%s from the machine-readable status", cluster.Namespace, cluster.Name)
+	}
+
+	_, err := fdbv1beta2.ParseConnectionString(connectionString)
+	if err != nil {
+		return err
+	}
+
+	if cluster.Status.ConnectionString == connectionString {
+		cmd.Printf("Connection string for cluster %s/%s is already up to date: %s\n", cluster.Namespace, cluster.Name, connectionString)
+		return nil
+	}
+
+	if wait {
+		if !confirmAction(fmt.Sprintf("Update connection string for cluster %s/%s from %s to %s", cluster.Namespace, cluster.Name, cluster.Status.ConnectionString, connectionString)) {
+			return fmt.Errorf("user aborted the update")
+		}
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Status.ConnectionString = connectionString
+	err = kubeClient.Status().Patch(ctx.Background(), cluster, patch)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Updated connection string for cluster %s/%s to %s\n", cluster.Namespace, cluster.Name, connectionString)
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+ * refresh_connection_string_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] refresh-connection-string command", func() {
+	When("refreshing the connection string", func() {
+		oldConnectionString := "test:test@127.0.0.1:4501"
+
+		BeforeEach(func() {
+			cluster.Status.ConnectionString = oldConnectionString
+		})
+
+		DescribeTable("should update the connection string from the status",
+			func(statusConnectionString string, expectedConnectionString string, expectError bool) {
+				outBuffer := bytes.Buffer{}
+				cmd := newRefreshConnectionStringCmd(genericclioptions.IOStreams{Out: &outBuffer, ErrOut: &outBuffer})
+				status := &fdbv1beta2.FoundationDBStatus{
+					Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+						ConnectionString: statusConnectionString,
+					},
+				}
+
+				err := runRefreshConnectionString(cmd, k8sClient, cluster, status, false)
+				if expectError {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				resCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), resCluster)).NotTo(HaveOccurred())
+				Expect(resCluster.Status.ConnectionString).To(Equal(expectedConnectionString))
+			},
+			Entry("the connection string has changed",
+				"test:test@127.0.0.2:4501,127.0.0.3:4501",
+				"test:test@127.0.0.2:4501,127.0.0.3:4501",
+				false,
+			),
+			Entry("the connection string is up to date",
+				oldConnectionString,
+				oldConnectionString,
+				false,
+			),
+			Entry("the status contains no connection string",
+				"",
+				oldConnectionString,
+				true,
+			),
+			Entry("the status contains an invalid connection string",
+				"invalid",
+				oldConnectionString,
+				true,
+			),
+		)
+	})
+})
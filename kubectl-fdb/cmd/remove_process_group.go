@@ -26,7 +26,9 @@ import (
 	"fmt"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -51,6 +53,10 @@ func newRemoveProcessGroupCmd(streams genericclioptions.IOStreams) *cobra.Comman
 			if err != nil {
 				return err
 			}
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
 
 			processGroupSelectionOpts, err := getProcessSelectionOptsFromFlags(cmd, o, args)
 			if err != nil {
@@ -67,6 +73,7 @@ func newRemoveProcessGroupCmd(streams genericclioptions.IOStreams) *cobra.Comman
 					withExclusion:   withExclusion,
 					wait:            wait,
 					removeAllFailed: removeAllFailed,
+					force:           force,
 				})
 			cmd.Printf("\nCompleted removal of %d processGroups\n", totalRemoved)
 			return err
@@ -93,12 +100,16 @@ kubectl fdb -n default remove process-groups -c cluster --process-class="statele
 
 # Remove all processes in the cluster that match the given label set
 kubectl fdb remove process-groups --match-labels="label-key=label-value,other-key=other-value" -c cluster
+
+# Remove process groups and delete their PVCs directly without waiting for the PVC retention duration of the cluster
+kubectl fdb -n default remove process-groups -c cluster --force pod-1 pod-2
 `,
 	}
 
 	addProcessSelectionFlags(cmd)
 	cmd.Flags().BoolP("exclusion", "e", true, "define if the process groups should be removed with exclusion.")
 	cmd.Flags().Bool("remove-all-failed", false, "define if all failed processes should be replaced.")
+	cmd.Flags().Bool("force", false, "define if the PVCs of the process groups should be deleted without waiting for the PVC retention duration.")
 
 	cmd.SetOut(o.Out)
 	cmd.SetErr(o.ErrOut)
@@ -113,6 +124,7 @@ type replaceProcessGroupsOptions struct {
 	withExclusion   bool
 	wait            bool
 	removeAllFailed bool
+	force           bool
 }
 
 // replaceProcessGroups adds process groups to the removal list of their respective clusters, and returns a count of
@@ -181,8 +193,44 @@ func replaceProcessGroupsFromCluster(cmd *cobra.Command, kubeClient client.Clien
 		if err != nil {
 			return totalRemoved, err
 		}
+
+		if opts.force {
+			err = skipPVCRetention(kubeClient, cluster, processGroupIDs)
+			if err != nil {
+				return totalRemoved, err
+			}
+		}
+
 		totalRemoved += len(processGroupIDs)
 		cmd.Printf("removed %v (exclude: %t)\n", processGroupIDs, opts.withExclusion)
 	}
 	return totalRemoved, nil
 }
+
+// skipPVCRetention annotates the PVCs of the provided process groups, so that the operator will delete them directly
+// once the process groups are removed instead of waiting for the PVC retention duration.
+func skipPVCRetention(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroupIDs []fdbv1beta2.ProcessGroupID) error {
+	for _, processGroupID := range processGroupIDs {
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		err := kubeClient.List(ctx.TODO(), pvcs, internal.GetSinglePodListOptions(cluster, processGroupID)...)
+		if err != nil {
+			return err
+		}
+
+		for idx := range pvcs.Items {
+			pvc := &pvcs.Items[idx]
+			patch := client.MergeFrom(pvc.DeepCopy())
+			if pvc.Annotations == nil {
+				pvc.Annotations = map[string]string{}
+			}
+			pvc.Annotations[fdbv1beta2.SkipPVCRetentionKey] = "true"
+
+			err = kubeClient.Patch(ctx.TODO(), pvc, patch)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
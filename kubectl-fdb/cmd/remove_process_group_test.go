@@ -25,8 +25,10 @@ import (
 	"fmt"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -199,6 +201,36 @@ var _ = Describe("[plugin] remove process groups command", func() {
 				})
 			})
 
+			When("the removal is forced", func() {
+				var pvc *corev1.PersistentVolumeClaim
+
+				JustBeforeEach(func() {
+					pvc = &corev1.PersistentVolumeClaim{
+						ObjectMeta: internal.GetPvcMetadata(cluster, fdbv1beta2.ProcessClassStorage, "storage-1"),
+					}
+					pvc.Name = "test-storage-1-data"
+					Expect(k8sClient.Create(context.Background(), pvc)).NotTo(HaveOccurred())
+
+					cmd := newRemoveProcessGroupCmd(genericclioptions.IOStreams{})
+					_, err := replaceProcessGroups(cmd, k8sClient,
+						processGroupSelectionOptions{
+							ids:         []string{"test-storage-1"},
+							namespace:   namespace,
+							clusterName: clusterName,
+						},
+						replaceProcessGroupsOptions{
+							withExclusion: true,
+							force:         true,
+						})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should annotate the PVCs to skip the retention", func() {
+					Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pvc), pvc)).NotTo(HaveOccurred())
+					Expect(pvc.Annotations).To(HaveKeyWithValue(fdbv1beta2.SkipPVCRetentionKey, "true"))
+				})
+			})
+
 			When("processes are removed by pod and clusterLabel criteria", func() {
 				BeforeEach(func() {
 					// creating Pods for first cluster.
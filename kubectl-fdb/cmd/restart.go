@@ -22,13 +22,16 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func newRestartCmd(streams genericclioptions.IOStreams) *cobra.Command {
@@ -60,6 +63,11 @@ func newRestartCmd(streams genericclioptions.IOStreams) *cobra.Command {
 				return cmd.Help()
 			}
 
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+
 			config, err := o.configFlags.ToRESTConfig()
 			if err != nil {
 				return err
@@ -75,12 +83,18 @@ func newRestartCmd(streams genericclioptions.IOStreams) *cobra.Command {
 				return err
 			}
 
-			podsByCluster, err := getPodNamesByCluster(cmd, kubeClient, processGroupSelectionOpts)
+			var processGroupsByCluster map[*fdbv1beta2.FoundationDBCluster][]fdbv1beta2.ProcessGroupID
+			if allProcesses {
+				processGroupsByCluster, err = getAllProcessGroupsByCluster(kubeClient, processGroupSelectionOpts)
+			} else {
+				processGroupsByCluster, err = getProcessGroupsByCluster(cmd, kubeClient, processGroupSelectionOpts)
+			}
 			if err != nil {
 				return err
 			}
-			for cluster, podNames := range podsByCluster {
-				err := restartProcesses(cmd, config, clientSet, podNames, processGroupSelectionOpts.namespace, cluster.Name, wait, sleep)
+
+			for cluster, processGroupIDs := range processGroupsByCluster {
+				err := restartProcesses(cmd, config, clientSet, kubeClient, cluster, processGroupIDs, wait, sleep, force)
 				if err != nil {
 					return err
 				}
@@ -104,11 +118,15 @@ kubectl fdb restart -c cluster --all-processes
 # Restart all processes for a cluster that have the given condition
 kubectl fdb restart -c cluster --process-condition=MissingProcesses
 
+# Restart the processes of the process groups storage-1 and storage-2, even if this exceeds the fault tolerance of the cluster
+kubectl fdb restart -c cluster --use-process-group-id --force storage-1 storage-2
+
 See help for even more process group selection options, such as by processClass, and processGroupID!
 `,
 	}
 	addProcessSelectionFlags(cmd)
 	cmd.Flags().Bool("all-processes", false, "restart all processes of this cluster.")
+	cmd.Flags().Bool("force", false, "restart the processes without checking the fault tolerance of the cluster, the processes will be killed inside their Pods.")
 	cmd.SetOut(o.Out)
 	cmd.SetErr(o.ErrOut)
 	cmd.SetIn(o.In)
@@ -133,23 +151,186 @@ func convertConditions(inputConditions []string) ([]fdbv1beta2.ProcessGroupCondi
 	return res, nil
 }
 
+// getAllProcessGroupsByCluster returns all process groups of the selected cluster.
+func getAllProcessGroupsByCluster(kubeClient client.Client, opts processGroupSelectionOptions) (map[*fdbv1beta2.FoundationDBCluster][]fdbv1beta2.ProcessGroupID, error) {
+	if opts.clusterName == "" {
+		return nil, fmt.Errorf("restarting all processes requires a cluster name")
+	}
+
+	cluster, err := loadCluster(kubeClient, opts.namespace, opts.clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(cluster.Status.ProcessGroups))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+	}
+
+	return map[*fdbv1beta2.FoundationDBCluster][]fdbv1beta2.ProcessGroupID{
+		cluster: processGroupIDs,
+	}, nil
+}
+
+// restartProcesses restarts the fdbserver processes of the provided process groups one after another. If the
+// machine-readable status of the cluster can be read, the processes of a process group are killed with fdbcli, which
+// works for the split image and the unified image, and every restart must be within the current fault tolerance of the
+// cluster. Process groups that have no processes in the status, e.g. because the processes are missing, and all process
+// groups when force is set, are restarted by running pkill in their Pod. This doesn't require an available cluster, so
+// it can be used to recover a cluster.
+//
 //nolint:interfacer // golint has a false-positive here -> `cmd` can be `github.com/hashicorp/go-retryablehttp.Logger`
-func restartProcesses(cmd *cobra.Command, restConfig *rest.Config, kubeClient *kubernetes.Clientset, podNames []string, namespace, clusterName string, wait bool, sleep uint16) error {
+func restartProcesses(cmd *cobra.Command, restConfig *rest.Config, clientSet *kubernetes.Clientset, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroupIDs []fdbv1beta2.ProcessGroupID, wait bool, sleep uint16, force bool) error {
+	pods, err := getPodsForCluster(kubeClient, cluster)
+	if err != nil {
+		return err
+	}
+
+	processGroupsToRestart := make([]fdbv1beta2.ProcessGroupID, len(processGroupIDs))
+	copy(processGroupsToRestart, processGroupIDs)
+	sort.Slice(processGroupsToRestart, func(i, j int) bool {
+		return processGroupsToRestart[i] < processGroupsToRestart[j]
+	})
+
 	if wait {
-		confirmed := confirmAction(fmt.Sprintf("Restart %v in cluster %s/%s", podNames, namespace, clusterName))
+		confirmed := confirmAction(fmt.Sprintf("Restart %v in cluster %s/%s", processGroupsToRestart, cluster.Namespace, cluster.Name))
 		if !confirmed {
-			return fmt.Errorf("user aborted the removal")
+			return fmt.Errorf("user aborted the restart")
 		}
 	}
 
-	for _, pod := range podNames {
-		cmd.Printf("Restart process: %s\n", podNames)
-		_, _, err := executeCmd(restConfig, kubeClient, pod, namespace, "pkill fdbserver")
+	var clientPod *corev1.Pod
+	var status *fdbv1beta2.FoundationDBStatus
+	addresses := map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress{}
+	if !force {
+		clientPod, err = chooseRandomPod(pods)
 		if err != nil {
 			return err
 		}
+
+		status, err = getStatus(restConfig, clientSet, clientPod)
+		if err != nil {
+			return fmt.Errorf("could not read the status of cluster %s/%s to check the fault tolerance, use --force to restart the processes anyway: %w", cluster.Namespace, cluster.Name, err)
+		}
+
+		addresses, _ = getProcessAddressesByProcessGroup(status, processGroupsToRestart)
+	}
+
+	podNames := getPodNamesByProcessGroup(cluster, pods)
+	refreshStatus := false
+	for _, processGroupID := range processGroupsToRestart {
+		processAddresses, ok := addresses[processGroupID]
+		if !ok {
+			podName, ok := podNames[processGroupID]
+			if !ok {
+				printStatement(cmd, fmt.Sprintf("no Pod found for %s, skipping", processGroupID), warnMessage)
+				continue
+			}
+
+			cmd.Printf("Restart processes of %s in Pod %s\n", processGroupID, podName)
+			_, stderr, err := executeCmd(restConfig, clientSet, podName, cluster.Namespace, "pkill fdbserver")
+			if err != nil {
+				return fmt.Errorf("error killing processes in Pod %s: %s, %w", podName, stderr, err)
+			}
+
+			time.Sleep(time.Duration(sleep) * time.Second)
+			continue
+		}
+
+		// The fault tolerance is checked for every process group. The status is read again to take the processes of
+		// the previous restarts into account.
+		if refreshStatus {
+			status, err = getStatus(restConfig, clientSet, clientPod)
+			if err != nil {
+				return err
+			}
+		}
+		refreshStatus = true
+
+		err = checkRestartFaultTolerance(status, map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress{processGroupID: processAddresses}, force)
+		if err != nil {
+			return fmt.Errorf("cannot restart the processes of %s: %w", processGroupID, err)
+		}
+
+		addressesString := fdbv1beta2.ProcessAddressesStringWithoutFlags(processAddresses, " ")
+		cmd.Printf("Restart processes of %s\n", processGroupID)
+		_, stderr, err := executeCmd(restConfig, clientSet, clientPod.Name, clientPod.Namespace, fmt.Sprintf("fdbcli --exec 'kill; kill %[1]s; sleep 1; kill %[1]s; sleep 5'", addressesString))
+		if err != nil {
+			return fmt.Errorf("error killing processes %s: %s, %w", addressesString, stderr, err)
+		}
+
+		cmd.Printf("Killed processes: %s\n", addressesString)
 		time.Sleep(time.Duration(sleep) * time.Second)
 	}
 
 	return nil
 }
+
+// getPodNamesByProcessGroup returns the names of the provided Pods by the process group ID of the Pod.
+func getPodNamesByProcessGroup(cluster *fdbv1beta2.FoundationDBCluster, pods *corev1.PodList) map[fdbv1beta2.ProcessGroupID]string {
+	podNames := make(map[fdbv1beta2.ProcessGroupID]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		processGroupID, ok := pod.Labels[cluster.GetProcessGroupIDLabel()]
+		if !ok {
+			continue
+		}
+
+		podNames[fdbv1beta2.ProcessGroupID(processGroupID)] = pod.Name
+	}
+
+	return podNames
+}
+
+// getProcessAddressesByProcessGroup returns the addresses of the processes of the provided process groups based on the
+// machine-readable status. Process groups without any process in the status will be returned separately.
+func getProcessAddressesByProcessGroup(status *fdbv1beta2.FoundationDBStatus, processGroupIDs []fdbv1beta2.ProcessGroupID) (map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress, []fdbv1beta2.ProcessGroupID) {
+	addresses := make(map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		addresses[processGroupID] = nil
+	}
+
+	for _, process := range status.Cluster.Processes {
+		processGroupID := fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+		if _, ok := addresses[processGroupID]; !ok {
+			continue
+		}
+
+		addresses[processGroupID] = append(addresses[processGroupID], process.Address)
+	}
+
+	var missing []fdbv1beta2.ProcessGroupID
+	for _, processGroupID := range processGroupIDs {
+		if len(addresses[processGroupID]) > 0 {
+			continue
+		}
+
+		missing = append(missing, processGroupID)
+		delete(addresses, processGroupID)
+	}
+
+	return addresses, missing
+}
+
+// checkRestartFaultTolerance returns an error if the processes of the provided process groups are spread across more
+// fault domains than the cluster can lose without losing availability, unless force is set.
+func checkRestartFaultTolerance(status *fdbv1beta2.FoundationDBStatus, addresses map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress, force bool) error {
+	if force {
+		return nil
+	}
+
+	faultDomains := map[string]fdbv1beta2.None{}
+	for _, process := range status.Cluster.Processes {
+		if _, ok := addresses[fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])]; !ok {
+			continue
+		}
+
+		faultDomains[process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]] = fdbv1beta2.None{}
+	}
+
+	faultTolerance := status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability
+	if len(faultDomains) > faultTolerance {
+		return fmt.Errorf("restarting the processes would affect %d fault domains, but the cluster can only tolerate the failure of %d fault domains, use --force to restart the processes anyway", len(faultDomains), faultTolerance)
+	}
+
+	return nil
+}
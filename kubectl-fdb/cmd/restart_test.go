@@ -23,6 +23,7 @@ package cmd
 import (
 	"bytes"
 
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -49,4 +50,143 @@ var _ = Describe("[plugin] root command", func() {
 			Expect(cmd.Execute()).NotTo(HaveOccurred())
 		})
 	})
+
+	When("selecting the processes to restart", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+
+		BeforeEach(func() {
+			status = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					FaultTolerance: fdbv1beta2.FaultTolerance{
+						MaxZoneFailuresWithoutLosingAvailability: 1,
+					},
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": {
+							Address: fdbv1beta2.ProcessAddress{StringAddress: "1.1.1.1", Port: 4501},
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+							},
+						},
+						"2": {
+							Address: fdbv1beta2.ProcessAddress{StringAddress: "1.1.1.1", Port: 4503},
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+							},
+						},
+						"3": {
+							Address: fdbv1beta2.ProcessAddress{StringAddress: "1.1.1.2", Port: 4501},
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-2",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-2",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should return the addresses of all processes of the process groups", func() {
+			addresses, missing := getProcessAddressesByProcessGroup(status, []fdbv1beta2.ProcessGroupID{"storage-1", "storage-3"})
+			Expect(addresses).To(HaveLen(1))
+			Expect(fdbv1beta2.ProcessAddressesStringWithoutFlags(addresses["storage-1"], " ")).To(SatisfyAny(
+				Equal("1.1.1.1:4501 1.1.1.1:4503"),
+				Equal("1.1.1.1:4503 1.1.1.1:4501"),
+			))
+			Expect(missing).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-3")))
+		})
+
+		DescribeTable("checking the fault tolerance",
+			func(processGroupIDs []fdbv1beta2.ProcessGroupID, force bool, expectedErr string) {
+				addresses, _ := getProcessAddressesByProcessGroup(status, processGroupIDs)
+				err := checkRestartFaultTolerance(status, addresses, force)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+
+				Expect(err).To(MatchError(expectedErr))
+			},
+			Entry("the processes are in a single fault domain",
+				[]fdbv1beta2.ProcessGroupID{"storage-1"},
+				false,
+				""),
+			Entry("the processes are in more fault domains than the cluster can tolerate",
+				[]fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"},
+				false,
+				"restarting the processes would affect 2 fault domains, but the cluster can only tolerate the failure of 1 fault domains, use --force to restart the processes anyway"),
+			Entry("the restart is forced",
+				[]fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"},
+				true,
+				""),
+		)
+
+		When("the cluster cannot tolerate the failure of a fault domain", func() {
+			BeforeEach(func() {
+				status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability = 0
+			})
+
+			It("should not restart a single process group", func() {
+				addresses, _ := getProcessAddressesByProcessGroup(status, []fdbv1beta2.ProcessGroupID{"storage-1"})
+				Expect(checkRestartFaultTolerance(status, addresses, false)).To(MatchError("restarting the processes would affect 1 fault domains, but the cluster can only tolerate the failure of 0 fault domains, use --force to restart the processes anyway"))
+			})
+		})
+
+		When("the process groups are selected by the MissingProcesses condition", func() {
+			var processGroupIDs []fdbv1beta2.ProcessGroupID
+
+			JustBeforeEach(func() {
+				Expect(createPods(clusterName, namespace)).NotTo(HaveOccurred())
+
+				cmd := newRestartCmd(genericclioptions.IOStreams{})
+				processGroupsByCluster, err := getProcessGroupsByCluster(cmd, k8sClient, processGroupSelectionOptions{
+					clusterName: clusterName,
+					namespace:   namespace,
+					conditions:  []fdbv1beta2.ProcessGroupConditionType{fdbv1beta2.MissingProcesses},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(processGroupsByCluster).To(HaveLen(1))
+				for _, ids := range processGroupsByCluster {
+					processGroupIDs = ids
+				}
+			})
+
+			It("should select the process group with missing processes", func() {
+				Expect(processGroupIDs).To(ConsistOf(fdbv1beta2.ProcessGroupID("test-storage-2")))
+			})
+
+			It("should restart the processes in the Pod, as they are missing in the status", func() {
+				addresses, missing := getProcessAddressesByProcessGroup(status, processGroupIDs)
+				Expect(addresses).To(BeEmpty())
+				Expect(missing).To(ConsistOf(fdbv1beta2.ProcessGroupID("test-storage-2")))
+
+				pods, err := getPodsForCluster(k8sClient, cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getPodNamesByProcessGroup(cluster, pods)).To(HaveKeyWithValue(fdbv1beta2.ProcessGroupID("test-storage-2"), "test-storage-2"))
+			})
+		})
+	})
+
+	When("restarting all processes", func() {
+		BeforeEach(func() {
+			cluster.Status.ProcessGroups[0].MarkForRemoval()
+		})
+
+		It("should select all process groups including the process groups marked for removal", func() {
+			processGroupsByCluster, err := getAllProcessGroupsByCluster(k8sClient, processGroupSelectionOptions{
+				clusterName: clusterName,
+				namespace:   namespace,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processGroupsByCluster).To(HaveLen(1))
+			for _, processGroupIDs := range processGroupsByCluster {
+				Expect(processGroupIDs).To(ConsistOf(
+					fdbv1beta2.ProcessGroupID("test-storage-1"),
+					fdbv1beta2.ProcessGroupID("test-storage-2"),
+					fdbv1beta2.ProcessGroupID("test-stateless-3"),
+				))
+			}
+		})
+	})
 })
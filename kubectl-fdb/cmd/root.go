@@ -90,12 +90,17 @@ func NewRootCmd(streams genericclioptions.IOStreams, pluginVersionChecker Versio
 		newRemoveCmd(streams),
 		newExecCmd(streams),
 		newCordonCmd(streams),
+		newUncordonCmd(streams),
 		newRestartCmd(streams),
 		newAnalyzeCmd(streams),
 		newDeprecationCmd(streams),
 		newFixCoordinatorIPsCmd(streams),
+		newRefreshConnectionStringCmd(streams),
+		newProcessCountsCmd(streams),
 		newGetCmd(streams),
 		newBuggifyCmd(streams),
+		newMaintenanceCmd(streams),
+		newSkipCmd(streams),
 	)
 
 	return cmd
@@ -180,12 +185,24 @@ type processGroupSelectionOptions struct {
 	namespace         string
 	clusterName       string
 	clusterLabel      string
+	customLabels      []string
 	matchLabels       map[string]string
 	processClass      string
 	useProcessGroupID bool
 	conditions        []fdbv1beta2.ProcessGroupConditionType
 }
 
+// getClusterLabels returns the label keys that should be used to identify the cluster of a Pod. The cluster label
+// takes precedence over the custom labels.
+func (opts processGroupSelectionOptions) getClusterLabels() []string {
+	clusterLabels := make([]string, 0, len(opts.customLabels)+1)
+	if opts.clusterLabel != "" {
+		clusterLabels = append(clusterLabels, opts.clusterLabel)
+	}
+
+	return append(clusterLabels, opts.customLabels...)
+}
+
 func addProcessSelectionFlags(cmd *cobra.Command) {
 	cmd.Flags().StringP("fdb-cluster", "c", "", "Selects process groups from the provided cluster. "+
 		"Required if not passing cluster-label.")
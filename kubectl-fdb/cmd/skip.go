@@ -0,0 +1,219 @@
+/*
+ * skip.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newSkipCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "skip",
+		Short: "Sets or unsets the skip flag of the given clusters",
+		Long:  "Sets or unsets the skip flag of the given clusters, if the skip flag is set the operator will not reconcile the cluster",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterNames, err := cmd.Flags().GetStringSlice("fdb-cluster")
+			if err != nil {
+				return err
+			}
+			allClusters, err := cmd.Flags().GetBool("all")
+			if err != nil {
+				return err
+			}
+			unset, err := cmd.Flags().GetBool("unset")
+			if err != nil {
+				return err
+			}
+
+			if len(clusterNames) == 0 && !allClusters {
+				return cmd.Help()
+			}
+
+			if len(clusterNames) > 0 && allClusters {
+				return fmt.Errorf("the --all flag can't be used together with specific clusters")
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			return updateSkip(cmd, kubeClient, namespace, clusterNames, allClusters, !unset, wait)
+		},
+		Example: `
+# Skip the reconciliation of a cluster in the current namespace
+kubectl fdb skip -c cluster
+
+# Resume the reconciliation of a cluster in the current namespace
+kubectl fdb skip --unset -c cluster
+
+# Skip the reconciliation of multiple clusters in the namespace default
+kubectl fdb -n default skip -c cluster-1 -c cluster-2
+
+# Skip the reconciliation of all clusters in the current namespace
+kubectl fdb skip --all
+`,
+	}
+
+	cmd.Flags().StringSliceP("fdb-cluster", "c", nil, "updates the skip flag of the provided clusters.")
+	cmd.Flags().Bool("all", false, "updates the skip flag of all clusters in the namespace.")
+	cmd.Flags().Bool("unset", false, "unsets the skip flag, so that the operator resumes the reconciliation.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// updateSkip sets the skip flag of the provided clusters, or of all clusters in the namespace, to the provided value.
+func updateSkip(cmd *cobra.Command, kubeClient client.Client, namespace string, clusterNames []string, allClusters bool, skip bool, wait bool) error {
+	clusters, err := getClustersForSkip(kubeClient, namespace, clusterNames, allClusters)
+	if err != nil {
+		return err
+	}
+
+	if len(clusters) == 0 {
+		return fmt.Errorf("no clusters found in namespace %s", namespace)
+	}
+
+	if wait {
+		names := make([]string, 0, len(clusters))
+		for _, cluster := range clusters {
+			names = append(names, cluster.Name)
+		}
+
+		if !confirmAction(fmt.Sprintf("Setting skip to %v for clusters %s in namespace %s", skip, strings.Join(names, ", "), namespace)) {
+			return fmt.Errorf("user aborted the update")
+		}
+	}
+
+	now := time.Now()
+	for _, cluster := range clusters {
+		err = setClusterSkip(cmd, kubeClient, cluster, skip, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getClustersForSkip returns the clusters with the provided names or all clusters in the namespace.
+func getClustersForSkip(kubeClient client.Client, namespace string, clusterNames []string, allClusters bool) ([]*fdbv1beta2.FoundationDBCluster, error) {
+	if allClusters {
+		var clusterList fdbv1beta2.FoundationDBClusterList
+		err := kubeClient.List(ctx.Background(), &clusterList, client.InNamespace(namespace))
+		if err != nil {
+			return nil, err
+		}
+
+		clusters := make([]*fdbv1beta2.FoundationDBCluster, 0, len(clusterList.Items))
+		for idx := range clusterList.Items {
+			clusters = append(clusters, &clusterList.Items[idx])
+		}
+
+		return clusters, nil
+	}
+
+	clusters := make([]*fdbv1beta2.FoundationDBCluster, 0, len(clusterNames))
+	for _, clusterName := range clusterNames {
+		cluster, err := loadCluster(kubeClient, namespace, clusterName)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch cluster information for: %s/%s, error: %w", namespace, clusterName, err)
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters, nil
+}
+
+// setClusterSkip sets the skip flag of the cluster and prints the previous value. If the skip flag was already set, a
+// warning with the duration since the flag was set will be printed. The time when the skip flag is set will be recorded
+// in the SkipSetAtAnnotation, the annotation will be removed when the skip flag is unset.
+func setClusterSkip(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, skip bool, now time.Time) error {
+	previous := cluster.Spec.Skip
+	if previous {
+		printStatement(cmd, getSkipDurationMessage(cluster, now), warnMessage)
+	}
+
+	if previous == skip {
+		cmd.Printf("Cluster %s/%s: skip is already set to %v\n", cluster.Namespace, cluster.Name, skip)
+		return nil
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.Skip = skip
+	if skip {
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+
+		cluster.Annotations[fdbv1beta2.SkipSetAtAnnotation] = now.UTC().Format(time.RFC3339)
+	} else {
+		delete(cluster.Annotations, fdbv1beta2.SkipSetAtAnnotation)
+	}
+
+	err := kubeClient.Patch(ctx.TODO(), cluster, patch)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Cluster %s/%s: skip set to %v (previous value: %v)\n", cluster.Namespace, cluster.Name, skip, previous)
+
+	return nil
+}
+
+// getSkipDurationMessage returns a message how long the cluster has been skipped, based on the SkipSetAtAnnotation.
+func getSkipDurationMessage(cluster *fdbv1beta2.FoundationDBCluster, now time.Time) string {
+	setAt, ok := cluster.Annotations[fdbv1beta2.SkipSetAtAnnotation]
+	if !ok {
+		return fmt.Sprintf("cluster %s/%s is skipped since an unknown time", cluster.Namespace, cluster.Name)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, setAt)
+	if err != nil {
+		return fmt.Sprintf("cluster %s/%s is skipped since an unknown time, could not parse %s annotation: %s", cluster.Namespace, cluster.Name, fdbv1beta2.SkipSetAtAnnotation, err.Error())
+	}
+
+	return fmt.Sprintf("cluster %s/%s has been skipped for %s (since %s)", cluster.Namespace, cluster.Name, now.Sub(timestamp).Round(time.Second), setAt)
+}
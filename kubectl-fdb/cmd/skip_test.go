@@ -0,0 +1,133 @@
+/*
+ * skip_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] skip command", func() {
+	When("running the skip command", func() {
+		var outBuffer, errBuffer bytes.Buffer
+
+		BeforeEach(func() {
+			secondCluster = generateClusterStruct(secondClusterName, namespace)
+			Expect(k8sClient.Create(context.TODO(), secondCluster)).NotTo(HaveOccurred())
+
+			outBuffer = bytes.Buffer{}
+			errBuffer = bytes.Buffer{}
+		})
+
+		getSkip := func(name string) (bool, string) {
+			var resCluster fdbv1beta2.FoundationDBCluster
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name}, &resCluster)).NotTo(HaveOccurred())
+
+			return resCluster.Spec.Skip, resCluster.Annotations[fdbv1beta2.SkipSetAtAnnotation]
+		}
+
+		When("setting the skip flag for a single cluster", func() {
+			JustBeforeEach(func() {
+				cmd := newSkipCmd(genericclioptions.IOStreams{Out: &outBuffer, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+				Expect(updateSkip(cmd, k8sClient, namespace, []string{clusterName}, false, true, false)).NotTo(HaveOccurred())
+			})
+
+			It("should only set the skip flag and the annotation of that cluster", func() {
+				skip, setAt := getSkip(clusterName)
+				Expect(skip).To(BeTrue())
+				Expect(setAt).NotTo(BeEmpty())
+
+				skip, setAt = getSkip(secondClusterName)
+				Expect(skip).To(BeFalse())
+				Expect(setAt).To(BeEmpty())
+
+				Expect(outBuffer.String()).To(ContainSubstring("skip set to true (previous value: false)"))
+				Expect(errBuffer.String()).To(BeEmpty())
+			})
+		})
+
+		When("setting the skip flag for all clusters", func() {
+			JustBeforeEach(func() {
+				cmd := newSkipCmd(genericclioptions.IOStreams{Out: &outBuffer, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+				Expect(updateSkip(cmd, k8sClient, namespace, nil, true, true, false)).NotTo(HaveOccurred())
+			})
+
+			It("should set the skip flag of all clusters", func() {
+				for _, name := range []string{clusterName, secondClusterName} {
+					skip, setAt := getSkip(name)
+					Expect(skip).To(BeTrue())
+					Expect(setAt).NotTo(BeEmpty())
+				}
+			})
+		})
+
+		When("the skip flag is already set", func() {
+			BeforeEach(func() {
+				cluster.Spec.Skip = true
+				cluster.Annotations = map[string]string{
+					fdbv1beta2.SkipSetAtAnnotation: time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+				}
+			})
+
+			When("setting the skip flag again", func() {
+				JustBeforeEach(func() {
+					cmd := newSkipCmd(genericclioptions.IOStreams{Out: &outBuffer, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+					Expect(updateSkip(cmd, k8sClient, namespace, []string{clusterName}, false, true, false)).NotTo(HaveOccurred())
+				})
+
+				It("should warn how long the cluster has been skipped", func() {
+					Expect(errBuffer.String()).To(ContainSubstring("cluster test/test has been skipped for 2h0m"))
+					Expect(outBuffer.String()).To(ContainSubstring("skip is already set to true"))
+				})
+			})
+
+			When("unsetting the skip flag", func() {
+				JustBeforeEach(func() {
+					cmd := newSkipCmd(genericclioptions.IOStreams{Out: &outBuffer, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+					Expect(updateSkip(cmd, k8sClient, namespace, []string{clusterName}, false, false, false)).NotTo(HaveOccurred())
+				})
+
+				It("should unset the skip flag and remove the annotation", func() {
+					skip, setAt := getSkip(clusterName)
+					Expect(skip).To(BeFalse())
+					Expect(setAt).To(BeEmpty())
+
+					Expect(errBuffer.String()).To(ContainSubstring("cluster test/test has been skipped for 2h0m"))
+					Expect(outBuffer.String()).To(ContainSubstring("skip set to false (previous value: true)"))
+				})
+			})
+		})
+
+		When("the cluster doesn't exist", func() {
+			It("should return an error", func() {
+				cmd := newSkipCmd(genericclioptions.IOStreams{Out: &outBuffer, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+				Expect(updateSkip(cmd, k8sClient, namespace, []string{"missing"}, false, true, false)).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,216 @@
+/*
+ * uncordon.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"errors"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newUncordonCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+	var nodeSelectors map[string]string
+
+	cmd := &cobra.Command{
+		Use:   "uncordon",
+		Short: "Removes all process groups that run on a node from the remove list of the given cluster",
+		Long:  "Removes all process groups that run on a node from the remove list of the given cluster, process groups that are already excluded will be skipped",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+			nodeSelector, err := cmd.Flags().GetStringToString("node-selector")
+			if err != nil {
+				return err
+			}
+			clusterLabel, err := cmd.Flags().GetString("cluster-label")
+			if err != nil {
+				return err
+			}
+			customLabels, err := cmd.Flags().GetStringSlice("custom-labels")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			nodes, err := getNodesFromArgs(kubeClient, nodeSelector, args)
+			if err != nil {
+				return err
+			}
+
+			return uncordonNode(cmd, kubeClient, clusterName, nodes, namespace, wait, clusterLabel, customLabels)
+		},
+		Example: `
+# Remove all process groups for a cluster in the current namespace that are hosted on node-1 from the remove list
+kubectl fdb uncordon -c cluster node-1
+
+# Remove all process groups for a cluster in the current namespace that are hosted on nodes with the labels machine=a,disk=fast from the remove list
+kubectl fdb uncordon -c cluster --node-selector machine=a,disk=fast
+
+# Remove all process groups in the current namespace that are hosted on node-1 with cluster-label from the remove list
+kubectl fdb uncordon -l fdb-cluster-label node-1
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "remove process group(s) of the provided cluster from the remove list.")
+	cmd.Flags().StringToStringVarP(&nodeSelectors, "node-selector", "", nil, "node-selector to select all nodes that should be uncordoned. Can't be used with specific nodes.")
+	cmd.Flags().StringP("cluster-label", "l", fdbv1beta2.FDBClusterLabel, "cluster label to fetch the appropriate Pods and identify the according cluster.")
+	cmd.Flags().StringSlice("custom-labels", nil, "additional labels to identify the according cluster of a Pod, if the cluster label is not present. If none of the labels is present, the cluster will be identified by the owner reference of the Pod.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// uncordonNode gets all process groups of this cluster that run on the given nodes and removes them from the remove
+// lists. Process groups that are already excluded will not be removed from the remove lists, as the exclusion was
+// already completed.
+func uncordonNode(cmd *cobra.Command, kubeClient client.Client, inputClusterName string, nodes []string, namespace string, wait bool, clusterLabel string, customLabels []string) error {
+	cmd.Printf("Starting to uncordon %d nodes\n", len(nodes))
+	if len(nodes) == 0 {
+		return errors.New("no nodes were provided for uncordoning")
+	}
+
+	selectionOpts := processGroupSelectionOptions{
+		namespace:    namespace,
+		clusterName:  inputClusterName,
+		clusterLabel: clusterLabel,
+		customLabels: customLabels,
+	}
+
+	var totalUncordoned int
+	for _, node := range nodes {
+		podNames, err := getPodNamesOnNode(kubeClient, inputClusterName, namespace, node, selectionOpts.getClusterLabels())
+		if err != nil {
+			return err
+		}
+
+		selectionOpts.ids = podNames
+		processGroupsByCluster, err := getProcessGroupsByCluster(cmd, kubeClient, selectionOpts)
+		if err != nil {
+			return fmt.Errorf("unable to uncordon all Pods running on node %s. Error: %w", node, err)
+		}
+
+		cmd.Printf("\nUncordoning node: %s\n", node)
+		for cluster, processGroupIDs := range processGroupsByCluster {
+			uncordoned, err := uncordonProcessGroups(cmd, kubeClient, cluster, processGroupIDs, wait)
+			if err != nil {
+				return fmt.Errorf("unable to uncordon all Pods running on node %s. Error: %w", node, err)
+			}
+
+			totalUncordoned += uncordoned
+		}
+	}
+
+	cmd.Printf("\nCompleted uncordon of %d Pods\n", totalUncordoned)
+	return nil
+}
+
+// uncordonProcessGroups removes the provided process groups from the remove lists of the cluster and returns the number
+// of process groups that were removed from the remove lists. Process groups that are already excluded will be skipped.
+func uncordonProcessGroups(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroupIDs []fdbv1beta2.ProcessGroupID, wait bool) (int, error) {
+	cmd.Printf("Cluster %v/%v:\n", cluster.Namespace, cluster.Name)
+
+	markedForRemoval := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	for _, processGroupID := range cluster.Spec.ProcessGroupsToRemove {
+		markedForRemoval[processGroupID] = fdbv1beta2.None{}
+	}
+	for _, processGroupID := range cluster.Spec.ProcessGroupsToRemoveWithoutExclusion {
+		markedForRemoval[processGroupID] = fdbv1beta2.None{}
+	}
+
+	toUncordon := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	var processGroupsToUncordon []fdbv1beta2.ProcessGroupID
+	for _, processGroupID := range processGroupIDs {
+		if _, ok := markedForRemoval[processGroupID]; !ok {
+			continue
+		}
+
+		processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID)
+		if processGroup != nil && processGroup.IsExcluded() {
+			printStatement(cmd, fmt.Sprintf("skipping %s, the process group is already excluded", processGroupID), warnMessage)
+			continue
+		}
+
+		toUncordon[processGroupID] = fdbv1beta2.None{}
+		processGroupsToUncordon = append(processGroupsToUncordon, processGroupID)
+	}
+
+	if len(processGroupsToUncordon) == 0 {
+		cmd.Println("no process groups to uncordon")
+		return 0, nil
+	}
+
+	if wait {
+		if !confirmAction(fmt.Sprintf("Uncordon %v in cluster %s/%s", processGroupsToUncordon, cluster.Namespace, cluster.Name)) {
+			return 0, fmt.Errorf("user aborted the uncordon")
+		}
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.ProcessGroupsToRemove = filterProcessGroupIDs(cluster.Spec.ProcessGroupsToRemove, toUncordon)
+	cluster.Spec.ProcessGroupsToRemoveWithoutExclusion = filterProcessGroupIDs(cluster.Spec.ProcessGroupsToRemoveWithoutExclusion, toUncordon)
+
+	err := kubeClient.Patch(ctx.TODO(), cluster, patch)
+	if err != nil {
+		return 0, err
+	}
+
+	cmd.Printf("uncordoned %v\n", processGroupsToUncordon)
+	return len(processGroupsToUncordon), nil
+}
+
+// filterProcessGroupIDs returns the process group IDs that are not part of the provided set.
+func filterProcessGroupIDs(processGroupIDs []fdbv1beta2.ProcessGroupID, toRemove map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None) []fdbv1beta2.ProcessGroupID {
+	filtered := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		if _, ok := toRemove[processGroupID]; ok {
+			continue
+		}
+
+		filtered = append(filtered, processGroupID)
+	}
+
+	return filtered
+}
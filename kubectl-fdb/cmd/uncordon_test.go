@@ -0,0 +1,144 @@
+/*
+ * uncordon_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] uncordon command", func() {
+	When("running uncordon command", func() {
+		var errBuffer bytes.Buffer
+		var firstStorage, secondStorage, secondClusterStorage fdbv1beta2.ProcessGroupID
+
+		BeforeEach(func() {
+			firstStorage = fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-1", clusterName, fdbv1beta2.ProcessClassStorage))
+			secondStorage = fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-2", clusterName, fdbv1beta2.ProcessClassStorage))
+			secondClusterStorage = fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-1", secondClusterName, fdbv1beta2.ProcessClassStorage))
+
+			cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{firstStorage}
+			cluster.Spec.ProcessGroupsToRemoveWithoutExclusion = []fdbv1beta2.ProcessGroupID{secondStorage}
+			Expect(createPods(clusterName, namespace)).NotTo(HaveOccurred())
+
+			secondCluster = generateClusterStruct(secondClusterName, namespace)
+			secondCluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{secondClusterStorage}
+			Expect(k8sClient.Create(context.TODO(), secondCluster)).NotTo(HaveOccurred())
+			Expect(createPods(secondClusterName, namespace)).NotTo(HaveOccurred())
+
+			errBuffer = bytes.Buffer{}
+		})
+
+		DescribeTable("should uncordon all targeted processes",
+			func(inputClusterName string, nodes []string, clusterLabel string, expectedToRemove []fdbv1beta2.ProcessGroupID, expectedToRemoveWithoutExclusion []fdbv1beta2.ProcessGroupID) {
+				cmd := newUncordonCmd(genericclioptions.IOStreams{Out: &bytes.Buffer{}, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+				Expect(uncordonNode(cmd, k8sClient, inputClusterName, nodes, namespace, false, clusterLabel, nil)).NotTo(HaveOccurred())
+
+				var toRemove []fdbv1beta2.ProcessGroupID
+				var toRemoveWithoutExclusion []fdbv1beta2.ProcessGroupID
+				for _, name := range []string{clusterName, secondClusterName} {
+					var resCluster fdbv1beta2.FoundationDBCluster
+					Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name}, &resCluster)).NotTo(HaveOccurred())
+					toRemove = append(toRemove, resCluster.Spec.ProcessGroupsToRemove...)
+					toRemoveWithoutExclusion = append(toRemoveWithoutExclusion, resCluster.Spec.ProcessGroupsToRemoveWithoutExclusion...)
+				}
+
+				Expect(toRemove).To(ConsistOf(expectedToRemove))
+				Expect(toRemoveWithoutExclusion).To(ConsistOf(expectedToRemoveWithoutExclusion))
+			},
+			Entry("uncordon a single node of a cluster",
+				clusterName,
+				[]string{"node-1"},
+				"",
+				[]fdbv1beta2.ProcessGroupID{fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-1", secondClusterName, fdbv1beta2.ProcessClassStorage))},
+				[]fdbv1beta2.ProcessGroupID{fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-2", clusterName, fdbv1beta2.ProcessClassStorage))}),
+			Entry("uncordon all nodes of a cluster",
+				clusterName,
+				[]string{"node-1", "node-2"},
+				"",
+				[]fdbv1beta2.ProcessGroupID{fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-1", secondClusterName, fdbv1beta2.ProcessClassStorage))},
+				[]fdbv1beta2.ProcessGroupID{}),
+			Entry("uncordon a node of all clusters with the cluster label",
+				"",
+				[]string{"node-1"},
+				fdbv1beta2.FDBClusterLabel,
+				[]fdbv1beta2.ProcessGroupID{},
+				[]fdbv1beta2.ProcessGroupID{fdbv1beta2.ProcessGroupID(fmt.Sprintf("%s-%s-2", clusterName, fdbv1beta2.ProcessClassStorage))}),
+		)
+
+		When("a process group is already excluded", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].ExclusionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			JustBeforeEach(func() {
+				cmd := newUncordonCmd(genericclioptions.IOStreams{Out: &bytes.Buffer{}, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+				Expect(uncordonNode(cmd, k8sClient, clusterName, []string{"node-1", "node-2"}, namespace, false, "", nil)).NotTo(HaveOccurred())
+			})
+
+			It("should only uncordon the process groups that are not excluded", func() {
+				var resCluster fdbv1beta2.FoundationDBCluster
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: clusterName}, &resCluster)).NotTo(HaveOccurred())
+				Expect(resCluster.Spec.ProcessGroupsToRemove).To(ConsistOf(firstStorage))
+				Expect(resCluster.Spec.ProcessGroupsToRemoveWithoutExclusion).To(BeEmpty())
+			})
+
+			It("should print a warning for the excluded process group", func() {
+				Expect(errBuffer.String()).To(ContainSubstring(fmt.Sprintf("skipping %s, the process group is already excluded", firstStorage)))
+			})
+		})
+
+		When("a process group is already marked for removal but not excluded", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].RemovalTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			JustBeforeEach(func() {
+				cmd := newUncordonCmd(genericclioptions.IOStreams{Out: &bytes.Buffer{}, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+				Expect(uncordonNode(cmd, k8sClient, clusterName, []string{"node-1", "node-2"}, namespace, false, "", nil)).NotTo(HaveOccurred())
+			})
+
+			It("should uncordon the process group", func() {
+				var resCluster fdbv1beta2.FoundationDBCluster
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: clusterName}, &resCluster)).NotTo(HaveOccurred())
+				Expect(resCluster.Spec.ProcessGroupsToRemove).To(BeEmpty())
+				Expect(resCluster.Spec.ProcessGroupsToRemoveWithoutExclusion).To(BeEmpty())
+			})
+		})
+
+		When("no pods are running on the node", func() {
+			It("should return an error", func() {
+				cmd := newUncordonCmd(genericclioptions.IOStreams{Out: &bytes.Buffer{}, ErrOut: &errBuffer, In: &bytes.Buffer{}})
+				err := uncordonNode(cmd, k8sClient, clusterName, []string{"node-4"}, namespace, false, "", nil)
+				Expect(err).To(MatchError(ContainSubstring("no pods were found that were running on node node-4")))
+			})
+		})
+	})
+})
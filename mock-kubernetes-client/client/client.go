@@ -140,6 +140,15 @@ func (client *MockClient) setNewFakeClient() {
 			return []string{o.(*corev1.Pod).Spec.NodeName}
 		}).WithIndex(&corev1.Pod{}, "status.phase", func(o ctrlClient.Object) []string {
 			return []string{string(o.(*corev1.Pod).Status.Phase)}
+		}).WithIndex(&corev1.Pod{}, "metadata.labels.processGroupID", func(o ctrlClient.Object) []string {
+			// This index matches the process group ID index of the operator, which uses the default process group
+			// ID label.
+			processGroupID, ok := o.GetLabels()["foundationdb.org/fdb-process-group-id"]
+			if !ok {
+				return nil
+			}
+
+			return []string{processGroupID}
 		})
 	}
 
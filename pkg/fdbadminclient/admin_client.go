@@ -21,8 +21,11 @@
 package fdbadminclient
 
 import (
-	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"fmt"
+	"strings"
 	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
 
 // AdminClient describes an interface for running administrative commands on a
@@ -38,10 +41,19 @@ type AdminClient interface {
 	// from the database.
 	ExcludeProcesses(addresses []fdbv1beta2.ProcessAddress) error
 
+	// ExcludeProcessesWithFailed starts evacuating processes so that they can be removed from the database. If failed
+	// is true, the processes will be excluded with the failed flag, this should only be used for processes that will
+	// never come back, as the database will not try to fetch data from those processes anymore.
+	ExcludeProcessesWithFailed(addresses []fdbv1beta2.ProcessAddress, failed bool) error
+
 	// IncludeProcesses removes processes from the exclusion list and allows
 	// them to take on roles again.
 	IncludeProcesses(addresses []fdbv1beta2.ProcessAddress) error
 
+	// IncludeProcessesWithResults removes processes from the exclusion list and reports the result of the inclusion
+	// for every provided address. The returned error is not nil if at least one address could not be included.
+	IncludeProcessesWithResults(addresses []fdbv1beta2.ProcessAddress) ([]InclusionResult, error)
+
 	// GetExclusions gets a list of the addresses currently excluded from the
 	// database.
 	GetExclusions() ([]fdbv1beta2.ProcessAddress, error)
@@ -70,30 +82,82 @@ type AdminClient interface {
 	// version of FDB.
 	GetProtocolVersion(version string) (string, error)
 
-	// StartBackup starts a new backup.
+	// StartBackup starts a new backup with the default tag.
 	StartBackup(url string, snapshotPeriodSeconds int) error
 
-	// StopBackup stops a backup.
+	// StartBackupWithTag starts a new backup with the provided tag. If
+	// blobCredentialsPath is not empty, the blob credentials will be read from
+	// this file. If keyRanges is empty, the entire keyspace will be backed up.
+	StartBackupWithTag(url string, tag string, snapshotPeriodSeconds int, blobCredentialsPath string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error
+
+	// StopBackup stops the backup with the default tag.
 	StopBackup(url string) error
 
-	// PauseBackups pauses the backups.
+	// StopBackupWithTag stops the backup with the provided tag.
+	StopBackupWithTag(tag string) error
+
+	// AbortBackup aborts the backup with the provided tag, which writes to
+	// the provided URL, without waiting for the current snapshot to complete.
+	// If no backup is running for the tag, this is a no-op.
+	AbortBackup(url string, tag string) error
+
+	// PauseBackups pauses the backups. This pauses the backups for all tags.
 	PauseBackups() error
 
-	// ResumeBackups resumes the backups.
+	// ResumeBackups resumes the backups. This resumes the backups for all
+	// tags.
 	ResumeBackups() error
 
-	// ModifyBackup modifies the configuration of the backup.
+	// ModifyBackup modifies the configuration of the backup with the default
+	// tag.
 	ModifyBackup(int) error
 
-	// GetBackupStatus gets the status of the current backup.
+	// ModifyBackupWithTag modifies the configuration of the backup with the
+	// provided tag.
+	ModifyBackupWithTag(tag string, snapshotPeriodSeconds int) error
+
+	// ExpireBackup deletes the backup data that is older than expireBefore,
+	// while making sure that the backup stays restorable after
+	// restorableAfter. It returns the output of the expiration. If
+	// blobCredentialsPath is not empty, the blob credentials will be read from
+	// this file.
+	ExpireBackup(url string, expireBefore time.Time, restorableAfter time.Time, blobCredentialsPath string) (string, error)
+
+	// DescribeBackup describes the data of the backup in the blob store. If
+	// blobCredentialsPath is not empty, the blob credentials will be read from
+	// this file.
+	DescribeBackup(url string, blobCredentialsPath string) (*fdbv1beta2.FoundationDBBackupDescription, error)
+
+	// GetBackupStatus gets the status of the backup with the default tag.
 	GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error)
 
-	// StartRestore starts a new restore.
-	StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error
+	// GetBackupStatusWithTag gets the status of the backup with the provided
+	// tag.
+	GetBackupStatusWithTag(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error)
+
+	// StartRestore starts a new restore. If the version is 0, the latest
+	// restorable version will be restored.
+	StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, version int64) error
+
+	// StartRestoreAtTimestamp starts a new restore of the backup data at the
+	// provided timestamp. The timestamp is resolved to a version based on the
+	// version history of the source cluster.
+	StartRestoreAtTimestamp(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, timestamp time.Time, sourceCluster *fdbv1beta2.FoundationDBCluster) error
 
 	// GetRestoreStatus gets the status of the current restore.
 	GetRestoreStatus() (string, error)
 
+	// GetLiveRestoreStatus gets the parsed status of the current restore.
+	GetLiveRestoreStatus() (*fdbv1beta2.FoundationDBLiveRestoreStatus, error)
+
+	// AbortRestore aborts the restore with the provided tag. If no restore is
+	// in progress for the tag, this is a no-op.
+	AbortRestore(tag string) error
+
+	// IsKeyspaceEmpty checks if the provided key ranges contain no keys. If
+	// no key ranges are provided, the whole user keyspace will be checked.
+	IsKeyspaceEmpty(keyRanges []fdbv1beta2.FoundationDBKeyRange) (bool, error)
+
 	// Close shuts down any resources for the client once it is no longer
 	// needed.
 	Close() error
@@ -120,6 +184,9 @@ type AdminClient interface {
 	// SetTimeout will overwrite the default timeout for interacting the FDB cluster.
 	SetTimeout(timeout time.Duration)
 
+	// GetTimeout returns the timeout for interacting with the FDB cluster.
+	GetTimeout() time.Duration
+
 	// GetProcessesUnderMaintenance will return all process groups that are currently stored to be under maintenance.
 	// The result is a map with the process group ID as key and the start of the maintenance as value.
 	GetProcessesUnderMaintenance() (map[fdbv1beta2.ProcessGroupID]int64, error)
@@ -133,3 +200,32 @@ type AdminClient interface {
 	// list, the timestamp will be updated.
 	SetProcessesUnderMaintenance([]fdbv1beta2.ProcessGroupID, int64) error
 }
+
+// InclusionResult provides the result of the inclusion of a single address.
+type InclusionResult struct {
+	// Address is the address that should be included.
+	Address fdbv1beta2.ProcessAddress
+
+	// Err contains the error that occurred during the inclusion of the address. If the address was included, Err
+	// will be nil.
+	Err error
+}
+
+// GetInclusionError returns an error that contains all addresses that could not be included. If all addresses were
+// included, nil will be returned.
+func GetInclusionError(results []InclusionResult) error {
+	failed := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+
+		failed = append(failed, fmt.Sprintf("%s: %s", result.Address.String(), result.Err.Error()))
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("could not include %d of %d addresses: %s", len(failed), len(results), strings.Join(failed, ", "))
+}
@@ -34,4 +34,8 @@ type DatabaseClientProvider interface {
 	// GetAdminClient generates a client for performing administrative actions
 	// against the database.
 	GetAdminClient(cluster *fdbv1beta2.FoundationDBCluster, kubernetesClient client.Client) (AdminClient, error)
+
+	// RecreateNativeConnection drops the native connection for the cluster, the next client will create a new native
+	// connection. This can be used to recover from connection issues that only affect a single cluster.
+	RecreateNativeConnection(cluster *fdbv1beta2.FoundationDBCluster) error
 }
@@ -21,10 +21,12 @@
 package mock
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbstatus"
 	"net"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -46,6 +48,7 @@ type AdminClient struct {
 	KubeClient                               client.Client
 	DatabaseConfiguration                    *fdbv1beta2.DatabaseConfiguration
 	ExcludedAddresses                        map[string]fdbv1beta2.None
+	FailedExcludedAddresses                  map[string]fdbv1beta2.None
 	KilledAddresses                          map[string]fdbv1beta2.None
 	Knobs                                    map[string]fdbv1beta2.None
 	missingLocalities                        map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
@@ -53,6 +56,17 @@ type AdminClient struct {
 	incorrectCommandLines                    map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None
 	FrozenStatus                             *fdbv1beta2.FoundationDBStatus
 	Backups                                  map[string]fdbv1beta2.FoundationDBBackupStatusBackupDetails
+	LatestRestorablePoint                    *fdbv1beta2.FoundationDBLiveBackupStatusRestorablePoint
+	BackupDescription                        *fdbv1beta2.FoundationDBBackupDescription
+	BackupDescriptions                       int
+	BlobCredentialsPath                      string
+	BlobCredentials                          string
+	BackupExpirations                        int
+	BackupAborts                             int
+	StartBackupError                         error
+	DescribeBackupError                      error
+	LastBackupExpireBefore                   time.Time
+	LastBackupRestorableAfter                time.Time
 	clientVersions                           map[string][]string
 	currentCommandLines                      map[string]string
 	VersionProcessGroups                     map[fdbv1beta2.ProcessGroupID]string
@@ -63,13 +77,26 @@ type AdminClient struct {
 	MaxZoneFailuresWithoutLosingAvailability *int
 	MaintenanceZone                          fdbv1beta2.FaultDomain
 	restoreURL                               string
+	RestoreKeyRanges                         []fdbv1beta2.FoundationDBKeyRange
+	BackupKeyRanges                          map[string][]fdbv1beta2.FoundationDBKeyRange
+	RestoreVersion                           int64
+	RestoreTimestamp                         *time.Time
+	RestoreSourceCluster                     string
+	StartRestoreError                        error
+	RestoreAborts                            int
+	RestoreProgress                          []fdbv1beta2.FoundationDBLiveRestoreStatus
+	DatabaseKeys                             []string
 	maintenanceZoneStartTimestamp            time.Time
 	uptimeSecondsForMaintenanceZone          float64
 	TeamTracker                              []fdbv1beta2.FoundationDBStatusTeamTracker
 	Logs                                     []fdbv1beta2.FoundationDBStatusLogInfo
 	mockError                                error
+	mockErrorIsRecoverable                   bool
+	NativeConnectionRecreations              int
 	LagInfo                                  map[string]fdbv1beta2.FoundationDBStatusLagInfo
 	processesUnderMaintenance                map[fdbv1beta2.ProcessGroupID]int64
+	inclusionErrors                          map[string]error
+	timeout                                  time.Duration
 }
 
 // adminClientCache provides a cache of mock admin clients.
@@ -95,6 +122,7 @@ func NewMockAdminClientUncast(cluster *fdbv1beta2.FoundationDBCluster, kubeClien
 			Cluster:                   cluster.DeepCopy(),
 			KubeClient:                kubeClient,
 			ExcludedAddresses:         make(map[string]fdbv1beta2.None),
+			FailedExcludedAddresses:   make(map[string]fdbv1beta2.None),
 			ReincludedAddresses:       make(map[string]bool),
 			KilledAddresses:           make(map[string]fdbv1beta2.None),
 			missingProcessGroups:      make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None),
@@ -106,9 +134,11 @@ func NewMockAdminClientUncast(cluster *fdbv1beta2.FoundationDBCluster, kubeClien
 			VersionProcessGroups:      make(map[fdbv1beta2.ProcessGroupID]string),
 			LagInfo:                   make(map[string]fdbv1beta2.FoundationDBStatusLagInfo),
 			processesUnderMaintenance: make(map[fdbv1beta2.ProcessGroupID]int64),
+			inclusionErrors:           make(map[string]error),
 		}
 		adminClientCache[cluster.Name] = cachedClient
 		cachedClient.Backups = make(map[string]fdbv1beta2.FoundationDBBackupStatusBackupDetails)
+		cachedClient.BackupKeyRanges = make(map[string][]fdbv1beta2.FoundationDBKeyRange)
 	} else {
 		cachedClient.Cluster = cluster.DeepCopy()
 	}
@@ -528,6 +558,12 @@ func (client *AdminClient) ConfigureDatabase(configuration fdbv1beta2.DatabaseCo
 // ExcludeProcesses starts evacuating processes so that they can be removed
 // from the database.
 func (client *AdminClient) ExcludeProcesses(addresses []fdbv1beta2.ProcessAddress) error {
+	return client.ExcludeProcessesWithFailed(addresses, false)
+}
+
+// ExcludeProcessesWithFailed starts evacuating processes so that they can be removed from the database. If failed is
+// true, the addresses will additionally be tracked in FailedExcludedAddresses.
+func (client *AdminClient) ExcludeProcessesWithFailed(addresses []fdbv1beta2.ProcessAddress, failed bool) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -538,6 +574,9 @@ func (client *AdminClient) ExcludeProcesses(addresses []fdbv1beta2.ProcessAddres
 	for _, pAddr := range addresses {
 		address := pAddr.String()
 		client.ExcludedAddresses[address] = fdbv1beta2.None{}
+		if failed {
+			client.FailedExcludedAddresses[address] = fdbv1beta2.None{}
+		}
 	}
 	return nil
 }
@@ -554,11 +593,36 @@ func (client *AdminClient) IncludeProcesses(addresses []fdbv1beta2.ProcessAddres
 		if ok {
 			client.ReincludedAddresses[address] = true
 			delete(client.ExcludedAddresses, address)
+			delete(client.FailedExcludedAddresses, address)
 		}
 	}
 	return nil
 }
 
+// IncludeProcessesWithResults removes processes from the exclusion list and reports the result for every address.
+// Addresses with a mocked inclusion error will not be included.
+func (client *AdminClient) IncludeProcessesWithResults(addresses []fdbv1beta2.ProcessAddress) ([]fdbadminclient.InclusionResult, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	results := make([]fdbadminclient.InclusionResult, 0, len(addresses))
+	for _, pAddr := range addresses {
+		address := pAddr.String()
+		err, failed := client.inclusionErrors[address]
+		if !failed {
+			if _, ok := client.ExcludedAddresses[address]; ok {
+				client.ReincludedAddresses[address] = true
+				delete(client.ExcludedAddresses, address)
+				delete(client.FailedExcludedAddresses, address)
+			}
+		}
+
+		results = append(results, fdbadminclient.InclusionResult{Address: pAddr, Err: err})
+	}
+
+	return results, fdbadminclient.GetInclusionError(results)
+}
+
 // CanSafelyRemove checks whether it is safe to remove the process group from the
 // cluster
 //
@@ -723,8 +787,13 @@ func (client *AdminClient) GetProtocolVersion(version string) (string, error) {
 	return version, nil
 }
 
-// StartBackup starts a new backup.
+// StartBackup starts a new backup with the default tag.
 func (client *AdminClient) StartBackup(url string, snapshotPeriodSeconds int) error {
+	return client.StartBackupWithTag(url, fdbv1beta2.DefaultBackupTag, snapshotPeriodSeconds, "", nil)
+}
+
+// StartBackupWithTag starts a new backup with the provided tag.
+func (client *AdminClient) StartBackupWithTag(url string, tag string, snapshotPeriodSeconds int, blobCredentialsPath string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -732,14 +801,42 @@ func (client *AdminClient) StartBackup(url string, snapshotPeriodSeconds int) er
 		return client.mockError
 	}
 
-	client.Backups["default"] = fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+	// StartBackupError can be used to simulate issues with the blobstore, e.g. an unreachable blobstore.
+	if client.StartBackupError != nil {
+		return client.StartBackupError
+	}
+
+	blobCredentials, err := readBlobCredentials(blobCredentialsPath)
+	if err != nil {
+		return err
+	}
+
+	client.Backups[tag] = fdbv1beta2.FoundationDBBackupStatusBackupDetails{
 		URL:                   url,
 		Running:               true,
 		SnapshotPeriodSeconds: snapshotPeriodSeconds,
 	}
+	client.BlobCredentialsPath = blobCredentialsPath
+	client.BlobCredentials = blobCredentials
+	client.BackupKeyRanges[tag] = keyRanges
 	return nil
 }
 
+// readBlobCredentials returns the content of the blob credentials file, this allows to verify that the caller provided
+// a readable copy of the credentials. If the path is empty, an empty string will be returned.
+func readBlobCredentials(blobCredentialsPath string) (string, error) {
+	if blobCredentialsPath == "" {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(blobCredentialsPath)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
 // PauseBackups pauses backups.
 func (client *AdminClient) PauseBackups() error {
 	adminClientMutex.Lock()
@@ -772,8 +869,13 @@ func (client *AdminClient) ResumeBackups() error {
 	return nil
 }
 
-// ModifyBackup reconfigures the backup.
+// ModifyBackup reconfigures the backup with the default tag.
 func (client *AdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
+	return client.ModifyBackupWithTag(fdbv1beta2.DefaultBackupTag, snapshotPeriodSeconds)
+}
+
+// ModifyBackupWithTag reconfigures the backup with the provided tag.
+func (client *AdminClient) ModifyBackupWithTag(tag string, snapshotPeriodSeconds int) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -781,14 +883,23 @@ func (client *AdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
 		return client.mockError
 	}
 
-	backup := client.Backups["default"]
+	backup, present := client.Backups[tag]
+	if !present {
+		return fmt.Errorf("no backup found for tag %s", tag)
+	}
+
 	backup.SnapshotPeriodSeconds = snapshotPeriodSeconds
-	client.Backups["default"] = backup
+	client.Backups[tag] = backup
 	return nil
 }
 
-// StopBackup stops a backup.
-func (client *AdminClient) StopBackup(url string) error {
+// StopBackup stops the backup with the default tag.
+func (client *AdminClient) StopBackup(_ string) error {
+	return client.StopBackupWithTag(fdbv1beta2.DefaultBackupTag)
+}
+
+// StopBackupWithTag stops the backup with the provided tag.
+func (client *AdminClient) StopBackupWithTag(tag string) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -796,18 +907,107 @@ func (client *AdminClient) StopBackup(url string) error {
 		return client.mockError
 	}
 
-	for tag, backup := range client.Backups {
+	backup, present := client.Backups[tag]
+	if !present {
+		return fmt.Errorf("no backup found for tag %s", tag)
+	}
+
+	backup.Running = false
+	client.Backups[tag] = backup
+	return nil
+}
+
+// AbortBackup aborts a backup.
+func (client *AdminClient) AbortBackup(url string, tag string) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	backup, present := client.Backups[tag]
+	if !present || !backup.Running {
+		return nil
+	}
+
+	if backup.URL != url {
+		return fmt.Errorf("backup for tag %s writes to %s instead of %s", tag, backup.URL, url)
+	}
+
+	client.BackupAborts++
+	backup.Running = false
+	client.Backups[tag] = backup
+	return nil
+}
+
+// ExpireBackup expires the backup data.
+func (client *AdminClient) ExpireBackup(url string, expireBefore time.Time, restorableAfter time.Time, blobCredentialsPath string) (string, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return "", client.mockError
+	}
+
+	blobCredentials, err := readBlobCredentials(blobCredentialsPath)
+	if err != nil {
+		return "", err
+	}
+
+	for _, backup := range client.Backups {
 		if backup.URL == url {
-			backup.Running = false
-			client.Backups[tag] = backup
-			return nil
+			client.BackupExpirations++
+			client.LastBackupExpireBefore = expireBefore
+			client.LastBackupRestorableAfter = restorableAfter
+			client.BlobCredentialsPath = blobCredentialsPath
+			client.BlobCredentials = blobCredentials
+			return fmt.Sprintf("All data before %s is deleted.", expireBefore.UTC().Format(time.RFC3339)), nil
 		}
 	}
-	return fmt.Errorf("no backup found for URL %s", url)
+
+	return "", fmt.Errorf("no backup found for URL %s", url)
 }
 
-// GetBackupStatus gets the status of the current backup.
+// DescribeBackup describes the data of the backup. If no BackupDescription is set, a restorable backup without any
+// snapshots will be described.
+func (client *AdminClient) DescribeBackup(url string, blobCredentialsPath string) (*fdbv1beta2.FoundationDBBackupDescription, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return nil, client.mockError
+	}
+
+	client.BackupDescriptions++
+	// DescribeBackupError can be used to simulate issues with the blobstore, e.g. an unreachable blobstore.
+	if client.DescribeBackupError != nil {
+		return nil, client.DescribeBackupError
+	}
+
+	blobCredentials, err := readBlobCredentials(blobCredentialsPath)
+	if err != nil {
+		return nil, err
+	}
+	client.BlobCredentials = blobCredentials
+
+	if client.BackupDescription != nil {
+		return client.BackupDescription.DeepCopy(), nil
+	}
+
+	return &fdbv1beta2.FoundationDBBackupDescription{
+		URL:        url,
+		Restorable: true,
+	}, nil
+}
+
+// GetBackupStatus gets the status of the backup with the default tag.
 func (client *AdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
+	return client.GetBackupStatusWithTag(fdbv1beta2.DefaultBackupTag)
+}
+
+// GetBackupStatusWithTag gets the status of the backup with the provided tag.
+func (client *AdminClient) GetBackupStatusWithTag(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -817,20 +1017,40 @@ func (client *AdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackup
 
 	status := &fdbv1beta2.FoundationDBLiveBackupStatus{}
 
-	tag := "default"
 	backup, present := client.Backups[tag]
 	if present {
+		status.Tag = tag
 		status.DestinationURL = backup.URL
 		status.Status.Running = backup.Running
 		status.BackupAgentsPaused = backup.Paused
 		status.SnapshotIntervalSeconds = backup.SnapshotPeriodSeconds
+		status.LatestRestorablePoint = client.LatestRestorablePoint.DeepCopy()
 	}
 
 	return status, nil
 }
 
 // StartRestore starts a new restore.
-func (client *AdminClient) StartRestore(url string, _ []fdbv1beta2.FoundationDBKeyRange) error {
+func (client *AdminClient) StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, version int64) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	if client.StartRestoreError != nil {
+		return client.StartRestoreError
+	}
+
+	client.restoreURL = url
+	client.RestoreKeyRanges = keyRanges
+	client.RestoreVersion = version
+	return nil
+}
+
+// StartRestoreAtTimestamp starts a new restore at the provided timestamp.
+func (client *AdminClient) StartRestoreAtTimestamp(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, timestamp time.Time, sourceCluster *fdbv1beta2.FoundationDBCluster) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
@@ -838,7 +1058,15 @@ func (client *AdminClient) StartRestore(url string, _ []fdbv1beta2.FoundationDBK
 		return client.mockError
 	}
 
+	// StartRestoreError can be used to simulate issues with the restore, e.g. a timestamp that cannot be resolved.
+	if client.StartRestoreError != nil {
+		return client.StartRestoreError
+	}
+
 	client.restoreURL = url
+	client.RestoreKeyRanges = keyRanges
+	client.RestoreTimestamp = &timestamp
+	client.RestoreSourceCluster = sourceCluster.Name
 	return nil
 }
 
@@ -854,6 +1082,104 @@ func (client *AdminClient) GetRestoreStatus() (string, error) {
 	return fmt.Sprintf("%s\n", client.restoreURL), nil
 }
 
+// GetLiveRestoreStatus gets the parsed status of the current restore. Every call returns the next status of
+// RestoreProgress until the last status is reached, to simulate the progress of a restore. If no RestoreProgress is
+// defined, a started restore will be reported as running.
+func (client *AdminClient) GetLiveRestoreStatus() (*fdbv1beta2.FoundationDBLiveRestoreStatus, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return nil, client.mockError
+	}
+
+	if client.restoreURL == "" {
+		return &fdbv1beta2.FoundationDBLiveRestoreStatus{}, nil
+	}
+
+	status := fdbv1beta2.FoundationDBLiveRestoreStatus{State: "running"}
+	if len(client.RestoreProgress) > 0 {
+		status = client.RestoreProgress[0]
+		if len(client.RestoreProgress) > 1 {
+			client.RestoreProgress = client.RestoreProgress[1:]
+		}
+	}
+
+	if status.Tag == "" {
+		status.Tag = fdbv1beta2.DefaultBackupTag
+	}
+
+	if status.URL == "" {
+		status.URL = client.restoreURL
+	}
+
+	if status.Version == 0 {
+		status.Version = client.RestoreVersion
+	}
+
+	return &status, nil
+}
+
+// AbortRestore aborts the restore with the provided tag. After the restore was aborted, GetLiveRestoreStatus will report
+// the restore as aborted.
+func (client *AdminClient) AbortRestore(tag string) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return client.mockError
+	}
+
+	if client.restoreURL == "" {
+		return nil
+	}
+
+	status := fdbv1beta2.FoundationDBLiveRestoreStatus{State: "running"}
+	if len(client.RestoreProgress) > 0 {
+		status = client.RestoreProgress[0]
+	}
+
+	if !status.GetState().IsInProgress() {
+		return nil
+	}
+
+	client.RestoreAborts++
+	status.State = "aborted"
+	status.Tag = tag
+	client.RestoreProgress = []fdbv1beta2.FoundationDBLiveRestoreStatus{status}
+	return nil
+}
+
+// IsKeyspaceEmpty checks if any of the raw keys defined in DatabaseKeys is part of the provided key ranges. If no key
+// ranges are provided, all keys outside of the system keyspace will be checked.
+func (client *AdminClient) IsKeyspaceEmpty(keyRanges []fdbv1beta2.FoundationDBKeyRange) (bool, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.mockError != nil {
+		return false, client.mockError
+	}
+
+	for _, key := range client.DatabaseKeys {
+		if len(keyRanges) == 0 && !strings.HasPrefix(key, "\xff") {
+			return false, nil
+		}
+
+		for _, keyRange := range keyRanges {
+			start, end, err := keyRange.GetRawKeys()
+			if err != nil {
+				return false, err
+			}
+
+			if bytes.Compare([]byte(key), start) >= 0 && bytes.Compare([]byte(key), end) < 0 {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
 // MockClientVersion returns a mocked client version
 func (client *AdminClient) MockClientVersion(version string, clients []string) {
 	adminClientMutex.Lock()
@@ -889,6 +1215,20 @@ func (client *AdminClient) MockLocalityInfo(processGroupID fdbv1beta2.ProcessGro
 	client.localityInfo[processGroupID] = locality
 }
 
+// MockInclusionError updates the mock to return the provided error when the address is included. If err is nil, the
+// mocked error will be removed.
+func (client *AdminClient) MockInclusionError(address string, err error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if err == nil {
+		delete(client.inclusionErrors, address)
+		return
+	}
+
+	client.inclusionErrors[address] = err
+}
+
 // MockIncorrectCommandLine updates the mock for whether a process group should
 // be have an incorrect command-line.
 func (client *AdminClient) MockIncorrectCommandLine(processGroupID fdbv1beta2.ProcessGroupID, incorrect bool) {
@@ -1006,6 +1346,32 @@ func (client *AdminClient) MockUptimeSecondsForMaintenanceZone(seconds float64)
 // a nil value to this method.
 func (client *AdminClient) MockError(err error) {
 	client.mockError = err
+	client.mockErrorIsRecoverable = false
+}
+
+// MockRecoverableError mocks an error that will be returned when making any calls to the mock client until the native
+// connection for the cluster is recreated.
+func (client *AdminClient) MockRecoverableError(err error) {
+	client.mockError = err
+	client.mockErrorIsRecoverable = true
+}
+
+// recreateNativeConnection records that the native connection of the cached mock admin client for the cluster was
+// recreated and clears the mocked error if it is recoverable.
+func recreateNativeConnection(cluster *fdbv1beta2.FoundationDBCluster) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	client := adminClientCache[cluster.Name]
+	if client == nil {
+		return
+	}
+
+	client.NativeConnectionRecreations++
+	if client.mockErrorIsRecoverable {
+		client.mockError = nil
+		client.mockErrorIsRecoverable = false
+	}
 }
 
 // SetLimitingDurabilityLag sets/mocks the limiting durability lag of any storage server in the cluster.
@@ -1064,7 +1430,19 @@ func (client *AdminClient) GetWorstDurabilityLag() (fdbv1beta2.FoundationDBStatu
 func (client *AdminClient) WithValues(_ ...interface{}) {}
 
 // SetTimeout will overwrite the default timeout for interacting the FDB cluster.
-func (client *AdminClient) SetTimeout(_ time.Duration) {}
+func (client *AdminClient) SetTimeout(timeout time.Duration) {
+	client.timeout = timeout
+}
+
+// GetTimeout returns the timeout for interacting with the FDB cluster. If no timeout was set, 40 seconds will be
+// returned, which is the default of the real admin client.
+func (client *AdminClient) GetTimeout() time.Duration {
+	if client.timeout == 0 {
+		return 40 * time.Second
+	}
+
+	return client.timeout
+}
 
 // GetProcessesUnderMaintenance will return all process groups that are currently stored to be under maintenance.
 // The result is a map with the process group ID as key and the start of the maintenance as value.
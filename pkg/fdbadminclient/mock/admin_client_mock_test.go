@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	corev1 "k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -311,6 +312,38 @@ var _ = Describe("mock_client", func() {
 		})
 	})
 
+	When("including processes with results", func() {
+		var adminClient *AdminClient
+		var results []fdbadminclient.InclusionResult
+		var err error
+
+		BeforeEach(func() {
+			cluster := internal.CreateDefaultCluster()
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			adminClient, err = NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			adminClient.ExcludedAddresses["1.1.1.1"] = fdbv1beta2.None{}
+			adminClient.ExcludedAddresses["1.1.1.2"] = fdbv1beta2.None{}
+			adminClient.MockInclusionError("1.1.1.2", fmt.Errorf("mocked"))
+
+			results, err = adminClient.IncludeProcessesWithResults([]fdbv1beta2.ProcessAddress{
+				{StringAddress: "1.1.1.1"},
+				{StringAddress: "1.1.1.2"},
+			})
+		})
+
+		It("should report the result for every address", func() {
+			Expect(err).To(MatchError(ContainSubstring("1.1.1.2: mocked")))
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Err).NotTo(HaveOccurred())
+			Expect(results[1].Err).To(HaveOccurred())
+			Expect(adminClient.ExcludedAddresses).To(HaveLen(1))
+			Expect(adminClient.ExcludedAddresses).To(HaveKey("1.1.1.2"))
+		})
+	})
+
 	When("getting the status", func() {
 		var adminClient *AdminClient
 		var status *fdbv1beta2.FoundationDBStatus
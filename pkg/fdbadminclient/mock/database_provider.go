@@ -39,3 +39,9 @@ func (p DatabaseClientProvider) GetLockClient(cluster *fdbv1beta2.FoundationDBCl
 func (p DatabaseClientProvider) GetAdminClient(cluster *fdbv1beta2.FoundationDBCluster, kubernetesClient client.Client) (fdbadminclient.AdminClient, error) {
 	return NewMockAdminClient(cluster, kubernetesClient)
 }
+
+// RecreateNativeConnection records that the native connection was recreated and clears any recoverable mocked error.
+func (p DatabaseClientProvider) RecreateNativeConnection(cluster *fdbv1beta2.FoundationDBCluster) error {
+	recreateNativeConnection(cluster)
+	return nil
+}
@@ -274,6 +274,60 @@ func GetCoordinatorsFromStatus(status *fdbv1beta2.FoundationDBStatus) map[string
 	return coordinators
 }
 
+// GetProcessGroupsWithActiveRoles gets the process groups that have at least one process that still serves a role,
+// based on the provided status. A process that is reported as excluded can still appear with roles for a short time
+// until the exclusion is fully done. The returning set will contain all processes by their process group ID.
+func GetProcessGroupsWithActiveRoles(status *fdbv1beta2.FoundationDBStatus) map[string]fdbv1beta2.None {
+	activeProcessGroups := make(map[string]fdbv1beta2.None)
+
+	for _, pInfo := range status.Cluster.Processes {
+		if len(pInfo.Roles) == 0 {
+			continue
+		}
+
+		processGroupID, ok := pInfo.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]
+		if !ok {
+			continue
+		}
+
+		activeProcessGroups[processGroupID] = fdbv1beta2.None{}
+	}
+
+	return activeProcessGroups
+}
+
+// GetRemainingBytesForExcludedProcessGroups returns the bytes that are still stored on the excluded processes, grouped
+// by the process group ID. Only roles of stateful processes are considered. The excluded flag is reported by FoundationDB
+// for locality based and IP based exclusions, so this works for both exclusion modes. Process groups that have excluded
+// processes without any data left will be reported with 0 remaining bytes.
+func GetRemainingBytesForExcludedProcessGroups(status *fdbv1beta2.FoundationDBStatus) map[fdbv1beta2.ProcessGroupID]int {
+	remainingBytes := make(map[fdbv1beta2.ProcessGroupID]int)
+
+	for _, pInfo := range status.Cluster.Processes {
+		if !pInfo.Excluded {
+			continue
+		}
+
+		processGroupID, ok := pInfo.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]
+		if !ok {
+			continue
+		}
+
+		storedBytes := remainingBytes[fdbv1beta2.ProcessGroupID(processGroupID)]
+		for _, role := range pInfo.Roles {
+			if !fdbv1beta2.ProcessClass(role.Role).IsStateful() {
+				continue
+			}
+
+			storedBytes += role.StoredBytes
+		}
+
+		remainingBytes[fdbv1beta2.ProcessGroupID(processGroupID)] = storedBytes
+	}
+
+	return remainingBytes
+}
+
 // GetMinimumUptimeAndAddressMap returns address map of the processes included the the foundationdb status. The minimum
 // uptime will be either secondsSinceLastRecovered if the recovery state is supported and enabled otherwise we will
 // take the minimum uptime of all processes.
@@ -551,9 +605,40 @@ func recoveryStateAllowsInclusion(status *fdbv1beta2.FoundationDBStatus) bool {
 }
 
 // CanSafelyExcludeProcessesWithRecoveryState currently performs the DefaultSafetyChecks and makes sure that the last recovery was at least `minRecoverySeconds` seconds ago.
-// In the future this check might be extended to perform more specific checks.
-func CanSafelyExcludeProcessesWithRecoveryState(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, minRecoverySeconds float64) error {
-	return canSafelyExcludeOrIncludeProcesses(cluster, status, false, minRecoverySeconds)
+// If a zone is under maintenance, the exclusion of storage process groups outside of the maintenance zone will be rejected.
+// The processGroups should contain the process groups that will be excluded.
+func CanSafelyExcludeProcessesWithRecoveryState(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, minRecoverySeconds float64, processGroups []*fdbv1beta2.ProcessGroupStatus) error {
+	err := canSafelyExcludeOrIncludeProcesses(cluster, status, false, minRecoverySeconds)
+	if err != nil {
+		return err
+	}
+
+	return exclusionsAllowedDuringMaintenance(status, processGroups)
+}
+
+// exclusionsAllowedDuringMaintenance returns an error if storage process groups outside of the current maintenance zone
+// should be excluded. Excluding those processes while a zone is taken down for maintenance could bring the cluster below
+// the desired replication.
+func exclusionsAllowedDuringMaintenance(status *fdbv1beta2.FoundationDBStatus, processGroups []*fdbv1beta2.ProcessGroupStatus) error {
+	maintenanceZone := status.Cluster.MaintenanceZone
+	if maintenanceZone == "" {
+		return nil
+	}
+
+	blockedProcessGroups := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage || processGroup.FaultDomain == maintenanceZone {
+			continue
+		}
+
+		blockedProcessGroups = append(blockedProcessGroups, processGroup.ProcessGroupID)
+	}
+
+	if len(blockedProcessGroups) > 0 {
+		return fmt.Errorf("cannot: exclude processes, zone %s is under maintenance and the storage process groups %v are in a different zone", maintenanceZone, blockedProcessGroups)
+	}
+
+	return nil
 }
 
 // CanSafelyIncludeProcesses currently performs the DefaultSafetyChecks and makes sure that the last recovery was at least `minRecoverySeconds` seconds ago.
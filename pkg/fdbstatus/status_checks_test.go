@@ -801,6 +801,136 @@ var _ = Describe("status_checks", func() {
 		)
 	})
 
+	DescribeTable("parsing the status for process groups with active roles",
+		func(status *fdbv1beta2.FoundationDBStatus, expected map[string]fdbv1beta2.None) {
+			Expect(GetProcessGroupsWithActiveRoles(status)).To(Equal(expected))
+		},
+		Entry("no processes",
+			&fdbv1beta2.FoundationDBStatus{},
+			map[string]fdbv1beta2.None{},
+		),
+		Entry("an excluded process that still serves a role",
+			&fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"foo": {
+							Excluded: true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "foo",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role: string(fdbv1beta2.ProcessRoleStorage),
+								},
+							},
+						},
+						"bar": {
+							Excluded: true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "bar",
+							},
+						},
+					},
+				},
+			},
+			map[string]fdbv1beta2.None{
+				"foo": {},
+			},
+		),
+		Entry("a process with a role but without localities",
+			&fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"foo": {
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role: string(fdbv1beta2.ProcessRoleStorage),
+								},
+							},
+						},
+					},
+				},
+			},
+			map[string]fdbv1beta2.None{},
+		),
+	)
+
+	DescribeTable("parsing the status for the remaining bytes of excluded process groups",
+		func(status *fdbv1beta2.FoundationDBStatus, expected map[fdbv1beta2.ProcessGroupID]int) {
+			Expect(GetRemainingBytesForExcludedProcessGroups(status)).To(Equal(expected))
+		},
+		Entry("no processes",
+			&fdbv1beta2.FoundationDBStatus{},
+			map[fdbv1beta2.ProcessGroupID]int{},
+		),
+		Entry("excluded processes with and without data",
+			&fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"storage-1-1": {
+							Excluded: true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleStorage),
+									StoredBytes: 1024,
+								},
+							},
+						},
+						"storage-1-2": {
+							Excluded: true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleStorage),
+									StoredBytes: 512,
+								},
+							},
+						},
+						"storage-2": {
+							Excluded: true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-2",
+							},
+						},
+						"stateless-1": {
+							Excluded: true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "stateless-1",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleResolver),
+									StoredBytes: 100,
+								},
+							},
+						},
+						"storage-3": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-3",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleStorage),
+									StoredBytes: 2048,
+								},
+							},
+						},
+					},
+				},
+			},
+			map[fdbv1beta2.ProcessGroupID]int{
+				"storage-1":   1536,
+				"storage-2":   0,
+				"stateless-1": 0,
+			},
+		),
+	)
+
 	DescribeTable("when getting the minimum uptime and the address map", func(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, useRecoveryState bool, expectedMinimumUptime float64, expectedAddressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress) {
 		minimumUptime, addressMap, err := GetMinimumUptimeAndAddressMap(logr.Discard(), cluster, status, useRecoveryState)
 		Expect(err).NotTo(HaveOccurred())
@@ -1815,7 +1945,7 @@ var _ = Describe("status_checks", func() {
 	When("performing the exclude safety check.", func() {
 		DescribeTable("should return if the safety check is satisfied or not",
 			func(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, expected error) {
-				err := CanSafelyExcludeProcessesWithRecoveryState(cluster, status, 120.0)
+				err := CanSafelyExcludeProcessesWithRecoveryState(cluster, status, 120.0, nil)
 				if expected == nil {
 					Expect(err).To(BeNil())
 				} else {
@@ -1948,6 +2078,66 @@ var _ = Describe("status_checks", func() {
 		)
 	})
 
+	When("performing the exclude safety check while a zone is under maintenance", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var status *fdbv1beta2.FoundationDBStatus
+
+		BeforeEach(func() {
+			cluster = &fdbv1beta2.FoundationDBCluster{
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					RunningVersion: "7.1.57",
+				},
+			}
+
+			status = &fdbv1beta2.FoundationDBStatus{
+				Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+					DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+						Available: true,
+					},
+				},
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					RecoveryState: fdbv1beta2.RecoveryState{
+						ActiveGenerations:         1,
+						SecondsSinceLastRecovered: 120.0,
+					},
+				},
+			}
+		})
+
+		DescribeTable("should return if the safety check is satisfied or not",
+			func(maintenanceZone fdbv1beta2.FaultDomain, processGroup *fdbv1beta2.ProcessGroupStatus, expected error) {
+				status.Cluster.MaintenanceZone = maintenanceZone
+				err := CanSafelyExcludeProcessesWithRecoveryState(cluster, status, 120.0, []*fdbv1beta2.ProcessGroupStatus{processGroup})
+				if expected == nil {
+					Expect(err).To(BeNil())
+				} else {
+					Expect(err).To(HaveOccurred())
+					Expect(err).To(Equal(expected))
+				}
+			},
+			Entry("no zone is under maintenance",
+				fdbv1beta2.FaultDomain(""),
+				&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage, FaultDomain: "zone-a"},
+				nil,
+			),
+			Entry("a storage process group in another zone should be excluded",
+				fdbv1beta2.FaultDomain("zone-b"),
+				&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage, FaultDomain: "zone-a"},
+				fmt.Errorf("cannot: exclude processes, zone zone-b is under maintenance and the storage process groups [storage-1] are in a different zone"),
+			),
+			Entry("a storage process group in the maintenance zone should be excluded",
+				fdbv1beta2.FaultDomain("zone-a"),
+				&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage, FaultDomain: "zone-a"},
+				nil,
+			),
+			Entry("a log process group in another zone should be excluded",
+				fdbv1beta2.FaultDomain("zone-b"),
+				&fdbv1beta2.ProcessGroupStatus{ProcessGroupID: "log-1", ProcessClass: fdbv1beta2.ProcessClassLog, FaultDomain: "zone-a"},
+				nil,
+			),
+		)
+	})
+
 	When("performing the include safety check.", func() {
 		DescribeTable("should return if the safety check is satisfied or not",
 			func(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, expected error) {
@@ -0,0 +1,74 @@
+/*
+ * pod_index.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podmanager
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ProcessGroupIDIndexField defines the field that is used to index the Pods by the value of the default process group
+// ID label.
+const ProcessGroupIDIndexField = "metadata.labels.processGroupID"
+
+// GetProcessGroupIDIndexValues returns the values of the ProcessGroupIDIndexField for the provided Pod.
+func GetProcessGroupIDIndexValues(object client.Object) []string {
+	processGroupID, ok := object.GetLabels()[fdbv1beta2.FDBProcessGroupIDLabel]
+	if !ok {
+		return nil
+	}
+
+	return []string{processGroupID}
+}
+
+// GetPodForProcessGroup returns the Pod of the provided process group through the provided PodLifecycleManager. If the
+// cluster uses the default process group ID label, the ProcessGroupIDIndexField will be used to only list the Pods of
+// the process group instead of listing and filtering all Pods of the cluster. The ProcessGroupIDIndexField must be
+// registered for the provided client. Otherwise the Pod will be fetched by its name.
+func GetPodForProcessGroup(ctx context.Context, manager PodLifecycleManager, r client.Client, cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) (*corev1.Pod, error) {
+	podName := processGroup.GetPodName(cluster)
+	if cluster.GetProcessGroupIDLabel() != fdbv1beta2.FDBProcessGroupIDLabel {
+		return manager.GetPod(ctx, r, cluster, podName)
+	}
+
+	pods, err := manager.GetPods(ctx, r, cluster,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(cluster.GetMatchLabels()),
+		client.MatchingFields{ProcessGroupIDIndexField: string(processGroup.ProcessGroupID)})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pods) == 0 {
+		return nil, k8serrors.NewNotFound(corev1.Resource("pods"), podName)
+	}
+
+	if len(pods) > 1 {
+		return nil, fmt.Errorf("found %d Pods for process group %s in cluster %s/%s", len(pods), processGroup.ProcessGroupID, cluster.Namespace, cluster.Name)
+	}
+
+	return pods[0], nil
+}
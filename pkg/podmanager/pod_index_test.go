@@ -0,0 +1,132 @@
+/*
+ * pod_index_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podmanager
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	mockclient "github.com/FoundationDB/fdb-kubernetes-operator/mock-kubernetes-client/client"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("pod_index", func() {
+	var k8sClient *mockclient.MockClient
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var processGroups []*fdbv1beta2.ProcessGroupStatus
+	var manager StandardPodLifecycleManager
+
+	createPod := func(cluster *fdbv1beta2.FoundationDBCluster, processGroup *fdbv1beta2.ProcessGroupStatus) {
+		Expect(k8sClient.Create(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      processGroup.GetPodName(cluster),
+				Namespace: cluster.Namespace,
+				Labels:    internal.GetPodLabels(cluster, processGroup.ProcessClass, string(processGroup.ProcessGroupID)),
+			},
+		})).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		k8sClient = mockclient.NewMockClientWithHooksAndIndexes(scheme.Scheme, nil, nil, true)
+		cluster = internal.CreateDefaultCluster()
+		processGroups = make([]*fdbv1beta2.ProcessGroupStatus, 0, 500)
+
+		for i := 1; i <= 500; i++ {
+			processGroup := fdbv1beta2.NewProcessGroupStatus(fdbv1beta2.ProcessGroupID(fmt.Sprintf("storage-%d", i)), fdbv1beta2.ProcessClassStorage, nil)
+			processGroups = append(processGroups, processGroup)
+			createPod(cluster, processGroup)
+		}
+	})
+
+	When("getting the Pod for a process group", func() {
+		It("should only list the Pod of the process group", func() {
+			allPods := &corev1.PodList{}
+			Expect(k8sClient.List(context.TODO(), allPods, client.InNamespace(cluster.Namespace), client.MatchingLabels(cluster.GetMatchLabels()))).NotTo(HaveOccurred())
+			Expect(allPods.Items).To(HaveLen(500))
+
+			indexedPods := &corev1.PodList{}
+			Expect(k8sClient.List(context.TODO(), indexedPods, client.InNamespace(cluster.Namespace), client.MatchingFields{ProcessGroupIDIndexField: "storage-42"})).NotTo(HaveOccurred())
+			Expect(indexedPods.Items).To(HaveLen(1))
+
+			for _, processGroup := range processGroups {
+				pod, err := GetPodForProcessGroup(context.TODO(), manager, k8sClient, cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Name).To(Equal(processGroup.GetPodName(cluster)))
+			}
+		})
+
+		It("should ignore Pods of other clusters with the same process group ID", func() {
+			otherCluster := internal.CreateDefaultCluster()
+			otherCluster.Name = "operator-test-2"
+			createPod(otherCluster, processGroups[0])
+
+			pod, err := GetPodForProcessGroup(context.TODO(), manager, k8sClient, cluster, processGroups[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pod.Name).To(Equal(processGroups[0].GetPodName(cluster)))
+		})
+
+		It("should find the Pod by the process group ID if the Pod name doesn't match", func() {
+			processGroup := fdbv1beta2.NewProcessGroupStatus("storage-1337", fdbv1beta2.ProcessClassStorage, nil)
+			Expect(k8sClient.Create(context.TODO(), &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "renamed-pod",
+					Namespace: cluster.Namespace,
+					Labels:    internal.GetPodLabels(cluster, processGroup.ProcessClass, string(processGroup.ProcessGroupID)),
+				},
+			})).NotTo(HaveOccurred())
+
+			pod, err := GetPodForProcessGroup(context.TODO(), manager, k8sClient, cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pod.Name).To(Equal("renamed-pod"))
+		})
+
+		It("should return a not found error if the Pod is missing", func() {
+			processGroup := fdbv1beta2.NewProcessGroupStatus("storage-1337", fdbv1beta2.ProcessClassStorage, nil)
+			_, err := GetPodForProcessGroup(context.TODO(), manager, k8sClient, cluster, processGroup)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		When("the cluster uses a custom process group ID label", func() {
+			BeforeEach(func() {
+				cluster.Spec.LabelConfig.ProcessGroupIDLabels = []string{"custom-id"}
+				processGroup := fdbv1beta2.NewProcessGroupStatus("storage-1337", fdbv1beta2.ProcessClassStorage, nil)
+				processGroups = append(processGroups, processGroup)
+				createPod(cluster, processGroup)
+			})
+
+			It("should get the Pod by its name", func() {
+				processGroup := processGroups[len(processGroups)-1]
+				pod, err := GetPodForProcessGroup(context.TODO(), manager, k8sClient, cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Name).To(Equal(processGroup.GetPodName(cluster)))
+				Expect(pod.Labels).NotTo(HaveKey(fdbv1beta2.FDBProcessGroupIDLabel))
+			})
+		})
+	})
+})
@@ -27,6 +27,7 @@ import (
 	"io/fs"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"log"
 	"os"
@@ -45,7 +46,9 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -65,13 +68,17 @@ type Options struct {
 	EnableRecoveryState                bool
 	CacheDatabaseStatus                bool
 	EnableNodeIndex                    bool
+	EnableTLSSecretWatch               bool
+	TerminateOnNativeConnectionFailure bool
 	MetricsAddr                        string
 	LeaderElectionID                   string
 	LogFile                            string
 	LogFilePermission                  string
 	LabelSelector                      string
 	ClusterLabelKeyForNodeTrigger      string
+	NodeTriggerTaintKeys               string
 	WatchNamespace                     string
+	BounceBlockingConditions           string
 	CliTimeout                         int
 	MaxCliTimeout                      int
 	MaxConcurrentReconciles            int
@@ -85,6 +92,8 @@ type Options struct {
 	PostTimeout                        time.Duration
 	MaintenanceListStaleDuration       time.Duration
 	MaintenanceListWaitDuration        time.Duration
+	NodeTriggerMinimumTaintDuration    time.Duration
+	NodeTriggerTaintTimeAddedTolerance time.Duration
 	// LeaseDuration is the duration that non-leader candidates will
 	// wait to force acquire leadership. This is measured against time of
 	// last observed ack. Default is 15 seconds.
@@ -97,6 +106,21 @@ type Options struct {
 	RetryPeriod                   time.Duration
 	DeprecationOptions            internal.DeprecationOptions
 	MinimumRequiredUptimeCCBounce time.Duration
+	// ReconciliationTimeout defines the maximum duration of a single reconciliation run for a cluster.
+	ReconciliationTimeout time.Duration
+	// DegradedClusterRequeueDelayDivisor defines the divisor for the requeue delays of clusters with a degraded fault
+	// tolerance.
+	DegradedClusterRequeueDelayDivisor int
+	// DegradedClusterMaximumRequeueDelay defines the maximum requeue delay for clusters with a degraded fault tolerance.
+	DegradedClusterMaximumRequeueDelay time.Duration
+	// DisableCheckClientCompatibility defines if the checkClientCompatibility sub-reconciler should be disabled.
+	DisableCheckClientCompatibility bool
+	// DisableDeletePodsForBuggification defines if the deletePodsForBuggification sub-reconciler should be disabled.
+	DisableDeletePodsForBuggification bool
+	// DisableUpdatePodDisruptionBudgets defines if the updatePodDisruptionBudgets sub-reconciler should be disabled.
+	DisableUpdatePodDisruptionBudgets bool
+	// DisableMaintenanceModeChecker defines if the maintenanceModeChecker sub-reconciler should be disabled.
+	DisableMaintenanceModeChecker bool
 }
 
 // BindFlags will parse the given flagset for the operator option flags
@@ -120,12 +144,16 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 	fs.StringVar(&o.LogFilePermission, "log-file-permission", "0644",
 		"The file permission for the log file. Only used if log-file is set. Only the octal representation is supported.")
 	fs.StringVar(&o.ClusterLabelKeyForNodeTrigger, "cluster-label-key-for-node-trigger", "",
-		"The label key to use to trigger a reconciliation if a node resources changes.")
+		"The label key to use to trigger a reconciliation if a node resources changes. Multiple label keys can be provided as a comma separated list, the first label key that is present on a Pod will be used to get the cluster name.")
+	fs.StringVar(&o.NodeTriggerTaintKeys, "node-trigger-taint-keys", "",
+		"Defines a comma separated list of taint keys that trigger a reconciliation if a node taint changes, the wildcard \"*\" matches all taint keys. If not set all taint changes will trigger a reconciliation. The taintReplacementOptions of the clusters override the settings for the same taint key.")
+	fs.DurationVar(&o.NodeTriggerMinimumTaintDuration, "node-trigger-minimum-taint-duration", 0, "Defines the minimum duration a taint with a key from \"--node-trigger-taint-keys\" must be present before the taint triggers a reconciliation.")
+	fs.DurationVar(&o.NodeTriggerTaintTimeAddedTolerance, "node-trigger-taint-time-added-tolerance", 0, "Defines the tolerance in which a change of only the timeAdded of a node taint will not trigger a reconciliation.")
 	fs.IntVar(&o.MaxNumberOfOldLogFiles, "max-old-log-files", 3, "Defines the maximum number of old operator log files to retain.")
 	fs.BoolVar(&o.CompressOldFiles, "compress", false, "Defines whether the rotated log files should be compressed using gzip or not.")
 	fs.BoolVar(&o.PrintVersion, "version", false, "Prints the version of the operator and exits.")
 	fs.StringVar(&o.LabelSelector, "label-selector", "", "Defines a label-selector that will be used to select resources.")
-	fs.StringVar(&o.WatchNamespace, "watch-namespace", os.Getenv("WATCH_NAMESPACE"), "Defines which namespace the operator should watch.")
+	fs.StringVar(&o.WatchNamespace, "watch-namespace", os.Getenv("WATCH_NAMESPACE"), "Defines which namespace the operator should watch. Multiple namespaces can be provided as a comma separated list.")
 	fs.DurationVar(&o.GetTimeout, "get-timeout", 5*time.Second, "http timeout for get requests to the FDB sidecar.")
 	fs.DurationVar(&o.PostTimeout, "post-timeout", 10*time.Second, "http timeout for post requests to the FDB sidecar.")
 	fs.DurationVar(&o.LeaseDuration, "leader-election-lease-duration", 15*time.Second, "the duration that non-leader candidates will wait to force acquire leadership.")
@@ -133,16 +161,60 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 	fs.DurationVar(&o.RetryPeriod, "leader-election-retry-period", 2*time.Second, "the duration the LeaderElector clients should wait between tries of action.")
 	fs.DurationVar(&o.MaintenanceListStaleDuration, "maintenance-list-stale-duration", 4*time.Hour, "the duration after stale entries will be deleted form the maintenance list. Only has an affect if the operator is allowed to reset the maintenance zone.")
 	fs.DurationVar(&o.MaintenanceListWaitDuration, "maintenance-list-wait-duration", 5*time.Minute, "the duration where a process in the maintenance list in a different zone will be assumed to block the maintenance zone reset. Only has an affect if the operator is allowed to reset the maintenance zone.")
+	fs.DurationVar(&o.ReconciliationTimeout, "reconciliation-timeout", 0, "the maximum duration of a single reconciliation run for a cluster, if exceeded the reconciliation will be stopped and requeued. Can be overwritten per cluster with the reconciliationTimeoutSeconds setting. A value of 0 disables the timeout.")
+	fs.IntVar(&o.DegradedClusterRequeueDelayDivisor, "degraded-cluster-requeue-delay-divisor", 1, "Defines the divisor for the requeue delays of clusters with a fault tolerance below the desired fault tolerance, so those clusters will be reconciled before healthy clusters. The fault tolerance is only checked if the database status is cached. A value of 1 disables the divisor.")
+	fs.DurationVar(&o.DegradedClusterMaximumRequeueDelay, "degraded-cluster-maximum-requeue-delay", 0, "Defines the maximum requeue delay for clusters with a fault tolerance below the desired fault tolerance. Requeues without a delay will use this delay instead of the exponential backoff. A value of 0 disables the maximum delay.")
+	fs.BoolVar(&o.DisableCheckClientCompatibility, "disable-check-client-compatibility", false, "Disables the checkClientCompatibility sub-reconciler for all clusters.")
+	fs.BoolVar(&o.DisableDeletePodsForBuggification, "disable-delete-pods-for-buggification", false, "Disables the deletePodsForBuggification sub-reconciler for all clusters.")
+	fs.BoolVar(&o.DisableUpdatePodDisruptionBudgets, "disable-update-pod-disruption-budgets", false, "Disables the updatePodDisruptionBudgets sub-reconciler for all clusters.")
+	fs.BoolVar(&o.DisableMaintenanceModeChecker, "disable-maintenance-mode-checker", false, "Disables the maintenanceModeChecker sub-reconciler for all clusters that don't let the operator reset the maintenance mode. Clusters with resetMaintenanceMode or UseMaintenanceModeChecker enabled will still run the maintenanceModeChecker.")
 	fs.DurationVar(&o.MinimumRequiredUptimeCCBounce, "minimum-required-uptime-for-cc-bounce", 1*time.Hour, "the minimum required uptime of the cluster before allowing the operator to restart the CC if there is a failed tester process.")
-	fs.BoolVar(&o.EnableRestartIncompatibleProcesses, "enable-restart-incompatible-processes", true, "This flag enables/disables in the operator to restart incompatible fdbserver processes.")
+	fs.BoolVar(&o.EnableRestartIncompatibleProcesses, "enable-restart-incompatible-processes", true, "This flag enables/disables in the operator to restart incompatible fdbserver processes. Can be overwritten per cluster with the restartIncompatibleProcesses setting.")
 	fs.BoolVar(&o.ServerSideApply, "server-side-apply", false, "This flag enables server side apply.")
 	fs.BoolVar(&o.EnableRecoveryState, "enable-recovery-state", true, "This flag enables the use of the recovery state for the minimum uptime between bounced if the FDB version supports it.")
 	fs.BoolVar(&o.CacheDatabaseStatus, "cache-database-status", true, "Defines the default value for caching the database status.")
 	fs.BoolVar(&o.EnableNodeIndex, "enable-node-index", false, "Deprecated, not used anymore. Defines if the operator should add an index for accessing node objects. This requires a ClusterRoleBinding with node access. If the taint feature should be used, this setting should be set to true.")
+	fs.BoolVar(&o.EnableTLSSecretWatch, "enable-tls-secret-watch", false, "Defines if the operator should watch the secrets referenced in the Pod templates and bounce the processes once those secrets are rotated. This will add all secrets to the operator cache.")
+	fs.BoolVar(&o.TerminateOnNativeConnectionFailure, "terminate-on-native-connection-failure", false, "Defines if the operator should terminate itself if the native connection to a cluster could not be recovered by recreating it. This will restart the connections to all clusters managed by the operator.")
 	fs.Float64Var(&o.MinimumRecoveryTimeForInclusion, "minimum-recovery-time-for-inclusion", 600.0, "Defines the minimum uptime of the cluster before inclusions are allowed. For clusters after 7.1 this will use the recovery state. This should reduce the risk of frequent recoveries because of inclusions.")
+	fs.StringVar(&o.BounceBlockingConditions, "bounce-blocking-conditions", "", "Defines a comma separated list of process group conditions that prevent a process group from being bounced. If not set the default conditions will be used. The default conditions will always be used for version incompatible upgrades.")
 	fs.Float64Var(&o.MinimumRecoveryTimeForExclusion, "minimum-recovery-time-for-exclusion", 120.0, "Defines the minimum uptime of the cluster before exclusions are allowed. For clusters after 7.1 this will use the recovery state. This should reduce the risk of frequent recoveries because of exclusions.")
 }
 
+// getNodeTriggerTaintReplacementOptions returns the taint replacement options for the provided taint keys with the
+// provided minimum taint duration.
+func getNodeTriggerTaintReplacementOptions(taintKeys []string, minimumTaintDuration time.Duration) []fdbv1beta2.TaintReplacementOption {
+	if len(taintKeys) == 0 {
+		return nil
+	}
+
+	options := make([]fdbv1beta2.TaintReplacementOption, 0, len(taintKeys))
+	for _, taintKey := range taintKeys {
+		options = append(options, fdbv1beta2.TaintReplacementOption{
+			Key:               pointer.String(taintKey),
+			DurationInSeconds: pointer.Int64(int64(minimumTaintDuration.Seconds())),
+		})
+	}
+
+	return options
+}
+
+// parseCommaSeparatedList returns the trimmed and non-empty entries of the comma separated list, e.g. the namespaces
+// that the operator should watch. If the list is empty, nil will be returned.
+func parseCommaSeparatedList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
 // StartManager will start the FoundationDB operator manager.
 // Each reconciler that is not nil will be added to the list of reconcilers
 // For all reconcilers the Client, Recorder and if appropriate the namespace will be set.
@@ -201,6 +273,7 @@ func StartManager(
 			&corev1.ConfigMap{}:               selector,
 			&corev1.Service{}:                 selector,
 			&appsv1.Deployment{}:              selector,
+			&policyv1.PodDisruptionBudget{}:   selector,
 		}
 
 		// Make sure we set the label selector for any additional watched objects.
@@ -221,10 +294,17 @@ func StartManager(
 		NewCache:           cache.BuilderWithOptions(cacheOptions),
 	}
 
-	if operatorOpts.WatchNamespace != "" {
-		options.Namespace = operatorOpts.WatchNamespace
+	watchNamespaces := parseCommaSeparatedList(operatorOpts.WatchNamespace)
+	if len(watchNamespaces) == 1 {
+		options.Namespace = watchNamespaces[0]
 		setupLog.Info("Operator starting in single namespace mode", "namespace", options.Namespace)
-		cacheOptions.Namespace = operatorOpts.WatchNamespace
+	} else if len(watchNamespaces) > 1 {
+		setupLog.Info("Operator starting in multi namespace mode", "namespaces", watchNamespaces)
+		// Only cache the resources of the watched namespaces.
+		options.NewCache = func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+			opts.SelectorsByObject = cacheOptions.SelectorsByObject
+			return cache.MultiNamespacedCacheBuilder(watchNamespaces)(config, opts)
+		}
 	} else {
 		setupLog.Info("Operator starting in Global mode")
 	}
@@ -259,6 +339,13 @@ func StartManager(
 		clusterReconciler.EnableRecoveryState = operatorOpts.EnableRecoveryState
 		clusterReconciler.CacheDatabaseStatusForReconciliationDefault = operatorOpts.CacheDatabaseStatus
 		clusterReconciler.MinimumRequiredUptimeCCBounce = operatorOpts.MinimumRequiredUptimeCCBounce
+		clusterReconciler.ReconciliationTimeout = operatorOpts.ReconciliationTimeout
+		clusterReconciler.DegradedClusterRequeueDelayDivisor = operatorOpts.DegradedClusterRequeueDelayDivisor
+		clusterReconciler.DegradedClusterMaximumRequeueDelay = operatorOpts.DegradedClusterMaximumRequeueDelay
+		clusterReconciler.DisableCheckClientCompatibility = operatorOpts.DisableCheckClientCompatibility
+		clusterReconciler.DisableDeletePodsForBuggification = operatorOpts.DisableDeletePodsForBuggification
+		clusterReconciler.DisableUpdatePodDisruptionBudgets = operatorOpts.DisableUpdatePodDisruptionBudgets
+		clusterReconciler.DisableMaintenanceModeChecker = operatorOpts.DisableMaintenanceModeChecker
 		clusterReconciler.MaintenanceListStaleDuration = operatorOpts.MaintenanceListStaleDuration
 		clusterReconciler.MaintenanceListWaitDuration = operatorOpts.MaintenanceListWaitDuration
 		clusterReconciler.MinimumRecoveryTimeForInclusion = operatorOpts.MinimumRecoveryTimeForInclusion
@@ -267,8 +354,21 @@ func StartManager(
 		clusterReconciler.MaintenanceListWaitDuration = operatorOpts.MaintenanceListWaitDuration
 		clusterReconciler.MinimumRecoveryTimeForInclusion = operatorOpts.MinimumRecoveryTimeForInclusion
 		clusterReconciler.MinimumRecoveryTimeForExclusion = operatorOpts.MinimumRecoveryTimeForExclusion
-		clusterReconciler.ClusterLabelKeyForNodeTrigger = strings.Trim(operatorOpts.ClusterLabelKeyForNodeTrigger, "\"")
-		clusterReconciler.Namespace = operatorOpts.WatchNamespace
+		clusterReconciler.ClusterLabelKeysForNodeTrigger = parseCommaSeparatedList(strings.Trim(operatorOpts.ClusterLabelKeyForNodeTrigger, "\""))
+		clusterReconciler.NodeTriggerTaintReplacementOptions = getNodeTriggerTaintReplacementOptions(parseCommaSeparatedList(strings.Trim(operatorOpts.NodeTriggerTaintKeys, "\"")), operatorOpts.NodeTriggerMinimumTaintDuration)
+		clusterReconciler.NodeTriggerTaintTimeAddedTolerance = operatorOpts.NodeTriggerTaintTimeAddedTolerance
+		clusterReconciler.Namespaces = watchNamespaces
+		clusterReconciler.EnableTLSSecretWatch = operatorOpts.EnableTLSSecretWatch
+		clusterReconciler.TerminateOnNativeConnectionFailure = operatorOpts.TerminateOnNativeConnectionFailure
+
+		if operatorOpts.BounceBlockingConditions != "" {
+			bounceBlockingConditions, err := parseProcessGroupConditions(strings.Trim(operatorOpts.BounceBlockingConditions, "\""))
+			if err != nil {
+				setupLog.Error(err, "unable to parse bounce blocking conditions")
+				os.Exit(1)
+			}
+			clusterReconciler.BounceBlockingConditions = bounceBlockingConditions
+		}
 
 		if err := clusterReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector, watchedObjects...); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBCluster")
@@ -291,6 +391,10 @@ func StartManager(
 			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBBackup")
 			os.Exit(1)
 		}
+
+		if operatorOpts.MetricsAddr != "0" {
+			controllers.InitCustomBackupMetrics(backupReconciler)
+		}
 	}
 
 	if restoreReconciler != nil {
@@ -451,3 +555,23 @@ func setupLogger(operatorOpts Options) (io.Writer, error) {
 
 	return os.Stdout, nil
 }
+
+// parseProcessGroupConditions parses a comma separated list of process group conditions.
+func parseProcessGroupConditions(input string) ([]fdbv1beta2.ProcessGroupConditionType, error) {
+	conditions := make([]fdbv1beta2.ProcessGroupConditionType, 0)
+	for _, rawCondition := range strings.Split(input, ",") {
+		rawCondition = strings.TrimSpace(rawCondition)
+		if rawCondition == "" {
+			continue
+		}
+
+		condition, err := fdbv1beta2.GetProcessGroupConditionType(rawCondition)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
@@ -24,9 +24,12 @@ import (
 	"io/fs"
 	"os"
 	"path"
+	"time"
 
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("setup", func() {
@@ -108,4 +111,37 @@ var _ = Describe("setup", func() {
 			})
 		})
 	})
+	DescribeTable("parsing the process group conditions", func(input string, expected []fdbv1beta2.ProcessGroupConditionType, expectErr bool) {
+		conditions, err := parseProcessGroupConditions(input)
+		if expectErr {
+			Expect(err).To(HaveOccurred())
+			return
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conditions).To(Equal(expected))
+	},
+		Entry("a single condition", "SidecarUnreachable", []fdbv1beta2.ProcessGroupConditionType{fdbv1beta2.SidecarUnreachable}, false),
+		Entry("multiple conditions with spaces", "SidecarUnreachable, IncorrectPodSpec", []fdbv1beta2.ProcessGroupConditionType{fdbv1beta2.SidecarUnreachable, fdbv1beta2.IncorrectPodSpec}, false),
+		Entry("an unknown condition", "SidecarUnreachable,Unknown", nil, true),
+	)
+
+	DescribeTable("getting the taint replacement options for the node trigger", func(taintKeys []string, minimumTaintDuration time.Duration, expected []fdbv1beta2.TaintReplacementOption) {
+		Expect(getNodeTriggerTaintReplacementOptions(taintKeys, minimumTaintDuration)).To(Equal(expected))
+	},
+		Entry("no taint keys", nil, time.Minute, nil),
+		Entry("multiple taint keys", []string{"*", "example.org/maintenance"}, 5*time.Minute, []fdbv1beta2.TaintReplacementOption{
+			{Key: pointer.String("*"), DurationInSeconds: pointer.Int64(300)},
+			{Key: pointer.String("example.org/maintenance"), DurationInSeconds: pointer.Int64(300)},
+		}),
+	)
+
+	DescribeTable("parsing a comma separated list", func(input string, expected []string) {
+		Expect(parseCommaSeparatedList(input)).To(Equal(expected))
+	},
+		Entry("no namespace", "", nil),
+		Entry("a single namespace", "default", []string{"default"}),
+		Entry("multiple namespaces with spaces", "team-a, team-b,team-c", []string{"team-a", "team-b", "team-c"}),
+		Entry("multiple namespaces with empty entries", "team-a,,team-b,", []string{"team-a", "team-b"}),
+	)
 })